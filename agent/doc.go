@@ -3,12 +3,13 @@
 
 // Package agent provides hierarchical agent implementations for building sophisticated AI agents.
 //
-// The agent package implements a hierarchical, event-driven agent architecture with four core agent types:
+// The agent package implements a hierarchical, event-driven agent architecture with these core agent types:
 //
 //   - LLMAgent: Full-featured agents powered by language models with tools, instructions, callbacks, planners, and code execution
 //   - SequentialAgent: Executes sub-agents one after another, supports live mode with taskCompleted() flow control
 //   - ParallelAgent: Runs sub-agents concurrently in isolated branches, merges event streams
 //   - LoopAgent: Repeatedly executes sub-agents until escalation or max iterations
+//   - SummarizerAgent: Condenses the prior agents' output into a single summary event between pipeline stages
 //
 // All agents embed types.BaseAgent for common functionality and use event streaming via
 // iter.Seq2[*Event, error] iterators for real-time processing. The rich InvocationContext
@@ -38,6 +39,10 @@
 //		// Process event
 //	}
 //
+// RunToCompletion drains the events, appending them to the session, and returns the final answer:
+//
+//	answer, events, err := agent.RunToCompletion(ctx, myAgent, invocationContext)
+//
 // # Agent Types
 //
 // LLMAgent provides comprehensive LLM integration with features like:
@@ -47,22 +52,54 @@
 //   - Before/after callbacks for customization
 //   - Planning and reasoning capabilities
 //   - Code execution support
+//   - Dry runs with WithDryRun, recording the tool calls instead of running them
+//   - Citations of the grounding sources in the events with WithCitations
+//   - The encoding and size limits of the tool results with WithFunctionResponseFormat
+//   - A window of the most recent turns sent to the model with WithMaxTurns
+//   - The large parts repeated in the history sent once with WithDedupe
+//   - A budget of the tool outputs sent to the model with WithMaxTotalToolOutputBytes
+//   - Instructions memoized within an invocation with WithInstructionMemoization
+//   - Live runs falling back to a streaming run, with a warning, when the model has no live
+//     connections; SupportsLive reports it beforehand
 //
 // SequentialAgent executes child agents in order:
 //   - Useful for multi-step workflows
 //   - Supports live mode for real-time interactions
 //   - Maintains conversation flow between agents
+//   - Stage filter to drop or rewrite the events flowing between stages
 //
 // ParallelAgent runs multiple agents concurrently:
 //   - Isolated execution branches
 //   - Event stream merging
 //   - Useful for multi-perspective analysis
+//   - Aggregator combining the branch results into a final event, such as a majority vote
+//   - Branch-scoped state under the "temp:branch:" prefix, private to each branch; the other
+//     "temp:" keys are shared by all the branches
+//   - Bounded concurrency with WithMaxConcurrentBranches, so that a large fan-out does not start
+//     all its model sessions at once
 //
 // LoopAgent provides iterative execution:
 //   - Configurable maximum iterations
 //   - Escalation-based termination
+//   - Stop condition evaluated on the accumulated state after each iteration
 //   - Useful for refinement workflows
 //
+// SummarizerAgent controls the token growth of pipelines:
+//   - Summarizes the last N events or the events since a marker
+//   - Configurable target length and focus
+//   - Appends the summary to the history or replaces the summarized events
+//
+// Placing a summarizer between pipeline stages:
+//
+//	pipeline := agent.NewSequentialAgent("pipeline").WithAgents(
+//		researcher,
+//		agent.NewSummarizerAgent("condense", model,
+//			agent.WithSummaryMaxWords(150),
+//			agent.WithReplaceHistory(true),
+//		),
+//		writer,
+//	)
+//
 // # Event-Driven Architecture
 //
 // All agents use Go 1.23+ iterators for streaming results:
@@ -81,6 +118,21 @@
 //		}
 //	}
 //
+// The iteration applies backpressure: the agent is suspended at each event until the consumer
+// asks for the next one, so that it never runs ahead of a slow consumer. WithEventBuffer lets an
+// LLMAgent stream up to n partial events ahead, blocking once the buffer is full and never
+// dropping an event, while the complete events are still handed over one at a time so that the
+// consumer appends them to the session before the next step. BufferEvents does the same for any
+// event sequence.
+//
+// DedupeEvents drops the events whose ID was already seen, such as when merging streams that may
+// emit an event twice, remembering only the most recently seen IDs so that an endless stream
+// stays bounded:
+//
+//	for event, err := range agent.DedupeEvents(merged, 0) {
+//		// Each event ID at most once within the last DefaultDedupeWindow IDs seen.
+//	}
+//
 // # Callbacks and Customization
 //
 // Agents support before/after callbacks for customization:
@@ -107,4 +159,17 @@
 //
 // The agent hierarchy enables complex workflows with proper context propagation
 // and state management throughout the execution tree.
+//
+// # Lifecycle
+//
+// The agents own their model set by WithModel, their closable tools, their toolsets and the
+// resources set by WithResources; the LoopAgent, SequentialAgent and ParallelAgent own the
+// resources set by their WithResources method. Init initializes them, and Close releases them for
+// the whole tree, the sub-agents before their parent and a shared resource once, joining the errors:
+//
+//	root := agent.NewLoopAgent("root", analyzer, reporter).WithResources(client)
+//	if err := root.Init(ctx); err != nil {
+//		return err
+//	}
+//	defer root.Close()
 package agent
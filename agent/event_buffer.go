@@ -0,0 +1,128 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"runtime/debug"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// WithEventBuffer lets the agent produce up to n partial events ahead of a slow consumer of
+// [LLMAgent.Run], see [BufferEvents].
+//
+// Zero or less means no buffer, which is the default: the agent is suspended at each event until
+// the consumer asks for the next one.
+func WithEventBuffer(n int) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.eventBuffer = n
+	}
+}
+
+// bufferedEvent is an event or an error of the buffered sequence.
+type bufferedEvent struct {
+	event *types.Event
+	err   error
+}
+
+// BufferEvents returns the events of the sequence run with ctx, produced ahead of the consumer
+// into a buffer of n events.
+//
+// Without a buffer, an event sequence has the backpressure of [iter.Seq2]: the producer is
+// suspended at each event until the consumer asks for the next one. With a buffer, the producer
+// keeps streaming the partial events of the model while the consumer handles the previous ones,
+// and blocks once n events are waiting, so that a slow consumer never makes the buffer grow
+// beyond n events. No event is dropped.
+//
+// The complete events, and the errors, are still handed over one at a time: the producer waits
+// after each of them until the consumer asks for the next event. The consumer, such as
+// [RunToCompletion], appends the complete events to the session, so that the agent always reads
+// the session up to date at its next step.
+//
+// The producer runs in its own goroutine. When the consumer stops the iteration, the context of
+// the sequence is canceled, so that a model or tool call in progress is abandoned, and the
+// producer is waited for. A panic of the producer is reported to the consumer as an error.
+// Zero or less means no buffer, and the sequence is run as is.
+func BufferEvents(ctx context.Context, run func(ctx context.Context) iter.Seq2[*types.Event, error], n int) iter.Seq2[*types.Event, error] {
+	if n <= 0 {
+		return run(ctx)
+	}
+
+	return func(yield func(*types.Event, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		var (
+			buffer   = make(chan bufferedEvent, n)
+			resume   = make(chan struct{})
+			done     = make(chan struct{})
+			finished = make(chan struct{})
+		)
+		go func() {
+			defer close(finished)
+			defer close(buffer)
+			defer func() {
+				if r := recover(); r != nil {
+					err := fmt.Errorf("event producer panicked: %v\n%s", r, debug.Stack())
+					select {
+					case buffer <- bufferedEvent{err: err}:
+					case <-done:
+					}
+				}
+			}()
+
+			for event, err := range run(ctx) {
+				select {
+				case buffer <- bufferedEvent{event: event, err: err}:
+				case <-done:
+					return
+				}
+				if isBarrier(event, err) {
+					select {
+					case <-resume:
+					case <-done:
+						return
+					}
+				}
+				// Do not run the next step for a consumer that is gone.
+				select {
+				case <-done:
+					return
+				default:
+				}
+			}
+		}()
+		defer func() {
+			close(done)
+			cancel()
+			<-finished
+		}()
+
+		for item := range buffer {
+			if !yield(item.event, item.err) {
+				return
+			}
+			if isBarrier(item.event, item.err) {
+				select {
+				case resume <- struct{}{}:
+				case <-finished:
+				}
+			}
+		}
+	}
+}
+
+// isBarrier reports whether the producer of [BufferEvents] waits for the consumer after the event
+// or the error, that is unless it is a partial event.
+func isBarrier(event *types.Event, err error) bool {
+	return err != nil || event == nil || event.LLMResponse == nil || !event.Partial
+}
+
+// runBuffered runs the agent with the event buffer set by [WithEventBuffer].
+func (a *LLMAgent) runBuffered(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return BufferEvents(ctx, func(ctx context.Context) iter.Seq2[*types.Event, error] {
+		return a.base.RunAgent(ctx, a, parentContext)
+	}, a.eventBuffer)
+}
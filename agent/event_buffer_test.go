@@ -0,0 +1,179 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func partialEvent(text string) *types.Event {
+	event := types.NewEvent().
+		WithAuthor("model").
+		WithContent(genai.NewContentFromText(text, genai.RoleModel))
+	event.Partial = true
+	return event
+}
+
+// runSeq returns a run function of [agent.BufferEvents] ignoring its context.
+func runSeq(seq iter.Seq2[*types.Event, error]) func(context.Context) iter.Seq2[*types.Event, error] {
+	return func(context.Context) iter.Seq2[*types.Event, error] { return seq }
+}
+
+func TestBufferEvents_SlowConsumer(t *testing.T) {
+	t.Parallel()
+
+	const (
+		buffer = 4
+		total  = 200
+	)
+	var produced atomic.Int64
+	seq := func(yield func(*types.Event, error) bool) {
+		for range total {
+			produced.Add(1)
+			if !yield(partialEvent("chunk"), nil) {
+				return
+			}
+		}
+	}
+
+	consumed := 0
+	for _, err := range agent.BufferEvents(t.Context(), runSeq(seq), buffer) {
+		if err != nil {
+			t.Fatalf("BufferEvents() error = %v", err)
+		}
+		consumed++
+		if consumed%50 == 0 {
+			// Let the producer run as far ahead as it can.
+			time.Sleep(10 * time.Millisecond)
+		}
+		// The producer holds at most the buffer and the event it is sending.
+		if ahead := produced.Load() - int64(consumed); ahead > buffer+1 {
+			t.Fatalf("producer ran %d events ahead, want at most %d", ahead, buffer+1)
+		}
+	}
+	if consumed != total {
+		t.Errorf("consumed %d events, want %d", consumed, total)
+	}
+}
+
+func TestBufferEvents_CompleteEventsWaitForConsumer(t *testing.T) {
+	t.Parallel()
+
+	var afterComplete atomic.Bool
+	seq := func(yield func(*types.Event, error) bool) {
+		if !yield(partialEvent("a"), nil) || !yield(types.NewEvent().WithAuthor("model"), nil) {
+			return
+		}
+		afterComplete.Store(true)
+		yield(partialEvent("b"), nil)
+	}
+
+	next, stop := iter.Pull2(agent.BufferEvents(t.Context(), runSeq(seq), 8))
+	defer stop()
+
+	for range 2 {
+		if _, err, ok := next(); !ok || err != nil {
+			t.Fatalf("next() = (%v, %t), want an event", err, ok)
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if afterComplete.Load() {
+		t.Error("producer went on after a complete event before the consumer asked for the next one")
+	}
+	if event, _, ok := next(); !ok || event.Content.Parts[0].Text != "b" {
+		t.Errorf("next() = %v, want the last partial event", event)
+	}
+	if _, _, ok := next(); ok {
+		t.Error("next() after the last event = ok, want the end of the sequence")
+	}
+}
+
+func TestBufferEvents_StopStopsProducer(t *testing.T) {
+	t.Parallel()
+
+	stopped := make(chan struct{})
+	seq := func(yield func(*types.Event, error) bool) {
+		defer close(stopped)
+		for yield(partialEvent("chunk"), nil) {
+		}
+	}
+
+	for range agent.BufferEvents(t.Context(), runSeq(seq), 2) {
+		break
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Error("producer still running after the consumer stopped")
+	}
+}
+
+func TestBufferEvents_StopCancelsProducer(t *testing.T) {
+	t.Parallel()
+
+	run := func(ctx context.Context) iter.Seq2[*types.Event, error] {
+		return func(yield func(*types.Event, error) bool) {
+			if !yield(partialEvent("a"), nil) {
+				return
+			}
+			// A model call going on after the partial event, until its context is canceled.
+			<-ctx.Done()
+			yield(nil, ctx.Err())
+		}
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for range agent.BufferEvents(t.Context(), run, 2) {
+			break
+		}
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stopping the iteration waited for the model call")
+	}
+}
+
+func TestBufferEvents_ProducerPanic(t *testing.T) {
+	t.Parallel()
+
+	seq := func(yield func(*types.Event, error) bool) {
+		if !yield(partialEvent("a"), nil) {
+			return
+		}
+		panic("boom")
+	}
+
+	var (
+		events int
+		errs   []error
+	)
+	for event, err := range agent.BufferEvents(t.Context(), runSeq(seq), 2) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if event != nil {
+			events++
+		}
+	}
+	if events != 1 {
+		t.Errorf("got %d events, want 1", events)
+	}
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "panicked: boom") {
+		t.Errorf("errors = %v, want the panic reported", errs)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"iter"
+
+	"github.com/go-a2a/adk-go/internal/cache"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultDedupeWindow is the number of recent event IDs remembered by [DedupeEvents] by default.
+const DefaultDedupeWindow = 4096
+
+// DedupeEvents returns the events of seq without the events whose ID was already seen, such as
+// when merging the streams of a [ParallelAgent] or replaying a session that may emit an event
+// twice. The errors, and the events without an ID, are passed through unchanged.
+//
+// Only window IDs are remembered, so that an endless stream does not grow the memory without
+// limit: once full, the least recently seen ID is forgotten, and an event repeated after window
+// other IDs were seen is yielded again. Zero or less means [DefaultDedupeWindow].
+func DedupeEvents(seq iter.Seq2[*types.Event, error], window int) iter.Seq2[*types.Event, error] {
+	if window <= 0 {
+		window = DefaultDedupeWindow
+	}
+
+	return func(yield func(*types.Event, error) bool) {
+		seen := cache.NewLRU[string, struct{}](window, 0)
+		for event, err := range seq {
+			if err != nil || event == nil || event.ID == "" {
+				if !yield(event, err) {
+					return
+				}
+				continue
+			}
+			if _, ok := seen.Get(event.ID); ok {
+				continue
+			}
+
+			seen.Put(event.ID, struct{}{})
+			if !yield(event, nil) {
+				return
+			}
+		}
+	}
+}
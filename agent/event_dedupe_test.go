@@ -0,0 +1,85 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestDedupeEvents(t *testing.T) {
+	t.Parallel()
+
+	errStream := errors.New("stream failed")
+	type item struct {
+		id  string
+		err error
+	}
+
+	tests := map[string]struct {
+		window int
+		items  []item
+		want   []string
+	}{
+		"duplicates dropped": {
+			items: []item{{id: "a"}, {id: "b"}, {id: "a"}, {id: "c"}, {id: "b"}},
+			want:  []string{"a", "b", "c"},
+		},
+		"errors and events without an ID passed through": {
+			items: []item{{id: "a"}, {err: errStream}, {id: ""}, {id: ""}, {id: "a"}, {err: errStream}},
+			want:  []string{"a", "error", "", "", "error"},
+		},
+		"IDs forgotten past the window": {
+			window: 2,
+			items:  []item{{id: "a"}, {id: "b"}, {id: "b"}, {id: "c"}, {id: "a"}, {id: "c"}},
+			want:   []string{"a", "b", "c", "a"},
+		},
+		"IDs seen again kept in the window": {
+			window: 2,
+			items:  []item{{id: "a"}, {id: "b"}, {id: "a"}, {id: "c"}, {id: "a"}, {id: "b"}},
+			want:   []string{"a", "b", "c", "b"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			seq := func(yield func(*types.Event, error) bool) {
+				for _, it := range tt.items {
+					if it.err != nil {
+						if !yield(nil, it.err) {
+							return
+						}
+						continue
+					}
+					event := types.NewEvent()
+					event.ID = it.id
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+
+			var got []string
+			for event, err := range agent.DedupeEvents(seq, tt.window) {
+				if err != nil {
+					if !errors.Is(err, errStream) {
+						t.Fatalf("DedupeEvents() error = %v, want %v", err, errStream)
+					}
+					got = append(got, "error")
+					continue
+				}
+				got = append(got, event.ID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DedupeEvents() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import "github.com/go-a2a/adk-go/flow/llmflow"
+
+var ApplyStageFilter = applyStageFilter
+
+// ApplyOutputGuardrails exports LLMAgent.applyOutputGuardrails for testing.
+var ApplyOutputGuardrails = (*LLMAgent).applyOutputGuardrails
+
+// GuardrailBlockedEvent exports LLMAgent.guardrailBlockedEvent for testing.
+var GuardrailBlockedEvent = (*LLMAgent).guardrailBlockedEvent
+
+// FlowOf returns the flow built by the agent for its runs, for testing.
+func FlowOf(a *LLMAgent) *llmflow.LLMFlow {
+	switch flow := a.llmFlow().(type) {
+	case *llmflow.SingleFlow:
+		return flow.LLMFlow
+	case *llmflow.AutoFlow:
+		return flow.LLMFlow
+	}
+	return nil
+}
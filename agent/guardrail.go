@@ -0,0 +1,149 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"cmp"
+	"context"
+	"errors"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultGuardrailMessage is the message sent to the user when a guardrail blocks the run
+// without giving its own message.
+const DefaultGuardrailMessage = "Sorry, I can't help with that request."
+
+// InputGuardrail decides whether the incoming user turn is allowed.
+//
+// It returns the content to continue with, which may be the given content as-is or a
+// rewritten one, or a [*GuardrailBlockedError] to block the run.
+type InputGuardrail func(ctx context.Context, content *genai.Content) (*genai.Content, error)
+
+// OutputGuardrail decides whether the final model response is allowed.
+//
+// It returns the response to continue with, which may be the given response as-is or a
+// rewritten one, or a [*GuardrailBlockedError] to block the response.
+type OutputGuardrail func(ctx context.Context, response *types.LLMResponse) (*types.LLMResponse, error)
+
+// GuardrailBlockedError is the error returned by an [InputGuardrail] or [OutputGuardrail] to block the run.
+//
+// The run is halted and a single event carrying the Message is sent instead.
+type GuardrailBlockedError struct {
+	// Reason is the reason of the block. It is not shown to the user.
+	Reason string
+
+	// Message is the safe message shown to the user. [DefaultGuardrailMessage] is used if empty.
+	Message string
+}
+
+// Error implements error.
+func (e *GuardrailBlockedError) Error() string {
+	if e.Reason == "" {
+		return "blocked by guardrail"
+	}
+	return "blocked by guardrail: " + e.Reason
+}
+
+// WithInputGuardrail adds a guardrail evaluated on the incoming user turn before calling the model.
+//
+// Guardrails are evaluated in the order they are added, each one receiving the content
+// returned by the previous one.
+func WithInputGuardrail(guardrail InputGuardrail) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.inputGuardrails = append(a.inputGuardrails, guardrail)
+	}
+}
+
+// WithOutputGuardrail adds a guardrail evaluated on the final response of the agent.
+//
+// Guardrails are evaluated in the order they are added, each one receiving the response
+// returned by the previous one.
+//
+// NOTE: partial streaming responses are not evaluated.
+func WithOutputGuardrail(guardrail OutputGuardrail) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.outputGuardrails = append(a.outputGuardrails, guardrail)
+	}
+}
+
+// applyInputGuardrails runs the input guardrails on the user content of the invocation.
+//
+// A rewritten content replaces the user content of the invocation and of the session event it comes from.
+func (a *LLMAgent) applyInputGuardrails(ctx context.Context, ictx *types.InvocationContext) error {
+	if len(a.inputGuardrails) == 0 || ictx.UserContent == nil {
+		return nil
+	}
+
+	original := ictx.UserContent
+	content := original
+	for _, guardrail := range a.inputGuardrails {
+		rewritten, err := guardrail(ctx, content)
+		if err != nil {
+			return err
+		}
+		if rewritten != nil {
+			content = rewritten
+		}
+	}
+	if content == original {
+		return nil
+	}
+
+	ictx.UserContent = content
+	if ictx.Session != nil {
+		events := ictx.Session.Events()
+		for i := len(events) - 1; i >= 0; i-- {
+			if events[i].LLMResponse != nil && events[i].Content == original {
+				events[i].Content = content
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// applyOutputGuardrails runs the output guardrails on the final response event.
+func (a *LLMAgent) applyOutputGuardrails(ctx context.Context, event *types.Event) error {
+	if len(a.outputGuardrails) == 0 || event.LLMResponse == nil || event.Actions == nil || !event.IsFinalResponse() {
+		return nil
+	}
+
+	response := event.LLMResponse
+	for _, guardrail := range a.outputGuardrails {
+		rewritten, err := guardrail(ctx, response)
+		if err != nil {
+			return err
+		}
+		if rewritten != nil {
+			response = rewritten
+		}
+	}
+	event.LLMResponse = response
+
+	return nil
+}
+
+// guardrailBlockedEvent returns the event sent instead of the blocked content, if err is a [*GuardrailBlockedError].
+//
+// It also ends the invocation.
+func (a *LLMAgent) guardrailBlockedEvent(ictx *types.InvocationContext, err error) (*types.Event, bool) {
+	var blocked *GuardrailBlockedError
+	if !errors.As(err, &blocked) {
+		return nil, false
+	}
+
+	ictx.EndInvocation = true
+	event := ictx.NewEvent().
+		WithInvocationID(ictx.InvocationID).
+		WithAuthor(a.Name()).
+		WithBranch(ictx.Branch).
+		WithContent(genai.NewContentFromText(cmp.Or(blocked.Message, DefaultGuardrailMessage), genai.RoleModel)).
+		WithActions(types.NewEventActions())
+
+	return event, true
+}
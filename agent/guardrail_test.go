@@ -0,0 +1,172 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestLLMAgent_InputGuardrailBlocked(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err         error
+		wantMessage string
+	}{
+		"custom message": {
+			err:         &agent.GuardrailBlockedError{Reason: "profanity", Message: "Please rephrase."},
+			wantMessage: "Please rephrase.",
+		},
+		"default message": {
+			err:         fmt.Errorf("wrapped: %w", &agent.GuardrailBlockedError{Reason: "profanity"}),
+			wantMessage: agent.DefaultGuardrailMessage,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var called bool
+			a, err := agent.NewLLMAgent(t.Context(), "guarded",
+				agent.WithInputGuardrail(func(ctx context.Context, content *genai.Content) (*genai.Content, error) {
+					called = true
+					if strings.Contains(content.Parts[0].Text, "bad") {
+						return nil, tt.err
+					}
+					return content, nil
+				}),
+			)
+			if err != nil {
+				t.Fatalf("NewLLMAgent: %v", err)
+			}
+
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(),
+				types.WithUserContent(genai.NewContentFromText("say something bad", genai.RoleUser)),
+			)
+
+			var events []*types.Event
+			for event, err := range a.Execute(t.Context(), ictx) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				events = append(events, event)
+			}
+
+			if !called {
+				t.Fatal("input guardrail was not called")
+			}
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+			if got := events[0].Content.Parts[0].Text; got != tt.wantMessage {
+				t.Errorf("message = %q, want %q", got, tt.wantMessage)
+			}
+			if got := events[0].Author; got != "guarded" {
+				t.Errorf("author = %q, want %q", got, "guarded")
+			}
+			if !ictx.EndInvocation {
+				t.Error("EndInvocation = false, want true")
+			}
+		})
+	}
+}
+
+func TestLLMAgent_OutputGuardrail(t *testing.T) {
+	t.Parallel()
+
+	redact := func(ctx context.Context, response *types.LLMResponse) (*types.LLMResponse, error) {
+		text := strings.ReplaceAll(response.Content.Parts[0].Text, "123-45-6789", "[redacted]")
+		return &types.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}, nil
+	}
+	blockPII := func(ctx context.Context, response *types.LLMResponse) (*types.LLMResponse, error) {
+		if strings.Contains(response.Content.Parts[0].Text, "123-45-6789") {
+			return nil, &agent.GuardrailBlockedError{Reason: "pii", Message: "I can't share that."}
+		}
+		return nil, nil
+	}
+
+	tests := map[string]struct {
+		guardrails []agent.OutputGuardrail
+		event      *types.Event
+		wantText   string
+		wantEnded  bool
+	}{
+		"blocked": {
+			guardrails: []agent.OutputGuardrail{blockPII},
+			event:      textEvent("guarded", "The number is 123-45-6789."),
+			wantText:   "I can't share that.",
+			wantEnded:  true,
+		},
+		"rewritten before the next guardrail": {
+			guardrails: []agent.OutputGuardrail{redact, blockPII},
+			event:      textEvent("guarded", "The number is 123-45-6789."),
+			wantText:   "The number is [redacted].",
+		},
+		"partial response not evaluated": {
+			guardrails: []agent.OutputGuardrail{blockPII},
+			event:      partialEvent("The number is 123-45-6789."),
+			wantText:   "The number is 123-45-6789.",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := make([]agent.LLMAgentOption, len(tt.guardrails))
+			for i, guardrail := range tt.guardrails {
+				opts[i] = agent.WithOutputGuardrail(guardrail)
+			}
+			a, err := agent.NewLLMAgent(t.Context(), "guarded", opts...)
+			if err != nil {
+				t.Fatalf("NewLLMAgent: %v", err)
+			}
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			event := tt.event
+			if err := agent.ApplyOutputGuardrails(a, t.Context(), event); err != nil {
+				blocked, ok := agent.GuardrailBlockedEvent(a, ictx, err)
+				if !ok {
+					t.Fatalf("applyOutputGuardrails() error = %v, want a GuardrailBlockedError", err)
+				}
+				event = blocked
+			}
+
+			if got := event.Content.Parts[0].Text; got != tt.wantText {
+				t.Errorf("text = %q, want %q", got, tt.wantText)
+			}
+			if got := event.Author; tt.wantEnded && got != "guarded" {
+				t.Errorf("blocked event author = %q, want %q", got, "guarded")
+			}
+			if ictx.EndInvocation != tt.wantEnded {
+				t.Errorf("EndInvocation = %t, want %t", ictx.EndInvocation, tt.wantEnded)
+			}
+		})
+	}
+}
+
+func TestGuardrailBlockedError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("check: %w", &agent.GuardrailBlockedError{Reason: "pii"})
+	var blocked *agent.GuardrailBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("errors.As(%v) = false, want true", err)
+	}
+	if got, want := blocked.Error(), "blocked by guardrail: pii"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
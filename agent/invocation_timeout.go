@@ -0,0 +1,69 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/pkg/py/pyasyncio"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// ErrorCodeInvocationTimeout is the [types.LLMResponse.ErrorCode] of the terminal event sent
+// when the invocation exceeds the timeout set by [WithInvocationTimeout].
+const ErrorCodeInvocationTimeout = "INVOCATION_TIMEOUT"
+
+// WithInvocationTimeout bounds the whole invocation of the agent to d, including all model calls,
+// tool calls, retries and agent transfers.
+//
+// The context passed to the flow, the tools and the callbacks is canceled when d elapses,
+// with a [*pyasyncio.TimeoutError] as its cause. The run then stops and a terminal event with
+// the [ErrorCodeInvocationTimeout] error code is sent instead of an error.
+//
+// Zero or less means no timeout, which is the default.
+func WithInvocationTimeout(d time.Duration) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.invocationTimeout = d
+	}
+}
+
+// withInvocationTimeout returns the context bounded by the invocation timeout of the agent.
+func (a *LLMAgent) withInvocationTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if a.invocationTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	cause := pyasyncio.NewTimeoutErrorWithMessage(fmt.Sprintf("invocation timed out after %s", a.invocationTimeout), a.invocationTimeout)
+	return context.WithTimeoutCause(ctx, a.invocationTimeout, cause)
+}
+
+// invocationTimeoutEvent returns the terminal event of the invocation if its timeout has elapsed.
+//
+// It also ends the invocation.
+func (a *LLMAgent) invocationTimeoutEvent(ctx context.Context, ictx *types.InvocationContext) (*types.Event, bool) {
+	if a.invocationTimeout <= 0 || ctx.Err() == nil {
+		return nil, false
+	}
+	var timeoutErr *pyasyncio.TimeoutError
+	if !errors.As(context.Cause(ctx), &timeoutErr) {
+		return nil, false
+	}
+
+	ictx.EndInvocation = true
+	event := ictx.NewEvent().
+		WithInvocationID(ictx.InvocationID).
+		WithAuthor(a.Name()).
+		WithBranch(ictx.Branch).
+		WithContent(genai.NewContentFromText("The request took too long and was stopped.", genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	event.ErrorCode = ErrorCodeInvocationTimeout
+	event.ErrorMessage = timeoutErr.Error()
+
+	return event, true
+}
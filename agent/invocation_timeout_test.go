@@ -0,0 +1,85 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/pkg/py/pyasyncio"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// blockingTool is a tool blocking in the flow until its context is done, which it reports.
+type blockingTool struct {
+	types.Tool
+
+	observed chan error
+}
+
+func (t *blockingTool) ProcessLLMRequest(ctx context.Context, toolCtx *types.ToolContext, request *types.LLMRequest) error {
+	select {
+	case <-ctx.Done():
+		t.observed <- context.Cause(ctx)
+		return ctx.Err()
+	case <-time.After(5 * time.Second):
+		return nil
+	}
+}
+
+func TestLLMAgent_InvocationTimeout(t *testing.T) {
+	t.Parallel()
+
+	// the in-flight tool observes the cancellation through its context
+	tool := &blockingTool{
+		Tool:     tools.NewFunctionTool(func(context.Context, map[string]any) (any, error) { return nil, nil }),
+		observed: make(chan error, 1),
+	}
+	a, err := agent.NewLLMAgent(t.Context(), "slow",
+		agent.WithModel(&summaryModel{summary: "unused"}),
+		agent.WithInvocationTimeout(20*time.Millisecond),
+		agent.WithTools(tool),
+	)
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(),
+		types.WithUserContent(genai.NewContentFromText("hello", genai.RoleUser)),
+	)
+
+	start := time.Now()
+	var events []*types.Event
+	for event, err := range a.Execute(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events = append(events, event)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("Execute took %s, want it bounded by the invocation timeout", elapsed)
+	}
+
+	var timeoutErr *pyasyncio.TimeoutError
+	if cause := <-tool.observed; !errors.As(cause, &timeoutErr) {
+		t.Errorf("context cause = %v, want *pyasyncio.TimeoutError", cause)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	if got := events[0].ErrorCode; got != agent.ErrorCodeInvocationTimeout {
+		t.Errorf("ErrorCode = %q, want %q", got, agent.ErrorCodeInvocationTimeout)
+	}
+	if !ictx.EndInvocation {
+		t.Error("EndInvocation = false, want true")
+	}
+}
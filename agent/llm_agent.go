@@ -7,16 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"iter"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/go-json-experiment/json"
 	"google.golang.org/genai"
 
 	"github.com/go-a2a/adk-go/flow/llmflow"
 	"github.com/go-a2a/adk-go/internal/pool"
-	"github.com/go-a2a/adk-go/internal/xiter"
 	"github.com/go-a2a/adk-go/model"
 	"github.com/go-a2a/adk-go/tool/tools"
 	"github.com/go-a2a/adk-go/types"
@@ -124,9 +125,58 @@ type LLMAgent struct {
 	// When a list of callbacks is provided, the callbacks will be called in the
 	// order they are listed until a callback does not return None.
 	afterToolCallbacks []types.AfterToolCallback
+
+	// Guardrails evaluated on the incoming user turn.
+	inputGuardrails []InputGuardrail
+
+	// Guardrails evaluated on the final response.
+	outputGuardrails []OutputGuardrail
+
+	// Deadline of the whole invocation, including model calls, tool calls and transfers.
+	invocationTimeout time.Duration
+
+	// Maximum number of agent transfers in an invocation when this agent transfers, zero for no limit.
+	maxTransferDepth int
+
+	// Whether the tool calls are intercepted instead of run, see [WithDryRun].
+	dryRun bool
+
+	// Number of most recent turns in the history sent to the model, zero for the whole history.
+	maxTurns int
+
+	// Whether the grounding sources of the model responses are extracted into the events.
+	citations bool
+
+	// Minimum size of the repeated parts deduplicated in the history, zero to keep them all.
+	dedupeMinSize int
+
+	// Conversion of the tool results into function responses, nil to send them as is.
+	functionResponseFormat *llmflow.FunctionResponseFormat
+
+	// Conversions overriding functionResponseFormat, by tool name.
+	toolFunctionResponseFormats map[string]*llmflow.FunctionResponseFormat
+
+	// Total size of the tool outputs in the history sent to the model, zero for no limit.
+	maxTotalToolOutputBytes int
+
+	// Whether the instructions are memoized within an invocation, see [WithInstructionMemoization].
+	memoizeInstructions  bool
+	onInstructionRebuild func(llmflow.InstructionRebuild)
+
+	// Number of partial events produced ahead of the consumer of Run, zero for no buffer.
+	eventBuffer int
+
+	// Function calling mode of the model requests, nil to let the model decide.
+	toolChoice *types.ToolChoice
+
+	// Grounding of the answers in Google Search, nil for no grounding.
+	googleSearchGrounding *types.GoogleSearchGrounding
 }
 
-var _ types.Agent = (*LLMAgent)(nil)
+var (
+	_ types.Agent          = (*LLMAgent)(nil)
+	_ types.ResourceHolder = (*LLMAgent)(nil)
+)
 
 // AsLLMAgent implements [types.Agent].
 func (a *LLMAgent) AsLLMAgent() (types.LLMAgent, bool) {
@@ -151,6 +201,9 @@ func WithModel(model types.Model) LLMAgentOption {
 }
 
 // WithInstruction sets the instruction for the agent.
+//
+// The state values referred to by a string instruction, such as {topic}, are injected into it. The
+// instruction of a [types.InstructionProvider] is used as is.
 func WithInstruction[T string | types.InstructionProvider](instruction T) LLMAgentOption {
 	return func(a *LLMAgent) {
 		a.instruction = instruction
@@ -164,24 +217,35 @@ func WithGlobalInstruction[T string | types.InstructionProvider](instruction T)
 	}
 }
 
-// WithTools sets the [tools.function] for the agent.
+// WithFunctionTools adds the [tools.Function] to the tools of the agent.
 func WithFunctionTools(tools ...tools.Function) LLMAgentOption {
 	return func(a *LLMAgent) {
-		a.tools = []any{tools}
+		for _, tool := range tools {
+			a.tools = append(a.tools, tool)
+		}
 	}
 }
 
-// WithTools sets the [Tool] for the agent.
+// WithTools adds the [Tool] to the tools of the agent.
+//
+// [NewLLMAgent] fails with a [*types.DuplicateToolNameError] if several tools share a name.
 func WithTools(tools ...types.Tool) LLMAgentOption {
 	return func(a *LLMAgent) {
-		a.tools = []any{tools}
+		for _, tool := range tools {
+			a.tools = append(a.tools, tool)
+		}
 	}
 }
 
-// WithToolset sets the [Toolset] for the agent.
+// WithToolset adds the [Toolset] to the tools of the agent.
+//
+// The tools of a toolset depend on the context, so that their names are checked by
+// [LLMAgent.Validate] rather than by [NewLLMAgent].
 func WithToolset(tools ...types.Toolset) LLMAgentOption {
 	return func(a *LLMAgent) {
-		a.tools = []any{tools}
+		for _, tool := range tools {
+			a.tools = append(a.tools, tool)
+		}
 	}
 }
 
@@ -206,6 +270,91 @@ func WithDisallowTransferToPeers(disallow bool) LLMAgentOption {
 	}
 }
 
+// WithMaxTransferDepth stops the transfers of the agent once the invocation made n agent
+// transfers, yielding an event with the [types.ErrorCodeMaxTransferDepth] error code instead.
+//
+// The limit applies to the transfers made by this agent, counted over all the agents of the
+// invocation. Set it on each agent of a hierarchy to guard against delegation loops between them.
+func WithMaxTransferDepth(n int) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.maxTransferDepth = n
+	}
+}
+
+// WithDryRun makes the agent preview its actions without taking them: its tool calls are recorded
+// and answered with a synthetic response instead of being run, and its run ends with an event
+// listing them in [types.Event.IntendedToolCalls], see [llmflow.LLMFlow.WithDryRun].
+func WithDryRun() LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.dryRun = true
+	}
+}
+
+// WithCitations sets whether the sources of the grounding and citation metadata of the model
+// responses are extracted into [types.Event.Citations], see [llmflow.LLMFlow.WithCitations].
+func WithCitations(enabled bool) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.citations = enabled
+	}
+}
+
+// WithFunctionResponseFormat sets how the results of the tools of the agent become the function
+// responses sent back to the model, see [llmflow.LLMFlow.WithFunctionResponseFormat].
+func WithFunctionResponseFormat(format *llmflow.FunctionResponseFormat) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.functionResponseFormat = format
+	}
+}
+
+// WithToolFunctionResponseFormat sets the format of the function responses of the named tool,
+// overriding the format set with [WithFunctionResponseFormat].
+func WithToolFunctionResponseFormat(toolName string, format *llmflow.FunctionResponseFormat) LLMAgentOption {
+	return func(a *LLMAgent) {
+		if a.toolFunctionResponseFormats == nil {
+			a.toolFunctionResponseFormats = make(map[string]*llmflow.FunctionResponseFormat)
+		}
+		a.toolFunctionResponseFormats[toolName] = format
+	}
+}
+
+// WithMaxTurns keeps the n most recent conversational turns in the history sent to the model,
+// see [llmflow.ContentLLMRequestProcessor.WithMaxTurns]. Zero or less keeps the whole history,
+// which is the default.
+func WithMaxTurns(n int) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.maxTurns = n
+	}
+}
+
+// WithDedupe replaces the later occurrences of the parts of at least minSize bytes repeated in the
+// history sent to the model with a short marker, see [llmflow.ContentLLMRequestProcessor.WithDedupe].
+// Zero or less disables it, which is the default.
+func WithDedupe(minSize int) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.dedupeMinSize = minSize
+	}
+}
+
+// WithMaxTotalToolOutputBytes caps the total size of the tool outputs in the history sent to the
+// model to n bytes, truncating the oldest ones over the budget, see
+// [llmflow.LLMFlow.WithMaxTotalToolOutputBytes]. Zero or less means no limit, which is the default.
+func WithMaxTotalToolOutputBytes(n int) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.maxTotalToolOutputBytes = n
+	}
+}
+
+// WithInstructionMemoization memoizes the instructions of the agent within an invocation, rather
+// than populating them again at each request of the function calling loop, see
+// [llmflow.LLMFlow.WithInstructionMemoization]. onRebuild, unless nil, is called when a memoized
+// instruction is rebuilt.
+func WithInstructionMemoization(onRebuild func(llmflow.InstructionRebuild)) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.memoizeInstructions = true
+		a.onInstructionRebuild = onRebuild
+	}
+}
+
 // WithIncludeContents sets the [IncludeContents] for the agent.
 func WithIncludeContents(includeContents types.IncludeContents) LLMAgentOption {
 	return func(a *LLMAgent) {
@@ -283,6 +432,17 @@ func WithAfterToolCallback(callback types.AfterToolCallback) LLMAgentOption {
 	}
 }
 
+// WithResources registers resources of the agent, such as clients used by its callbacks or
+// instruction providers, initialized and released with the agent by [LLMAgent.Init] and
+// [LLMAgent.Close].
+func WithResources(closers ...io.Closer) LLMAgentOption {
+	return func(a *LLMAgent) {
+		for _, closer := range closers {
+			a.base.AddResource(closer)
+		}
+	}
+}
+
 // NewLLMAgent creates a new [LLMAgent] with the given name and options.
 func NewLLMAgent(ctx context.Context, name string, opts ...LLMAgentOption) (*LLMAgent, error) {
 	agent := &LLMAgent{
@@ -355,17 +515,18 @@ func (a *LLMAgent) CanonicalModel(ctx context.Context) (types.Model, error) {
 	return nil, fmt.Errorf("no model found for %s", a.model)
 }
 
-// CanonicalInstructions returns the resolved self.instruction field to construct instruction for this agent.
+// CanonicalInstructions returns the resolved self.instruction field to construct instruction for this agent,
+// and whether to bypass the state injection, which is the case of an [types.InstructionProvider].
 //
 // This method is only for use by Agent Development Kit.
-func (a *LLMAgent) CanonicalInstructions(rctx *types.ReadOnlyContext) string {
+func (a *LLMAgent) CanonicalInstructions(rctx *types.ReadOnlyContext) (string, bool) {
 	switch inst := a.instruction.(type) {
 	case string:
-		return inst
+		return inst, false
 	case types.InstructionProvider:
-		return inst(rctx)
+		return inst(rctx), true
 	default:
-		return ""
+		return "", false
 	}
 }
 
@@ -409,9 +570,30 @@ func (a *LLMAgent) parseTool(tool any, rctx *types.ReadOnlyContext) []types.Tool
 
 func (a *LLMAgent) llmFlow() types.Flow {
 	if a.disallowTransferToParent && a.disallowTransferToPeers && len(a.base.SubAgents()) == 0 {
-		return llmflow.NewSingleFlow()
+		flow := llmflow.NewSingleFlow()
+		a.configureFlow(flow.LLMFlow)
+		return flow
+	}
+	flow := llmflow.NewAutoFlow()
+	flow.WithMaxTransferDepth(a.maxTransferDepth)
+	a.configureFlow(flow.LLMFlow)
+	return flow
+}
+
+// configureFlow applies the options of the agent shared by the single and the auto flows.
+func (a *LLMAgent) configureFlow(flow *llmflow.LLMFlow) {
+	flow.WithDryRun(a.dryRun)
+	flow.WithMaxTurns(a.maxTurns)
+	flow.WithDedupe(a.dedupeMinSize)
+	flow.WithCitations(a.citations)
+	flow.WithFunctionResponseFormat(a.functionResponseFormat)
+	for toolName, format := range a.toolFunctionResponseFormats {
+		flow.WithToolFunctionResponseFormat(toolName, format)
+	}
+	flow.WithMaxTotalToolOutputBytes(a.maxTotalToolOutputBytes)
+	if a.memoizeInstructions {
+		flow.WithInstructionMemoization(a.onInstructionRebuild)
 	}
-	return llmflow.NewAutoFlow()
 }
 
 // saveOutputToState saves the model output to state if needed.
@@ -474,6 +656,16 @@ func (a *LLMAgent) OutputKey() string {
 	return a.outputKey
 }
 
+// ToolChoice returns the function calling mode of the model requests of the agent.
+func (a *LLMAgent) ToolChoice() *types.ToolChoice {
+	return a.toolChoice
+}
+
+// GoogleSearchGrounding returns the grounding of the answers of the agent in Google Search.
+func (a *LLMAgent) GoogleSearchGrounding() *types.GoogleSearchGrounding {
+	return a.googleSearchGrounding
+}
+
 // Planner returns the instructs the agent to make a plan and execute it step by step.
 func (a *LLMAgent) Planner() types.Planner {
 	return a.planner
@@ -515,9 +707,37 @@ func (a *LLMAgent) AfterToolCallbacks() []types.AfterToolCallback {
 // Execute implements [types.Agent].
 func (a *LLMAgent) Execute(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
+		ctx, cancel := a.withInvocationTimeout(ctx)
+		defer cancel()
+
+		if err := a.applyInputGuardrails(ctx, ictx); err != nil {
+			if event, ok := a.guardrailBlockedEvent(ictx, err); ok {
+				yield(event, nil)
+				return
+			}
+			if event, ok := a.invocationTimeoutEvent(ctx, ictx); ok {
+				yield(event, nil)
+				return
+			}
+			yield(nil, err)
+			return
+		}
+
 		for event, err := range a.llmFlow().Run(ctx, ictx) {
 			if err != nil {
-				xiter.Error[types.Event](err)
+				if event, ok := a.invocationTimeoutEvent(ctx, ictx); ok {
+					yield(event, nil)
+					return
+				}
+				yield(nil, err)
+				return
+			}
+			if err := a.applyOutputGuardrails(ctx, event); err != nil {
+				if event, ok := a.guardrailBlockedEvent(ictx, err); ok {
+					yield(event, nil)
+					return
+				}
+				yield(nil, err)
 				return
 			}
 			if err := a.saveOutputToState(event); err != nil {
@@ -529,16 +749,47 @@ func (a *LLMAgent) Execute(ctx context.Context, ictx *types.InvocationContext) i
 			if !yield(event, nil) {
 				return
 			}
+			if event, ok := a.invocationTimeoutEvent(ctx, ictx); ok {
+				yield(event, nil)
+				return
+			}
+		}
+
+		if event, ok := a.invocationTimeoutEvent(ctx, ictx); ok {
+			yield(event, nil)
 		}
 	}
 }
 
 // ExecuteLive implements [types.Agent].
+//
+// If the model of the agent does not support live connections, see [LLMAgent.SupportsLive], the
+// agent falls back to a streaming run with server-sent events: its text and function call events
+// are produced as by [LLMAgent.Execute], without the audio and video features of the live mode.
 func (a *LLMAgent) ExecuteLive(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
+		if !a.SupportsLive(ctx) {
+			a.base.Logger().WarnContext(ctx, "model does not support live connections, falling back to a streaming run without the live audio and video features",
+				slog.String("agent", a.Name()),
+			)
+			runConfig := types.RunConfig{}
+			if ictx.RunConfig != nil {
+				runConfig = *ictx.RunConfig
+			}
+			runConfig.StreamingMode = types.StreamingModeSSE
+			ictx.RunConfig = &runConfig
+
+			for event, err := range a.Execute(ctx, ictx) {
+				if !yield(event, err) {
+					return
+				}
+			}
+			return
+		}
+
 		for event, err := range a.llmFlow().RunLive(ctx, ictx) {
 			if err != nil {
-				xiter.Error[types.Event](err)
+				yield(nil, err)
 				return
 			}
 			if err := a.saveOutputToState(event); err != nil {
@@ -558,14 +809,57 @@ func (a *LLMAgent) ExecuteLive(ctx context.Context, ictx *types.InvocationContex
 	}
 }
 
+// SupportsLive reports whether the model of the agent supports live connections, see
+// [types.SupportsLive]. [LLMAgent.ExecuteLive] falls back to a streaming run otherwise.
+func (a *LLMAgent) SupportsLive(ctx context.Context) bool {
+	m, err := a.CanonicalModel(ctx)
+	if err != nil {
+		return false
+	}
+	return types.SupportsLive(m)
+}
+
 // Run implements [types.Agent].
+//
+// The events are produced as the consumer asks for them, or ahead of it into the buffer set by
+// [WithEventBuffer].
 func (a *LLMAgent) Run(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.Run(ctx, parentContext)
+	return a.runBuffered(ctx, parentContext)
 }
 
 // RunLive implements [types.Agent].
 func (a *LLMAgent) RunLive(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.RunLive(ctx, parentContext)
+	return a.base.RunLiveAgent(ctx, a, parentContext)
+}
+
+// Resources implements [types.ResourceHolder].
+//
+// The resources of the agent are its model if it was set by [WithModel], its tools implementing
+// [io.Closer] and its toolsets, in this order, then the resources set by [WithResources].
+func (a *LLMAgent) Resources() []io.Closer {
+	var resources []io.Closer
+	if closer, ok := a.model.(io.Closer); ok {
+		resources = append(resources, closer)
+	}
+	for _, tool := range a.tools {
+		switch tool := tool.(type) {
+		case types.Toolset:
+			resources = append(resources, types.ToolsetResource(tool))
+		case io.Closer:
+			resources = append(resources, tool)
+		}
+	}
+	return append(resources, a.base.Resources()...)
+}
+
+// Init initializes the resources of the agent and its sub-agents, see [types.InitAgent].
+func (a *LLMAgent) Init(ctx context.Context) error {
+	return types.InitAgent(ctx, a)
+}
+
+// Close releases the resources of the agent and its sub-agents, see [types.CloseAgent].
+func (a *LLMAgent) Close() error {
+	return types.CloseAgent(a)
 }
 
 // RootAgent implements [types.Agent].
@@ -608,5 +902,28 @@ func (a *LLMAgent) validateConfig(ctx context.Context) error {
 		}
 	}
 
+	// The tools of the toolsets depend on the context, and are checked by Validate.
+	var staticTools []types.Tool
+	for _, tool := range a.tools {
+		if _, ok := tool.(types.Toolset); !ok {
+			staticTools = append(staticTools, a.parseTool(tool, nil)...)
+		}
+	}
+	if err := types.CheckToolNames(staticTools); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate reports the problems of the configuration of the agent with the tools resolved in the
+// context, including those of its toolsets, such as tools sharing a name as a
+// [*types.DuplicateToolNameError].
+//
+// The tools added with [WithTools] and [WithFunctionTools] are already checked by [NewLLMAgent].
+func (a *LLMAgent) Validate(rctx *types.ReadOnlyContext) error {
+	if err := types.CheckToolNames(a.CanonicalTool(rctx)); err != nil {
+		return fmt.Errorf("invalid agent configuration: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,407 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	nooptrace "go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// staticToolset is a toolset of fixed tools.
+type staticToolset []types.Tool
+
+func (ts staticToolset) GetTools(*types.ReadOnlyContext) []types.Tool {
+	return ts
+}
+
+func (staticToolset) Close() {}
+
+func TestLLMAgent_DuplicateToolNames(t *testing.T) {
+	t.Parallel()
+
+	tool := func(name string) types.Tool {
+		return tools.NewAgent(name, name)
+	}
+
+	tests := map[string]struct {
+		opts         []agent.LLMAgentOption
+		wantNewErr   []string
+		wantValidate []string
+	}{
+		"unique": {
+			opts: []agent.LLMAgentOption{
+				agent.WithTools(tool("search"), tool("fetch")),
+				agent.WithToolset(staticToolset{tool("list")}),
+			},
+		},
+		"duplicate tools": {
+			opts: []agent.LLMAgentOption{
+				agent.WithTools(tool("search"), tool("fetch"), tool("search")),
+				agent.WithTools(tool("fetch"), tool("list")),
+			},
+			wantNewErr: []string{"fetch", "search"},
+		},
+		"toolset conflicting with a tool": {
+			opts: []agent.LLMAgentOption{
+				agent.WithTools(tool("search")),
+				agent.WithToolset(staticToolset{tool("search"), tool("list")}),
+			},
+			wantValidate: []string{"search"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "agent", tt.opts...)
+			if tt.wantNewErr != nil {
+				checkDuplicateToolNames(t, "NewLLMAgent()", err, tt.wantNewErr)
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewLLMAgent() error = %v", err)
+			}
+
+			err = a.Validate(types.NewReadOnlyContext(types.NewInvocationContext(a, nil, nil)))
+			if tt.wantValidate == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			checkDuplicateToolNames(t, "Validate()", err, tt.wantValidate)
+		})
+	}
+}
+
+func checkDuplicateToolNames(t *testing.T, call string, err error, want []string) {
+	t.Helper()
+
+	if !errors.Is(err, types.ErrDuplicateToolName) {
+		t.Fatalf("%s error = %v, want %v", call, err, types.ErrDuplicateToolName)
+	}
+	var dupErr *types.DuplicateToolNameError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("%s error = %T, want *types.DuplicateToolNameError", call, err)
+	}
+	if diff := cmp.Diff(want, dupErr.Names); diff != "" {
+		t.Errorf("%s names mismatch (-want +got):\n%s", call, diff)
+	}
+}
+
+// closingToolset is a toolset counting its releases.
+type closingToolset struct {
+	closed int
+}
+
+func (ts *closingToolset) GetTools(*types.ReadOnlyContext) []types.Tool { return nil }
+
+func (ts *closingToolset) Close() { ts.closed++ }
+
+// closeFunc is a resource released by calling the function.
+type closeFunc func() error
+
+func (f closeFunc) Close() error { return f() }
+
+func TestLLMAgent_Close(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	shared := &closingToolset{}
+	errClose := errors.New("close failed")
+	var order []string
+	newAgent := func(name string, closeErr error) *agent.LLMAgent {
+		a, err := agent.NewLLMAgent(ctx, name,
+			agent.WithToolset(shared),
+			agent.WithResources(closeFunc(func() error {
+				order = append(order, name)
+				return closeErr
+			})),
+		)
+		if err != nil {
+			t.Fatalf("NewLLMAgent() error = %v", err)
+		}
+		return a
+	}
+	root := agent.NewLoopAgent("root", newAgent("first", nil), newAgent("second", errClose))
+
+	if err := root.Init(ctx); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := root.Close(); !errors.Is(err, errClose) {
+		t.Errorf("Close() error = %v, want %v", err, errClose)
+	}
+	if shared.closed != 1 {
+		t.Errorf("shared toolset closed %d times, want 1", shared.closed)
+	}
+	if diff := cmp.Diff([]string{"second", "first"}, order); diff != "" {
+		t.Errorf("close order mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWorkflowAgents_Resources(t *testing.T) {
+	t.Parallel()
+
+	type workflowAgent interface {
+		types.ResourceHolder
+		Close() error
+	}
+	tests := map[string]func(resource io.Closer) workflowAgent{
+		"loop": func(resource io.Closer) workflowAgent {
+			return agent.NewLoopAgent("root").WithResources(resource)
+		},
+		"sequential": func(resource io.Closer) workflowAgent {
+			return agent.NewSequentialAgent("root").WithResources(resource)
+		},
+		"parallel": func(resource io.Closer) workflowAgent {
+			return agent.NewParallelAgent("root").WithResources(resource)
+		},
+	}
+	for name, newAgent := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var closed int
+			root := newAgent(closeFunc(func() error {
+				closed++
+				return nil
+			}))
+
+			if got := len(root.Resources()); got != 1 {
+				t.Errorf("Resources() holds %d resources, want 1", got)
+			}
+			if err := root.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if closed != 1 {
+				t.Errorf("resource closed %d times, want 1", closed)
+			}
+		})
+	}
+}
+
+// liveModel is a model connecting to nothing, which may report whether it supports live connections.
+type liveModel struct {
+	summaryModel
+
+	connects int
+}
+
+func (m *liveModel) Connect(context.Context, *types.LLMRequest) (types.ModelConnection, error) {
+	m.connects++
+	return nil, types.NotImplementedError("Connect")
+}
+
+// textOnlyModel is a model without live connections.
+type textOnlyModel struct {
+	liveModel
+}
+
+func (m *textOnlyModel) SupportsLive() bool { return false }
+
+func TestLLMAgent_SupportsLive(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model types.Model
+		want  bool
+	}{
+		"live model":           {model: &liveModel{}, want: true},
+		"text only model":      {model: &textOnlyModel{}, want: false},
+		"traced text only":     {model: model.NewTraced(&textOnlyModel{}, nooptrace.NewTracerProvider().Tracer("")), want: false},
+		"base model of Claude": {model: model.NewBaseLLM("claude-sonnet-4-0"), want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "agent", agent.WithModel(tt.model))
+			if err != nil {
+				t.Fatalf("NewLLMAgent() error = %v", err)
+			}
+			if got := a.SupportsLive(t.Context()); got != tt.want {
+				t.Errorf("SupportsLive() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLLMAgent_ExecuteLive_StreamingFallback(t *testing.T) {
+	t.Parallel()
+
+	m := &textOnlyModel{}
+	a, err := agent.NewLLMAgent(t.Context(), "agent", agent.WithModel(m))
+	if err != nil {
+		t.Fatalf("NewLLMAgent() error = %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	runConfig := &types.RunConfig{StreamingMode: types.StreamingModeBidi}
+	ictx.RunConfig = runConfig
+
+	for _, err := range a.ExecuteLive(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("ExecuteLive() error = %v", err)
+		}
+	}
+	if m.connects != 0 {
+		t.Errorf("model connected %d times, want the streaming fallback", m.connects)
+	}
+	if ictx.RunConfig.StreamingMode != types.StreamingModeSSE {
+		t.Errorf("fallback streaming mode = %v, want %v", ictx.RunConfig.StreamingMode, types.StreamingModeSSE)
+	}
+	if runConfig.StreamingMode != types.StreamingModeBidi {
+		t.Error("fallback modified the run config of the caller")
+	}
+}
+
+func TestLLMAgent_Run_FlowError(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "agent", agent.WithModel(&liveModel{}))
+	if err != nil {
+		t.Fatalf("NewLLMAgent() error = %v", err)
+	}
+	// A function response without its function call fails the build of the history.
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ses.AddEvent(types.NewEvent().
+		WithAuthor("agent").
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			genai.NewPartFromFunctionResponse("lookup", map[string]any{"result": "ok"}),
+		}, genai.RoleUser)))
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	var runErr error
+	for _, err := range a.Run(t.Context(), ictx) {
+		if err != nil {
+			runErr = err
+			break
+		}
+	}
+
+	var flowErr *types.FlowError
+	if !errors.As(runErr, &flowErr) {
+		t.Fatalf("Run() error = %v, want a *types.FlowError", runErr)
+	}
+	if flowErr.Stage != types.FlowStagePreprocess || flowErr.Name != "*llmflow.ContentLLMRequestProcessor" {
+		t.Errorf("FlowError stage = (%s, %s), want (%s, *llmflow.ContentLLMRequestProcessor)", flowErr.Stage, flowErr.Name, types.FlowStagePreprocess)
+	}
+	if flowErr.InvocationID != ictx.InvocationID {
+		t.Errorf("FlowError invocation ID = %s, want %s", flowErr.InvocationID, ictx.InvocationID)
+	}
+}
+
+func TestLLMAgent_FlowOptions(t *testing.T) {
+	t.Parallel()
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	for _, turn := range []struct {
+		author string
+		role   genai.Role
+		text   string
+	}{
+		{"user", genai.RoleUser, "first question"},
+		{"agent", genai.RoleModel, "first answer"},
+		{"user", genai.RoleUser, "first question"},
+	} {
+		ses.AddEvent(types.NewEvent().WithAuthor(turn.author).WithContent(genai.NewContentFromText(turn.text, turn.role)))
+	}
+
+	tests := map[string]struct {
+		opts []agent.LLMAgentOption
+		want []string
+	}{
+		"defaults": {
+			want: []string{"first question", "first answer", "first question"},
+		},
+		"max turns": {
+			opts: []agent.LLMAgentOption{agent.WithMaxTurns(1)},
+			want: []string{"first question"},
+		},
+		"dedupe": {
+			opts: []agent.LLMAgentOption{agent.WithDedupe(len("first question"))},
+			want: []string{"first question", "first answer", llmflow.DedupeMarker},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "agent", append([]agent.LLMAgentOption{agent.WithModel(&liveModel{})}, tt.opts...)...)
+			if err != nil {
+				t.Fatalf("NewLLMAgent() error = %v", err)
+			}
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			request := types.NewLLMRequest(nil)
+			for _, processor := range agent.FlowOf(a).RequestProcessors {
+				for _, err := range processor.Run(t.Context(), ictx, request) {
+					if err != nil {
+						t.Fatalf("%T.Run() error = %v", processor, err)
+					}
+				}
+			}
+			var got []string
+			for _, content := range request.Contents {
+				for _, part := range content.Parts {
+					got = append(got, part.Text)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("request contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLLMAgent_FunctionResponseFormat(t *testing.T) {
+	t.Parallel()
+
+	format := &llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSONString, MaxBytes: 1024}
+	searchFormat := &llmflow.FunctionResponseFormat{MaxBytes: 64}
+	a, err := agent.NewLLMAgent(t.Context(), "agent",
+		agent.WithModel(&liveModel{}),
+		agent.WithFunctionResponseFormat(format),
+		agent.WithToolFunctionResponseFormat("search", searchFormat),
+	)
+	if err != nil {
+		t.Fatalf("NewLLMAgent() error = %v", err)
+	}
+
+	flow := agent.FlowOf(a)
+	if flow.FunctionResponseFormat != format {
+		t.Errorf("flow FunctionResponseFormat = %+v, want %+v", flow.FunctionResponseFormat, format)
+	}
+	if got := flow.ToolFunctionResponseFormats["search"]; got != searchFormat {
+		t.Errorf("flow format of the search tool = %+v, want %+v", got, searchFormat)
+	}
+}
+
+func TestLLMAgent_WithCitations(t *testing.T) {
+	t.Parallel()
+
+	for _, enabled := range []bool{false, true} {
+		a, err := agent.NewLLMAgent(t.Context(), "agent", agent.WithModel(&liveModel{}), agent.WithCitations(enabled))
+		if err != nil {
+			t.Fatalf("NewLLMAgent() error = %v", err)
+		}
+		if got := agent.FlowOf(a).ExtractCitations; got != enabled {
+			t.Errorf("WithCitations(%t): flow ExtractCitations = %t", enabled, got)
+		}
+	}
+}
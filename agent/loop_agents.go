@@ -5,9 +5,10 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"iter"
 
-	"github.com/go-a2a/adk-go/internal/xiter"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -20,9 +21,26 @@ type LoopAgent struct {
 	// If not set, the loop agent will run indefinitely until a sub-agent
 	// escalates.
 	maxIterations int
+
+	// Reports whether the loop should stop after an iteration.
+	stopCondition func(rctx *types.ReadOnlyContext, iteration int) (bool, error)
 }
 
-var _ types.Agent = (*LoopAgent)(nil)
+var (
+	_ types.Agent          = (*LoopAgent)(nil)
+	_ types.ResourceHolder = (*LoopAgent)(nil)
+)
+
+// Keys of the custom metadata of the event emitted when the stop condition of a [LoopAgent] ends the loop.
+const (
+	// LoopStopReasonKey holds why the loop stopped, [LoopStopReasonCondition].
+	LoopStopReasonKey = "loop_stop_reason"
+	// LoopIterationsKey holds the number of completed iterations.
+	LoopIterationsKey = "loop_iterations"
+)
+
+// LoopStopReasonCondition is the [LoopStopReasonKey] of a loop stopped by its stop condition.
+const LoopStopReasonCondition = "stop_condition"
 
 // AsLLMAgent implements [types.Agent].
 func (a *LoopAgent) AsLLMAgent() (types.LLMAgent, bool) {
@@ -35,10 +53,32 @@ func (a *LoopAgent) WithMaxIterations(maxIterations int) *LoopAgent {
 	return a
 }
 
-// NewLoopAgent creates a new loop agent with the given name and options.
-func NewLoopAgent(name string) *LoopAgent {
+// WithStopCondition sets the condition evaluated after each iteration, numbered from 1, that ends
+// the loop when it returns true.
+//
+// The condition reads the state accumulated by the iterations through the read-only context. It
+// composes with the maximum number of iterations and the escalation of a sub-agent, whichever
+// comes first. When the condition ends the loop, the loop agent emits an event whose custom
+// metadata holds [LoopStopReasonKey] and [LoopIterationsKey]. An error of the condition ends the
+// loop with that error.
+func (a *LoopAgent) WithStopCondition(condition func(rctx *types.ReadOnlyContext, iteration int) (bool, error)) *LoopAgent {
+	a.stopCondition = condition
+	return a
+}
+
+// WithResources registers resources of the agent, such as a client shared by its sub-agents,
+// released by [LoopAgent.Close] after the resources of the sub-agents.
+func (a *LoopAgent) WithResources(closers ...io.Closer) *LoopAgent {
+	for _, closer := range closers {
+		a.base.AddResource(closer)
+	}
+	return a
+}
+
+// NewLoopAgent creates a new loop agent with the given name and sub-agents.
+func NewLoopAgent(name string, agents ...types.Agent) *LoopAgent {
 	a := &LoopAgent{
-		base:          types.NewBaseAgent(name),
+		base:          types.NewBaseAgent(name, types.WithSubAgents(agents...)),
 		maxIterations: 10, // Default
 	}
 
@@ -83,7 +123,7 @@ func (a *LoopAgent) Execute(ctx context.Context, ictx *types.InvocationContext)
 			for _, subAgent := range a.base.SubAgents() {
 				for event, err := range subAgent.Run(ctx, ictx) {
 					if err != nil {
-						xiter.Error[types.Event](err)
+						yield(nil, err)
 						return
 					}
 					if !yield(event, nil) {
@@ -94,7 +134,30 @@ func (a *LoopAgent) Execute(ctx context.Context, ictx *types.InvocationContext)
 						return
 					}
 				}
-				timesLooped++
+			}
+			timesLooped++
+
+			if a.stopCondition == nil {
+				continue
+			}
+			stop, err := a.stopCondition(types.NewReadOnlyContext(ictx), timesLooped)
+			if err != nil {
+				yield(nil, fmt.Errorf("loop agent %s: stop condition: %w", a.Name(), err))
+				return
+			}
+			if stop {
+				event := ictx.NewEvent().
+					WithInvocationID(ictx.InvocationID).
+					WithAuthor(a.Name()).
+					WithBranch(ictx.Branch).
+					WithLLMResponse(&types.LLMResponse{
+						CustomMetadata: map[string]any{
+							LoopStopReasonKey: LoopStopReasonCondition,
+							LoopIterationsKey: timesLooped,
+						},
+					})
+				yield(event, nil)
+				return
 			}
 		}
 	}
@@ -103,18 +166,33 @@ func (a *LoopAgent) Execute(ctx context.Context, ictx *types.InvocationContext)
 // ExecuteLive implements [types.Agent].
 func (a *LoopAgent) ExecuteLive(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
-		xiter.Error[types.Event](types.NotImplementedError("ExecuteLive not supported yet for LoopAgent"))
+		yield(nil, types.NotImplementedError("ExecuteLive not supported yet for LoopAgent"))
 	}
 }
 
 // Run implements [types.Agent].
 func (a *LoopAgent) Run(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.Run(ctx, parentContext)
+	return a.base.RunAgent(ctx, a, parentContext)
 }
 
 // RunLive implements [types.Agent].
 func (a *LoopAgent) RunLive(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.RunLive(ctx, parentContext)
+	return a.base.RunLiveAgent(ctx, a, parentContext)
+}
+
+// Resources implements [types.ResourceHolder]: the resources set by [LoopAgent.WithResources].
+func (a *LoopAgent) Resources() []io.Closer {
+	return a.base.Resources()
+}
+
+// Init initializes the resources of the agent and its sub-agents, see [types.InitAgent].
+func (a *LoopAgent) Init(ctx context.Context) error {
+	return types.InitAgent(ctx, a)
+}
+
+// Close releases the resources of the agent and its sub-agents, see [types.CloseAgent].
+func (a *LoopAgent) Close() error {
+	return types.CloseAgent(a)
 }
 
 // RootAgent implements [types.Agent].
@@ -0,0 +1,126 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// refineAgent raises the confidence in session state by 0.25 on each run.
+type refineAgent struct {
+	types.Agent
+}
+
+func (a *refineAgent) Name() string {
+	return "refine"
+}
+
+func (a *refineAgent) ParentAgent() types.Agent {
+	return nil
+}
+
+func (a *refineAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		state := ictx.Session.State()
+		confidence, _ := state["confidence"].(float64)
+		state["confidence"] = confidence + 0.25
+		yield(types.NewEvent().WithAuthor(a.Name()).WithActions(types.NewEventActions()), nil)
+	}
+}
+
+func TestLoopAgent_WithStopCondition(t *testing.T) {
+	t.Parallel()
+
+	converged := func(rctx *types.ReadOnlyContext, iteration int) (bool, error) {
+		confidence, _ := rctx.Lookup("confidence")
+		return confidence.(float64) >= 0.9, nil
+	}
+	errBroken := errors.New("broken")
+
+	tests := map[string]struct {
+		maxIterations  int
+		condition      func(*types.ReadOnlyContext, int) (bool, error)
+		wantIterations int
+		wantStopEvent  bool
+		wantErr        error
+	}{
+		"stops on condition": {
+			maxIterations:  10,
+			condition:      converged,
+			wantIterations: 4,
+			wantStopEvent:  true,
+		},
+		"max iterations first": {
+			maxIterations:  2,
+			condition:      converged,
+			wantIterations: 2,
+		},
+		"no condition": {
+			maxIterations:  3,
+			wantIterations: 3,
+		},
+		"condition error": {
+			maxIterations: 10,
+			condition: func(*types.ReadOnlyContext, int) (bool, error) {
+				return false, errBroken
+			},
+			wantIterations: 1,
+			wantErr:        errBroken,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a := agent.NewLoopAgent("refine_loop", &refineAgent{}).WithMaxIterations(tt.maxIterations)
+			if tt.condition != nil {
+				a.WithStopCondition(tt.condition)
+			}
+			ses := session.NewSession("app", "user", "session", map[string]any{}, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			var (
+				iterations int
+				stopEvent  *types.Event
+				gotErr     error
+			)
+			for event, err := range a.Execute(t.Context(), ictx) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				if event.Author == "refine" {
+					iterations++
+					continue
+				}
+				stopEvent = event
+			}
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Fatalf("Execute error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if iterations != tt.wantIterations {
+				t.Errorf("iterations = %d, want %d", iterations, tt.wantIterations)
+			}
+			if (stopEvent != nil) != tt.wantStopEvent {
+				t.Fatalf("stop event = %v, want %t", stopEvent, tt.wantStopEvent)
+			}
+			if stopEvent != nil {
+				metadata := stopEvent.CustomMetadata
+				if metadata[agent.LoopStopReasonKey] != agent.LoopStopReasonCondition || metadata[agent.LoopIterationsKey] != tt.wantIterations {
+					t.Errorf("stop event metadata = %v", metadata)
+				}
+			}
+		})
+	}
+}
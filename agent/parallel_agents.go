@@ -5,6 +5,8 @@ package agent
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"iter"
 	"sync"
 
@@ -14,6 +16,10 @@ import (
 
 // A shell agent that run its sub-agents in parallel in isolated manner.
 //
+// Each sub-agent runs in its own branch, with a copy of the invocation context. The state keys
+// prefixed with [types.BranchPrefix] are private to the branch and discarded when it ends, while
+// the other keys, including the [types.TempPrefix] ones, are shared by all the branches.
+//
 // This approach is beneficial for scenarios requiring multiple perspectives or
 // attempts on a single task, such as:
 //
@@ -21,9 +27,18 @@ import (
 //   - Generating multiple responses for review by a subsequent evaluation agent.
 type ParallelAgent struct {
 	base *types.BaseAgent
+
+	// Combines the results of all branches into a final event.
+	aggregator Aggregator
+
+	// The maximum number of branches running at once, or zero for no limit.
+	maxConcurrentBranches int
 }
 
-var _ types.Agent = (*ParallelAgent)(nil)
+var (
+	_ types.Agent          = (*ParallelAgent)(nil)
+	_ types.ResourceHolder = (*ParallelAgent)(nil)
+)
 
 // AsLLMAgent implements [types.Agent].
 func (a *ParallelAgent) AsLLMAgent() (types.LLMAgent, bool) {
@@ -37,6 +52,35 @@ func NewParallelAgent(name string, agents ...types.Agent) *ParallelAgent {
 	}
 }
 
+// WithResources registers resources of the agent, such as a client shared by its sub-agents,
+// released by [ParallelAgent.Close] after the resources of the sub-agents.
+func (a *ParallelAgent) WithResources(closers ...io.Closer) *ParallelAgent {
+	for _, closer := range closers {
+		a.base.AddResource(closer)
+	}
+	return a
+}
+
+// WithAggregator sets the aggregator that combines the results of all branches, once they all
+// completed, into a final event emitted after the events of the branches.
+//
+// See [ConcatenateAggregator], [FirstSuccessfulAggregator] and [MajorityVoteAggregator] for common aggregators.
+func (a *ParallelAgent) WithAggregator(aggregator Aggregator) *ParallelAgent {
+	a.aggregator = aggregator
+	return a
+}
+
+// WithMaxConcurrentBranches bounds the number of branches running at once to n, so that a large
+// fan-out does not start all its model sessions together.
+//
+// The branches start in order, each as soon as a running one completes, and their events are
+// still merged as they arrive; the events and the aggregated results are the same as without a
+// bound. Zero or less means no bound, which is the default: all the branches run at once.
+func (a *ParallelAgent) WithMaxConcurrentBranches(n int) *ParallelAgent {
+	a.maxConcurrentBranches = max(n, 0)
+	return a
+}
+
 // Name implements [types.Agent].
 func (a *ParallelAgent) Name() string {
 	return a.base.Name()
@@ -71,13 +115,83 @@ func (a *ParallelAgent) AfterAgentCallbacks() []types.AgentCallback {
 func (a *ParallelAgent) Execute(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	ictx = a.setBranchForCurrentAgent(a, ictx)
 
+	var (
+		mu            sync.Mutex
+		branchResults map[string][]*types.Event
+	)
+	if a.aggregator != nil {
+		branchResults = make(map[string][]*types.Event, len(a.base.SubAgents()))
+	}
+
 	agentRuns := make([]iter.Seq2[*types.Event, error], len(a.base.SubAgents()))
 	for i, subAgent := range a.base.SubAgents() {
-		agentRuns[i] = subAgent.Run(ctx, ictx)
+		branchCtx := ictx.ForkBranch()
+		agentRuns[i] = isolateBranchState(subAgent.Run(ctx, branchCtx), branchCtx)
+		if a.aggregator != nil {
+			agentRuns[i] = collectBranch(agentRuns[i], subAgent.Name(), &mu, branchResults)
+		}
+	}
+
+	return func(yield func(*types.Event, error) bool) {
+		for event, err := range mergeAgentRun(ctx, agentRuns, a.maxConcurrentBranches) {
+			if !yield(event, err) {
+				return
+			}
+		}
+		if a.aggregator == nil {
+			return
+		}
+
+		event, err := a.aggregator(ctx, branchResults)
+		if err != nil {
+			yield(nil, fmt.Errorf("aggregate branches of %s: %w", a.Name(), err))
+			return
+		}
+		if event == nil {
+			return
+		}
+		if event.ID == "" {
+			event.ID = ictx.NewEventID()
+		}
+		if event.Timestamp.IsZero() {
+			event.Timestamp = ictx.Now()
+		}
+		if event.InvocationID == "" {
+			event.InvocationID = ictx.InvocationID
+		}
+		if event.Author == "" {
+			event.Author = a.Name()
+		}
+		if event.Branch == "" {
+			event.Branch = ictx.Branch
+		}
+		yield(event, nil)
 	}
+}
 
+// isolateBranchState moves the branch-scoped keys of the state deltas of the branch run to the
+// branch-scoped state of its invocation context, so that they are neither persisted nor seen by
+// the other branches.
+func isolateBranchState(run iter.Seq2[*types.Event, error], branchCtx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
-		for event, err := range MergeAgentRun(ctx, agentRuns) {
+		for event, err := range run {
+			branchCtx.ApplyBranchDelta(event)
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// collectBranch records the final events of the branch run into branchResults, under the name of its sub-agent.
+func collectBranch(run iter.Seq2[*types.Event, error], name string, mu *sync.Mutex, branchResults map[string][]*types.Event) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		for event, err := range run {
+			if err == nil && event != nil && (event.LLMResponse == nil || !event.Partial) {
+				mu.Lock()
+				branchResults[name] = append(branchResults[name], event)
+				mu.Unlock()
+			}
 			if !yield(event, err) {
 				return
 			}
@@ -92,12 +206,27 @@ func (a *ParallelAgent) ExecuteLive(ctx context.Context, ictx *types.InvocationC
 
 // Run implements [types.Agent].
 func (a *ParallelAgent) Run(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.Run(ctx, parentContext)
+	return a.base.RunAgent(ctx, a, parentContext)
 }
 
 // RunLive implements [types.Agent].
 func (a *ParallelAgent) RunLive(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.RunLive(ctx, parentContext)
+	return a.base.RunLiveAgent(ctx, a, parentContext)
+}
+
+// Resources implements [types.ResourceHolder]: the resources set by [ParallelAgent.WithResources].
+func (a *ParallelAgent) Resources() []io.Closer {
+	return a.base.Resources()
+}
+
+// Init initializes the resources of the agent and its sub-agents, see [types.InitAgent].
+func (a *ParallelAgent) Init(ctx context.Context) error {
+	return types.InitAgent(ctx, a)
+}
+
+// Close releases the resources of the agent and its sub-agents, see [types.CloseAgent].
+func (a *ParallelAgent) Close() error {
+	return types.CloseAgent(a)
 }
 
 // RootAgent implements [types.Agent].
@@ -137,6 +266,12 @@ type eventResult struct {
 // This implementation guarantees for each agent, it won't move on until the
 // generated event is processed by upstream runner.
 func MergeAgentRun(ctx context.Context, agentRuns []iter.Seq2[*types.Event, error]) iter.Seq2[*types.Event, error] {
+	return mergeAgentRun(ctx, agentRuns, 0)
+}
+
+// mergeAgentRun merges the agent runs as [MergeAgentRun], running at most limit of them at once,
+// or all of them if limit is zero or less.
+func mergeAgentRun(ctx context.Context, agentRuns []iter.Seq2[*types.Event, error], limit int) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
 		// Handle empty case
 		if len(agentRuns) == 0 {
@@ -148,25 +283,44 @@ func MergeAgentRun(ctx context.Context, agentRuns []iter.Seq2[*types.Event, erro
 
 		eventCh := make(chan eventResult)
 		wg := new(sync.WaitGroup)
+		// The slots of the running agents, if limited.
+		var slots chan struct{}
+		if limit > 0 && limit < len(agentRuns) {
+			slots = make(chan struct{}, limit)
+		}
 
-		// Start goroutine for each agent
-		for i, agentRun := range agentRuns {
-			wg.Add(1)
-			go func(agentID int, run iter.Seq2[*types.Event, error]) {
-				defer wg.Done()
-				for event, err := range run {
+		// Start goroutine for each agent, in order, each once a slot is free if limited.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i, agentRun := range agentRuns {
+				if slots != nil {
 					select {
-					case eventCh <- eventResult{
-						event:   event,
-						err:     err,
-						agentID: agentID,
-					}:
+					case slots <- struct{}{}:
 					case <-ctx.Done():
 						return
 					}
 				}
-			}(i, agentRun)
-		}
+				wg.Add(1)
+				go func(agentID int, run iter.Seq2[*types.Event, error]) {
+					defer wg.Done()
+					if slots != nil {
+						defer func() { <-slots }()
+					}
+					for event, err := range run {
+						select {
+						case eventCh <- eventResult{
+							event:   event,
+							err:     err,
+							agentID: agentID,
+						}:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}(i, agentRun)
+			}
+		}()
 
 		// Close eventCh when all agents complete
 		go func() {
@@ -6,9 +6,14 @@ package agent_test
 import (
 	"context"
 	"iter"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -49,3 +54,234 @@ func Test_mergeAgentRun(t *testing.T) {
 		t.Errorf("expected 3 events, got %d", len(events))
 	}
 }
+
+// branchStateAgent writes its name to the same branch-scoped keys as its siblings, waits for all
+// of them to write, and records what it reads back.
+type branchStateAgent struct {
+	types.Agent
+
+	name    string
+	written *sync.WaitGroup
+
+	mu   *sync.Mutex
+	seen map[string][2]any
+}
+
+func (a *branchStateAgent) Name() string {
+	return a.name
+}
+
+func (a *branchStateAgent) ParentAgent() types.Agent {
+	return nil
+}
+
+func (a *branchStateAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		cc := types.NewCallbackContext(ictx)
+		cc.SetBranchState("owner", a.name)
+
+		actions := types.NewEventActions()
+		actions.StateDelta[types.BranchPrefix+"delta_owner"] = a.name
+		if !yield(types.NewEvent().WithAuthor(a.name).WithActions(actions), nil) {
+			return
+		}
+
+		a.written.Done()
+		a.written.Wait()
+
+		rctx := types.NewReadOnlyContext(ictx)
+		owner, _ := rctx.BranchState("owner")
+		deltaOwner, _ := types.NewCallbackContext(ictx).State().Get(types.BranchPrefix + "delta_owner")
+
+		a.mu.Lock()
+		a.seen[a.name] = [2]any{owner, deltaOwner}
+		a.mu.Unlock()
+	}
+}
+
+func TestParallelAgent_BranchState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		written sync.WaitGroup
+		mu      sync.Mutex
+	)
+	seen := make(map[string][2]any)
+	names := []string{"left", "right"}
+	written.Add(len(names))
+	subAgents := make([]types.Agent, len(names))
+	for i, name := range names {
+		subAgents[i] = &branchStateAgent{name: name, written: &written, mu: &mu, seen: seen}
+	}
+
+	a := agent.NewParallelAgent("parallel", subAgents...)
+	ses := session.NewSession("app", "user", "session", map[string]any{}, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	ictx.SetBranchState("owner", "parent")
+
+	for event, err := range a.Execute(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("Execute error = %v", err)
+		}
+		for key := range event.Actions.StateDelta {
+			if strings.HasPrefix(key, types.BranchPrefix) {
+				t.Errorf("event of %s: branch-scoped key %q in the state delta", event.Author, key)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if got, want := seen[name], [2]any{name, name}; got != want {
+			t.Errorf("branch %s read %v, want %v", name, got, want)
+		}
+	}
+	if owner, _ := ictx.BranchState("owner"); owner != "parent" {
+		t.Errorf("parent BranchState(owner) = %v, want %q", owner, "parent")
+	}
+	for key := range ses.State() {
+		if strings.HasPrefix(key, types.BranchPrefix) {
+			t.Errorf("branch-scoped key %q persisted to the session", key)
+		}
+	}
+}
+
+// concurrencyAgent records the number of its siblings running along with it, and its start order.
+type concurrencyAgent struct {
+	types.Agent
+
+	name string
+
+	mu      *sync.Mutex
+	running *int
+	maxSeen *int
+	started *[]string
+}
+
+func (a *concurrencyAgent) Name() string {
+	return a.name
+}
+
+func (a *concurrencyAgent) ParentAgent() types.Agent {
+	return nil
+}
+
+func (a *concurrencyAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		a.mu.Lock()
+		*a.running++
+		*a.maxSeen = max(*a.maxSeen, *a.running)
+		*a.started = append(*a.started, a.name)
+		a.mu.Unlock()
+		defer func() {
+			a.mu.Lock()
+			*a.running--
+			a.mu.Unlock()
+		}()
+
+		time.Sleep(5 * time.Millisecond)
+		yield(types.NewEvent().WithAuthor(a.name), nil)
+	}
+}
+
+func TestParallelAgent_WithMaxConcurrentBranches(t *testing.T) {
+	t.Parallel()
+
+	const limit = 2
+	var (
+		mu               sync.Mutex
+		running, maxSeen int
+		started          []string
+	)
+	names := []string{"a", "b", "c", "d", "e", "f"}
+	subAgents := make([]types.Agent, len(names))
+	for i, name := range names {
+		subAgents[i] = &concurrencyAgent{name: name, mu: &mu, running: &running, maxSeen: &maxSeen, started: &started}
+	}
+
+	a := agent.NewParallelAgent("parallel", subAgents...).WithMaxConcurrentBranches(limit)
+	ses := session.NewSession("app", "user", "session", map[string]any{}, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	var authors []string
+	for event, err := range a.Execute(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("Execute error = %v", err)
+		}
+		authors = append(authors, event.Author)
+	}
+
+	slices.Sort(authors)
+	if !slices.Equal(authors, names) {
+		t.Errorf("Execute yielded events of %v, want one event per branch", authors)
+	}
+	if maxSeen > limit {
+		t.Errorf("%d branches ran at once, want at most %d", maxSeen, limit)
+	}
+	// The later branches wait for the first ones.
+	first := slices.Sorted(slices.Values(started[:limit]))
+	if !slices.Equal(first, names[:limit]) {
+		t.Errorf("first branches started = %v, want %v", first, names[:limit])
+	}
+}
+
+// replyAgent replies with a fixed text.
+type replyAgent struct {
+	types.Agent
+
+	name string
+	text string
+}
+
+func (a *replyAgent) Name() string {
+	return a.name
+}
+
+func (a *replyAgent) ParentAgent() types.Agent {
+	return nil
+}
+
+func (a *replyAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		yield(textEvent(a.name, a.text), nil)
+	}
+}
+
+func TestParallelAgent_WithAggregator(t *testing.T) {
+	t.Parallel()
+
+	a := agent.NewParallelAgent("parallel",
+		&replyAgent{name: "a", text: "yes"},
+		&replyAgent{name: "b", text: "no"},
+	).WithAggregator(agent.ConcatenateAggregator(", "))
+	ses := session.NewSession("app", "user", "session", map[string]any{}, time.Now())
+	clock := types.NewFakeClock(time.Unix(0, 0), time.Second)
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(),
+		types.WithClock(clock),
+		types.WithIDGenerator(types.NewSequentialIDGenerator()),
+	)
+
+	var last *types.Event
+	for event, err := range a.Execute(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("Execute error = %v", err)
+		}
+		last = event
+	}
+
+	if last == nil || last.Author != "parallel" {
+		t.Fatalf("last event = %+v, want the aggregated event of parallel", last)
+	}
+	// The aggregated event is created from the invocation context.
+	if !strings.HasPrefix(last.ID, "event-") {
+		t.Errorf("aggregated event ID = %q, want an ID from the invocation ID generator", last.ID)
+	}
+	if last.InvocationID != ictx.InvocationID {
+		t.Errorf("aggregated event InvocationID = %q, want %q", last.InvocationID, ictx.InvocationID)
+	}
+	if last.Timestamp.Before(time.Unix(0, 0)) || last.Timestamp.After(clock.Now()) {
+		t.Errorf("aggregated event Timestamp = %v, want a time of the invocation clock", last.Timestamp)
+	}
+	if got, want := last.Content.Parts[0].Text, "yes, no"; got != want {
+		t.Errorf("aggregated text = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// Aggregator combines the results of the branches of a [ParallelAgent] into a final event.
+//
+// branchResults holds the final events of each branch, in emission order, keyed by the name of
+// the sub-agent running the branch. Branches that emitted no event are absent.
+// The aggregator returns nil to emit no final event. The author, branch and invocation ID of the
+// returned event default to those of the parallel agent, and its ID and timestamp to new ones from
+// the invocation context.
+type Aggregator func(ctx context.Context, branchResults map[string][]*types.Event) (*types.Event, error)
+
+// ErrNoBranchResult is returned by the aggregators when no branch produced a usable result.
+var ErrNoBranchResult = errors.New("no branch produced a result")
+
+// ConcatenateAggregator returns an [Aggregator] joining the final text of each branch with
+// separator, in the order of the branch names.
+func ConcatenateAggregator(separator string) Aggregator {
+	return func(ctx context.Context, branchResults map[string][]*types.Event) (*types.Event, error) {
+		var texts []string
+		for _, name := range slices.Sorted(maps.Keys(branchResults)) {
+			if text, ok := branchText(branchResults[name]); ok {
+				texts = append(texts, text)
+			}
+		}
+		if len(texts) == 0 {
+			return nil, ErrNoBranchResult
+		}
+
+		return aggregatedEvent(strings.Join(texts, separator)), nil
+	}
+}
+
+// FirstSuccessfulAggregator returns an [Aggregator] keeping the final text of the first branch,
+// in the order of the branch names, that produced a text without error.
+//
+// Name the sub-agents so that their names sort in order of preference.
+func FirstSuccessfulAggregator() Aggregator {
+	return func(ctx context.Context, branchResults map[string][]*types.Event) (*types.Event, error) {
+		for _, name := range slices.Sorted(maps.Keys(branchResults)) {
+			if text, ok := branchText(branchResults[name]); ok {
+				return aggregatedEvent(text), nil
+			}
+		}
+
+		return nil, ErrNoBranchResult
+	}
+}
+
+// MajorityVoteAggregator returns an [Aggregator] keeping the final text produced by the most branches.
+//
+// Texts are compared after trimming surrounding spaces. A tie goes to the text of the first
+// branch, in the order of the branch names.
+func MajorityVoteAggregator() Aggregator {
+	return func(ctx context.Context, branchResults map[string][]*types.Event) (*types.Event, error) {
+		var (
+			votes = make(map[string]int)
+			texts []string // in the order of the branch names
+		)
+		for _, name := range slices.Sorted(maps.Keys(branchResults)) {
+			text, ok := branchText(branchResults[name])
+			if !ok {
+				continue
+			}
+			text = strings.TrimSpace(text)
+			votes[text]++
+			texts = append(texts, text)
+		}
+		if len(texts) == 0 {
+			return nil, ErrNoBranchResult
+		}
+
+		// The votes are all counted before picking the winner, so that a tie goes to the first
+		// branch rather than to the text reaching the count first.
+		winner := texts[0]
+		for _, text := range texts[1:] {
+			if votes[text] > votes[winner] {
+				winner = text
+			}
+		}
+
+		return aggregatedEvent(winner), nil
+	}
+}
+
+// branchText returns the text of the last event of the branch with a text, and false if the
+// branch produced no text or ended with an error response.
+func branchText(events []*types.Event) (string, bool) {
+	for _, event := range slices.Backward(events) {
+		if event.LLMResponse == nil {
+			continue
+		}
+		if event.ErrorCode != "" {
+			return "", false
+		}
+		if text := contentText(event.Content); text != "" {
+			return text, true
+		}
+	}
+
+	return "", false
+}
+
+// aggregatedEvent returns the final event carrying the aggregated text. Its ID and timestamp are
+// set by the [ParallelAgent] from the invocation context.
+func aggregatedEvent(text string) *types.Event {
+	return (&types.Event{}).WithContent(genai.NewContentFromText(text, genai.RoleModel))
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func errorEvent(code string) *types.Event {
+	return types.NewEvent().WithLLMResponse(&types.LLMResponse{ErrorCode: code, ErrorMessage: "failed"})
+}
+
+func TestAggregators(t *testing.T) {
+	t.Parallel()
+
+	branches := map[string][]*types.Event{
+		"a_fast":   {textEvent("model", "draft"), textEvent("model", "42")},
+		"b_broken": {textEvent("model", "partial answer"), errorEvent("INTERNAL")},
+		"c_slow":   {textEvent("model", " 41 ")},
+		"d_alt":    {textEvent("model", "41")},
+		"e_silent": {types.NewEvent()},
+	}
+
+	tests := map[string]struct {
+		aggregator agent.Aggregator
+		branches   map[string][]*types.Event
+		want       string
+		wantErr    error
+	}{
+		"concatenate": {
+			aggregator: agent.ConcatenateAggregator("\n"),
+			branches:   branches,
+			want:       "42\n 41 \n41",
+		},
+		"first successful": {
+			aggregator: agent.FirstSuccessfulAggregator(),
+			branches:   branches,
+			want:       "42",
+		},
+		"first successful skips failed branch": {
+			aggregator: agent.FirstSuccessfulAggregator(),
+			branches:   map[string][]*types.Event{"a": {errorEvent("INTERNAL")}, "b": {textEvent("model", "ok")}},
+			want:       "ok",
+		},
+		"majority vote": {
+			aggregator: agent.MajorityVoteAggregator(),
+			branches:   branches,
+			want:       "41",
+		},
+		"majority vote tie": {
+			aggregator: agent.MajorityVoteAggregator(),
+			branches:   map[string][]*types.Event{"b": {textEvent("model", "yes")}, "a": {textEvent("model", "no")}},
+			want:       "no",
+		},
+		"majority vote tie after the first branch": {
+			aggregator: agent.MajorityVoteAggregator(),
+			branches: map[string][]*types.Event{
+				"a": {textEvent("model", "x")},
+				"b": {textEvent("model", "y")},
+				"c": {textEvent("model", "y")},
+				"d": {textEvent("model", "x")},
+			},
+			want: "x",
+		},
+		"no result": {
+			aggregator: agent.MajorityVoteAggregator(),
+			branches:   map[string][]*types.Event{"a": {errorEvent("INTERNAL")}},
+			wantErr:    agent.ErrNoBranchResult,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			event, err := tt.aggregator(t.Context(), tt.branches)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("aggregator error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := event.Content.Parts[0].Text; got != tt.want {
+				t.Errorf("aggregated text = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// RunToCompletion runs the agent until it completes, and returns the text of its final answer
+// along with all the events of the run, for callers that do not need to consume the event stream.
+//
+// Each event is appended to the session with the session service of the invocation context, if
+// any, so that its state delta is applied before the next event is produced. The final answer is
+// the text of the last final response, so that the intermediate tool calls and responses are
+// skipped; the thoughts of the model are left out.
+//
+// It stops at the first error of the run, and returns it with the events received until then.
+func RunToCompletion(ctx context.Context, agent types.Agent, ictx *types.InvocationContext) (string, []*types.Event, error) {
+	var (
+		answer string
+		events []*types.Event
+	)
+	for event, err := range agent.Run(ctx, ictx) {
+		if err != nil {
+			return answer, events, err
+		}
+		if event == nil {
+			continue
+		}
+
+		if ictx.SessionService != nil && ictx.Session != nil {
+			if _, err := ictx.SessionService.AppendEvent(ctx, ictx.Session, event); err != nil {
+				return answer, events, fmt.Errorf("append event of %s: %w", event.Author, err)
+			}
+		}
+		events = append(events, event)
+
+		if text, ok := event.FinalText(); ok {
+			answer = text
+		}
+	}
+
+	return answer, events, nil
+}
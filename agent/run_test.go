@@ -0,0 +1,122 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// scriptedAgent yields the events returned by its script, then its error if any.
+type scriptedAgent struct {
+	types.Agent
+
+	events []*types.Event
+	err    error
+}
+
+func (a *scriptedAgent) Name() string {
+	return "scripted"
+}
+
+func (a *scriptedAgent) ParentAgent() types.Agent {
+	return nil
+}
+
+func (a *scriptedAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		for _, event := range a.events {
+			if !yield(event, nil) {
+				return
+			}
+		}
+		if a.err != nil {
+			yield(nil, a.err)
+		}
+	}
+}
+
+func TestRunToCompletion(t *testing.T) {
+	t.Parallel()
+
+	modelEvent := func(parts ...*genai.Part) *types.Event {
+		return types.NewEvent().
+			WithAuthor("scripted").
+			WithContent(genai.NewContentFromParts(parts, genai.RoleModel)).
+			WithActions(types.NewEventActions())
+	}
+	callEvent := modelEvent(genai.NewPartFromFunctionCall("lookup", map[string]any{"q": "x"}))
+	responseEvent := types.NewEvent().
+		WithAuthor("scripted").
+		WithContent(genai.NewContentFromFunctionResponse("lookup", map[string]any{"result": "y"}, genai.RoleUser)).
+		WithActions(types.NewEventActions())
+	answerEvent := modelEvent(&genai.Part{Text: "thinking", Thought: true}, genai.NewPartFromText("The answer "), genai.NewPartFromText("is y."))
+	answerEvent.Actions.StateDelta["answered"] = true
+	stateEvent := types.NewEvent().WithAuthor("scripted").WithActions(types.NewEventActions())
+	errBroken := errors.New("broken")
+
+	tests := map[string]struct {
+		agent      *scriptedAgent
+		wantAnswer string
+		wantEvents int
+		wantErr    error
+	}{
+		"skips the tool round-trip": {
+			agent:      &scriptedAgent{events: []*types.Event{callEvent, responseEvent, answerEvent, stateEvent}},
+			wantAnswer: "The answer is y.",
+			wantEvents: 4,
+		},
+		"no answer": {
+			agent:      &scriptedAgent{events: []*types.Event{callEvent, responseEvent}},
+			wantEvents: 2,
+		},
+		"error": {
+			agent:      &scriptedAgent{events: []*types.Event{callEvent}, err: errBroken},
+			wantEvents: 1,
+			wantErr:    errBroken,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := session.NewInMemoryService()
+			ses, err := service.CreateSession(t.Context(), "app", "user", "session", map[string]any{})
+			if err != nil {
+				t.Fatalf("CreateSession error = %v", err)
+			}
+			ictx := types.NewInvocationContext(tt.agent, ses, service)
+
+			answer, events, err := agent.RunToCompletion(t.Context(), tt.agent, ictx)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunToCompletion error = %v, want %v", err, tt.wantErr)
+			}
+			if answer != tt.wantAnswer {
+				t.Errorf("RunToCompletion answer = %q, want %q", answer, tt.wantAnswer)
+			}
+			if len(events) != tt.wantEvents {
+				t.Errorf("RunToCompletion returned %d events, want %d", len(events), tt.wantEvents)
+			}
+
+			stored, err := service.GetSession(t.Context(), "app", "user", "session", nil)
+			if err != nil {
+				t.Fatalf("GetSession error = %v", err)
+			}
+			if got := len(stored.Events()); got != tt.wantEvents {
+				t.Errorf("stored %d events, want %d", got, tt.wantEvents)
+			}
+			if _, answered := stored.State()["answered"]; answered != (tt.wantAnswer != "") {
+				t.Errorf("state delta applied = %t, want %t", answered, tt.wantAnswer != "")
+			}
+		})
+	}
+}
@@ -5,7 +5,9 @@ package agent
 
 import (
 	"context"
+	"io"
 	"iter"
+	"maps"
 	"reflect"
 	"runtime"
 	"strings"
@@ -18,9 +20,15 @@ type SequentialAgent struct {
 	base *types.BaseAgent
 
 	agents []types.Agent
+
+	// Transforms or drops the events flowing out of each sub-agent.
+	stageFilter func(event *types.Event) (*types.Event, bool)
 }
 
-var _ types.Agent = (*SequentialAgent)(nil)
+var (
+	_ types.Agent          = (*SequentialAgent)(nil)
+	_ types.ResourceHolder = (*SequentialAgent)(nil)
+)
 
 // AsLLMAgent implements [types.Agent].
 func (a *SequentialAgent) AsLLMAgent() (types.LLMAgent, bool) {
@@ -33,6 +41,27 @@ func (a *SequentialAgent) WithAgents(agents ...types.Agent) *SequentialAgent {
 	return a
 }
 
+// WithStageFilter sets the filter applied to the events flowing out of each sub-agent, before the
+// following sub-agents see them.
+//
+// The filter returns the event to forward, which may be the given event, a modified one or a new
+// one, and false to drop it. Errors are not filtered. The filter cannot discard state changes:
+// the state and artifact deltas of a dropped event are still forwarded on an event without content,
+// and the deltas of a rewritten event are kept unless the rewritten event sets them itself.
+func (a *SequentialAgent) WithStageFilter(filter func(event *types.Event) (*types.Event, bool)) *SequentialAgent {
+	a.stageFilter = filter
+	return a
+}
+
+// WithResources registers resources of the agent, such as a client shared by its sub-agents,
+// released by [SequentialAgent.Close] after the resources of the sub-agents.
+func (a *SequentialAgent) WithResources(closers ...io.Closer) *SequentialAgent {
+	for _, closer := range closers {
+		a.base.AddResource(closer)
+	}
+	return a
+}
+
 // NewSequentialAgent creates a new sequential agent with the given name and options.
 func NewSequentialAgent(name string) *SequentialAgent {
 	return &SequentialAgent{
@@ -75,6 +104,12 @@ func (a *SequentialAgent) Execute(ctx context.Context, ictx *types.InvocationCon
 	return func(yield func(*types.Event, error) bool) {
 		for _, subAgent := range a.base.SubAgents() {
 			for event, err := range subAgent.Run(ctx, ictx) {
+				if err == nil && a.stageFilter != nil {
+					var ok bool
+					if event, ok = applyStageFilter(a.stageFilter, event); !ok {
+						continue
+					}
+				}
 				if !yield(event, err) {
 					return
 				}
@@ -83,6 +118,57 @@ func (a *SequentialAgent) Execute(ctx context.Context, ictx *types.InvocationCon
 	}
 }
 
+// applyStageFilter applies the stage filter to the event, keeping its state and artifact deltas.
+//
+// It reports false if the event is dropped and has no delta to forward.
+func applyStageFilter(filter func(event *types.Event) (*types.Event, bool), event *types.Event) (*types.Event, bool) {
+	var (
+		stateDelta    map[string]any
+		artifactDelta map[string]int
+	)
+	if event.Actions != nil {
+		stateDelta, artifactDelta = maps.Clone(event.Actions.StateDelta), maps.Clone(event.Actions.ArtifactDelta)
+	}
+
+	filtered, ok := filter(event)
+	if !ok || filtered == nil {
+		if len(stateDelta) == 0 && len(artifactDelta) == 0 {
+			return nil, false
+		}
+		filtered = &types.Event{
+			LLMResponse:  &types.LLMResponse{},
+			InvocationID: event.InvocationID,
+			Author:       event.Author,
+			Actions:      types.NewEventActions(),
+			Branch:       event.Branch,
+			ID:           event.ID,
+			Timestamp:    event.Timestamp,
+		}
+	}
+
+	if filtered.Actions == nil {
+		filtered.Actions = types.NewEventActions()
+	}
+	for key, value := range stateDelta {
+		if _, ok := filtered.Actions.StateDelta[key]; !ok {
+			if filtered.Actions.StateDelta == nil {
+				filtered.Actions.StateDelta = make(map[string]any)
+			}
+			filtered.Actions.StateDelta[key] = value
+		}
+	}
+	for key, value := range artifactDelta {
+		if _, ok := filtered.Actions.ArtifactDelta[key]; !ok {
+			if filtered.Actions.ArtifactDelta == nil {
+				filtered.Actions.ArtifactDelta = make(map[string]int)
+			}
+			filtered.Actions.ArtifactDelta[key] = value
+		}
+	}
+
+	return filtered, true
+}
+
 // taskCompleted signals that the model has successfully completed the user's question
 // or task.
 func taskCompleted() string {
@@ -127,6 +213,12 @@ func (a *SequentialAgent) ExecuteLive(ctx context.Context, ictx *types.Invocatio
 
 		for _, subAgent := range a.base.SubAgents() {
 			for event, err := range subAgent.RunLive(ctx, ictx) {
+				if err == nil && a.stageFilter != nil {
+					var ok bool
+					if event, ok = applyStageFilter(a.stageFilter, event); !ok {
+						continue
+					}
+				}
 				if !yield(event, err) {
 					return
 				}
@@ -137,12 +229,27 @@ func (a *SequentialAgent) ExecuteLive(ctx context.Context, ictx *types.Invocatio
 
 // Run implements [types.Agent].
 func (a *SequentialAgent) Run(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.Run(ctx, parentContext)
+	return a.base.RunAgent(ctx, a, parentContext)
 }
 
 // RunLive implements [types.Agent].
 func (a *SequentialAgent) RunLive(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
-	return a.base.RunLive(ctx, parentContext)
+	return a.base.RunLiveAgent(ctx, a, parentContext)
+}
+
+// Resources implements [types.ResourceHolder]: the resources set by [SequentialAgent.WithResources].
+func (a *SequentialAgent) Resources() []io.Closer {
+	return a.base.Resources()
+}
+
+// Init initializes the resources of the agent and its sub-agents, see [types.InitAgent].
+func (a *SequentialAgent) Init(ctx context.Context) error {
+	return types.InitAgent(ctx, a)
+}
+
+// Close releases the resources of the agent and its sub-agents, see [types.CloseAgent].
+func (a *SequentialAgent) Close() error {
+	return types.CloseAgent(a)
 }
 
 // RootAgent implements [types.Agent].
@@ -0,0 +1,110 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestApplyStageFilter(t *testing.T) {
+	t.Parallel()
+
+	dropToolCalls := func(event *types.Event) (*types.Event, bool) {
+		return event, len(event.GetFunctionCalls()) == 0
+	}
+	rewrite := func(event *types.Event) (*types.Event, bool) {
+		rewritten := types.NewEvent().
+			WithAuthor(event.Author).
+			WithContent(genai.NewContentFromText("rewritten", genai.RoleModel)).
+			WithActions(types.NewEventActions().WithStateDelta(map[string]any{"draft": "rewritten"}))
+		return rewritten, true
+	}
+
+	toolCall := genai.NewContentFromFunctionCall("search", map[string]any{"q": "go"}, genai.RoleModel)
+
+	tests := map[string]struct {
+		filter       func(*types.Event) (*types.Event, bool)
+		event        *types.Event
+		wantOK       bool
+		wantText     string
+		wantContent  bool
+		wantState    map[string]any
+		wantArtifact map[string]int
+	}{
+		"kept": {
+			filter:      dropToolCalls,
+			event:       types.NewEvent().WithAuthor("writer").WithContent(genai.NewContentFromText("draft", genai.RoleModel)),
+			wantOK:      true,
+			wantText:    "draft",
+			wantContent: true,
+			wantState:   map[string]any{},
+		},
+		"dropped": {
+			filter: dropToolCalls,
+			event:  types.NewEvent().WithAuthor("writer").WithContent(toolCall),
+			wantOK: false,
+		},
+		"dropped with deltas": {
+			filter: dropToolCalls,
+			event: types.NewEvent().WithAuthor("writer").WithContent(toolCall).WithActions(
+				types.NewEventActions().
+					WithStateDelta(map[string]any{"step": 1}).
+					WithArtifactDelta(map[string]int{"report.md": 2}),
+			),
+			wantOK:       true,
+			wantState:    map[string]any{"step": 1},
+			wantArtifact: map[string]int{"report.md": 2},
+		},
+		"rewritten keeps original deltas": {
+			filter: rewrite,
+			event: types.NewEvent().WithAuthor("writer").WithContent(genai.NewContentFromText("draft", genai.RoleModel)).WithActions(
+				types.NewEventActions().WithStateDelta(map[string]any{"draft": "original", "step": 1}),
+			),
+			wantOK:      true,
+			wantText:    "rewritten",
+			wantContent: true,
+			wantState:   map[string]any{"draft": "rewritten", "step": 1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := agent.ApplyStageFilter(tt.filter, tt.event)
+			if ok != tt.wantOK {
+				t.Fatalf("ApplyStageFilter() ok = %t, want %t", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+
+			if got.Author != tt.event.Author {
+				t.Errorf("Author = %q, want %q", got.Author, tt.event.Author)
+			}
+			if hasContent := got.Content != nil; hasContent != tt.wantContent {
+				t.Fatalf("has content = %t, want %t", hasContent, tt.wantContent)
+			}
+			if tt.wantContent {
+				if diff := cmp.Diff(tt.wantText, got.Content.Parts[0].Text); diff != "" {
+					t.Errorf("text mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tt.wantState, got.Actions.StateDelta); diff != "" {
+				t.Errorf("StateDelta mismatch (-want +got):\n%s", diff)
+			}
+			if len(tt.wantArtifact) > 0 {
+				if diff := cmp.Diff(tt.wantArtifact, got.Actions.ArtifactDelta); diff != "" {
+					t.Errorf("ArtifactDelta mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/internal/xiter"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultSummaryMaxWords is the default target length of the summary produced by a [SummarizerAgent].
+const DefaultSummaryMaxWords = 200
+
+// SummarizerAgent is a pipeline stage that condenses the conversation so far into a single summary event.
+//
+// It reads the recent events of its branch, asks the model to summarize them and emits the summary
+// as a model event, which the following stages of a [SequentialAgent] receive instead of the full
+// output of the previous stages when [WithReplaceHistory] is set.
+type SummarizerAgent struct {
+	base *types.BaseAgent
+
+	// The model used to summarize.
+	model types.Model
+
+	// Target length of the summary, in words.
+	maxWords int
+
+	// What the summary should focus on.
+	focus string
+
+	// Instruction replacing the default summarization instruction.
+	instruction string
+
+	// Number of most recent turns to summarize. Zero means all.
+	lastTurns int
+
+	// Reports whether an event marks the start of the events to summarize.
+	sinceMarker func(event *types.Event) bool
+
+	// Whether the summary replaces the summarized events in later model requests.
+	replaceHistory bool
+
+	// The key in session state to store the summary.
+	outputKey string
+}
+
+var _ types.Agent = (*SummarizerAgent)(nil)
+
+// SummarizerOption configures a [SummarizerAgent].
+type SummarizerOption func(*SummarizerAgent)
+
+// WithSummaryDescription sets the description of the summarizer agent.
+func WithSummaryDescription(description string) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.base.Config.Description = description
+	}
+}
+
+// WithSummaryMaxWords sets the target length of the summary, in words.
+//
+// The default is [DefaultSummaryMaxWords].
+func WithSummaryMaxWords(n int) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.maxWords = n
+	}
+}
+
+// WithSummaryFocus sets what the summary should focus on, such as "decisions and open questions".
+func WithSummaryFocus(focus string) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.focus = focus
+	}
+}
+
+// WithSummaryInstruction replaces the default summarization instruction.
+//
+// The target length and focus are not added to a custom instruction.
+func WithSummaryInstruction(instruction string) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.instruction = instruction
+	}
+}
+
+// WithLastTurns limits the summary to the n most recent conversational turns, each starting with
+// a message of the user and holding the replies of the agents to it. Zero or less summarizes all
+// the events.
+func WithLastTurns(n int) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.lastTurns = n
+	}
+}
+
+// WithSinceMarker limits the summary to the events after the most recent event for which marker returns true.
+//
+// If no event matches, all the events are summarized.
+func WithSinceMarker(marker func(event *types.Event) bool) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.sinceMarker = marker
+	}
+}
+
+// WithReplaceHistory sets whether the summary replaces the summarized events in the contents
+// of later model requests on the same branch.
+//
+// By default the summary is appended to the history.
+func WithReplaceHistory(replace bool) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.replaceHistory = replace
+	}
+}
+
+// WithSummaryOutputKey sets the key in session state to store the summary.
+func WithSummaryOutputKey(key string) SummarizerOption {
+	return func(a *SummarizerAgent) {
+		a.outputKey = key
+	}
+}
+
+// NewSummarizerAgent creates a new summarizer agent with the given name, model and options.
+func NewSummarizerAgent(name string, model types.Model, opts ...SummarizerOption) *SummarizerAgent {
+	a := &SummarizerAgent{
+		base:     types.NewBaseAgent(name),
+		model:    model,
+		maxWords: DefaultSummaryMaxWords,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// AsLLMAgent implements [types.Agent].
+func (a *SummarizerAgent) AsLLMAgent() (types.LLMAgent, bool) {
+	return nil, false
+}
+
+// Name implements [types.Agent].
+func (a *SummarizerAgent) Name() string {
+	return a.base.Name()
+}
+
+// Description implements [types.Agent].
+func (a *SummarizerAgent) Description() string {
+	return a.base.Description()
+}
+
+// ParentAgent implements [types.Agent].
+func (a *SummarizerAgent) ParentAgent() types.Agent {
+	return a.base.ParentAgent()
+}
+
+// SubAgents implements [types.Agent].
+func (a *SummarizerAgent) SubAgents() []types.Agent {
+	return a.base.SubAgents()
+}
+
+// BeforeAgentCallbacks implements [types.Agent].
+func (a *SummarizerAgent) BeforeAgentCallbacks() []types.AgentCallback {
+	return a.base.BeforeAgentCallbacks()
+}
+
+// AfterAgentCallbacks implements [types.Agent].
+func (a *SummarizerAgent) AfterAgentCallbacks() []types.AgentCallback {
+	return a.base.AfterAgentCallbacks()
+}
+
+// Execute implements [types.Agent].
+//
+// It emits a single summary event, or no event if there is nothing to summarize.
+func (a *SummarizerAgent) Execute(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		if a.model == nil {
+			yield(nil, fmt.Errorf("summarizer agent %s: no model", a.Name()))
+			return
+		}
+
+		transcript := a.transcript(ictx)
+		if transcript == "" {
+			return
+		}
+
+		request := types.NewLLMRequest([]*genai.Content{
+			genai.NewContentFromText(transcript, genai.RoleUser),
+		})
+		request.Model = a.model.Name()
+		request.AppendInstructions(a.summaryInstruction())
+
+		response, err := a.model.GenerateContent(ctx, request)
+		if err != nil {
+			yield(nil, fmt.Errorf("summarize: %w", err))
+			return
+		}
+		if response.ErrorCode != "" {
+			yield(nil, fmt.Errorf("summarize: %s: %s", response.ErrorCode, response.ErrorMessage))
+			return
+		}
+		summary := strings.TrimSpace(contentText(response.Content))
+		if summary == "" {
+			yield(nil, errors.New("summarize: empty summary"))
+			return
+		}
+
+		actions := types.NewEventActions().WithReplacesHistory(a.replaceHistory)
+		if a.outputKey != "" {
+			actions.StateDelta[a.outputKey] = summary
+		}
+		event := ictx.NewEvent().
+			WithInvocationID(ictx.InvocationID).
+			WithAuthor(a.Name()).
+			WithBranch(ictx.Branch).
+			WithContent(genai.NewContentFromText(summary, genai.RoleModel)).
+			WithActions(actions)
+		yield(event, nil)
+	}
+}
+
+// ExecuteLive implements [types.Agent].
+func (a *SummarizerAgent) ExecuteLive(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return xiter.EndError[types.Event](types.NotImplementedError("ExecuteLive not supported yet for SummarizerAgent"))
+}
+
+// Run implements [types.Agent].
+func (a *SummarizerAgent) Run(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return a.base.RunAgent(ctx, a, parentContext)
+}
+
+// RunLive implements [types.Agent].
+func (a *SummarizerAgent) RunLive(ctx context.Context, parentContext *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return a.base.RunLiveAgent(ctx, a, parentContext)
+}
+
+// Resources implements [types.ResourceHolder]: the model of the agent, if it implements [io.Closer].
+func (a *SummarizerAgent) Resources() []io.Closer {
+	if closer, ok := a.model.(io.Closer); ok {
+		return []io.Closer{closer}
+	}
+	return nil
+}
+
+// Init initializes the resources of the agent and its sub-agents, see [types.InitAgent].
+func (a *SummarizerAgent) Init(ctx context.Context) error {
+	return types.InitAgent(ctx, a)
+}
+
+// Close releases the resources of the agent and its sub-agents, see [types.CloseAgent].
+func (a *SummarizerAgent) Close() error {
+	return types.CloseAgent(a)
+}
+
+// RootAgent implements [types.Agent].
+func (a *SummarizerAgent) RootAgent() types.Agent {
+	return a.base.RootAgent()
+}
+
+// FindAgent implements [types.Agent].
+func (a *SummarizerAgent) FindAgent(name string) types.Agent {
+	return a.base.FindAgent(name)
+}
+
+// FindSubAgent implements [types.Agent].
+func (a *SummarizerAgent) FindSubAgent(name string) types.Agent {
+	return a.base.FindSubAgent(name)
+}
+
+// summaryInstruction returns the system instruction of the summarization request.
+func (a *SummarizerAgent) summaryInstruction() string {
+	if a.instruction != "" {
+		return a.instruction
+	}
+
+	var sb strings.Builder
+	sb.WriteString("You condense a conversation between a user and AI agents so that the next agents can continue the work from the summary alone. ")
+	sb.WriteString("Keep the facts, results, decisions and open questions; drop greetings, repetitions and intermediate reasoning. ")
+	if a.maxWords > 0 {
+		fmt.Fprintf(&sb, "Write at most %d words. ", a.maxWords)
+	}
+	if a.focus != "" {
+		fmt.Fprintf(&sb, "Focus on %s. ", a.focus)
+	}
+	sb.WriteString("Reply with the summary only.")
+
+	return sb.String()
+}
+
+// transcript renders the events to summarize as text, one line per part.
+func (a *SummarizerAgent) transcript(ictx *types.InvocationContext) string {
+	if ictx.Session == nil {
+		return ""
+	}
+
+	var events []*types.Event
+	for _, event := range ictx.Session.Events() {
+		if event.LLMResponse == nil || event.Content == nil || event.Partial {
+			continue
+		}
+		if ictx.Branch != "" && event.Branch != "" && !strings.HasPrefix(ictx.Branch, event.Branch) {
+			continue
+		}
+		events = append(events, event)
+	}
+
+	if a.sinceMarker != nil {
+		for i := len(events) - 1; i >= 0; i-- {
+			if a.sinceMarker(events[i]) {
+				events = events[i+1:]
+				break
+			}
+		}
+	}
+	if a.lastTurns > 0 {
+		events = lastTurnEvents(events, a.lastTurns)
+	}
+
+	var sb strings.Builder
+	for _, event := range events {
+		for _, part := range event.Content.Parts {
+			switch {
+			case part.Text != "" && !part.Thought:
+				fmt.Fprintf(&sb, "[%s]: %s\n", event.Author, part.Text)
+			case part.FunctionCall != nil:
+				fmt.Fprintf(&sb, "[%s] called tool %s with %v\n", event.Author, part.FunctionCall.Name, part.FunctionCall.Args)
+			case part.FunctionResponse != nil:
+				fmt.Fprintf(&sb, "[%s] tool %s returned %v\n", event.Author, part.FunctionResponse.Name, part.FunctionResponse.Response)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// contentText returns the concatenated text parts of the content, excluding thoughts.
+func contentText(content *genai.Content) string {
+	if content == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, part := range content.Parts {
+		if part.Text != "" && !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+// lastTurnEvents returns the events of the last n conversational turns, or all the events if there
+// are fewer turns.
+func lastTurnEvents(events []*types.Event, n int) []*types.Event {
+	for i := len(events) - 1; i >= 0; i-- {
+		if !isTurnStart(events[i]) {
+			continue
+		}
+		if n--; n == 0 {
+			return events[i:]
+		}
+	}
+	return events
+}
+
+// isTurnStart reports whether the event is a message of the user starting a conversational turn,
+// rather than the response to a function call.
+func isTurnStart(event *types.Event) bool {
+	return event.Author == "user" && len(event.GetFunctionResponses()) == 0
+}
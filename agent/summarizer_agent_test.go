@@ -0,0 +1,191 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent_test
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// summaryModel is a [types.Model] replying with a fixed summary and recording the request.
+type summaryModel struct {
+	summary string
+	request *types.LLMRequest
+}
+
+var _ types.Model = (*summaryModel)(nil)
+
+func (m *summaryModel) Name() string              { return "summary-model" }
+func (m *summaryModel) SupportedModels() []string { return []string{"summary-model"} }
+
+func (m *summaryModel) Connect(context.Context, *types.LLMRequest) (types.ModelConnection, error) {
+	return nil, types.NotImplementedError("Connect")
+}
+
+func (m *summaryModel) GenerateContent(ctx context.Context, request *types.LLMRequest) (*types.LLMResponse, error) {
+	m.request = request
+	return &types.LLMResponse{Content: genai.NewContentFromText(m.summary, genai.RoleModel)}, nil
+}
+
+func (m *summaryModel) StreamGenerateContent(ctx context.Context, request *types.LLMRequest) iter.Seq2[*types.LLMResponse, error] {
+	return func(yield func(*types.LLMResponse, error) bool) {
+		yield(m.GenerateContent(ctx, request))
+	}
+}
+
+func textEvent(author, text string) *types.Event {
+	return types.NewEvent().
+		WithAuthor(author).
+		WithContent(genai.NewContentFromText(text, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+}
+
+func TestSummarizerAgent(t *testing.T) {
+	t.Parallel()
+
+	m := &summaryModel{summary: "The user wants a trip to Kyoto in May."}
+	a := agent.NewSummarizerAgent("summarizer", m,
+		agent.WithSummaryMaxWords(50),
+		agent.WithSummaryFocus("travel constraints"),
+		agent.WithSinceMarker(func(event *types.Event) bool { return event.Author == "marker" }),
+		agent.WithLastTurns(1),
+		agent.WithReplaceHistory(true),
+		agent.WithSummaryOutputKey("summary"),
+	)
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ses.AddEvent(
+		textEvent("planner", "old plan"),
+		textEvent("marker", "---"),
+		textEvent("user", "Plan a trip to Kyoto."),
+		textEvent("researcher", "Kyoto is busy in April."),
+		textEvent("user", "Then go in May."),
+		textEvent("planner", "Plan the trip for May."),
+		textEvent("booker", "Hotel found near Gion."),
+	)
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	var events []*types.Event
+	for event, err := range a.Execute(t.Context(), ictx) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	event := events[0]
+	if got := event.Content.Parts[0].Text; got != m.summary {
+		t.Errorf("summary = %q, want %q", got, m.summary)
+	}
+	if got := event.Author; got != "summarizer" {
+		t.Errorf("author = %q, want %q", got, "summarizer")
+	}
+	if !event.Actions.ReplacesHistory {
+		t.Error("ReplacesHistory = false, want true")
+	}
+	if got := event.Actions.StateDelta["summary"]; got != m.summary {
+		t.Errorf("state delta = %v, want %q", got, m.summary)
+	}
+
+	transcript := m.request.Contents[0].Parts[0].Text
+	for _, want := range []string{"[user]: Then go in May.", "[planner]: Plan the trip for May.", "[booker]: Hotel found near Gion."} {
+		if !strings.Contains(transcript, want) {
+			t.Errorf("transcript %q does not contain %q", transcript, want)
+		}
+	}
+	for _, unwanted := range []string{"old plan", "trip to Kyoto", "Kyoto is busy"} {
+		if strings.Contains(transcript, unwanted) {
+			t.Errorf("transcript %q contains %q", transcript, unwanted)
+		}
+	}
+
+	instruction := m.request.Config.SystemInstruction.Parts[0].Text
+	for _, want := range []string{"at most 50 words", "Focus on travel constraints"} {
+		if !strings.Contains(instruction, want) {
+			t.Errorf("instruction %q does not contain %q", instruction, want)
+		}
+	}
+}
+
+func TestSummarizerAgent_LastTurns(t *testing.T) {
+	t.Parallel()
+
+	functionResponse := types.NewEvent().
+		WithAuthor("user").
+		WithContent(genai.NewContentFromFunctionResponse("search", map[string]any{"result": "3 hotels"}, genai.RoleUser)).
+		WithActions(types.NewEventActions())
+	history := []*types.Event{
+		textEvent("user", "Plan a trip to Kyoto."),
+		textEvent("planner", "When?"),
+		textEvent("user", "In May."),
+		textEvent("planner", "Searching hotels."),
+		// A function response does not start a turn.
+		functionResponse,
+		textEvent("booker", "Hotel found near Gion."),
+	}
+
+	tests := map[string]struct {
+		n        int
+		wantFrom string
+	}{
+		"last turn":      {n: 1, wantFrom: "[user]: In May."},
+		"two turns":      {n: 2, wantFrom: "[user]: Plan a trip to Kyoto."},
+		"more than held": {n: 5, wantFrom: "[user]: Plan a trip to Kyoto."},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &summaryModel{summary: "A trip to Kyoto in May."}
+			a := agent.NewSummarizerAgent("summarizer", m, agent.WithLastTurns(tt.n))
+
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ses.AddEvent(history...)
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			for _, err := range a.Execute(t.Context(), ictx) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			transcript := m.request.Contents[0].Parts[0].Text
+			if !strings.HasPrefix(transcript, tt.wantFrom) {
+				t.Errorf("transcript %q does not start with %q", transcript, tt.wantFrom)
+			}
+			if !strings.Contains(transcript, "[booker]: Hotel found near Gion.") {
+				t.Errorf("transcript %q does not contain the last event", transcript)
+			}
+		})
+	}
+}
+
+func TestSummarizerAgent_NothingToSummarize(t *testing.T) {
+	t.Parallel()
+
+	m := &summaryModel{summary: "unused"}
+	a := agent.NewSummarizerAgent("summarizer", m)
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	for event, err := range a.Execute(t.Context(), ictx) {
+		t.Fatalf("unexpected event %v, err %v", event, err)
+	}
+	if m.request != nil {
+		t.Error("model was called without events to summarize")
+	}
+}
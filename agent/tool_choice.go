@@ -0,0 +1,49 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"github.com/go-a2a/adk-go/types"
+)
+
+// WithForcedTool forces the model to call the named tool, such as in an extraction flow that must
+// end with exactly one known tool call.
+//
+// The tool is forced until the agent has called it in the invocation: the next model calls are
+// left to the model, which can then answer with the result of the tool.
+//
+// The tool must be one of the tools of the agent: the model reports an error otherwise, as it does
+// when it has no mechanism to force a tool. See [types.ToolChoice].
+func WithForcedTool(name string) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.toolChoice = &types.ToolChoice{Mode: types.ToolChoiceTool, Name: name}
+	}
+}
+
+// WithForceAnyTool forces the model to call one of the tools of the agent, until the agent has
+// called one in the invocation, as [WithForcedTool].
+func WithForceAnyTool() LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.toolChoice = &types.ToolChoice{Mode: types.ToolChoiceAny}
+	}
+}
+
+// WithToolsDisabled forbids the model to call the tools of the agent, which stay declared so that
+// the history of their previous calls remains valid.
+func WithToolsDisabled() LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.toolChoice = &types.ToolChoice{Mode: types.ToolChoiceNone}
+	}
+}
+
+// WithGoogleSearchGrounding sets whether the answers of the agent are grounded in Google Search by
+// the model, with citations of the web sources, see [types.GoogleSearchGrounding].
+//
+// This needs no google_search tool, and is only supported by the Gemini models: the others report
+// a [*types.UnsupportedGroundingError].
+func WithGoogleSearchGrounding(enabled bool, opts ...types.GroundingOption) LLMAgentOption {
+	return func(a *LLMAgent) {
+		a.googleSearchGrounding = types.NewGoogleSearchGrounding(enabled, opts...)
+	}
+}
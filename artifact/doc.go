@@ -32,6 +32,11 @@
 //   - List and load operations support version-specific access
 //   - Version history can be retrieved for any artifact
 //
+// With WithDedupeVersions, saving the content of the latest version returns that version
+// instead of creating a new one:
+//
+//	service := artifact.NewInMemoryService(artifact.WithDedupeVersions(true))
+//
 // # Basic Usage
 //
 // Creating a service:
@@ -82,6 +87,19 @@
 //	// Delete an artifact (all versions)
 //	err := service.DeleteArtifact(ctx, "myapp", "user123", "session456", "report.txt")
 //
+// # Signed URLs
+//
+// GCSService signs time-limited URLs, so that clients download and upload large artifacts from
+// Google Cloud Storage directly instead of through the service:
+//
+//	url, err := service.SignedURL(ctx, "myapp", "user123", "session456", "report.pdf", 0, 15*time.Minute)
+//
+//	upload, err := service.SignedUploadURL(ctx, "myapp", "user123", "session456", "report.pdf", "application/pdf", 15*time.Minute)
+//	// The client sends a PUT request to upload.URL with upload.Headers, which registers upload.Version.
+//
+// The URLs point to the object of the artifact, so that they keep the isolation of the
+// application, user and session.
+//
 // # Content Types
 //
 // Artifacts support all genai.Part types:
@@ -0,0 +1,20 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"cloud.google.com/go/storage"
+)
+
+// NewGCSServiceWithClient returns a [GCSService] storing its artifacts in the bucket of the client for testing.
+func NewGCSServiceWithClient(client *storage.Client, bucketName string, opts ...Option) *GCSService {
+	return &GCSService{
+		client: client,
+		bucket: client.Bucket(bucketName),
+		opts:   newOptions(opts),
+	}
+}
+
+// ValidateSignedURLTTL exports validateSignedURLTTL for testing.
+var ValidateSignedURLTTL = validateSignedURLTTL
@@ -28,12 +28,13 @@ import (
 type GCSService struct {
 	client *storage.Client
 	bucket *storage.BucketHandle
+	opts   options
 }
 
 var _ types.ArtifactService = (*GCSService)(nil)
 
 // NewGCSService creates a new [GCSService] instance with the given bucket name.
-func NewGCSService(ctx context.Context, bucketName string) (*GCSService, error) {
+func NewGCSService(ctx context.Context, bucketName string, opts ...Option) (*GCSService, error) {
 	creds, err := credentials.DetectDefault(&credentials.DetectOptions{
 		Scopes: []string{
 			storage.ScopeFullControl,
@@ -53,6 +54,7 @@ func NewGCSService(ctx context.Context, bucketName string) (*GCSService, error)
 	return &GCSService{
 		client: client,
 		bucket: bucket,
+		opts:   newOptions(opts),
 	}, nil
 }
 
@@ -63,47 +65,111 @@ func (a *GCSService) fileHasUserNamespace(filename string) bool {
 
 // getBlobName constructs the blob name in GCS.
 func (a *GCSService) getBlobName(appName, userID, sessionID, filename string, version int) string {
+	return a.getBlobPrefix(appName, userID, sessionID, filename) + strconv.Itoa(version)
+}
+
+// getBlobPrefix constructs the prefix of the blob names of all versions of the artifact in GCS.
+func (a *GCSService) getBlobPrefix(appName, userID, sessionID, filename string) string {
 	if a.fileHasUserNamespace(filename) {
-		return fmt.Sprintf("%s/%s/user/%s/%d", appName, userID, filename, version)
+		return fmt.Sprintf("%s/%s/user/%s/", appName, userID, filename)
 	}
-	return fmt.Sprintf("%s/%s/%s/%s/%d", appName, userID, sessionID, filename, version)
+	return fmt.Sprintf("%s/%s/%s/%s/", appName, userID, sessionID, filename)
 }
 
 // SaveArtifact implements [types.ArtifactService].
 func (a *GCSService) SaveArtifact(ctx context.Context, appName, userID, sessionID, filename string, artifact *genai.Part) (int, error) {
+	return a.SaveArtifactStream(ctx, appName, userID, sessionID, filename, bytes.NewReader(artifact.InlineData.Data), artifact.InlineData.MIMEType)
+}
+
+// LoadArtifact implements [types.ArtifactService].
+func (a *GCSService) LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error) {
+	r, mimeType, err := a.LoadArtifactStream(ctx, appName, userID, sessionID, filename, version)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := genai.NewPartFromBytes(data, mimeType)
+
+	return artifact, nil
+}
+
+// SaveArtifactStream implements [types.ArtifactService].
+//
+// The artifact is uploaded as it is read from r, without being buffered in memory.
+//
+// With [WithDedupeVersions], the checksums of the uploaded object are compared with those of the
+// latest version once uploaded, and the new object is deleted in favor of the latest version if
+// they match.
+func (a *GCSService) SaveArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, r io.Reader, mimeType string) (int, error) {
 	versions, err := a.ListVersions(ctx, appName, userID, sessionID, filename)
 	if err != nil {
 		return 0, err
 	}
 	version := 0
 	if len(versions) > 0 {
-		version = len(versions) - 1
+		version = slices.Max(versions) + 1
 	}
 
 	blobName := a.getBlobName(appName, userID, sessionID, filename, version)
 	blob := a.bucket.Object(blobName)
 
 	w := blob.NewWriter(ctx)
-	defer w.Close()
-	if _, err := io.Copy(w, bytes.NewReader(artifact.InlineData.Data)); err != nil {
+	w.ContentType = mimeType
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
 		return 0, err
 	}
-
-	if _, err := blob.Update(ctx, storage.ObjectAttrsToUpdate{
-		ContentType: artifact.InlineData.MIMEType,
-	}); err != nil {
+	if err := w.Close(); err != nil {
 		return 0, err
 	}
 
+	if a.opts.dedupeVersions && version > 0 {
+		latest, err := a.bucket.Object(a.getBlobName(appName, userID, sessionID, filename, version-1)).Attrs(ctx)
+		switch {
+		case errors.Is(err, storage.ErrObjectNotExist):
+			// the latest version was deleted concurrently; keep the new version
+		case err != nil:
+			return 0, fmt.Errorf("get attributes of the latest version of artifact %s: %w", filename, err)
+		case sameObject(latest, w.Attrs()):
+			if err := blob.Delete(ctx); err != nil {
+				return 0, fmt.Errorf("delete duplicate version of artifact %s: %w", filename, err)
+			}
+			return version - 1, nil
+		}
+	}
+
 	return version, nil
 }
 
-// LoadArtifact implements [types.ArtifactService].
-func (a *GCSService) LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error) {
+// sameObject reports whether the two objects have the same content, comparing their MD5 hashes,
+// or their CRC32C checksums for the objects without MD5 hash.
+func sameObject(a, b *storage.ObjectAttrs) bool {
+	if a == nil || b == nil || a.Size != b.Size || a.ContentType != b.ContentType {
+		return false
+	}
+	if len(a.MD5) > 0 && len(b.MD5) > 0 {
+		return bytes.Equal(a.MD5, b.MD5)
+	}
+	return a.CRC32C == b.CRC32C
+}
+
+// LoadArtifactStream implements [types.ArtifactService].
+//
+// The artifact is downloaded as it is read from the returned reader.
+func (a *GCSService) LoadArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, version int) (io.ReadCloser, string, error) {
 	if version == 0 {
 		versions, err := a.ListVersions(ctx, appName, userID, sessionID, filename)
 		if err != nil {
-			return nil, err
+			return nil, "", err
+		}
+		if len(versions) == 0 {
+			return nil, "", fmt.Errorf("artifact %s not found", filename)
 		}
 		slices.Reverse(versions)
 		version = versions[len(versions)-1]
@@ -114,23 +180,10 @@ func (a *GCSService) LoadArtifact(ctx context.Context, appName, userID, sessionI
 
 	r, err := blob.NewReader(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
-	defer r.Close()
 
-	attrs, err := blob.Attrs(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	data, err := io.ReadAll(r)
-	if err != nil {
-		return nil, err
-	}
-
-	artifact := genai.NewPartFromBytes(data, attrs.ContentType)
-
-	return artifact, nil
+	return r, r.Attrs.ContentType, nil
 }
 
 // ListArtifactKey implements [types.ArtifactService].
@@ -215,7 +268,7 @@ func (a *GCSService) DeleteArtifact(ctx context.Context, appName, userID, sessio
 
 // ListVersions implements [types.ArtifactService].
 func (a *GCSService) ListVersions(ctx context.Context, appName, userID, sessionID, filename string) ([]int, error) {
-	prefix := a.getBlobName(appName, userID, sessionID, filename, 0)
+	prefix := a.getBlobPrefix(appName, userID, sessionID, filename)
 	it := a.bucket.Objects(ctx, &storage.Query{
 		Prefix: prefix,
 	})
@@ -246,6 +299,19 @@ func (a *GCSService) ListVersions(ctx context.Context, appName, userID, sessionI
 	return versions, nil
 }
 
+// Ping implements [types.ArtifactService].
+//
+// It lists at most one object of the bucket, which checks that the bucket exists and that the
+// credentials may read it.
+func (a *GCSService) Ping(ctx context.Context) error {
+	it := a.bucket.Objects(ctx, &storage.Query{})
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && !errors.Is(err, iterator.Done) {
+		return &types.UnhealthyError{Service: "artifact.GCSService", Err: err}
+	}
+	return nil
+}
+
 // Close implements [types.ArtifactService].
 func (a *GCSService) Close() error {
 	return a.client.Close()
@@ -0,0 +1,132 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// MaxSignedURLTTL is the longest validity of a V4 signed URL accepted by Google Cloud Storage.
+const MaxSignedURLTTL = 7 * 24 * time.Hour
+
+// SignedUpload is a signed URL uploading a new version of an artifact directly to Google Cloud Storage.
+type SignedUpload struct {
+	// URL is the signed URL the client uploads the artifact to, with a PUT request.
+	URL string
+
+	// Version is the version the artifact is registered as once uploaded.
+	Version int
+
+	// Headers are the headers the client must send with the upload request, as they are part of
+	// the signature.
+	Headers map[string]string
+
+	// Expires is the time after which the URL is no longer valid.
+	Expires time.Time
+}
+
+// SignedURL returns a V4 signed URL downloading the version of the artifact with a GET request,
+// valid for ttl, so that clients fetch large artifacts from Google Cloud Storage directly instead
+// of through the service. A version of 0 selects the latest version, as in [GCSService.LoadArtifact].
+//
+// The URL is signed with the credentials of the service, which must be able to sign blobs, such as
+// a service account key or a service account with the iam.serviceAccounts.signBlob permission.
+// The ttl must be positive and at most [MaxSignedURLTTL].
+func (a *GCSService) SignedURL(ctx context.Context, appName, userID, sessionID, filename string, version int, ttl time.Duration) (string, error) {
+	if err := validateSignedURLTTL(ttl); err != nil {
+		return "", err
+	}
+
+	if version == 0 {
+		versions, err := a.ListVersions(ctx, appName, userID, sessionID, filename)
+		if err != nil {
+			return "", err
+		}
+		if len(versions) == 0 {
+			return "", fmt.Errorf("artifact %s not found", filename)
+		}
+		version = slices.Max(versions)
+	}
+
+	blobName := a.getBlobName(appName, userID, sessionID, filename, version)
+	if _, err := a.bucket.Object(blobName).Attrs(ctx); err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return "", fmt.Errorf("artifact %s version %d not found", filename, version)
+		}
+		return "", fmt.Errorf("get attributes of artifact %s version %d: %w", filename, version, err)
+	}
+
+	url, err := a.bucket.SignedURL(blobName, &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", fmt.Errorf("sign download URL of artifact %s: %w", filename, err)
+	}
+
+	return url, nil
+}
+
+// SignedUploadURL returns a V4 signed URL uploading the next version of the artifact with a PUT
+// request, valid for ttl, so that clients upload large artifacts to Google Cloud Storage directly.
+//
+// The artifact is registered as the returned version once the upload completes. The upload
+// request must send the returned headers, which set the content type and make the upload fail
+// if the version was taken in the meantime, for example by a concurrent save; the client then
+// requests a new URL.
+func (a *GCSService) SignedUploadURL(ctx context.Context, appName, userID, sessionID, filename, mimeType string, ttl time.Duration) (*SignedUpload, error) {
+	if err := validateSignedURLTTL(ttl); err != nil {
+		return nil, err
+	}
+
+	versions, err := a.ListVersions(ctx, appName, userID, sessionID, filename)
+	if err != nil {
+		return nil, err
+	}
+	version := 0
+	if len(versions) > 0 {
+		version = slices.Max(versions) + 1
+	}
+
+	const ifGenerationMatch = "x-goog-if-generation-match"
+	expires := time.Now().Add(ttl)
+	url, err := a.bucket.SignedURL(a.getBlobName(appName, userID, sessionID, filename, version), &storage.SignedURLOptions{
+		Scheme:      storage.SigningSchemeV4,
+		Method:      http.MethodPut,
+		Expires:     expires,
+		ContentType: mimeType,
+		Headers:     []string{ifGenerationMatch + ":0"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign upload URL of artifact %s: %w", filename, err)
+	}
+
+	headers := map[string]string{ifGenerationMatch: "0"}
+	if mimeType != "" {
+		headers["Content-Type"] = mimeType
+	}
+
+	return &SignedUpload{
+		URL:     url,
+		Version: version,
+		Headers: headers,
+		Expires: expires,
+	}, nil
+}
+
+// validateSignedURLTTL returns an error if the ttl is not a valid validity of a V4 signed URL.
+func validateSignedURLTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxSignedURLTTL {
+		return fmt.Errorf("signed URL ttl %s must be positive and at most %s", ttl, MaxSignedURLTTL)
+	}
+	return nil
+}
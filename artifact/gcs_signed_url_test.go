@@ -0,0 +1,277 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/storage"
+	"github.com/go-json-experiment/json"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+
+	"github.com/go-a2a/adk-go/artifact"
+)
+
+// fakeBucket serves the object listings and attributes of a fixed set of objects, the subset of
+// the GCS JSON API used to sign URLs.
+type fakeBucket struct {
+	name    string
+	objects []string
+}
+
+func (f *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	objects := fmt.Sprintf("/storage/v1/b/%s/o", f.name)
+	escaped := r.URL.EscapedPath()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && escaped == objects:
+		prefix := r.URL.Query().Get("prefix")
+		items := []map[string]any{}
+		for _, name := range f.objects {
+			if strings.HasPrefix(name, prefix) {
+				items = append(items, f.resource(name))
+			}
+		}
+		json.MarshalWrite(w, map[string]any{"kind": "storage#objects", "items": items})
+	case r.Method == http.MethodGet && strings.HasPrefix(escaped, objects+"/"):
+		name, _ := url.PathUnescape(strings.TrimPrefix(escaped, objects+"/"))
+		if !slices.Contains(f.objects, name) {
+			w.WriteHeader(http.StatusNotFound)
+			json.MarshalWrite(w, map[string]any{"error": map[string]any{"code": http.StatusNotFound, "message": "no such object: " + name}})
+			return
+		}
+		json.MarshalWrite(w, f.resource(name))
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+		json.MarshalWrite(w, map[string]any{"error": map[string]any{"code": http.StatusNotImplemented, "message": r.Method + " " + escaped}})
+	}
+}
+
+// resource returns the JSON API resource of the object.
+func (f *fakeBucket) resource(name string) map[string]any {
+	return map[string]any{"kind": "storage#object", "bucket": f.name, "name": name, "generation": "1"}
+}
+
+// newSigningGCSService returns an [artifact.GCSService] reading the objects from a fake bucket and
+// signing URLs with a generated service account key.
+func newSigningGCSService(t *testing.T, objects ...string) *artifact.GCSService {
+	t.Helper()
+
+	fake := &fakeBucket{name: "artifacts", objects: objects}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	saJSON, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "signer@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		"token_uri":    srv.URL + "/token",
+	})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	creds, err := credentials.DetectDefault(&credentials.DetectOptions{
+		CredentialsJSON: saJSON,
+		Scopes:          []string{storage.ScopeReadWrite},
+	})
+	if err != nil {
+		t.Fatalf("credentials.DetectDefault() error = %v", err)
+	}
+
+	// The HTTP client sends the requests without authentication; the credentials only sign the URLs.
+	client, err := storage.NewClient(t.Context(),
+		option.WithEndpoint(srv.URL+"/storage/v1/"),
+		option.WithHTTPClient(srv.Client()),
+		option.WithAuthCredentials(creds),
+		storage.WithJSONReads(),
+	)
+	if err != nil {
+		t.Fatalf("storage.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	return artifact.NewGCSServiceWithClient(client, fake.name)
+}
+
+func TestValidateSignedURLTTL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ttl     time.Duration
+		wantErr bool
+	}{
+		"zero":        {ttl: 0, wantErr: true},
+		"negative":    {ttl: -time.Minute, wantErr: true},
+		"one second":  {ttl: time.Second},
+		"one hour":    {ttl: time.Hour},
+		"maximum":     {ttl: artifact.MaxSignedURLTTL},
+		"above limit": {ttl: artifact.MaxSignedURLTTL + time.Second, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := artifact.ValidateSignedURLTTL(tt.ttl); (err != nil) != tt.wantErr {
+				t.Errorf("validateSignedURLTTL(%s) error = %v, wantErr %t", tt.ttl, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGCSService_SignedURL(t *testing.T) {
+	t.Parallel()
+
+	svc := newSigningGCSService(t,
+		"app/user/session/report.txt/0",
+		"app/user/session/report.txt/1",
+		"app/user/session/report.txt/2",
+		"app/user/user/user:profile.json/0",
+	)
+
+	tests := map[string]struct {
+		filename string
+		version  int
+		ttl      time.Duration
+		wantPath string
+		wantErr  string
+	}{
+		"latest version": {
+			filename: "report.txt",
+			wantPath: "/artifacts/app/user/session/report.txt/2",
+		},
+		"given version": {
+			filename: "report.txt",
+			version:  1,
+			wantPath: "/artifacts/app/user/session/report.txt/1",
+		},
+		"user namespace": {
+			filename: "user:profile.json",
+			wantPath: "/artifacts/app/user/user/user:profile.json/0",
+		},
+		"missing version": {
+			filename: "report.txt",
+			version:  5,
+			wantErr:  "artifact report.txt version 5 not found",
+		},
+		"missing artifact": {
+			filename: "notes.txt",
+			wantErr:  "artifact notes.txt not found",
+		},
+		"invalid ttl": {
+			filename: "report.txt",
+			ttl:      artifact.MaxSignedURLTTL + time.Hour,
+			wantErr:  "signed URL ttl",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ttl := tt.ttl
+			if ttl == 0 {
+				ttl = time.Hour
+			}
+			signed, err := svc.SignedURL(t.Context(), "app", "user", "session", tt.filename, tt.version, ttl)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("SignedURL() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SignedURL() error = %v", err)
+			}
+
+			u, err := url.Parse(signed)
+			if err != nil {
+				t.Fatalf("url.Parse(%q) error = %v", signed, err)
+			}
+			if u.Path != tt.wantPath {
+				t.Errorf("SignedURL() path = %q, want %q", u.Path, tt.wantPath)
+			}
+			if got := u.Query().Get("X-Goog-Algorithm"); got != "GOOG4-RSA-SHA256" {
+				t.Errorf("SignedURL() X-Goog-Algorithm = %q, want a V4 signature", got)
+			}
+			// The validity is counted from the signature, a little after the call.
+			if got, err := strconv.Atoi(u.Query().Get("X-Goog-Expires")); err != nil || got < 3590 || got > 3600 {
+				t.Errorf("SignedURL() X-Goog-Expires = %q, want about 3600 seconds", u.Query().Get("X-Goog-Expires"))
+			}
+		})
+	}
+}
+
+func TestGCSService_SignedUploadURL(t *testing.T) {
+	t.Parallel()
+
+	svc := newSigningGCSService(t,
+		"app/user/session/report.txt/0",
+		"app/user/session/report.txt/1",
+	)
+
+	tests := map[string]struct {
+		filename    string
+		mimeType    string
+		wantVersion int
+		wantHeaders map[string]string
+	}{
+		"next version": {
+			filename:    "report.txt",
+			mimeType:    "text/plain",
+			wantVersion: 2,
+			wantHeaders: map[string]string{"x-goog-if-generation-match": "0", "Content-Type": "text/plain"},
+		},
+		"new artifact without content type": {
+			filename:    "notes.txt",
+			wantVersion: 0,
+			wantHeaders: map[string]string{"x-goog-if-generation-match": "0"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			upload, err := svc.SignedUploadURL(t.Context(), "app", "user", "session", tt.filename, tt.mimeType, time.Hour)
+			if err != nil {
+				t.Fatalf("SignedUploadURL() error = %v", err)
+			}
+			if upload.Version != tt.wantVersion {
+				t.Errorf("SignedUploadURL() version = %d, want %d", upload.Version, tt.wantVersion)
+			}
+			if diff := cmp.Diff(tt.wantHeaders, upload.Headers); diff != "" {
+				t.Errorf("SignedUploadURL() headers mismatch (-want +got):\n%s", diff)
+			}
+
+			u, err := url.Parse(upload.URL)
+			if err != nil {
+				t.Fatalf("url.Parse(%q) error = %v", upload.URL, err)
+			}
+			if want := fmt.Sprintf("/artifacts/app/user/session/%s/%d", tt.filename, tt.wantVersion); u.Path != want {
+				t.Errorf("SignedUploadURL() path = %q, want %q", u.Path, want)
+			}
+			// The precondition header is signed, so that the client cannot leave it out.
+			signedHeaders := strings.Split(u.Query().Get("X-Goog-SignedHeaders"), ";")
+			if !slices.Contains(signedHeaders, "x-goog-if-generation-match") {
+				t.Errorf("SignedUploadURL() signed headers = %v, want x-goog-if-generation-match", signedHeaders)
+			}
+		})
+	}
+}
@@ -4,12 +4,15 @@
 package artifact
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"slices"
 	"strings"
 	"sync"
 
+	"github.com/go-json-experiment/json"
 	"google.golang.org/genai"
 
 	"github.com/go-a2a/adk-go/types"
@@ -19,14 +22,16 @@ import (
 type InMemoryService struct {
 	artifacts map[string][]*genai.Part
 	mu        sync.Mutex
+	opts      options
 }
 
 var _ types.ArtifactService = (*InMemoryService)(nil)
 
 // NewInMemoryService creates a new instance of [InMemoryService].
-func NewInMemoryService() *InMemoryService {
+func NewInMemoryService(opts ...Option) *InMemoryService {
 	return &InMemoryService{
 		artifacts: make(map[string][]*genai.Part),
+		opts:      newOptions(opts),
 	}
 }
 
@@ -44,17 +49,38 @@ func (a *InMemoryService) artifactPath(appName, userID, sessionID, filename stri
 }
 
 // SaveArtifact implements [types.ArtifactService].
+//
+// With [WithDedupeVersions], the latest version is returned if its content is identical to artifact.
 func (a *InMemoryService) SaveArtifact(ctx context.Context, appName, userID, sessionID, filename string, artifact *genai.Part) (int, error) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	path := a.artifactPath(appName, userID, sessionID, filename)
 	version := len(a.artifacts[path])
+	if a.opts.dedupeVersions && version > 0 && samePart(a.artifacts[path][version-1], artifact) {
+		return version - 1, nil
+	}
 	a.artifacts[path] = append(a.artifacts[path], artifact)
 
 	return version, nil
 }
 
+// samePart reports whether the two parts have the same content.
+func samePart(a, b *genai.Part) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	da, err := json.Marshal(a, json.DefaultOptionsV2(), json.Deterministic(true))
+	if err != nil {
+		return false
+	}
+	db, err := json.Marshal(b, json.DefaultOptionsV2(), json.Deterministic(true))
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(da, db)
+}
+
 // LoadArtifact implements [types.ArtifactService].
 func (a *InMemoryService) LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error) {
 	a.mu.Lock()
@@ -72,6 +98,31 @@ func (a *InMemoryService) LoadArtifact(ctx context.Context, appName, userID, ses
 	return versions[version], nil
 }
 
+// SaveArtifactStream implements [types.ArtifactService].
+//
+// The artifact is buffered in memory.
+func (a *InMemoryService) SaveArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, r io.Reader, mimeType string) (int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, fmt.Errorf("read artifact %s: %w", filename, err)
+	}
+
+	return a.SaveArtifact(ctx, appName, userID, sessionID, filename, genai.NewPartFromBytes(data, mimeType))
+}
+
+// LoadArtifactStream implements [types.ArtifactService].
+func (a *InMemoryService) LoadArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, version int) (io.ReadCloser, string, error) {
+	artifact, err := a.LoadArtifact(ctx, appName, userID, sessionID, filename, version)
+	if err != nil {
+		return nil, "", err
+	}
+	if artifact == nil || artifact.InlineData == nil {
+		return nil, "", fmt.Errorf("artifact %s not found", filename)
+	}
+
+	return io.NopCloser(bytes.NewReader(artifact.InlineData.Data)), artifact.InlineData.MIMEType, nil
+}
+
 // ListArtifactKey implements [types.ArtifactService].
 func (a *InMemoryService) ListArtifactKey(ctx context.Context, appName, userID, sessionID string) ([]string, error) {
 	a.mu.Lock()
@@ -130,6 +181,13 @@ func (a *InMemoryService) ListVersions(ctx context.Context, appName, userID, ses
 	return verList, nil
 }
 
+// Ping implements [types.ArtifactService].
+//
+// It always succeeds, the artifacts having no backend.
+func (a *InMemoryService) Ping(context.Context) error {
+	return nil
+}
+
 // Close implements [types.ArtifactService].
 func (a *InMemoryService) Close() error {
 	// nothing to do
@@ -0,0 +1,122 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/artifact"
+)
+
+func TestInMemoryService_DedupeVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts         []artifact.Option
+		wantVersions []int
+		wantSaved    []int
+	}{
+		"default": {
+			wantSaved:    []int{0, 1, 2, 3},
+			wantVersions: []int{0, 1, 2, 3},
+		},
+		"dedupe": {
+			opts:         []artifact.Option{artifact.WithDedupeVersions(true)},
+			wantSaved:    []int{0, 0, 1, 2},
+			wantVersions: []int{0, 1, 2},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			service := artifact.NewInMemoryService(tt.opts...)
+			parts := []*genai.Part{
+				genai.NewPartFromBytes([]byte("report v1"), "text/plain"),
+				genai.NewPartFromBytes([]byte("report v1"), "text/plain"),
+				genai.NewPartFromBytes([]byte("report v1"), "text/markdown"),
+				genai.NewPartFromBytes([]byte("report v2"), "text/markdown"),
+			}
+
+			var saved []int
+			for _, part := range parts {
+				version, err := service.SaveArtifact(t.Context(), "app", "user", "session", "report", part)
+				if err != nil {
+					t.Fatalf("SaveArtifact() error = %v", err)
+				}
+				saved = append(saved, version)
+			}
+			if diff := cmp.Diff(tt.wantSaved, saved); diff != "" {
+				t.Errorf("saved versions mismatch (-want +got):\n%s", diff)
+			}
+
+			versions, err := service.ListVersions(t.Context(), "app", "user", "session", "report")
+			if err != nil {
+				t.Fatalf("ListVersions() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantVersions, versions); diff != "" {
+				t.Errorf("ListVersions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInMemoryService_ArtifactStream(t *testing.T) {
+	t.Parallel()
+
+	service := artifact.NewInMemoryService()
+	ctx := t.Context()
+
+	for i, data := range []string{"report v1", "report v2"} {
+		version, err := service.SaveArtifactStream(ctx, "app", "user", "session", "report", strings.NewReader(data), "text/plain")
+		if err != nil {
+			t.Fatalf("SaveArtifactStream() error = %v", err)
+		}
+		if version != i {
+			t.Errorf("SaveArtifactStream() version = %d, want %d", version, i)
+		}
+	}
+
+	rc, mimeType, err := service.LoadArtifactStream(ctx, "app", "user", "session", "report", 0)
+	if err != nil {
+		t.Fatalf("LoadArtifactStream() error = %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read artifact stream: %v", err)
+	}
+	if got, want := string(data), "report v2"; got != want {
+		t.Errorf("LoadArtifactStream() data = %q, want %q", got, want)
+	}
+	if got, want := mimeType, "text/plain"; got != want {
+		t.Errorf("LoadArtifactStream() mime type = %q, want %q", got, want)
+	}
+
+	// The streamed artifact is also loaded as a part.
+	part, err := service.LoadArtifact(ctx, "app", "user", "session", "report", 0)
+	if err != nil {
+		t.Fatalf("LoadArtifact() error = %v", err)
+	}
+	if diff := cmp.Diff(genai.NewPartFromBytes([]byte("report v2"), "text/plain"), part); diff != "" {
+		t.Errorf("LoadArtifact() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := service.LoadArtifactStream(ctx, "app", "user", "session", "missing", 0); err == nil || !strings.Contains(err.Error(), "artifact missing not found") {
+		t.Errorf("LoadArtifactStream() of a missing artifact error = %v, want not found", err)
+	}
+
+	errRead := errors.New("connection reset")
+	if _, err := service.SaveArtifactStream(ctx, "app", "user", "session", "broken", iotest.ErrReader(errRead), "text/plain"); !errors.Is(err, errRead) {
+		t.Errorf("SaveArtifactStream() of a failing reader error = %v, want %v", err, errRead)
+	}
+}
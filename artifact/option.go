@@ -0,0 +1,32 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package artifact
+
+// options holds the options shared by the artifact services.
+type options struct {
+	// Whether saving the content of the latest version returns that version instead of creating a new one.
+	dedupeVersions bool
+}
+
+// Option configures an artifact service.
+type Option func(*options)
+
+// WithDedupeVersions sets whether saving an artifact whose content is identical to its latest
+// version returns the number of that version instead of creating a new one.
+//
+// This keeps the version history free of duplicates when agents periodically re-emit the same output.
+func WithDedupeVersions(dedupe bool) Option {
+	return func(o *options) {
+		o.dedupeVersions = dedupe
+	}
+}
+
+// newOptions returns the options set by opts.
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 
+	"github.com/go-a2a/adk-go/pkg/backoff"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -58,7 +59,10 @@ type ContainerExecutor struct {
 	activeContainers map[string]string // executionID -> containerID
 }
 
-var _ types.CodeExecutor = (*ContainerExecutor)(nil)
+var (
+	_ types.CodeExecutor      = (*ContainerExecutor)(nil)
+	_ types.LanguageSupporter = (*ContainerExecutor)(nil)
+)
 
 // ContainerExecutorOption is a functional option for configuring ContainerExecutor.
 type ContainerExecutorOption func(*ContainerExecutor)
@@ -217,6 +221,11 @@ func (e *ContainerExecutor) CodeBlockDelimiters() []types.DelimiterPair {
 	return e.config.CodeBlockDelimiters
 }
 
+// SupportedLanguages implements [types.LanguageSupporter].
+func (e *ContainerExecutor) SupportedLanguages() []string {
+	return []string{"python", "go", "javascript", "bash"}
+}
+
 // ExecutionResultDelimiters implements [types.CodeExecutor].
 func (e *ContainerExecutor) ExecutionResultDelimiters() types.DelimiterPair {
 	return e.config.ExecutionResultDelimiters
@@ -524,28 +533,14 @@ func (e *ContainerExecutor) ExecuteCode(ctx context.Context, ictx *types.Invocat
 
 	// Execute with retry logic
 	var result *types.CodeExecutionResult
-	var lastErr error
-
-	for attempt := 0; attempt <= e.config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(e.config.RetryDelay):
-				// Continue with retry
-			}
-		}
-
-		result, lastErr = e.executeInContainer(ctx, input)
-		if lastErr == nil {
-			break
-		}
-
-		// Increment error count
-		if ictx != nil {
+	lastErr := backoff.Retry(ctx, retryPolicy(e.config), func() error {
+		var err error
+		result, err = e.executeInContainer(ctx, input)
+		if err != nil && ictx != nil {
 			execCtx.IncrementErrorCount(ictx.InvocationID)
 		}
-	}
+		return err
+	}, nil)
 
 	if lastErr != nil {
 		return &types.CodeExecutionResult{
@@ -143,11 +143,12 @@ func (ec *CodeExecutorContext) GetErrorCount(invocationID string) int {
 func (ec *CodeExecutorContext) IncrementErrorCount(invocationID string) {
 	ec.mu.Lock()
 	defer ec.mu.Unlock()
-	val, ok := ec.context[ErrorCountKey]
+	val, ok := ec.context[ErrorCountKey].(map[string]int)
 	if !ok {
-		ec.context[ErrorCountKey] = make(map[string]int)
+		val = make(map[string]int)
+		ec.context[ErrorCountKey] = val
 	}
-	val.(map[string]int)[invocationID] = ec.GetErrorCount(invocationID) + 1
+	val[invocationID]++
 }
 
 // ResetErrorCount resets the error count from the session state.
@@ -167,14 +168,12 @@ func (ec *CodeExecutorContext) UpdateExecutionResult(invocationID, code, stdout,
 	ec.mu.Lock()
 	defer ec.mu.Unlock()
 
-	if _, ok := ec.context[CodeExecutionResultsKey]; !ok {
-		ec.context[ErrorCountKey] = make(map[string][]*types.CodeExecutionResult)
-	}
-	if _, ok := ec.context[CodeExecutionResultsKey].(map[string][]*types.CodeExecutionResult)[invocationID]; !ok {
-		ec.context[CodeExecutionResultsKey].(map[string][]*types.CodeExecutionResult)[invocationID] = []*types.CodeExecutionResult{}
+	results, ok := ec.context[CodeExecutionResultsKey].(map[string][]*types.CodeExecutionResult)
+	if !ok {
+		results = make(map[string][]*types.CodeExecutionResult)
+		ec.context[CodeExecutionResultsKey] = results
 	}
-
-	ec.context[CodeExecutionResultsKey] = append(ec.context[CodeExecutionResultsKey].(map[string][]*types.CodeExecutionResult)[invocationID], &types.CodeExecutionResult{
+	results[invocationID] = append(results[invocationID], &types.CodeExecutionResult{
 		Code:      code,
 		Stdout:    stdout,
 		Stderr:    stderr,
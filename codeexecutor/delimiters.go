@@ -4,8 +4,10 @@
 package codeexecutor
 
 import (
+	"cmp"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/go-a2a/adk-go/types"
@@ -54,19 +56,29 @@ func (p *CodeBlockParser) ExtractCodeBlocks(text string) ([]*CodeBlock, error) {
 	}
 	blocks = append(blocks, delimiterBlocks...)
 
-	return p.deduplicateBlocks(blocks), nil
+	blocks = p.deduplicateBlocks(blocks)
+	slices.SortStableFunc(blocks, func(a, b *CodeBlock) int { return cmp.Compare(a.Start, b.Start) })
+	return blocks, nil
 }
 
+// markdownCodeBlockRe matches the fenced code blocks: the info string after the opening fence, then
+// the code up to the closing fence on its own line.
+var markdownCodeBlockRe = regexp.MustCompile("```([^\\n`]*)\\r?\\n([\\s\\S]*?)\\r?\\n```")
+
 // extractMarkdownCodeBlocks extracts standard markdown code blocks (```language\ncode\n```).
+//
+// The language is the first word of the info string after the opening fence, so that
+// "```python title=main.py" is a python block, and is empty for an unlabeled block.
 func (p *CodeBlockParser) extractMarkdownCodeBlocks(text string) ([]*CodeBlock, error) {
-	// Regex to match markdown code blocks: ```language\ncode\n```
-	re := regexp.MustCompile("```([a-zA-Z0-9_+-]*)\n([\\s\\S]*?)\n```")
-	matches := re.FindAllStringSubmatchIndex(text, -1)
+	matches := markdownCodeBlockRe.FindAllStringSubmatchIndex(text, -1)
 
 	var blocks []*CodeBlock
 	for _, match := range matches {
 		if len(match) >= 6 {
-			language := text[match[2]:match[3]]
+			var language string
+			if fields := strings.Fields(text[match[2]:match[3]]); len(fields) > 0 {
+				language = fields[0]
+			}
 			code := text[match[4]:match[5]]
 
 			blocks = append(blocks, &CodeBlock{
@@ -152,21 +164,16 @@ func (p *CodeBlockParser) deduplicateBlocks(blocks []*CodeBlock) []*CodeBlock {
 	return result
 }
 
-// FilterByLanguage returns only code blocks matching the specified languages.
-// If no languages are specified, all blocks are returned.
+// FilterByLanguage returns only code blocks matching the specified languages, compared by their
+// [NormalizeLanguage] form. If no languages are specified, all blocks are returned.
 func (p *CodeBlockParser) FilterByLanguage(blocks []*CodeBlock, languages ...string) []*CodeBlock {
 	if len(languages) == 0 {
 		return blocks
 	}
 
-	languageSet := make(map[string]bool)
-	for _, lang := range languages {
-		languageSet[strings.ToLower(lang)] = true
-	}
-
 	var filtered []*CodeBlock
 	for _, block := range blocks {
-		if languageSet[strings.ToLower(block.Language)] {
+		if LanguageAllowed(block.Language, languages) {
 			filtered = append(filtered, block)
 		}
 	}
@@ -174,6 +181,47 @@ func (p *CodeBlockParser) FilterByLanguage(blocks []*CodeBlock, languages ...str
 	return filtered
 }
 
+// languageAliases maps the language tags of the code blocks to their canonical name.
+var languageAliases = map[string]string{
+	"py":        "python",
+	"python3":   "python",
+	"tool_code": "python",
+	"golang":    "go",
+	"js":        "javascript",
+	"node":      "javascript",
+	"sh":        "bash",
+	"shell":     "bash",
+}
+
+// NormalizeLanguage returns the canonical lower-case name of the language tag of a code block,
+// such as "python" for "py" or "bash" for "sh".
+func NormalizeLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if name, ok := languageAliases[tag]; ok {
+		return name
+	}
+	return tag
+}
+
+// LanguageAllowed reports whether the language tag names one of the allowed languages. An
+// unlabeled block is never allowed.
+func LanguageAllowed(tag string, allowed []string) bool {
+	language := NormalizeLanguage(tag)
+	if language == "" {
+		return false
+	}
+	return slices.ContainsFunc(allowed, func(name string) bool { return NormalizeLanguage(name) == language })
+}
+
+// SupportedLanguages returns the languages of the code blocks run by the executor, see
+// [types.LanguageSupporter], defaulting to python.
+func SupportedLanguages(executor types.CodeExecutor) []string {
+	if supporter, ok := executor.(types.LanguageSupporter); ok {
+		return supporter.SupportedLanguages()
+	}
+	return []string{"python"}
+}
+
 // ExecutionResultFormatter formats execution results with configurable delimiters.
 type ExecutionResultFormatter struct {
 	delimiters types.DelimiterPair
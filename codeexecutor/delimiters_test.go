@@ -0,0 +1,138 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codeexecutor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/genai"
+)
+
+// mixedResponse is a model response with a json, a python and a bash code block.
+const mixedResponse = "Here is the data:\n" +
+	"```json\n{\"n\": 1}\n```\n" +
+	"Run it with:\n" +
+	"```python title=main.py \nprint(1)\n```\n" +
+	"or:\n" +
+	"```sh\r\necho 1\r\n```\n" +
+	"Done."
+
+func TestCodeBlockParser_ExtractCodeBlocks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text string
+		want []*CodeBlock
+	}{
+		"mixed blocks": {
+			text: mixedResponse,
+			want: []*CodeBlock{
+				{Language: "json", Code: "{\"n\": 1}"},
+				{Language: "python", Code: "print(1)"},
+				{Language: "sh", Code: "echo 1"},
+			},
+		},
+		"unlabeled block": {
+			text: "```\nls\n```",
+			want: []*CodeBlock{{Code: "ls"}},
+		},
+		"tool_code block": {
+			text: "```tool_code\nprint(2)\n```",
+			want: []*CodeBlock{{Language: "tool_code", Code: "print(2)"}},
+		},
+		"no block": {
+			text: "no code here",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewDefaultCodeBlockParser().ExtractCodeBlocks(tt.text)
+			if err != nil {
+				t.Fatalf("ExtractCodeBlocks() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got, cmpopts.IgnoreFields(CodeBlock{}, "Start", "End")); diff != "" {
+				t.Errorf("ExtractCodeBlocks() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeLanguage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"py":        "python",
+		"Python":    "python",
+		"tool_code": "python",
+		"sh":        "bash",
+		"shell":     "bash",
+		"js":        "javascript",
+		"node":      "javascript",
+		"golang":    "go",
+		"json":      "json",
+		"":          "",
+	}
+	for tag, want := range tests {
+		if got := NormalizeLanguage(tag); got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestExtractCodeBlockAndTruncateContent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		allowed      []string
+		wantBlock    *CodeBlock
+		wantPrefix   string
+		wantLanguage genai.Language
+	}{
+		"python allowed": {
+			allowed:      []string{"python", "bash"},
+			wantBlock:    &CodeBlock{Language: "python", Code: "print(1)"},
+			wantPrefix:   "Here is the data:\n```json\n{\"n\": 1}\n```\nRun it with:\n",
+			wantLanguage: genai.LanguagePython,
+		},
+		"bash only": {
+			allowed:      []string{"shell"},
+			wantBlock:    &CodeBlock{Language: "bash", Code: "echo 1"},
+			wantPrefix:   "Here is the data:\n```json\n{\"n\": 1}\n```\nRun it with:\n```python title=main.py \nprint(1)\n```\nor:\n",
+			wantLanguage: genai.LanguageUnspecified,
+		},
+		"none allowed": {
+			allowed: []string{"go"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			content := genai.NewContentFromText(mixedResponse, genai.RoleModel)
+			got := NewCodeExecutionUtils().ExtractCodeBlockAndTruncateContent(content, nil, tt.allowed)
+			if diff := cmp.Diff(tt.wantBlock, got, cmpopts.IgnoreFields(CodeBlock{}, "Start", "End")); diff != "" {
+				t.Fatalf("ExtractCodeBlockAndTruncateContent() mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantBlock == nil {
+				if len(content.Parts) != 1 || content.Parts[0].Text != mixedResponse {
+					t.Errorf("content = %+v, want the response left unchanged", content.Parts)
+				}
+				return
+			}
+			if len(content.Parts) != 2 {
+				t.Fatalf("content has %d parts, want the text prefix and the code", len(content.Parts))
+			}
+			if got := content.Parts[0].Text; got != tt.wantPrefix {
+				t.Errorf("text prefix = %q, want %q", got, tt.wantPrefix)
+			}
+			if code := content.Parts[1].ExecutableCode; code == nil || code.Code != tt.wantBlock.Code || code.Language != tt.wantLanguage {
+				t.Errorf("executable code = %+v, want %q in %s", code, tt.wantBlock.Code, tt.wantLanguage)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@
 //   - BuiltInExecutor: Uses model's native code execution (Gemini 2.0+)
 //   - ContainerExecutor: Docker-based sandboxing with resource limits
 //   - LocalExecutor: Direct host execution (requires explicit opt-in)
+//   - KernelExecutor: Stateful Python kernels kept alive per session
 //
 // # Security Model
 //
@@ -100,6 +101,10 @@
 //
 // Language detection is automatic based on code patterns and explicit hints.
 //
+// The language of a markdown code block is the first word of the info string after its opening
+// fence, normalized by [NormalizeLanguage] so that "py" is "python" and "sh" is "bash". The
+// executors report the languages they run through [types.LanguageSupporter].
+//
 // # Container Execution
 //
 // ContainerExecutor provides robust sandboxing:
@@ -119,6 +124,23 @@
 //   - Custom Docker images
 //   - Automatic cleanup
 //
+// # Stateful Execution
+//
+// The KernelExecutor keeps a Python interpreter alive per session, so that successive code
+// blocks share a namespace, as in a Jupyter kernel:
+//
+//	executor, err := codeexecutor.NewKernelExecutor(
+//		codeexecutor.WithKernelAllowUnsafe(true),
+//		codeexecutor.WithKernelIdleTimeout(10*time.Minute),
+//	)
+//
+// A block "x = 5" then makes x visible to the next blocks of the session. The kernels can run
+// in a sandbox with WithKernelCommand, such as "docker exec -i sandbox python3 -u".
+//
+// A block exceeding its timeout is interrupted and the kernel keeps its state. A kernel that
+// dies, or is stopped after being idle, loses its state. The next result reports the loss in
+// its Stderr, and the next block starts a new kernel. Close stops all the kernels.
+//
 // # Error Handling and Retries
 //
 // Robust error handling with configurable retry logic:
@@ -0,0 +1,652 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codeexecutor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+const (
+	// DefaultKernelIdleTimeout is the default time after which an idle kernel is stopped.
+	DefaultKernelIdleTimeout = 30 * time.Minute
+
+	// kernelInterruptGrace is the time given to an interrupted kernel to report the interruption
+	// before it is killed.
+	kernelInterruptGrace = time.Second
+)
+
+// ErrKernelDied reports that the kernel of a session exited, losing the state of the previous
+// executions.
+var ErrKernelDied = errors.New("kernel died")
+
+// kernelDriver is the Python program run by a kernel.
+//
+// It executes the code of each request line in a namespace kept across requests, and writes the
+// captured output as a response line to the original stdout. The file descriptor 1 is redirected
+// to stderr, so that the output of the child processes does not corrupt the responses.
+const kernelDriver = `
+import contextlib, io, json, os, sys, traceback
+_responses = os.fdopen(os.dup(1), "w")
+os.dup2(2, 1)
+_namespace = {"__name__": "__main__"}
+while True:
+    try:
+        _line = sys.stdin.readline()
+    except KeyboardInterrupt:
+        continue
+    if not _line:
+        break
+    _code = json.loads(_line)["code"]
+    _stdout, _stderr, _exit_code = io.StringIO(), io.StringIO(), 0
+    try:
+        with contextlib.redirect_stdout(_stdout), contextlib.redirect_stderr(_stderr):
+            exec(compile(_code, "<cell>", "exec"), _namespace)
+    except BaseException:
+        _stderr.write(traceback.format_exc())
+        _exit_code = 1
+    _responses.write(json.dumps({"stdout": _stdout.getvalue(), "stderr": _stderr.getvalue(), "exit_code": _exit_code}) + "\n")
+    _responses.flush()
+`
+
+// KernelExecutor executes Python code in a kernel kept alive per session, so that the variables,
+// functions and imports of a code block are visible to the next blocks of the same session.
+//
+// The kernels are keyed by the execution ID of the input, or the session ID of the invocation if
+// not set. A kernel that dies is restarted on the next execution, and the lost state is reported
+// in the result. A kernel idle for the idle timeout is stopped.
+//
+// WARNING: By default, the kernels run on the host with the same privileges as the calling
+// process. Use [WithKernelCommand] to run them in a sandbox, such as a container.
+type KernelExecutor struct {
+	config *types.ExecutionConfig
+
+	// allowUnsafe must be explicitly set to true to run the kernels on the host
+	allowUnsafe bool
+
+	// command is the command starting the Python interpreter of a kernel
+	command []string
+
+	// idleTimeout is the time after which an idle kernel is stopped
+	idleTimeout time.Duration
+
+	// workDir is the directory containing the working directory of each kernel
+	workDir string
+
+	// tempDir is used for temporary files when workDir is not specified
+	tempDir string
+
+	mu      sync.Mutex
+	kernels map[string]*kernel // execution ID -> kernel
+	lost    map[string]string  // execution ID -> reason the kernel was stopped, until reported
+	closed  bool
+}
+
+var (
+	_ types.CodeExecutor      = (*KernelExecutor)(nil)
+	_ types.LanguageSupporter = (*KernelExecutor)(nil)
+)
+
+// KernelExecutorOption is a functional option for configuring KernelExecutor.
+type KernelExecutorOption func(*KernelExecutor)
+
+// WithKernelAllowUnsafe explicitly enables running the kernels on the host.
+//
+// It is not required with [WithKernelCommand], whose command is trusted by the caller.
+func WithKernelAllowUnsafe(allow bool) KernelExecutorOption {
+	return func(e *KernelExecutor) {
+		e.allowUnsafe = allow
+	}
+}
+
+// WithKernelCommand sets the command starting the Python interpreter of a kernel, such as
+// "docker exec -i sandbox python3 -u". The driver program of the kernel is appended as a "-c"
+// argument.
+//
+// The default command is "python3 -u".
+func WithKernelCommand(name string, args ...string) KernelExecutorOption {
+	return func(e *KernelExecutor) {
+		e.command = append([]string{name}, args...)
+	}
+}
+
+// WithKernelIdleTimeout sets the time after which an idle kernel is stopped.
+//
+// The default is [DefaultKernelIdleTimeout]. A zero timeout keeps the kernels until Close.
+func WithKernelIdleTimeout(timeout time.Duration) KernelExecutorOption {
+	return func(e *KernelExecutor) {
+		e.idleTimeout = timeout
+	}
+}
+
+// WithKernelWorkDir sets the directory containing the working directory of each kernel.
+func WithKernelWorkDir(dir string) KernelExecutorOption {
+	return func(e *KernelExecutor) {
+		e.workDir = dir
+	}
+}
+
+// NewKernelExecutor creates a new stateful code executor.
+//
+// NOTE(adk-go): This executor requires explicit opt-in to unsafe execution unless the kernel command is set.
+func NewKernelExecutor(opts ...any) (*KernelExecutor, error) {
+	// Separate execution options from kernel executor options
+	var execOpts []types.ExecutionOption
+	var kernelOpts []KernelExecutorOption
+
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case types.ExecutionOption:
+			execOpts = append(execOpts, o)
+		case KernelExecutorOption:
+			kernelOpts = append(kernelOpts, o)
+		default:
+			return nil, fmt.Errorf("unsupported option type: %T", opt)
+		}
+	}
+
+	config := types.DefaultConfig()
+	for _, opt := range execOpts {
+		opt(config)
+	}
+	config.Stateful = true
+
+	executor := &KernelExecutor{
+		config:      config,
+		idleTimeout: DefaultKernelIdleTimeout,
+		kernels:     make(map[string]*kernel),
+		lost:        make(map[string]string),
+	}
+	for _, opt := range kernelOpts {
+		opt(executor)
+	}
+
+	if executor.command == nil {
+		if !executor.allowUnsafe {
+			return nil, fmt.Errorf("kernel executor requires explicit opt-in to unsafe execution via WithKernelAllowUnsafe(true) or a kernel command")
+		}
+		executor.command = []string{"python3", "-u"}
+	}
+
+	// Create temporary directory if no working directory specified
+	if executor.workDir == "" {
+		tempDir, err := os.MkdirTemp("", "adk-kernel-executor-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+		executor.tempDir = tempDir
+		executor.workDir = tempDir
+	}
+
+	return executor, nil
+}
+
+// OptimizeDataFile implements [types.CodeExecutor].
+func (e *KernelExecutor) OptimizeDataFile() bool {
+	return e.config.OptimizeDataFiles
+}
+
+// IsLongRunning implements [types.CodeExecutor].
+func (e *KernelExecutor) IsLongRunning() bool {
+	return e.config.LongRunning
+}
+
+// IsStateful implements [types.CodeExecutor].
+func (e *KernelExecutor) IsStateful() bool {
+	return e.config.Stateful
+}
+
+// ErrorRetryAttempts implements [types.CodeExecutor].
+func (e *KernelExecutor) ErrorRetryAttempts() int {
+	return e.config.MaxRetries
+}
+
+// CodeBlockDelimiters implements [types.CodeExecutor].
+func (e *KernelExecutor) CodeBlockDelimiters() []types.DelimiterPair {
+	return e.config.CodeBlockDelimiters
+}
+
+// SupportedLanguages implements [types.LanguageSupporter].
+func (e *KernelExecutor) SupportedLanguages() []string {
+	return []string{"python"}
+}
+
+// ExecutionResultDelimiters implements [types.CodeExecutor].
+func (e *KernelExecutor) ExecutionResultDelimiters() types.DelimiterPair {
+	return e.config.ExecutionResultDelimiters
+}
+
+// ExecuteCode implements [types.CodeExecutor].
+//
+// An execution exceeding its timeout is interrupted, keeping the state of the kernel, or the
+// kernel is killed if it does not respond to the interruption. If the kernel dies, the result
+// wraps [ErrKernelDied] and the next execution of the session starts a new kernel.
+func (e *KernelExecutor) ExecuteCode(ctx context.Context, ictx *types.InvocationContext, input *types.CodeExecutionInput) (*types.CodeExecutionResult, error) {
+	switch strings.ToLower(input.Language) {
+	case "", "python", "py":
+	default:
+		return nil, fmt.Errorf("kernel executor does not support language %q", input.Language)
+	}
+
+	executionID := input.ExecutionID
+	if executionID == "" && ictx != nil && ictx.Session != nil {
+		executionID = ictx.Session.ID()
+	}
+
+	startTime := time.Now()
+
+	k, lost, err := e.kernel(executionID)
+	if err != nil {
+		return nil, err
+	}
+	defer e.release(k)
+
+	for _, file := range input.InputFiles {
+		if err := file.WriteToFile(filepath.Join(k.workDir, file.Name)); err != nil {
+			return nil, fmt.Errorf("failed to write input file %s: %w", file.Name, err)
+		}
+	}
+	before := snapshotFiles(k.workDir)
+
+	timeout := input.Timeout
+	if timeout == 0 {
+		timeout = e.config.DefaultTimeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result, err := k.execute(ctx, input.Code)
+	if err != nil {
+		e.discard(k)
+		result.Error = fmt.Errorf("%w: %w", ErrKernelDied, err)
+		result.Stderr += fmt.Sprintf("\nThe kernel died (%v) and the state of the previous executions was lost. The next execution starts a new kernel.\n", err)
+	}
+	if lost != "" {
+		result.Stderr = fmt.Sprintf("The previous kernel was stopped as %s, and the state of the previous executions was lost.\n", lost) + result.Stderr
+	}
+
+	result.Code = input.Code
+	result.OutputFiles = changedFiles(k.workDir, before, input.InputFiles)
+	result.Timestamp = startTime
+	result.ExecutionTime = time.Since(startTime)
+	result.ExecutionID = executionID
+
+	return result, nil
+}
+
+// kernel returns the locked kernel of the execution ID, starting it if needed, and the reason the
+// previous kernel of the execution ID was stopped, if not yet reported.
+func (e *KernelExecutor) kernel(executionID string) (*kernel, string, error) {
+	for {
+		e.mu.Lock()
+		if e.closed {
+			e.mu.Unlock()
+			return nil, "", errors.New("kernel executor is closed")
+		}
+
+		k, ok := e.kernels[executionID]
+		lost := ""
+		if !ok {
+			var err error
+			k, err = e.startKernel(executionID)
+			if err != nil {
+				e.mu.Unlock()
+				return nil, "", err
+			}
+			e.kernels[executionID] = k
+			lost = e.lost[executionID]
+			delete(e.lost, executionID)
+		}
+		k.busy++
+		e.mu.Unlock()
+
+		k.mu.Lock()
+		if !k.stopped {
+			return k, lost, nil
+		}
+
+		// The kernel died during the previous execution.
+		k.mu.Unlock()
+		e.mu.Lock()
+		k.busy--
+		e.mu.Unlock()
+	}
+}
+
+// startKernel starts the kernel of the execution ID.
+func (e *KernelExecutor) startKernel(executionID string) (*kernel, error) {
+	workDir := filepath.Join(e.workDir, kernelDirName(executionID))
+	if err := os.MkdirAll(workDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create working directory: %w", err)
+	}
+
+	args := append(slices.Clone(e.command[1:]), "-c", kernelDriver)
+	cmd := exec.Command(e.command[0], args...)
+	cmd.Dir = workDir
+	cmd.Env = os.Environ()
+
+	k := &kernel{
+		id:      executionID,
+		cmd:     cmd,
+		workDir: workDir,
+		stderr:  new(lockedBuffer),
+	}
+	cmd.Stderr = k.stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("create kernel stdin: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("create kernel stdout: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("start kernel: %w", err)
+	}
+	k.stdin = stdin
+	k.stdout = bufio.NewReader(stdout)
+
+	return k, nil
+}
+
+// release unlocks the kernel and schedules it to be stopped when idle.
+func (e *KernelExecutor) release(k *kernel) {
+	k.mu.Unlock()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	k.busy--
+	if e.idleTimeout <= 0 || k.busy > 0 || e.kernels[k.id] != k {
+		return
+	}
+	if k.idle == nil {
+		k.idle = time.AfterFunc(e.idleTimeout, func() { e.reap(k) })
+		return
+	}
+	k.idle.Reset(e.idleTimeout)
+}
+
+// reap stops the kernel if it is still idle.
+func (e *KernelExecutor) reap(k *kernel) {
+	e.mu.Lock()
+	if e.kernels[k.id] != k || k.busy > 0 {
+		e.mu.Unlock()
+		return
+	}
+	delete(e.kernels, k.id)
+	e.lost[k.id] = "it was idle"
+	e.mu.Unlock()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.stop()
+}
+
+// discard removes the locked kernel, whose process exited.
+//
+// The lost state is reported by the result of the current execution.
+func (e *KernelExecutor) discard(k *kernel) {
+	e.mu.Lock()
+	if e.kernels[k.id] == k {
+		delete(e.kernels, k.id)
+	}
+	e.mu.Unlock()
+
+	k.stop()
+}
+
+// Close implements [types.CodeExecutor].
+//
+// It stops all the kernels and removes the temporary directory.
+func (e *KernelExecutor) Close() error {
+	e.mu.Lock()
+	e.closed = true
+	kernels := slices.Collect(maps.Values(e.kernels))
+	clear(e.kernels)
+	e.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, k := range kernels {
+		if k.idle != nil {
+			k.idle.Stop()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			k.mu.Lock()
+			defer k.mu.Unlock()
+			k.stop()
+		}()
+	}
+	wg.Wait()
+
+	// Clean up temporary directory if we created one
+	if e.tempDir != "" {
+		if err := os.RemoveAll(e.tempDir); err != nil {
+			return fmt.Errorf("failed to clean up temporary directory: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// kernel is a Python interpreter running the kernel driver.
+type kernel struct {
+	id      string
+	cmd     *exec.Cmd
+	stdin   io.WriteCloser
+	stdout  *bufio.Reader
+	stderr  *lockedBuffer
+	workDir string
+
+	// idle stops the kernel when idle, and busy counts the executions holding or waiting for the
+	// kernel. Both are guarded by the mutex of the executor.
+	idle *time.Timer
+	busy int
+
+	// mu serializes the executions
+	mu      sync.Mutex
+	stopped bool
+}
+
+// kernelResponse is the response line of the kernel driver.
+type kernelResponse struct {
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// execute executes the code in the kernel, and returns an error only if the kernel died.
+func (k *kernel) execute(ctx context.Context, code string) (*types.CodeExecutionResult, error) {
+	request, err := json.Marshal(map[string]string{"code": code}, json.DefaultOptionsV2())
+	if err != nil {
+		// The code cannot be sent, as with invalid UTF-8, but the kernel is still alive.
+		return &types.CodeExecutionResult{
+			Stderr:   fmt.Sprintf("The code could not be sent to the kernel: %v\n", err),
+			ExitCode: 1,
+		}, nil
+	}
+	if _, err := k.stdin.Write(append(request, '\n')); err != nil {
+		return &types.CodeExecutionResult{ExitCode: 1}, fmt.Errorf("write request: %w", err)
+	}
+
+	type readResult struct {
+		response kernelResponse
+		err      error
+	}
+	read := make(chan readResult, 1)
+	go func() {
+		var r readResult
+		line, err := k.stdout.ReadBytes('\n')
+		if err != nil {
+			r.err = err
+		} else {
+			r.err = json.Unmarshal(line, &r.response, json.DefaultOptionsV2())
+		}
+		read <- r
+	}()
+
+	var r readResult
+	select {
+	case r = <-read:
+	case <-ctx.Done():
+		// Interrupt the execution, raising KeyboardInterrupt in the code.
+		_ = k.cmd.Process.Signal(os.Interrupt)
+		select {
+		case r = <-read:
+		case <-time.After(kernelInterruptGrace):
+			_ = k.cmd.Process.Kill()
+			r = <-read
+		}
+	}
+
+	if r.err != nil {
+		err := k.wait(r.err)
+		return &types.CodeExecutionResult{
+			Stdout:   r.response.Stdout,
+			Stderr:   r.response.Stderr + k.stderr.Flush(),
+			ExitCode: 1,
+		}, err
+	}
+	result := &types.CodeExecutionResult{
+		Stdout:   r.response.Stdout,
+		Stderr:   r.response.Stderr + k.stderr.Flush(),
+		ExitCode: r.response.ExitCode,
+	}
+	if ctx.Err() != nil {
+		result.Stderr += fmt.Sprintf("\nThe execution was interrupted: %v.\n", ctx.Err())
+	}
+
+	return result, nil
+}
+
+// wait waits for the process of the kernel, whose output ended with err, and returns the cause.
+func (k *kernel) wait(err error) error {
+	_ = k.cmd.Process.Kill()
+	k.stopped = true
+	if werr := k.cmd.Wait(); werr != nil {
+		return werr
+	}
+	if errors.Is(err, io.EOF) {
+		return errors.New("kernel exited")
+	}
+	return err
+}
+
+// stop stops the process of the kernel, giving it the interrupt grace to exit by itself.
+func (k *kernel) stop() {
+	if k.stopped {
+		return
+	}
+	k.stopped = true
+
+	k.stdin.Close()
+	done := make(chan struct{})
+	go func() {
+		_ = k.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(kernelInterruptGrace):
+		_ = k.cmd.Process.Kill()
+		<-done
+	}
+}
+
+// lockedBuffer is a [bytes.Buffer] safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write implements [io.Writer].
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Flush returns the buffered data and resets the buffer.
+func (b *lockedBuffer) Flush() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.buf.String()
+	b.buf.Reset()
+	return s
+}
+
+// kernelDirName returns the name of the working directory of the kernel of the execution ID.
+func kernelDirName(executionID string) string {
+	if executionID == "" {
+		return "default"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, executionID)
+}
+
+// snapshotFiles returns the modification time of the files of the directory.
+func snapshotFiles(dir string) map[string]time.Time {
+	files := make(map[string]time.Time)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return files
+	}
+	for _, entry := range entries {
+		if info, err := entry.Info(); err == nil && !entry.IsDir() {
+			files[entry.Name()] = info.ModTime()
+		}
+	}
+	return files
+}
+
+// changedFiles returns the files of the directory created or modified since the snapshot,
+// except the input files.
+func changedFiles(dir string, before map[string]time.Time, inputFiles []*types.CodeExecutionFile) []*types.CodeExecutionFile {
+	var outputFiles []*types.CodeExecutionFile
+	for name, modTime := range snapshotFiles(dir) {
+		if prev, ok := before[name]; ok && prev.Equal(modTime) {
+			continue
+		}
+		if slices.ContainsFunc(inputFiles, func(f *types.CodeExecutionFile) bool { return f.Name == name }) {
+			continue
+		}
+		file, err := types.NewExecutionFileFromPath(filepath.Join(dir, name))
+		if err != nil {
+			continue // Skip files we can't read
+		}
+		outputFiles = append(outputFiles, file)
+	}
+	slices.SortFunc(outputFiles, func(a, b *types.CodeExecutionFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return outputFiles
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package codeexecutor_test
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/codeexecutor"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func newKernelExecutor(t *testing.T, opts ...any) *codeexecutor.KernelExecutor {
+	t.Helper()
+
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not found")
+	}
+	executor, err := codeexecutor.NewKernelExecutor(append([]any{codeexecutor.WithKernelAllowUnsafe(true)}, opts...)...)
+	if err != nil {
+		t.Fatalf("NewKernelExecutor() error = %v", err)
+	}
+	t.Cleanup(func() {
+		if err := executor.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+	})
+	return executor
+}
+
+func execute(t *testing.T, executor *codeexecutor.KernelExecutor, executionID, code string) *types.CodeExecutionResult {
+	t.Helper()
+
+	result, err := executor.ExecuteCode(t.Context(), nil, &types.CodeExecutionInput{Code: code, ExecutionID: executionID})
+	if err != nil {
+		t.Fatalf("ExecuteCode(%q) error = %v", code, err)
+	}
+	return result
+}
+
+func TestKernelExecutorKeepsState(t *testing.T) {
+	t.Parallel()
+
+	executor := newKernelExecutor(t)
+
+	execute(t, executor, "s1", "x = 5\nimport math")
+	execute(t, executor, "s2", "x = 1")
+	if got := execute(t, executor, "s1", "print(x * 2, math.floor(1.5))"); got.Stdout != "10 1\n" || got.ExitCode != 0 {
+		t.Errorf("ExecuteCode() = (%q, %d), want (%q, 0)", got.Stdout, got.ExitCode, "10 1\n")
+	}
+
+	got := execute(t, executor, "s1", "raise ValueError('boom')")
+	if got.ExitCode != 1 || !strings.Contains(got.Stderr, "ValueError: boom") {
+		t.Errorf("ExecuteCode() = (%q, %d), want ValueError", got.Stderr, got.ExitCode)
+	}
+	if got := execute(t, executor, "s1", "print(x)"); got.Stdout != "5\n" {
+		t.Errorf("state after error: Stdout = %q, want %q", got.Stdout, "5\n")
+	}
+}
+
+func TestKernelExecutorCrash(t *testing.T) {
+	t.Parallel()
+
+	executor := newKernelExecutor(t)
+
+	execute(t, executor, "s1", "x = 5")
+	got := execute(t, executor, "s1", "import os\nos._exit(3)")
+	if !errors.Is(got.Error, codeexecutor.ErrKernelDied) {
+		t.Errorf("ExecuteCode() Error = %v, want %v", got.Error, codeexecutor.ErrKernelDied)
+	}
+	if !strings.Contains(got.Stderr, "state of the previous executions was lost") {
+		t.Errorf("ExecuteCode() Stderr = %q, want lost state reported", got.Stderr)
+	}
+
+	// The kernel is restarted with an empty namespace.
+	got = execute(t, executor, "s1", "print('x' in globals())")
+	if got.Stdout != "False\n" || got.Error != nil {
+		t.Errorf("ExecuteCode() after crash = (%q, %v), want (%q, nil)", got.Stdout, got.Error, "False\n")
+	}
+}
+
+func TestKernelExecutorInvalidUTF8(t *testing.T) {
+	t.Parallel()
+
+	executor := newKernelExecutor(t)
+
+	execute(t, executor, "s1", "x = 5")
+	got := execute(t, executor, "s1", "print('\xff')")
+	if got.ExitCode != 1 || got.Error != nil || !strings.Contains(got.Stderr, "could not be sent") {
+		t.Errorf("ExecuteCode() = (%q, %d, %v), want the code rejected", got.Stderr, got.ExitCode, got.Error)
+	}
+
+	// The kernel is kept with its state.
+	if got := execute(t, executor, "s1", "print(x)"); got.Stdout != "5\n" {
+		t.Errorf("state after invalid code: Stdout = %q, want %q", got.Stdout, "5\n")
+	}
+}
+
+func TestKernelExecutorTimeout(t *testing.T) {
+	t.Parallel()
+
+	executor := newKernelExecutor(t)
+
+	execute(t, executor, "s1", "x = 5")
+	result, err := executor.ExecuteCode(t.Context(), nil, &types.CodeExecutionInput{
+		Code:        "import time\ntime.sleep(60)",
+		ExecutionID: "s1",
+		Timeout:     200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("ExecuteCode() error = %v", err)
+	}
+	if result.ExitCode != 1 || !strings.Contains(result.Stderr, "KeyboardInterrupt") {
+		t.Errorf("ExecuteCode() = (%q, %d), want KeyboardInterrupt", result.Stderr, result.ExitCode)
+	}
+
+	// The interrupted kernel keeps its state.
+	if got := execute(t, executor, "s1", "print(x)"); got.Stdout != "5\n" {
+		t.Errorf("state after interrupt: Stdout = %q, want %q", got.Stdout, "5\n")
+	}
+}
+
+func TestKernelExecutorIdleTimeout(t *testing.T) {
+	t.Parallel()
+
+	executor := newKernelExecutor(t, codeexecutor.WithKernelIdleTimeout(50*time.Millisecond))
+
+	execute(t, executor, "s1", "x = 5")
+	time.Sleep(300 * time.Millisecond)
+
+	got := execute(t, executor, "s1", "print('x' in globals())")
+	if got.Stdout != "False\n" {
+		t.Errorf("ExecuteCode() after idle timeout Stdout = %q, want %q", got.Stdout, "False\n")
+	}
+	if !strings.Contains(got.Stderr, "idle") {
+		t.Errorf("ExecuteCode() after idle timeout Stderr = %q, want idle kernel reported", got.Stderr)
+	}
+}
+
+func TestNewKernelExecutorRequiresOptIn(t *testing.T) {
+	t.Parallel()
+
+	if _, err := codeexecutor.NewKernelExecutor(); err == nil {
+		t.Error("NewKernelExecutor() without opt-in succeeded")
+	}
+}
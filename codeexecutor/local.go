@@ -13,6 +13,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-a2a/adk-go/pkg/backoff"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -36,7 +37,10 @@ type LocalExecutor struct {
 	stateful bool
 }
 
-var _ types.CodeExecutor = (*LocalExecutor)(nil)
+var (
+	_ types.CodeExecutor      = (*LocalExecutor)(nil)
+	_ types.LanguageSupporter = (*LocalExecutor)(nil)
+)
 
 // LocalExecutorOption is a functional option for configuring LocalExecutor.
 type LocalExecutorOption func(*LocalExecutor)
@@ -139,6 +143,11 @@ func (e *LocalExecutor) CodeBlockDelimiters() []types.DelimiterPair {
 	return e.config.CodeBlockDelimiters
 }
 
+// SupportedLanguages implements [types.LanguageSupporter].
+func (e *LocalExecutor) SupportedLanguages() []string {
+	return []string{"python", "go", "javascript", "bash"}
+}
+
 // ExecutionResultDelimiters implements [types.CodeExecutor].
 func (e *LocalExecutor) ExecutionResultDelimiters() types.DelimiterPair {
 	return e.config.ExecutionResultDelimiters
@@ -163,28 +172,14 @@ func (e *LocalExecutor) ExecuteCode(ctx context.Context, ictx *types.InvocationC
 
 	// Execute with retry logic
 	var result *types.CodeExecutionResult
-	var lastErr error
-
-	for attempt := 0; attempt <= e.config.MaxRetries; attempt++ {
-		if attempt > 0 {
-			select {
-			case <-ctx.Done():
-				return nil, ctx.Err()
-			case <-time.After(e.config.RetryDelay):
-				// Continue with retry
-			}
-		}
-
-		result, lastErr = e.executeCode(ctx, input, workDir, execCtx)
-		if lastErr == nil {
-			break
-		}
-
-		// Increment error count
-		if ictx != nil {
+	lastErr := backoff.Retry(ctx, retryPolicy(e.config), func() error {
+		var err error
+		result, err = e.executeCode(ctx, input, workDir, execCtx)
+		if err != nil && ictx != nil {
 			execCtx.IncrementErrorCount(ictx.InvocationID)
 		}
-	}
+		return err
+	}, nil)
 
 	if lastErr != nil {
 		return &types.CodeExecutionResult{
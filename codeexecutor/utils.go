@@ -7,15 +7,25 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
-	"regexp"
+	"slices"
 	"strings"
 
 	"google.golang.org/genai"
 
 	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/pkg/backoff"
 	"github.com/go-a2a/adk-go/types"
 )
 
+// retryPolicy returns the policy retrying a failed execution up to MaxRetries times, after a
+// constant RetryDelay.
+func retryPolicy(config *types.ExecutionConfig) backoff.Policy {
+	return backoff.Policy{
+		Backoff:     backoff.Backoff{Base: config.RetryDelay, Factor: 1},
+		MaxAttempts: max(config.MaxRetries, 0) + 1,
+	}
+}
+
 // CodeExecutionUtils represents an utility functions for code execution.
 type CodeExecutionUtils struct{}
 
@@ -36,65 +46,87 @@ func (e *CodeExecutionUtils) GetEncodedFileContent(data []byte) []byte {
 	return buf
 }
 
-// ExtractCodeAndTruncateContent extracts the first code block from the content and truncate everything after it.
+// ExtractCodeAndTruncateContent extracts the first python code block from the content and truncate everything after it.
+//
+// See [CodeExecutionUtils.ExtractCodeBlockAndTruncateContent].
 func (e *CodeExecutionUtils) ExtractCodeAndTruncateContent(content *genai.Content, codeBlockDelimiters []types.DelimiterPair) string {
-	if content == nil || len(content.Parts) == 0 {
+	block := e.ExtractCodeBlockAndTruncateContent(content, codeBlockDelimiters, []string{"python"})
+	if block == nil {
 		return ""
 	}
+	return block.Code
+}
+
+// ExtractCodeBlockAndTruncateContent extracts the first code block of one of the allowed languages
+// from the content, and truncates the content to the text before the block followed by the block as
+// an executable code part. The language of the returned block is the [NormalizeLanguage] form of its
+// tag.
+//
+// The unlabeled blocks and the blocks of the other languages are not executed: they are left in the
+// text before the extracted block, or the content is left unchanged and nil is returned if no block
+// is allowed.
+func (e *CodeExecutionUtils) ExtractCodeBlockAndTruncateContent(content *genai.Content, codeBlockDelimiters []types.DelimiterPair, allowed []string) *CodeBlock {
+	if content == nil || len(content.Parts) == 0 {
+		return nil
+	}
 
 	// Extract the code from the executable code parts if there're no associated
 	// code execution result parts.
 	for i, part := range content.Parts {
-		if (part.ExecutableCode != nil || i == len(content.Parts)-1) || (content.Parts[i+1].CodeExecutionResult == nil) {
-			content.Parts = content.Parts[:i+1]
-			return part.ExecutableCode.Code
+		if part.ExecutableCode == nil {
+			continue
+		}
+		if i < len(content.Parts)-1 && content.Parts[i+1].CodeExecutionResult != nil {
+			continue
+		}
+		language := NormalizeLanguage(string(part.ExecutableCode.Language))
+		if !LanguageAllowed(language, allowed) {
+			continue
 		}
+		content.Parts = content.Parts[:i+1]
+		return &CodeBlock{Language: language, Code: part.ExecutableCode.Code}
 	}
 
 	// Extract the code from the text parts.
-	textParts := []*genai.Part{}
+	var (
+		firstTextPart *genai.Part
+		responseTexts []string
+	)
 	for _, part := range content.Parts {
-		if part.Text != "" {
-			textParts = append(textParts, part)
+		if part.Text == "" {
+			continue
 		}
+		if firstTextPart == nil {
+			firstTextPart = part
+		}
+		responseTexts = append(responseTexts, part.Text)
 	}
-
-	firstTextPart := textParts[0]
-	responseTexts := make([]string, len(textParts))
-	for i, p := range textParts {
-		responseTexts[i] = p.Text
+	if firstTextPart == nil {
+		return nil
 	}
 	responseText := strings.Join(responseTexts, "\n")
 
-	leadingDelimiterPatterns := make([]string, len(codeBlockDelimiters))
-	trailingDelimiterPatterns := make([]string, len(codeBlockDelimiters))
-	for i, delimiters := range codeBlockDelimiters {
-		leadingDelimiterPatterns[i] = delimiters.Start
-		trailingDelimiterPatterns[i] = delimiters.End
-	}
-
-	leadingDelimiterPattern := strings.Join(leadingDelimiterPatterns, "|")
-	trailingDelimiterPattern := strings.Join(trailingDelimiterPatterns, "|")
-	// rf'(?P<prefix>.*?)({leading_delimiter_pattern})(?P<code>.*?)({trailing_delimiter_pattern})(?P<suffix>.*?)$' in Python
-	patternRe := regexp.MustCompile(`(.*?)(` + leadingDelimiterPattern + `)(.*?)(` + trailingDelimiterPattern + `)(.*?)$`)
-	patternMatch := patternRe.FindStringSubmatch(responseText)
-	if len(patternMatch) == 0 {
-		return ""
+	blocks, err := NewCodeBlockParser(codeBlockDelimiters).ExtractCodeBlocks(responseText)
+	if err != nil {
+		return nil
 	}
-
-	codeStr := patternMatch[2] // group('code')
-	if codeStr == "" {
-		return ""
+	idx := slices.IndexFunc(blocks, func(block *CodeBlock) bool {
+		return block.Code != "" && LanguageAllowed(block.Language, allowed)
+	})
+	if idx < 0 {
+		return nil
 	}
+	block := blocks[idx]
+	block.Language = NormalizeLanguage(block.Language)
 
-	clear(content.Parts)
-	if prefix := patternMatch[0]; prefix != "" { // group('prefix')
+	parts := make([]*genai.Part, 0, 2)
+	if prefix := responseText[:block.Start]; strings.TrimSpace(prefix) != "" {
 		firstTextPart.Text = prefix
-		content.Parts = append(content.Parts, firstTextPart)
+		parts = append(parts, firstTextPart)
 	}
-	content.Parts = append(content.Parts, e.BuildExecutableCodePart(codeStr))
+	content.Parts = append(parts, e.buildExecutableCodePart(block.Code, block.Language))
 
-	return patternMatch[2] // group('code')
+	return block
 }
 
 // BuildExecutableCodePart builds an executable code part with code string.
@@ -102,6 +134,15 @@ func (e *CodeExecutionUtils) BuildExecutableCodePart(code string) *genai.Part {
 	return genai.NewPartFromExecutableCode(code, genai.LanguagePython)
 }
 
+// buildExecutableCodePart builds an executable code part with code string of the language, which
+// the part only tells if it is python.
+func (e *CodeExecutionUtils) buildExecutableCodePart(code, language string) *genai.Part {
+	if language == "python" {
+		return e.BuildExecutableCodePart(code)
+	}
+	return genai.NewPartFromExecutableCode(code, genai.LanguageUnspecified)
+}
+
 // BuildCodeExecutionResultPart builds the code execution result part from the code execution result.
 func (e *CodeExecutionUtils) BuildCodeExecutionResultPart(codeExecutionResult *types.CodeExecutionResult) *genai.Part {
 	if codeExecutionResult.Stderr != "" {
@@ -93,6 +93,16 @@
 //	// Retrieve examples based on semantic similarity
 //	examples, err := provider.GetExamples(ctx, "technical documentation questions")
 //
+// # Local Similarity Search
+//
+// The InMemoryProvider selects the examples by the cosine similarity of the embeddings of their
+// input, computed locally with any Embedder, for development and small example sets:
+//
+//	provider, err := example.NewInMemoryProvider(ctx, examples, embedder,
+//		example.WithTopK(3),
+//		example.WithMinSimilarity(0.7),
+//	)
+//
 // # Tool Integration
 //
 // Examples can include tool usage patterns:
@@ -114,6 +124,17 @@
 //		}},
 //	}
 //
+// Stale examples can teach the model to call tools that no longer exist. ValidateAgainstTools
+// checks the tool calls of the examples against the tools of the agent, their names and the
+// arguments their declarations accept, whether declared as a genai.Schema or as a JSON Schema, and
+// WithToolValidation makes the formatting fail fast on a mismatch:
+//
+//	for _, err := range example.ValidateAgainstTools(examples, agentTools) {
+//		log.Println(err)
+//	}
+//
+//	si, err := example.BuildExampleSI(ctx, examples, query, modelName, example.WithToolValidation(agentTools))
+//
 // # Dynamic Example Selection
 //
 // Providers can implement intelligent example selection:
@@ -0,0 +1,189 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package example
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Embedder embeds texts into vectors for the similarity search.
+type Embedder interface {
+	// Embed returns the embedding of each text, in the order of the texts.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EmbedderFunc is an adapter to use an ordinary function as an [Embedder].
+type EmbedderFunc func(ctx context.Context, texts []string) ([][]float32, error)
+
+var _ Embedder = EmbedderFunc(nil)
+
+// Embed implements [Embedder].
+func (f EmbedderFunc) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	return f(ctx, texts)
+}
+
+const (
+	// DefaultInMemoryTopK is the default number of examples returned by the [InMemoryProvider].
+	DefaultInMemoryTopK = 10
+
+	// DefaultInMemoryMinSimilarity is the default minimum cosine similarity of the examples
+	// returned by the [InMemoryProvider].
+	DefaultInMemoryMinSimilarity = 0.5
+)
+
+// InMemoryProvider is a [Provider] selecting the examples most similar to the query by the cosine
+// similarity of the embeddings of their input, without any cloud call.
+//
+// It is a local alternative to the [VertexAIExampleStore] for development and small example sets.
+type InMemoryProvider struct {
+	embedder      Embedder
+	topK          int
+	minSimilarity float64
+
+	examples   []*Example
+	embeddings [][]float32
+}
+
+var _ Provider = (*InMemoryProvider)(nil)
+
+// InMemoryProviderOption configures an [InMemoryProvider].
+type InMemoryProviderOption func(*InMemoryProvider)
+
+// WithTopK sets the maximum number of examples returned for a query.
+//
+// The default is [DefaultInMemoryTopK]; zero or less means no limit.
+func WithTopK(k int) InMemoryProviderOption {
+	return func(p *InMemoryProvider) {
+		p.topK = k
+	}
+}
+
+// WithMinSimilarity sets the minimum cosine similarity between the query and the input of an
+// example for the example to be returned.
+//
+// The default is [DefaultInMemoryMinSimilarity].
+func WithMinSimilarity(similarity float64) InMemoryProviderOption {
+	return func(p *InMemoryProvider) {
+		p.minSimilarity = similarity
+	}
+}
+
+// NewInMemoryProvider creates a new [InMemoryProvider] from the given examples, embedding the text
+// of their input with embedder.
+func NewInMemoryProvider(ctx context.Context, examples []*Example, embedder Embedder, opts ...InMemoryProviderOption) (*InMemoryProvider, error) {
+	if embedder == nil {
+		return nil, errors.New("embedder is required")
+	}
+
+	p := &InMemoryProvider{
+		embedder:      embedder,
+		topK:          DefaultInMemoryTopK,
+		minSimilarity: DefaultInMemoryMinSimilarity,
+		examples:      examples,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if len(examples) == 0 {
+		return p, nil
+	}
+
+	inputs := make([]string, len(examples))
+	for i, example := range examples {
+		inputs[i] = exampleInputText(example)
+	}
+	embeddings, err := embedder.Embed(ctx, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("embed examples: %w", err)
+	}
+	if len(embeddings) != len(examples) {
+		return nil, fmt.Errorf("embed examples: got %d embeddings for %d examples", len(embeddings), len(examples))
+	}
+	p.embeddings = embeddings
+
+	return p, nil
+}
+
+// GetExamples returns the examples most similar to the query, the most similar first.
+func (p *InMemoryProvider) GetExamples(ctx context.Context, query string) ([]*Example, error) {
+	if len(p.examples) == 0 {
+		return nil, nil
+	}
+
+	embeddings, err := p.embedder.Embed(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query: %w", err)
+	}
+	if len(embeddings) != 1 {
+		return nil, fmt.Errorf("embed query: got %d embeddings, want 1", len(embeddings))
+	}
+	queryEmbedding := embeddings[0]
+
+	type scored struct {
+		example    *Example
+		similarity float64
+	}
+	var matches []scored
+	for i, embedding := range p.embeddings {
+		similarity := cosineSimilarity(queryEmbedding, embedding)
+		if similarity < p.minSimilarity {
+			continue
+		}
+		matches = append(matches, scored{example: p.examples[i], similarity: similarity})
+	}
+	slices.SortStableFunc(matches, func(a, b scored) int {
+		return cmp.Compare(b.similarity, a.similarity)
+	})
+	if p.topK > 0 && len(matches) > p.topK {
+		matches = matches[:p.topK]
+	}
+
+	examples := make([]*Example, len(matches))
+	for i, match := range matches {
+		examples[i] = match.example
+	}
+
+	return examples, nil
+}
+
+// exampleInputText returns the text parts of the input of the example.
+func exampleInputText(example *Example) string {
+	if example.Input == nil {
+		return ""
+	}
+
+	var texts []string
+	for _, part := range example.Input.Parts {
+		if part.Text != "" {
+			texts = append(texts, part.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// cosineSimilarity returns the cosine similarity of the vectors, or zero if their lengths differ
+// or one of them is zero.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
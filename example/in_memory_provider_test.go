@@ -0,0 +1,110 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package example_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/example"
+)
+
+// topicEmbedder embeds a text as its number of occurrences of each topic word.
+var topicEmbedder = example.EmbedderFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+	topics := []string{"weather", "stock", "time"}
+	embeddings := make([][]float32, len(texts))
+	for i, text := range texts {
+		embeddings[i] = make([]float32, len(topics))
+		for j, topic := range topics {
+			for k := 0; k+len(topic) <= len(text); k++ {
+				if text[k:k+len(topic)] == topic {
+					embeddings[i][j]++
+				}
+			}
+		}
+	}
+	return embeddings, nil
+})
+
+func newExample(input string) *example.Example {
+	return &example.Example{
+		Input:  genai.NewContentFromText(input, genai.RoleUser),
+		Output: []*genai.Content{genai.NewContentFromText("answer to "+input, genai.RoleModel)},
+	}
+}
+
+func TestInMemoryProvider_GetExamples(t *testing.T) {
+	t.Parallel()
+
+	examples := []*example.Example{
+		newExample("weather in Tokyo"),
+		newExample("stock price of GOOG"),
+		newExample("weather and time in Paris"),
+	}
+
+	tests := map[string]struct {
+		opts  []example.InMemoryProviderOption
+		query string
+		want  []string
+	}{
+		"most similar first": {
+			query: "what is the weather",
+			want:  []string{"weather in Tokyo", "weather and time in Paris"},
+		},
+		"top k": {
+			opts:  []example.InMemoryProviderOption{example.WithTopK(1)},
+			query: "what is the weather",
+			want:  []string{"weather in Tokyo"},
+		},
+		"min similarity": {
+			opts:  []example.InMemoryProviderOption{example.WithMinSimilarity(0.9)},
+			query: "what is the weather",
+			want:  []string{"weather in Tokyo"},
+		},
+		"no match": {
+			query: "hello",
+			want:  []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := example.NewInMemoryProvider(t.Context(), examples, topicEmbedder, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewInMemoryProvider() error = %v", err)
+			}
+			got, err := provider.GetExamples(t.Context(), tt.query)
+			if err != nil {
+				t.Fatalf("GetExamples() error = %v", err)
+			}
+
+			inputs := make([]string, len(got))
+			for i, ex := range got {
+				inputs[i] = ex.Input.Parts[0].Text
+			}
+			if diff := cmp.Diff(tt.want, inputs); diff != "" {
+				t.Errorf("GetExamples() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewInMemoryProvider_EmbedError(t *testing.T) {
+	t.Parallel()
+
+	errEmbed := errors.New("embedding failed")
+	embedder := example.EmbedderFunc(func(context.Context, []string) ([][]float32, error) {
+		return nil, errEmbed
+	})
+
+	_, err := example.NewInMemoryProvider(t.Context(), []*example.Example{newExample("hi")}, embedder)
+	if !errors.Is(err, errEmbed) {
+		t.Errorf("NewInMemoryProvider() error = %v, want %v", err, errEmbed)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/go-a2a/adk-go/internal/pool"
 	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
 )
 
 // Constant parts of the example string.
@@ -31,10 +32,40 @@ const (
 	FunctionResponseSuffix = "\n```\n"
 )
 
+// formatConfig is the configuration of the formatting of the examples.
+type formatConfig struct {
+	// tools the tool calls of the examples are validated against, if non-nil.
+	tools []types.Tool
+}
+
+// FormatOption configures [ConvertExamplesToText] and [BuildExampleSI].
+type FormatOption func(*formatConfig)
+
+// WithToolValidation makes the formatting fail fast when a tool call of the examples does not
+// match the tools, reporting the mismatches of [ValidateAgainstTools] joined in one error.
+func WithToolValidation(tools []types.Tool) FormatOption {
+	return func(c *formatConfig) {
+		c.tools = tools
+		if c.tools == nil {
+			c.tools = []types.Tool{}
+		}
+	}
+}
+
 // ConvertExamplesToText converts a list of examples to a string that can be used in a system instruction.
 //
 // TODO(adk-python: yaojie): Add unit tests for this function.
-func ConvertExamplesToText(examples []*Example, modelStr string) (string, error) {
+func ConvertExamplesToText(examples []*Example, modelStr string, opts ...FormatOption) (string, error) {
+	var cfg formatConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.tools != nil {
+		if errs := ValidateAgainstTools(examples, cfg.tools); len(errs) > 0 {
+			return "", errors.Join(errs...)
+		}
+	}
+
 	var (
 		examplesStr strings.Builder
 		otuput      strings.Builder
@@ -115,16 +146,16 @@ func ConvertExamplesToText(examples []*Example, modelStr string) (string, error)
 }
 
 // BuildExampleSI builds a system instruction string from examples.
-func BuildExampleSI[T any](ctx context.Context, examples T, query, modelStr string) (string, error) {
+func BuildExampleSI[T any](ctx context.Context, examples T, query, modelStr string, opts ...FormatOption) (string, error) {
 	switch examples := any(examples).(type) {
 	case []*Example:
-		return ConvertExamplesToText(examples, modelStr)
+		return ConvertExamplesToText(examples, modelStr, opts...)
 	case Provider:
 		exmpls, err := examples.GetExamples(ctx, query)
 		if err != nil {
 			return "", err
 		}
-		return ConvertExamplesToText(exmpls, modelStr)
+		return ConvertExamplesToText(exmpls, modelStr, opts...)
 	default:
 		return "", errors.New("Invalid example configuration")
 	}
@@ -0,0 +1,252 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package example
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/modelcontextprotocol/go-sdk/jsonschema"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// ErrToolMismatch is reported for a tool call of an example that does not match the tools of the
+// agent, see [ValidateAgainstTools].
+//
+// The concrete error is a [*ToolMismatchError]; use [errors.Is] to match it.
+var ErrToolMismatch = errors.New("example tool call does not match the tools")
+
+// ToolMismatchError is the error for a tool call of an example that calls an unknown tool, or with
+// arguments its declaration does not accept.
+type ToolMismatchError struct {
+	// Example is the index of the example in the validated examples.
+	Example int
+
+	// Tool is the name of the called tool.
+	Tool string
+
+	// Arg is the name of the mismatching argument, or empty if the tool itself is unknown.
+	Arg string
+
+	// Reason describes the mismatch.
+	Reason string
+}
+
+var _ error = (*ToolMismatchError)(nil)
+
+// Error implements error.
+func (e *ToolMismatchError) Error() string {
+	if e.Arg == "" {
+		return fmt.Sprintf("example %d: tool %s: %s", e.Example+1, e.Tool, e.Reason)
+	}
+	return fmt.Sprintf("example %d: tool %s: argument %s: %s", e.Example+1, e.Tool, e.Arg, e.Reason)
+}
+
+// Is reports whether the target is [ErrToolMismatch].
+func (e *ToolMismatchError) Is(target error) bool {
+	return target == ErrToolMismatch
+}
+
+// ValidateAgainstTools checks that the tool calls demonstrated by the examples, the function calls
+// of their outputs formatted as tool_code, call tools among the given ones with arguments their
+// declarations accept, so that stale examples do not teach the model to call tools that do not
+// exist.
+//
+// It returns a [*ToolMismatchError] per unknown tool, unknown argument, missing required argument
+// and argument of the wrong type or outside of its enum, or nil if the examples all match. The
+// arguments of a tool declaring its parameters as a JSON Schema, in ParametersJsonSchema, are
+// validated against it, with a single [*ToolMismatchError] per call describing the first
+// violation. The arguments of a tool declaring no parameters schema are not checked.
+func ValidateAgainstTools(examples []*Example, tools []types.Tool) []error {
+	declarations := make(map[string]*genai.FunctionDeclaration, len(tools))
+	for _, tool := range tools {
+		if tool != nil {
+			declarations[tool.Name()] = tool.GetDeclaration()
+		}
+	}
+
+	var errs []error
+	for i, example := range examples {
+		if example == nil {
+			continue
+		}
+		for _, content := range example.Output {
+			if content == nil {
+				continue
+			}
+			for _, part := range content.Parts {
+				if part == nil || part.FunctionCall == nil {
+					continue
+				}
+				call := part.FunctionCall
+				declaration, ok := declarations[call.Name]
+				if !ok {
+					errs = append(errs, &ToolMismatchError{Example: i, Tool: call.Name, Reason: "no such tool"})
+					continue
+				}
+				var mismatches []*ToolMismatchError
+				switch {
+				case declaration == nil:
+				case declaration.Parameters != nil:
+					mismatches = checkArgs(declaration.Parameters, call.Args)
+				case declaration.ParametersJsonSchema != nil:
+					mismatches = checkJSONSchemaArgs(declaration.ParametersJsonSchema, call.Args)
+				}
+				for _, mismatch := range mismatches {
+					mismatch.Example, mismatch.Tool = i, call.Name
+					errs = append(errs, mismatch)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// checkArgs returns the mismatches of the arguments of a call with the parameters schema, in the
+// order of the argument names.
+func checkArgs(params *genai.Schema, args map[string]any) []*ToolMismatchError {
+	var mismatches []*ToolMismatchError
+	for _, name := range params.Required {
+		if _, ok := args[name]; !ok {
+			mismatches = append(mismatches, &ToolMismatchError{Arg: name, Reason: "required argument missing"})
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		schema, ok := params.Properties[name]
+		if !ok {
+			if len(params.Properties) > 0 {
+				mismatches = append(mismatches, &ToolMismatchError{Arg: name, Reason: "no such parameter"})
+			}
+			continue
+		}
+		if reason := checkValue(schema, args[name]); reason != "" {
+			mismatches = append(mismatches, &ToolMismatchError{Arg: name, Reason: reason})
+		}
+	}
+	return mismatches
+}
+
+// checkValue returns why the value does not match the schema, or an empty string if it matches.
+func checkValue(schema *genai.Schema, v any) string {
+	if schema == nil {
+		return ""
+	}
+	typ := genai.Type(strings.ToUpper(string(schema.Type)))
+	if v == nil {
+		if schema.Nullable != nil && *schema.Nullable || typ == "" || typ == genai.TypeNULL {
+			return ""
+		}
+		return fmt.Sprintf("null, want %s", typ)
+	}
+
+	switch typ {
+	case genai.TypeString:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Sprintf("%T, want %s", v, typ)
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			return fmt.Sprintf("%q, want one of %q", s, schema.Enum)
+		}
+	case genai.TypeBoolean:
+		if _, ok := v.(bool); !ok {
+			return fmt.Sprintf("%T, want %s", v, typ)
+		}
+	case genai.TypeNumber, genai.TypeInteger:
+		f, ok := toFloat(v)
+		if !ok {
+			return fmt.Sprintf("%T, want %s", v, typ)
+		}
+		if typ == genai.TypeInteger && f != math.Trunc(f) {
+			return fmt.Sprintf("%v, want %s", v, typ)
+		}
+	case genai.TypeArray:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Sprintf("%T, want %s", v, typ)
+		}
+		for i := range rv.Len() {
+			if reason := checkValue(schema.Items, rv.Index(i).Interface()); reason != "" {
+				return fmt.Sprintf("item %d: %s", i, reason)
+			}
+		}
+	case genai.TypeObject:
+		obj, ok := toObject(v)
+		if !ok {
+			return fmt.Sprintf("%T, want %s", v, typ)
+		}
+		if mismatches := checkArgs(schema, obj); len(mismatches) > 0 {
+			return fmt.Sprintf("field %s: %s", mismatches[0].Arg, mismatches[0].Reason)
+		}
+	}
+	return ""
+}
+
+// checkJSONSchemaArgs returns the mismatch of the arguments of a call with the parameters declared
+// as a JSON Schema, the schema being given as any Go value encoding to JSON.
+func checkJSONSchemaArgs(params any, args map[string]any) []*ToolMismatchError {
+	schema, ok := params.(*jsonschema.Schema)
+	if !ok {
+		data, err := json.Marshal(params, json.DefaultOptionsV2())
+		if err != nil {
+			return []*ToolMismatchError{{Reason: fmt.Sprintf("invalid parameters schema: %v", err)}}
+		}
+		schema = new(jsonschema.Schema)
+		if err := json.Unmarshal(data, schema, json.DefaultOptionsV2()); err != nil {
+			return []*ToolMismatchError{{Reason: fmt.Sprintf("invalid parameters schema: %v", err)}}
+		}
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return []*ToolMismatchError{{Reason: fmt.Sprintf("invalid parameters schema: %v", err)}}
+	}
+
+	if args == nil {
+		args = map[string]any{}
+	}
+	if err := resolved.Validate(args); err != nil {
+		return []*ToolMismatchError{{Reason: err.Error()}}
+	}
+	return nil
+}
+
+// toObject returns the value as an object, converting the maps of string keys of any value type.
+func toObject(v any) (map[string]any, bool) {
+	if obj, ok := v.(map[string]any); ok {
+		return obj, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	obj := make(map[string]any, rv.Len())
+	for iter := rv.MapRange(); iter.Next(); {
+		obj[iter.Key().String()] = iter.Value().Interface()
+	}
+	return obj, true
+}
+
+// toFloat returns the numeric value as a float64, whatever its Go numeric type.
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package example_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/example"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// declaredTool is a tool of a fixed declaration.
+type declaredTool struct {
+	types.Tool
+
+	declaration *genai.FunctionDeclaration
+}
+
+func (t *declaredTool) Name() string { return t.declaration.Name }
+
+func (t *declaredTool) GetDeclaration() *genai.FunctionDeclaration { return t.declaration }
+
+var weatherTool = &declaredTool{declaration: &genai.FunctionDeclaration{
+	Name: "get_weather",
+	Parameters: &genai.Schema{
+		Type: genai.TypeObject,
+		Properties: map[string]*genai.Schema{
+			"city":  {Type: genai.TypeString},
+			"days":  {Type: genai.TypeInteger},
+			"units": {Type: genai.TypeString, Enum: []string{"metric", "imperial"}},
+			"tags":  {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
+			"where": {Type: "object", Properties: map[string]*genai.Schema{"lat": {Type: "number"}}},
+		},
+		Required: []string{"city"},
+	},
+}}
+
+func callExample(name string, args map[string]any) *example.Example {
+	return &example.Example{
+		Input: genai.NewContentFromText("What is the weather?", genai.RoleUser),
+		Output: []*genai.Content{
+			genai.NewContentFromFunctionCall(name, args, genai.RoleModel),
+			genai.NewContentFromText("It is sunny.", genai.RoleModel),
+		},
+	}
+}
+
+func TestValidateAgainstTools(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		example *example.Example
+		want    []string
+	}{
+		"matching call": {
+			example: callExample("get_weather", map[string]any{"city": "Tokyo", "days": float64(3), "units": "metric", "tags": []any{"rain"}}),
+		},
+		"Go typed values": {
+			example: callExample("get_weather", map[string]any{"city": "Tokyo", "days": int32(3), "tags": []string{"rain"}, "where": map[string]float32{"lat": 35.6}}),
+		},
+		"Go typed mismatch": {
+			example: callExample("get_weather", map[string]any{"city": "Tokyo", "tags": []int{1}, "where": map[string]string{"lat": "north"}}),
+			want: []string{
+				"example 1: tool get_weather: argument tags: item 0: int, want STRING",
+				"example 1: tool get_weather: argument where: field lat: string, want NUMBER",
+			},
+		},
+		"unknown tool": {
+			example: callExample("get_forecast", map[string]any{"city": "Tokyo"}),
+			want:    []string{"example 1: tool get_forecast: no such tool"},
+		},
+		"mismatching args": {
+			example: callExample("get_weather", map[string]any{"days": 1.5, "units": "kelvin", "tags": []any{3}, "lang": "en"}),
+			want: []string{
+				"example 1: tool get_weather: argument city: required argument missing",
+				"example 1: tool get_weather: argument days: 1.5, want INTEGER",
+				"example 1: tool get_weather: argument lang: no such parameter",
+				"example 1: tool get_weather: argument tags: item 0: int, want STRING",
+				`example 1: tool get_weather: argument units: "kelvin", want one of ["metric" "imperial"]`,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := example.ValidateAgainstTools([]*example.Example{tt.example}, []types.Tool{weatherTool})
+			var got []string
+			for _, err := range errs {
+				if !errors.Is(err, example.ErrToolMismatch) {
+					t.Errorf("error %v is not %v", err, example.ErrToolMismatch)
+				}
+				got = append(got, err.Error())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ValidateAgainstTools() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBuildExampleSI_WithToolValidation(t *testing.T) {
+	t.Parallel()
+
+	examples := []*example.Example{callExample("get_forecast", map[string]any{"city": "Tokyo"})}
+
+	// Without validation, the stale example is formatted as is.
+	text, err := example.BuildExampleSI(t.Context(), examples, "weather", "gemini-2")
+	if err != nil || !strings.Contains(text, "get_forecast(") {
+		t.Fatalf("BuildExampleSI() = (%q, %v), want the example formatted", text, err)
+	}
+
+	_, err = example.BuildExampleSI(t.Context(), examples, "weather", "gemini-2", example.WithToolValidation([]types.Tool{weatherTool}))
+	var mismatch *example.ToolMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Tool != "get_forecast" {
+		t.Errorf("BuildExampleSI() error = %v, want a mismatch of get_forecast", err)
+	}
+}
+
+func TestValidateAgainstTools_JSONSchema(t *testing.T) {
+	t.Parallel()
+
+	searchTool := &declaredTool{declaration: &genai.FunctionDeclaration{
+		Name: "search",
+		ParametersJsonSchema: map[string]any{
+			"type":                 "object",
+			"properties":           map[string]any{"query": map[string]any{"type": "string"}},
+			"required":             []any{"query"},
+			"additionalProperties": false,
+		},
+	}}
+
+	tests := map[string]struct {
+		args    map[string]any
+		wantErr bool
+	}{
+		"matching call":    {args: map[string]any{"query": "go"}},
+		"missing argument": {args: map[string]any{}, wantErr: true},
+		"wrong type":       {args: map[string]any{"query": 3}, wantErr: true},
+		"unknown argument": {args: map[string]any{"query": "go", "page": 2}, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := example.ValidateAgainstTools([]*example.Example{callExample("search", tt.args)}, []types.Tool{searchTool})
+			if gotErr := len(errs) > 0; gotErr != tt.wantErr {
+				t.Fatalf("ValidateAgainstTools() = %v, want errors %t", errs, tt.wantErr)
+			}
+			for _, err := range errs {
+				var mismatch *example.ToolMismatchError
+				if !errors.As(err, &mismatch) || mismatch.Tool != "search" {
+					t.Errorf("error %v, want a *example.ToolMismatchError of the search tool", err)
+				}
+			}
+		})
+	}
+}
@@ -89,16 +89,20 @@ to your parent agent. If you don't have parent agent, try answer by yourself.
 func (rp *AgentTransferLlmRequestProcessor) getTransferTargets(llmAgent types.LLMAgent) []types.Agent {
 	agents := llmAgent.SubAgents()
 
-	if _, ok := llmAgent.ParentAgent().AsLLMAgent(); !ok {
+	parent := llmAgent.ParentAgent()
+	if parent == nil {
+		return agents
+	}
+	if _, ok := parent.AsLLMAgent(); !ok {
 		return agents
 	}
 
 	if !llmAgent.DisallowTransferToParent() {
-		agents = append(agents, llmAgent.ParentAgent())
+		agents = append(agents, parent)
 	}
 
 	if !llmAgent.DisallowTransferToPeers() {
-		for _, subAgent := range llmAgent.ParentAgent().SubAgents() {
+		for _, subAgent := range parent.SubAgents() {
 			if subAgent.Name() != llmAgent.Name() {
 				agents = append([]types.Agent{subAgent}, agents...)
 			}
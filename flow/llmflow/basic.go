@@ -33,23 +33,64 @@ func (f *BasicLlmRequestProcessor) Run(ctx context.Context, ictx *types.Invocati
 		}
 		request.Model = model.Name()
 
+		runConfig := ictx.RunConfig
+		if runConfig == nil {
+			runConfig = &types.RunConfig{}
+		}
+
 		config := llmAgent.GenerateContentConfig()
 		if config == nil {
 			config = &genai.GenerateContentConfig{}
 		}
+		if overrides := runConfig.GenerationOverrides; overrides != nil {
+			if err := overrides.Validate(); err != nil {
+				yield(nil, err)
+				return
+			}
+			config = overrides.Apply(config)
+		}
 		request.Config = config
+		if choice := llmAgent.ToolChoice(); choice != nil && !forcedToolCalled(ictx, choice) {
+			request.ToolChoice = choice
+		}
+		if grounding := llmAgent.GoogleSearchGrounding(); grounding != nil {
+			request.GoogleSearchGrounding = grounding
+		}
 
 		if outputschema := llmAgent.OutputSchema(); outputschema != nil {
 			request.SetOutputSchema(outputschema)
 		}
 
-		request.LiveConnectConfig.ResponseModalities = ictx.RunConfig.ResponseModalities
-		request.LiveConnectConfig.SpeechConfig = ictx.RunConfig.SpeechConfig
-		request.LiveConnectConfig.OutputAudioTranscription = ictx.RunConfig.OutputAudioTranscription
-		request.LiveConnectConfig.InputAudioTranscription = ictx.RunConfig.InputAudioTranscription
+		if request.LiveConnectConfig == nil {
+			request.LiveConnectConfig = new(genai.LiveConnectConfig)
+		}
+		request.LiveConnectConfig.ResponseModalities = runConfig.ResponseModalities
+		request.LiveConnectConfig.SpeechConfig = runConfig.SpeechConfig
+		request.LiveConnectConfig.OutputAudioTranscription = runConfig.OutputAudioTranscription
+		request.LiveConnectConfig.InputAudioTranscription = runConfig.InputAudioTranscription
 
 		// TODO(adk-python): handle tool append here, instead of in BaseTool.process_llm_request.
 
 		return
 	}
 }
+
+// forcedToolCalled reports whether the tool forced by the choice was already called by the agent in
+// the invocation. The later model calls are then left to the model, so that it can answer with the
+// result of the tool instead of calling it again.
+func forcedToolCalled(ictx *types.InvocationContext, choice *types.ToolChoice) bool {
+	if choice.Mode != types.ToolChoiceAny && choice.Mode != types.ToolChoiceTool {
+		return false
+	}
+	for _, event := range ictx.Session.Events() {
+		if event.InvocationID != ictx.InvocationID || event.Author != ictx.Agent.Name() {
+			continue
+		}
+		for _, call := range event.GetFunctionCalls() {
+			if choice.Mode == types.ToolChoiceAny || call.Name == choice.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestBasicLlmRequestProcessor_GenerationOverrides(t *testing.T) {
+	t.Parallel()
+
+	agentConfig := &genai.GenerateContentConfig{
+		Temperature:     types.ToPtr[float32](0.9),
+		MaxOutputTokens: 1024,
+		StopSequences:   []string{"END"},
+	}
+	a, err := agent.NewLLMAgent(t.Context(), "writer",
+		agent.WithModel(model.NewBaseLLM("base-model")),
+		agent.WithGenerateContentConfig(agentConfig),
+	)
+	if err != nil {
+		t.Fatalf("NewLLMAgent() error = %v", err)
+	}
+
+	run := func(overrides *types.GenerationOverrides) (*types.LLMRequest, error) {
+		ses := session.NewSession("app", "user", "session", nil, time.Now())
+		ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+		ictx.RunConfig = &types.RunConfig{GenerationOverrides: overrides}
+		request := types.NewLLMRequest(nil)
+		request.LiveConnectConfig = &genai.LiveConnectConfig{}
+		for _, err := range (&llmflow.BasicLlmRequestProcessor{}).Run(t.Context(), ictx, request) {
+			if err != nil {
+				return nil, err
+			}
+		}
+		return request, nil
+	}
+
+	request, err := run(&types.GenerationOverrides{
+		Temperature: types.ToPtr[float32](0.1),
+		TopP:        types.ToPtr[float32](0.5),
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	config := request.Config
+	if *config.Temperature != 0.1 || *config.TopP != 0.5 || config.MaxOutputTokens != 1024 || config.StopSequences[0] != "END" {
+		t.Errorf("request config = %+v, want the overrides merged over the agent config", config)
+	}
+	if *agentConfig.Temperature != 0.9 || agentConfig.TopP != nil {
+		t.Errorf("agent config = %+v, want it unchanged", agentConfig)
+	}
+
+	if _, err := run(&types.GenerationOverrides{Temperature: types.ToPtr[float32](3)}); err == nil {
+		t.Error("Run() with an invalid temperature succeeded")
+	}
+}
+
+func TestBasicLlmRequestProcessor_ForcedTool(t *testing.T) {
+	t.Parallel()
+
+	callEvent := func(invocationID, name string) *types.Event {
+		return types.NewEvent().
+			WithInvocationID(invocationID).
+			WithAuthor("extractor").
+			WithContent(genai.NewContentFromFunctionCall(name, nil, genai.RoleModel))
+	}
+	tests := map[string]struct {
+		opt    agent.LLMAgentOption
+		events []*types.Event
+		want   *types.ToolChoice
+	}{
+		"first request": {
+			opt:  agent.WithForcedTool("extract"),
+			want: &types.ToolChoice{Mode: types.ToolChoiceTool, Name: "extract"},
+		},
+		"after another tool": {
+			opt:    agent.WithForcedTool("extract"),
+			events: []*types.Event{callEvent("inv", "lookup")},
+			want:   &types.ToolChoice{Mode: types.ToolChoiceTool, Name: "extract"},
+		},
+		"after the forced tool": {
+			opt:    agent.WithForcedTool("extract"),
+			events: []*types.Event{callEvent("inv", "extract")},
+		},
+		"after the forced tool of a previous invocation": {
+			opt:    agent.WithForcedTool("extract"),
+			events: []*types.Event{callEvent("previous", "extract")},
+			want:   &types.ToolChoice{Mode: types.ToolChoiceTool, Name: "extract"},
+		},
+		"any tool after a tool": {
+			opt:    agent.WithForceAnyTool(),
+			events: []*types.Event{callEvent("inv", "lookup")},
+		},
+		"tools disabled after a tool": {
+			opt:    agent.WithToolsDisabled(),
+			events: []*types.Event{callEvent("inv", "lookup")},
+			want:   &types.ToolChoice{Mode: types.ToolChoiceNone},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "extractor", agent.WithModel(model.NewBaseLLM("base-model")), tt.opt)
+			if err != nil {
+				t.Fatalf("NewLLMAgent() error = %v", err)
+			}
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ses.AddEvent(tt.events...)
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+			ictx.InvocationID = "inv"
+			request := types.NewLLMRequest(nil)
+			for _, err := range (&llmflow.BasicLlmRequestProcessor{}).Run(t.Context(), ictx, request) {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+			}
+			if diff := cmp.Diff(tt.want, request.ToolChoice); diff != "" {
+				t.Errorf("request tool choice mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
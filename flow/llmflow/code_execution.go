@@ -90,7 +90,7 @@ func (p *CodeExecutionRequestProcessor) Run(ctx context.Context, ictx *types.Inv
 		if !ok {
 			return
 		}
-		if llmAgent.CodeExecutor() == nil {
+		if codeExecutorOf(ictx, llmAgent) == nil {
 			return
 		}
 	}
@@ -103,7 +103,7 @@ func (p *CodeExecutionRequestProcessor) runPreProcessor(ctx context.Context, ict
 		if !ok {
 			return
 		}
-		codeExecutor := llmAgent.CodeExecutor()
+		codeExecutor := codeExecutorOf(ictx, llmAgent)
 		if codeExecutor == nil {
 			return
 		}
@@ -158,7 +158,7 @@ func (p *CodeExecutionRequestProcessor) runPreProcessor(ctx context.Context, ict
 			codeContent := genai.NewContentFromParts(parts, genai.Role(model.RoleModel))
 			request.Contents = append(request.Contents, codeContent)
 
-			event := types.NewEvent().
+			event := ictx.NewEvent().
 				WithInvocationID(ictx.InvocationID).
 				WithAuthor(llmAgent.Name()).
 				WithBranch(ictx.Branch).
@@ -198,7 +198,14 @@ func (p *CodeExecutionRequestProcessor) runPreProcessor(ctx context.Context, ict
 }
 
 // CodeExecutionResponseProcessor represents a processes code execution responses.
-type CodeExecutionResponseProcessor struct{}
+type CodeExecutionResponseProcessor struct {
+	// AllowedLanguages is the allowlist of the languages of the code blocks to execute, compared by
+	// their [codeexecutor.NormalizeLanguage] form.
+	//
+	// Default to the languages supported by the code executor, see [codeexecutor.SupportedLanguages].
+	// The unlabeled code blocks and the ones of other languages are left in the response as text.
+	AllowedLanguages []string
+}
 
 var _ types.LLMResponseProcessor = (*CodeExecutionResponseProcessor)(nil)
 
@@ -206,10 +213,24 @@ var _ types.LLMResponseProcessor = (*CodeExecutionResponseProcessor)(nil)
 func (p *CodeExecutionResponseProcessor) Run(ctx context.Context, ictx *types.InvocationContext, response *types.LLMResponse) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
 		// Skip if the response is partial (streaming).
-		if response.Partial {
+		if response == nil || response.Partial {
 			return
 		}
+
+		for event, err := range p.runPostProcessor(ctx, ictx, response) {
+			if !yield(event, err) {
+				return
+			}
+		}
+	}
+}
+
+// allowedLanguages returns the allowlist of the languages of the code blocks run by codeExecutor.
+func (p *CodeExecutionResponseProcessor) allowedLanguages(codeExecutor types.CodeExecutor) []string {
+	if len(p.AllowedLanguages) > 0 {
+		return p.AllowedLanguages
 	}
+	return codeexecutor.SupportedLanguages(codeExecutor)
 }
 
 // runPostProcessor post-process the model response by extracting and executing the first code block.
@@ -219,7 +240,7 @@ func (p *CodeExecutionResponseProcessor) runPostProcessor(ctx context.Context, i
 		if !ok {
 			return
 		}
-		codeExecutor := llmAgent.CodeExecutor()
+		codeExecutor := codeExecutorOf(ictx, llmAgent)
 		if codeExecutor == nil {
 			return
 		}
@@ -238,15 +259,15 @@ func (p *CodeExecutionResponseProcessor) runPostProcessor(ctx context.Context, i
 		}
 
 		// [Step 1] Extract code from the model predict response and truncate the
-		// content to the part with the first code block.
+		// content to the part with the first code block of an allowed language.
 		responseContent := response.Content
-		codeStr := codeexecutor.NewCodeExecutionUtils().ExtractCodeAndTruncateContent(responseContent, codeExecutor.CodeBlockDelimiters())
-		if codeStr == "" {
+		block := codeexecutor.NewCodeExecutionUtils().ExtractCodeBlockAndTruncateContent(responseContent, codeExecutor.CodeBlockDelimiters(), p.allowedLanguages(codeExecutor))
+		if block == nil {
 			return
 		}
 
 		// [Step 2] Executes the code and emit 2 Events for code and execution result.
-		event := types.NewEvent().
+		event := ictx.NewEvent().
 			WithInvocationID(ictx.InvocationID).
 			WithAuthor(llmAgent.Name()).
 			WithBranch(ictx.Branch).
@@ -257,19 +278,29 @@ func (p *CodeExecutionResponseProcessor) runPostProcessor(ctx context.Context, i
 		}
 
 		codeExecutionResult, err := codeExecutor.ExecuteCode(ctx, ictx, &types.CodeExecutionInput{
-			Code:        codeStr,
+			Code:        block.Code,
+			Language:    block.Language,
 			InputFiles:  codeExecutorContent.GetInputFiles(),
 			ExecutionID: getOrSetExecutionID(ictx, codeExecutorContent),
 		})
 		if err != nil {
-			xiter.Error[types.Event](err)
+			yield(nil, fmt.Errorf("execute %s code block: %w", block.Language, err))
 			return
 		}
 
-		codeExecutorContent.UpdateExecutionResult(ictx.InvocationID, codeStr, codeExecutionResult.Stdout, codeExecutionResult.Stderr)
-		if !yield(event, nil) {
+		codeExecutorContent.UpdateExecutionResult(ictx.InvocationID, block.Code, codeExecutionResult.Stdout, codeExecutionResult.Stderr)
+		resultEvent, err := postProcessCodeExecutionResult(ctx, ictx, codeExecutorContent, codeExecutionResult)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if !yield(resultEvent, nil) {
 			return
 		}
+
+		// The code and its result are emitted as the events above, so that the response is not
+		// emitted again.
+		response.Content = nil
 	}
 }
 
@@ -313,13 +344,22 @@ func (p *CodeExecutionRequestProcessor) extractAndReplaceInlineFiles(codeExecuto
 	return allInputFiles
 }
 
+// codeExecutorOf returns the code executor of the agent, or the one of the invocation if the agent
+// has none.
+func codeExecutorOf(ictx *types.InvocationContext, llmAgent types.LLMAgent) types.CodeExecutor {
+	if codeExecutor := llmAgent.CodeExecutor(); codeExecutor != nil {
+		return codeExecutor
+	}
+	return ictx.CodeExecutor
+}
+
 // getOrSetExecutionID returns the ID for stateful code execution or None if not stateful.
 func getOrSetExecutionID(ictx *types.InvocationContext, codeExecutorContext *codeexecutor.CodeExecutorContext) string {
 	llmAgent, ok := ictx.Agent.AsLLMAgent()
 	if !ok {
 		return ""
 	}
-	if llmAgent.CodeExecutor().IsStateful() {
+	if codeExecutor := codeExecutorOf(ictx, llmAgent); codeExecutor == nil || !codeExecutor.IsStateful() {
 		return ""
 	}
 
@@ -371,7 +411,7 @@ func postProcessCodeExecutionResult(ctx context.Context, ictx *types.InvocationC
 		eventActions.ArtifactDelta[outputFile.Name] = version
 	}
 
-	event := types.NewEvent().
+	event := ictx.NewEvent().
 		WithInvocationID(ictx.InvocationID).
 		WithAuthor(ictx.Agent.Name()).
 		WithBranch(ictx.Branch).
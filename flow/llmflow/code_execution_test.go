@@ -0,0 +1,124 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/artifact"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// recordingExecutor is a code executor of python and bash recording the code it runs.
+type recordingExecutor struct {
+	inputs []*types.CodeExecutionInput
+}
+
+var _ types.CodeExecutor = (*recordingExecutor)(nil)
+
+func (*recordingExecutor) OptimizeDataFile() bool                     { return false }
+func (*recordingExecutor) IsLongRunning() bool                        { return false }
+func (*recordingExecutor) IsStateful() bool                           { return false }
+func (*recordingExecutor) ErrorRetryAttempts() int                    { return 2 }
+func (*recordingExecutor) CodeBlockDelimiters() []types.DelimiterPair { return nil }
+func (*recordingExecutor) ExecutionResultDelimiters() types.DelimiterPair {
+	return types.DelimiterPair{}
+}
+func (*recordingExecutor) SupportedLanguages() []string { return []string{"python", "bash"} }
+func (*recordingExecutor) Close() error                 { return nil }
+
+func (e *recordingExecutor) ExecuteCode(_ context.Context, _ *types.InvocationContext, input *types.CodeExecutionInput) (*types.CodeExecutionResult, error) {
+	e.inputs = append(e.inputs, input)
+	return &types.CodeExecutionResult{Stdout: "1\n"}, nil
+}
+
+func TestCodeExecutionResponseProcessor_AllowedLanguages(t *testing.T) {
+	t.Parallel()
+
+	const text = "Data:\n" +
+		"```json\n{\"n\": 1}\n```\n" +
+		"```python\nprint(1)\n```\n" +
+		"```bash\necho 1\n```\n"
+
+	tests := map[string]struct {
+		allowed      []string
+		wantLanguage string
+		wantCode     string
+	}{
+		"executor languages": {
+			wantLanguage: "python",
+			wantCode:     "print(1)",
+		},
+		"bash only": {
+			allowed:      []string{"sh"},
+			wantLanguage: "bash",
+			wantCode:     "echo 1",
+		},
+		"no allowed block": {
+			allowed: []string{"go"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "coder")
+			if err != nil {
+				t.Fatalf("NewLLMAgent() error = %v", err)
+			}
+			executor := &recordingExecutor{}
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(), types.WithArtifactService(artifact.NewInMemoryService()))
+			ictx.CodeExecutor = executor
+
+			response := &types.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)}
+			processor := &llmflow.CodeExecutionResponseProcessor{AllowedLanguages: tt.allowed}
+			var events []*types.Event
+			for event, err := range processor.Run(t.Context(), ictx, response) {
+				if err != nil {
+					t.Fatalf("Run() error = %v", err)
+				}
+				events = append(events, event)
+			}
+
+			if tt.wantCode == "" {
+				if len(events) != 0 || len(executor.inputs) != 0 {
+					t.Fatalf("Run() = %d events and %d executions, want none", len(events), len(executor.inputs))
+				}
+				if response.Content == nil || response.Content.Parts[0].Text != text {
+					t.Errorf("response content = %+v, want the text left unchanged", response.Content)
+				}
+				return
+			}
+
+			if len(executor.inputs) != 1 {
+				t.Fatalf("executed %d code blocks, want 1", len(executor.inputs))
+			}
+			if input := executor.inputs[0]; input.Language != tt.wantLanguage || input.Code != tt.wantCode {
+				t.Errorf("executed (%s, %q), want (%s, %q)", input.Language, input.Code, tt.wantLanguage, tt.wantCode)
+			}
+			if len(events) != 2 {
+				t.Fatalf("Run() = %d events, want the code and its result", len(events))
+			}
+			parts := events[0].Content.Parts
+			if code := parts[len(parts)-1].ExecutableCode; code == nil || code.Code != tt.wantCode {
+				t.Errorf("code event last part = %+v, want the executed code", parts[len(parts)-1])
+			}
+			if result := events[1].Content.Parts[0].CodeExecutionResult; result == nil || !strings.Contains(result.Output, "1\n") {
+				t.Errorf("result event part = %+v, want the execution output", events[1].Content.Parts[0])
+			}
+			if response.Content != nil {
+				t.Errorf("response content = %+v, want nil once emitted as events", response.Content)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"crypto/sha256"
+
+	"google.golang.org/genai"
+)
+
+// DedupeMarker is the text replacing a large part repeated in the history, see
+// [ContentLLMRequestProcessor.WithDedupe].
+const DedupeMarker = "[see earlier message]"
+
+// WithDedupe replaces the later occurrences of a large part repeated byte for byte in the history
+// with a short [DedupeMarker] text part, keeping the first full copy, such as a document pasted in
+// several messages. Only the text and inline data parts of at least minSize bytes are deduplicated.
+// Zero or less disables it, which is the default.
+//
+// Only the contents of the request are rewritten, the events of the session are never modified.
+func (cp *ContentLLMRequestProcessor) WithDedupe(minSize int) *ContentLLMRequestProcessor {
+	cp.dedupeMinSize = max(minSize, 0)
+	return cp
+}
+
+// dedupeContents replaces the later occurrences of the parts of at least minSize bytes repeated in
+// the contents with a [DedupeMarker] part.
+//
+// The contents are the copies built from the history, so their parts are replaced in place.
+func dedupeContents(contents []*genai.Content, minSize int) {
+	seen := make(map[[sha256.Size]byte]bool)
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		for i, part := range content.Parts {
+			key, ok := dedupeKey(part, minSize)
+			if !ok {
+				continue
+			}
+			if seen[key] {
+				content.Parts[i] = genai.NewPartFromText(DedupeMarker)
+				continue
+			}
+			seen[key] = true
+		}
+	}
+}
+
+// dedupeKey returns the key of the part identifying its identical copies, or false if the part is
+// not a text or inline data part of at least minSize bytes.
+func dedupeKey(part *genai.Part, minSize int) ([sha256.Size]byte, bool) {
+	h := sha256.New()
+	switch {
+	case part == nil || part.Thought:
+		return [sha256.Size]byte{}, false
+	case part.Text != "" && len(part.Text) >= minSize:
+		h.Write([]byte("text\x00"))
+		h.Write([]byte(part.Text))
+	case part.InlineData != nil && len(part.InlineData.Data) >= minSize:
+		h.Write([]byte("inline\x00" + part.InlineData.MIMEType + "\x00"))
+		h.Write(part.InlineData.Data)
+	default:
+		return [sha256.Size]byte{}, false
+	}
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key, true
+}
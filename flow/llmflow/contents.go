@@ -14,17 +14,38 @@ import (
 	deepcopy "github.com/tiendc/go-deepcopy"
 	"google.golang.org/genai"
 
-	"github.com/go-a2a/adk-go/internal/xiter"
 	"github.com/go-a2a/adk-go/model"
 	"github.com/go-a2a/adk-go/pkg/py"
 	"github.com/go-a2a/adk-go/types"
 )
 
 // ContentLLMRequestProcessor builds the contents for the LLM request.
-type ContentLLMRequestProcessor struct{}
+type ContentLLMRequestProcessor struct {
+	// The number of most recent conversational turns kept in the contents, or zero to keep them all.
+	maxTurns int
+
+	// The minimum size of the repeated parts deduplicated, or zero to keep them all.
+	dedupeMinSize int
+
+	// The maximum total size of the tool outputs, or zero for no limit.
+	maxToolOutputBytes int
+}
 
 var _ types.LLMRequestProcessor = (*ContentLLMRequestProcessor)(nil)
 
+// WithMaxTurns sets the number of most recent conversational turns kept in the contents, older
+// turns being dropped entirely. Zero or less keeps the whole history.
+//
+// A turn starts with a user message and holds the full response of the agents to it, including
+// the tool calls and their responses, so that a function call is never separated from its
+// response. The system instruction is not part of the contents and is always kept.
+//
+// Unlike a token budget, the window only depends on the structure of the conversation.
+func (cp *ContentLLMRequestProcessor) WithMaxTurns(n int) *ContentLLMRequestProcessor {
+	cp.maxTurns = max(n, 0)
+	return cp
+}
+
 // Run implements [LLMRequestProcessor].
 func (cp *ContentLLMRequestProcessor) Run(ctx context.Context, ictx *types.InvocationContext, request *types.LLMRequest) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
@@ -34,9 +55,9 @@ func (cp *ContentLLMRequestProcessor) Run(ctx context.Context, ictx *types.Invoc
 		}
 
 		if llmAgent.IncludeContents() != types.IncludeContentsNone {
-			contents, err := cp.getContents(ictx.Branch, ictx.Session.Events(), llmAgent.Name())
+			contents, err := cp.getContents(ictx.Branch, ictx.Session.Events(), llmAgent.Name(), ictx.NewEvent)
 			if err != nil {
-				xiter.Error[*types.Event](err)
+				yield(nil, err)
 				return
 			}
 			request.Contents = contents
@@ -45,54 +66,54 @@ func (cp *ContentLLMRequestProcessor) Run(ctx context.Context, ictx *types.Invoc
 }
 
 // getContents get the contents for the LLM request.
-func (cp *ContentLLMRequestProcessor) getContents(currentBranch string, events []*types.Event, agentName string) ([]*genai.Content, error) {
-	var filteredEvents []*types.Event
-
-	for _, event := range events {
-		if event.Content == nil || event.Content.Role == "" || len(event.Content.Parts) == 0 || event.Content.Parts[0].Text == "" {
-			// Skip events without content, or generated neither by user nor by model
-			// or has empty text.
-			// E.g. events purely for mutating session states.
-			continue
-		}
-
-		if !cp.isEventBelongsToBranch(currentBranch, event) {
-			// Skip events not belong to current branch.
-			continue
-		}
-
-		if cp.isAuthEvent(event) {
-			// Skip events not belong to current branch.
-			continue
-		}
-
-		ev := event
-		if cp.isOtherAgentReply(currentBranch, event) {
-			ev = cp.convertForeignEvent(event)
-		}
-		filteredEvents = append(filteredEvents, ev)
-	}
-
-	resultEvents, err := cp.rearrangeEventsForLatestFunctionResponse(filteredEvents)
+//
+// The history is windowed to the last turns before the consecutive contents of the same role are
+// merged, so that a merged content never spans two turns. newEvent creates the events converted
+// from the replies of the other agents.
+func (cp *ContentLLMRequestProcessor) getContents(currentBranch string, events []*types.Event, agentName string, newEvent func() *types.Event) ([]*genai.Content, error) {
+	history, err := buildHistory(events, &historyConfig{branch: currentBranch, agentName: agentName, newEvent: newEvent})
 	if err != nil {
 		return nil, err
 	}
-	resultEvents, err = cp.rearrangeEventsForAsyncFunctionResponsesInHistory(resultEvents)
-	if err != nil {
-		return nil, err
+
+	if cp.maxTurns > 0 {
+		history = lastTurns(history, cp.maxTurns)
+	}
+	contents := historyContents(history)
+	if cp.dedupeMinSize > 0 {
+		dedupeContents(contents, cp.dedupeMinSize)
+	}
+	if cp.maxToolOutputBytes > 0 {
+		budgetToolOutputs(contents, cp.maxToolOutputBytes)
 	}
 
-	contents := []*genai.Content{}
-	for _, event := range resultEvents {
-		content := &genai.Content{}
-		if err := deepcopy.Copy(content, event.Content); err != nil {
-			return nil, err
+	return mergeTurns(contents), nil
+}
+
+// isEmptyPart reports whether the part holds neither text nor any other data, such as a function call.
+func isEmptyPart(part *genai.Part) bool {
+	return part == nil || (part.Text == "" && part.FunctionCall == nil && part.FunctionResponse == nil &&
+		part.InlineData == nil && part.FileData == nil && part.ExecutableCode == nil && part.CodeExecutionResult == nil)
+}
+
+// lastTurns returns the contents of the last n conversational turns, each starting with a user
+// message that is not a function response.
+func lastTurns(history []historyContent, n int) []historyContent {
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].turnStart {
+			continue
+		}
+		if n--; n == 0 {
+			return history[i:]
 		}
-		content = RemoveClientFunctionCallID(content)
-		contents = append(contents, content)
 	}
+	return history
+}
 
-	return contents, nil
+// isTurnStart reports whether the event is a message of the user starting a conversational turn,
+// rather than the response to a function call.
+func isTurnStart(event *types.Event) bool {
+	return event.Author == model.RoleUser && len(event.GetFunctionResponses()) == 0
 }
 
 // rearrangeEventsForAsyncFunctionResponsesInHistory rearrange the async function_response events in the history.
@@ -171,6 +192,9 @@ func (cp *ContentLLMRequestProcessor) rearrangeEventsForLatestFunctionResponse(e
 		funcResponsesIDs.Insert(funcResponse.ID)
 	}
 
+	if len(events) < 2 {
+		return nil, fmt.Errorf("no function call event found for function responses ids: %v", funcResponsesIDs.UnsortedList())
+	}
 	funcCalls := events[len(events)-2].GetFunctionCalls()
 	if len(funcCalls) > 0 {
 		for _, funcCall := range funcCalls {
@@ -183,7 +207,7 @@ func (cp *ContentLLMRequestProcessor) rearrangeEventsForLatestFunctionResponse(e
 
 	funcCallEventIdx := -1
 	// look for corresponding function call event reversely
-	for idx := len(events) - 2; idx >= -1; idx-- {
+	for idx := len(events) - 2; idx >= 0; idx-- {
 		event := events[idx]
 		funcCalls := event.GetFunctionCalls()
 		if len(funcCalls) > 0 {
@@ -241,7 +265,7 @@ func (cp *ContentLLMRequestProcessor) isOtherAgentReply(currentAgentName string,
 // This is to provide another agent's output as context to the current agent, so
 // that current agent can continue to respond, such as summarizing previous
 // agent's reply, etc.
-func (cp *ContentLLMRequestProcessor) convertForeignEvent(event *types.Event) *types.Event {
+func (cp *ContentLLMRequestProcessor) convertForeignEvent(event *types.Event, newEvent func() *types.Event) *types.Event {
 	if event.Content == nil || len(event.Content.Parts) == 0 {
 		return event
 	}
@@ -270,7 +294,7 @@ func (cp *ContentLLMRequestProcessor) convertForeignEvent(event *types.Event) *t
 		}
 	}
 
-	ev := types.NewEvent().
+	ev := newEvent().
 		WithAuthor("user").
 		WithContent(content).
 		WithBranch(event.Branch)
@@ -0,0 +1,331 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestGetContents_ReplacesHistory(t *testing.T) {
+	t.Parallel()
+
+	userEvent := func(text string) *types.Event {
+		return types.NewEvent().
+			WithAuthor("user").
+			WithContent(genai.NewContentFromText(text, genai.RoleUser)).
+			WithActions(types.NewEventActions())
+	}
+	summary := types.NewEvent().
+		WithAuthor("writer").
+		WithContent(genai.NewContentFromText("summary", genai.RoleModel)).
+		WithActions(types.NewEventActions().WithReplacesHistory(true))
+
+	events := []*types.Event{
+		userEvent("first"),
+		userEvent("second"),
+		summary,
+		userEvent("third"),
+	}
+
+	contents, err := llmflow.GetContents(&llmflow.ContentLLMRequestProcessor{}, "", events, "writer", types.NewEvent)
+	if err != nil {
+		t.Fatalf("getContents: %v", err)
+	}
+
+	var got []string
+	for _, content := range contents {
+		got = append(got, content.Parts[0].Text)
+	}
+	if diff := cmp.Diff([]string{"summary", "third"}, got); diff != "" {
+		t.Errorf("contents mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetContents_WithMaxTurns(t *testing.T) {
+	t.Parallel()
+
+	textEvent := func(author, text string, role genai.Role) *types.Event {
+		return types.NewEvent().
+			WithAuthor(author).
+			WithContent(genai.NewContentFromText(text, role)).
+			WithActions(types.NewEventActions())
+	}
+	callEvent := types.NewEvent().
+		WithAuthor("writer").
+		WithContent(genai.NewContentFromFunctionCall("lookup", map[string]any{"q": "x"}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	callEvent.Content.Parts[0].FunctionCall.ID = "call-1"
+	responseEvent := types.NewEvent().
+		WithAuthor("writer").
+		WithContent(genai.NewContentFromFunctionResponse("lookup", map[string]any{"result": "y"}, genai.RoleUser)).
+		WithActions(types.NewEventActions())
+	responseEvent.Content.Parts[0].FunctionResponse.ID = "call-1"
+
+	events := []*types.Event{
+		textEvent("user", "first", genai.RoleUser),
+		textEvent("writer", "first answer", genai.RoleModel),
+		textEvent("user", "second", genai.RoleUser),
+		callEvent,
+		responseEvent,
+		textEvent("writer", "second answer", genai.RoleModel),
+		textEvent("user", "third", genai.RoleUser),
+		textEvent("writer", "third answer", genai.RoleModel),
+	}
+
+	describe := func(contents []*genai.Content) []string {
+		var got []string
+		for _, content := range contents {
+			part := content.Parts[0]
+			switch {
+			case part.FunctionCall != nil:
+				got = append(got, "call "+part.FunctionCall.Name)
+			case part.FunctionResponse != nil:
+				got = append(got, "response "+part.FunctionResponse.Name)
+			default:
+				got = append(got, part.Text)
+			}
+		}
+		return got
+	}
+
+	tests := map[string]struct {
+		maxTurns int
+		want     []string
+	}{
+		"all turns": {
+			maxTurns: 0,
+			want:     []string{"first", "first answer", "second", "call lookup", "response lookup", "second answer", "third", "third answer"},
+		},
+		"last turn": {
+			maxTurns: 1,
+			want:     []string{"third", "third answer"},
+		},
+		"keeps the tool round-trip": {
+			maxTurns: 2,
+			want:     []string{"second", "call lookup", "response lookup", "second answer", "third", "third answer"},
+		},
+		"more turns than the history": {
+			maxTurns: 10,
+			want:     []string{"first", "first answer", "second", "call lookup", "response lookup", "second answer", "third", "third answer"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cp := (&llmflow.ContentLLMRequestProcessor{}).WithMaxTurns(tt.maxTurns)
+			contents, err := llmflow.GetContents(cp, "", events, "writer", types.NewEvent)
+			if err != nil {
+				t.Fatalf("getContents: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, describe(contents)); diff != "" {
+				t.Errorf("contents mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetContents_WithMaxTurnsForeignReplies(t *testing.T) {
+	t.Parallel()
+
+	textEvent := func(author, text string, role genai.Role) *types.Event {
+		return types.NewEvent().
+			WithAuthor(author).
+			WithContent(genai.NewContentFromText(text, role)).
+			WithActions(types.NewEventActions())
+	}
+	events := []*types.Event{
+		textEvent("user", "first", genai.RoleUser),
+		textEvent("writer", "first answer", genai.RoleModel),
+		textEvent("user", "second", genai.RoleUser),
+		// The reply of another agent is given as a user content, but does not start a turn.
+		textEvent("researcher", "notes", genai.RoleModel),
+		textEvent("writer", "second answer", genai.RoleModel),
+	}
+
+	cp := (&llmflow.ContentLLMRequestProcessor{}).WithMaxTurns(1)
+	contents, err := llmflow.GetContents(cp, "", events, "writer", types.NewEvent)
+	if err != nil {
+		t.Fatalf("getContents: %v", err)
+	}
+
+	var got []string
+	for _, content := range contents {
+		for _, part := range content.Parts {
+			got = append(got, content.Role+": "+part.Text)
+		}
+	}
+	want := []string{
+		"user: second",
+		"user: For context:",
+		"user: [researcher] said: notes",
+		"model: second answer",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("contents mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetContents_WithDedupe(t *testing.T) {
+	t.Parallel()
+
+	document := strings.Repeat("lorem ipsum ", 20)
+	image := bytes.Repeat([]byte{0xff}, 64)
+	textEvent := func(author, text string, role genai.Role) *types.Event {
+		return types.NewEvent().
+			WithAuthor(author).
+			WithContent(genai.NewContentFromText(text, role)).
+			WithActions(types.NewEventActions())
+	}
+	imageEvent := func(mimeType string) *types.Event {
+		return types.NewEvent().
+			WithAuthor("user").
+			WithContent(genai.NewContentFromBytes(image, mimeType, genai.RoleUser)).
+			WithActions(types.NewEventActions())
+	}
+	events := []*types.Event{
+		textEvent("user", document, genai.RoleUser),
+		textEvent("writer", "ok", genai.RoleModel),
+		textEvent("user", document, genai.RoleUser),
+		textEvent("writer", "ok", genai.RoleModel),
+		imageEvent("image/png"),
+		textEvent("writer", "ok", genai.RoleModel),
+		imageEvent("image/png"),
+		textEvent("writer", "ok", genai.RoleModel),
+		imageEvent("image/jpeg"),
+	}
+
+	describe := func(contents []*genai.Content) []string {
+		var got []string
+		for _, content := range contents {
+			for _, part := range content.Parts {
+				switch {
+				case part.InlineData != nil:
+					got = append(got, part.InlineData.MIMEType)
+				case part.Text == document:
+					got = append(got, "document")
+				default:
+					got = append(got, part.Text)
+				}
+			}
+		}
+		return got
+	}
+
+	tests := map[string]struct {
+		minSize int
+		want    []string
+	}{
+		"disabled": {
+			minSize: 0,
+			want:    []string{"document", "ok", "document", "ok", "image/png", "ok", "image/png", "ok", "image/jpeg"},
+		},
+		"large parts": {
+			minSize: 32,
+			want:    []string{"document", "ok", llmflow.DedupeMarker, "ok", "image/png", "ok", llmflow.DedupeMarker, "ok", "image/jpeg"},
+		},
+		"parts smaller than the minimum size": {
+			minSize: 1024,
+			want:    []string{"document", "ok", "document", "ok", "image/png", "ok", "image/png", "ok", "image/jpeg"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cp := (&llmflow.ContentLLMRequestProcessor{}).WithDedupe(tt.minSize)
+			contents, err := llmflow.GetContents(cp, "", events, "writer", types.NewEvent)
+			if err != nil {
+				t.Fatalf("getContents: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, describe(contents)); diff != "" {
+				t.Errorf("contents mismatch (-want +got):\n%s", diff)
+			}
+			if events[2].Content.Parts[0].Text != document || events[6].Content.Parts[0].InlineData == nil {
+				t.Error("deduplication modified the events of the session")
+			}
+		})
+	}
+}
+
+func TestGetContents_WithMaxToolOutputBytes(t *testing.T) {
+	t.Parallel()
+
+	output := strings.Repeat("x", 90)
+	var events []*types.Event
+	for i, id := range []string{"call-1", "call-2", "call-3"} {
+		callEvent := types.NewEvent().
+			WithAuthor("writer").
+			WithContent(genai.NewContentFromFunctionCall("lookup", map[string]any{"n": i}, genai.RoleModel)).
+			WithActions(types.NewEventActions())
+		callEvent.Content.Parts[0].FunctionCall.ID = id
+		responseEvent := types.NewEvent().
+			WithAuthor("writer").
+			WithContent(genai.NewContentFromFunctionResponse("lookup", map[string]any{"result": output}, genai.RoleUser)).
+			WithActions(types.NewEventActions())
+		responseEvent.Content.Parts[0].FunctionResponse.ID = id
+		events = append(events, callEvent, responseEvent)
+	}
+
+	// Each tool output is 103 bytes of JSON.
+	tests := map[string]struct {
+		limit int
+		want  []string
+	}{
+		"no limit": {
+			limit: 0,
+			want:  []string{output, output, output},
+		},
+		"within the budget": {
+			limit: 400,
+			want:  []string{output, output, output},
+		},
+		"oldest output truncated": {
+			// The oldest output shrinks by 29 bytes to 74 bytes, its escaped JSON included.
+			limit: 280,
+			want:  []string{`{"result":"` + strings.Repeat("x", 16) + llmflow.TruncationMarker, output, output},
+		},
+		"oldest outputs cut to the marker over the budget, the latest kept": {
+			// The stubs of 44 bytes and the latest output exceed the budget.
+			limit: 50,
+			want:  []string{llmflow.TruncationMarker, llmflow.TruncationMarker, output},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cp := (&llmflow.ContentLLMRequestProcessor{}).WithMaxToolOutputBytes(tt.limit)
+			contents, err := llmflow.GetContents(cp, "", events, "writer", types.NewEvent)
+			if err != nil {
+				t.Fatalf("getContents: %v", err)
+			}
+			var got []string
+			for _, content := range contents {
+				for _, part := range content.Parts {
+					if resp := part.FunctionResponse; resp != nil {
+						if resp.Name != "lookup" || resp.ID == "" {
+							t.Errorf("function response = (%s, %s), want the name and ID of its call", resp.Name, resp.ID)
+						}
+						got = append(got, resp.Response["result"].(string))
+					}
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("tool outputs mismatch (-want +got):\n%s", diff)
+			}
+			if events[1].Content.Parts[0].FunctionResponse.Response["result"] != output {
+				t.Error("the tool output budget modified the events of the session")
+			}
+		})
+	}
+}
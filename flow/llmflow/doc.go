@@ -88,6 +88,14 @@
 //	processor := &BasicLlmRequestProcessor{}
 //	// Automatically manages model creation, request formatting, and basic error handling
 //
+// It merges the GenerationOverrides of the RunConfig over the generation config of the agent, so
+// that a caller tunes the temperature, top-p, top-k, maximum output tokens or stop sequences of
+// one invocation without rebuilding the agent; invalid overrides fail the model call:
+//
+//	ictx.RunConfig.GenerationOverrides = &types.GenerationOverrides{
+//		Temperature: types.ToPtr[float32](0.1),
+//	}
+//
 // ## AuthLLMRequestProcessor
 //
 // Processes authentication requirements for tools:
@@ -100,7 +108,15 @@
 // Manages system instructions and context:
 //
 //	processor := &InstructionsLlmRequestProcessor{}
-//	// Applies agent instructions, context-specific prompts, and system messages
+//	// Applies the global instruction of the root agent and the agent instruction, with state values injected
+//
+// For agents with a long instruction, WithInstructionMemoization builds the instructions once per
+// invocation and reuses them across the function calling loop. A dynamic instruction returning
+// another text, or a change of a state value it refers to, rebuilds them, reported to the hook:
+//
+//	flow := NewSingleFlow().WithInstructionMemoization(func(r InstructionRebuild) {
+//		logger.Debug("instruction rebuilt", "agent", r.Agent, "reason", r.Reason)
+//	})
 //
 // ## ContentLLMRequestProcessor
 //
@@ -109,6 +125,29 @@
 //	processor := &ContentLLMRequestProcessor{}
 //	// Handles content optimization, artifact management, and context preparation
 //
+// WithMaxTurns keeps only the most recent conversational turns, a turn being a user message and
+// the full response to it including the tool round-trips:
+//
+//	processor := (&ContentLLMRequestProcessor{}).WithMaxTurns(5)
+//
+// WithDedupe replaces the later copies of a large part repeated in the history, such as a
+// document pasted several times, with a short DedupeMarker, keeping the first full copy. Only the
+// request is rewritten, the session is left as is. The flow sets it with LLMFlow.WithDedupe:
+//
+//	processor := (&ContentLLMRequestProcessor{}).WithDedupe(4096)
+//
+// WithMaxToolOutputBytes caps the total size of the tool outputs in the history, truncating the
+// oldest ones over the budget, after the turn window and the deduplication. The flow sets it on its
+// processors with WithMaxTotalToolOutputBytes, the aggregate guard on top of the per-tool limits
+// of WithFunctionResponseFormat:
+//
+//	flow.WithMaxTotalToolOutputBytes(64 << 10)
+//
+// The history is built with BuildHistory, which custom flows and tools can use to reconstruct the
+// same contents from the events of a session:
+//
+//	contents, err := BuildHistory(session.Events(), WithHistoryAgent(agent.Name()), WithToolTurns(false))
+//
 // ## CodeExecutionRequestProcessor
 //
 // Prepares code execution context and optimizes data files:
@@ -134,6 +173,12 @@
 //	processor := &CodeExecutionResponseProcessor{}
 //	// Detects code blocks, executes them securely, and integrates results
 //
+// Only the first code block of an allowed language is executed, the language being the first word
+// after the opening fence. AllowedLanguages defaults to the languages supported by the code
+// executor; unlabeled blocks and blocks of other languages, such as json, are left as text:
+//
+//	processor := &CodeExecutionResponseProcessor{AllowedLanguages: []string{"python"}}
+//
 // ## NLPlanningResponseProcessor
 //
 // Processes natural language planning responses:
@@ -160,6 +205,37 @@
 //		}
 //	}
 //
+// WithFunctionResponseFormat controls how the tool results become the function responses sent
+// back to the model, for example as their JSON form capped in size:
+//
+//	flow.WithFunctionResponseFormat(&FunctionResponseFormat{
+//		Encoding: FunctionResponseJSON,
+//		MaxDepth: 4,
+//		MaxBytes: 16 << 10,
+//	})
+//
+// WithDryRun previews the actions of an agent without taking them, such as before approving an
+// agent that sends emails: the tool calls are answered with a synthetic "dry-run: would have
+// called" response instead of being run, and the flow ends with an event listing them:
+//
+//	for event, err := range flow.WithDryRun(true).Run(ctx, ictx) {
+//		if err == nil && event.IntendedToolCalls != nil {
+//			// Review or approve the intended tool calls.
+//		}
+//	}
+//
+// # Citations
+//
+// WithCitations extracts the grounding supports and citations of the model responses into
+// Event.Citations, with the URI and title of each source and the span of text it supports:
+//
+//	flow.WithCitations(true)
+//	for event, err := range flow.Run(ctx, ictx) {
+//		for _, c := range event.Citations {
+//			fmt.Printf("[%s](%s): %q\n", c.Title, c.URI, c.Text)
+//		}
+//	}
+//
 // # Authentication Flow
 //
 // Authentication is seamlessly integrated through the auth processor:
@@ -197,6 +273,13 @@
 //	// - Conversation continuity
 //	// - Proper delegation workflow
 //
+// WithMaxTransferDepth bounds the number of transfers in an invocation, so that agents handing
+// the conversation back and forth cannot loop until the token budget is spent. The transfer over
+// the limit is replaced by an event with the types.ErrorCodeMaxTransferDepth error code:
+//
+//	flow := NewAutoFlow()
+//	flow.WithMaxTransferDepth(5)
+//
 // # Code Execution Pipeline
 //
 // Code execution is integrated throughout the pipeline:
@@ -220,20 +303,23 @@
 //
 // # Error Handling and Retry Logic
 //
-// The pipeline includes comprehensive error handling:
+// The failure of a stage of a flow step is reported as a [*types.FlowError], naming the stage
+// (preprocess, model, postprocess, tool or transfer), the processor, model, tool or agent that
+// failed, the invocation and branch, and the events of the step before the failure. It wraps
+// the failure of the stage, so that [errors.As] and [errors.Is] still match the underlying error:
 //
 //	for event, err := range flow.Run(ctx, ictx) {
 //		if err != nil {
-//			// Handle different error types
-//			if rateLimitErr, ok := err.(*types.RateLimitError); ok {
+//			var rateLimitErr *types.RateLimitError
+//			if errors.As(err, &rateLimitErr) {
 //				// Wait and retry
-//				time.Sleep(rateLimitErr.RetryAfter)
+//				time.Sleep(rateLimitErr.RetryDelay())
 //				continue
 //			}
 //
-//			if execErr, ok := err.(*types.ExecutionError); ok {
-//				// Code execution failed
-//				log.Printf("Execution failed after %d attempts: %v", execErr.Attempts, execErr.LastError)
+//			var flowErr *types.FlowError
+//			if errors.As(err, &flowErr) {
+//				log.Printf("%s %s failed after %d events: %v", flowErr.Stage, flowErr.Name, len(flowErr.Events), errors.Unwrap(flowErr))
 //			}
 //
 //			// Other error handling
@@ -310,6 +396,34 @@
 //   - Efficient memory management with object pooling
 //   - Request batching where supported
 //
+// # Multi-Tenant Rate Limiting
+//
+// A [TenantRateLimiter] keeps one tenant from starving the others by throttling the model calls
+// per application and user, by calls per second and tokens per minute:
+//
+//	limiter := llmflow.NewTenantRateLimiter(
+//		llmflow.TenantLimits{QPS: 2, TokensPerMinute: 50_000},
+//		llmflow.WithTenantLimits("my-app", "premium-user", llmflow.TenantLimits{QPS: 10, TokensPerMinute: 500_000}),
+//	)
+//	flow := llmflow.NewSingleFlow()
+//	flow.WithTenantRateLimiter(limiter)
+//
+// A call over budget fails with an error matching [types.ErrRateLimited], unless the limiter waits
+// for the budget to refill with [WithRateLimitWait].
+//
+// # System Content Ordering
+//
+// The instructions, identity, few-shot examples and memory are contributed to the system
+// instruction by separate processors and tools, as [types.SystemContentBlock]s. Their order, which
+// affects the model behavior, can be set on the flow, omitting the blocks not listed:
+//
+//	flow := llmflow.NewSingleFlow()
+//	flow.WithSystemContentOrder(
+//		types.SystemContentIdentity,
+//		types.SystemContentInstructions,
+//		types.SystemContentMemory,
+//	)
+//
 // # Security Considerations
 //
 // The pipeline implements security best practices:
@@ -0,0 +1,65 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// HandleFunctionCallsWithLimit exports handleFunctionCalls with a concurrency limit for testing.
+func HandleFunctionCallsWithLimit(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, filters py.Set[string], maxParallel int) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, filters, functionCallOptions{maxParallel: maxParallel})
+}
+
+// HandleFunctionCallsWithoutValidation exports handleFunctionCalls with the argument validation disabled for testing.
+func HandleFunctionCallsWithoutValidation(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, nil, functionCallOptions{skipArgValidation: true})
+}
+
+// GetContents exports ContentLLMRequestProcessor.getContents for testing.
+var GetContents = (*ContentLLMRequestProcessor).getContents
+
+// SetTenantRateLimiterClock sets the clock of the limiter for testing.
+func SetTenantRateLimiterClock(l *TenantRateLimiter, now func() time.Time) {
+	l.now = now
+}
+
+// EstimateTokens exports estimateTokens with the default tokenizer for testing.
+func EstimateTokens(request *types.LLMRequest) int {
+	return estimateTokens(nil, request)
+}
+
+// Preprocess exports LLMFlow.preprocess for testing.
+func Preprocess(ctx context.Context, f *LLMFlow, ictx *types.InvocationContext, request *types.LLMRequest) iter.Seq2[*types.Event, error] {
+	return f.preprocess(ctx, ictx, request)
+}
+
+// HandleFunctionCallsWithAuditor exports handleFunctionCalls with a tool auditor and the argument validation disabled for testing.
+func HandleFunctionCallsWithAuditor(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, auditor types.ToolAuditor) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, nil, functionCallOptions{skipArgValidation: true, auditor: auditor})
+}
+
+// HandleFunctionCallsWithResponseFormat exports handleFunctionCalls with a function response format and the argument validation disabled for testing.
+func HandleFunctionCallsWithResponseFormat(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, format *FunctionResponseFormat) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, nil, functionCallOptions{skipArgValidation: true, responseFormat: format})
+}
+
+// HandleFunctionCallsWithDryRun exports handleFunctionCalls in a dry run with a tool auditor and the argument validation disabled for testing.
+func HandleFunctionCallsWithDryRun(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, auditor types.ToolAuditor) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, nil, functionCallOptions{skipArgValidation: true, auditor: auditor, dryRun: true})
+}
+
+// DryRunEvent exports LLMFlow.dryRunEvent for testing.
+var DryRunEvent = (*LLMFlow).dryRunEvent
+
+// TransferLimitEvent exports LLMFlow.transferLimitEvent for testing.
+var TransferLimitEvent = (*LLMFlow).transferLimitEvent
+
+// FinalizeModelResponseEvent exports LLMFlow.finalizeModelResponseEvent for testing.
+var FinalizeModelResponseEvent = (*LLMFlow).finalizeModelResponseEvent
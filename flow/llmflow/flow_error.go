@@ -0,0 +1,47 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// wrapFlowError returns err as a [*types.FlowError] of the stage of the invocation.
+//
+// An error already holding a [*types.FlowError] is returned as is, so that the stage closest to
+// the failure is reported.
+func wrapFlowError(ic *types.InvocationContext, stage types.FlowStage, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var flowErr *types.FlowError
+	if errors.As(err, &flowErr) {
+		return err
+	}
+	return &types.FlowError{
+		Stage:        stage,
+		Name:         name,
+		InvocationID: ic.InvocationID,
+		Branch:       ic.Branch,
+		Err:          err,
+	}
+}
+
+// withFlowEvents records the events yielded by the flow before err, unless the [*types.FlowError]
+// of err already records them.
+func withFlowEvents(err error, events []*types.Event) error {
+	var flowErr *types.FlowError
+	if errors.As(err, &flowErr) && flowErr.Events == nil {
+		flowErr.Events = events
+	}
+	return err
+}
+
+// processorName returns the name of the processor reported by a [*types.FlowError].
+func processorName(processor any) string {
+	return fmt.Sprintf("%T", processor)
+}
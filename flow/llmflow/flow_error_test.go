@@ -0,0 +1,116 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// failingProcessor is a request processor yielding an event, then failing.
+type failingProcessor struct {
+	err error
+}
+
+func (p *failingProcessor) Run(ctx context.Context, ictx *types.InvocationContext, request *types.LLMRequest) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		event := ictx.NewEvent().WithAuthor(ictx.Agent.Name())
+		if !yield(event, nil) {
+			return
+		}
+		yield(nil, p.err)
+	}
+}
+
+func TestLLMFlow_Run_FlowError(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "helper")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	ictx.Branch = "root.helper"
+
+	errProcess := errors.New("process failed")
+	flow := llmflow.NewLLMFlow().WithRequestProcessors(&failingProcessor{err: errProcess})
+
+	var events []*types.Event
+	var runErr error
+	for event, err := range flow.Run(t.Context(), ictx) {
+		if err != nil {
+			runErr = err
+			break
+		}
+		events = append(events, event)
+	}
+
+	if !errors.Is(runErr, types.ErrFlow) || !errors.Is(runErr, errProcess) {
+		t.Fatalf("Run() error = %v, want %v wrapping %v", runErr, types.ErrFlow, errProcess)
+	}
+	var flowErr *types.FlowError
+	if !errors.As(runErr, &flowErr) {
+		t.Fatalf("Run() error = %T, want *types.FlowError", runErr)
+	}
+	if flowErr.Stage != types.FlowStagePreprocess || flowErr.Name != "*llmflow_test.failingProcessor" {
+		t.Errorf("FlowError stage = (%s, %s), want (%s, *llmflow_test.failingProcessor)", flowErr.Stage, flowErr.Name, types.FlowStagePreprocess)
+	}
+	if flowErr.InvocationID != ictx.InvocationID || flowErr.Branch != "root.helper" {
+		t.Errorf("FlowError invocation = (%s, %s), want (%s, root.helper)", flowErr.InvocationID, flowErr.Branch, ictx.InvocationID)
+	}
+	if len(flowErr.Events) != 1 || flowErr.Events[0] != events[0] {
+		t.Errorf("FlowError events = %v, want the %d events yielded before the failure", flowErr.Events, len(events))
+	}
+	if got := errors.Unwrap(runErr); got != errProcess {
+		t.Errorf("errors.Unwrap() = %v, want %v", got, errProcess)
+	}
+}
+
+var errTool = errors.New("tool failed")
+
+func failTool(ctx context.Context, args map[string]any) (any, error) {
+	return nil, errTool
+}
+
+func TestHandleFunctionCalls_FlowError(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "helper")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	fail := tools.NewFunctionTool(failTool)
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "failTool"}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+
+	_, err = llmflow.HandleFunctionCalls(t.Context(), ictx, funcCallEvent, map[string]types.Tool{fail.Name(): fail}, nil)
+	var flowErr *types.FlowError
+	if !errors.As(err, &flowErr) {
+		t.Fatalf("HandleFunctionCalls() error = %v, want a *types.FlowError", err)
+	}
+	if flowErr.Stage != types.FlowStageTool || flowErr.Name != "failTool" || flowErr.InvocationID != ictx.InvocationID {
+		t.Errorf("FlowError = (%s, %s, %s), want (%s, failTool, %s)", flowErr.Stage, flowErr.Name, flowErr.InvocationID, types.FlowStageTool, ictx.InvocationID)
+	}
+	if !errors.Is(err, errTool) {
+		t.Errorf("HandleFunctionCalls() error = %v, want it to wrap %v", err, errTool)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// TruncationMarker replaces the values cut by the limits of a [FunctionResponseFormat].
+const TruncationMarker = "...(truncated)"
+
+// FunctionResponseEncoding is how a [FunctionResponseFormat] encodes the result of a tool.
+type FunctionResponseEncoding int
+
+const (
+	// FunctionResponseMap sends a map[string]any result as is, and rejects other results.
+	// This is the default.
+	FunctionResponseMap FunctionResponseEncoding = iota
+
+	// FunctionResponseJSON sends the JSON form of the result, so that structs are sent with their
+	// JSON field names. Results which are not JSON objects are sent under the "result" key.
+	FunctionResponseJSON
+
+	// FunctionResponseJSONString sends the result encoded as a JSON string under the "result" key.
+	FunctionResponseJSONString
+)
+
+// FunctionResponseFormat controls how the results of [types.Tool.Run] become the payload of the
+// function response sent back to the model.
+//
+// The zero value sends map[string]any results as is, without limits.
+type FunctionResponseFormat struct {
+	// Encoding is how the result is encoded.
+	Encoding FunctionResponseEncoding
+
+	// Indent indents the JSON string of [FunctionResponseJSONString] with the given string.
+	// Empty means compact.
+	Indent string
+
+	// MaxDepth replaces the objects and arrays nested deeper than MaxDepth with [TruncationMarker].
+	// The payload itself is at depth one. Zero means no limit.
+	MaxDepth int
+
+	// MaxBytes caps the size of the payload encoded as compact JSON. A larger payload is replaced
+	// by its JSON truncated to MaxBytes followed by [TruncationMarker], under the "result" key with
+	// "truncated" set to true. Zero means no limit.
+	MaxBytes int
+
+	// Serializer converts the result into the payload, in place of Encoding.
+	// MaxDepth and MaxBytes still apply to its payload.
+	Serializer func(result any) (map[string]any, error)
+}
+
+// Encode converts the result of a tool into the payload of its function response.
+func (f *FunctionResponseFormat) Encode(result any) (map[string]any, error) {
+	var payload map[string]any
+	switch {
+	case f.Serializer != nil:
+		m, err := f.Serializer(result)
+		if err != nil {
+			return nil, fmt.Errorf("serialize function response: %w", err)
+		}
+		payload = m
+
+	case f.Encoding == FunctionResponseMap:
+		m, ok := result.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("res is not map[string]any: %T", result)
+		}
+		payload = m
+
+	default:
+		v, err := toJSONValue(result)
+		if err != nil {
+			return nil, err
+		}
+		if f.Encoding == FunctionResponseJSONString {
+			return f.encodeString(v)
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			m = map[string]any{"result": v}
+		}
+		payload = m
+	}
+
+	if f.MaxDepth > 0 {
+		payload = truncateDepth(payload, f.MaxDepth).(map[string]any)
+	}
+	if f.MaxBytes > 0 {
+		data, err := json.Marshal(payload, json.DefaultOptionsV2(), json.Deterministic(true))
+		if err != nil {
+			return nil, fmt.Errorf("encode function response: %w", err)
+		}
+		if len(data) > f.MaxBytes {
+			return map[string]any{
+				"result":    truncateString(string(data), f.MaxBytes),
+				"truncated": true,
+			}, nil
+		}
+	}
+
+	return payload, nil
+}
+
+// encodeString returns the payload holding the JSON string of the decoded JSON value.
+func (f *FunctionResponseFormat) encodeString(v any) (map[string]any, error) {
+	if f.MaxDepth > 0 {
+		v = truncateDepth(v, f.MaxDepth)
+	}
+	opts := []json.Options{json.DefaultOptionsV2(), json.Deterministic(true)}
+	if f.Indent != "" {
+		opts = append(opts, jsontext.WithIndent(f.Indent))
+	}
+	data, err := json.Marshal(v, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("encode function response: %w", err)
+	}
+
+	s := string(data)
+	if f.MaxBytes > 0 && len(s) > f.MaxBytes {
+		return map[string]any{"result": truncateString(s, f.MaxBytes), "truncated": true}, nil
+	}
+	return map[string]any{"result": s}, nil
+}
+
+// toJSONValue returns the JSON form of v, decoded into maps, slices and scalars.
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v, json.DefaultOptionsV2())
+	if err != nil {
+		return nil, fmt.Errorf("encode function response: %w", err)
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded, json.DefaultOptionsV2()); err != nil {
+		return nil, fmt.Errorf("decode function response: %w", err)
+	}
+	return decoded, nil
+}
+
+// truncateDepth returns v with the maps and slices nested deeper than depth replaced by
+// [TruncationMarker]. The maps and slices are copied, not modified.
+func truncateDepth(v any, depth int) any {
+	switch v := v.(type) {
+	case map[string]any:
+		if depth <= 0 {
+			return TruncationMarker
+		}
+		truncated := make(map[string]any, len(v))
+		for key, e := range v {
+			truncated[key] = truncateDepth(e, depth-1)
+		}
+		return truncated
+	case []any:
+		if depth <= 0 {
+			return TruncationMarker
+		}
+		truncated := make([]any, len(v))
+		for i, e := range v {
+			truncated[i] = truncateDepth(e, depth-1)
+		}
+		return truncated
+	default:
+		return v
+	}
+}
+
+// truncateString returns the first n bytes of s, cut at a rune boundary, followed by
+// [TruncationMarker].
+func truncateString(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + TruncationMarker
+}
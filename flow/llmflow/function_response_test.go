@@ -0,0 +1,139 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+type forecast struct {
+	City  string `json:"city"`
+	Days  []day  `json:"days"`
+	Notes string `json:"notes,omitempty"`
+}
+
+type day struct {
+	High int `json:"high"`
+}
+
+func TestFunctionResponseFormat_Encode(t *testing.T) {
+	t.Parallel()
+
+	result := forecast{City: "Tokyo", Days: []day{{High: 20}}}
+
+	tests := map[string]struct {
+		format  llmflow.FunctionResponseFormat
+		result  any
+		want    map[string]any
+		wantErr bool
+	}{
+		"map passes through": {
+			result: map[string]any{"weather": "sunny"},
+			want:   map[string]any{"weather": "sunny"},
+		},
+		"map rejects other results": {
+			result:  result,
+			wantErr: true,
+		},
+		"json object": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSON},
+			result: result,
+			want:   map[string]any{"city": "Tokyo", "days": []any{map[string]any{"high": 20.0}}},
+		},
+		"json scalar": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSON},
+			result: 42,
+			want:   map[string]any{"result": 42.0},
+		},
+		"json string compact": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSONString},
+			result: result,
+			want:   map[string]any{"result": `{"city":"Tokyo","days":[{"high":20}]}`},
+		},
+		"json string pretty": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSONString, Indent: " "},
+			result: day{High: 20},
+			want:   map[string]any{"result": "{\n \"high\": 20\n}"},
+		},
+		"max depth": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSON, MaxDepth: 2},
+			result: result,
+			want:   map[string]any{"city": "Tokyo", "days": []any{llmflow.TruncationMarker}},
+		},
+		"max bytes": {
+			format: llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSON, MaxBytes: 10},
+			result: result,
+			want:   map[string]any{"result": `{"city":"T` + llmflow.TruncationMarker, "truncated": true},
+		},
+		"max bytes not reached": {
+			format: llmflow.FunctionResponseFormat{MaxBytes: 100},
+			result: map[string]any{"weather": "sunny"},
+			want:   map[string]any{"weather": "sunny"},
+		},
+		"serializer": {
+			format: llmflow.FunctionResponseFormat{
+				Serializer: func(result any) (map[string]any, error) {
+					return map[string]any{"summary": result.(forecast).City}, nil
+				},
+			},
+			result: result,
+			want:   map[string]any{"summary": "Tokyo"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.format.Encode(tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encode() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Encode() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandleFunctionCalls_ResponseFormat(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	getForecast := tools.NewFunctionTool(func(context.Context, map[string]any) (any, error) {
+		return forecast{City: "Tokyo", Notes: strings.Repeat("n", 100)}, nil
+	})
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: getForecast.Name()}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	format := &llmflow.FunctionResponseFormat{Encoding: llmflow.FunctionResponseJSON, MaxBytes: 50}
+
+	event, err := llmflow.HandleFunctionCallsWithResponseFormat(t.Context(), ictx, funcCallEvent, map[string]types.Tool{getForecast.Name(): getForecast}, format)
+	if err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+	resp := event.GetFunctionResponses()[0].Response
+	if resp["truncated"] != true || !strings.HasSuffix(resp["result"].(string), llmflow.TruncationMarker) {
+		t.Errorf("function response = %v, want truncated result", resp)
+	}
+}
@@ -10,11 +10,13 @@ import (
 	"iter"
 	"log/slog"
 	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-json-experiment/json"
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/genai"
 
 	"github.com/go-a2a/adk-go/internal/pool"
@@ -128,7 +130,46 @@ func GenerateAuthEvent(ctx context.Context, ictx *types.InvocationContext, funcR
 }
 
 // HandleFunctionCalls processes function calls asynchronously.
+//
+// All function calls are executed in parallel. Use [LLMFlow.WithMaxParallelToolCalls] to bound the concurrency.
+// The arguments of each call are validated against the parameters declared by its tool.
 func HandleFunctionCalls(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, filters py.Set[string]) (*types.Event, error) {
+	return handleFunctionCalls(ctx, ictx, functionCallEvent, toolsDict, filters, functionCallOptions{})
+}
+
+// functionCallOptions holds the flow settings applied to the function calls.
+type functionCallOptions struct {
+	// maxParallel is the maximum number of function calls executed at once. Zero or less means no limit.
+	maxParallel int
+
+	// skipArgValidation disables the validation of the arguments against the tool declarations.
+	skipArgValidation bool
+
+	// auditor records the tool calls, if set.
+	auditor types.ToolAuditor
+
+	// responseFormat converts the tool results into function responses, if set.
+	responseFormat *FunctionResponseFormat
+
+	// toolResponseFormats overrides responseFormat for the tools of the given names.
+	toolResponseFormats map[string]*FunctionResponseFormat
+
+	// dryRun intercepts the tool calls instead of running them.
+	dryRun bool
+}
+
+// responseFormatFor returns the format of the function responses of the named tool, nil for the default.
+func (o functionCallOptions) responseFormatFor(toolName string) *FunctionResponseFormat {
+	if format, ok := o.toolResponseFormats[toolName]; ok {
+		return format
+	}
+	return o.responseFormat
+}
+
+// handleFunctionCalls processes function calls in parallel, running at most opts.maxParallel calls at once.
+//
+// The function responses are merged in the order of the function calls, regardless of their completion order.
+func handleFunctionCalls(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, filters py.Set[string], opts functionCallOptions) (*types.Event, error) {
 	// Check if context is already canceled
 	select {
 	case <-ctx.Done():
@@ -142,102 +183,122 @@ func HandleFunctionCalls(ctx context.Context, ictx *types.InvocationContext, fun
 	}
 
 	// Extract function calls from event
-	funcResponseEvents := []*types.Event{}
 	funcCalls := functionCallEvent.GetFunctionCalls()
 
-	// Create result channels
-	resultCh := make(chan *types.Event, 1)
-	errCh := make(chan error, 1)
+	// Each call writes its own slot, which keeps the response order stable
+	results := make([]*types.Event, len(funcCalls))
 
-	go func() {
-		for _, funcCall := range funcCalls {
-			if !filters.Has(funcCall.ID) {
-				continue
+	eg, egCtx := errgroup.WithContext(ctx)
+	if opts.maxParallel > 0 {
+		eg.SetLimit(opts.maxParallel)
+	}
+	for i, funcCall := range funcCalls {
+		if len(filters) > 0 && !filters.Has(funcCall.ID) {
+			continue
+		}
+		eg.Go(func() error {
+			if err := egCtx.Err(); err != nil {
+				return err
 			}
-			t, toolCtx, err := getToolAndContext(ctx, ictx, funcCall, toolsDict)
+			funcResponseEvent, err := handleFunctionCall(egCtx, ictx, llmAgent, funcCall, toolsDict, opts)
 			if err != nil {
-				errCh <- err
-				return
+				return err
 			}
+			results[i] = funcResponseEvent
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
 
-			funcArgs := funcCall.Args
-			var funcResponse map[string]any
-			for i, callback := range llmAgent.BeforeToolCallback() {
-				funcResponse, err = callback(t, funcArgs, toolCtx)
-				if err != nil {
-					errCh <- fmt.Errorf("BeforeToolCallbacks[%d]: %w", i, err)
-					return
-				}
-				// TODO(zchee): wait for complete with [py.Future]
-				// if inspect.isawaitable(function_response):
-				//   function_response = await function_response
-				if len(funcResponse) == 0 {
-					break
-				}
-			}
+	funcResponseEvents := slices.DeleteFunc(results, func(event *types.Event) bool { return event == nil })
+	if len(funcResponseEvents) == 0 {
+		return nil, nil
+	}
 
-			if len(funcResponse) == 0 {
-				funcResponse, err = callTool(ctx, t, funcArgs, toolCtx)
-				if err != nil {
-					errCh <- err
-					return
-				}
-			}
+	mergedEvent, err := mergeParallelFunctionResponseEvents(ictx, funcResponseEvents)
+	if err != nil {
+		return nil, err
+	}
 
-			for i, callback := range llmAgent.AfterToolCallbacks() {
-				funcResp, err := callback(t, funcArgs, toolCtx, funcResponse)
-				if err != nil {
-					errCh <- fmt.Errorf("BeforeToolCallbacks[%d]: %w", i, err)
-					return
-				}
-				// TODO(zchee): wait for complete with [py.Future]
-				// if inspect.isawaitable(function_response):
-				//   function_response = await function_response
-				if len(funcResp) > 0 {
-					funcResponse = funcResp
-					break
-				}
+	if len(funcResponseEvents) > 1 {
+		// TODO(zchee): support OTel tracing
+	}
 
-				if t.IsLongRunning() && len(funcResponse) == 0 {
-					continue
-				}
+	return mergedEvent, nil
+}
 
-				// Builds the function response event
-				funcResponseEvent := buildResponseEvent(ctx, t, funcResponse, toolCtx, ictx)
-				funcResponseEvents = append(funcResponseEvents, funcResponseEvent)
-			}
+// handleFunctionCall executes a single function call with the tool callbacks of the agent and returns the function response event.
+//
+// It returns a nil event for a long running tool without response.
+func handleFunctionCall(ctx context.Context, ictx *types.InvocationContext, llmAgent types.LLMAgent, funcCall *genai.FunctionCall, toolsDict map[string]types.Tool, opts functionCallOptions) (*types.Event, error) {
+	t, toolCtx, err := getToolAndContext(ctx, ictx, funcCall, toolsDict)
+	if err != nil {
+		if errors.Is(err, types.ErrUnknownFunction) {
+			return buildUnknownFunctionEvent(ctx, funcCall, err, ictx), nil
 		}
+		return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, err)
+	}
 
-		if len(funcResponseEvents) == 0 {
-			return
+	funcArgs := funcCall.Args
+	var funcResponse map[string]any
+	for i, callback := range llmAgent.BeforeToolCallback() {
+		funcResponse, err = callback(t, funcArgs, toolCtx)
+		if err != nil {
+			return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, fmt.Errorf("BeforeToolCallbacks[%d]: %w", i, err))
+		}
+		// TODO(zchee): wait for complete with [py.Future]
+		// if inspect.isawaitable(function_response):
+		//   function_response = await function_response
+		if len(funcResponse) == 0 {
+			break
 		}
+	}
 
-		mergedEvent, err := mergeParallelFunctionResponseEvents(funcResponseEvents)
+	if len(funcResponse) == 0 {
+		if !opts.skipArgValidation {
+			if err := validateFunctionArgs(t, funcCall); err != nil {
+				return buildInvalidArgumentsEvent(ctx, funcCall, err, ictx), nil
+			}
+		}
+		funcResponse, err = runTool(ctx, ictx, t, funcCall, toolCtx, opts)
 		if err != nil {
-			errCh <- err
-			return
+			return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, err)
 		}
+	}
 
-		if len(funcResponseEvents) > 1 {
-			// TODO(zchee): support OTel tracing
+	for i, callback := range llmAgent.AfterToolCallbacks() {
+		funcResp, err := callback(t, funcArgs, toolCtx, funcResponse)
+		if err != nil {
+			return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, fmt.Errorf("AfterToolCallbacks[%d]: %w", i, err))
 		}
+		// TODO(zchee): wait for complete with [py.Future]
+		// if inspect.isawaitable(function_response):
+		//   function_response = await function_response
+		if len(funcResp) > 0 {
+			funcResponse = funcResp
+			break
+		}
+	}
 
-		resultCh <- mergedEvent
-	}()
-
-	// Wait for result or cancellation
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case err := <-errCh:
-		return nil, err
-	case mergedEvent := <-resultCh:
-		return mergedEvent, nil
+	if t.IsLongRunning() && len(funcResponse) == 0 {
+		return nil, nil
 	}
+
+	// Builds the function response event
+	return buildResponseEvent(ctx, t, funcResponse, toolCtx, ictx), nil
 }
 
 // HandleFunctionCallsLive calls the functions and returns the function response event.
+//
+// The arguments of each call are validated against the parameters declared by its tool.
 func HandleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool) (*types.Event, error) {
+	return handleFunctionCallsLive(ctx, ictx, functionCallEvent, toolsDict, functionCallOptions{})
+}
+
+// handleFunctionCallsLive calls the functions sequentially and returns the function response event.
+func handleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext, functionCallEvent *types.Event, toolsDict map[string]types.Tool, opts functionCallOptions) (*types.Event, error) {
 	// Check if context is already canceled
 	select {
 	case <-ctx.Done():
@@ -255,7 +316,11 @@ func HandleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext,
 	for _, funcCall := range funcCalls {
 		t, toolCtx, err := getToolAndContext(ctx, ictx, funcCall, toolsDict)
 		if err != nil {
-			return nil, err
+			if errors.Is(err, types.ErrUnknownFunction) {
+				funcResponseEvents = append(funcResponseEvents, buildUnknownFunctionEvent(ctx, funcCall, err, ictx))
+				continue
+			}
+			return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, err)
 		}
 
 		funcArgs := funcCall.Args
@@ -264,19 +329,25 @@ func HandleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext,
 			for _, callback := range callbacks {
 				functResponse, err = callback(t, funcArgs, toolCtx)
 				if err != nil {
-					return nil, err
+					return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, err)
 				}
 			}
 		}
 		if len(functResponse) == 0 {
-			functResponse = processFunctionLiveHelper(ctx, t, toolCtx, funcCall, funcArgs, ictx)
+			if !opts.skipArgValidation {
+				if err := validateFunctionArgs(t, funcCall); err != nil {
+					funcResponseEvents = append(funcResponseEvents, buildInvalidArgumentsEvent(ctx, funcCall, err, ictx))
+					continue
+				}
+			}
+			functResponse = processFunctionLiveHelper(ctx, t, toolCtx, funcCall, funcArgs, ictx, opts)
 		}
 
 		if callbacks := llmAgent.AfterToolCallbacks(); len(callbacks) > 0 {
 			for _, callback := range callbacks {
 				functResponse, err = callback(t, funcArgs, toolCtx, functResponse)
 				if err != nil {
-					return nil, err
+					return nil, wrapFlowError(ictx, types.FlowStageTool, funcCall.Name, err)
 				}
 			}
 		}
@@ -291,7 +362,7 @@ func HandleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext,
 	var mergedEvent *types.Event
 	if len(funcResponseEvents) > 0 {
 		var err error
-		mergedEvent, err = mergeParallelFunctionResponseEvents(funcResponseEvents)
+		mergedEvent, err = mergeParallelFunctionResponseEvents(ictx, funcResponseEvents)
 		if err != nil {
 			return nil, err
 		}
@@ -300,7 +371,7 @@ func HandleFunctionCallsLive(ctx context.Context, ictx *types.InvocationContext,
 	return mergedEvent, nil
 }
 
-func processFunctionLiveHelper(ctx context.Context, t types.Tool, toolCtx *types.ToolContext, funcCall *genai.FunctionCall, funcArgs map[string]any, ictx *types.InvocationContext) map[string]any {
+func processFunctionLiveHelper(ctx context.Context, t types.Tool, toolCtx *types.ToolContext, funcCall *genai.FunctionCall, funcArgs map[string]any, ictx *types.InvocationContext, opts functionCallOptions) map[string]any {
 	funcResponse := make(map[string]any)
 
 	if funcCall.Name == "stop_streaming" && xmaps.Contains(funcArgs, "function_name") {
@@ -370,7 +441,7 @@ func processFunctionLiveHelper(ctx context.Context, t types.Tool, toolCtx *types
 		return funcResponse
 	}
 
-	resp, err := callTool(ctx, t, funcArgs, toolCtx)
+	resp, err := runTool(ctx, ictx, t, funcCall, toolCtx, opts)
 	if err != nil {
 		return nil
 	}
@@ -382,9 +453,12 @@ func processFunctionLiveHelper(ctx context.Context, t types.Tool, toolCtx *types
 func getToolAndContext(ctx context.Context, ictx *types.InvocationContext, funcCall *genai.FunctionCall, toolsDict map[string]types.Tool) (types.Tool, *types.ToolContext, error) {
 	t, ok := toolsDict[funcCall.Name]
 	if !ok {
-		return nil, nil, fmt.Errorf("Function %s is not found in the tools_dict", funcCall.Name)
+		return nil, nil, &types.UnknownFunctionError{
+			Name:      funcCall.Name,
+			Available: slices.Sorted(maps.Keys(toolsDict)),
+		}
 	}
-	toolCtx := types.NewToolContext(ictx).WithFunctionCallID(funcCall.ID)
+	toolCtx := types.NewToolContext(ictx).WithFunctionCallID(funcCall.ID).WithEventActions(types.NewEventActions())
 
 	return t, toolCtx, nil
 }
@@ -399,18 +473,78 @@ func callToolLive(ctx context.Context, t types.Tool, args map[string]any, toolCt
 	}
 }
 
-// callTool calls the tool.
-func callTool(ctx context.Context, t types.Tool, args map[string]any, tctx *types.ToolContext) (map[string]any, error) {
+// runTool calls the tool for the function call, recording the call with opts.auditor if set.
+//
+// With opts.dryRun, the tool is not run: the call is recorded as intended, see [dryRunTool].
+func runTool(ctx context.Context, ictx *types.InvocationContext, t types.Tool, funcCall *genai.FunctionCall, toolCtx *types.ToolContext, opts functionCallOptions) (map[string]any, error) {
+	if opts.dryRun {
+		return dryRunTool(ictx, t, funcCall, opts), nil
+	}
+
+	format := opts.responseFormatFor(t.Name())
+	if opts.auditor == nil {
+		return callTool(ctx, t, funcCall.Args, toolCtx, format)
+	}
+
+	start := ictx.Now()
+	result, err := callTool(ctx, t, funcCall.Args, toolCtx, format)
+	record := toolCallRecord(ictx, t, funcCall, start)
+	record.Duration = ictx.Now().Sub(start)
+	if err != nil {
+		record.Status = types.ToolCallStatusError
+		record.Error = err.Error()
+	}
+	opts.auditor.RecordToolCall(record)
+
+	return result, err
+}
+
+// dryRunTool records the function call as intended by the invocation, and with opts.auditor if
+// set, and returns the synthetic function response telling the model what would have been done.
+func dryRunTool(ictx *types.InvocationContext, t types.Tool, funcCall *genai.FunctionCall, opts functionCallOptions) map[string]any {
+	record := toolCallRecord(ictx, t, funcCall, ictx.Now())
+	record.Status = types.ToolCallStatusDryRun
+	ictx.AddIntendedToolCall(record)
+	if opts.auditor != nil {
+		opts.auditor.RecordToolCall(record)
+	}
+
+	args, err := json.Marshal(funcCall.Args, json.Deterministic(true))
+	if err != nil {
+		args = fmt.Appendf(nil, "%v", funcCall.Args)
+	}
+	return map[string]any{
+		"result": fmt.Sprintf("dry-run: would have called %s with args %s", t.Name(), args),
+	}
+}
+
+// toolCallRecord returns the record of the call of the tool started at start, with the ok status.
+func toolCallRecord(ictx *types.InvocationContext, t types.Tool, funcCall *genai.FunctionCall, start time.Time) types.ToolCallRecord {
+	return types.ToolCallRecord{
+		Timestamp:      start,
+		ToolName:       t.Name(),
+		FunctionCallID: funcCall.ID,
+		Args:           funcCall.Args,
+		Status:         types.ToolCallStatusOK,
+		AppName:        ictx.Session.AppName(),
+		UserID:         ictx.Session.UserID(),
+		SessionID:      ictx.Session.ID(),
+		InvocationID:   ictx.InvocationID,
+		AgentName:      ictx.Agent.Name(),
+	}
+}
+
+// callTool calls the tool and converts its result with format, or requires a map[string]any result if format is nil.
+func callTool(ctx context.Context, t types.Tool, args map[string]any, tctx *types.ToolContext, format *FunctionResponseFormat) (map[string]any, error) {
 	res, err := t.Run(ctx, args, tctx)
 	if err != nil {
 		return nil, err
 	}
-	result, ok := res.(map[string]any)
-	if !ok {
-		return nil, fmt.Errorf("res is not map[string]any: %T", res)
+	if format == nil {
+		format = &FunctionResponseFormat{}
 	}
 
-	return result, nil
+	return format.Encode(res)
 }
 
 // TODO(zchee): support OTel tracing.
@@ -430,7 +564,7 @@ func buildResponseEvent(ctx context.Context, t types.Tool, funcResult map[string
 		Parts: []*genai.Part{partFuncResponse},
 	}
 
-	funcRespEvent := types.NewEvent().
+	funcRespEvent := ictx.NewEvent().
 		WithInvocationID(ictx.InvocationID).
 		WithAuthor(ictx.Agent.Name()).
 		WithContent(content).
@@ -440,7 +574,73 @@ func buildResponseEvent(ctx context.Context, t types.Tool, funcResult map[string
 	return funcRespEvent
 }
 
-func mergeParallelFunctionResponseEvents(funcRespEvents []*types.Event) (*types.Event, error) {
+// buildUnknownFunctionEvent builds the function response event for a call to an unknown function.
+//
+// The error is fed back to the model as the function response so it can correct the call,
+// and is recorded on the event so observers of the run can see it.
+func buildUnknownFunctionEvent(ctx context.Context, funcCall *genai.FunctionCall, err error, ictx *types.InvocationContext) *types.Event {
+	funcResult := map[string]any{
+		"error": err.Error(),
+	}
+	var unknown *types.UnknownFunctionError
+	if errors.As(err, &unknown) {
+		funcResult["available_tools"] = unknown.Available
+	}
+	slog.Default().WarnContext(ctx, "model called an unknown function",
+		slog.String("function_name", funcCall.Name),
+		slog.String("function_call_id", funcCall.ID),
+		slog.Any("error", err),
+	)
+
+	return buildErrorResponseEvent(funcCall, funcResult, types.ErrorCodeUnknownFunction, err, ictx)
+}
+
+// buildInvalidArgumentsEvent builds the function response event for a call with invalid arguments.
+//
+// The violations are fed back to the model as the function response so it can correct the call,
+// and the error is recorded on the event so observers of the run can see it.
+func buildInvalidArgumentsEvent(ctx context.Context, funcCall *genai.FunctionCall, err error, ictx *types.InvocationContext) *types.Event {
+	funcResult := map[string]any{
+		"error": err.Error(),
+	}
+	var invalid *types.InvalidArgumentsError
+	if errors.As(err, &invalid) {
+		violations := make([]any, len(invalid.Violations))
+		for i, v := range invalid.Violations {
+			violations[i] = map[string]any{
+				"path":   v.Path,
+				"reason": v.Reason,
+			}
+		}
+		funcResult["violations"] = violations
+	}
+	slog.Default().WarnContext(ctx, "model called a function with invalid arguments",
+		slog.String("function_name", funcCall.Name),
+		slog.String("function_call_id", funcCall.ID),
+		slog.Any("error", err),
+	)
+
+	return buildErrorResponseEvent(funcCall, funcResult, types.ErrorCodeInvalidArguments, err, ictx)
+}
+
+// buildErrorResponseEvent builds the function response event carrying funcResult for a failed call, with the error code set.
+func buildErrorResponseEvent(funcCall *genai.FunctionCall, funcResult map[string]any, errorCode string, err error, ictx *types.InvocationContext) *types.Event {
+	partFuncResponse := genai.NewPartFromFunctionResponse(funcCall.Name, funcResult)
+	partFuncResponse.FunctionResponse.ID = funcCall.ID
+
+	funcRespEvent := ictx.NewEvent().
+		WithInvocationID(ictx.InvocationID).
+		WithAuthor(ictx.Agent.Name()).
+		WithContent(genai.NewContentFromParts([]*genai.Part{partFuncResponse}, genai.RoleUser)).
+		WithActions(types.NewEventActions()).
+		WithBranch(ictx.Branch)
+	funcRespEvent.ErrorCode = errorCode
+	funcRespEvent.ErrorMessage = err.Error()
+
+	return funcRespEvent
+}
+
+func mergeParallelFunctionResponseEvents(ictx *types.InvocationContext, funcRespEvents []*types.Event) (*types.Event, error) {
 	switch len(funcRespEvents) {
 	case 0:
 		return nil, errors.New("no function response events provided")
@@ -470,8 +670,8 @@ func mergeParallelFunctionResponseEvents(funcRespEvents []*types.Event) (*types.
 	mergedActions.RequestedAuthConfigs = mergedRequestedAuthConfigs
 
 	// Create the new merged event
-	mergedEvent := types.NewEvent().
-		WithInvocationID(types.NewEventID()).
+	mergedEvent := ictx.NewEvent().
+		WithInvocationID(ictx.InvocationID).
 		WithAuthor(baseEvent.Author).
 		WithBranch(baseEvent.Branch).
 		WithContent(genai.NewContentFromParts(mergedParts, genai.Role("user"))).
@@ -480,5 +680,14 @@ func mergeParallelFunctionResponseEvents(funcRespEvents []*types.Event) (*types.
 	// Use the base_event as the timestamp
 	mergedEvent.Timestamp = baseEvent.Timestamp
 
+	// Keep the first error so observers still see it after the merge
+	for _, event := range funcRespEvents {
+		if event.LLMResponse != nil && event.ErrorCode != "" {
+			mergedEvent.ErrorCode = event.ErrorCode
+			mergedEvent.ErrorMessage = event.ErrorMessage
+			break
+		}
+	}
+
 	return mergedEvent, nil
 }
@@ -0,0 +1,467 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func getWeather(ctx context.Context, args map[string]any) (any, error) {
+	return map[string]any{"weather": "sunny"}, nil
+}
+
+func TestHandleFunctionCalls_UnknownFunction(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	weather := tools.NewFunctionTool(getWeather)
+	toolsDict := map[string]types.Tool{
+		weather.Name(): weather,
+	}
+
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "getWeather"}},
+			{FunctionCall: &genai.FunctionCall{ID: "call-2", Name: "getTime"}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+
+	event, err := llmflow.HandleFunctionCalls(t.Context(), ictx, funcCallEvent, toolsDict, nil)
+	if err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+
+	want := []*genai.FunctionResponse{
+		{
+			ID:       "call-1",
+			Name:     "getWeather",
+			Response: map[string]any{"weather": "sunny"},
+		},
+		{
+			ID:   "call-2",
+			Name: "getTime",
+			Response: map[string]any{
+				"error":           "no such tool: getTime",
+				"available_tools": []string{"getWeather"},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, event.GetFunctionResponses()); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := event.ErrorCode, types.ErrorCodeUnknownFunction; got != want {
+		t.Errorf("ErrorCode = %q, want %q", got, want)
+	}
+}
+
+func TestUnknownFunctionError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("call: %w", &types.UnknownFunctionError{Name: "getTime", Available: []string{"getWeather"}})
+	if !errors.Is(err, types.ErrUnknownFunction) {
+		t.Fatalf("errors.Is(%v, ErrUnknownFunction) = false, want true", err)
+	}
+
+	var unknown *types.UnknownFunctionError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("errors.As(%v) = false, want true", err)
+	}
+	if diff := cmp.Diff([]string{"getWeather"}, unknown.Available); diff != "" {
+		t.Errorf("Available mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestHandleFunctionCalls_MaxParallel(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numCalls    = 6
+		maxParallel = 2
+	)
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	var inFlight, peak atomic.Int32
+	echo := tools.NewFunctionTool(func(ctx context.Context, args map[string]any) (any, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		// earlier calls finish later
+		idx := args["index"].(int)
+		time.Sleep(time.Duration(numCalls-idx) * 5 * time.Millisecond)
+		return map[string]any{"index": idx}, nil
+	})
+	toolsDict := map[string]types.Tool{
+		echo.Name(): echo,
+	}
+
+	parts := make([]*genai.Part, numCalls)
+	want := make([]*genai.FunctionResponse, numCalls)
+	for i := range numCalls {
+		id := fmt.Sprintf("call-%d", i)
+		parts[i] = &genai.Part{FunctionCall: &genai.FunctionCall{ID: id, Name: echo.Name(), Args: map[string]any{"index": i}}}
+		want[i] = &genai.FunctionResponse{ID: id, Name: echo.Name(), Response: map[string]any{"index": i}}
+	}
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts(parts, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+
+	event, err := llmflow.HandleFunctionCallsWithLimit(t.Context(), ictx, funcCallEvent, toolsDict, nil, maxParallel)
+	if err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+
+	if diff := cmp.Diff(want, event.GetFunctionResponses()); diff != "" {
+		t.Errorf("function responses mismatch (-want +got):\n%s", diff)
+	}
+	if got := peak.Load(); got > maxParallel {
+		t.Errorf("peak concurrency = %d, want <= %d", got, maxParallel)
+	}
+}
+
+// searchTool is a function tool declaring typed parameters.
+type searchTool struct {
+	*tools.FunctionTool
+}
+
+func (t *searchTool) GetDeclaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name: t.Name(),
+		Parameters: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"query": {Type: genai.TypeString},
+				"limit": {Type: genai.TypeInteger},
+				"order": {Type: genai.TypeString, Enum: []string{"asc", "desc"}},
+				"tags":  {Type: genai.TypeArray, Items: &genai.Schema{Type: genai.TypeString}},
+			},
+			Required: []string{"query"},
+		},
+	}
+}
+
+func search(ctx context.Context, args map[string]any) (any, error) {
+	return map[string]any{"results": []any{}}, nil
+}
+
+func TestHandleFunctionCalls_InvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	var called atomic.Int32
+	searcher := &searchTool{FunctionTool: tools.NewFunctionTool(func(ctx context.Context, args map[string]any) (any, error) {
+		called.Add(1)
+		return search(ctx, args)
+	})}
+	toolsDict := map[string]types.Tool{
+		searcher.Name(): searcher,
+	}
+
+	newEvent := func(args map[string]any) *types.Event {
+		return types.NewEvent().
+			WithContent(genai.NewContentFromParts([]*genai.Part{
+				{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: searcher.Name(), Args: args}},
+			}, genai.RoleModel)).
+			WithActions(types.NewEventActions())
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		event, err := llmflow.HandleFunctionCalls(t.Context(), ictx, newEvent(map[string]any{
+			"query": "go",
+			"limit": float64(10),
+			"order": "asc",
+			"tags":  []any{"lang"},
+		}), toolsDict, nil)
+		if err != nil {
+			t.Fatalf("HandleFunctionCalls: %v", err)
+		}
+		if event.ErrorCode != "" {
+			t.Errorf("ErrorCode = %q, want empty", event.ErrorCode)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		before := called.Load()
+		event, err := llmflow.HandleFunctionCalls(t.Context(), ictx, newEvent(map[string]any{
+			"limit": 2.5,
+			"order": "random",
+			"tags":  []any{"lang", 1},
+		}), toolsDict, nil)
+		if err != nil {
+			t.Fatalf("HandleFunctionCalls: %v", err)
+		}
+		if called.Load() != before {
+			t.Error("tool was called with invalid arguments")
+		}
+		if got, want := event.ErrorCode, types.ErrorCodeInvalidArguments; got != want {
+			t.Errorf("ErrorCode = %q, want %q", got, want)
+		}
+
+		want := []any{
+			map[string]any{"path": "limit", "reason": "must be an integer, got number"},
+			map[string]any{"path": "order", "reason": `must be one of [asc, desc], got "random"`},
+			map[string]any{"path": "query", "reason": "is required"},
+			map[string]any{"path": "tags[1]", "reason": "must be a string, got integer"},
+		}
+		if diff := cmp.Diff(want, event.GetFunctionResponses()[0].Response["violations"]); diff != "" {
+			t.Errorf("violations mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		before := called.Load()
+		event, err := llmflow.HandleFunctionCallsWithoutValidation(t.Context(), ictx, newEvent(map[string]any{"limit": "ten"}), toolsDict)
+		if err != nil {
+			t.Fatalf("HandleFunctionCalls: %v", err)
+		}
+		if called.Load() != before+1 {
+			t.Error("tool was not called with validation disabled")
+		}
+		if event.ErrorCode != "" {
+			t.Errorf("ErrorCode = %q, want empty", event.ErrorCode)
+		}
+	})
+}
+
+func TestInvalidArgumentsError(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("call: %w", &types.InvalidArgumentsError{
+		Name: "search",
+		Violations: []types.ArgumentViolation{
+			{Path: "query", Reason: "is required"},
+			{Path: "limit", Reason: "must be an integer, got string"},
+		},
+	})
+	if !errors.Is(err, types.ErrInvalidArguments) {
+		t.Fatalf("errors.Is(%v, ErrInvalidArguments) = false, want true", err)
+	}
+	if got, want := err.Error(), "call: invalid arguments for search: query: is required; limit: must be an integer, got string"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestHandleFunctionCalls_ToolAuditor(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(), types.WithClock(types.NewFakeClock(time.Unix(0, 0), time.Second)))
+
+	errTool := errors.New("service unavailable")
+	weather := tools.NewFunctionTool(getWeather)
+	failing := tools.NewFunctionTool(func(context.Context, map[string]any) (any, error) {
+		return nil, errTool
+	})
+	toolsDict := map[string]types.Tool{
+		weather.Name(): weather,
+	}
+	auditor := tool.NewInMemoryAuditor(
+		tool.WithRedactedArgs("", "api_key"),
+		tool.WithRedactedArgs(weather.Name(), "location.street"),
+	)
+
+	args := map[string]any{
+		"api_key":  "secret",
+		"location": map[string]any{"city": "Tokyo", "street": "1-1 Chiyoda"},
+	}
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: weather.Name(), Args: args}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	if _, err := llmflow.HandleFunctionCallsWithAuditor(t.Context(), ictx, funcCallEvent, toolsDict, auditor); err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+
+	failingEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-2", Name: failing.Name()}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	toolsDict = map[string]types.Tool{failing.Name(): failing}
+	if _, err := llmflow.HandleFunctionCallsWithAuditor(t.Context(), ictx, failingEvent, toolsDict, auditor); !errors.Is(err, errTool) {
+		t.Fatalf("HandleFunctionCalls error = %v, want %v", err, errTool)
+	}
+
+	base := types.ToolCallRecord{
+		AppName:      "app",
+		UserID:       "user",
+		SessionID:    "session",
+		InvocationID: ictx.InvocationID,
+		AgentName:    "test-agent",
+		Duration:     time.Second,
+	}
+	want := []types.ToolCallRecord{base, base}
+	want[0].Timestamp = time.Unix(0, 0)
+	want[0].ToolName = weather.Name()
+	want[0].FunctionCallID = "call-1"
+	want[0].Args = map[string]any{
+		"api_key":  tool.RedactedValue,
+		"location": map[string]any{"city": "Tokyo", "street": tool.RedactedValue},
+	}
+	want[0].Status = types.ToolCallStatusOK
+	// The clock also ticked for the function response event of the first call.
+	want[1].Timestamp = time.Unix(3, 0)
+	want[1].ToolName = failing.Name()
+	want[1].FunctionCallID = "call-2"
+	want[1].Status = types.ToolCallStatusError
+	want[1].Error = errTool.Error()
+	if diff := cmp.Diff(want, auditor.Records()); diff != "" {
+		t.Errorf("audit records mismatch (-want +got):\n%s", diff)
+	}
+
+	// The arguments of the function call are not redacted.
+	if got := args["api_key"]; got != "secret" {
+		t.Errorf("function call argument api_key = %v, want %q", got, "secret")
+	}
+}
+
+func TestHandleFunctionCalls_DryRun(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(), types.WithClock(types.NewFakeClock(time.Unix(0, 0), time.Second)))
+
+	var sent int
+	sendEmail := tools.NewFunctionTool(func(context.Context, map[string]any) (any, error) {
+		sent++
+		return map[string]any{"status": "sent"}, nil
+	})
+	toolsDict := map[string]types.Tool{sendEmail.Name(): sendEmail}
+	auditor := tool.NewInMemoryAuditor()
+
+	args := map[string]any{"to": "bob@example.com", "subject": "hello"}
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: sendEmail.Name(), Args: args}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+	event, err := llmflow.HandleFunctionCallsWithDryRun(t.Context(), ictx, funcCallEvent, toolsDict, auditor)
+	if err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+	if sent != 0 {
+		t.Errorf("tool ran %d times in a dry run, want 0", sent)
+	}
+
+	responses := event.GetFunctionResponses()
+	if len(responses) != 1 {
+		t.Fatalf("function responses = %d, want 1", len(responses))
+	}
+	wantResult := fmt.Sprintf(`dry-run: would have called %s with args {"subject":"hello","to":"bob@example.com"}`, sendEmail.Name())
+	if got := responses[0].Response["result"]; got != wantResult {
+		t.Errorf("function response = %v, want %q", got, wantResult)
+	}
+
+	want := []types.ToolCallRecord{{
+		Timestamp:      time.Unix(0, 0),
+		ToolName:       sendEmail.Name(),
+		FunctionCallID: "call-1",
+		Args:           args,
+		Status:         types.ToolCallStatusDryRun,
+		AppName:        "app",
+		UserID:         "user",
+		SessionID:      "session",
+		InvocationID:   ictx.InvocationID,
+		AgentName:      "test-agent",
+	}}
+	if diff := cmp.Diff(want, ictx.IntendedToolCalls()); diff != "" {
+		t.Errorf("intended tool calls mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, auditor.Records()); diff != "" {
+		t.Errorf("audit records mismatch (-want +got):\n%s", diff)
+	}
+
+	end := llmflow.DryRunEvent(llmflow.NewLLMFlow().WithDryRun(true), ictx, ictx.IntendedToolCalls())
+	if diff := cmp.Diff(want, end.IntendedToolCalls); diff != "" {
+		t.Errorf("dry run event mismatch (-want +got):\n%s", diff)
+	}
+	if end.Author != "test-agent" || end.Content != nil {
+		t.Errorf("dry run event = (author %q, content %v), want an event of test-agent without content", end.Author, end.Content)
+	}
+}
+
+func TestHandleFunctionCalls_MergedEvent(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "test-agent")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService(),
+		types.WithClock(types.NewFakeClock(time.Unix(0, 0), time.Second)),
+		types.WithIDGenerator(types.NewSequentialIDGenerator()),
+	)
+
+	weather := tools.NewFunctionTool(getWeather)
+	toolsDict := map[string]types.Tool{weather.Name(): weather}
+	funcCallEvent := types.NewEvent().
+		WithContent(genai.NewContentFromParts([]*genai.Part{
+			{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: weather.Name(), Args: map[string]any{}}},
+			{FunctionCall: &genai.FunctionCall{ID: "call-2", Name: weather.Name(), Args: map[string]any{}}},
+		}, genai.RoleModel)).
+		WithActions(types.NewEventActions())
+
+	merged, err := llmflow.HandleFunctionCallsWithoutValidation(t.Context(), ictx, funcCallEvent, toolsDict)
+	if err != nil {
+		t.Fatalf("HandleFunctionCalls: %v", err)
+	}
+	if got := len(merged.Content.Parts); got != 2 {
+		t.Fatalf("merged event has %d parts, want 2", got)
+	}
+	// The merged event is created from the invocation context.
+	if !strings.HasPrefix(merged.ID, "event-") {
+		t.Errorf("merged event ID = %q, want an ID from the invocation ID generator", merged.ID)
+	}
+	if merged.InvocationID != ictx.InvocationID {
+		t.Errorf("merged event InvocationID = %q, want %q", merged.InvocationID, ictx.InvocationID)
+	}
+}
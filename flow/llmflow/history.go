@@ -0,0 +1,228 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	deepcopy "github.com/tiendc/go-deepcopy"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// historyConfig holds the options of [BuildHistory].
+type historyConfig struct {
+	branch       string
+	agentName    string
+	excludeTools bool
+	noMergeTurns bool
+
+	// newEvent creates the events converted from the replies of the other agents.
+	newEvent func() *types.Event
+}
+
+// HistoryOption configures [BuildHistory].
+type HistoryOption func(*historyConfig)
+
+// WithHistoryBranch keeps only the events of the branch and of its ancestors.
+func WithHistoryBranch(branch string) HistoryOption {
+	return func(c *historyConfig) {
+		c.branch = branch
+	}
+}
+
+// WithHistoryAgent builds the history seen by the named agent: the replies of the other agents
+// are given to it as user context, rather than as its own replies.
+func WithHistoryAgent(name string) HistoryOption {
+	return func(c *historyConfig) {
+		c.agentName = name
+	}
+}
+
+// WithToolTurns sets whether the function calls and responses are kept in the history, which is
+// the default. Without them, the contents left empty are dropped.
+func WithToolTurns(include bool) HistoryOption {
+	return func(c *historyConfig) {
+		c.excludeTools = !include
+	}
+}
+
+// WithMergeTurns sets whether the consecutive contents of the same role are merged into a single
+// content, which is the default.
+func WithMergeTurns(merge bool) HistoryOption {
+	return func(c *historyConfig) {
+		c.noMergeTurns = !merge
+	}
+}
+
+// BuildHistory converts the events of a session into the contents of an [types.LLMRequest], as
+// done by [ContentLLMRequestProcessor].
+//
+// The events without content, the authentication requests and the captured reasoning are left
+// out, as are the events before the latest summary replacing the history. The roles are
+// normalized to user and model from the author of the events, the function responses being sent
+// by the user. Each function call is directly followed by its responses, the responses of an
+// asynchronous call received later being moved after it.
+//
+// It returns an error if a function response has no matching function call.
+func BuildHistory(events []*types.Event, opts ...HistoryOption) ([]*genai.Content, error) {
+	var cfg historyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	history, err := buildHistory(events, &cfg)
+	if err != nil {
+		return nil, err
+	}
+	contents := historyContents(history)
+	if !cfg.noMergeTurns {
+		contents = mergeTurns(contents)
+	}
+	return contents, nil
+}
+
+// historyContent is a content of the history.
+type historyContent struct {
+	content *genai.Content
+
+	// turnStart reports whether the content is a message of the user starting a conversational
+	// turn. It is decided from the author of the event rather than from the role of the content,
+	// as the replies of the other agents are given as user contents.
+	turnStart bool
+}
+
+// historyContents returns the contents of the history.
+func historyContents(history []historyContent) []*genai.Content {
+	contents := make([]*genai.Content, len(history))
+	for i, h := range history {
+		contents[i] = h.content
+	}
+	return contents
+}
+
+// buildHistory returns the contents of the events, one content per kept event.
+func buildHistory(events []*types.Event, cfg *historyConfig) ([]historyContent, error) {
+	var cp ContentLLMRequestProcessor
+	newEvent := cfg.newEvent
+	if newEvent == nil {
+		newEvent = types.NewEvent
+	}
+
+	// Events before the latest summary replacing the history are left out.
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Actions != nil && events[i].Actions.ReplacesHistory && cp.isEventBelongsToBranch(cfg.branch, events[i]) {
+			events = events[i:]
+			break
+		}
+	}
+
+	var filteredEvents []*types.Event
+	foreign := make(map[*types.Event]bool)
+	for _, event := range events {
+		if event.LLMResponse == nil || event.Content == nil || len(event.Content.Parts) == 0 || isEmptyPart(event.Content.Parts[0]) {
+			// Skip events without content or with empty text.
+			// E.g. events purely for mutating session states.
+			continue
+		}
+
+		if !cp.isEventBelongsToBranch(cfg.branch, event) {
+			// Skip events not belong to current branch.
+			continue
+		}
+
+		if cp.isAuthEvent(event) {
+			// Skip the authentication requests, which are handled by the flow.
+			continue
+		}
+
+		if event.Reasoning {
+			// Skip the thinking captured by the planner, which is not part of the answers.
+			continue
+		}
+
+		ev := event
+		if cp.isOtherAgentReply(cfg.agentName, event) {
+			ev = cp.convertForeignEvent(event, newEvent)
+			foreign[ev] = true
+		}
+		filteredEvents = append(filteredEvents, ev)
+	}
+
+	resultEvents, err := cp.rearrangeEventsForLatestFunctionResponse(filteredEvents)
+	if err != nil {
+		return nil, err
+	}
+	resultEvents, err = cp.rearrangeEventsForAsyncFunctionResponsesInHistory(resultEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	history := []historyContent{}
+	for _, event := range resultEvents {
+		content := &genai.Content{}
+		if err := deepcopy.Copy(content, event.Content); err != nil {
+			return nil, err
+		}
+		content = RemoveClientFunctionCallID(content)
+		content.Role = normalizeRole(event)
+		if cfg.excludeTools {
+			content.Parts = withoutToolParts(content.Parts)
+			if len(content.Parts) == 0 {
+				continue
+			}
+		}
+		history = append(history, historyContent{
+			content:   content,
+			turnStart: !foreign[event] && isTurnStart(event),
+		})
+	}
+
+	return history, nil
+}
+
+// normalizeRole returns the role of the content of the event, user or model.
+//
+// The contents without a known role are given by the user if authored by the user or holding a
+// function response, and by the model otherwise.
+func normalizeRole(event *types.Event) string {
+	switch role := event.Content.Role; role {
+	case model.RoleUser, model.RoleModel:
+		return role
+	}
+	if event.Author == model.RoleUser || len(event.GetFunctionResponses()) > 0 {
+		return model.RoleUser
+	}
+	return model.RoleModel
+}
+
+// withoutToolParts returns the parts without the function calls and responses.
+func withoutToolParts(parts []*genai.Part) []*genai.Part {
+	kept := make([]*genai.Part, 0, len(parts))
+	for _, part := range parts {
+		if part.FunctionCall != nil || part.FunctionResponse != nil {
+			continue
+		}
+		kept = append(kept, part)
+	}
+	return kept
+}
+
+// mergeTurns merges the consecutive contents of the same role into a single content.
+// The merged contents are new, the contents are not modified.
+func mergeTurns(contents []*genai.Content) []*genai.Content {
+	merged := make([]*genai.Content, 0, len(contents))
+	for _, content := range contents {
+		n := len(merged)
+		if n == 0 || merged[n-1].Role != content.Role {
+			merged = append(merged, content)
+			continue
+		}
+		parts := make([]*genai.Part, 0, len(merged[n-1].Parts)+len(content.Parts))
+		parts = append(parts, merged[n-1].Parts...)
+		parts = append(parts, content.Parts...)
+		merged[n-1] = &genai.Content{Role: content.Role, Parts: parts}
+	}
+	return merged
+}
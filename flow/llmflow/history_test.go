@@ -0,0 +1,137 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestBuildHistory(t *testing.T) {
+	t.Parallel()
+
+	event := func(author string, content *genai.Content) *types.Event {
+		return types.NewEvent().
+			WithAuthor(author).
+			WithContent(content).
+			WithActions(types.NewEventActions())
+	}
+	call := event("writer", genai.NewContentFromFunctionCall("lookup", map[string]any{"q": "x"}, genai.RoleModel))
+	call.Content.Parts[0].FunctionCall.ID = "call-1"
+	response := event("writer", genai.NewContentFromFunctionResponse("lookup", map[string]any{"result": "y"}, genai.RoleUser))
+	response.Content.Parts[0].FunctionResponse.ID = "call-1"
+	response.Content.Role = ""
+	// noRole returns a text content without role.
+	noRole := func(text string) *genai.Content {
+		return &genai.Content{Parts: []*genai.Part{genai.NewPartFromText(text)}}
+	}
+
+	events := []*types.Event{
+		event("user", noRole("hello")),
+		event("user", genai.NewContentFromText("are you there?", genai.RoleUser)),
+		event("writer", noRole("let me check")),
+		call,
+		response,
+		event("writer", genai.NewContentFromText("found y", genai.RoleModel)),
+		event("reviewer", genai.NewContentFromText("looks good", genai.RoleModel)),
+		types.NewEvent().WithAuthor("writer").WithActions(types.NewEventActions()),
+	}
+
+	// describe returns the role and the description of the parts of each content.
+	describe := func(contents []*genai.Content) [][]string {
+		var got [][]string
+		for _, content := range contents {
+			desc := []string{content.Role}
+			for _, part := range content.Parts {
+				switch {
+				case part.FunctionCall != nil:
+					desc = append(desc, "call "+part.FunctionCall.Name)
+				case part.FunctionResponse != nil:
+					desc = append(desc, "response "+part.FunctionResponse.Name)
+				default:
+					desc = append(desc, part.Text)
+				}
+			}
+			got = append(got, desc)
+		}
+		return got
+	}
+
+	tests := map[string]struct {
+		opts []llmflow.HistoryOption
+		want [][]string
+	}{
+		"default": {
+			want: [][]string{
+				{"user", "hello", "are you there?"},
+				{"model", "let me check", "call lookup"},
+				{"user", "response lookup"},
+				{"model", "found y", "looks good"},
+			},
+		},
+		"as seen by an agent": {
+			opts: []llmflow.HistoryOption{llmflow.WithHistoryAgent("writer")},
+			want: [][]string{
+				{"user", "hello", "are you there?"},
+				{"model", "let me check", "call lookup"},
+				{"user", "response lookup"},
+				{"model", "found y"},
+				{"user", "For context:", "[reviewer] said: looks good"},
+			},
+		},
+		"without tool turns": {
+			opts: []llmflow.HistoryOption{llmflow.WithToolTurns(false)},
+			want: [][]string{
+				{"user", "hello", "are you there?"},
+				{"model", "let me check", "found y", "looks good"},
+			},
+		},
+		"without merging": {
+			opts: []llmflow.HistoryOption{llmflow.WithMergeTurns(false), llmflow.WithToolTurns(false)},
+			want: [][]string{
+				{"user", "hello"},
+				{"user", "are you there?"},
+				{"model", "let me check"},
+				{"model", "found y"},
+				{"model", "looks good"},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			contents, err := llmflow.BuildHistory(events, tt.opts...)
+			if err != nil {
+				t.Fatalf("BuildHistory: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, describe(contents)); diff != "" {
+				t.Errorf("history mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if events[0].Content.Role != "" {
+		t.Errorf("BuildHistory modified the role of the event to %q", events[0].Content.Role)
+	}
+}
+
+func TestBuildHistory_OrphanFunctionResponse(t *testing.T) {
+	t.Parallel()
+
+	response := types.NewEvent().
+		WithAuthor("writer").
+		WithContent(genai.NewContentFromFunctionResponse("lookup", map[string]any{"result": "y"}, genai.RoleUser)).
+		WithActions(types.NewEventActions())
+	response.Content.Parts[0].FunctionResponse.ID = "call-1"
+
+	if _, err := llmflow.BuildHistory([]*types.Event{response}); err == nil {
+		t.Error("BuildHistory() with a function response without call succeeded")
+	}
+}
@@ -23,7 +23,7 @@ func (p *IdentityLlmRequestProcessor) Run(ctx context.Context, ictx *types.Invoc
 		if llmAgent.Description() != "" {
 			si = append(si, ` The description about you is "`+llmAgent.Description()+`"`)
 		}
-		request.AppendInstructions(si...)
+		request.AppendSystemContent(types.SystemContentIdentity, si...)
 
 		return
 	}
@@ -11,13 +11,38 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"unicode"
 
+	"github.com/go-a2a/adk-go/internal/cache"
 	"github.com/go-a2a/adk-go/types"
 )
 
+// maxInstructionMemos is the number of invocations of agents whose instructions are memoized by
+// an [InstructionsLlmRequestProcessor], the least recently used being forgotten.
+const maxInstructionMemos = 1024
+
 // InstructionsLlmRequestProcessor represents a handles instructions and global instructions for LLM flow.
-type InstructionsLlmRequestProcessor struct{}
+type InstructionsLlmRequestProcessor struct {
+	// Memoize reuses the instructions built at the first request of an invocation for the next
+	// requests of the multi-turn function calling loop, rather than populating them again.
+	//
+	// The instructions are still resolved at each request, so that another instruction template, or a
+	// change of a state value the instruction refers to, rebuilds them. An instruction referring to an
+	// artifact is rebuilt at each request. The instructions of a [types.InstructionProvider] are used
+	// as is, without state injection, and are not memoized.
+	//
+	// The instructions are memoized by invocation, branch and agent, so that a processor shared by
+	// concurrent invocations keeps the memo of each.
+	Memoize bool
+
+	// OnRebuild is called when a memoized instruction is rebuilt within an invocation. Nil means
+	// no hook.
+	OnRebuild func(InstructionRebuild)
+
+	mu    sync.Mutex
+	memos *cache.LRU[instructionMemoKey, *instructionMemo]
+}
 
 var _ types.LLMRequestProcessor = (*InstructionsLlmRequestProcessor)(nil)
 
@@ -30,19 +55,174 @@ func (p *InstructionsLlmRequestProcessor) Run(ctx context.Context, ictx *types.I
 		}
 
 		rootAgent := llmAgent.RootAgent()
+		memo := p.memo(ictx)
 
 		// Appends global instructions if set.
 		if rootAgent, ok := rootAgent.AsLLMAgent(); ok {
 			rawSI, bypassStateInjection := rootAgent.CanonicalGlobalInstruction(types.NewReadOnlyContext(ictx))
 			si := rawSI
-			_ = si
 			if !bypassStateInjection {
-				// si = pop
+				si = p.build(ctx, ictx, memo, true, rawSI)
 			}
+			if si != "" {
+				request.AppendSystemContent(types.SystemContentInstructions, si)
+			}
+		}
+
+		// Appends agent instructions if set.
+		rawSI, bypassStateInjection := llmAgent.CanonicalInstructions(types.NewReadOnlyContext(ictx))
+		si := rawSI
+		if !bypassStateInjection {
+			si = p.build(ctx, ictx, memo, false, rawSI)
+		}
+		if si != "" {
+			request.AppendSystemContent(types.SystemContentInstructions, si)
 		}
 	}
 }
 
+// InstructionRebuildReason is the reason why a memoized instruction is rebuilt.
+type InstructionRebuildReason string
+
+const (
+	// InstructionRebuildTemplate is another instruction template for the agent.
+	InstructionRebuildTemplate InstructionRebuildReason = "template"
+
+	// InstructionRebuildState is a change of a state value the instruction refers to.
+	InstructionRebuildState InstructionRebuildReason = "state"
+
+	// InstructionRebuildArtifact is an instruction referring to an artifact, never memoized.
+	InstructionRebuildArtifact InstructionRebuildReason = "artifact"
+)
+
+// InstructionRebuild describes a memoized instruction rebuilt within an invocation, see
+// [InstructionsLlmRequestProcessor.OnRebuild].
+type InstructionRebuild struct {
+	// InvocationID is the ID of the invocation.
+	InvocationID string
+
+	// Agent is the name of the agent.
+	Agent string
+
+	// Global reports whether the instruction is the global instruction of the root agent.
+	Global bool
+
+	// Reason is the reason of the rebuild.
+	Reason InstructionRebuildReason
+}
+
+// instructionMemoKey identifies the run of an agent whose instructions are memoized.
+type instructionMemoKey struct {
+	invocationID string
+	branch       string
+	agent        string
+}
+
+// instructionMemo is the instructions of an agent built in an invocation.
+type instructionMemo struct {
+	invocationID string
+	agent        string
+	global       *builtInstruction
+	instruction  *builtInstruction
+}
+
+// builtInstruction is an instruction populated from its template.
+type builtInstruction struct {
+	template string
+	text     string
+	deps     *instructionDeps
+}
+
+// instructionDeps records the values an instruction is populated with.
+type instructionDeps struct {
+	// state holds the substituted state values, by key, or nil for the missing keys.
+	state map[string]*string
+
+	// artifacts reports whether the instruction refers to an artifact.
+	artifacts bool
+}
+
+// changed reports whether a state value the instruction was populated with changed.
+func (d *instructionDeps) changed(state map[string]any) bool {
+	for key, substituted := range d.state {
+		val, ok := state[key]
+		if ok != (substituted != nil) || ok && fmt.Sprintf("%v", val) != *substituted {
+			return true
+		}
+	}
+	return false
+}
+
+// memo returns the memoized instructions of the agent in the invocation, or nil if not memoized.
+func (p *InstructionsLlmRequestProcessor) memo(ictx *types.InvocationContext) *instructionMemo {
+	if !p.Memoize {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := instructionMemoKey{invocationID: ictx.InvocationID, branch: ictx.Branch, agent: ictx.Agent.Name()}
+	if p.memos == nil {
+		p.memos = cache.NewLRU[instructionMemoKey, *instructionMemo](maxInstructionMemos, 0)
+	}
+	memo, ok := p.memos.Get(key)
+	if !ok {
+		memo = &instructionMemo{invocationID: key.invocationID, agent: key.agent}
+		p.memos.Put(key, memo)
+	}
+	return memo
+}
+
+// build populates the instruction template, reusing the memoized instruction if its template and
+// the values it was populated with are unchanged.
+func (p *InstructionsLlmRequestProcessor) build(ctx context.Context, ictx *types.InvocationContext, memo *instructionMemo, global bool, template string) string {
+	if memo == nil {
+		return p.populateValues(ctx, template, ictx, nil)
+	}
+
+	p.mu.Lock()
+	slot := &memo.instruction
+	if global {
+		slot = &memo.global
+	}
+	built := *slot
+	p.mu.Unlock()
+
+	var reason InstructionRebuildReason
+	switch {
+	case built == nil:
+	case built.template != template:
+		reason = InstructionRebuildTemplate
+	case built.deps.artifacts:
+		reason = InstructionRebuildArtifact
+	case built.deps.changed(ictx.Session.State()):
+		reason = InstructionRebuildState
+	default:
+		return built.text
+	}
+	if reason != "" && p.OnRebuild != nil {
+		p.OnRebuild(InstructionRebuild{
+			InvocationID: memo.invocationID,
+			Agent:        memo.agent,
+			Global:       global,
+			Reason:       reason,
+		})
+	}
+
+	deps := &instructionDeps{state: make(map[string]*string)}
+	built = &builtInstruction{
+		template: template,
+		text:     p.populateValues(ctx, template, ictx, deps),
+		deps:     deps,
+	}
+	p.mu.Lock()
+	*slot = built
+	p.mu.Unlock()
+
+	return built.text
+}
+
 // Match represents a regular expression match
 type Match struct {
 	Text   string
@@ -80,7 +260,9 @@ func FindIterChannel(pattern *regexp.Regexp, text string) <-chan Match {
 }
 
 // populateValues populates values in the instruction template, e.g. state, artifact, etc.
-func (p *InstructionsLlmRequestProcessor) populateValues(ctx context.Context, instructionTemplate string, ictx *types.InvocationContext) string {
+//
+// The values the instruction is populated with are recorded in deps, unless nil.
+func (p *InstructionsLlmRequestProcessor) populateValues(ctx context.Context, instructionTemplate string, ictx *types.InvocationContext, deps *instructionDeps) string {
 	sub := func(pattern *regexp.Regexp, fn func(match Match) (string, error), src string) string {
 		results := []string{}
 		lastEnd := 0
@@ -107,6 +289,9 @@ func (p *InstructionsLlmRequestProcessor) populateValues(ctx context.Context, in
 		}
 		if after, ok := strings.CutPrefix(varName, "artifact."); ok {
 			varName = after
+			if deps != nil {
+				deps.artifacts = true
+			}
 			if ictx.ArtifactService == nil {
 				return "", errors.New("artifact service is not initialized")
 			}
@@ -124,8 +309,15 @@ func (p *InstructionsLlmRequestProcessor) populateValues(ctx context.Context, in
 			}
 			if val, ok := ictx.Session.State()[varName]; ok {
 				// TODO(zchee): can't str(artifact) cast in Go
-				return fmt.Sprintf("%v", val), nil
+				substituted := fmt.Sprintf("%v", val)
+				if deps != nil {
+					deps.state[varName] = &substituted
+				}
+				return substituted, nil
 			} else {
+				if deps != nil {
+					deps.state[varName] = nil
+				}
 				if optional {
 					return "", nil
 				}
@@ -134,9 +326,12 @@ func (p *InstructionsLlmRequestProcessor) populateValues(ctx context.Context, in
 		return "", fmt.Errorf("Context variable not found: %s", varName)
 	}
 
-	return sub(regexp.MustCompile(`{+[^{}]*}+`), replaceMatch, instructionTemplate)
+	return sub(instructionPlaceholderRe, replaceMatch, instructionTemplate)
 }
 
+// instructionPlaceholderRe matches the placeholders of the instruction templates, such as {name}.
+var instructionPlaceholderRe = regexp.MustCompile(`{+[^{}]*}+`)
+
 func isIdentifier(s string) bool {
 	if len(s) == 0 {
 		return false
@@ -0,0 +1,228 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestLLMFlow_SystemContentOrder(t *testing.T) {
+	t.Parallel()
+
+	const (
+		identity     = "\n\nYou are an agent. Your internal name is \"helper\"."
+		instructions = "\n\nAnswer questions about Go."
+	)
+	tests := map[string]struct {
+		order []types.SystemContentBlock
+		want  []string
+	}{
+		"processor order": {
+			want: []string{instructions, identity},
+		},
+		"reordered": {
+			order: []types.SystemContentBlock{types.SystemContentIdentity, types.SystemContentInstructions},
+			want:  []string{identity, instructions},
+		},
+		"omitted": {
+			order: []types.SystemContentBlock{types.SystemContentInstructions},
+			want:  []string{instructions},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "helper", agent.WithInstruction("Answer questions about {topic}."))
+			if err != nil {
+				t.Fatalf("NewLLMAgent: %v", err)
+			}
+			ses := session.NewSession("app", "user", "session", map[string]any{"topic": "Go"}, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			flow := llmflow.NewLLMFlow().
+				WithRequestProcessors(&llmflow.InstructionsLlmRequestProcessor{}, &llmflow.IdentityLlmRequestProcessor{})
+			if tt.order != nil {
+				flow.WithSystemContentOrder(tt.order...)
+			}
+
+			request := types.NewLLMRequest(nil)
+			for _, err := range llmflow.Preprocess(t.Context(), flow, ictx, request) {
+				if err != nil {
+					t.Fatalf("preprocess: %v", err)
+				}
+			}
+
+			var got []string
+			for _, part := range request.Config.SystemInstruction.Parts {
+				got = append(got, part.Text)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("system instruction mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstructionsLlmRequestProcessor_Memoize(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	a, err := agent.NewLLMAgent(ctx, "helper", agent.WithInstruction("Answer concise questions about {topic}."))
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	concise := a
+	// The same agent with another instruction template.
+	detailed, err := agent.NewLLMAgent(ctx, "helper", agent.WithInstruction("Answer detailed questions about {topic}."))
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	sessionSvc := session.NewInMemoryService()
+	ses, err := sessionSvc.CreateSession(ctx, "app", "user", "session", map[string]any{"topic": "Go"})
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+
+	var rebuilds []llmflow.InstructionRebuildReason
+	flow := llmflow.NewLLMFlow().
+		WithRequestProcessors(&llmflow.InstructionsLlmRequestProcessor{}).
+		WithInstructionMemoization(func(rebuild llmflow.InstructionRebuild) {
+			rebuilds = append(rebuilds, rebuild.Reason)
+		})
+	instruction := func(invocationID string) string {
+		ictx := types.NewInvocationContext(a, ses, sessionSvc)
+		ictx.InvocationID = invocationID
+		request := types.NewLLMRequest(nil)
+		for _, err := range llmflow.Preprocess(ctx, flow, ictx, request) {
+			if err != nil {
+				t.Fatalf("preprocess: %v", err)
+			}
+		}
+		return request.Config.SystemInstruction.Parts[0].Text
+	}
+
+	steps := []struct {
+		name         string
+		invocationID string
+		update       func()
+		want         string
+		wantRebuilds []llmflow.InstructionRebuildReason
+	}{
+		{name: "first request", invocationID: "first", want: "\n\nAnswer concise questions about Go."},
+		{name: "unchanged", invocationID: "first", want: "\n\nAnswer concise questions about Go."},
+		{
+			name:         "state changed",
+			invocationID: "first",
+			update: func() {
+				event := types.NewEvent().WithInvocationID("first").WithAuthor("helper").
+					WithActions(types.NewEventActions().SetSessionState("topic", "Rust"))
+				if _, err := sessionSvc.AppendEvent(ctx, ses, event); err != nil {
+					t.Fatalf("AppendEvent: %v", err)
+				}
+			},
+			want:         "\n\nAnswer concise questions about Rust.",
+			wantRebuilds: []llmflow.InstructionRebuildReason{llmflow.InstructionRebuildState},
+		},
+		{
+			name:         "instruction changed",
+			invocationID: "first",
+			update:       func() { a = detailed },
+			want:         "\n\nAnswer detailed questions about Rust.",
+			wantRebuilds: []llmflow.InstructionRebuildReason{llmflow.InstructionRebuildTemplate},
+		},
+		{name: "new invocation", invocationID: "second", want: "\n\nAnswer detailed questions about Rust."},
+		{
+			name:         "interleaved invocation",
+			invocationID: "first",
+			update:       func() { a = concise },
+			want:         "\n\nAnswer concise questions about Rust.",
+			wantRebuilds: []llmflow.InstructionRebuildReason{llmflow.InstructionRebuildTemplate},
+		},
+		{name: "other invocation kept", invocationID: "second", update: func() { a = detailed }, want: "\n\nAnswer detailed questions about Rust."},
+	}
+	for _, step := range steps {
+		rebuilds = nil
+		if step.update != nil {
+			step.update()
+		}
+		if got := instruction(step.invocationID); got != step.want {
+			t.Errorf("%s: instruction = %q, want %q", step.name, got, step.want)
+		}
+		if diff := cmp.Diff(step.wantRebuilds, rebuilds); diff != "" {
+			t.Errorf("%s: rebuilds mismatch (-want +got):\n%s", step.name, diff)
+		}
+	}
+}
+
+func TestInstructionsLlmRequestProcessor_InstructionProvider(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "helper", agent.WithInstruction(types.InstructionProvider(func(rctx *types.ReadOnlyContext) string {
+		return fmt.Sprintf("Answer questions about %v, formatted as {json}.", rctx.State()["topic"])
+	})))
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", map[string]any{"topic": "Go", "json": "text"}, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+	for _, memoize := range []bool{false, true} {
+		request := types.NewLLMRequest(nil)
+		for _, err := range (&llmflow.InstructionsLlmRequestProcessor{Memoize: memoize}).Run(t.Context(), ictx, request) {
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+		}
+
+		// The instruction of a provider is used as is, without state injection.
+		want := "\n\nAnswer questions about Go, formatted as {json}."
+		if got := request.Config.SystemInstruction.Parts[0].Text; got != want {
+			t.Errorf("memoize=%t: instruction = %q, want %q", memoize, got, want)
+		}
+	}
+}
+
+func BenchmarkInstructionsLlmRequestProcessor(b *testing.B) {
+	const roundTrips = 50
+	instruction := strings.Repeat("Follow the style guide of the team when you answer questions about {topic}. ", 200)
+
+	a, err := agent.NewLLMAgent(b.Context(), "helper", agent.WithInstruction(instruction))
+	if err != nil {
+		b.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", map[string]any{"topic": "Go"}, time.Now())
+
+	for _, memoize := range []bool{false, true} {
+		b.Run(fmt.Sprintf("memoize=%t", memoize), func(b *testing.B) {
+			processor := &llmflow.InstructionsLlmRequestProcessor{Memoize: memoize}
+			b.ReportAllocs()
+			invocation := 0
+			for b.Loop() {
+				invocation++
+				ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+				ictx.InvocationID = fmt.Sprintf("invocation-%d", invocation)
+				// The requests of the function calling loop of an invocation.
+				for range roundTrips {
+					request := types.NewLLMRequest(nil)
+					for _, err := range processor.Run(b.Context(), ictx, request) {
+						if err != nil {
+							b.Fatalf("Run: %v", err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"runtime"
 	"time"
 
+	"google.golang.org/genai"
+
 	"github.com/go-a2a/adk-go/internal/xiter"
 	"github.com/go-a2a/adk-go/model"
 	"github.com/go-a2a/adk-go/pkg/py"
@@ -27,6 +29,45 @@ type LLMFlow struct {
 	RequestProcessors  []types.LLMRequestProcessor
 	ResponseProcessors []types.LLMResponseProcessor
 	Logger             *slog.Logger
+
+	// MaxParallelToolCalls is the maximum number of function calls from a single model turn executed at once.
+	// Zero or less means no limit.
+	MaxParallelToolCalls int
+
+	// DisableArgumentValidation disables the validation of the function call arguments against
+	// the parameters declared by the tools, leaving it to the tools.
+	DisableArgumentValidation bool
+
+	// TenantRateLimiter throttles the model calls per application and user. Nil means no limit.
+	TenantRateLimiter *TenantRateLimiter
+
+	// ToolAuditor records every tool call. Nil means no audit.
+	ToolAuditor types.ToolAuditor
+
+	// SystemContentOrder is the order of the system content blocks in the system instruction.
+	// Nil keeps the order in which the processors and tools contribute them.
+	SystemContentOrder []types.SystemContentBlock
+
+	// FunctionResponseFormat converts the tool results into function responses. Nil sends
+	// map[string]any results as is.
+	FunctionResponseFormat *FunctionResponseFormat
+
+	// ToolFunctionResponseFormats overrides FunctionResponseFormat for the tools of the given names.
+	ToolFunctionResponseFormats map[string]*FunctionResponseFormat
+
+	// MaxTransferDepth is the maximum number of agent transfers in an invocation. Zero or less
+	// means no limit.
+	MaxTransferDepth int
+
+	// ExtractCitations fills the citations of the model response events.
+	ExtractCitations bool
+
+	// Tokenizer estimates the tokens of the requests to the models that cannot count them. Nil
+	// means the approximate tokenizer of the model family.
+	Tokenizer types.Tokenizer
+
+	// DryRun intercepts the tool calls instead of running them.
+	DryRun bool
 }
 
 var _ types.Flow = (*LLMFlow)(nil)
@@ -49,6 +90,188 @@ func (f *LLMFlow) WithResponseProcessors(processors ...types.LLMResponseProcesso
 	return f
 }
 
+// WithMaxParallelToolCalls sets the maximum number of function calls from a single model turn executed at once.
+//
+// Function responses are still sent back to the model in the order of the function calls.
+// Zero or less means no limit, which is the default.
+func (f *LLMFlow) WithMaxParallelToolCalls(n int) *LLMFlow {
+	f.MaxParallelToolCalls = n
+	return f
+}
+
+// WithArgumentValidation sets whether the function call arguments are validated against the parameters
+// declared by the tools before running them.
+//
+// Invalid calls are not run; the violations are sent back to the model as the function response so it
+// can correct the call. Validation is enabled by default; disable it for tools that handle loose arguments.
+func (f *LLMFlow) WithArgumentValidation(enabled bool) *LLMFlow {
+	f.DisableArgumentValidation = !enabled
+	return f
+}
+
+// WithTenantRateLimiter sets the limiter consulted before each model call with the application and
+// user of the invocation.
+//
+// A call over the budget of its tenant fails with a [*types.RateLimitError], or waits for the budget
+// to refill if the limiter is configured with [WithRateLimitWait].
+func (f *LLMFlow) WithTenantRateLimiter(limiter *TenantRateLimiter) *LLMFlow {
+	f.TenantRateLimiter = limiter
+	return f
+}
+
+// WithTokenizer sets the tokenizer estimating the input tokens of the requests, for the token
+// budgets of the [TenantRateLimiter], when the model does not implement [types.TokenCounter].
+func (f *LLMFlow) WithTokenizer(tokenizer types.Tokenizer) *LLMFlow {
+	f.Tokenizer = tokenizer
+	return f
+}
+
+// WithToolAuditor sets the auditor recording every call of [types.Tool.Run] made by the flow,
+// with its arguments, outcome, duration and session.
+//
+// The calls answered by a before-tool callback do not run the tool and are not recorded.
+func (f *LLMFlow) WithToolAuditor(auditor types.ToolAuditor) *LLMFlow {
+	f.ToolAuditor = auditor
+	return f
+}
+
+// WithSystemContentOrder sets the order of the system content blocks, such as the identity,
+// instructions, few-shot examples and memory, assembled into the system instruction.
+//
+// The blocks not listed are omitted from the system instruction. Other system instructions,
+// such as the agent transfer and planning instructions, are left in place.
+// By default the blocks follow the order of the processors, see [types.DefaultSystemContentOrder].
+func (f *LLMFlow) WithSystemContentOrder(blocks ...types.SystemContentBlock) *LLMFlow {
+	f.SystemContentOrder = blocks
+	return f
+}
+
+// WithFunctionResponseFormat sets how the results of the tools become the function responses
+// sent back to the model, such as their JSON encoding and size limits.
+//
+// By default the tools must return a map[string]any, sent as is.
+func (f *LLMFlow) WithFunctionResponseFormat(format *FunctionResponseFormat) *LLMFlow {
+	f.FunctionResponseFormat = format
+	return f
+}
+
+// WithToolFunctionResponseFormat sets the format of the function responses of the named tool,
+// overriding the format set with [LLMFlow.WithFunctionResponseFormat].
+func (f *LLMFlow) WithToolFunctionResponseFormat(toolName string, format *FunctionResponseFormat) *LLMFlow {
+	if f.ToolFunctionResponseFormats == nil {
+		f.ToolFunctionResponseFormats = make(map[string]*FunctionResponseFormat)
+	}
+	f.ToolFunctionResponseFormats[toolName] = format
+	return f
+}
+
+// WithMaxTransferDepth sets the maximum number of agent transfers in an invocation, guarding
+// against delegation loops between agents.
+//
+// A transfer beyond the limit is not made: the flow yields an event with the
+// [types.ErrorCodeMaxTransferDepth] error code instead, and the current agent keeps control.
+// The transfers are counted by the invocation context, see [types.InvocationContext.TransferDepth],
+// so the count starts over with each invocation. Zero or less means no limit, which is the default.
+func (f *LLMFlow) WithMaxTransferDepth(n int) *LLMFlow {
+	f.MaxTransferDepth = n
+	return f
+}
+
+// WithCitations sets whether the sources of the grounding and citation metadata of the model
+// responses are extracted into [types.Event.Citations], so that they can be rendered as footnotes
+// or verified. The responses without grounding nor citations leave the field empty.
+//
+// It is disabled by default.
+func (f *LLMFlow) WithCitations(enabled bool) *LLMFlow {
+	f.ExtractCitations = enabled
+	return f
+}
+
+// WithInstructionMemoization memoizes the instructions built by the [InstructionsLlmRequestProcessor]s
+// of the flow once per invocation, so that the multi-turn function calling loop does not populate a
+// long static instruction again at each request, see [InstructionsLlmRequestProcessor.Memoize].
+//
+// onRebuild, unless nil, is called when a dynamic instruction or a change of the state forces a
+// memoized instruction to be rebuilt.
+func (f *LLMFlow) WithInstructionMemoization(onRebuild func(InstructionRebuild)) *LLMFlow {
+	for _, processor := range f.RequestProcessors {
+		if processor, ok := processor.(*InstructionsLlmRequestProcessor); ok {
+			processor.Memoize = true
+			processor.OnRebuild = onRebuild
+		}
+	}
+	return f
+}
+
+// WithMaxTurns keeps the n most recent conversational turns in the contents sent to the model,
+// see [ContentLLMRequestProcessor.WithMaxTurns]. It configures the [ContentLLMRequestProcessor]s
+// of the flow. Zero or less keeps the whole history, which is the default.
+func (f *LLMFlow) WithMaxTurns(n int) *LLMFlow {
+	for _, processor := range f.RequestProcessors {
+		if processor, ok := processor.(*ContentLLMRequestProcessor); ok {
+			processor.WithMaxTurns(n)
+		}
+	}
+	return f
+}
+
+// WithDedupe replaces the later occurrences of the parts of at least minSize bytes repeated in the
+// contents sent to the model with a [DedupeMarker], see [ContentLLMRequestProcessor.WithDedupe].
+// It configures the [ContentLLMRequestProcessor]s of the flow. Zero or less disables it, which is
+// the default.
+func (f *LLMFlow) WithDedupe(minSize int) *LLMFlow {
+	for _, processor := range f.RequestProcessors {
+		if processor, ok := processor.(*ContentLLMRequestProcessor); ok {
+			processor.WithDedupe(minSize)
+		}
+	}
+	return f
+}
+
+// WithMaxTotalToolOutputBytes caps the total size of the tool outputs in the history sent to the
+// model to n bytes, truncating the oldest tool outputs over the budget before each model call,
+// see [ContentLLMRequestProcessor.WithMaxToolOutputBytes].
+//
+// It is the aggregate guard of the tool outputs accumulating over a multi-turn run, on top of the
+// size limit of each tool output set with [LLMFlow.WithFunctionResponseFormat]. It configures the
+// [ContentLLMRequestProcessor]s of the flow. Zero or less means no limit, which is the default.
+func (f *LLMFlow) WithMaxTotalToolOutputBytes(n int) *LLMFlow {
+	for _, processor := range f.RequestProcessors {
+		if processor, ok := processor.(*ContentLLMRequestProcessor); ok {
+			processor.WithMaxToolOutputBytes(n)
+		}
+	}
+	return f
+}
+
+// WithDryRun sets whether the flow previews the actions of the agent without taking them.
+//
+// In a dry run, the tool calls of the model are intercepted once validated and past the
+// before-tool callbacks: instead of running the tool, the flow records the intended call in the
+// invocation, see [types.InvocationContext.IntendedToolCalls], and answers the model with a
+// synthetic "dry-run: would have called X with args Y" function response, so that the run goes
+// on. The flow ends with an event listing the calls it intercepted in
+// [types.Event.IntendedToolCalls], and a [types.ToolAuditor] records them with the
+// [types.ToolCallStatusDryRun] status.
+//
+// No tool is run, including the agent transfers and the long-running tools. It is disabled by default.
+func (f *LLMFlow) WithDryRun(enabled bool) *LLMFlow {
+	f.DryRun = enabled
+	return f
+}
+
+// functionCallOptions returns the settings of the flow applied to the function calls.
+func (f *LLMFlow) functionCallOptions() functionCallOptions {
+	return functionCallOptions{
+		maxParallel:         f.MaxParallelToolCalls,
+		skipArgValidation:   f.DisableArgumentValidation,
+		auditor:             f.ToolAuditor,
+		responseFormat:      f.FunctionResponseFormat,
+		toolResponseFormats: f.ToolFunctionResponseFormats,
+		dryRun:              f.DryRun,
+	}
+}
+
 // NewLLMFlow creates a new [LLMFlow] with the given model and options.
 func NewLLMFlow() *LLMFlow {
 	return &LLMFlow{
@@ -61,6 +284,10 @@ func NewLLMFlow() *LLMFlow {
 // TODO(zchee): support OTel tracing.
 func (f *LLMFlow) RunLive(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
+		// The background jobs of the long-running tools are cancelled with the run.
+		stop := ictx.CancelJobsOnDone(ctx)
+		defer stop()
+
 		request := &types.LLMRequest{}
 		eventSeq := f.preprocess(ctx, ictx, request)
 		for event, err := range eventSeq {
@@ -269,7 +496,7 @@ func (f *LLMFlow) receiveFromModel(ctx context.Context, connection types.ModelCo
 					xiter.Error[types.Event](errors.New("must be LiveRequestQueue field is non-nil"))
 				}
 
-				modelRespEvent := types.NewEvent().
+				modelRespEvent := ic.NewEvent().
 					WithInvocationID(ic.InvocationID).
 					WithAuthor(getAuthorForEvent(resp))
 
@@ -303,14 +530,25 @@ func (f *LLMFlow) receiveFromModel(ctx context.Context, connection types.ModelCo
 // Run implements [Flow].
 func (f *LLMFlow) Run(ctx context.Context, ic *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
+		// The background jobs of the long-running tools are cancelled with the run.
+		stop := ic.CancelJobsOnDone(ctx)
+		defer stop()
+
+		intended := len(ic.IntendedToolCalls())
 		for {
-			var lastEvent *types.Event
+			var (
+				lastEvent *types.Event
+				// The events of the step, reported with its failure; those of the previous
+				// steps are not kept, so that a long tool loop does not grow the memory.
+				events []*types.Event
+			)
 			for event, err := range f.runOneStep(ctx, ic) {
 				if err != nil {
-					xiter.EndError[types.Event](err)
+					yield(nil, withFlowEvents(err, events))
 					return
 				}
 				lastEvent = event
+				events = append(events, event)
 				if !yield(event, nil) {
 					return
 				}
@@ -319,9 +557,27 @@ func (f *LLMFlow) Run(ctx context.Context, ic *types.InvocationContext) iter.Seq
 				break
 			}
 		}
+
+		if f.DryRun {
+			if calls := ic.IntendedToolCalls()[intended:]; len(calls) > 0 {
+				yield(f.dryRunEvent(ic, calls), nil)
+			}
+		}
 	}
 }
 
+// dryRunEvent returns the event ending a dry run, listing the tool calls it intercepted.
+func (f *LLMFlow) dryRunEvent(ic *types.InvocationContext, calls []types.ToolCallRecord) *types.Event {
+	event := ic.NewEvent().
+		WithInvocationID(ic.InvocationID).
+		WithAuthor(ic.Agent.Name()).
+		WithBranch(ic.Branch).
+		WithLLMResponse(&types.LLMResponse{}).
+		WithActions(types.NewEventActions())
+	event.IntendedToolCalls = calls
+	return event
+}
+
 // runOneStepAsync one step means one LLM call.
 func (f *LLMFlow) runOneStep(ctx context.Context, ic *types.InvocationContext) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
@@ -339,8 +595,8 @@ func (f *LLMFlow) runOneStep(ctx context.Context, ic *types.InvocationContext) i
 		}
 
 		// Calls the LLM.
-		modelResponseEvent := types.NewEvent()
-		modelResponseEvent.InvocationID = types.NewEventID()
+		modelResponseEvent := ic.NewEvent()
+		modelResponseEvent.InvocationID = ic.NewEventID()
 		modelResponseEvent.Author = ic.Agent.Name()
 		modelResponseEvent.Branch = ic.Branch
 
@@ -370,7 +626,7 @@ func (f *LLMFlow) preprocess(ctx context.Context, ic *types.InvocationContext, r
 			eventSeq := processor.Run(ctx, ic, request)
 			for event, err := range eventSeq {
 				if err != nil {
-					yield(nil, err)
+					yield(nil, wrapFlowError(ic, types.FlowStagePreprocess, processorName(processor), err))
 					return
 				}
 				if !yield(event, nil) {
@@ -384,6 +640,10 @@ func (f *LLMFlow) preprocess(ctx context.Context, ic *types.InvocationContext, r
 			toolCtx := types.NewToolContext(ic)
 			tool.ProcessLLMRequest(ctx, toolCtx, request)
 		}
+
+		if f.SystemContentOrder != nil {
+			request.OrderSystemContent(f.SystemContentOrder...)
+		}
 	}
 }
 
@@ -393,7 +653,7 @@ func (f *LLMFlow) postProcess(ctx context.Context, ic *types.InvocationContext,
 		// Runs processors.
 		for event, err := range f.postProcessRunProcessors(ctx, ic, response) {
 			if err != nil {
-				xiter.Error[types.Event](err)
+				yield(nil, err)
 				return
 			}
 
@@ -415,7 +675,7 @@ func (f *LLMFlow) postProcess(ctx context.Context, ic *types.InvocationContext,
 			if len(modelResponseEvent.GetFunctionCalls()) > 0 {
 				for event, err := range f.postprocessHandleFunctionCalls(ctx, ic, modelResponseEvent, request) {
 					if err != nil {
-						xiter.Error[types.Event](err)
+						yield(nil, err)
 						return
 					}
 					if !yield(event, nil) {
@@ -433,7 +693,7 @@ func (f *LLMFlow) postProcessLive(ctx context.Context, ic *types.InvocationConte
 		// Runs processors
 		for event, err := range f.postProcessRunProcessors(ctx, ic, response) {
 			if err != nil {
-				xiter.Error[types.Event](err)
+				yield(nil, err)
 				return
 			}
 			if !yield(event, nil) {
@@ -456,9 +716,9 @@ func (f *LLMFlow) postProcessLive(ctx context.Context, ic *types.InvocationConte
 
 		// Handles function calls.
 		if len(modelResponseEvent.GetFunctionCalls()) > 0 {
-			funcResponseEvent, err := HandleFunctionCallsLive(ctx, ic, modelResponseEvent, request.ToolMap)
+			funcResponseEvent, err := handleFunctionCallsLive(ctx, ic, modelResponseEvent, request.ToolMap, f.functionCallOptions())
 			if err != nil {
-				xiter.Error[types.Event](err)
+				yield(nil, wrapFlowError(ic, types.FlowStageTool, "", err))
 				return
 			}
 			if !yield(funcResponseEvent, nil) {
@@ -467,9 +727,13 @@ func (f *LLMFlow) postProcessLive(ctx context.Context, ic *types.InvocationConte
 
 			transferToAgent := funcResponseEvent.Actions.TransferToAgent
 			if transferToAgent != "" {
+				if event := f.transferLimitEvent(ic, transferToAgent); event != nil {
+					yield(event, nil)
+					return
+				}
 				agentToRun, err := f.getAgentToRun(ctx, ic, transferToAgent)
 				if err != nil {
-					xiter.Error[types.Event](err)
+					yield(nil, wrapFlowError(ic, types.FlowStageTransfer, transferToAgent, err))
 					return
 				}
 				for event, err := range agentToRun.RunLive(ctx, ic) {
@@ -487,7 +751,8 @@ func (f *LLMFlow) postProcessRunProcessors(ctx context.Context, ic *types.Invoca
 		for _, processor := range f.ResponseProcessors {
 			for event, err := range processor.Run(ctx, ic, response) {
 				if err != nil {
-					xiter.EndError[types.Event](errors.New("must be LiveRequestQueue field is non-nil"))
+					yield(nil, wrapFlowError(ic, types.FlowStagePostprocess, processorName(processor), err))
+					return
 				}
 				if !yield(event, nil) {
 					return
@@ -499,15 +764,18 @@ func (f *LLMFlow) postProcessRunProcessors(ctx context.Context, ic *types.Invoca
 
 func (f *LLMFlow) postprocessHandleFunctionCalls(ctx context.Context, ic *types.InvocationContext, funcCallEvent *types.Event, request *types.LLMRequest) iter.Seq2[*types.Event, error] {
 	return func(yield func(*types.Event, error) bool) {
-		funcResponseEvent, err := HandleFunctionCalls(ctx, ic, funcCallEvent, request.ToolMap, py.Set[string]{})
+		funcResponseEvent, err := handleFunctionCalls(ctx, ic, funcCallEvent, request.ToolMap, py.Set[string]{}, f.functionCallOptions())
 		if err != nil {
-			xiter.Error[types.Event](err)
+			yield(nil, wrapFlowError(ic, types.FlowStageTool, "", err))
+			return
+		}
+		if funcResponseEvent == nil {
 			return
 		}
 
 		authEvent, err := GenerateAuthEvent(ctx, ic, funcResponseEvent)
 		if err != nil {
-			xiter.Error[types.Event](err)
+			yield(nil, wrapFlowError(ic, types.FlowStagePostprocess, "GenerateAuthEvent", err))
 			return
 		}
 		if authEvent != nil {
@@ -522,9 +790,13 @@ func (f *LLMFlow) postprocessHandleFunctionCalls(ctx context.Context, ic *types.
 
 		transferToAgent := funcResponseEvent.Actions.TransferToAgent
 		if transferToAgent != "" {
+			if event := f.transferLimitEvent(ic, transferToAgent); event != nil {
+				yield(event, nil)
+				return
+			}
 			agentToRun, err := f.getAgentToRun(ctx, ic, transferToAgent)
 			if err != nil {
-				xiter.Error[*types.ModelConnection](err)
+				yield(nil, wrapFlowError(ic, types.FlowStageTransfer, transferToAgent, err))
 				return
 			}
 			for event, err := range agentToRun.Run(ctx, ic) {
@@ -536,6 +808,33 @@ func (f *LLMFlow) postprocessHandleFunctionCalls(ctx context.Context, ic *types.
 	}
 }
 
+// transferLimitEvent counts the transfer to the agent and returns nil if it is allowed, or the
+// event reporting the transfer limit of the flow otherwise.
+func (f *LLMFlow) transferLimitEvent(ic *types.InvocationContext, transferToAgent string) *types.Event {
+	if f.MaxTransferDepth <= 0 || ic.TransferDepth() < f.MaxTransferDepth {
+		ic.IncrementTransferDepth()
+		return nil
+	}
+
+	f.Logger.Warn("agent transfer limit reached",
+		slog.String("agent", ic.Agent.Name()),
+		slog.String("transfer_to_agent", transferToAgent),
+		slog.Int("max_transfer_depth", f.MaxTransferDepth),
+	)
+	event := ic.NewEvent().
+		WithInvocationID(ic.InvocationID).
+		WithAuthor(ic.Agent.Name()).
+		WithBranch(ic.Branch).
+		WithContent(genai.NewContentFromText(
+			fmt.Sprintf("The transfer to agent %s was stopped: the limit of %d agent transfers was reached.", transferToAgent, f.MaxTransferDepth),
+			genai.RoleModel,
+		)).
+		WithActions(types.NewEventActions())
+	event.ErrorCode = types.ErrorCodeMaxTransferDepth
+	event.ErrorMessage = fmt.Sprintf("max transfer depth %d reached", f.MaxTransferDepth)
+	return event
+}
+
 func (f *LLMFlow) getAgentToRun(ctx context.Context, ic *types.InvocationContext, transferToAgent string) (types.Agent, error) {
 	rootAgent := ic.Agent.RootAgent()
 	agentToRun := rootAgent.FindAgent(transferToAgent)
@@ -550,7 +849,7 @@ func (f *LLMFlow) callLLM(ctx context.Context, ic *types.InvocationContext, requ
 		// Runs before_model_callback if it exists
 		response, err := f.handleBeforeModelCallback(ctx, ic, request, modelResponseEvent)
 		if err != nil {
-			if !yield(nil, err) {
+			if !yield(nil, wrapFlowError(ic, types.FlowStageModel, request.Model, err)) {
 				return
 			}
 		}
@@ -593,15 +892,26 @@ func (f *LLMFlow) callLLM(ctx context.Context, ic *types.InvocationContext, requ
 			ic.IncrementLLMCallCount()
 
 			llm := f.getLLM(ctx, ic)
+			inputTokens, err := f.acquireTenantBudget(ctx, ic, llm, request)
+			if err != nil {
+				yield(nil, wrapFlowError(ic, types.FlowStageModel, llm.Name(), err))
+				return
+			}
+			var usage *genai.GenerateContentResponseUsageMetadata
+			defer func() { f.recordTenantUsage(ic, inputTokens, usage) }()
+
 			isStream := ic.RunConfig.StreamingMode == types.StreamingModeSSE
 			if isStream {
 				respSeq := llm.StreamGenerateContent(ctx, request)
 				for response, err := range respSeq {
 					if err != nil {
-						if !yield(nil, err) {
+						if !yield(nil, wrapFlowError(ic, types.FlowStageModel, llm.Name(), err)) {
 							return
 						}
 					}
+					if response != nil && response.UsageMetadata != nil {
+						usage = response.UsageMetadata
+					}
 
 					// Runs after_model_callback if it exists.
 					alterResponse, err := f.handleAfterModelCallback(ctx, ic, response, modelResponseEvent)
@@ -617,6 +927,52 @@ func (f *LLMFlow) callLLM(ctx context.Context, ic *types.InvocationContext, requ
 	}
 }
 
+// acquireTenantBudget takes the model call from the budget of the tenant of the invocation, and
+// returns the input tokens charged.
+func (f *LLMFlow) acquireTenantBudget(ctx context.Context, ic *types.InvocationContext, llm types.Model, request *types.LLMRequest) (int, error) {
+	if f.TenantRateLimiter == nil {
+		return 0, nil
+	}
+
+	appName, userID := invocationTenant(ic)
+	var tokens int
+	if f.TenantRateLimiter.limitsTokens(appName, userID) {
+		tokens = estimateTokens(f.Tokenizer, request)
+		if counter, ok := llm.(types.TokenCounter); ok {
+			n, err := counter.CountTokens(ctx, request)
+			if err != nil {
+				f.Logger.WarnContext(ctx, "count tokens failed, using an estimate", slog.String("error", err.Error()))
+			} else {
+				tokens = n
+			}
+		}
+	}
+
+	if err := f.TenantRateLimiter.Acquire(ctx, appName, userID, tokens); err != nil {
+		return 0, err
+	}
+	return tokens, nil
+}
+
+// recordTenantUsage charges the tokens of the response not charged before the call to the budget
+// of the tenant of the invocation.
+func (f *LLMFlow) recordTenantUsage(ic *types.InvocationContext, inputTokens int, usage *genai.GenerateContentResponseUsageMetadata) {
+	if f.TenantRateLimiter == nil || usage == nil {
+		return
+	}
+
+	appName, userID := invocationTenant(ic)
+	f.TenantRateLimiter.Record(appName, userID, int(usage.TotalTokenCount)-inputTokens)
+}
+
+// invocationTenant returns the application and user of the invocation.
+func invocationTenant(ic *types.InvocationContext) (appName, userID string) {
+	if ic.Session == nil {
+		return "", ""
+	}
+	return ic.AppName(), ic.UserID()
+}
+
 // handleBeforeModelCallback processes callbacks that should run before the model has generated a response.
 func (f *LLMFlow) handleBeforeModelCallback(ctx context.Context, ic *types.InvocationContext, request *types.LLMRequest, modelResponseEvent *types.Event) (*types.LLMResponse, error) {
 	llmAgent, ok := ic.Agent.AsLLMAgent()
@@ -674,6 +1030,10 @@ func (f *LLMFlow) finalizeModelResponseEvent(ctx context.Context, request *types
 			modelResponseEvent.LongRunningToolIDs.Insert(GetLongRunningFunctionCalls(ctx, funcCalls, request.ToolMap).UnsortedList()...)
 		}
 	}
+	// The grounded answers always carry their citations.
+	if f.ExtractCitations || request.GoogleSearchGrounding != nil {
+		modelResponseEvent.Citations = types.ExtractCitations(response)
+	}
 	return modelResponseEvent
 }
 
@@ -0,0 +1,144 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestLLMFlow_MaxTransferDepth(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "router")
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	flow := llmflow.NewLLMFlow().WithMaxTransferDepth(2)
+
+	for i, target := range []string{"billing", "router"} {
+		if event := llmflow.TransferLimitEvent(flow, ictx, target); event != nil {
+			t.Fatalf("transfer %d to %s stopped: %v", i+1, target, event.ErrorMessage)
+		}
+	}
+	if got := ictx.TransferDepth(); got != 2 {
+		t.Errorf("TransferDepth() = %d, want 2", got)
+	}
+
+	event := llmflow.TransferLimitEvent(flow, ictx, "billing")
+	if event == nil {
+		t.Fatal("transfer beyond the limit was not stopped")
+	}
+	if event.ErrorCode != types.ErrorCodeMaxTransferDepth || event.Author != "router" {
+		t.Errorf("limit event = (%q, %q), want (%q, %q)", event.ErrorCode, event.Author, types.ErrorCodeMaxTransferDepth, "router")
+	}
+	if got := ictx.TransferDepth(); got != 2 {
+		t.Errorf("TransferDepth() after the stopped transfer = %d, want 2", got)
+	}
+
+	// A new invocation starts over.
+	next := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	if event := llmflow.TransferLimitEvent(flow, next, "billing"); event != nil {
+		t.Errorf("first transfer of a new invocation stopped: %v", event.ErrorMessage)
+	}
+}
+
+func TestLLMFlow_WithCitations(t *testing.T) {
+	t.Parallel()
+
+	response := &types.LLMResponse{
+		Content: genai.NewContentFromText("Kyoto is in Japan.", genai.RoleModel),
+		CitationMetadata: &genai.CitationMetadata{
+			Citations: []*genai.Citation{{URI: "https://example.com/kyoto", StartIndex: 0, EndIndex: 5}},
+		},
+	}
+
+	for name, enabled := range map[string]bool{"enabled": true, "disabled": false} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			flow := llmflow.NewLLMFlow().WithCitations(enabled)
+			event := llmflow.FinalizeModelResponseEvent(flow, t.Context(), &types.LLMRequest{}, response, types.NewEvent().WithLLMResponse(response))
+			if got := len(event.Citations); (got == 1) != enabled {
+				t.Fatalf("len(Citations) = %d, want citations %t", got, enabled)
+			}
+			if enabled && event.Citations[0].Text != "Kyoto" {
+				t.Errorf("Citations[0].Text = %q, want %q", event.Citations[0].Text, "Kyoto")
+			}
+		})
+	}
+}
+
+func TestLLMFlow_GroundedCitations(t *testing.T) {
+	t.Parallel()
+
+	response := &types.LLMResponse{
+		Content: genai.NewContentFromText("Kyoto is in Japan.", genai.RoleModel),
+		GroundingMetadata: &genai.GroundingMetadata{
+			GroundingChunks: []*genai.GroundingChunk{{Web: &genai.GroundingChunkWeb{URI: "https://example.com/kyoto", Title: "Kyoto"}}},
+			GroundingSupports: []*genai.GroundingSupport{{
+				GroundingChunkIndices: []int32{0},
+				Segment:               &genai.Segment{StartIndex: 0, EndIndex: 5, Text: "Kyoto"},
+			}},
+		},
+	}
+	request := types.NewLLMRequest(nil, types.WithGoogleSearchGrounding(true))
+
+	// The citations of a grounded answer are extracted without WithCitations.
+	event := llmflow.FinalizeModelResponseEvent(llmflow.NewLLMFlow(), t.Context(), request, response, types.NewEvent().WithLLMResponse(response))
+	if len(event.Citations) != 1 || event.Citations[0].URI != "https://example.com/kyoto" || event.Citations[0].Source != types.CitationSourceGrounding {
+		t.Errorf("Citations = %+v, want the grounding source", event.Citations)
+	}
+}
+
+// recordingCanceler is a [types.JobCanceler] recording the cancelled jobs.
+type recordingCanceler struct {
+	mu        sync.Mutex
+	cancelled []string
+}
+
+func (c *recordingCanceler) Cancel(jobID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelled = append(c.cancelled, jobID)
+	return nil
+}
+
+func TestLLMFlow_RunStopsCancellingJobs(t *testing.T) {
+	t.Parallel()
+
+	a, err := agent.NewLLMAgent(t.Context(), "assistant", agent.WithModel(model.NewBaseLLM("base-model")))
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+	canceler := &recordingCanceler{}
+	ictx.RegisterJob("job-1", canceler)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	for range llmflow.NewLLMFlow().Run(ctx, ictx) {
+	}
+	// The run is over: its context no longer cancels the jobs, nor holds the invocation.
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+
+	canceler.mu.Lock()
+	defer canceler.mu.Unlock()
+	if len(canceler.cancelled) != 0 {
+		t.Errorf("jobs cancelled after the run = %v, want none", canceler.cancelled)
+	}
+}
@@ -5,7 +5,11 @@ package llmflow
 
 import (
 	"context"
+	"fmt"
 	"iter"
+	"slices"
+
+	"google.golang.org/genai"
 
 	"github.com/go-a2a/adk-go/planner"
 	"github.com/go-a2a/adk-go/types"
@@ -34,6 +38,12 @@ func (p *NLPlanningRequestProcessor) Run(ctx context.Context, ictx *types.Invoca
 			request.AppendInstructions(planningInstruction)
 		}
 
+		// Force the final answer once the planning steps are exhausted.
+		if maxSteps := maxPlanningSteps(plnr); maxSteps > 0 && planningSteps(ictx) >= maxSteps {
+			request.AppendInstructions(planner.ForceFinalAnswerPreamble)
+			request.ToolChoice = &types.ToolChoice{Mode: types.ToolChoiceNone}
+		}
+
 		removeThoughtFromRequest(request)
 	}
 }
@@ -53,19 +63,54 @@ func (p *NLPlanningResponseProcessor) Run(ctx context.Context, ictx *types.Invoc
 			return
 		}
 
-		// Postprocess the LLM response.
 		cctx := types.NewCallbackContext(ictx)
+		if captor, ok := plnr.(interface {
+			CaptureThoughts() (capture, persist bool)
+		}); ok {
+			if capture, persist := captor.CaptureThoughts(); capture {
+				// The thoughts are moved out of the response, so their plan is detected first.
+				plnr.ProcessPlanningResponse(ctx, cctx, response.Content.Parts)
+				if event := captureThoughts(ictx, response, persist); event != nil {
+					if !yield(event, nil) {
+						return
+					}
+				}
+			}
+		}
+
+		// End the planning if the model still acts rather than answer once the planning steps are
+		// exhausted.
+		if maxSteps := maxPlanningSteps(plnr); maxSteps > 0 && planningSteps(ictx) >= maxSteps && hasFunctionCall(response.Content.Parts) {
+			response.Content.Parts = slices.DeleteFunc(response.Content.Parts, func(part *genai.Part) bool {
+				return part != nil && part.FunctionCall != nil
+			})
+			event := ictx.NewEvent().
+				WithInvocationID(ictx.InvocationID).
+				WithAuthor(ictx.Agent.Name()).
+				WithBranch(ictx.Branch).
+				WithActions(types.NewEventActions()).
+				WithLLMResponse(&types.LLMResponse{
+					ErrorCode:    planner.ErrorCodeMaxPlanningSteps,
+					ErrorMessage: fmt.Sprintf("planning ended after %d steps without a final answer", maxSteps),
+				})
+			if !yield(event, nil) {
+				return
+			}
+		}
+
+		// Postprocess the LLM response.
 		processedParts := plnr.ProcessPlanningResponse(ctx, cctx, response.Content.Parts)
 		if len(processedParts) > 0 {
 			response.Content.Parts = append(response.Content.Parts, processedParts...)
 		}
 
-		if cctx.State().HasDelta() {
-			stateUpdateEvent := types.NewEvent().
+		if cctx.State().HasDelta() || cctx.Plan() != nil {
+			stateUpdateEvent := ictx.NewEvent().
 				WithInvocationID(ictx.InvocationID).
 				WithAuthor(ictx.Agent.Name()).
 				WithBranch(ictx.Branch).
 				WithActions(cctx.EventActions())
+			stateUpdateEvent.Plan = cctx.Plan()
 
 			if !yield(stateUpdateEvent, nil) {
 				return
@@ -74,6 +119,63 @@ func (p *NLPlanningResponseProcessor) Run(ctx context.Context, ictx *types.Invoc
 	}
 }
 
+// captureThoughts moves the thought parts of the response to a reasoning event, and returns it,
+// or nil if the response has no thought. The event is marked as partial unless persisted.
+func captureThoughts(ictx *types.InvocationContext, response *types.LLMResponse, persist bool) *types.Event {
+	var thoughts, answer []*genai.Part
+	for _, part := range response.Content.Parts {
+		if part != nil && part.Thought {
+			thoughts = append(thoughts, part)
+			continue
+		}
+		answer = append(answer, part)
+	}
+	if len(thoughts) == 0 {
+		return nil
+	}
+	response.Content.Parts = answer
+
+	event := ictx.NewEvent().
+		WithInvocationID(ictx.InvocationID).
+		WithAuthor(ictx.Agent.Name()).
+		WithBranch(ictx.Branch).
+		WithActions(types.NewEventActions()).
+		WithContent(&genai.Content{Role: response.Content.Role, Parts: thoughts})
+	event.Reasoning = true
+	event.Partial = !persist
+
+	return event
+}
+
+// maxPlanningSteps returns the maximum number of planning steps of the planner, or zero for no
+// limit.
+func maxPlanningSteps(plnr types.Planner) int {
+	if plnr, ok := plnr.(interface{ MaxPlanningSteps() int }); ok {
+		return plnr.MaxPlanningSteps()
+	}
+	return 0
+}
+
+// planningSteps returns the number of planning steps taken by the agent in the invocation, that is
+// its complete responses calling tools.
+func planningSteps(ictx *types.InvocationContext) int {
+	steps := 0
+	for _, event := range ictx.Session.Events() {
+		if event.InvocationID != ictx.InvocationID || event.Author != ictx.Agent.Name() || event.LLMResponse == nil || event.Partial {
+			continue
+		}
+		if len(event.GetFunctionCalls()) > 0 {
+			steps++
+		}
+	}
+	return steps
+}
+
+// hasFunctionCall reports whether one of the parts is a function call.
+func hasFunctionCall(parts []*genai.Part) bool {
+	return slices.ContainsFunc(parts, func(part *genai.Part) bool { return part != nil && part.FunctionCall != nil })
+}
+
 func getPlanner(ictx *types.InvocationContext) types.Planner {
 	llmAgent, ok := ictx.Agent.AsLLMAgent()
 	if !ok {
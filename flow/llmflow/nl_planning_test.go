@@ -0,0 +1,251 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/planner"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestNLPlanningResponseProcessor_CaptureThoughts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts        []planner.BuiltInPlannerOption
+		wantEvent   bool
+		wantPartial bool
+		wantAnswer  []*genai.Part
+	}{
+		"not captured": {
+			wantAnswer: []*genai.Part{{Text: "thinking", Thought: true}, {Text: "answer"}},
+		},
+		"captured": {
+			opts:        []planner.BuiltInPlannerOption{planner.WithCaptureThoughts(true, false)},
+			wantEvent:   true,
+			wantPartial: true,
+			wantAnswer:  []*genai.Part{{Text: "answer"}},
+		},
+		"captured and persisted": {
+			opts:       []planner.BuiltInPlannerOption{planner.WithCaptureThoughts(true, true)},
+			wantEvent:  true,
+			wantAnswer: []*genai.Part{{Text: "answer"}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "test-agent",
+				agent.WithPlanner(planner.NewBuiltInPlanner(&genai.ThinkingConfig{}, tt.opts...)))
+			if err != nil {
+				t.Fatalf("NewLLMAgent: %v", err)
+			}
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			response := &types.LLMResponse{
+				Content: genai.NewContentFromParts([]*genai.Part{
+					{Text: "thinking", Thought: true},
+					{Text: "answer"},
+				}, genai.RoleModel),
+			}
+
+			var events []*types.Event
+			for event, err := range (&llmflow.NLPlanningResponseProcessor{}).Run(t.Context(), ictx, response) {
+				if err != nil {
+					t.Fatalf("Run error = %v", err)
+				}
+				events = append(events, event)
+			}
+
+			if diff := cmp.Diff(tt.wantAnswer, response.Content.Parts); diff != "" {
+				t.Errorf("response parts mismatch (-want +got):\n%s", diff)
+			}
+			if !tt.wantEvent {
+				if len(events) != 0 {
+					t.Errorf("Run yielded %d events, want none", len(events))
+				}
+				return
+			}
+			if len(events) != 1 {
+				t.Fatalf("Run yielded %d events, want 1", len(events))
+			}
+			event := events[0]
+			if !event.Reasoning || event.Partial != tt.wantPartial || event.IsFinalResponse() {
+				t.Errorf("event Reasoning = %t, Partial = %t, IsFinalResponse = %t, want true, %t, false",
+					event.Reasoning, event.Partial, event.IsFinalResponse(), tt.wantPartial)
+			}
+			if diff := cmp.Diff([]*genai.Part{{Text: "thinking", Thought: true}}, event.Content.Parts); diff != "" {
+				t.Errorf("reasoning parts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNLPlanning_MaxPlanningSteps(t *testing.T) {
+	t.Parallel()
+
+	const maxSteps = 2
+	ctx := t.Context()
+	a, err := agent.NewLLMAgent(ctx, "planner-agent",
+		agent.WithPlanner(planner.NewPlanReActPlanner(planner.WithMaxPlanningSteps(maxSteps))))
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+	sessionSvc := session.NewInMemoryService()
+	ses, err := sessionSvc.CreateSession(ctx, "app", "user", "session", nil)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	ictx := types.NewInvocationContext(a, ses, sessionSvc)
+	ictx.InvocationID = "invocation"
+
+	// The scripted model acts at each step and never gives a final answer.
+	for step := range maxSteps + 1 {
+		request := types.NewLLMRequest(nil)
+		for _, err := range (&llmflow.NLPlanningRequestProcessor{}).Run(ctx, ictx, request) {
+			if err != nil {
+				t.Fatalf("step %d: request Run error = %v", step, err)
+			}
+		}
+		parts := request.Config.SystemInstruction.Parts
+		forced := strings.Contains(parts[len(parts)-1].Text, planner.ForceFinalAnswerPreamble)
+		if wantForced := step == maxSteps; forced != wantForced || !request.ToolChoice.IsAuto() != wantForced {
+			t.Fatalf("step %d: final answer forced = %t, tool choice = %s, want forced %t", step, forced, request.ToolChoice, wantForced)
+		}
+
+		response := &types.LLMResponse{
+			Content: genai.NewContentFromParts([]*genai.Part{
+				genai.NewPartFromText(planner.ActionTag + " search again"),
+				genai.NewPartFromFunctionCall("search", map[string]any{"q": "more"}),
+			}, genai.RoleModel),
+		}
+		var events []*types.Event
+		for event, err := range (&llmflow.NLPlanningResponseProcessor{}).Run(ctx, ictx, response) {
+			if err != nil {
+				t.Fatalf("step %d: response Run error = %v", step, err)
+			}
+			events = append(events, event)
+		}
+
+		if step < maxSteps {
+			if len(events) != 0 {
+				t.Fatalf("step %d: Run yielded %d events, want none", step, len(events))
+			}
+			event := ictx.NewEvent().
+				WithInvocationID(ictx.InvocationID).
+				WithAuthor(a.Name()).
+				WithContent(response.Content)
+			if _, err := sessionSvc.AppendEvent(ctx, ses, event); err != nil {
+				t.Fatalf("AppendEvent: %v", err)
+			}
+			continue
+		}
+
+		if len(events) != 1 || events[0].ErrorCode != planner.ErrorCodeMaxPlanningSteps {
+			t.Fatalf("step %d: Run yielded %+v, want an event of error code %s", step, events, planner.ErrorCodeMaxPlanningSteps)
+		}
+		for _, part := range response.Content.Parts {
+			if part.FunctionCall != nil {
+				t.Errorf("step %d: response still calls %s, want the function calls dropped", step, part.FunctionCall.Name)
+			}
+		}
+	}
+}
+
+func TestNLPlanningResponseProcessor_Plan(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		planner types.Planner
+		parts   []*genai.Part
+		want    *types.Plan
+	}{
+		"plan-react": {
+			planner: planner.NewPlanReActPlanner(),
+			parts: []*genai.Part{
+				genai.NewPartFromText(planner.PlanningTag + "\n1. Look the docs up with search.\n2. Answer.\n" + planner.ActionTag),
+				genai.NewPartFromFunctionCall("search", map[string]any{"q": "docs"}),
+			},
+			want: &types.Plan{
+				Steps: []types.PlanStep{
+					{Description: "Look the docs up with search.", ToolName: "search"},
+					{Description: "Answer."},
+				},
+				Text: "1. Look the docs up with search.\n2. Answer.",
+			},
+		},
+		"plan-react replanning": {
+			planner: planner.NewPlanReActPlanner(),
+			parts: []*genai.Part{
+				genai.NewPartFromText(planner.ReplanningTag + "\n1. Retry search(q=\"guide\")."),
+			},
+			want: &types.Plan{
+				Steps:     []types.PlanStep{{Description: "Retry search(q=\"guide\").", ToolName: "search", Args: map[string]any{"q": "guide"}}},
+				Replanned: true,
+				Text:      "1. Retry search(q=\"guide\").",
+			},
+		},
+		"captured thinking": {
+			planner: planner.NewBuiltInPlanner(nil, planner.WithCaptureThoughts(true, true)),
+			parts: []*genai.Part{
+				{Text: "Plan:\n- Call search.\n- Reply.", Thought: true},
+				genai.NewPartFromText("answer"),
+			},
+			want: &types.Plan{
+				Steps: []types.PlanStep{
+					{Description: "Call search.", ToolName: "search"},
+					{Description: "Reply."},
+				},
+				Text: "Plan:\n- Call search.\n- Reply.",
+			},
+		},
+		"no plan": {
+			planner: planner.NewPlanReActPlanner(),
+			parts:   []*genai.Part{genai.NewPartFromText(planner.FinalAnswerTag + " done")},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			a, err := agent.NewLLMAgent(t.Context(), "test-agent",
+				agent.WithPlanner(tt.planner),
+				agent.WithTools(tools.NewAgent("search", "search")))
+			if err != nil {
+				t.Fatalf("NewLLMAgent: %v", err)
+			}
+			ses := session.NewSession("app", "user", "session", nil, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+			response := &types.LLMResponse{Content: genai.NewContentFromParts(tt.parts, genai.RoleModel)}
+
+			var plan *types.Plan
+			for event, err := range (&llmflow.NLPlanningResponseProcessor{}).Run(t.Context(), ictx, response) {
+				if err != nil {
+					t.Fatalf("Run error = %v", err)
+				}
+				if event.Plan != nil {
+					if plan != nil {
+						t.Fatal("Run yielded several plans")
+					}
+					plan = event.Plan
+				}
+			}
+			if diff := cmp.Diff(tt.want, plan); diff != "" {
+				t.Errorf("plan mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
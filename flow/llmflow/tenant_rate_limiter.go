@@ -0,0 +1,221 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// Names of the limits reported by [types.RateLimitError.Limit].
+const (
+	RateLimitQPS             = "qps"
+	RateLimitTokensPerMinute = "tokens_per_minute"
+)
+
+// TenantLimits is the model usage budget of a tenant.
+type TenantLimits struct {
+	// QPS is the number of model calls per second. Zero or less means no limit.
+	QPS float64
+
+	// Burst is the number of model calls allowed at once above QPS. Defaults to QPS rounded up.
+	Burst int
+
+	// TokensPerMinute is the number of tokens, input and output, per minute. Zero or less means no limit.
+	//
+	// The input tokens of a request are counted with [types.TokenCounter] when the model implements it,
+	// and estimated with [LLMFlow.Tokenizer] otherwise; the output tokens are charged from the usage
+	// metadata of the response.
+	TokensPerMinute int
+}
+
+// tenantKey identifies a tenant. An empty user ID stands for every user of the application.
+type tenantKey struct {
+	appName string
+	userID  string
+}
+
+// tenantState holds the limiters of a tenant.
+type tenantState struct {
+	calls  *rate.Limiter // nil without QPS limit
+	tokens *rate.Limiter // nil without token limit
+}
+
+// TenantRateLimiter throttles the model calls per tenant, keyed by application and user.
+//
+// It is safe for concurrent use.
+type TenantRateLimiter struct {
+	defaults  TenantLimits
+	overrides map[tenantKey]TenantLimits
+	wait      bool
+	now       func() time.Time
+
+	mu      sync.Mutex
+	tenants map[tenantKey]*tenantState
+}
+
+// TenantRateLimiterOption configures a [TenantRateLimiter].
+type TenantRateLimiterOption func(*TenantRateLimiter)
+
+// WithTenantLimits sets the limits of a tenant, overriding the default limits.
+//
+// An empty userID sets the limits of each user of the application that has no limits of its own.
+func WithTenantLimits(appName, userID string, limits TenantLimits) TenantRateLimiterOption {
+	return func(l *TenantRateLimiter) {
+		l.overrides[tenantKey{appName: appName, userID: userID}] = limits
+	}
+}
+
+// WithRateLimitWait sets whether a call over budget waits for the budget to refill instead of
+// being rejected with a [*types.RateLimitError].
+//
+// A call still fails if it would never fit the budget, or if its context is done before.
+func WithRateLimitWait(wait bool) TenantRateLimiterOption {
+	return func(l *TenantRateLimiter) {
+		l.wait = wait
+	}
+}
+
+// NewTenantRateLimiter returns a new [TenantRateLimiter] applying the default limits to each tenant
+// without limits of its own.
+func NewTenantRateLimiter(defaults TenantLimits, opts ...TenantRateLimiterOption) *TenantRateLimiter {
+	l := &TenantRateLimiter{
+		defaults:  defaults,
+		overrides: make(map[tenantKey]TenantLimits),
+		now:       time.Now,
+		tenants:   make(map[tenantKey]*tenantState),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// Acquire takes a call and the input tokens from the budget of the tenant.
+//
+// It returns a [*types.RateLimitError] if the budget is exhausted, or waits for it to refill
+// when [WithRateLimitWait] is set. Nothing is taken from the budget when the call is rejected.
+func (l *TenantRateLimiter) Acquire(ctx context.Context, appName, userID string, tokens int) error {
+	state := l.tenant(appName, userID)
+	now := l.now()
+
+	var (
+		reservations []*rate.Reservation
+		delay        time.Duration
+		limit        string
+	)
+	cancel := func() {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+	reserve := func(limiter *rate.Limiter, n int, name string) {
+		r := limiter.ReserveN(now, n)
+		reservations = append(reservations, r)
+		if d := r.DelayFrom(now); d > delay {
+			delay, limit = d, name
+		}
+	}
+
+	if state.calls != nil {
+		reserve(state.calls, 1, RateLimitQPS)
+	}
+	if state.tokens != nil && tokens > 0 {
+		// A single request larger than the whole budget is charged the whole budget, so that it
+		// may still run once the budget is full.
+		reserve(state.tokens, min(tokens, state.tokens.Burst()), RateLimitTokensPerMinute)
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	if !l.wait {
+		cancel()
+		return &types.RateLimitError{AppName: appName, UserID: userID, Limit: limit, RetryAfter: delay}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancel()
+		return ctx.Err()
+	}
+}
+
+// Record charges the output tokens of a completed call to the budget of the tenant.
+//
+// The budget may go negative, which delays the next calls of the tenant.
+func (l *TenantRateLimiter) Record(appName, userID string, tokens int) {
+	state := l.tenant(appName, userID)
+	if state.tokens == nil || tokens <= 0 {
+		return
+	}
+
+	now := l.now()
+	for tokens > 0 {
+		n := min(tokens, state.tokens.Burst())
+		state.tokens.ReserveN(now, n)
+		tokens -= n
+	}
+}
+
+// limitsTokens reports whether the tenant has a token budget, so that the tokens of its calls must be counted.
+func (l *TenantRateLimiter) limitsTokens(appName, userID string) bool {
+	return l.tenant(appName, userID).tokens != nil
+}
+
+// tenant returns the limiters of the tenant, creating them on first use.
+func (l *TenantRateLimiter) tenant(appName, userID string) *tenantState {
+	key := tenantKey{appName: appName, userID: userID}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.tenants[key]; ok {
+		return state
+	}
+
+	limits := l.limits(key)
+	state := new(tenantState)
+	if limits.QPS > 0 {
+		burst := limits.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(limits.QPS))
+		}
+		state.calls = rate.NewLimiter(rate.Limit(limits.QPS), burst)
+	}
+	if limits.TokensPerMinute > 0 {
+		state.tokens = rate.NewLimiter(rate.Limit(float64(limits.TokensPerMinute)/60), limits.TokensPerMinute)
+	}
+	l.tenants[key] = state
+
+	return state
+}
+
+// limits returns the limits of the tenant: its own, those of its application, or the defaults.
+func (l *TenantRateLimiter) limits(key tenantKey) TenantLimits {
+	if limits, ok := l.overrides[key]; ok {
+		return limits
+	}
+	if limits, ok := l.overrides[tenantKey{appName: key.appName}]; ok {
+		return limits
+	}
+	return l.defaults
+}
+
+// estimateTokens estimates the input tokens of the request with the tokenizer, or with the
+// approximate tokenizer of the model of the request if nil, for the models that cannot count them.
+func estimateTokens(tokenizer types.Tokenizer, request *types.LLMRequest) int {
+	return types.CountRequestTokens(tokenizer, request)
+}
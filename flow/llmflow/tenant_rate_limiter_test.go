@@ -0,0 +1,138 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/flow/llmflow"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestTenantRateLimiter_QPS(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	limiter := llmflow.NewTenantRateLimiter(
+		llmflow.TenantLimits{QPS: 1},
+		llmflow.WithTenantLimits("app", "vip", llmflow.TenantLimits{QPS: 10, Burst: 3}),
+	)
+	llmflow.SetTenantRateLimiterClock(limiter, func() time.Time { return now })
+	ctx := t.Context()
+
+	if err := limiter.Acquire(ctx, "app", "alice", 0); err != nil {
+		t.Fatalf("first Acquire() error = %v", err)
+	}
+	err := limiter.Acquire(ctx, "app", "alice", 0)
+	var rateErr *types.RateLimitError
+	if !errors.As(err, &rateErr) || !errors.Is(err, types.ErrRateLimited) {
+		t.Fatalf("second Acquire() error = %v, want a RateLimitError", err)
+	}
+	if rateErr.UserID != "alice" || rateErr.Limit != llmflow.RateLimitQPS || rateErr.RetryAfter != time.Second {
+		t.Errorf("RateLimitError = %+v, want alice over qps retrying after 1s", rateErr)
+	}
+
+	// Other tenants have their own budget
+	if err := limiter.Acquire(ctx, "app", "bob", 0); err != nil {
+		t.Errorf("Acquire() for another user error = %v", err)
+	}
+	for i := range 3 {
+		if err := limiter.Acquire(ctx, "app", "vip", 0); err != nil {
+			t.Errorf("Acquire() %d for the overridden user error = %v", i, err)
+		}
+	}
+
+	now = now.Add(time.Second)
+	if err := limiter.Acquire(ctx, "app", "alice", 0); err != nil {
+		t.Errorf("Acquire() after the refill error = %v", err)
+	}
+}
+
+func TestTenantRateLimiter_TokensPerMinute(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	limiter := llmflow.NewTenantRateLimiter(
+		llmflow.TenantLimits{},
+		llmflow.WithTenantLimits("metered", "", llmflow.TenantLimits{TokensPerMinute: 600}),
+	)
+	llmflow.SetTenantRateLimiterClock(limiter, func() time.Time { return now })
+	ctx := t.Context()
+
+	// The default limits are unlimited
+	for range 5 {
+		if err := limiter.Acquire(ctx, "free", "alice", 1_000_000); err != nil {
+			t.Fatalf("Acquire() without limits error = %v", err)
+		}
+	}
+
+	if err := limiter.Acquire(ctx, "metered", "alice", 400); err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	limiter.Record("metered", "alice", 200)
+
+	err := limiter.Acquire(ctx, "metered", "alice", 100)
+	var rateErr *types.RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Acquire() over budget error = %v, want a RateLimitError", err)
+	}
+	if rateErr.Limit != llmflow.RateLimitTokensPerMinute || rateErr.RetryAfter != 10*time.Second {
+		t.Errorf("RateLimitError = %+v, want tokens_per_minute retrying after 10s", rateErr)
+	}
+
+	// A rejected call takes nothing from the budget, and the app limits apply to each user
+	now = now.Add(10 * time.Second)
+	if err := limiter.Acquire(ctx, "metered", "alice", 100); err != nil {
+		t.Errorf("Acquire() after the refill error = %v", err)
+	}
+	if err := limiter.Acquire(ctx, "metered", "bob", 600); err != nil {
+		t.Errorf("Acquire() for another user error = %v", err)
+	}
+}
+
+func TestTenantRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter := llmflow.NewTenantRateLimiter(llmflow.TenantLimits{QPS: 20, Burst: 1}, llmflow.WithRateLimitWait(true))
+
+	ctx := t.Context()
+	start := time.Now()
+	for range 3 {
+		if err := limiter.Acquire(ctx, "app", "alice", 0); err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Acquire() waited %s for 3 calls at 20 QPS, want at least 100ms", elapsed)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := limiter.Acquire(cctx, "app", "alice", 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() with a cancelled context error = %v, want context.Canceled", err)
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	t.Parallel()
+
+	request := &types.LLMRequest{
+		Contents: []*genai.Content{
+			genai.NewContentFromText("12345678", genai.RoleUser),
+			genai.NewContentFromFunctionResponse("tool", map[string]any{"ab": "cdef"}, genai.RoleUser),
+		},
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText("1234", genai.RoleUser),
+		},
+	}
+	// 8 + 4 + len("tool") + len("ab") + len("cdef") = 22 characters
+	if got, want := llmflow.EstimateTokens(request), 6; got != want {
+		t.Errorf("EstimateTokens() = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"github.com/go-json-experiment/json"
+	"google.golang.org/genai"
+)
+
+// WithMaxToolOutputBytes caps the total size of the tool outputs in the contents, the function
+// responses encoded as compact JSON, to n bytes. Zero or less means no limit, which is the default.
+//
+// Over the budget, the oldest tool outputs are truncated until the total fits: each is replaced by
+// its JSON cut to the bytes left, followed by [TruncationMarker], under the "result" key with
+// "truncated" set to true, like a [FunctionResponseFormat] over MaxBytes. The function responses
+// keep their name and ID, so that they still answer their calls. The tool outputs of the latest
+// model turn are never cut, so that the model always sees the results it asked for; bound them
+// with [FunctionResponseFormat.MaxBytes].
+//
+// A tool output is never dropped: cut to nothing, it still holds the marker, 44 bytes of JSON, so
+// that the model knows a result was removed. The total may therefore exceed a budget smaller than
+// these stubs and the latest tool outputs together.
+//
+// The budget applies to the contents after the turn window of [ContentLLMRequestProcessor.WithMaxTurns]
+// and the deduplication of [ContentLLMRequestProcessor.WithDedupe], so that only the tool outputs
+// actually sent are counted. Only the contents of the request are rewritten, the events of the
+// session are never modified.
+func (cp *ContentLLMRequestProcessor) WithMaxToolOutputBytes(n int) *ContentLLMRequestProcessor {
+	cp.maxToolOutputBytes = max(n, 0)
+	return cp
+}
+
+// toolOutput is a function response of the contents, with the size of its JSON.
+type toolOutput struct {
+	response *genai.FunctionResponse
+	data     []byte
+}
+
+// budgetToolOutputs truncates the oldest function responses of the contents until their total
+// size fits in limit bytes, leaving the function responses of the last content holding some. The
+// truncated responses keep the marker, so the total may still exceed limit once all are cut.
+//
+// The contents are the copies built from the history, so their function responses are replaced in place.
+func budgetToolOutputs(contents []*genai.Content, limit int) {
+	var (
+		outputs []toolOutput
+		total   int
+		latest  int // the index in outputs of the first function response of the last content holding some
+	)
+	for _, content := range contents {
+		first := true
+		for _, part := range content.Parts {
+			if part == nil || part.FunctionResponse == nil {
+				continue
+			}
+			if first {
+				latest = len(outputs)
+				first = false
+			}
+			data, err := json.Marshal(part.FunctionResponse.Response)
+			if err != nil {
+				continue
+			}
+			outputs = append(outputs, toolOutput{response: part.FunctionResponse, data: data})
+			total += len(data)
+		}
+	}
+
+	for _, out := range outputs[:latest] {
+		if total <= limit {
+			return
+		}
+		// The size the output must shrink to, its truncated JSON being escaped in the replacement.
+		target := len(out.data) - (total - limit)
+		keep := target
+		var data []byte
+		for {
+			keep = max(keep, 0)
+			out.response.Response = map[string]any{
+				"result":    truncateString(string(out.data), keep),
+				"truncated": true,
+			}
+			data, _ = json.Marshal(out.response.Response)
+			if len(data) <= target || keep == 0 {
+				break
+			}
+			keep -= len(data) - target
+		}
+		total += len(data) - len(out.data)
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package llmflow
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// validateFunctionArgs validates the arguments of the function call against the parameters declared by the tool.
+//
+// It returns an [*types.InvalidArgumentsError] listing every violation, or nil if the arguments are valid
+// or the tool declares no parameters.
+func validateFunctionArgs(t types.Tool, funcCall *genai.FunctionCall) error {
+	decl := t.GetDeclaration()
+	if decl == nil || decl.Parameters == nil {
+		return nil
+	}
+
+	var violations []types.ArgumentViolation
+	report := func(path, format string, args ...any) {
+		violations = append(violations, types.ArgumentViolation{
+			Path:   path,
+			Reason: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var args any = funcCall.Args
+	if funcCall.Args == nil {
+		args = map[string]any{}
+	}
+	validateValue(decl.Parameters, "", args, report)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(violations, func(a, b types.ArgumentViolation) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return &types.InvalidArgumentsError{
+		Name:       funcCall.Name,
+		Violations: violations,
+	}
+}
+
+// validateValue validates the value at path against the schema, calling report for each violation.
+func validateValue(schema *genai.Schema, path string, value any, report func(path, format string, args ...any)) {
+	if schema == nil {
+		return
+	}
+
+	if value == nil {
+		if schema.Nullable != nil && *schema.Nullable {
+			return
+		}
+		if schemaType(schema) == genai.TypeNULL || schemaType(schema) == "" {
+			return
+		}
+		report(displayPath(path), "must not be null")
+		return
+	}
+
+	if len(schema.AnyOf) > 0 {
+		for _, sub := range schema.AnyOf {
+			var failed bool
+			validateValue(sub, path, value, func(string, string, ...any) { failed = true })
+			if !failed {
+				return
+			}
+		}
+		report(displayPath(path), "does not match any of the allowed schemas")
+		return
+	}
+
+	switch schemaType(schema) {
+	case genai.TypeString:
+		s, ok := value.(string)
+		if !ok {
+			report(displayPath(path), "must be a string, got %s", jsonTypeName(value))
+			return
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			report(displayPath(path), "must be one of [%s], got %q", strings.Join(schema.Enum, ", "), s)
+		}
+
+	case genai.TypeInteger:
+		f, ok := toFloat(value)
+		if !ok || f != math.Trunc(f) {
+			report(displayPath(path), "must be an integer, got %s", jsonTypeName(value))
+			return
+		}
+		validateEnum(schema, path, value, report)
+
+	case genai.TypeNumber:
+		if _, ok := toFloat(value); !ok {
+			report(displayPath(path), "must be a number, got %s", jsonTypeName(value))
+			return
+		}
+		validateEnum(schema, path, value, report)
+
+	case genai.TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			report(displayPath(path), "must be a boolean, got %s", jsonTypeName(value))
+		}
+
+	case genai.TypeArray:
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			report(displayPath(path), "must be an array, got %s", jsonTypeName(value))
+			return
+		}
+		for i := range rv.Len() {
+			validateValue(schema.Items, path+"["+strconv.Itoa(i)+"]", rv.Index(i).Interface(), report)
+		}
+
+	case genai.TypeObject:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			report(displayPath(path), "must be an object, got %s", jsonTypeName(value))
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := obj[name]; !ok {
+				report(joinPath(path, name), "is required")
+			}
+		}
+		for name, prop := range schema.Properties {
+			if v, ok := obj[name]; ok {
+				validateValue(prop, joinPath(path, name), v, report)
+			}
+		}
+	}
+}
+
+// validateEnum validates a numeric value against the enum of the schema, which holds its string forms.
+func validateEnum(schema *genai.Schema, path string, value any, report func(path, format string, args ...any)) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	f, _ := toFloat(value)
+	for _, e := range schema.Enum {
+		if ef, err := strconv.ParseFloat(e, 64); err == nil && ef == f {
+			return
+		}
+	}
+	report(displayPath(path), "must be one of [%s], got %v", strings.Join(schema.Enum, ", "), value)
+}
+
+// schemaType returns the normalized type of the schema, which may be declared in lower case.
+func schemaType(schema *genai.Schema) genai.Type {
+	if schema.Type == genai.TypeUnspecified {
+		return ""
+	}
+	return genai.Type(strings.ToUpper(string(schema.Type)))
+}
+
+// toFloat converts a numeric value, either decoded from JSON or given by Go code, to a float64.
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// jsonTypeName returns the JSON type name of the value, as the model knows it.
+func jsonTypeName(value any) string {
+	if _, ok := value.(map[string]any); ok {
+		return "object"
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	if f, ok := toFloat(value); ok {
+		if f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// joinPath returns the path of the named property of the object at path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayPath returns the path shown in violations, naming the root arguments object.
+func displayPath(path string) string {
+	if path == "" {
+		return "args"
+	}
+	return path
+}
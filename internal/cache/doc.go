@@ -0,0 +1,35 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cache provides a bounded, thread-safe in-memory LRU cache with TTL expiry.
+//
+// The LRU type is shared by the layers caching computed values, such as the model names resolved
+// by the model registry or the event IDs remembered by agent.DedupeEvents, instead of each of
+// them implementing its own cache.
+//
+// # Basic Usage
+//
+//	c := cache.NewLRU[string, *Result](1024, 10*time.Minute)
+//	c.Put("key", result)
+//	if result, ok := c.Get("key"); ok {
+//		// use the cached result
+//	}
+//
+// Get and Put run in O(1). When full, Put evicts the least recently used entry. The entries
+// older than the TTL are expired lazily, when they are looked up, so that the cache needs no
+// background goroutine.
+//
+// # Eviction Callback
+//
+// WithOnEvict sets a function called with each entry leaving the cache, to release the
+// resources held by the values:
+//
+//	c := cache.NewLRU(16, time.Hour, cache.WithOnEvict(func(key string, conn *Conn) {
+//		conn.Close()
+//	}))
+//
+// # Thread Safety
+//
+// LRU is safe for concurrent use. The eviction callback is called without holding the lock of
+// the cache, so that it may use the cache.
+package cache
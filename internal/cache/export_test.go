@@ -0,0 +1,11 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import "time"
+
+// SetClock sets the clock of the cache for testing.
+func SetClock[K comparable, V any](c *LRU[K, V], now func() time.Time) {
+	c.now = now
+}
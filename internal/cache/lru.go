@@ -0,0 +1,159 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a bounded least-recently-used cache whose entries expire after a TTL.
+type LRU[K comparable, V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	onEvict    func(K, V)
+	now        func() time.Time
+
+	// order holds the entries from the most to the least recently used.
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+// entry is an entry of an LRU.
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// Option configures an [LRU].
+type Option[K comparable, V any] func(*LRU[K, V])
+
+// WithOnEvict sets the function called with the entries leaving the cache, whether they are
+// evicted, expired, deleted or replaced by Put.
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(c *LRU[K, V]) {
+		c.onEvict = fn
+	}
+}
+
+// NewLRU returns a new [LRU] holding at most maxEntries entries, each expiring ttl after it was
+// put. A maxEntries of zero or less does not bound the cache, and a ttl of zero or less does not
+// expire the entries.
+func NewLRU[K comparable, V any](maxEntries int, ttl time.Duration, opts ...Option[K, V]) *LRU[K, V] {
+	c := &LRU[K, V]{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+		order:      list.New(),
+		entries:    make(map[K]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the value of the key, and marks it as the most recently used.
+//
+// An expired entry is removed and reported as missing.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		var zero V
+		return zero, false
+	}
+	e := elem.Value.(*entry[K, V])
+	if c.expired(e) {
+		c.remove(elem)
+		c.mu.Unlock()
+		c.evicted(e)
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	value := e.value
+	c.mu.Unlock()
+
+	return value, true
+}
+
+// Put sets the value of the key, as the most recently used, and evicts the least recently used
+// entry if the cache is full.
+func (c *LRU[K, V]) Put(key K, value V) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = c.now().Add(c.ttl)
+	}
+
+	c.mu.Lock()
+
+	var removed []*entry[K, V]
+	if elem, ok := c.entries[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		removed = append(removed, &entry[K, V]{key: e.key, value: e.value})
+		e.value = value
+		e.expires = expires
+		c.order.MoveToFront(elem)
+	} else {
+		c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+		for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+			oldest := c.order.Back()
+			c.remove(oldest)
+			removed = append(removed, oldest.Value.(*entry[K, V]))
+		}
+	}
+	c.mu.Unlock()
+
+	for _, e := range removed {
+		c.evicted(e)
+	}
+}
+
+// Delete removes the key from the cache, and reports whether it was cached.
+func (c *LRU[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return false
+	}
+	c.remove(elem)
+	c.mu.Unlock()
+
+	c.evicted(elem.Value.(*entry[K, V]))
+	return true
+}
+
+// Len returns the number of entries in the cache, including the expired entries not yet removed.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// expired reports whether the entry expired. Must be called with mutex held.
+func (c *LRU[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expires.IsZero() && !c.now().Before(e.expires)
+}
+
+// remove removes the element from the cache. Must be called with mutex held.
+func (c *LRU[K, V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*entry[K, V]).key)
+}
+
+// evicted calls the eviction callback with the entry, if any. Must be called without mutex held.
+func (c *LRU[K, V]) evicted(e *entry[K, V]) {
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
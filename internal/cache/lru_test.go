@@ -0,0 +1,129 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cache_test
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/internal/cache"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	t.Parallel()
+
+	var evicted []string
+	c := cache.NewLRU(2, 0, cache.WithOnEvict(func(key string, value int) {
+		evicted = append(evicted, key+"="+strconv.Itoa(value))
+	}))
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if _, ok := c.Get("a"); !ok { // a becomes the most recently used
+		t.Fatal("Get(a) missing")
+	}
+	c.Put("c", 3) // evicts b
+	c.Put("a", 10)
+	if !c.Delete("c") {
+		t.Error("Delete(c) = false, want true")
+	}
+	if c.Delete("c") {
+		t.Error("Delete(c) twice = true, want false")
+	}
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Get(b) found an evicted entry")
+	}
+	if got, ok := c.Get("a"); !ok || got != 10 {
+		t.Errorf("Get(a) = (%d, %t), want (10, true)", got, ok)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1", got)
+	}
+	if diff := cmp.Diff([]string{"b=2", "a=1", "c=3"}, evicted); diff != "" {
+		t.Errorf("evicted mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLRU_TTL(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	var evicted []string
+	c := cache.NewLRU(0, time.Minute, cache.WithOnEvict(func(key string, _ int) {
+		evicted = append(evicted, key)
+	}))
+	cache.SetClock(c, func() time.Time { return now })
+
+	c.Put("a", 1)
+	now = now.Add(30 * time.Second)
+	c.Put("b", 2)
+	now = now.Add(30 * time.Second)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("Get(a) found an expired entry")
+	}
+	if got, ok := c.Get("b"); !ok || got != 2 {
+		t.Errorf("Get(b) = (%d, %t), want (2, true)", got, ok)
+	}
+	if diff := cmp.Diff([]string{"a"}, evicted); diff != "" {
+		t.Errorf("evicted mismatch (-want +got):\n%s", diff)
+	}
+
+	// Putting a key again restarts its TTL.
+	c.Put("b", 3)
+	now = now.Add(45 * time.Second)
+	if got, ok := c.Get("b"); !ok || got != 3 {
+		t.Errorf("Get(b) after Put = (%d, %t), want (3, true)", got, ok)
+	}
+}
+
+func TestLRU_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	c := cache.NewLRU[int, int](64, time.Hour)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				key := (g*1000 + i) % 128
+				c.Put(key, i)
+				c.Get(key)
+				if i%10 == 0 {
+					c.Delete(key)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := c.Len(); got > 64 {
+		t.Errorf("Len() = %d, want at most 64", got)
+	}
+}
+
+func BenchmarkLRU_Get(b *testing.B) {
+	c := cache.NewLRU[int, int](1024, time.Hour)
+	for i := range 1024 {
+		c.Put(i, i)
+	}
+
+	for i := 0; b.Loop(); i++ {
+		c.Get(i % 1024)
+	}
+}
+
+func BenchmarkLRU_Put(b *testing.B) {
+	c := cache.NewLRU[int, int](1024, time.Hour)
+
+	for i := 0; b.Loop(); i++ {
+		c.Put(i%2048, i)
+	}
+}
@@ -265,6 +265,24 @@
 //		return result, nil
 //	}
 //
+// ## Streaming JSON Arrays
+//
+// Large result sets can be streamed as a JSON array with [JSONArrayWriter], which encodes each
+// element into a pooled buffer and flushes it to the writer, instead of marshaling everything at once:
+//
+//	func writeRows(w io.Writer, rows iter.Seq[Row]) error {
+//		array := pool.NewJSONArrayWriter(w)
+//		if err := array.Begin(); err != nil {
+//			return err
+//		}
+//		for row := range rows {
+//			if err := array.WriteElement(row); err != nil {
+//				return err
+//			}
+//		}
+//		return array.End() // returns the buffer to the pool
+//	}
+//
 // # Thread Safety
 //
 // All pool operations are thread-safe and can be used concurrently:
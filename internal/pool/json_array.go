@@ -0,0 +1,134 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pool
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-json-experiment/json"
+)
+
+// DefaultJSONArrayFlushSize is the buffered size from which a [JSONArrayWriter] flushes to its writer.
+const DefaultJSONArrayFlushSize = 32 << 10
+
+// JSONArrayWriter writes a JSON array to an [io.Writer] one element at a time, so that large
+// result sets are never held in memory as a whole.
+//
+// The elements are encoded into a pooled [*bytes.Buffer] that is flushed to the writer whenever
+// it grows past the flush size, and returned to [Buffer] by End or on the first error.
+// A JSONArrayWriter is not safe for concurrent use.
+type JSONArrayWriter struct {
+	w         io.Writer
+	buf       *bytes.Buffer
+	flushSize int
+	count     int
+	begun     bool
+	err       error
+}
+
+// NewJSONArrayWriter returns a new [JSONArrayWriter] writing to w, flushing every [DefaultJSONArrayFlushSize] bytes.
+func NewJSONArrayWriter(w io.Writer) *JSONArrayWriter {
+	return NewJSONArrayWriterSize(w, DefaultJSONArrayFlushSize)
+}
+
+// NewJSONArrayWriterSize returns a new [JSONArrayWriter] writing to w, flushing every flushSize bytes.
+// A flushSize of zero or less flushes after every element.
+func NewJSONArrayWriterSize(w io.Writer, flushSize int) *JSONArrayWriter {
+	return &JSONArrayWriter{
+		w:         w,
+		flushSize: flushSize,
+	}
+}
+
+// Begin starts the array. It must be called once before WriteElement.
+func (a *JSONArrayWriter) Begin() error {
+	if a.err != nil {
+		return a.err
+	}
+	if a.begun {
+		return errors.New("json array already begun")
+	}
+
+	a.begun = true
+	a.buf = Buffer.Get()
+	a.buf.WriteByte('[')
+	return nil
+}
+
+// WriteElement encodes v as the next element of the array.
+//
+// An element that fails to encode is not written and the array stays valid, so the caller may
+// skip it and continue. Errors writing to the underlying writer are permanent.
+func (a *JSONArrayWriter) WriteElement(v any) error {
+	if a.err != nil {
+		return a.err
+	}
+	if !a.begun || a.buf == nil {
+		return errors.New("json array not begun")
+	}
+
+	mark := a.buf.Len()
+	if a.count > 0 {
+		a.buf.WriteByte(',')
+	}
+	if err := json.MarshalWrite(a.buf, v, json.DefaultOptionsV2()); err != nil {
+		a.buf.Truncate(mark)
+		return fmt.Errorf("encode json array element %d: %w", a.count, err)
+	}
+	a.count++
+
+	if a.buf.Len() >= a.flushSize {
+		return a.flush()
+	}
+	return nil
+}
+
+// End closes the array, flushes the remaining bytes and returns the buffer to the pool.
+func (a *JSONArrayWriter) End() error {
+	if a.err != nil {
+		return a.err
+	}
+	if !a.begun || a.buf == nil {
+		return errors.New("json array not begun")
+	}
+
+	a.buf.WriteByte(']')
+	err := a.flush()
+	a.release()
+	if err != nil {
+		return err
+	}
+
+	a.err = errors.New("json array already ended")
+	return nil
+}
+
+// Len returns the number of elements written so far.
+func (a *JSONArrayWriter) Len() int {
+	return a.count
+}
+
+// flush writes the buffered bytes to the writer.
+func (a *JSONArrayWriter) flush() error {
+	if _, err := a.w.Write(a.buf.Bytes()); err != nil {
+		a.err = fmt.Errorf("write json array: %w", err)
+		a.release()
+		return a.err
+	}
+	a.buf.Reset()
+	return nil
+}
+
+// release returns the buffer to the pool.
+func (a *JSONArrayWriter) release() {
+	if a.buf == nil {
+		return
+	}
+	a.buf.Reset()
+	Buffer.Put(a.buf)
+	a.buf = nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pool_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/go-json-experiment/json/jsontext"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/internal/pool"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestJSONArrayWriter(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		elements  []any
+		flushSize int
+		want      string
+	}{
+		"empty": {
+			want: `[]`,
+		},
+		"escaping": {
+			elements:  []any{"a \"quoted\" <tag>", map[string]any{"k": "line\nbreak"}, 1.5, nil},
+			flushSize: pool.DefaultJSONArrayFlushSize,
+			want:      `["a \"quoted\" <tag>",{"k":"line\nbreak"},1.5,null]`,
+		},
+		"flush every element": {
+			elements: []any{1, 2, 3},
+			want:     `[1,2,3]`,
+		},
+		"skips unencodable element": {
+			elements:  []any{1, func() {}, 2},
+			flushSize: 4,
+			want:      `[1,2]`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			a := pool.NewJSONArrayWriterSize(&out, tt.flushSize)
+			if err := a.Begin(); err != nil {
+				t.Fatalf("Begin() error = %v", err)
+			}
+			for _, v := range tt.elements {
+				_ = a.WriteElement(v)
+			}
+			if err := a.End(); err != nil {
+				t.Fatalf("End() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, out.String()); diff != "" {
+				t.Errorf("output mismatch (-want +got):\n%s", diff)
+			}
+			if !jsontext.Value(out.Bytes()).IsValid() {
+				t.Errorf("output %q is not valid JSON", out.String())
+			}
+			if err := a.WriteElement(1); err == nil {
+				t.Error("WriteElement() after End() succeeded")
+			}
+		})
+	}
+}
+
+func TestJSONArrayWriter_WriteError(t *testing.T) {
+	t.Parallel()
+
+	a := pool.NewJSONArrayWriterSize(failingWriter{}, 0)
+	if err := a.Begin(); err != nil {
+		t.Fatalf("Begin() error = %v", err)
+	}
+	if err := a.WriteElement("x"); err == nil {
+		t.Fatal("WriteElement() error = nil, want the write error")
+	}
+	if err := a.End(); err == nil {
+		t.Error("End() error = nil, want the sticky write error")
+	}
+}
+
+func TestJSONArrayWriter_NotBegun(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	a := pool.NewJSONArrayWriter(&out)
+	if err := a.WriteElement(1); err == nil {
+		t.Error("WriteElement() before Begin() succeeded")
+	}
+	if err := a.End(); err == nil {
+		t.Error("End() before Begin() succeeded")
+	}
+}
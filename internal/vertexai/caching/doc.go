@@ -0,0 +1,32 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package caching provides Vertex AI context caching with automatic reuse of equivalent caches.
+//
+// A cached content holds a large prompt prefix, such as documents or a long system instruction,
+// that later requests reference by name instead of sending it again. Tracking the cache names by
+// hand is error prone: a caller that loses the name creates a fresh cache on every request.
+//
+// [Service.GetOrCreateCache] derives a stable key from the model, the contents and the cached
+// configuration, and returns the existing cache with that key after refreshing its TTL, or
+// creates a new one:
+//
+//	service, err := caching.NewService(ctx, "my-project", "us-central1")
+//	if err != nil {
+//		return err
+//	}
+//	defer service.Close()
+//
+//	entry, err := service.GetOrCreateCache(ctx, contents, &caching.CacheConfig{
+//		Model:             "gemini-2.0-flash-001",
+//		SystemInstruction: systemInstruction,
+//		TTL:               30 * time.Minute,
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	// use entry.Name as the cached content of the generation requests
+//
+// The key is stored in the display name of the cache, and [CacheKey] exposes it to callers
+// that keep their own index.
+package caching
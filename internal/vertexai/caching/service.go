@@ -0,0 +1,286 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package caching
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"log/slog"
+	"strings"
+	"time"
+
+	aiplatform "cloud.google.com/go/aiplatform/apiv1beta1"
+	"cloud.google.com/go/aiplatform/apiv1beta1/aiplatformpb"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/go-a2a/adk-go/pkg/logging"
+)
+
+// DefaultTTL is the time to live of a cache when [CacheConfig.TTL] is not set.
+const DefaultTTL = time.Hour
+
+// displayNamePrefix prefixes the key in the display name of the caches managed by [Service.GetOrCreateCache].
+const displayNamePrefix = "adk-cache-"
+
+// CacheConfig is the configuration of a cached content.
+type CacheConfig struct {
+	// Model is the model the cache is used with, either a model ID such as "gemini-2.0-flash-001"
+	// or a full publisher model resource name.
+	Model string
+
+	// SystemInstruction is the cached system instruction.
+	SystemInstruction *aiplatformpb.Content
+
+	// Tools are the cached tool declarations.
+	Tools []*aiplatformpb.Tool
+
+	// ToolConfig is the cached tool configuration.
+	ToolConfig *aiplatformpb.ToolConfig
+
+	// TTL is the time to live of the cache, set on creation and on every reuse.
+	// It is not part of the cache key. Defaults to [DefaultTTL].
+	TTL time.Duration
+}
+
+// CacheEntry is a cached content returned by [Service.GetOrCreateCache].
+type CacheEntry struct {
+	*aiplatformpb.CachedContent
+
+	// Key is the key derived from the contents and configuration, see [CacheKey].
+	Key string
+
+	// Created reports whether the cache was created rather than reused.
+	Created bool
+}
+
+// Service manages Vertex AI cached contents.
+type Service struct {
+	client    *aiplatform.GenAiCacheClient
+	projectID string
+	location  string
+	logger    *slog.Logger
+
+	// group deduplicates concurrent calls for the same key, which would otherwise create duplicate caches.
+	group singleflight.Group
+}
+
+// NewService creates a new caching service.
+func NewService(ctx context.Context, projectID, location string, opts ...option.ClientOption) (*Service, error) {
+	client, err := aiplatform.NewGenAiCacheClient(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("create GenAI cache client: %w", err)
+	}
+
+	service := &Service{
+		client:    client,
+		projectID: projectID,
+		location:  location,
+		logger:    logging.FromContext(ctx),
+	}
+	service.logger.InfoContext(ctx, "GenAI cache service initialized successfully",
+		slog.String("project_id", projectID),
+		slog.String("location", location),
+	)
+
+	return service, nil
+}
+
+// Client returns the underlying GenAI cache client.
+func (s *Service) Client() *aiplatform.GenAiCacheClient {
+	return s.client
+}
+
+// Close closes the service and releases any resources.
+func (s *Service) Close() error {
+	return s.client.Close()
+}
+
+// CacheKey returns the stable key of the contents and configuration.
+//
+// Two calls with equal model, system instruction, contents, tools and tool configuration return
+// the same key, whatever the TTL.
+func CacheKey(contents []*aiplatformpb.Content, config *CacheConfig) (string, error) {
+	if config == nil {
+		config = new(CacheConfig)
+	}
+
+	h := sha256.New()
+	writeField(h, []byte(modelID(config.Model)))
+	if err := writeMessage(h, config.SystemInstruction); err != nil {
+		return "", fmt.Errorf("hash system instruction: %w", err)
+	}
+	writeLen(h, len(contents))
+	for i, content := range contents {
+		if err := writeMessage(h, content); err != nil {
+			return "", fmt.Errorf("hash content %d: %w", i, err)
+		}
+	}
+	writeLen(h, len(config.Tools))
+	for i, tool := range config.Tools {
+		if err := writeMessage(h, tool); err != nil {
+			return "", fmt.Errorf("hash tool %d: %w", i, err)
+		}
+	}
+	if err := writeMessage(h, config.ToolConfig); err != nil {
+		return "", fmt.Errorf("hash tool config: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GetOrCreateCache returns the cache of the contents and configuration, creating it only if no
+// unexpired cache with the same [CacheKey] exists.
+//
+// The TTL of a reused cache is refreshed to [CacheConfig.TTL].
+func (s *Service) GetOrCreateCache(ctx context.Context, contents []*aiplatformpb.Content, config *CacheConfig) (*CacheEntry, error) {
+	if config == nil || config.Model == "" {
+		return nil, errors.New("model is required")
+	}
+	if len(contents) == 0 && config.SystemInstruction == nil {
+		return nil, errors.New("contents or system instruction is required")
+	}
+
+	key, err := CacheKey(contents, config)
+	if err != nil {
+		return nil, fmt.Errorf("derive cache key: %w", err)
+	}
+
+	v, err, _ := s.group.Do(key, func() (any, error) {
+		return s.getOrCreateCache(ctx, key, contents, config)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*CacheEntry), nil
+}
+
+// getOrCreateCache reuses the cache with the key or creates it.
+func (s *Service) getOrCreateCache(ctx context.Context, key string, contents []*aiplatformpb.Content, config *CacheConfig) (*CacheEntry, error) {
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	existing, err := s.findCache(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		refreshed, err := s.client.UpdateCachedContent(ctx, &aiplatformpb.UpdateCachedContentRequest{
+			CachedContent: &aiplatformpb.CachedContent{
+				Name:       existing.GetName(),
+				Expiration: &aiplatformpb.CachedContent_Ttl{Ttl: durationpb.New(ttl)},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"ttl"}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("refresh TTL of cached content %s: %w", existing.GetName(), err)
+		}
+		s.logger.DebugContext(ctx, "reused cached content",
+			slog.String("name", refreshed.GetName()),
+			slog.String("key", key),
+		)
+		return &CacheEntry{CachedContent: refreshed, Key: key}, nil
+	}
+
+	created, err := s.client.CreateCachedContent(ctx, &aiplatformpb.CreateCachedContentRequest{
+		Parent: s.parent(),
+		CachedContent: &aiplatformpb.CachedContent{
+			DisplayName:       displayNamePrefix + key,
+			Model:             s.modelName(config.Model),
+			SystemInstruction: config.SystemInstruction,
+			Contents:          contents,
+			Tools:             config.Tools,
+			ToolConfig:        config.ToolConfig,
+			Expiration:        &aiplatformpb.CachedContent_Ttl{Ttl: durationpb.New(ttl)},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create cached content: %w", err)
+	}
+	s.logger.InfoContext(ctx, "created cached content",
+		slog.String("name", created.GetName()),
+		slog.String("key", key),
+	)
+
+	return &CacheEntry{CachedContent: created, Key: key, Created: true}, nil
+}
+
+// findCache returns the unexpired cache with the key, or nil if there is none.
+func (s *Service) findCache(ctx context.Context, key string) (*aiplatformpb.CachedContent, error) {
+	it := s.client.ListCachedContents(ctx, &aiplatformpb.ListCachedContentsRequest{
+		Parent: s.parent(),
+	})
+	now := time.Now()
+	for {
+		cache, err := it.Next()
+		if err == iterator.Done {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("list cached contents: %w", err)
+		}
+		if cache.GetDisplayName() != displayNamePrefix+key {
+			continue
+		}
+		if expireTime := cache.GetExpireTime(); expireTime != nil && !expireTime.AsTime().After(now) {
+			continue
+		}
+		return cache, nil
+	}
+}
+
+// parent returns the resource name of the location of the caches.
+func (s *Service) parent() string {
+	return fmt.Sprintf("projects/%s/locations/%s", s.projectID, s.location)
+}
+
+// modelName returns the publisher model resource name of the model.
+func (s *Service) modelName(model string) string {
+	if strings.HasPrefix(model, "projects/") {
+		return model
+	}
+	return fmt.Sprintf("%s/publishers/google/models/%s", s.parent(), modelID(model))
+}
+
+// modelID returns the model ID of a model ID or resource name, so that both forms derive the same key.
+func modelID(model string) string {
+	if i := strings.LastIndex(model, "/models/"); i >= 0 {
+		return model[i+len("/models/"):]
+	}
+	return strings.TrimPrefix(model, "models/")
+}
+
+// writeMessage writes the deterministic encoding of the message to the hash.
+func writeMessage(h hash.Hash, m proto.Message) error {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	writeField(h, b)
+	return nil
+}
+
+// writeField writes the length prefixed bytes to the hash, so that adjacent fields cannot collide.
+func writeField(h hash.Hash, b []byte) {
+	writeLen(h, len(b))
+	h.Write(b)
+}
+
+// writeLen writes the length to the hash.
+func writeLen(h hash.Hash, n int) {
+	var buf [binary.MaxVarintLen64]byte
+	h.Write(buf[:binary.PutUvarint(buf[:], uint64(n))])
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package caching_test
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/aiplatform/apiv1beta1/aiplatformpb"
+
+	"github.com/go-a2a/adk-go/internal/vertexai/caching"
+)
+
+func textContent(role, text string) *aiplatformpb.Content {
+	return &aiplatformpb.Content{
+		Role: role,
+		Parts: []*aiplatformpb.Part{
+			{Data: &aiplatformpb.Part_Text{Text: text}},
+		},
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	contents := []*aiplatformpb.Content{textContent("user", "a long document")}
+	base := &caching.CacheConfig{
+		Model:             "gemini-2.0-flash-001",
+		SystemInstruction: textContent("system", "answer from the document"),
+		TTL:               time.Hour,
+	}
+
+	key, err := caching.CacheKey(contents, base)
+	if err != nil {
+		t.Fatalf("CacheKey() error = %v", err)
+	}
+	if len(key) != 64 {
+		t.Errorf("CacheKey() = %q, want a hex SHA-256", key)
+	}
+
+	tests := map[string]struct {
+		contents []*aiplatformpb.Content
+		config   *caching.CacheConfig
+		wantSame bool
+	}{
+		"equal copy": {
+			contents: []*aiplatformpb.Content{textContent("user", "a long document")},
+			config:   &caching.CacheConfig{Model: base.Model, SystemInstruction: textContent("system", "answer from the document"), TTL: time.Hour},
+			wantSame: true,
+		},
+		"different TTL": {
+			contents: contents,
+			config:   &caching.CacheConfig{Model: base.Model, SystemInstruction: base.SystemInstruction, TTL: time.Minute},
+			wantSame: true,
+		},
+		"model resource name": {
+			contents: contents,
+			config: &caching.CacheConfig{
+				Model:             "projects/p/locations/us-central1/publishers/google/models/gemini-2.0-flash-001",
+				SystemInstruction: base.SystemInstruction,
+			},
+			wantSame: true,
+		},
+		"different model": {
+			contents: contents,
+			config:   &caching.CacheConfig{Model: "gemini-2.5-pro", SystemInstruction: base.SystemInstruction},
+		},
+		"different contents": {
+			contents: []*aiplatformpb.Content{textContent("user", "another document")},
+			config:   base,
+		},
+		"split contents": {
+			contents: []*aiplatformpb.Content{textContent("user", "a long"), textContent("user", " document")},
+			config:   base,
+		},
+		"without system instruction": {
+			contents: contents,
+			config:   &caching.CacheConfig{Model: base.Model},
+		},
+		"with tools": {
+			contents: contents,
+			config: &caching.CacheConfig{
+				Model:             base.Model,
+				SystemInstruction: base.SystemInstruction,
+				Tools: []*aiplatformpb.Tool{{
+					FunctionDeclarations: []*aiplatformpb.FunctionDeclaration{{Name: "search"}},
+				}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := caching.CacheKey(tt.contents, tt.config)
+			if err != nil {
+				t.Fatalf("CacheKey() error = %v", err)
+			}
+			if same := got == key; same != tt.wantSame {
+				t.Errorf("CacheKey() same key = %t, want %t", same, tt.wantSame)
+			}
+		})
+	}
+}
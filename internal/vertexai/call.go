@@ -0,0 +1,135 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vertexai
+
+import (
+	"context"
+	"errors"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/option/internaloption"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-a2a/adk-go/pkg/backoff"
+)
+
+type callRetryOption struct {
+	*internaloption.EmbeddableAdapter
+	policy backoff.Policy
+}
+
+func (o callRetryOption) apply(c *Client) {
+	c.callRetry = &o.policy
+}
+
+// WithCallRetry retries the calls of every service of the client failing with a transient error,
+// with the delays and the attempts of the policy, see [IsRetryableCall].
+//
+// Only the read methods, such as Get, List or GenerateContent, are retried on any transient error.
+// The other methods may change a resource, such as Create, Delete or ImportRagFiles, and may
+// already have been run by the service when the attempt failed: they are only retried when their
+// quota is exhausted, the service rejecting them before running them.
+func WithCallRetry(policy backoff.Policy) option.ClientOption {
+	return callRetryOption{policy: policy}
+}
+
+type callTimeoutOption struct {
+	*internaloption.EmbeddableAdapter
+	timeout time.Duration
+}
+
+func (o callTimeoutOption) apply(c *Client) {
+	c.callTimeout = o.timeout
+}
+
+// WithCallTimeout bounds each attempt of the calls of every service of the client to the timeout,
+// within the deadline of the context of the call. Zero or less means no timeout.
+func WithCallTimeout(timeout time.Duration) option.ClientOption {
+	return callTimeoutOption{timeout: timeout}
+}
+
+// IsRetryableCall reports whether a failed call is retried by [WithCallRetry]: the service is
+// unavailable, its quota is exhausted, or the attempt ran out of the time set by [WithCallTimeout].
+// Any other error, such as an invalid argument, fails the call.
+func IsRetryableCall(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return errors.Is(err, errCallTimeout)
+	}
+}
+
+// readMethodPrefixes are the prefixes of the names of the methods that change no resource, whose
+// calls can safely be run twice.
+var readMethodPrefixes = []string{
+	"Get", "List", "Count", "Search", "Retrieve", "Read",
+	"Generate", "Predict", "Embed", "Compute", "Explain",
+}
+
+// isReadMethod reports whether the full method name of the call, such as
+// "/google.cloud.aiplatform.v1.VertexRagDataService/GetRagCorpus", is of a read method.
+func isReadMethod(method string) bool {
+	name := path.Base(method)
+	return slices.ContainsFunc(readMethodPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+// isRejectedCall reports whether a failed call was rejected by the service without being run, so
+// that it may be retried even if it changes a resource.
+func isRejectedCall(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// errCallTimeout is the cause of the context of an attempt out of the time set by [WithCallTimeout].
+var errCallTimeout = errors.New("vertexai: call attempt timed out")
+
+// callOptions returns the client options applying the retry policy and the timeout of the client to
+// the calls of its services, if any.
+func (c *Client) callOptions() []option.ClientOption {
+	if c.callRetry == nil && c.callTimeout <= 0 {
+		return nil
+	}
+	return []option.ClientOption{
+		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(callInterceptor(c.callRetry, c.callTimeout))),
+	}
+}
+
+// callInterceptor returns the interceptor of the unary calls retrying them with the policy, unless
+// nil, and bounding each attempt to the timeout, unless zero or less. The calls of the methods
+// changing a resource are only retried when rejected, see [WithCallRetry].
+func callInterceptor(policy *backoff.Policy, timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		attempt := func() error {
+			actx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				actx, cancel = context.WithTimeoutCause(ctx, timeout, errCallTimeout)
+				defer cancel()
+			}
+			err := invoker(actx, method, req, reply, cc, opts...)
+			// The attempt out of its own time, rather than of the time of the call, may be retried.
+			if err != nil && ctx.Err() == nil && errors.Is(context.Cause(actx), errCallTimeout) {
+				return errors.Join(errCallTimeout, err)
+			}
+			return err
+		}
+
+		if policy == nil {
+			return attempt()
+		}
+		retryable := IsRetryableCall
+		if !isReadMethod(method) {
+			retryable = isRejectedCall
+		}
+		return backoff.Retry(ctx, *policy, attempt, retryable)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vertexai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-a2a/adk-go/pkg/backoff"
+)
+
+func TestCallInterceptor(t *testing.T) {
+	t.Parallel()
+
+	noSleep := func(ctx context.Context, _ time.Duration) error { return ctx.Err() }
+	policy := &backoff.Policy{MaxAttempts: 3, Sleep: noSleep}
+
+	tests := map[string]struct {
+		method       string
+		policy       *backoff.Policy
+		timeout      time.Duration
+		errs         []error
+		wantCode     codes.Code
+		wantAttempts int
+	}{
+		"retried until success": {
+			policy:       policy,
+			errs:         []error{status.Error(codes.Unavailable, "503"), status.Error(codes.ResourceExhausted, "quota")},
+			wantCode:     codes.OK,
+			wantAttempts: 3,
+		},
+		"invalid argument not retried": {
+			policy:       policy,
+			errs:         []error{status.Error(codes.InvalidArgument, "bad")},
+			wantCode:     codes.InvalidArgument,
+			wantAttempts: 1,
+		},
+		"out of attempts": {
+			policy:       policy,
+			errs:         []error{status.Error(codes.Unavailable, "1"), status.Error(codes.Unavailable, "2"), status.Error(codes.Unavailable, "3")},
+			wantCode:     codes.Unavailable,
+			wantAttempts: 3,
+		},
+		"no policy": {
+			errs:         []error{status.Error(codes.Unavailable, "503")},
+			wantCode:     codes.Unavailable,
+			wantAttempts: 1,
+		},
+		"timed out attempt retried": {
+			policy:       policy,
+			timeout:      time.Millisecond,
+			errs:         []error{context.DeadlineExceeded},
+			wantCode:     codes.OK,
+			wantAttempts: 2,
+		},
+		"timed out mutation not retried": {
+			method:       "/google.cloud.aiplatform.v1.VertexRagDataService/CreateRagCorpus",
+			policy:       policy,
+			timeout:      time.Millisecond,
+			errs:         []error{context.DeadlineExceeded},
+			wantCode:     codes.DeadlineExceeded,
+			wantAttempts: 1,
+		},
+		"unavailable mutation not retried": {
+			method:       "/google.cloud.aiplatform.v1.VertexRagDataService/DeleteRagFile",
+			policy:       policy,
+			errs:         []error{status.Error(codes.Unavailable, "503")},
+			wantCode:     codes.Unavailable,
+			wantAttempts: 1,
+		},
+		"rejected mutation retried": {
+			method:       "/google.cloud.aiplatform.v1.VertexRagDataService/ImportRagFiles",
+			policy:       policy,
+			errs:         []error{status.Error(codes.ResourceExhausted, "quota")},
+			wantCode:     codes.OK,
+			wantAttempts: 2,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			attempts := 0
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				attempts++
+				if attempts > len(tt.errs) {
+					return nil
+				}
+				if errors.Is(tt.errs[attempts-1], context.DeadlineExceeded) {
+					<-ctx.Done()
+					return status.FromContextError(ctx.Err()).Err()
+				}
+				return tt.errs[attempts-1]
+			}
+
+			method := tt.method
+			if method == "" {
+				method = "/google.cloud.aiplatform.v1.VertexRagDataService/GetRagCorpus"
+			}
+			err := callInterceptor(tt.policy, tt.timeout)(t.Context(), method, nil, nil, nil, invoker)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("call error = %v, want code %v", err, tt.wantCode)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("call made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestCallInterceptor_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	attempts := 0
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+		cancel()
+		return status.Error(codes.Unavailable, "503")
+	}
+
+	err := callInterceptor(&backoff.Policy{MaxAttempts: 5}, 0)(ctx, "/google.cloud.aiplatform.v1.VertexRagDataService/GetRagCorpus", nil, nil, nil, invoker)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("call error = %v, want %v", err, context.Canceled)
+	}
+	if attempts != 1 {
+		t.Errorf("call made %d attempts after the context was done, want 1", attempts)
+	}
+}
+
+func TestClient_CallOptions(t *testing.T) {
+	t.Parallel()
+
+	c := &Client{}
+	if opts := c.callOptions(); opts != nil {
+		t.Errorf("callOptions() without policy = %v, want nil", opts)
+	}
+	for _, opt := range []any{WithCallRetry(backoff.Policy{}), WithCallTimeout(time.Second)} {
+		opt.(ClientOption).apply(c)
+	}
+	if c.callRetry == nil || c.callTimeout != time.Second {
+		t.Errorf("client call policy = (%v, %v), want the options applied", c.callRetry, c.callTimeout)
+	}
+	if opts := c.callOptions(); len(opts) != 1 {
+		t.Errorf("callOptions() = %d options, want 1", len(opts))
+	}
+}
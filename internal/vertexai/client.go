@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	aiplatform "cloud.google.com/go/aiplatform/apiv1beta1"
 	"cloud.google.com/go/auth/credentials"
@@ -20,10 +21,12 @@ import (
 	"google.golang.org/api/option"
 	"google.golang.org/api/option/internaloption"
 
+	"github.com/go-a2a/adk-go/internal/vertexai/caching"
 	"github.com/go-a2a/adk-go/internal/vertexai/extension"
 	"github.com/go-a2a/adk-go/internal/vertexai/generativemodel"
 	"github.com/go-a2a/adk-go/internal/vertexai/preview/rag"
 	"github.com/go-a2a/adk-go/internal/vertexai/prompt"
+	"github.com/go-a2a/adk-go/pkg/backoff"
 	"github.com/go-a2a/adk-go/pkg/logging"
 )
 
@@ -42,8 +45,12 @@ type Client struct {
 	loggerProvider log.LoggerProvider
 	logger         *slog.Logger
 
+	// call policy of the services, see WithCallRetry and WithCallTimeout
+	callRetry   *backoff.Policy
+	callTimeout time.Duration
+
 	// Core services
-	cacheClient        *aiplatform.GenAiCacheClient
+	cacheService       *caching.Service
 	exampleStoreClient *aiplatform.ExampleStoreClient
 	generativeService  generativemodel.Service
 	modelGardenClient  *aiplatform.ModelGardenClient
@@ -142,6 +149,7 @@ func NewClient(ctx context.Context, projectID, location string, options ...optio
 		client.logger = logger
 	}
 	copts = append(copts, option.WithLogger(logger))
+	copts = append(copts, client.callOptions()...)
 	ctx = logging.NewContext(ctx, logger)
 
 	// Create credentials
@@ -153,16 +161,12 @@ func NewClient(ctx context.Context, projectID, location string, options ...optio
 	}
 	copts = append(copts, option.WithAuthCredentials(creds))
 
-	// Initialize GenAI cache service client
-	cacheClient, err := aiplatform.NewGenAiCacheClient(ctx, copts...)
+	// Initialize GenAI cache service
+	cacheService, err := caching.NewService(ctx, projectID, location, copts...)
 	if err != nil {
 		return nil, fmt.Errorf("initialize GenAI cache service: %w", err)
 	}
-	client.logger.InfoContext(ctx, "GenAI cache service initialized successfully",
-		slog.String("project_id", projectID),
-		slog.String("location", location),
-	)
-	client.cacheClient = cacheClient
+	client.cacheService = cacheService
 
 	// Initialize example store service client
 	exampleStoreClient, err := aiplatform.NewExampleStoreClient(ctx, copts...)
@@ -262,7 +266,7 @@ func NewClient(ctx context.Context, projectID, location string, options ...optio
 func (c *Client) Close() error {
 	c.logger.Info("Closing Vertex AI client")
 
-	if err := c.cacheClient.Close(); err != nil {
+	if err := c.cacheService.Close(); err != nil {
 		c.logger.Error("close caching service", slog.String("error", err.Error()))
 		return fmt.Errorf("close caching service: %w", err)
 	}
@@ -343,8 +347,9 @@ func (c *Client) GetLogger() *slog.Logger {
 //
 // The content caching service provides optimized caching for large content
 // contexts, reducing token usage and improving performance for repeated queries.
-func (c *Client) Cache() *aiplatform.GenAiCacheClient {
-	return c.cacheClient
+// Equivalent caches are reused through [caching.Service.GetOrCreateCache].
+func (c *Client) Cache() *caching.Service {
+	return c.cacheService
 }
 
 // ExampleStore returns the example store service.
@@ -422,7 +427,7 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	// TODO(zchee): In a full implementation, you would perform actual health checks
 	// against each service. For now, we just verify the services are initialized.
 
-	if c.cacheClient == nil {
+	if c.cacheService == nil {
 		return fmt.Errorf("content caching service not initialized")
 	}
 
@@ -470,7 +475,7 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 func (c *Client) GetServiceStatus() map[string]string {
 	status := make(map[string]string)
 
-	if c.cacheClient != nil {
+	if c.cacheService != nil {
 		status["cache"] = "initialized"
 	} else {
 		status["cache"] = "not_initialized"
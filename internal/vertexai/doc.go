@@ -46,8 +46,8 @@
 //	corpus, err := ragClient.CreateDefaultCorpus(ctx, "My Corpus", "Description")
 //
 //	// Content caching
-//	cacheService := client.ContentCaching()
-//	cache, err := cacheService.CreateCache(ctx, content, cacheConfig)
+//	cacheService := client.Cache()
+//	cache, err := cacheService.GetOrCreateCache(ctx, contents, cacheConfig)
 //
 //	// Enhanced generative models
 //	genService := client.GenerativeModels()
@@ -78,6 +78,23 @@
 // All operations return Go-idiomatic errors with detailed context. Preview features
 // may have additional error conditions related to experimental functionality.
 //
+// # Retries and Timeouts
+//
+// [WithCallRetry] and [WithCallTimeout] apply uniformly to the calls of every service of the
+// client. A call failing with a transient error, an unavailable service or an exhausted quota, is
+// retried with the backoff of the policy, while an invalid argument fails at once; each attempt is
+// bounded by the timeout, and the retries stop when the context of the call is done. A method
+// changing a resource, such as a Create, a Delete or an import, may have been run by the service
+// when its attempt failed or timed out, so it is only retried when its quota is exhausted:
+//
+//	client, err := vertexai.NewClient(ctx, "my-project", "us-central1",
+//		vertexai.WithCallRetry(backoff.Policy{
+//			Backoff:     backoff.Backoff{Base: 200 * time.Millisecond, Max: 5 * time.Second, Jitter: 0.2},
+//			MaxAttempts: 5,
+//		}),
+//		vertexai.WithCallTimeout(30*time.Second),
+//	)
+//
 // # Authentication
 //
 // The package uses Google Cloud authentication via Application Default Credentials (ADC).
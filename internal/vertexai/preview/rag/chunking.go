@@ -0,0 +1,126 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rag
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/aiplatform/apiv1beta1/aiplatformpb"
+)
+
+// ChunkingStrategy is the strategy splitting the imported files into chunks.
+type ChunkingStrategy string
+
+const (
+	// ChunkingStrategyFixedLength splits the files into chunks of a fixed size. It is the default.
+	ChunkingStrategyFixedLength ChunkingStrategy = "fixed_length"
+
+	// ChunkingStrategySentence splits the files at sentence boundaries, into chunks of at most
+	// the chunk size.
+	//
+	// It is not supported by the Vertex RAG API yet, so that [ChunkingConfig.Validate] rejects it.
+	ChunkingStrategySentence ChunkingStrategy = "sentence"
+)
+
+// TokenizerEmbeddingModel is the tokenizer of the embedding model of the corpus, which the Vertex
+// RAG API counts the tokens with.
+const TokenizerEmbeddingModel = "embedding_model"
+
+// charsPerToken is the number of characters per token assumed when converting the character
+// sizes of a chunking config to the token sizes of the Vertex RAG API.
+const charsPerToken = 4
+
+// ChunkingConfig configures how the imported files are split into chunks.
+type ChunkingConfig struct {
+	// Strategy is the chunking strategy, [ChunkingStrategyFixedLength] if empty.
+	Strategy ChunkingStrategy `json:"strategy,omitempty"`
+
+	// ChunkSize is the size of the chunks, in tokens if TokenBased is set and in characters otherwise.
+	ChunkSize int32 `json:"chunk_size,omitempty"`
+
+	// Overlap is the size of the overlap between consecutive chunks, in the unit of ChunkSize.
+	// It must be less than ChunkSize.
+	Overlap int32 `json:"overlap,omitempty"`
+
+	// TokenBased sets whether the sizes are measured in tokens rather than characters.
+	TokenBased bool `json:"token_based,omitempty"`
+
+	// Tokenizer names the tokenizer counting the tokens, required when TokenBased is set. The Vertex
+	// RAG API only counts the tokens with [TokenizerEmbeddingModel].
+	Tokenizer string `json:"tokenizer,omitempty"`
+}
+
+// Validate returns an error if the chunking config is not valid.
+func (c *ChunkingConfig) Validate() error {
+	switch c.Strategy {
+	case "", ChunkingStrategyFixedLength:
+	case ChunkingStrategySentence:
+		return fmt.Errorf("chunking strategy %q is not supported by the Vertex RAG API", c.Strategy)
+	default:
+		return fmt.Errorf("unknown chunking strategy %q", c.Strategy)
+	}
+	if c.ChunkSize <= 0 {
+		return fmt.Errorf("chunk size must be positive, got %d", c.ChunkSize)
+	}
+	if c.Overlap < 0 || c.Overlap >= c.ChunkSize {
+		return fmt.Errorf("chunk overlap must be in [0, %d), got %d", c.ChunkSize, c.Overlap)
+	}
+	if c.TokenBased {
+		switch c.Tokenizer {
+		case "":
+			return errors.New("token-based chunking requires a tokenizer")
+		case TokenizerEmbeddingModel:
+		default:
+			return fmt.Errorf("tokenizer %q is not supported by the Vertex RAG API, want %q", c.Tokenizer, TokenizerEmbeddingModel)
+		}
+	}
+	return nil
+}
+
+// chunkingConfig returns the chunking config of the import, Chunking if set and otherwise the
+// fixed-length chunking in tokens set by ChunkSize and ChunkOverlap, or nil if neither is set.
+func (c *ImportFilesConfig) chunkingConfig() *ChunkingConfig {
+	if c.Chunking != nil {
+		return c.Chunking
+	}
+	if c.ChunkSize == 0 && c.ChunkOverlap == 0 {
+		return nil
+	}
+	return &ChunkingConfig{
+		ChunkSize:  c.ChunkSize,
+		Overlap:    c.ChunkOverlap,
+		TokenBased: true,
+		Tokenizer:  TokenizerEmbeddingModel,
+	}
+}
+
+// toTransformationConfig converts the chunking config to the transformation config of the
+// Vertex RAG API.
+//
+// The Vertex RAG API only supports the fixed-length chunking, with sizes measured in tokens of the
+// embedding model of the corpus ([TokenizerEmbeddingModel]), so that the character sizes are converted to tokens assuming
+// [charsPerToken] characters per token.
+func (c *ChunkingConfig) toTransformationConfig() (*aiplatformpb.RagFileTransformationConfig, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	size, overlap := c.ChunkSize, c.Overlap
+	if !c.TokenBased {
+		size = max(size/charsPerToken, 1)
+		overlap = min(overlap/charsPerToken, size-1)
+	}
+
+	return &aiplatformpb.RagFileTransformationConfig{
+		RagFileChunkingConfig: &aiplatformpb.RagFileChunkingConfig{
+			ChunkingConfig: &aiplatformpb.RagFileChunkingConfig_FixedLengthChunking_{
+				FixedLengthChunking: &aiplatformpb.RagFileChunkingConfig_FixedLengthChunking{
+					ChunkSize:    size,
+					ChunkOverlap: overlap,
+				},
+			},
+		},
+	}, nil
+}
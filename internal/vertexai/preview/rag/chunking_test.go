@@ -0,0 +1,117 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rag_test
+
+import (
+	"testing"
+
+	"github.com/go-a2a/adk-go/internal/vertexai/preview/rag"
+)
+
+func TestChunkingConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config  rag.ChunkingConfig
+		wantErr bool
+	}{
+		"fixed length": {
+			config: rag.ChunkingConfig{ChunkSize: 1000, Overlap: 100},
+		},
+		"token based": {
+			config: rag.ChunkingConfig{Strategy: rag.ChunkingStrategyFixedLength, ChunkSize: 256, Overlap: 32, TokenBased: true, Tokenizer: rag.TokenizerEmbeddingModel},
+		},
+		"sentence not supported": {
+			config:  rag.ChunkingConfig{Strategy: rag.ChunkingStrategySentence, ChunkSize: 256, Overlap: 32, TokenBased: true, Tokenizer: "cl100k_base"},
+			wantErr: true,
+		},
+		"overlap not less than size": {
+			config:  rag.ChunkingConfig{ChunkSize: 100, Overlap: 100},
+			wantErr: true,
+		},
+		"negative overlap": {
+			config:  rag.ChunkingConfig{ChunkSize: 100, Overlap: -1},
+			wantErr: true,
+		},
+		"no size": {
+			config:  rag.ChunkingConfig{},
+			wantErr: true,
+		},
+		"token based without tokenizer": {
+			config:  rag.ChunkingConfig{ChunkSize: 256, TokenBased: true},
+			wantErr: true,
+		},
+		"token based with an unsupported tokenizer": {
+			config:  rag.ChunkingConfig{ChunkSize: 256, TokenBased: true, Tokenizer: "cl100k_base"},
+			wantErr: true,
+		},
+		"unknown strategy": {
+			config:  rag.ChunkingConfig{Strategy: "semantic", ChunkSize: 256},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConvertImportFilesConfigToPb_Chunking(t *testing.T) {
+	t.Parallel()
+
+	source := &rag.GcsSource{Uris: []string{"gs://bucket/docs/*"}}
+	tests := map[string]struct {
+		config      *rag.ImportFilesConfig
+		wantSize    int32
+		wantOverlap int32
+		wantErr     bool
+	}{
+		"tokens": {
+			config:      &rag.ImportFilesConfig{GcsSource: source, Chunking: &rag.ChunkingConfig{ChunkSize: 512, Overlap: 64, TokenBased: true, Tokenizer: rag.TokenizerEmbeddingModel}},
+			wantSize:    512,
+			wantOverlap: 64,
+		},
+		"characters": {
+			config:      &rag.ImportFilesConfig{GcsSource: source, Chunking: &rag.ChunkingConfig{ChunkSize: 2000, Overlap: 200}},
+			wantSize:    500,
+			wantOverlap: 50,
+		},
+		"legacy sizes": {
+			config:      &rag.ImportFilesConfig{GcsSource: source, ChunkSize: 1000, ChunkOverlap: 100},
+			wantSize:    1000,
+			wantOverlap: 100,
+		},
+		"legacy overlap too large": {
+			config:  &rag.ImportFilesConfig{GcsSource: source, ChunkSize: 100, ChunkOverlap: 100},
+			wantErr: true,
+		},
+		"sentence not supported": {
+			config:  &rag.ImportFilesConfig{GcsSource: source, Chunking: &rag.ChunkingConfig{Strategy: rag.ChunkingStrategySentence, ChunkSize: 1000}},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			pbConfig, err := rag.ConvertImportFilesConfigToPb(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertImportFilesConfigToPb() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			chunking := pbConfig.GetRagFileTransformationConfig().GetRagFileChunkingConfig().GetFixedLengthChunking()
+			if chunking.GetChunkSize() != tt.wantSize || chunking.GetChunkOverlap() != tt.wantOverlap {
+				t.Errorf("fixed length chunking = (%d, %d), want (%d, %d)",
+					chunking.GetChunkSize(), chunking.GetChunkOverlap(), tt.wantSize, tt.wantOverlap)
+			}
+		})
+	}
+}
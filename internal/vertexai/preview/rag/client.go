@@ -114,7 +114,9 @@ func (c *Service) UpdateCorpus(ctx context.Context, corpus *Corpus, updateMask *
 // File Management Methods
 
 // ImportFiles imports files into a RAG corpus from various sources.
-func (c *Service) ImportFiles(ctx context.Context, corpusName string, config *ImportFilesConfig) error {
+//
+// The result holds the numbers of files imported, failed and skipped, not the numbers of chunks.
+func (c *Service) ImportFiles(ctx context.Context, corpusName string, config *ImportFilesConfig) (*ImportFilesResult, error) {
 	req := &ImportFilesRequest{
 		Parent:            corpusName,
 		ImportFilesConfig: config,
@@ -122,26 +124,28 @@ func (c *Service) ImportFiles(ctx context.Context, corpusName string, config *Im
 	return c.fileService.ImportFiles(ctx, req)
 }
 
-// ImportFilesFromGCS imports files from Google Cloud Storage.
-func (c *Service) ImportFilesFromGCS(ctx context.Context, corpusName string, gcsUris []string, chunkSize, chunkOverlap int32) error {
+// ImportFilesFromGCS imports files from Google Cloud Storage, split into chunks as set by chunking.
+// A nil chunking config uses the default chunking of the service. The result holds file counts,
+// not chunk counts.
+func (c *Service) ImportFilesFromGCS(ctx context.Context, corpusName string, gcsUris []string, chunking *ChunkingConfig) (*ImportFilesResult, error) {
 	config := &ImportFilesConfig{
 		GcsSource: &GcsSource{
 			Uris: gcsUris,
 		},
-		ChunkSize:    chunkSize,
-		ChunkOverlap: chunkOverlap,
+		Chunking: chunking,
 	}
 	return c.ImportFiles(ctx, corpusName, config)
 }
 
-// ImportFilesFromGoogleDrive imports files from Google Drive.
-func (c *Service) ImportFilesFromGoogleDrive(ctx context.Context, corpusName string, resourceIds []string, chunkSize, chunkOverlap int32) error {
+// ImportFilesFromGoogleDrive imports files from Google Drive, split into chunks as set by chunking.
+// A nil chunking config uses the default chunking of the service. The result holds file counts,
+// not chunk counts.
+func (c *Service) ImportFilesFromGoogleDrive(ctx context.Context, corpusName string, resourceIds []string, chunking *ChunkingConfig) (*ImportFilesResult, error) {
 	config := &ImportFilesConfig{
 		GoogleDriveSource: &GoogleDriveSource{
 			ResourceIds: resourceIds,
 		},
-		ChunkSize:    chunkSize,
-		ChunkOverlap: chunkOverlap,
+		Chunking: chunking,
 	}
 	return c.ImportFiles(ctx, corpusName, config)
 }
@@ -320,12 +324,14 @@ func (c *Service) BatchImportFiles(ctx context.Context, corpusName string, sourc
 		case source.GcsUris != nil:
 			config = &ImportFilesConfig{
 				GcsSource:    &GcsSource{Uris: source.GcsUris},
+				Chunking:     source.Chunking,
 				ChunkSize:    source.ChunkSize,
 				ChunkOverlap: source.ChunkOverlap,
 			}
 		case source.GoogleDriveResourceIds != nil:
 			config = &ImportFilesConfig{
 				GoogleDriveSource: &GoogleDriveSource{ResourceIds: source.GoogleDriveResourceIds},
+				Chunking:          source.Chunking,
 				ChunkSize:         source.ChunkSize,
 				ChunkOverlap:      source.ChunkOverlap,
 			}
@@ -333,7 +339,7 @@ func (c *Service) BatchImportFiles(ctx context.Context, corpusName string, sourc
 			return fmt.Errorf("invalid import source at index %d: must specify either GcsUris or GoogleDriveResourceIds", i)
 		}
 
-		if err := c.ImportFiles(ctx, corpusName, config); err != nil {
+		if _, err := c.ImportFiles(ctx, corpusName, config); err != nil {
 			return fmt.Errorf("failed to import files from source %d: %w", i, err)
 		}
 	}
@@ -353,9 +359,13 @@ type ImportSource struct {
 	// GoogleDriveResourceIds are the Google Drive resource IDs.
 	GoogleDriveResourceIds []string `json:"google_drive_resource_ids,omitempty"`
 
-	// ChunkSize is the chunk size for processing files.
+	// Chunking configures how the files are split into chunks. It takes precedence over
+	// ChunkSize and ChunkOverlap.
+	Chunking *ChunkingConfig `json:"chunking,omitempty"`
+
+	// ChunkSize is the chunk size for processing files, in tokens.
 	ChunkSize int32 `json:"chunk_size,omitempty"`
 
-	// ChunkOverlap is the overlap between chunks.
+	// ChunkOverlap is the overlap between chunks, in tokens.
 	ChunkOverlap int32 `json:"chunk_overlap,omitempty"`
 }
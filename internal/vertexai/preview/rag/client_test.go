@@ -134,7 +134,7 @@ func TestClient_FileOperations(t *testing.T) {
 
 	t.Run("import_files_from_gcs", func(t *testing.T) {
 		gcsUris := []string{"gs://test-bucket/test-file.txt"}
-		err := client.ImportFilesFromGCS(ctx, corpus.Name, gcsUris, 1000, 100)
+		_, err := client.ImportFilesFromGCS(ctx, corpus.Name, gcsUris, &rag.ChunkingConfig{ChunkSize: 1000, Overlap: 100, TokenBased: true, Tokenizer: rag.TokenizerEmbeddingModel})
 		if err != nil {
 			// This is expected to fail without a real GCS bucket
 			t.Logf("Expected error importing from non-existent GCS bucket: %v", err)
@@ -39,10 +39,22 @@
 //
 // Import files:
 //
-//	err = client.ImportFilesFromGCS(ctx, corpus.Name, []string{"gs://my-bucket/docs/*"}, 1000, 100)
+//	result, err := client.ImportFilesFromGCS(ctx, corpus.Name, []string{"gs://my-bucket/docs/*"}, &rag.ChunkingConfig{
+//		ChunkSize:  1000,
+//		Overlap:    100,
+//		TokenBased: true,
+//		Tokenizer:  rag.TokenizerEmbeddingModel,
+//	})
 //	if err != nil {
 //		log.Fatal(err)
 //	}
+//	log.Printf("imported %d files, %d failed", result.ImportedFiles, result.FailedFiles)
+//
+// The chunking config is validated before the import: the overlap must be less than the chunk
+// size, and the token-based chunking must name its tokenizer. The Vertex RAG API only supports the
+// fixed-length chunking in tokens of the embedding model, so that the character sizes are
+// converted to tokens and the sentence chunking is rejected by the validation. The result of an
+// import counts the files, not the chunks they were split into.
 //
 // Query the corpus:
 //
@@ -0,0 +1,7 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rag
+
+// ConvertImportFilesConfigToPb exports convertImportFilesConfigToPb for testing.
+var ConvertImportFilesConfigToPb = convertImportFilesConfigToPb
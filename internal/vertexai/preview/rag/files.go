@@ -5,6 +5,7 @@ package rag
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -37,34 +38,56 @@ func NewFileService(ragClient *aiplatform.VertexRagClient, ragDataClient *aiplat
 }
 
 // ImportFiles imports files into a RAG corpus from various sources.
-func (s *FileService) ImportFiles(ctx context.Context, req *ImportFilesRequest) error {
-	s.logger.InfoContext(ctx, "Importing files into RAG corpus",
-		slog.String("parent", req.Parent),
-		slog.Int("chunk_size", int(req.ImportFilesConfig.ChunkSize)),
-		slog.Int("chunk_overlap", int(req.ImportFilesConfig.ChunkOverlap)),
-	)
-
+//
+// The chunking config is validated before the import is started.
+func (s *FileService) ImportFiles(ctx context.Context, req *ImportFilesRequest) (*ImportFilesResult, error) {
+	if req.ImportFilesConfig == nil {
+		return nil, errors.New("import files config is required")
+	}
+	chunking := req.ImportFilesConfig.chunkingConfig()
+
+	attrs := []any{slog.String("parent", req.Parent)}
+	if chunking != nil {
+		attrs = append(attrs,
+			slog.String("chunking_strategy", string(chunking.Strategy)),
+			slog.Int("chunk_size", int(chunking.ChunkSize)),
+			slog.Int("chunk_overlap", int(chunking.Overlap)),
+			slog.Bool("token_based", chunking.TokenBased),
+		)
+	}
+	s.logger.InfoContext(ctx, "Importing files into RAG corpus", attrs...)
+
+	pbConfig, err := convertImportFilesConfigToPb(req.ImportFilesConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import files config: %w", err)
+	}
 	pbReq := &aiplatformpb.ImportRagFilesRequest{
 		Parent:               req.Parent,
-		ImportRagFilesConfig: convertImportFilesConfigToPb(req.ImportFilesConfig),
+		ImportRagFilesConfig: pbConfig,
 	}
 
 	op, err := s.ragDataClient.ImportRagFiles(ctx, pbReq)
 	if err != nil {
-		return fmt.Errorf("failed to import RAG files: %w", err)
+		return nil, fmt.Errorf("failed to import RAG files: %w", err)
 	}
 
 	resp, err := op.Wait(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to wait for RAG files import: %w", err)
+		return nil, fmt.Errorf("failed to wait for RAG files import: %w", err)
 	}
 
+	result := &ImportFilesResult{
+		ImportedFiles: resp.GetImportedRagFilesCount(),
+		FailedFiles:   resp.GetFailedRagFilesCount(),
+		SkippedFiles:  resp.GetSkippedRagFilesCount(),
+	}
 	s.logger.InfoContext(ctx, "Files imported successfully",
-		slog.Int("imported_count", int(resp.GetImportedRagFilesCount())),
-		slog.Int("failed_count", int(resp.GetFailedRagFilesCount())),
+		slog.Int64("imported_count", result.ImportedFiles),
+		slog.Int64("failed_count", result.FailedFiles),
+		slog.Int64("skipped_count", result.SkippedFiles),
 	)
 
-	return nil
+	return result, nil
 }
 
 // UploadFile uploads a file directly to a RAG corpus.
@@ -196,15 +219,25 @@ func (s *FileService) DeleteFile(ctx context.Context, req *DeleteFileRequest) er
 }
 
 // convertImportFilesConfigToPb converts our ImportFilesConfig to protobuf.
-func convertImportFilesConfigToPb(config *ImportFilesConfig) *aiplatformpb.ImportRagFilesConfig {
+//
+// It returns an error if the chunking config is not valid or not supported by the Vertex RAG API.
+func convertImportFilesConfigToPb(config *ImportFilesConfig) (*aiplatformpb.ImportRagFilesConfig, error) {
 	if config == nil {
-		return nil
+		return nil, nil
 	}
 
 	pbConfig := &aiplatformpb.ImportRagFilesConfig{
 		MaxEmbeddingRequestsPerMin: config.MaxEmbeddingRequestsPerMin,
 	}
 
+	if chunking := config.chunkingConfig(); chunking != nil {
+		transformation, err := chunking.toTransformationConfig()
+		if err != nil {
+			return nil, err
+		}
+		pbConfig.RagFileTransformationConfig = transformation
+	}
+
 	if config.GcsSource != nil {
 		pbConfig.ImportSource = &aiplatformpb.ImportRagFilesConfig_GcsSource{
 			GcsSource: &aiplatformpb.GcsSource{
@@ -221,7 +254,7 @@ func convertImportFilesConfigToPb(config *ImportFilesConfig) *aiplatformpb.Impor
 		}
 	}
 
-	return pbConfig
+	return pbConfig, nil
 }
 
 // convertResourceIdsToProto converts string resource IDs to protobuf ResourceId format.
@@ -101,7 +101,7 @@ func TestRAGWorkflowIntegration(t *testing.T) {
 	// Test import from GCS (expected to fail with non-existent bucket)
 	t.Log("Testing GCS import (expected to fail)...")
 	gcsUris := []string{"gs://non-existent-test-bucket/test-file.txt"}
-	err = client.ImportFilesFromGCS(ctx, corpus.Name, gcsUris, 1000, 100)
+	_, err = client.ImportFilesFromGCS(ctx, corpus.Name, gcsUris, &rag.ChunkingConfig{ChunkSize: 1000, Overlap: 100, TokenBased: true, Tokenizer: rag.TokenizerEmbeddingModel})
 	if err == nil {
 		t.Log("GCS import unexpectedly succeeded (may indicate test bucket exists)")
 	} else {
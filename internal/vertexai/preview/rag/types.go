@@ -196,16 +196,34 @@ type ImportFilesConfig struct {
 	// GoogleDriveSource is the Google Drive source.
 	GoogleDriveSource *GoogleDriveSource `json:"google_drive_source,omitempty"`
 
-	// ChunkSize is the chunk size for processing files.
+	// Chunking configures how the files are split into chunks. It takes precedence over
+	// ChunkSize and ChunkOverlap.
+	Chunking *ChunkingConfig `json:"chunking,omitempty"`
+
+	// ChunkSize is the chunk size for processing files, in tokens.
 	ChunkSize int32 `json:"chunk_size,omitempty"`
 
-	// ChunkOverlap is the overlap between chunks.
+	// ChunkOverlap is the overlap between chunks, in tokens.
 	ChunkOverlap int32 `json:"chunk_overlap,omitempty"`
 
 	// MaxEmbeddingRequestsPerMin is the maximum embedding requests per minute.
 	MaxEmbeddingRequestsPerMin int32 `json:"max_embedding_requests_per_min,omitempty"`
 }
 
+// ImportFilesResult reports the outcome of an import of files into a corpus.
+//
+// The Vertex RAG API reports the number of files, not the number of chunks they were split into.
+type ImportFilesResult struct {
+	// ImportedFiles is the number of files imported.
+	ImportedFiles int64 `json:"imported_files,omitempty"`
+
+	// FailedFiles is the number of files that failed to be imported.
+	FailedFiles int64 `json:"failed_files,omitempty"`
+
+	// SkippedFiles is the number of files skipped, for example because they were already imported.
+	SkippedFiles int64 `json:"skipped_files,omitempty"`
+}
+
 // RetrievalQuery represents a query for retrieving documents from a corpus.
 type RetrievalQuery struct {
 	// Text is the query text.
@@ -4,6 +4,7 @@
 package prompt
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
@@ -23,6 +24,7 @@ func (s *service) ApplyTemplate(ctx context.Context, req *ApplyTemplateRequest)
 
 	var template string
 	var declaredVariables []string
+	engine := req.TemplateEngine
 
 	// Get the template content
 	if req.Template != "" {
@@ -37,18 +39,22 @@ func (s *service) ApplyTemplate(ctx context.Context, req *ApplyTemplateRequest)
 		}
 		template = prompt.Template
 		declaredVariables = prompt.Variables
+		engine = cmp.Or(engine, prompt.TemplateEngine)
 	}
 
+	// Select the engine of the prompt, or detect it from the template
+	processor := s.templateProcessorFor(template, engine)
+
 	// Validate variables if requested
 	if req.ValidateVariables {
-		if err := s.validateTemplateVariables(template, declaredVariables, req.Variables, req.StrictMode); err != nil {
+		if err := s.validateTemplateVariables(processor, template, declaredVariables, req.Variables, req.StrictMode); err != nil {
 			tracker.FinishWithError("validation")
 			return nil, err
 		}
 	}
 
 	// Apply the variables to the template
-	response, err := s.templateEngine.ApplyVariables(template, req.Variables)
+	response, err := processor.ApplyVariables(template, req.Variables)
 	if err != nil {
 		tracker.FinishWithError("template")
 		return nil, fmt.Errorf("failed to apply template variables: %w", err)
@@ -72,6 +78,7 @@ func (s *service) ApplyTemplate(ctx context.Context, req *ApplyTemplateRequest)
 func (s *service) ApplyTemplateToPrompt(ctx context.Context, prompt *Prompt, variables map[string]any) (*ApplyTemplateResponse, error) {
 	return s.ApplyTemplate(ctx, &ApplyTemplateRequest{
 		Template:          prompt.Template,
+		TemplateEngine:    prompt.TemplateEngine,
 		Variables:         variables,
 		ValidateVariables: true,
 		StrictMode:        false,
@@ -99,7 +106,7 @@ func (s *service) ValidateTemplate(ctx context.Context, template string, variabl
 // PreviewTemplate previews how a template would look with sample variables.
 func (s *service) PreviewTemplate(ctx context.Context, template string, sampleVariables map[string]any) (*ApplyTemplateResponse, error) {
 	// Create a copy of the template processor in loose mode for preview
-	previewProcessor := NewTemplateProcessorWithOptions(s.templateEngine.engine, ValidationModeLoose)
+	previewProcessor := NewTemplateProcessorWithOptions(s.templateProcessorFor(template, "").engine, ValidationModeLoose)
 
 	response, err := previewProcessor.ApplyVariables(template, sampleVariables)
 	if err != nil {
@@ -210,10 +217,21 @@ func (s *service) getPromptForTemplate(ctx context.Context, req *ApplyTemplateRe
 	return prompt, nil
 }
 
+// templateProcessorFor returns the template processor for the template.
+//
+// The engine is used if set. Otherwise the engine of the service is used, unless it is
+// [TemplateEngineSimple] and the template is detected as a [TemplateEngineAdvanced] one.
+func (s *service) templateProcessorFor(template string, engine TemplateEngine) *TemplateProcessor {
+	if engine == "" && s.templateEngine.engine == TemplateEngineSimple {
+		engine = DetectTemplateEngine(template)
+	}
+	return s.templateEngine.withEngine(engine)
+}
+
 // validateTemplateVariables validates template variables.
-func (s *service) validateTemplateVariables(template string, declaredVars []string, providedVars map[string]any, strictMode bool) error {
+func (s *service) validateTemplateVariables(processor *TemplateProcessor, template string, declaredVars []string, providedVars map[string]any, strictMode bool) error {
 	// Extract variables from template
-	templateVars := s.templateEngine.ExtractVariables(template)
+	templateVars := processor.ExtractVariables(template)
 
 	// Check for missing required variables
 	var missingVars []string
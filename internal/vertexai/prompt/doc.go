@@ -95,6 +95,22 @@ Please provide analysis in the specified format.`,
 		Tags:      []string{"image", "analysis", "multimodal"},
 	}
 
+# Conditionals and Loops
+
+Templates written with Go text/template actions are detected and rendered by the advanced
+engine, with helper functions such as upper, lower, trim, join, default and json. Set
+TemplateEngine on the prompt or the request to select the engine explicitly; simple {variable}
+substitution stays the default.
+
+	prompt := &prompts.Prompt{
+		Name: "support-template",
+		Template: `Hello {{.name | default "there"}}!
+	{{if .premium}}As a premium member, you get priority support.
+	{{end}}{{range .topics}}- {{.}}
+	{{end}}`,
+		TemplateEngine: prompts.TemplateEngineAdvanced,
+	}
+
 # Batch Operations
 
 	// Import multiple prompts from a configuration
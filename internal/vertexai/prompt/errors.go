@@ -42,6 +42,9 @@ var (
 
 	// ErrServiceUnavailable indicates that the service is temporarily unavailable.
 	ErrServiceUnavailable = errors.New("service unavailable")
+
+	// ErrTemplateExecution indicates that rendering a prompt template failed.
+	ErrTemplateExecution = errors.New("template execution failed")
 )
 
 // PromptError represents a detailed error with additional context.
@@ -128,6 +131,19 @@ func NewInvalidTemplateError(template string, details []string) *PromptError {
 	}
 }
 
+// NewTemplateExecutionError creates a template execution error.
+func NewTemplateExecutionError(engine TemplateEngine, err error) *PromptError {
+	return &PromptError{
+		Code:    "TEMPLATE_EXECUTION_FAILED",
+		Message: fmt.Sprintf("failed to render %s template: %v", engine, err),
+		Details: map[string]any{
+			"engine": string(engine),
+			"error":  err.Error(),
+		},
+		Err: ErrTemplateExecution,
+	}
+}
+
 // NewVersionNotFoundError creates a version not found error.
 func NewVersionNotFoundError(promptID, versionID string) *PromptError {
 	return &PromptError{
@@ -254,6 +270,15 @@ func IsInvalidTemplate(err error) bool {
 	return errors.Is(err, ErrInvalidTemplate)
 }
 
+// IsTemplateExecution checks if the error indicates a template execution failure.
+func IsTemplateExecution(err error) bool {
+	var promptErr *PromptError
+	if errors.As(err, &promptErr) {
+		return promptErr.Code == "TEMPLATE_EXECUTION_FAILED"
+	}
+	return errors.Is(err, ErrTemplateExecution)
+}
+
 // IsVersionConflict checks if the error indicates a version conflict.
 func IsVersionConflict(err error) bool {
 	var promptErr *PromptError
@@ -6,10 +6,15 @@ package prompt
 import (
 	"bytes"
 	"fmt"
+	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"text/template"
+	"text/template/parse"
 	"time"
+
+	"github.com/go-json-experiment/json"
 )
 
 // TemplateProcessor handles prompt template processing, validation, and variable substitution.
@@ -104,6 +109,14 @@ func (tp *TemplateProcessor) ValidateTemplateDetailed(templateText string, decla
 	return result
 }
 
+// withEngine returns the template processor using the given engine, keeping the validation mode.
+func (tp *TemplateProcessor) withEngine(engine TemplateEngine) *TemplateProcessor {
+	if engine == "" || engine == tp.engine {
+		return tp
+	}
+	return NewTemplateProcessorWithOptions(engine, tp.mode)
+}
+
 // ExtractVariables extracts all variable names from a template.
 func (tp *TemplateProcessor) ExtractVariables(templateText string) []string {
 	switch tp.engine {
@@ -243,32 +256,178 @@ func (tp *TemplateProcessor) applySimpleVariables(templateText string, variables
 
 // Advanced template engine implementation (Go text/template)
 
-// extractGoTemplateVariables extracts variables from Go template syntax.
+// templateFuncs are the helper functions available to [TemplateEngineAdvanced] templates,
+// in addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"contains":  strings.Contains,
+	"hasPrefix": strings.HasPrefix,
+	"hasSuffix": strings.HasSuffix,
+	"replace":   strings.ReplaceAll,
+	"join":      templateJoin,
+	"default":   templateDefault,
+	"json":      templateJSON,
+}
+
+// templateJoin joins the elements of list, a slice of any type, with sep.
+func templateJoin(sep string, list any) (string, error) {
+	v := reflect.ValueOf(list)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+	case reflect.Invalid:
+		return "", nil
+	default:
+		return "", fmt.Errorf("join: expected a list, got %T", list)
+	}
+
+	elems := make([]string, v.Len())
+	for i := range v.Len() {
+		elems[i] = fmt.Sprint(v.Index(i).Interface())
+	}
+	return strings.Join(elems, sep), nil
+}
+
+// templateDefault returns value, or def if value is empty.
+//
+// It is used as a pipeline: {{.name | default "guest"}}.
+func templateDefault(def, value any) any {
+	if value == nil {
+		return def
+	}
+	if v := reflect.ValueOf(value); v.IsZero() {
+		return def
+	}
+	return value
+}
+
+// templateJSON returns the JSON encoding of v.
+func templateJSON(v any) (string, error) {
+	data, err := json.Marshal(v, json.DefaultOptionsV2())
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// parseGoTemplate parses the template text with the helper functions.
+func parseGoTemplate(name, templateText string, mode ValidationMode) (*template.Template, error) {
+	tmpl := template.New(name).Funcs(templateFuncs)
+	if mode == ValidationModeStrict {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	return tmpl.Parse(templateText)
+}
+
+// DetectTemplateEngine reports the engine the template text is written for.
+//
+// A template containing Go text/template actions such as {{.name}}, {{if .premium}} or
+// {{range .items}} is [TemplateEngineAdvanced]; anything else is [TemplateEngineSimple].
+func DetectTemplateEngine(templateText string) TemplateEngine {
+	if !strings.Contains(templateText, "{{") {
+		return TemplateEngineSimple
+	}
+
+	tmpl, err := parseGoTemplate("detect", templateText, ValidationModeLoose)
+	if err != nil || tmpl.Tree == nil {
+		return TemplateEngineSimple
+	}
+	for _, node := range tmpl.Tree.Root.Nodes {
+		if node.Type() != parse.NodeText {
+			return TemplateEngineAdvanced
+		}
+	}
+
+	return TemplateEngineSimple
+}
+
+// extractGoTemplateVariables extracts the top-level variables referenced by a Go template.
+//
+// Fields referenced inside {{range}} and {{with}} blocks refer to the current element and
+// are not reported, except through the root variable as in {{$.name}}.
 func (tp *TemplateProcessor) extractGoTemplateVariables(templateText string) []string {
-	// This is a simplified extraction for Go templates
-	// In a real implementation, you'd parse the template AST
-	re := regexp.MustCompile(`\{\{\s*\.([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
-	matches := re.FindAllStringSubmatch(templateText, -1)
+	tmpl, err := parseGoTemplate("extract", templateText, ValidationModeLoose)
+	if err != nil || tmpl.Tree == nil {
+		// Fall back to the plain {{.variable}} form for unparsable templates
+		re := regexp.MustCompile(`\{\{\s*\.([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+		matches := re.FindAllStringSubmatch(templateText, -1)
+
+		variableSet := make(map[string]bool)
+		var variables []string
+		for _, match := range matches {
+			if len(match) > 1 && !variableSet[match[1]] {
+				variableSet[match[1]] = true
+				variables = append(variables, match[1])
+			}
+		}
+		return variables
+	}
 
-	variableSet := make(map[string]bool)
 	var variables []string
+	variableSet := make(map[string]bool)
+	add := func(name string) {
+		if !variableSet[name] {
+			variableSet[name] = true
+			variables = append(variables, name)
+		}
+	}
 
-	for _, match := range matches {
-		if len(match) > 1 {
-			varName := match[1]
-			if !variableSet[varName] {
-				variableSet[varName] = true
-				variables = append(variables, varName)
+	var walk func(node parse.Node, root bool)
+	walk = func(node parse.Node, root bool) {
+		switch node := node.(type) {
+		case *parse.ListNode:
+			if node == nil {
+				return
+			}
+			for _, n := range node.Nodes {
+				walk(n, root)
+			}
+		case *parse.ActionNode:
+			walk(node.Pipe, root)
+		case *parse.IfNode:
+			walk(node.Pipe, root)
+			walk(node.List, root)
+			walk(node.ElseList, root)
+		case *parse.RangeNode:
+			walk(node.Pipe, root)
+			walk(node.List, false)
+			walk(node.ElseList, root)
+		case *parse.WithNode:
+			walk(node.Pipe, root)
+			walk(node.List, false)
+			walk(node.ElseList, root)
+		case *parse.PipeNode:
+			if node == nil {
+				return
+			}
+			for _, cmd := range node.Cmds {
+				walk(cmd, root)
+			}
+		case *parse.CommandNode:
+			for _, arg := range node.Args {
+				walk(arg, root)
+			}
+		case *parse.ChainNode:
+			walk(node.Node, root)
+		case *parse.FieldNode:
+			if root && len(node.Ident) > 0 {
+				add(node.Ident[0])
+			}
+		case *parse.VariableNode:
+			if len(node.Ident) > 1 && node.Ident[0] == "$" {
+				add(node.Ident[1])
 			}
 		}
 	}
+	walk(tmpl.Tree.Root, true)
 
 	return variables
 }
 
 // validateGoTemplate validates Go template syntax.
 func (tp *TemplateProcessor) validateGoTemplate(templateText string) error {
-	_, err := template.New("validation").Parse(templateText)
+	_, err := parseGoTemplate("validation", templateText, tp.mode)
 	return err
 }
 
@@ -279,15 +438,27 @@ func (tp *TemplateProcessor) applyGoTemplateVariables(templateText string, varia
 	}
 
 	// Parse the template
-	tmpl, err := template.New("prompt").Parse(templateText)
+	tmpl, err := parseGoTemplate("prompt", templateText, tp.mode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse template: %w", err)
+		return nil, NewInvalidTemplateError(templateText, []string{err.Error()})
 	}
 
 	// Execute the template
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, variables); err != nil {
-		return nil, fmt.Errorf("failed to execute template: %w", err)
+		return nil, NewTemplateExecutionError(TemplateEngineAdvanced, err)
+	}
+
+	templateVars := tp.extractGoTemplateVariables(templateText)
+	for _, varName := range templateVars {
+		if _, exists := variables[varName]; !exists {
+			response.MissingVariables = append(response.MissingVariables, varName)
+		}
+	}
+	for varName := range variables {
+		if !slices.Contains(templateVars, varName) {
+			response.UnusedVariables = append(response.UnusedVariables, varName)
+		}
 	}
 
 	response.Content = buf.String()
@@ -334,7 +505,7 @@ func (tc *TemplateCompiler) Compile(templateText string) (*CompiledTemplate, err
 	// Compile based on engine
 	switch tc.processor.engine {
 	case TemplateEngineAdvanced:
-		tmpl, err := template.New("compiled").Parse(templateText)
+		tmpl, err := parseGoTemplate("compiled", templateText, tc.processor.mode)
 		if err != nil {
 			return nil, fmt.Errorf("failed to compile template: %w", err)
 		}
@@ -362,7 +533,7 @@ func (ct *CompiledTemplate) Execute(variables map[string]any) (*ApplyTemplateRes
 
 		var buf bytes.Buffer
 		if err := ct.compiledTemplate.Execute(&buf, variables); err != nil {
-			return nil, fmt.Errorf("failed to execute compiled template: %w", err)
+			return nil, NewTemplateExecutionError(ct.engine, err)
 		}
 
 		return &ApplyTemplateResponse{
@@ -529,3 +529,139 @@ func BenchmarkCompiledTemplateExecution(b *testing.B) {
 		}
 	}
 }
+
+func TestDetectTemplateEngine(t *testing.T) {
+	tests := map[string]struct {
+		template string
+		want     TemplateEngine
+	}{
+		"simple variables": {
+			template: "Hello {name}!",
+			want:     TemplateEngineSimple,
+		},
+		"field action": {
+			template: "Hello {{.name}}!",
+			want:     TemplateEngineAdvanced,
+		},
+		"conditional": {
+			template: "{{if .premium}}Priority support.{{end}}",
+			want:     TemplateEngineAdvanced,
+		},
+		"loop": {
+			template: "{{range .items}}- {{.}}\n{{end}}",
+			want:     TemplateEngineAdvanced,
+		},
+		"literal double braces": {
+			template: "Use {{name}} as a placeholder.",
+			want:     TemplateEngineSimple,
+		},
+		"unbalanced action": {
+			template: "Hello {{.name",
+			want:     TemplateEngineSimple,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := DetectTemplateEngine(tt.template); got != tt.want {
+				t.Errorf("DetectTemplateEngine(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdvancedTemplateEngine_ConditionalsAndLoops(t *testing.T) {
+	processor := NewTemplateProcessorWithOptions(TemplateEngineAdvanced, ValidationModeWarn)
+
+	template := `Hello {{.name | default "there"}}!
+{{if .premium}}As a premium member, you get priority support.
+{{end}}Topics: {{join ", " .topics}}
+{{range .orders}}- {{.id}} ({{upper .status}})
+{{end}}`
+
+	tests := map[string]struct {
+		variables map[string]any
+		want      string
+	}{
+		"premium user": {
+			variables: map[string]any{
+				"name":    "Alice",
+				"premium": true,
+				"topics":  []string{"billing", "shipping"},
+				"orders": []map[string]any{
+					{"id": "A-1", "status": "shipped"},
+					{"id": "A-2", "status": "pending"},
+				},
+			},
+			want: "Hello Alice!\nAs a premium member, you get priority support.\nTopics: billing, shipping\n- A-1 (SHIPPED)\n- A-2 (PENDING)\n",
+		},
+		"regular user": {
+			variables: map[string]any{
+				"name":    "",
+				"premium": false,
+				"topics":  []string{"billing"},
+			},
+			want: "Hello there!\nTopics: billing\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			response, err := processor.ApplyVariables(template, tt.variables)
+			if err != nil {
+				t.Fatalf("ApplyVariables() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, response.Content); diff != "" {
+				t.Errorf("ApplyVariables() content mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// fields inside range refer to the element, not to the variables
+	if diff := cmp.Diff([]string{"name", "premium", "topics", "orders"}, processor.ExtractVariables(template)); diff != "" {
+		t.Errorf("ExtractVariables() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestAdvancedTemplateEngine_ExecutionError(t *testing.T) {
+	processor := NewTemplateProcessorWithOptions(TemplateEngineAdvanced, ValidationModeStrict)
+
+	_, err := processor.ApplyVariables("Hello {{.name}}!", map[string]any{})
+	if !IsTemplateExecution(err) {
+		t.Fatalf("ApplyVariables() error = %v, want template execution error", err)
+	}
+
+	_, err = processor.ApplyVariables("Topics: {{join \", \" .topics}}", map[string]any{"topics": 42})
+	if !IsTemplateExecution(err) {
+		t.Fatalf("ApplyVariables() error = %v, want template execution error", err)
+	}
+}
+
+func TestService_TemplateProcessorFor(t *testing.T) {
+	s := &service{templateEngine: NewTemplateProcessor()}
+
+	tests := map[string]struct {
+		template string
+		engine   TemplateEngine
+		want     TemplateEngine
+	}{
+		"detect simple": {
+			template: "Hello {name}!",
+			want:     TemplateEngineSimple,
+		},
+		"detect advanced": {
+			template: "{{if .premium}}Hi{{end}}",
+			want:     TemplateEngineAdvanced,
+		},
+		"explicit engine": {
+			template: "Hello {name}!",
+			engine:   TemplateEngineAdvanced,
+			want:     TemplateEngineAdvanced,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := s.templateProcessorFor(tt.template, tt.engine).engine; got != tt.want {
+				t.Errorf("templateProcessorFor() engine = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
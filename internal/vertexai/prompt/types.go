@@ -30,6 +30,10 @@ type Prompt struct {
 	// Template is the prompt template text with variables in {variable} format
 	Template string `json:"template"`
 
+	// TemplateEngine is the engine used to render the Template.
+	// If empty, the engine is detected from the Template.
+	TemplateEngine TemplateEngine `json:"template_engine,omitempty"`
+
 	// Variables is a list of variable names used in the template
 	Variables []string `json:"variables,omitempty"`
 
@@ -124,6 +128,7 @@ type PromptVersion struct {
 
 	// Version content
 	Template          string                  `json:"template"`
+	TemplateEngine    TemplateEngine          `json:"template_engine,omitempty"`
 	Variables         []string                `json:"variables,omitempty"`
 	GenerationConfig  *genai.GenerationConfig `json:"generation_config,omitempty"`
 	SafetySettings    []*genai.SafetySetting  `json:"safety_settings,omitempty"`
@@ -256,6 +261,10 @@ type ApplyTemplateRequest struct {
 	VersionID string `json:"version_id,omitempty"`
 	Template  string `json:"template,omitempty"`
 
+	// TemplateEngine overrides the engine used to render the template.
+	// If empty, the engine of the prompt is used, or detected from the template.
+	TemplateEngine TemplateEngine `json:"template_engine,omitempty"`
+
 	// Variables to substitute
 	Variables map[string]any `json:"variables"`
 
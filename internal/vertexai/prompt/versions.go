@@ -132,6 +132,7 @@ func (s *service) RestoreVersion(ctx context.Context, req *RestoreVersionRequest
 	restoredPrompt := &Prompt{
 		ID:                versionToRestore.PromptID,
 		Template:          versionToRestore.Template,
+		TemplateEngine:    versionToRestore.TemplateEngine,
 		Variables:         versionToRestore.Variables,
 		GenerationConfig:  versionToRestore.GenerationConfig,
 		SafetySettings:    versionToRestore.SafetySettings,
@@ -213,6 +214,7 @@ func (s *service) createPromptVersion(ctx context.Context, promptID string, prom
 		VersionName:       versionName,
 		PromptID:          promptID,
 		Template:          prompt.Template,
+		TemplateEngine:    prompt.TemplateEngine,
 		Variables:         prompt.Variables,
 		GenerationConfig:  prompt.GenerationConfig,
 		SafetySettings:    prompt.SafetySettings,
@@ -355,6 +357,7 @@ func (s *service) generateVersionID() string {
 func (s *service) promptFromVersion(basePrompt *Prompt, version *PromptVersion) *Prompt {
 	prompt := *basePrompt
 	prompt.Template = version.Template
+	prompt.TemplateEngine = version.TemplateEngine
 	prompt.Variables = version.Variables
 	prompt.GenerationConfig = version.GenerationConfig
 	prompt.SafetySettings = version.SafetySettings
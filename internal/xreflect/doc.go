@@ -0,0 +1,14 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package xreflect provides utility functions built on the standard reflect package.
+//
+// # Same Function
+//
+// Same reports whether two values of an interface type are the same value, without panicking on
+// the values of a non comparable dynamic type, such as a slice or a map:
+//
+//	func Same(a, b any) bool
+//
+// It is used to close or ping the services and toolsets registered several times only once.
+package xreflect
@@ -0,0 +1,16 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xreflect
+
+import "reflect"
+
+// Same reports whether a and b are the same value, comparing only the values of a comparable
+// dynamic type: the values of a non comparable type, such as a slice, are never the same.
+func Same(a, b any) bool {
+	ta := reflect.TypeOf(a)
+	if ta != reflect.TypeOf(b) || ta != nil && !ta.Comparable() {
+		return false
+	}
+	return a == b
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xreflect_test
+
+import (
+	"testing"
+
+	"github.com/go-a2a/adk-go/internal/xreflect"
+)
+
+func TestSame(t *testing.T) {
+	t.Parallel()
+
+	type pointee struct{ n int }
+	p := &pointee{n: 1}
+	s := []int{1}
+
+	tests := map[string]struct {
+		a, b any
+		want bool
+	}{
+		"same pointer":         {a: p, b: p, want: true},
+		"different pointers":   {a: p, b: &pointee{n: 1}, want: false},
+		"equal values":         {a: pointee{n: 1}, b: pointee{n: 1}, want: true},
+		"different types":      {a: 1, b: int64(1), want: false},
+		"non comparable":       {a: s, b: s, want: false},
+		"both nil":             {a: nil, b: nil, want: true},
+		"nil and non nil":      {a: nil, b: p, want: false},
+		"non comparable field": {a: struct{ s []int }{s}, b: struct{ s []int }{s}, want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := xreflect.Same(tt.a, tt.b); got != tt.want {
+				t.Errorf("Same(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
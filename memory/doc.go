@@ -106,6 +106,19 @@
 //	// Store entire session
 //	memoryService.AddSessionToMemory(ctx, session)
 //
+// ## Bulk Import
+//
+// Backfill memory from an archive of sessions, skipping events already stored:
+//
+//	result, err := memoryService.AddSessionsToMemory(ctx, sessions)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	log.Printf("added %d, skipped %d", result.Added, result.Skipped)
+//	for _, failed := range result.Failed() {
+//		log.Printf("session %s: %v", failed.SessionID, failed.Err)
+//	}
+//
 // ## Memory Entry Structure
 //
 // Retrieved memories are returned as MemoryEntry objects:
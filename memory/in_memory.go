@@ -5,11 +5,15 @@ package memory
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"strings"
 	"sync"
 
+	"github.com/go-json-experiment/json"
+
 	"github.com/go-a2a/adk-go/internal/xmaps"
 	"github.com/go-a2a/adk-go/pkg/py"
 	"github.com/go-a2a/adk-go/types"
@@ -21,8 +25,10 @@ import (
 type InMemoryService struct {
 	// Keys are app_name/user_id, session_id. Values are session event lists.
 	sessionEvents map[string]map[string][]*types.Event
-	logger        *slog.Logger
-	mu            sync.RWMutex
+	// Fingerprints of the stored events, keyed by app_name/user_id.
+	fingerprints map[string]py.Set[string]
+	logger       *slog.Logger
+	mu           sync.RWMutex
 }
 
 var _ types.MemoryService = (*InMemoryService)(nil)
@@ -37,6 +43,7 @@ func (s *InMemoryService) WithLogger(logger *slog.Logger) *InMemoryService {
 func NewInMemoryService() *InMemoryService {
 	return &InMemoryService{
 		sessionEvents: make(map[string]map[string][]*types.Event),
+		fingerprints:  make(map[string]py.Set[string]),
 		logger:        slog.Default(),
 	}
 }
@@ -46,7 +53,7 @@ func (s *InMemoryService) userKey(appName, userID string) string {
 }
 
 func (s *InMemoryService) extractWordsLower(text string) py.Set[string] {
-	return py.NewSet(strings.ToLower(text))
+	return py.NewSetFromSlice(strings.Fields(strings.ToLower(text)))
 }
 
 // AddSessionToMemory implements [types.MemoryService].
@@ -54,17 +61,101 @@ func (s *InMemoryService) AddSessionToMemory(ctx context.Context, session types.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	_, _, err := s.addSession(session, false)
+	return err
+}
+
+// AddSessionsToMemory implements [types.MemoryService].
+//
+// Events are deduplicated by their JSON encoding.
+func (s *InMemoryService) AddSessionsToMemory(ctx context.Context, sessions []types.Session, opts ...types.AddSessionsOption) (*types.AddSessionsResult, error) {
+	config := types.DefaultAddSessionsConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &types.AddSessionsResult{
+		Sessions: make([]*types.AddSessionResult, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		res := &types.AddSessionResult{
+			AppName:   session.AppName(),
+			UserID:    session.UserID(),
+			SessionID: session.ID(),
+		}
+		res.Added, res.Skipped, res.Err = s.addSession(session, config.Deduplicate)
+		result.Added += res.Added
+		result.Skipped += res.Skipped
+		result.Sessions = append(result.Sessions, res)
+	}
+
+	s.logger.InfoContext(ctx, "added sessions to memory",
+		slog.Int("sessions", len(sessions)),
+		slog.Int("added", result.Added),
+		slog.Int("skipped", result.Skipped),
+	)
+
+	return result, nil
+}
+
+// addSession stores the events of the session with content, skipping the already stored ones if dedupe is true.
+//
+// It must be called with s.mu held.
+func (s *InMemoryService) addSession(session types.Session, dedupe bool) (added, skipped int, err error) {
 	userKey := s.userKey(session.AppName(), session.UserID())
+	if _, ok := s.sessionEvents[userKey]; !ok {
+		s.sessionEvents[userKey] = make(map[string][]*types.Event)
+	}
+	if _, ok := s.fingerprints[userKey]; !ok {
+		s.fingerprints[userKey] = py.NewSet[string]()
+	}
+
 	for _, event := range session.Events() {
-		if event.Content != nil || len(event.Content.Parts) > 0 {
-			s.sessionEvents[userKey][session.ID()] = append(s.sessionEvents[userKey][session.ID()], event)
+		if event.LLMResponse == nil || event.Content == nil || len(event.Content.Parts) == 0 {
+			continue
+		}
+
+		fingerprint, err := eventFingerprint(event)
+		if err != nil {
+			return added, skipped, err
+		}
+		if dedupe && s.fingerprints[userKey].Has(fingerprint) {
+			skipped++
+			continue
 		}
+		s.fingerprints[userKey].Insert(fingerprint)
+		s.sessionEvents[userKey][session.ID()] = append(s.sessionEvents[userKey][session.ID()], event)
+		added++
 	}
 
-	return nil
+	return added, skipped, nil
+}
+
+// eventFingerprint returns the SHA-256 hash of the JSON encoding of the event.
+func eventFingerprint(event *types.Event) (string, error) {
+	data, err := json.Marshal(event, json.DefaultOptionsV2())
+	if err != nil {
+		return "", fmt.Errorf("marshal event %s: %w", event.ID, err)
+	}
+	return contentFingerprint(data), nil
+}
+
+// contentFingerprint returns the SHA-256 hash of data.
+func contentFingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 // SearchMemory implements [types.MemoryService].
+//
+// The score of each memory is the fraction of the query words found in it.
 func (s *InMemoryService) SearchMemory(ctx context.Context, appName, userID, query string) (*types.SearchMemoryResponse, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -74,7 +165,10 @@ func (s *InMemoryService) SearchMemory(ctx context.Context, appName, userID, que
 		return &types.SearchMemoryResponse{}, nil
 	}
 
-	wordsInQuery := py.NewSet(strings.Split(query, " ")...)
+	wordsInQuery := s.extractWordsLower(query)
+	if wordsInQuery.Len() == 0 {
+		return &types.SearchMemoryResponse{}, nil
+	}
 	response := &types.SearchMemoryResponse{
 		Memories: make([]*types.MemoryEntry, 0),
 	}
@@ -88,27 +182,35 @@ func (s *InMemoryService) SearchMemory(ctx context.Context, appName, userID, que
 			for _, part := range event.Content.Parts {
 				partText = append(partText, part.Text)
 			}
-			wordsInEvent := s.extractWordsLower(strings.Join(partText, ""))
+			wordsInEvent := s.extractWordsLower(strings.Join(partText, " "))
 			if wordsInEvent.Len() == 0 {
 				continue
 			}
 
-			for _, queryWord := range wordsInQuery.UnsortedList() {
-				if wordsInEvent.Has(queryWord) {
-					response.Memories = append(response.Memories, &types.MemoryEntry{
-						Content:   event.Content,
-						Author:    event.Author,
-						Timestamp: event.Timestamp,
-					})
-				}
+			matched := wordsInQuery.Intersection(wordsInEvent).Len()
+			if matched == 0 {
+				continue
 			}
+			response.Memories = append(response.Memories, &types.MemoryEntry{
+				Content:   event.Content,
+				Author:    event.Author,
+				Timestamp: event.Timestamp,
+				Score:     float64(matched) / float64(wordsInQuery.Len()),
+			})
 		}
 	}
 
 	return response, nil
 }
 
-// SearchMemory implements [types.MemoryService].
+// Ping implements [types.MemoryService].
+//
+// It always succeeds, the memory having no backend.
+func (s *InMemoryService) Ping(context.Context) error {
+	return nil
+}
+
+// Close implements [types.MemoryService].
 func (s *InMemoryService) Close() error {
 	// nothing to do
 	return nil
@@ -0,0 +1,85 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memory_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/memory"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestInMemoryService_AddSessionsToMemory(t *testing.T) {
+	t.Parallel()
+
+	newEvent := func(text string) *types.Event {
+		return types.NewEvent().
+			WithAuthor("user").
+			WithContent(genai.NewContentFromText(text, genai.RoleUser))
+	}
+	shared := newEvent("my favorite color is blue")
+
+	first := session.NewSession("app", "user", "s1", nil, time.Now())
+	first.AddEvent(shared, newEvent("I live in Tokyo"))
+	second := session.NewSession("app", "user", "s2", nil, time.Now())
+	second.AddEvent(shared, newEvent("I like ramen"), types.NewEvent())
+
+	tests := map[string]struct {
+		opts        []types.AddSessionsOption
+		wantAdded   []int
+		wantSkipped []int
+		wantBlue    int
+	}{
+		"dedupe": {
+			wantAdded:   []int{2, 1},
+			wantSkipped: []int{0, 1},
+			wantBlue:    1,
+		},
+		"no dedupe": {
+			opts:        []types.AddSessionsOption{types.WithDeduplication(false)},
+			wantAdded:   []int{2, 2},
+			wantSkipped: []int{0, 0},
+			wantBlue:    2,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := memory.NewInMemoryService()
+			result, err := svc.AddSessionsToMemory(t.Context(), []types.Session{first, second}, tt.opts...)
+			if err != nil {
+				t.Fatalf("AddSessionsToMemory: %v", err)
+			}
+			if err := result.Err(); err != nil {
+				t.Fatalf("result.Err() = %v", err)
+			}
+
+			var gotAdded, gotSkipped []int
+			for _, res := range result.Sessions {
+				gotAdded = append(gotAdded, res.Added)
+				gotSkipped = append(gotSkipped, res.Skipped)
+			}
+			if diff := cmp.Diff(tt.wantAdded, gotAdded); diff != "" {
+				t.Errorf("added mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSkipped, gotSkipped); diff != "" {
+				t.Errorf("skipped mismatch (-want +got):\n%s", diff)
+			}
+
+			resp, err := svc.SearchMemory(t.Context(), "app", "user", "blue")
+			if err != nil {
+				t.Fatalf("SearchMemory: %v", err)
+			}
+			if got := len(resp.Memories); got != tt.wantBlue {
+				t.Errorf("got %d memories for %q, want %d", got, "blue", tt.wantBlue)
+			}
+		})
+	}
+}
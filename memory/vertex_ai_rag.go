@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-json-experiment/json"
@@ -18,6 +19,7 @@ import (
 	"github.com/go-a2a/adk-go/internal/pool"
 	"github.com/go-a2a/adk-go/internal/vertexai"
 	"github.com/go-a2a/adk-go/internal/vertexai/preview/rag"
+	"github.com/go-a2a/adk-go/pkg/py"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -28,7 +30,12 @@ type VertexAIRagService struct {
 	similarityTopK          int
 	vectorDistanceThreshold float64
 	vertexRAGStore          *genai.VertexRAGStore
+	maxSessionsPerUpload    int
 	logger                  *slog.Logger
+
+	// Fingerprints of the lines uploaded by this service, used for deduplication.
+	uploaded py.Set[string]
+	mu       sync.Mutex
 }
 
 var _ types.MemoryService = (*VertexAIRagService)(nil)
@@ -57,6 +64,14 @@ func WithVectorDistanceThreshold(threshold float64) VertexAIRagOption {
 	}
 }
 
+// WithMaxSessionsPerUpload sets the maximum number of sessions uploaded as a single RAG file by
+// [VertexAIRagService.AddSessionsToMemory].
+func WithMaxSessionsPerUpload(n int) VertexAIRagOption {
+	return func(s *VertexAIRagService) {
+		s.maxSessionsPerUpload = max(n, 1)
+	}
+}
+
 // NewVertexAIRagService creates a new VertexAIRagService.
 func NewVertexAIRagService(ctx context.Context, projectID, location, ragCorpus string, opts ...option.ClientOption) (*VertexAIRagService, error) {
 	client, err := vertexai.NewClient(ctx, projectID, location, opts...)
@@ -69,7 +84,9 @@ func NewVertexAIRagService(ctx context.Context, projectID, location, ragCorpus s
 		ragCorpus:               ragCorpus,
 		similarityTopK:          5,   // Default value
 		vectorDistanceThreshold: 0.7, // Default value
+		maxSessionsPerUpload:    50,  // Default value
 		logger:                  slog.Default(),
+		uploaded:                py.NewSet[string](),
 	}
 
 	vertexGagStore := &genai.VertexRAGStore{
@@ -99,18 +116,148 @@ func (s *VertexAIRagService) AddSessionToMemory(ctx context.Context, session typ
 		slog.String("rag_corpus", s.ragCorpus),
 	)
 
-	// Create temporary file with session content
-	tempfile, err := os.CreateTemp(os.TempDir(), "session-*.txt")
+	// Extract text content from session events
+	outputLines, err := s.sessionLines(session)
 	if err != nil {
-		return fmt.Errorf("failed to create temporary file: %w", err)
+		return err
 	}
-	defer os.Remove(tempfile.Name())
 
-	// Extract text content from session events
+	if len(outputLines) == 0 {
+		s.logger.InfoContext(ctx, "No text content found in session, skipping upload")
+		return nil
+	}
+
+	ragFile := &rag.RagFile{
+		DisplayName: fmt.Sprintf("session-%s-%s-%s", session.AppName(), session.UserID(), session.ID()),
+		Description: fmt.Sprintf("Session data for app %s, user %s, session %s", session.AppName(), session.UserID(), session.ID()),
+	}
+	if err := s.uploadLines(ctx, ragFile, outputLines); err != nil {
+		return err
+	}
+	s.markUploaded(outputLines)
+
+	return nil
+}
+
+// AddSessionsToMemory implements [types.MemoryService].
+//
+// The sessions are uploaded in batches of [WithMaxSessionsPerUpload] sessions per RAG file
+// instead of one file per session. Deduplication skips the events already uploaded by this
+// service; it does not look at the files already in the corpus.
+func (s *VertexAIRagService) AddSessionsToMemory(ctx context.Context, sessions []types.Session, opts ...types.AddSessionsOption) (*types.AddSessionsResult, error) {
+	if len(s.vertexRAGStore.RAGResources) == 0 {
+		return nil, fmt.Errorf("rag resources must be set")
+	}
+
+	config := types.DefaultAddSessionsConfig()
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	result := &types.AddSessionsResult{
+		Sessions: make([]*types.AddSessionResult, len(sessions)),
+	}
+
+	// pending holds the sessions and lines of the batch being built
+	var (
+		pending      []int
+		pendingLines [][]string
+		batchSeen    = make(map[string]bool)
+		batch        int
+	)
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		var lines []string
+		for _, l := range pendingLines {
+			lines = append(lines, l...)
+		}
+
+		batch++
+		first := sessions[pending[0]]
+		ragFile := &rag.RagFile{
+			DisplayName: fmt.Sprintf("sessions-%s-%d-%d", first.AppName(), time.Now().UnixNano(), batch),
+			Description: fmt.Sprintf("Session data for %d sessions of app %s", len(pending), first.AppName()),
+		}
+		err := s.uploadLines(ctx, ragFile, lines)
+		for i, idx := range pending {
+			res := result.Sessions[idx]
+			if err != nil {
+				res.Err = err
+				res.Skipped = 0
+				res.Added = 0
+				continue
+			}
+			s.markUploaded(pendingLines[i])
+			result.Added += res.Added
+			result.Skipped += res.Skipped
+		}
+		pending, pendingLines = nil, nil
+		clear(batchSeen)
+
+		return ctx.Err()
+	}
+
+	for i, session := range sessions {
+		res := &types.AddSessionResult{
+			AppName:   session.AppName(),
+			UserID:    session.UserID(),
+			SessionID: session.ID(),
+		}
+		result.Sessions[i] = res
+
+		lines, err := s.sessionLines(session)
+		if err != nil {
+			res.Err = err
+			continue
+		}
+
+		var newLines []string
+		for _, line := range lines {
+			fingerprint := contentFingerprint([]byte(line))
+			if config.Deduplicate && (s.isUploaded(fingerprint) || batchSeen[fingerprint]) {
+				res.Skipped++
+				continue
+			}
+			batchSeen[fingerprint] = true
+			newLines = append(newLines, line)
+		}
+		res.Added = len(newLines)
+		if len(newLines) == 0 {
+			result.Skipped += res.Skipped
+			continue
+		}
+
+		pending = append(pending, i)
+		pendingLines = append(pendingLines, newLines)
+		if len(pending) >= s.maxSessionsPerUpload {
+			if err := flush(); err != nil {
+				return result, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return result, err
+	}
+
+	s.logger.InfoContext(ctx, "Sessions added to Vertex AI RAG memory",
+		slog.Int("sessions", len(sessions)),
+		slog.Int("added", result.Added),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("uploads", batch),
+	)
+
+	return result, nil
+}
+
+// sessionLines returns the JSON lines stored in the RAG corpus for the text content of the session events.
+func (s *VertexAIRagService) sessionLines(session types.Session) ([]string, error) {
 	var outputLines []string
 	sb := pool.String.Get()
+	defer pool.String.Put(sb)
 	for _, event := range session.Events() {
-		if event.Content == nil || len(event.Content.Parts) == 0 {
+		if event.LLMResponse == nil || event.Content == nil || len(event.Content.Parts) == 0 {
 			continue
 		}
 
@@ -133,21 +280,27 @@ func (s *VertexAIRagService) AddSessionToMemory(ctx context.Context, session typ
 			}
 
 			sb.Reset()
-			if err := json.MarshalWrite(sb, eventData, json.DefaultOptionsV2()); err != nil {
-				return fmt.Errorf("failed to marshal event data: %w", err)
+			if err := json.MarshalWrite(sb, eventData, json.DefaultOptionsV2(), json.Deterministic(true)); err != nil {
+				return nil, fmt.Errorf("failed to marshal event data: %w", err)
 			}
 			outputLines = append(outputLines, sb.String())
 		}
 	}
-	pool.String.Put(sb)
 
-	if len(outputLines) == 0 {
-		s.logger.InfoContext(ctx, "No text content found in session, skipping upload")
-		return nil
+	return outputLines, nil
+}
+
+// uploadLines uploads the lines as a single file to the RAG corpus.
+func (s *VertexAIRagService) uploadLines(ctx context.Context, ragFile *rag.RagFile, lines []string) error {
+	// Create temporary file with session content
+	tempfile, err := os.CreateTemp(os.TempDir(), "session-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
 	}
+	defer os.Remove(tempfile.Name())
 
 	// Write session content to temporary file
-	outputString := strings.Join(outputLines, "\n")
+	outputString := strings.Join(lines, "\n")
 	if _, err := tempfile.WriteString(outputString); err != nil {
 		return fmt.Errorf("failed to write to temporary file: %w", err)
 	}
@@ -157,12 +310,8 @@ func (s *VertexAIRagService) AddSessionToMemory(ctx context.Context, session typ
 	}
 
 	// Upload file to RAG corpus using new internal client
-	ragFile := &rag.RagFile{
-		DisplayName: fmt.Sprintf("session-%s-%s-%s", session.AppName(), session.UserID(), session.ID()),
-		Description: fmt.Sprintf("Session data for app %s, user %s, session %s", session.AppName(), session.UserID(), session.ID()),
-		RagFileSource: &rag.RagFileSource{
-			DirectUploadSource: &rag.DirectUploadSource{},
-		},
+	ragFile.RagFileSource = &rag.RagFileSource{
+		DirectUploadSource: &rag.DirectUploadSource{},
 	}
 
 	uploadConfig := &rag.UploadRagFileConfig{
@@ -184,6 +333,24 @@ func (s *VertexAIRagService) AddSessionToMemory(ctx context.Context, session typ
 	return nil
 }
 
+// markUploaded records the fingerprints of the uploaded lines.
+func (s *VertexAIRagService) markUploaded(lines []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, line := range lines {
+		s.uploaded.Insert(contentFingerprint([]byte(line)))
+	}
+}
+
+// isUploaded reports whether a line with the fingerprint was uploaded by this service.
+func (s *VertexAIRagService) isUploaded(fingerprint string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.uploaded.Has(fingerprint)
+}
+
 // SearchMemory implements [types.MemoryService].
 func (s *VertexAIRagService) SearchMemory(ctx context.Context, appName, userID, query string) (*types.SearchMemoryResponse, error) {
 	s.logger.InfoContext(ctx, "Searching Vertex AI RAG memory",
@@ -224,6 +391,7 @@ func (s *VertexAIRagService) SearchMemory(ctx context.Context, appName, userID,
 			memory := &types.MemoryEntry{
 				Content: genai.NewContentFromText(doc.Content, genai.RoleUser),
 				Author:  "unknown",
+				Score:   distanceToScore(doc.Distance),
 			}
 			memories = append(memories, memory)
 			continue
@@ -243,6 +411,7 @@ func (s *VertexAIRagService) SearchMemory(ctx context.Context, appName, userID,
 		memory := &types.MemoryEntry{
 			Content: genai.NewContentFromText(text, genai.RoleUser),
 			Author:  author,
+			Score:   distanceToScore(doc.Distance),
 		}
 
 		// Parse timestamp if available
@@ -266,6 +435,16 @@ func (s *VertexAIRagService) SearchMemory(ctx context.Context, appName, userID,
 	return response, nil
 }
 
+// Ping implements [types.MemoryService].
+//
+// It gets the RAG corpus, which checks that the corpus exists and that the credentials may read it.
+func (s *VertexAIRagService) Ping(ctx context.Context) error {
+	if _, err := s.client.RAG().GetCorpus(ctx, s.ragCorpus); err != nil {
+		return &types.UnhealthyError{Service: "memory.VertexAIRagService", Err: err}
+	}
+	return nil
+}
+
 // Close closes the underlying RAG client and releases resources.
 func (s *VertexAIRagService) Close() error {
 	if s.client != nil {
@@ -273,3 +452,8 @@ func (s *VertexAIRagService) Close() error {
 	}
 	return nil
 }
+
+// distanceToScore converts the vector distance of a retrieved document to a relevance score from 0 to 1.
+func distanceToScore(distance float64) float64 {
+	return min(max(1-distance, 0), 1)
+}
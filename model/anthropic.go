@@ -245,6 +245,9 @@ type Claude struct {
 	*BaseLLM
 
 	anthropicClient anthropic.Client
+
+	// safetyInstruction is the system prompt instruction translated from the safety policy.
+	safetyInstruction string
 }
 
 var _ types.Model = (*Claude)(nil)
@@ -286,6 +289,11 @@ func NewClaude(ctx context.Context, modelName string, mode ClaudeMode, opts ...O
 	for _, opt := range opts {
 		claude.Config = opt.apply(claude.Config)
 	}
+	if claude.safetyPolicy != nil {
+		instruction, warnings := claudeSafetyInstruction(claude.safetyPolicy)
+		claude.safetyInstruction = instruction
+		claude.setSafetyWarnings(ctx, warnings)
+	}
 
 	return claude, nil
 }
@@ -399,20 +407,33 @@ func (m *Claude) GenerateContent(ctx context.Context, request *types.LLMRequest)
 		}
 	}
 
-	if len(request.ToolMap) > 0 {
-		toolchoice := anthropic.ToolChoiceUnionParam{
-			OfAuto: &anthropic.ToolChoiceAutoParam{
-				DisableParallelToolUse: anthropic.Bool(false),
-			},
-		}
-		params.ToolChoice = toolchoice
+	if m.safetyInstruction != "" {
+		params.System = append(params.System, anthropic.TextBlockParam{
+			Text: m.safetyInstruction,
+		})
+	}
+
+	if request.GoogleSearchGrounding != nil {
+		return nil, &types.UnsupportedGroundingError{Model: m.modelName}
+	}
+	if err := checkInlineData(m.modelName, request.Contents, m.maxInlineBytes); err != nil {
+		return nil, err
+	}
+	toolChoice, err := claudeToolChoice(m.modelName, request)
+	if err != nil {
+		return nil, err
 	}
+	params.ToolChoice = toolChoice
+
+	dump := m.newDebugDump(m.modelName)
+	dump.request(ctx, params)
 
 	// Make API call
 	resp, err := m.anthropicClient.Messages.New(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("claude API error: %w", err)
 	}
+	dump.response(ctx, jsontext.Value(resp.RawJSON()))
 
 	return m.messageToGenerateContentResponse(ctx, resp), nil
 }
@@ -476,14 +497,29 @@ func (m *Claude) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 			}
 		}
 
-		if len(request.ToolMap) > 0 {
-			toolchoice := anthropic.ToolChoiceUnionParam{
-				OfAuto: &anthropic.ToolChoiceAutoParam{
-					DisableParallelToolUse: anthropic.Bool(false),
-				},
-			}
-			params.ToolChoice = toolchoice
+		if m.safetyInstruction != "" {
+			params.System = append(params.System, anthropic.TextBlockParam{
+				Text: m.safetyInstruction,
+			})
+		}
+
+		if request.GoogleSearchGrounding != nil {
+			yield(nil, &types.UnsupportedGroundingError{Model: m.modelName})
+			return
+		}
+		if err := checkInlineData(m.modelName, request.Contents, m.maxInlineBytes); err != nil {
+			yield(nil, err)
+			return
 		}
+		toolChoice, err := claudeToolChoice(m.modelName, request)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		params.ToolChoice = toolChoice
+
+		dump := m.newDebugDump(m.modelName)
+		dump.request(ctx, params)
 
 		// Make streaming API call - stream parameter is added by the method
 		stream := m.anthropicClient.Messages.NewStreaming(ctx, params)
@@ -493,6 +529,11 @@ func (m *Claude) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 		}
 
 		message := anthropic.Message{}
+		if dump != nil {
+			defer func() {
+				dump.response(ctx, newClaudeDumpResponse(&message))
+			}()
+		}
 		for stream.Next() {
 			// Accumulate the response
 			llmResp := stream.Current()
@@ -568,3 +609,40 @@ func (m *Claude) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 		}
 	}
 }
+
+// claudeDumpResponse is the assembled representation of a streamed Claude message used for debug dumps.
+type claudeDumpResponse struct {
+	ID           string           `json:"id"`
+	Model        string           `json:"model"`
+	Role         string           `json:"role"`
+	Content      []jsontext.Value `json:"content"`
+	StopReason   string           `json:"stop_reason,omitempty"`
+	StopSequence string           `json:"stop_sequence,omitempty"`
+	Usage        map[string]int64 `json:"usage"`
+}
+
+// newClaudeDumpResponse returns the accumulated message for debug dumps.
+//
+// The raw JSON of the content blocks is used as-is, since [anthropic.Message.Accumulate]
+// only refreshes the raw JSON of the whole message once the stream has stopped.
+func newClaudeDumpResponse(message *anthropic.Message) *claudeDumpResponse {
+	content := make([]jsontext.Value, 0, len(message.Content))
+	for _, block := range message.Content {
+		if raw := block.RawJSON(); raw != "" {
+			content = append(content, jsontext.Value(raw))
+		}
+	}
+
+	return &claudeDumpResponse{
+		ID:           message.ID,
+		Model:        string(message.Model),
+		Role:         string(message.Role),
+		Content:      content,
+		StopReason:   string(message.StopReason),
+		StopSequence: message.StopSequence,
+		Usage: map[string]int64{
+			"input_tokens":  message.Usage.InputTokens,
+			"output_tokens": message.Usage.OutputTokens,
+		},
+	}
+}
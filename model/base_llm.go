@@ -48,6 +48,13 @@ func (m *BaseLLM) SupportedModels() []string {
 	return nil
 }
 
+// SupportsLive implements [types.LiveSupporter].
+//
+// The base model has no live connection.
+func (m *BaseLLM) SupportsLive() bool {
+	return false
+}
+
 // Connect implements [Model].
 func (m *BaseLLM) Connect(context.Context, *types.LLMRequest) (types.ModelConnection, error) {
 	return nil, types.NotImplementedError(fmt.Sprintf("BaseLLM: Live connection is not supported for %s", m.modelName))
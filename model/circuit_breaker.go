@@ -0,0 +1,101 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"iter"
+
+	"github.com/go-a2a/adk-go/pkg/circuitbreaker"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// CircuitBrokenModel wraps a [types.Model] with a [*circuitbreaker.Breaker].
+//
+// While the breaker is open, calls fail fast with an error matching [circuitbreaker.ErrCircuitOpen]
+// without reaching the underlying model.
+type CircuitBrokenModel struct {
+	types.Model
+
+	breaker *circuitbreaker.Breaker
+}
+
+var _ types.Model = (*CircuitBrokenModel)(nil)
+
+// NewCircuitBroken returns the [*CircuitBrokenModel] guarding inner with breaker.
+func NewCircuitBroken(inner types.Model, breaker *circuitbreaker.Breaker) *CircuitBrokenModel {
+	return &CircuitBrokenModel{
+		Model:   inner,
+		breaker: breaker,
+	}
+}
+
+// Breaker returns the circuit breaker of the model.
+func (m *CircuitBrokenModel) Breaker() *circuitbreaker.Breaker {
+	return m.breaker
+}
+
+// Connect implements [types.Model].
+//
+// Only establishing the connection is guarded by the breaker.
+func (m *CircuitBrokenModel) Connect(ctx context.Context, request *types.LLMRequest) (types.ModelConnection, error) {
+	var conn types.ModelConnection
+	err := m.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		conn, err = m.Model.Connect(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// GenerateContent implements [types.Model].
+func (m *CircuitBrokenModel) GenerateContent(ctx context.Context, request *types.LLMRequest) (*types.LLMResponse, error) {
+	var resp *types.LLMResponse
+	err := m.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		resp, err = m.Model.GenerateContent(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// StreamGenerateContent implements [types.Model].
+//
+// The outcome recorded by the breaker is the first error of the stream. A stream abandoned
+// by the caller before its end counts as a success.
+func (m *CircuitBrokenModel) StreamGenerateContent(ctx context.Context, request *types.LLMRequest) iter.Seq2[*types.LLMResponse, error] {
+	return func(yield func(*types.LLMResponse, error) bool) {
+		done, err := m.breaker.Allow()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		var streamErr error
+		defer func() { done(streamErr) }()
+
+		for resp, err := range m.Model.StreamGenerateContent(ctx, request) {
+			if err != nil && streamErr == nil {
+				streamErr = err
+			}
+			if !yield(resp, err) {
+				return
+			}
+		}
+	}
+}
+
+// SupportsLive implements [types.LiveSupporter], reporting whether the wrapped model supports live
+// connections.
+func (m *CircuitBrokenModel) SupportsLive() bool {
+	return types.SupportsLive(m.Model)
+}
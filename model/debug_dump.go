@@ -0,0 +1,171 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+)
+
+// redactedValue replaces the value of sensitive fields in debug dumps.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys lists the normalized JSON object keys whose values are redacted from debug dumps.
+var sensitiveKeys = []string{
+	"apikey",
+	"xgoogapikey",
+	"xapikey",
+	"authorization",
+	"accesstoken",
+	"refreshtoken",
+	"clientsecret",
+	"secret",
+	"password",
+}
+
+// debugDumpSeq is the process wide sequence number used to pair request and response dumps.
+var debugDumpSeq atomic.Uint64
+
+type debugDumpOption string
+
+func (o debugDumpOption) apply(base Config) Config {
+	base.debugDumpDir = string(o)
+	return base
+}
+
+// WithDebugDump enables writing each model request and response to dir as pretty-printed JSON files.
+//
+// Every call produces a "<timestamp>-<seq>-<model>-request.json" and a matching
+// "-response.json" file. Streaming responses are assembled into a single response
+// before being dumped. Values of well-known credential fields such as API keys and
+// authorization headers are redacted.
+//
+// Files are written asynchronously so dumping never blocks the generation. Passing
+// an empty dir disables dumping, which is also the default.
+func WithDebugDump(dir string) Option {
+	return debugDumpOption(dir)
+}
+
+// debugDump represents a single request/response pair of debug dump files.
+type debugDump struct {
+	dir    string
+	prefix string
+	logger *slog.Logger
+}
+
+// newDebugDump returns the [*debugDump] for a single model call, or nil if debug dumping is disabled.
+func (c Config) newDebugDump(modelName string) *debugDump {
+	if c.debugDumpDir == "" {
+		return nil
+	}
+
+	name := strings.NewReplacer("/", "_", ":", "_", "@", "_", string(filepath.Separator), "_").Replace(modelName)
+	prefix := fmt.Sprintf("%s-%06d-%s", time.Now().UTC().Format("20060102T150405.000000000Z"), debugDumpSeq.Add(1), name)
+
+	return &debugDump{
+		dir:    c.debugDumpDir,
+		prefix: prefix,
+		logger: c.logger,
+	}
+}
+
+// request dumps the request v.
+func (d *debugDump) request(ctx context.Context, v any) {
+	d.write(ctx, "request", v)
+}
+
+// response dumps the response v.
+func (d *debugDump) response(ctx context.Context, v any) {
+	d.write(ctx, "response", v)
+}
+
+// write marshals v synchronously, so later mutations by the caller are not observed,
+// and writes the redacted pretty-printed JSON to disk in a separate goroutine.
+//
+// A nil d is a no-op.
+func (d *debugDump) write(ctx context.Context, kind string, v any) {
+	if d == nil {
+		return
+	}
+
+	data, err := json.Marshal(v, json.DefaultOptionsV2())
+	if err != nil {
+		d.logger.WarnContext(ctx, "marshal debug dump", slog.String("kind", kind), slog.Any("err", err))
+		return
+	}
+
+	filename := filepath.Join(d.dir, d.prefix+"-"+kind+".json")
+	go func() {
+		if err := writeDebugDump(filename, data); err != nil {
+			d.logger.Warn("write debug dump", slog.String("filename", filename), slog.Any("err", err))
+		}
+	}()
+}
+
+// writeDebugDump redacts and indents the JSON data and writes it to filename.
+func writeDebugDump(filename string, data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("unmarshal debug dump: %w", err)
+	}
+
+	out, err := json.Marshal(redact(v), json.Deterministic(true), jsontext.WithIndent("  "))
+	if err != nil {
+		return fmt.Errorf("marshal debug dump: %w", err)
+	}
+	out = append(out, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return fmt.Errorf("create debug dump dir: %w", err)
+	}
+
+	return os.WriteFile(filename, out, 0o600)
+}
+
+// redact replaces the values of sensitive keys in the decoded JSON value v.
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, val := range v {
+			if isSensitiveKey(key) {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redact(val)
+		}
+		return v
+
+	case []any:
+		for i, val := range v {
+			v[i] = redact(val)
+		}
+		return v
+
+	default:
+		return v
+	}
+}
+
+// isSensitiveKey reports whether the JSON object key holds a credential.
+func isSensitiveKey(key string) bool {
+	normalized := strings.Map(func(r rune) rune {
+		switch r {
+		case '_', '-', '.', ' ':
+			return -1
+		}
+		return r
+	}, strings.ToLower(key))
+
+	return slices.Contains(sensitiveKeys, normalized)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+)
+
+func TestWriteDebugDump_Redacts(t *testing.T) {
+	t.Parallel()
+
+	req := &geminiDumpRequest{
+		Model: "gemini-2.0-flash",
+		Contents: []*genai.Content{
+			genai.NewContentFromText("hello", genai.RoleUser),
+		},
+		Config: &genai.GenerateContentConfig{
+			HTTPOptions: &genai.HTTPOptions{
+				Headers: map[string][]string{
+					"X-Goog-Api-Key": {"secret-key"},
+					"Authorization":  {"Bearer token"},
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(req, json.DefaultOptionsV2())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(t.TempDir(), "dump", "request.json")
+	if err := writeDebugDump(filename, data); err != nil {
+		t.Fatalf("writeDebugDump: %v", err)
+	}
+
+	out, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	headers := got["config"].(map[string]any)["httpOptions"].(map[string]any)["headers"]
+	want := map[string]any{
+		"X-Goog-Api-Key": redactedValue,
+		"Authorization":  redactedValue,
+	}
+	if diff := cmp.Diff(want, headers); diff != "" {
+		t.Errorf("headers mismatch (-want +got):\n%s", diff)
+	}
+	if got["model"] != "gemini-2.0-flash" {
+		t.Errorf("model = %v, want %q", got["model"], "gemini-2.0-flash")
+	}
+}
+
+func TestConfig_NewDebugDump_Disabled(t *testing.T) {
+	t.Parallel()
+
+	dump := newConfig().newDebugDump("gemini-2.0-flash")
+	if dump != nil {
+		t.Fatalf("newDebugDump = %v, want nil", dump)
+	}
+
+	// must be a no-op
+	dump.request(t.Context(), map[string]any{"model": "gemini-2.0-flash"})
+}
+
+func TestAssembleGeminiStream(t *testing.T) {
+	t.Parallel()
+
+	chunk := func(text string, finish genai.FinishReason) *genai.GenerateContentResponse {
+		return &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{
+				{
+					Content:      genai.NewContentFromText(text, genai.RoleModel),
+					FinishReason: finish,
+				},
+			},
+		}
+	}
+
+	got := assembleGeminiStream([]*genai.GenerateContentResponse{
+		chunk("Hello, ", ""),
+		chunk("world", ""),
+		chunk("!", genai.FinishReasonStop),
+	})
+
+	want := &genai.GenerateContentResponse{
+		Candidates: []*genai.Candidate{
+			{
+				Content:      genai.NewContentFromText("Hello, world!", genai.RoleModel),
+				FinishReason: genai.FinishReasonStop,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("assembleGeminiStream mismatch (-want +got):\n%s", diff)
+	}
+
+	if got := assembleGeminiStream(nil); got != nil {
+		t.Errorf("assembleGeminiStream(nil) = %v, want nil", got)
+	}
+}
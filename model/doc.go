@@ -87,6 +87,11 @@
 //		}
 //	}
 //
+// CollectStream consumes a stream and merges it into a single response, with the full text,
+// the merged function calls, and the final finish reason and usage metadata:
+//
+//	response, err := model.CollectStream(llm.StreamGenerateContent(ctx, request))
+//
 // # Live Connections
 //
 // Some providers support stateful live connections for real-time interactions:
@@ -104,6 +109,9 @@
 //		}
 //	}
 //
+// Gemini supports live connections, Claude does not. [types.SupportsLive] reports it for any
+// model, including the models wrapped by [NewTraced] or [NewCircuitBroken].
+//
 // # Model Configuration
 //
 // Models support extensive configuration options:
@@ -117,6 +125,24 @@
 //		model.WithSystemInstruction("You are a helpful assistant"),
 //	)
 //
+// # Safety Policy
+//
+// A [types.SafetyPolicy] declares the content safety intent once for every provider. Gemini
+// translates it to safety settings and Claude to a system prompt instruction. Rules a provider
+// cannot honor, such as turning the Claude safety behavior off, are logged and reported by
+// SafetyWarnings:
+//
+//	policy := types.NewSafetyPolicy().
+//		WithRule(types.SafetyCategoryHarassment, types.SafetyThresholdBlockMediumAndAbove).
+//		WithRule(types.SafetyCategoryDangerousContent, types.SafetyThresholdBlockLowAndAbove)
+//
+//	claude, err := model.NewClaude(ctx, "claude-3-5-sonnet-20241022", model.ClaudeModeAnthropic,
+//		model.WithSafetyPolicy(policy),
+//	)
+//	for _, w := range claude.SafetyWarnings() {
+//		log.Println(w)
+//	}
+//
 // # Claude Integration
 //
 // Claude models support multiple deployment modes:
@@ -144,6 +170,65 @@
 //	// Now factory can create custom models
 //	customModel, err := factory.CreateModel(ctx, "my-custom-model-v1")
 //
+// A registration can name itself and list the model names it is expected to handle, so that a
+// pattern of another registration matching them fails the registration with an
+// AmbiguousMatchError instead of silently taking over the names:
+//
+//	err := model.RegisterLLMType([]string{`my-custom-model-.*`}, newCustomModel,
+//		model.WithRegistryLabel("custom"),
+//		model.WithSampleNames("my-custom-model-v1"),
+//	)
+//
+// ListRegistered lists the registered patterns, and ResolveFactory explains which registration
+// handles a model name, or reports the registrations matching it ambiguously:
+//
+//	entry, err := model.ResolveFactory("my-custom-model-v1")
+//	log.Printf("resolved by %s (%s)", entry.Label, entry.Pattern)
+//
+// # Uploading Files
+//
+// Large documents and media are uploaded once with the Files API and referenced by URI instead
+// of being inlined in each request. UploadFile waits until the file is ready to be used:
+//
+//	file, err := gemini.UploadFile(ctx, f, "application/pdf", "report.pdf")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer gemini.DeleteFile(ctx, file)
+//	content := genai.NewContentFromParts([]*genai.Part{{FileData: file}}, genai.RoleUser)
+//
+// UploadArtifact does the same for an artifact stored in an artifact service.
+//
+// WithMaxInlineBytes makes the upload automatic: the inline data parts of a request over the
+// size are uploaded once, until the file is about to expire, and sent as file references. The
+// models without a Files API, such as Claude or Gemini on Vertex AI, report an
+// [types.ErrInlineDataTooLarge] error for them instead of failing obscurely at the provider:
+//
+//	gemini, err := model.NewGemini(ctx, apiKey, "gemini-2.0-flash", model.WithMaxInlineBytes(15<<20))
+//
+// # JSON Responses
+//
+// A JSON response of Gemini may come wrapped in a code fence or prose, or with trailing commas.
+// WithJSONRepair repairs the responses of the requests whose ResponseMIMEType is
+// "application/json", and WithJSONRepairReprompt also re-prompts the model once with the parse
+// error when the repair fails. A streamed response is repaired once aggregated, as the agents
+// stream their model calls:
+//
+//	gemini, err := model.NewGemini(ctx, apiKey, "gemini-2.0-flash", model.WithJSONRepairReprompt())
+//	// ...
+//	if resp.JSONRepaired {
+//		log.Println("the JSON response was repaired")
+//	}
+//
+// # Tracing
+//
+// NewTraced wraps any model, including custom registered ones, to record each call as an
+// OpenTelemetry span following the generative AI semantic conventions (gen_ai.system,
+// gen_ai.request.model, token usage, finish reasons), with the time to the first response
+// of streaming calls:
+//
+//	traced := model.NewTraced(gemini, otel.Tracer("my-app"))
+//
 // # Error Handling
 //
 // The package provides detailed error information:
@@ -181,6 +266,28 @@
 //		},
 //	}
 //
+// A provider-neutral [types.ToolChoice] forces a tool, forces any tool or disables the tools of a
+// request, translated to the function calling mode and allowed function names of Gemini and to
+// the tool_choice of Claude:
+//
+//	request := types.NewLLMRequest(contents, types.WithForcedTool("extract_invoice"))
+//
+// A model reports an [types.ErrUnsupportedToolChoice] error for a choice it cannot honor, such as
+// the Gemini live connections, which have no function calling config.
+//
+// # Grounding with Google Search
+//
+// A request, or an agent with the WithGoogleSearchGrounding option, can ground the Gemini answers
+// with Google Search. Gemini 1.x models use the dynamic retrieval tool, retrieving only above the
+// optional threshold, and later models the search tool, restricted to the optional time range:
+//
+//	request := types.NewLLMRequest(contents,
+//		types.WithGoogleSearchGrounding(true, types.WithDynamicRetrievalThreshold(0.3)),
+//	)
+//
+// The citations of the grounded answers are extracted into the response events. Models without
+// Google Search grounding, such as Claude, report an [types.ErrUnsupportedGrounding] error.
+//
 // # Content Caching
 //
 // Support for content caching to optimize token usage:
@@ -190,6 +297,17 @@
 //		CachedContent: "projects/my-project/locations/us-central1/cachedContents/my-cache",
 //	}
 //
+// # Comparing Responses
+//
+// [DiffResponses] compares the responses of two models or two prompt versions to the same
+// request: the final texts word by word, the function calls added, removed or called with other
+// arguments, the finish reasons and the token usage. Changed reports whether the behavior differs,
+// such as in a regression test of an agent:
+//
+//	if diff := model.DiffResponses(baseline, candidate); diff.Changed() {
+//		t.Errorf("response changed:\n%s", diff)
+//	}
+//
 // # Thread Safety
 //
 // All model implementations are safe for concurrent use across multiple goroutines.
@@ -0,0 +1,141 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultFilePollInterval is the default interval at which [Gemini.UploadFile] polls an uploaded
+// file until it is ready.
+const DefaultFilePollInterval = 2 * time.Second
+
+type filePollIntervalOption time.Duration
+
+func (o filePollIntervalOption) apply(base Config) Config {
+	base.filePollInterval = time.Duration(o)
+	return base
+}
+
+// WithFilePollInterval sets the interval at which [Gemini.UploadFile] polls an uploaded file
+// until it is ready. The default is [DefaultFilePollInterval].
+func WithFilePollInterval(d time.Duration) Option {
+	return filePollIntervalOption(d)
+}
+
+// UploadFile uploads the content of r with the Files API and returns a reference to it, to be sent
+// to the model with [genai.NewPartFromURI] or as the FileData of a [genai.Part] instead of the inline bytes.
+//
+// It waits until the file has been processed and is ready to be used, or ctx is done. The file
+// is deleted from the Files API if its processing fails.
+func (m *Gemini) UploadFile(ctx context.Context, r io.Reader, mimeType, displayName string) (*genai.FileData, error) {
+	file, err := m.uploadFile(ctx, r, mimeType, displayName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &genai.FileData{
+		DisplayName: file.DisplayName,
+		FileURI:     file.URI,
+		MIMEType:    file.MIMEType,
+	}, nil
+}
+
+// uploadFile uploads the content of r with the Files API and returns the file once it is ready.
+func (m *Gemini) uploadFile(ctx context.Context, r io.Reader, mimeType, displayName string) (*genai.File, error) {
+	file, err := m.genAIClient.Files.Upload(ctx, r, &genai.UploadFileConfig{
+		MIMEType:    mimeType,
+		DisplayName: displayName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upload file: %w", err)
+	}
+	m.logger.DebugContext(ctx, "uploaded file",
+		slog.String("name", file.Name),
+		slog.String("mime_type", file.MIMEType),
+		slog.String("state", string(file.State)),
+	)
+
+	return m.waitFileActive(ctx, file)
+}
+
+// UploadArtifact uploads a stored artifact with the Files API, see [Gemini.UploadFile].
+//
+// A version of zero or less uploads the latest version of the artifact. The artifact is streamed
+// from the artifact service without being loaded in memory.
+func (m *Gemini) UploadArtifact(ctx context.Context, artifacts types.ArtifactService, appName, userID, sessionID, filename string, version int) (*genai.FileData, error) {
+	rc, mimeType, err := artifacts.LoadArtifactStream(ctx, appName, userID, sessionID, filename, version)
+	if err != nil {
+		return nil, fmt.Errorf("load artifact %s: %w", filename, err)
+	}
+	defer rc.Close()
+
+	return m.UploadFile(ctx, rc, mimeType, filename)
+}
+
+// DeleteFile deletes a file uploaded by [Gemini.UploadFile].
+func (m *Gemini) DeleteFile(ctx context.Context, file *genai.FileData) error {
+	if file == nil || file.FileURI == "" {
+		return errors.New("delete file: no file URI")
+	}
+
+	name := fileName(file.FileURI)
+	if _, err := m.genAIClient.Files.Delete(ctx, name, nil); err != nil {
+		return fmt.Errorf("delete file %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// waitFileActive polls the file until its processing is done.
+func (m *Gemini) waitFileActive(ctx context.Context, file *genai.File) (*genai.File, error) {
+	interval := m.filePollInterval
+	if interval <= 0 {
+		interval = DefaultFilePollInterval
+	}
+
+	for {
+		switch file.State {
+		case genai.FileStateActive, genai.FileStateUnspecified, "":
+			return file, nil
+		case genai.FileStateFailed:
+			if _, err := m.genAIClient.Files.Delete(context.WithoutCancel(ctx), file.Name, nil); err != nil {
+				m.logger.WarnContext(ctx, "delete failed file", slog.String("name", file.Name), slog.Any("err", err))
+			}
+			if file.Error != nil {
+				return nil, fmt.Errorf("process file %s: %s", file.Name, file.Error.Message)
+			}
+			return nil, fmt.Errorf("process file %s: failed", file.Name)
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("wait for file %s: %w", file.Name, ctx.Err())
+		case <-timer.C:
+		}
+
+		next, err := m.genAIClient.Files.Get(ctx, file.Name, nil)
+		if err != nil {
+			return nil, fmt.Errorf("get file %s: %w", file.Name, err)
+		}
+		file = next
+	}
+}
+
+// fileName returns the resource name of the file, such as "files/abc-123", from its URI.
+func fileName(uri string) string {
+	return "files/" + path.Base(uri)
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/artifact"
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// fakeFilesAPI serves the resumable upload, get and delete methods of the Files API.
+//
+// An uploaded file is processing until it is polled pollsUntilActive times, and expires at
+// expiration if set.
+type fakeFilesAPI struct {
+	pollsUntilActive int
+	expiration       time.Time
+
+	mu       sync.Mutex
+	file     map[string]any
+	uploaded []byte
+	polls    int
+	deleted  []string
+}
+
+func (f *fakeFilesAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	writeJSON := func(v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.MarshalWrite(w, v, json.DefaultOptionsV2())
+	}
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/upload/v1beta/files":
+		var req struct {
+			File map[string]any `json:"file"`
+		}
+		json.UnmarshalRead(r.Body, &req, json.DefaultOptionsV2())
+		f.file = req.File
+		f.file["name"] = "files/abc-123"
+		f.file["uri"] = "http://" + r.Host + "/v1beta/files/abc-123"
+		f.file["mimeType"] = r.Header.Get("X-Goog-Upload-Header-Content-Type")
+		f.file["state"] = string(genai.FileStateProcessing)
+		if !f.expiration.IsZero() {
+			f.file["expirationTime"] = f.expiration.UTC().Format(time.RFC3339Nano)
+		}
+		w.Header().Set("X-Goog-Upload-Url", "http://"+r.Host+"/upload-session")
+		writeJSON(map[string]any{})
+
+	case r.Method == http.MethodPost && r.URL.Path == "/upload-session":
+		data, _ := io.ReadAll(r.Body)
+		f.uploaded = append(f.uploaded, data...)
+		w.Header().Set("X-Goog-Upload-Status", "final")
+		writeJSON(map[string]any{"file": f.file})
+
+	case r.Method == http.MethodGet && r.URL.Path == "/v1beta/files/abc-123":
+		f.polls++
+		if f.polls >= f.pollsUntilActive {
+			f.file["state"] = string(genai.FileStateActive)
+		}
+		writeJSON(f.file)
+
+	case r.Method == http.MethodDelete && r.URL.Path == "/v1beta/files/abc-123":
+		f.deleted = append(f.deleted, strings.TrimPrefix(r.URL.Path, "/v1beta/"))
+		writeJSON(map[string]any{})
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newFakeFilesGemini(t *testing.T, api *fakeFilesAPI) *model.Gemini {
+	t.Helper()
+
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+	t.Setenv("GOOGLE_GEMINI_BASE_URL", srv.URL)
+
+	gemini, err := model.NewGemini(t.Context(), "test-key", "gemini-2.0-flash", model.WithFilePollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewGemini: %v", err)
+	}
+	return gemini
+}
+
+func TestGemini_UploadFile(t *testing.T) {
+	api := &fakeFilesAPI{pollsUntilActive: 2}
+	gemini := newFakeFilesGemini(t, api)
+
+	got, err := gemini.UploadFile(t.Context(), strings.NewReader("%PDF-1.7"), "application/pdf", "report.pdf")
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+
+	want := &genai.FileData{
+		DisplayName: "report.pdf",
+		FileURI:     api.file["uri"].(string),
+		MIMEType:    "application/pdf",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UploadFile() mismatch (-want +got):\n%s", diff)
+	}
+	if api.polls != 2 {
+		t.Errorf("file polled %d times, want 2", api.polls)
+	}
+	if string(api.uploaded) != "%PDF-1.7" {
+		t.Errorf("uploaded %q, want %q", api.uploaded, "%PDF-1.7")
+	}
+
+	if err := gemini.DeleteFile(t.Context(), got); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"files/abc-123"}, api.deleted); diff != "" {
+		t.Errorf("deleted files mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGemini_UploadArtifact(t *testing.T) {
+	api := &fakeFilesAPI{pollsUntilActive: 1}
+	gemini := newFakeFilesGemini(t, api)
+
+	artifacts := artifact.NewInMemoryService()
+	part := genai.NewPartFromBytes([]byte("\x89PNG"), "image/png")
+	if _, err := artifacts.SaveArtifact(t.Context(), "app", "user", "session", "chart.png", part); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := gemini.UploadArtifact(t.Context(), artifacts, "app", "user", "session", "chart.png", 0)
+	if err != nil {
+		t.Fatalf("UploadArtifact() error = %v", err)
+	}
+
+	want := &genai.FileData{
+		DisplayName: "chart.png",
+		FileURI:     api.file["uri"].(string),
+		MIMEType:    "image/png",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("UploadArtifact() mismatch (-want +got):\n%s", diff)
+	}
+	if string(api.uploaded) != "\x89PNG" {
+		t.Errorf("uploaded %q, want %q", api.uploaded, "\x89PNG")
+	}
+}
+
+// newFakeGenerateGemini returns a Gemini model with the inline data limit of 8 bytes, serving the
+// Files API with api and recording the generateContent requests.
+func newFakeGenerateGemini(t *testing.T, api *fakeFilesAPI) (*model.Gemini, func() []map[string]any) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []map[string]any
+	)
+	mux := http.NewServeMux()
+	mux.Handle("/", api)
+	mux.HandleFunc("POST /v1beta/models/gemini-2.0-flash:generateContent", func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		json.UnmarshalRead(r.Body, &req, json.DefaultOptionsV2())
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.MarshalWrite(w, map[string]any{
+			"candidates": []any{map[string]any{
+				"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": "a chart"}}},
+			}},
+		}, json.DefaultOptionsV2())
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	t.Setenv("GOOGLE_GEMINI_BASE_URL", srv.URL)
+
+	gemini, err := model.NewGemini(t.Context(), "test-key", "gemini-2.0-flash",
+		model.WithFilePollInterval(time.Millisecond),
+		model.WithMaxInlineBytes(8),
+	)
+	if err != nil {
+		t.Fatalf("NewGemini: %v", err)
+	}
+	return gemini, func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestGemini_MaxInlineBytes(t *testing.T) {
+	api := &fakeFilesAPI{pollsUntilActive: 1}
+	gemini, received := newFakeGenerateGemini(t, api)
+
+	large := genai.NewPartFromBytes([]byte("\x89PNG large image"), "image/png")
+	small := genai.NewPartFromBytes([]byte("\x89PNG"), "image/png")
+	for range 2 {
+		contents := []*genai.Content{genai.NewContentFromParts([]*genai.Part{large, small, genai.NewPartFromText("describe")}, genai.RoleUser)}
+		request := types.NewLLMRequest(contents)
+		if _, err := gemini.GenerateContent(t.Context(), request); err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+		if request.Contents[0] != contents[0] || contents[0].Parts[0] != large || large.InlineData == nil {
+			t.Fatal("GenerateContent() modified the contents of the request")
+		}
+	}
+
+	if string(api.uploaded) != "\x89PNG large image" {
+		t.Errorf("uploaded %q, want the large image once", api.uploaded)
+	}
+	requests := received()
+	if len(requests) != 2 {
+		t.Fatalf("model received %d requests, want 2", len(requests))
+	}
+	for i, req := range requests {
+		parts := req["contents"].([]any)[0].(map[string]any)["parts"].([]any)
+		if _, ok := parts[0].(map[string]any)["fileData"]; !ok {
+			t.Errorf("request %d: large part = %v, want a file reference", i, parts[0])
+		}
+		if _, ok := parts[1].(map[string]any)["inlineData"]; !ok {
+			t.Errorf("request %d: small part = %v, want inline data", i, parts[1])
+		}
+	}
+}
+
+func TestGemini_MaxInlineBytesExpiringFile(t *testing.T) {
+	// The uploaded file expires within the margin, so it is not reused.
+	api := &fakeFilesAPI{pollsUntilActive: 1, expiration: time.Now().Add(time.Minute)}
+	gemini, received := newFakeGenerateGemini(t, api)
+
+	large := genai.NewPartFromBytes([]byte("\x89PNG large image"), "image/png")
+	for range 2 {
+		contents := []*genai.Content{genai.NewContentFromParts([]*genai.Part{large}, genai.RoleUser)}
+		if _, err := gemini.GenerateContent(t.Context(), types.NewLLMRequest(contents)); err != nil {
+			t.Fatalf("GenerateContent() error = %v", err)
+		}
+	}
+
+	if string(api.uploaded) != "\x89PNG large image\x89PNG large image" {
+		t.Errorf("uploaded %q, want the large image twice", api.uploaded)
+	}
+	if got := len(received()); got != 2 {
+		t.Errorf("model received %d requests, want 2", got)
+	}
+}
+
+func TestClaude_MaxInlineBytes(t *testing.T) {
+	claude, err := model.NewClaude(t.Context(), "claude-sonnet-4-0", model.ClaudeModeAnthropic, model.WithMaxInlineBytes(8))
+	if err != nil {
+		t.Fatalf("NewClaude: %v", err)
+	}
+
+	contents := []*genai.Content{genai.NewContentFromBytes([]byte("\x89PNG large image"), "image/png", genai.RoleUser)}
+	_, err = claude.GenerateContent(t.Context(), types.NewLLMRequest(contents))
+	var sizeErr *types.InlineDataTooLargeError
+	if !errors.Is(err, types.ErrInlineDataTooLarge) || !errors.As(err, &sizeErr) {
+		t.Fatalf("GenerateContent() error = %v, want %v", err, types.ErrInlineDataTooLarge)
+	}
+	if sizeErr.Size != 16 || sizeErr.Limit != 8 || sizeErr.MIMEType != "image/png" {
+		t.Errorf("GenerateContent() error = %+v, want a 16 bytes image/png over 8 bytes", sizeErr)
+	}
+}
@@ -32,9 +32,15 @@ type Gemini struct {
 	*BaseLLM
 
 	genAIClient *genai.Client
+
+	// uploads are the files uploaded for the inline data over the limit, see WithMaxInlineBytes.
+	uploads inlineUploads
 }
 
-var _ types.Model = (*Gemini)(nil)
+var (
+	_ types.Model        = (*Gemini)(nil)
+	_ types.TokenCounter = (*Gemini)(nil)
+)
 
 // NewGemini creates a new [Gemini] instance.
 func NewGemini(ctx context.Context, apiKey, modelName string, opts ...Option) (*Gemini, error) {
@@ -78,6 +84,11 @@ func NewGemini(ctx context.Context, apiKey, modelName string, opts ...Option) (*
 	for _, opt := range opts {
 		gemini.Config = opt.apply(gemini.Config)
 	}
+	if gemini.safetyPolicy != nil {
+		settings, warnings := geminiSafetySettings(gemini.safetyPolicy)
+		gemini.safetySettings = append(gemini.safetySettings, settings...)
+		gemini.setSafetyWarnings(ctx, warnings)
+	}
 
 	return gemini, nil
 }
@@ -114,8 +125,23 @@ func (m *Gemini) SupportedModels() []string {
 	}
 }
 
+// SupportsLive implements [types.LiveSupporter].
+func (m *Gemini) SupportsLive() bool {
+	return true
+}
+
 // Connect creates a live connection to the Gemini LLM.
-func (m *Gemini) Connect(ctx context.Context, _ *types.LLMRequest) (types.ModelConnection, error) {
+//
+// The live API has no function calling config, so that a request forcing or disabling the tools
+// is reported as an [*types.UnsupportedToolChoiceError].
+func (m *Gemini) Connect(ctx context.Context, request *types.LLMRequest) (types.ModelConnection, error) {
+	if request != nil && !request.ToolChoice.IsAuto() {
+		return nil, &types.UnsupportedToolChoiceError{Model: m.modelName, Choice: request.ToolChoice}
+	}
+	if request != nil && request.GoogleSearchGrounding != nil {
+		return nil, &types.UnsupportedGroundingError{Model: m.modelName}
+	}
+
 	// Create and return a new connection
 	return newGeminiConnection(ctx, m.modelName, m.genAIClient), nil
 }
@@ -125,14 +151,55 @@ func (m *Gemini) GenerateContent(ctx context.Context, request *types.LLMRequest)
 	// Ensure the last message is from the user
 	request.Contents = m.appendUserContent(request.Contents)
 
+	config, err := geminiToolConfig(m.modelName, mergeSafetySettings(request.Config, m.safetySettings), request)
+	if err != nil {
+		return nil, err
+	}
+	if config, err = geminiGrounding(m.modelName, config, request); err != nil {
+		return nil, err
+	}
+	contents, err := m.offloadInlineData(ctx, request.Contents)
+	if err != nil {
+		return nil, err
+	}
+
+	dump := m.newDebugDump(m.modelName)
+	dump.request(ctx, newGeminiDumpRequest(m.modelName, contents, config))
+
 	// Generate content
-	response, err := m.genAIClient.Models.GenerateContent(ctx, m.modelName, request.Contents, request.Config)
+	response, err := m.genAIClient.Models.GenerateContent(ctx, m.modelName, contents, config)
 	if err != nil {
 		return nil, fmt.Errorf("gemini API error: %w", err)
 	}
 	m.logger.DebugContext(ctx, "response", buildResponseLog(response))
+	dump.response(ctx, response)
+
+	llmResp := types.CreateLLMResponse(response)
+	if m.jsonRepair && wantsJSON(config) {
+		return m.repairJSONResponse(ctx, contents, llmResp, m.reprompt(config))
+	}
 
-	return types.CreateLLMResponse(response), nil
+	return llmResp, nil
+}
+
+// reprompt returns the function generating the response to the re-prompt of the JSON repair.
+func (m *Gemini) reprompt(config *genai.GenerateContentConfig) func(context.Context, []*genai.Content) (*types.LLMResponse, error) {
+	return func(ctx context.Context, contents []*genai.Content) (*types.LLMResponse, error) {
+		response, err := m.genAIClient.Models.GenerateContent(ctx, m.modelName, contents, config)
+		if err != nil {
+			return nil, fmt.Errorf("gemini API error: %w", err)
+		}
+		return types.CreateLLMResponse(response), nil
+	}
+}
+
+// CountTokens implements [types.TokenCounter].
+func (m *Gemini) CountTokens(ctx context.Context, request *types.LLMRequest) (int, error) {
+	response, err := m.genAIClient.Models.CountTokens(ctx, m.modelName, m.appendUserContent(request.Contents), nil)
+	if err != nil {
+		return 0, fmt.Errorf("gemini API error: %w", err)
+	}
+	return int(response.TotalTokens), nil
 }
 
 // StreamGenerateContent streams generated content from the model.
@@ -140,14 +207,46 @@ func (m *Gemini) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 	return func(yield func(*types.LLMResponse, error) bool) {
 		// Ensure the last message is from the user
 		contents := m.appendUserContent(request.Contents)
+		config, err := geminiToolConfig(m.modelName, mergeSafetySettings(request.Config, m.safetySettings), request)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if config, err = geminiGrounding(m.modelName, config, request); err != nil {
+			yield(nil, err)
+			return
+		}
+		if contents, err = m.offloadInlineData(ctx, contents); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		dump := m.newDebugDump(m.modelName)
+		dump.request(ctx, newGeminiDumpRequest(m.modelName, contents, config))
 
 		// Stream generate content
-		stream := m.genAIClient.Models.GenerateContentStream(ctx, m.modelName, contents, request.Config)
+		stream := m.genAIClient.Models.GenerateContentStream(ctx, m.modelName, contents, config)
 
 		var (
 			buf      strings.Builder
 			lastResp *genai.GenerateContentResponse
+			chunks   []*genai.GenerateContentResponse
 		)
+		// aggregate returns the response of the text streamed so far, repaired as the response of
+		// GenerateContent.
+		aggregate := func() (*types.LLMResponse, error) {
+			resp := newAggregateText(buf.String())
+			buf.Reset()
+			if m.jsonRepair && wantsJSON(config) {
+				return m.repairJSONResponse(ctx, contents, resp, m.reprompt(config))
+			}
+			return resp, nil
+		}
+		if dump != nil {
+			defer func() {
+				dump.response(ctx, assembleGeminiStream(chunks))
+			}()
+		}
 		for resp, err := range stream {
 			// catch error first
 			if err != nil {
@@ -161,6 +260,9 @@ func (m *Gemini) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 			}
 
 			lastResp = resp
+			if dump != nil {
+				chunks = append(chunks, resp)
+			}
 			llmResp := types.CreateLLMResponse(resp)
 
 			switch {
@@ -169,10 +271,9 @@ func (m *Gemini) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 				llmResp.WithPartial(true)
 
 			case buf.Len() > 0 && !isAudio(llmResp):
-				if !yield(newAggregateText(buf.String()), nil) {
+				if !yield(aggregate()) {
 					return
 				}
-				buf.Reset()
 			}
 
 			if !yield(llmResp, nil) {
@@ -181,11 +282,67 @@ func (m *Gemini) StreamGenerateContent(ctx context.Context, request *types.LLMRe
 		}
 
 		if buf.Len() > 0 && lastResp != nil && finishStop(lastResp) {
-			yield(newAggregateText(buf.String()), nil)
+			yield(aggregate())
 		}
 	}
 }
 
+// geminiDumpRequest is the wire representation of a Gemini request used for debug dumps.
+type geminiDumpRequest struct {
+	Model    string                       `json:"model"`
+	Contents []*genai.Content             `json:"contents"`
+	Config   *genai.GenerateContentConfig `json:"config,omitempty"`
+}
+
+func newGeminiDumpRequest(modelName string, contents []*genai.Content, config *genai.GenerateContentConfig) *geminiDumpRequest {
+	return &geminiDumpRequest{
+		Model:    modelName,
+		Contents: contents,
+		Config:   config,
+	}
+}
+
+// assembleGeminiStream assembles the streamed chunks into a single response for debug dumps.
+//
+// The metadata is taken from the last chunk and the parts of the first candidate
+// of every chunk are concatenated, merging adjacent text parts.
+func assembleGeminiStream(chunks []*genai.GenerateContentResponse) *genai.GenerateContentResponse {
+	if len(chunks) == 0 {
+		return nil
+	}
+
+	last := chunks[len(chunks)-1]
+	assembled := *last
+	if len(last.Candidates) == 0 {
+		return &assembled
+	}
+
+	var parts []*genai.Part
+	for _, chunk := range chunks {
+		if len(chunk.Candidates) == 0 || chunk.Candidates[0].Content == nil {
+			continue
+		}
+		for _, part := range chunk.Candidates[0].Content.Parts {
+			if n := len(parts); n > 0 && part.Text != "" && isPlainText(parts[n-1]) && isPlainText(part) {
+				parts[n-1] = genai.NewPartFromText(parts[n-1].Text + part.Text)
+				continue
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	candidate := *last.Candidates[0]
+	candidate.Content = &genai.Content{Role: RoleModel, Parts: parts}
+	assembled.Candidates = append([]*genai.Candidate{&candidate}, last.Candidates[1:]...)
+
+	return &assembled
+}
+
+// isPlainText reports whether the part only holds non-thought text.
+func isPlainText(part *genai.Part) bool {
+	return part.Text != "" && !part.Thought && part.FunctionCall == nil && part.InlineData == nil
+}
+
 func newAggregateText(s string) *types.LLMResponse {
 	return &types.LLMResponse{
 		Content: &genai.Content{
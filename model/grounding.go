@@ -0,0 +1,52 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// geminiGrounding returns a copy of the config with the Google Search tool grounding the answers, as
+// requested by the [types.GoogleSearchGrounding] of the request: the dynamic retrieval of Google
+// Search for the Gemini 1.x models, and Google Search for the Gemini 2.0+ models.
+//
+// The config is returned as is without grounding. The other models, such as the tuned model
+// endpoints, report an [*types.UnsupportedGroundingError].
+func geminiGrounding(modelName string, config *genai.GenerateContentConfig, request *types.LLMRequest) (*genai.GenerateContentConfig, error) {
+	grounding := request.GoogleSearchGrounding
+	if grounding == nil {
+		return config, nil
+	}
+
+	var tool *genai.Tool
+	name := strings.TrimPrefix(modelName, "models/")
+	switch {
+	case strings.HasPrefix(name, "gemini-1"):
+		retrieval := &genai.GoogleSearchRetrieval{}
+		if grounding.DynamicThreshold != nil {
+			retrieval.DynamicRetrievalConfig = &genai.DynamicRetrievalConfig{
+				Mode:             genai.DynamicRetrievalConfigModeDynamic,
+				DynamicThreshold: grounding.DynamicThreshold,
+			}
+		}
+		tool = &genai.Tool{GoogleSearchRetrieval: retrieval}
+	case strings.HasPrefix(name, "gemini-"):
+		tool = &genai.Tool{GoogleSearch: &genai.GoogleSearch{TimeRangeFilter: grounding.TimeRange}}
+	default:
+		return nil, &types.UnsupportedGroundingError{Model: modelName}
+	}
+
+	merged := new(genai.GenerateContentConfig)
+	if config != nil {
+		*merged = *config
+	}
+	merged.Tools = append(slices.Clip(merged.Tools), tool)
+
+	return merged, nil
+}
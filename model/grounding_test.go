@@ -0,0 +1,91 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestGeminiGrounding(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	declared := &genai.Tool{FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "extract"}}}
+
+	tests := map[string]struct {
+		model   string
+		opt     types.LLMRequestOption
+		want    []*genai.Tool
+		wantErr error
+	}{
+		"disabled": {
+			model: "gemini-2.5-flash",
+			opt:   types.WithGoogleSearchGrounding(false),
+			want:  []*genai.Tool{declared},
+		},
+		"gemini 2": {
+			model: "gemini-2.5-flash",
+			opt:   types.WithGoogleSearchGrounding(true, types.WithSearchTimeRange(start, end)),
+			want: []*genai.Tool{declared, {
+				GoogleSearch: &genai.GoogleSearch{TimeRangeFilter: &genai.Interval{StartTime: start, EndTime: end}},
+			}},
+		},
+		"gemini 1.5 with dynamic retrieval": {
+			model: "models/gemini-1.5-pro",
+			opt:   types.WithGoogleSearchGrounding(true, types.WithDynamicRetrievalThreshold(0.3)),
+			want: []*genai.Tool{declared, {
+				GoogleSearchRetrieval: &genai.GoogleSearchRetrieval{
+					DynamicRetrievalConfig: &genai.DynamicRetrievalConfig{
+						Mode:             genai.DynamicRetrievalConfigModeDynamic,
+						DynamicThreshold: genai.Ptr[float32](0.3),
+					},
+				},
+			}},
+		},
+		"tuned model endpoint": {
+			model:   "projects/p/locations/us-central1/endpoints/123",
+			opt:     types.WithGoogleSearchGrounding(true),
+			wantErr: types.ErrUnsupportedGrounding,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := &genai.GenerateContentConfig{Tools: []*genai.Tool{declared}}
+			request := types.NewLLMRequest(nil, types.WithGenerationConfig(config), tt.opt)
+			got, err := geminiGrounding(tt.model, config, request)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("geminiGrounding() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tt.want, got.Tools); diff != "" {
+				t.Errorf("geminiGrounding() tools mismatch (-want +got):\n%s", diff)
+			}
+			if len(config.Tools) != 1 {
+				t.Errorf("request config has %d tools, want it left unchanged", len(config.Tools))
+			}
+		})
+	}
+}
+
+func TestClaude_GoogleSearchGrounding(t *testing.T) {
+	t.Parallel()
+
+	m := &Claude{BaseLLM: NewBaseLLM("claude-sonnet-4-0")}
+	_, err := m.GenerateContent(t.Context(), types.NewLLMRequest(nil, types.WithGoogleSearchGrounding(true)))
+	if !errors.Is(err, types.ErrUnsupportedGrounding) {
+		t.Errorf("GenerateContent() error = %v, want %v", err, types.ErrUnsupportedGrounding)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+type maxInlineBytesOption int64
+
+func (o maxInlineBytesOption) apply(base Config) Config {
+	base.maxInlineBytes = int64(o)
+	return base
+}
+
+// WithMaxInlineBytes sets the size, in bytes, above which an inline data part of a request, such
+// as an image or an audio clip, is not sent inline.
+//
+// Gemini uploads such a part with the Files API and sends a reference to the file instead, see
+// [Gemini.UploadFile]; an identical part is uploaded once per model, and again when the file is
+// about to expire. The models without a Files API, including Gemini on Vertex AI, report an
+// [*types.InlineDataTooLargeError] instead. Zero or less means no limit, which is the default.
+func WithMaxInlineBytes(n int64) Option {
+	return maxInlineBytesOption(n)
+}
+
+// checkInlineData returns an [*types.InlineDataTooLargeError] for the first inline data part of the
+// contents over the limit, if any.
+func checkInlineData(modelName string, contents []*genai.Content, limit int64) error {
+	if limit <= 0 {
+		return nil
+	}
+	for _, content := range contents {
+		if content == nil {
+			continue
+		}
+		for _, part := range content.Parts {
+			if part != nil && part.InlineData != nil && int64(len(part.InlineData.Data)) > limit {
+				return &types.InlineDataTooLargeError{
+					Model:    modelName,
+					MIMEType: part.InlineData.MIMEType,
+					Size:     len(part.InlineData.Data),
+					Limit:    limit,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// inlineFileRetention is how long the Files API keeps an uploaded file whose expiration time is
+// not reported, and inlineFileMargin how long before its expiration an upload is no longer reused.
+const (
+	inlineFileRetention = 48 * time.Hour
+	inlineFileMargin    = time.Hour
+)
+
+// inlineUploads are the files uploaded for the inline data over the limit, by digest.
+//
+// The concurrent uploads of the same digest are coalesced, without holding mu during the upload.
+type inlineUploads struct {
+	group singleflight.Group
+
+	mu    sync.Mutex
+	files map[[sha256.Size]byte]*inlineUpload
+}
+
+// inlineUpload is a file uploaded for an inline data, reused until it is about to expire.
+type inlineUpload struct {
+	file    *genai.FileData
+	expires time.Time
+}
+
+// get returns the file uploaded for the digest, unless it expires within the margin.
+func (u *inlineUploads) get(digest [sha256.Size]byte) (*genai.FileData, bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	upload, ok := u.files[digest]
+	if !ok {
+		return nil, false
+	}
+	if time.Until(upload.expires) < inlineFileMargin {
+		delete(u.files, digest)
+		return nil, false
+	}
+	return upload.file, true
+}
+
+// put records the file uploaded for the digest.
+func (u *inlineUploads) put(digest [sha256.Size]byte, upload *inlineUpload) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.files == nil {
+		u.files = make(map[[sha256.Size]byte]*inlineUpload)
+	}
+	u.files[digest] = upload
+}
+
+// offloadInlineData returns the contents with their inline data parts over the limit replaced by
+// references to the files uploaded with the Files API. The contents are left as is, and are
+// returned unchanged if no part is over the limit.
+func (m *Gemini) offloadInlineData(ctx context.Context, contents []*genai.Content) ([]*genai.Content, error) {
+	if m.maxInlineBytes <= 0 {
+		return contents, nil
+	}
+	if m.genAIClient.ClientConfig().Backend != genai.BackendGeminiAPI {
+		return contents, checkInlineData(m.modelName, contents, m.maxInlineBytes)
+	}
+
+	var offloaded []*genai.Content
+	for i, content := range contents {
+		if content == nil {
+			continue
+		}
+		var parts []*genai.Part
+		for j, part := range content.Parts {
+			if part == nil || part.InlineData == nil || int64(len(part.InlineData.Data)) <= m.maxInlineBytes {
+				continue
+			}
+			file, err := m.uploadInlineData(ctx, part.InlineData)
+			if err != nil {
+				return nil, err
+			}
+			if parts == nil {
+				parts = append([]*genai.Part(nil), content.Parts...)
+			}
+			ref := *part
+			ref.InlineData = nil
+			ref.FileData = file
+			parts[j] = &ref
+		}
+		if parts == nil {
+			continue
+		}
+		if offloaded == nil {
+			offloaded = append([]*genai.Content(nil), contents...)
+		}
+		offloaded[i] = &genai.Content{Role: content.Role, Parts: parts}
+	}
+	if offloaded == nil {
+		return contents, nil
+	}
+	return offloaded, nil
+}
+
+// uploadInlineData uploads the inline data with the Files API, once per model until the file is
+// about to expire.
+func (m *Gemini) uploadInlineData(ctx context.Context, blob *genai.Blob) (*genai.FileData, error) {
+	digest := sha256.Sum256(blob.Data)
+	if file, ok := m.uploads.get(digest); ok {
+		return file, nil
+	}
+
+	v, err, _ := m.uploads.group.Do(string(digest[:]), func() (any, error) {
+		// Another upload of the digest may have completed since the lookup.
+		if file, ok := m.uploads.get(digest); ok {
+			return file, nil
+		}
+		file, err := m.uploadFile(ctx, bytes.NewReader(blob.Data), blob.MIMEType, blob.DisplayName)
+		if err != nil {
+			return nil, fmt.Errorf("upload inline data of %d bytes: %w", len(blob.Data), err)
+		}
+		expires := file.ExpirationTime
+		if expires.IsZero() {
+			expires = time.Now().Add(inlineFileRetention)
+		}
+		m.logger.DebugContext(ctx, "uploaded inline data over the limit",
+			slog.String("uri", file.URI),
+			slog.Int("size", len(blob.Data)),
+			slog.Time("expires", expires),
+		)
+		upload := &inlineUpload{
+			file: &genai.FileData{
+				DisplayName: file.DisplayName,
+				FileURI:     file.URI,
+				MIMEType:    file.MIMEType,
+			},
+			expires: expires,
+		}
+		m.uploads.put(digest, upload)
+		return upload.file, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*genai.FileData), nil
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// ErrInvalidJSON is returned by [RepairJSON] for a text it cannot repair into valid JSON.
+var ErrInvalidJSON = errors.New("invalid JSON")
+
+// jsonRepairReprompt is the message asking the model to correct a response that is not valid JSON.
+const jsonRepairReprompt = "Your previous response is not valid JSON: %v. Respond again with only the valid JSON, without any other text."
+
+type jsonRepairOption struct{ reprompt bool }
+
+func (o jsonRepairOption) apply(base Config) Config {
+	base.jsonRepair = true
+	base.jsonRepairReprompt = base.jsonRepairReprompt || o.reprompt
+	return base
+}
+
+// WithJSONRepair repairs the responses of the requests whose ResponseMIMEType is
+// "application/json" and whose text is not valid JSON, with [RepairJSON].
+//
+// A repaired response has [types.LLMResponse.JSONRepaired] set. A response that cannot be
+// repaired is returned as is. Only Gemini supports the repair: [Gemini.StreamGenerateContent]
+// repairs its final aggregated response, the partial responses being streamed as is.
+func WithJSONRepair() Option {
+	return jsonRepairOption{}
+}
+
+// WithJSONRepairReprompt is [WithJSONRepair], re-prompting the model once with the parse error
+// when a response cannot be repaired.
+func WithJSONRepairReprompt() Option {
+	return jsonRepairOption{reprompt: true}
+}
+
+// RepairJSON extracts and repairs the JSON value of a model response text.
+//
+// It strips the Markdown code fences and the prose around the first JSON object or array, and
+// removes the trailing commas. A text that is already valid JSON is returned unchanged. It returns
+// an error wrapping [ErrInvalidJSON] if the result is still not valid JSON.
+func RepairJSON(text string) (string, error) {
+	if jsontext.Value(text).IsValid() {
+		return text, nil
+	}
+
+	repaired := strings.TrimSpace(stripCodeFence(text))
+	if start := strings.IndexAny(repaired, "{["); start >= 0 {
+		repaired = repaired[start:]
+		if end := matchingBracket(repaired); end >= 0 {
+			repaired = repaired[:end+1]
+		}
+	}
+	repaired = removeTrailingCommas(repaired)
+
+	var v any
+	if err := json.Unmarshal([]byte(repaired), &v, json.DefaultOptionsV2()); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return repaired, nil
+}
+
+// stripCodeFence returns the content of the first Markdown code block of text, or text if it has none.
+func stripCodeFence(text string) string {
+	_, after, ok := strings.Cut(text, "```")
+	if !ok {
+		return text
+	}
+	// Skips the info string of the fence, such as "json".
+	if nl := strings.IndexByte(after, '\n'); nl >= 0 && !strings.ContainsAny(after[:nl], "{[") {
+		after = after[nl+1:]
+	}
+	content, _, _ := strings.Cut(after, "```")
+	return content
+}
+
+// matchingBracket returns the index of the bracket closing the one at the start of s, or -1.
+func matchingBracket(s string) int {
+	depth := 0
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// removeTrailingCommas removes the commas followed by a closing bracket, outside of the strings.
+func removeTrailingCommas(s string) string {
+	var sb strings.Builder
+	inString := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case inString && c == '\\':
+			sb.WriteByte(c)
+			if i+1 < len(s) {
+				i++
+				sb.WriteByte(s[i])
+			}
+			continue
+		case c == '"':
+			inString = !inString
+		case !inString && c == ',':
+			rest := strings.TrimLeft(s[i+1:], " \t\r\n")
+			if rest != "" && (rest[0] == '}' || rest[0] == ']') {
+				continue
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// wantsJSON reports whether the request config asks for a JSON response.
+func wantsJSON(config *genai.GenerateContentConfig) bool {
+	return config != nil && config.ResponseMIMEType == "application/json"
+}
+
+// responseText returns the text of the non-thought text parts of the response.
+func responseText(resp *types.LLMResponse) string {
+	if resp.Content == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, part := range resp.Content.Parts {
+		if part.Text != "" && !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
+
+// setResponseText replaces the non-thought text parts of the response with a single part of text.
+func setResponseText(resp *types.LLMResponse, text string) {
+	content := *resp.Content
+	content.Parts = slices.DeleteFunc(slices.Clone(content.Parts), func(part *genai.Part) bool {
+		return part.Text != "" && !part.Thought
+	})
+	content.Parts = append(content.Parts, genai.NewPartFromText(text))
+	resp.Content = &content
+}
+
+// repairJSONResponse repairs the text of the response with [RepairJSON], re-prompting the model
+// once with generate if the repair fails and re-prompting is enabled.
+func (c Config) repairJSONResponse(ctx context.Context, contents []*genai.Content, resp *types.LLMResponse, generate func(context.Context, []*genai.Content) (*types.LLMResponse, error)) (*types.LLMResponse, error) {
+	text := responseText(resp)
+	if text == "" {
+		return resp, nil
+	}
+
+	repaired, err := RepairJSON(text)
+	if err == nil {
+		if repaired != text {
+			setResponseText(resp, repaired)
+			resp.JSONRepaired = true
+		}
+		return resp, nil
+	}
+	if !c.jsonRepairReprompt {
+		c.logger.WarnContext(ctx, "response is not valid JSON", slog.String("error", err.Error()))
+		return resp, nil
+	}
+
+	contents = append(slices.Clone(contents), resp.Content, genai.NewContentFromText(fmt.Sprintf(jsonRepairReprompt, err), genai.RoleUser))
+	retry, err := generate(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+	repaired, err = RepairJSON(responseText(retry))
+	if err != nil {
+		c.logger.WarnContext(ctx, "re-prompted response is not valid JSON", slog.String("error", err.Error()))
+		return retry, nil
+	}
+	setResponseText(retry, repaired)
+	retry.JSONRepaired = true
+
+	return retry, nil
+}
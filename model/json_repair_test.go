@@ -0,0 +1,264 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestRepairJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		"valid": {
+			text: `{"name": "Alice"}`,
+			want: `{"name": "Alice"}`,
+		},
+		"code fence": {
+			text: "```json\n{\"name\": \"Alice\"}\n```",
+			want: `{"name": "Alice"}`,
+		},
+		"prose": {
+			text: "Here is the result:\n[1, 2, 3]\nLet me know if you need more.",
+			want: `[1, 2, 3]`,
+		},
+		"trailing commas": {
+			text: `{"tags": ["a", "b",], "note": "x, }",}`,
+			want: `{"tags": ["a", "b"], "note": "x, }"}`,
+		},
+		"brackets in strings": {
+			text: `Result: {"text": "a } b", "quote": "\"{"} done`,
+			want: `{"text": "a } b", "quote": "\"{"}`,
+		},
+		"truncated": {
+			text:    `{"name": "Ali`,
+			wantErr: true,
+		},
+		"no json": {
+			text:    "I cannot answer that.",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := model.RepairJSON(tt.text)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidJSON) {
+					t.Fatalf("RepairJSON() error = %v, want %v", err, model.ErrInvalidJSON)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RepairJSON() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RepairJSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeGenerateAPI serves the generateContent and streamGenerateContent methods, answering with the
+// texts in order.
+type fakeGenerateAPI struct {
+	texts []string
+
+	mu       sync.Mutex
+	requests []map[string]any
+}
+
+func (f *fakeGenerateAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stream := strings.HasSuffix(r.URL.Path, ":streamGenerateContent")
+	if !stream && !strings.HasSuffix(r.URL.Path, ":generateContent") {
+		http.NotFound(w, r)
+		return
+	}
+	var req map[string]any
+	json.UnmarshalRead(r.Body, &req, json.DefaultOptionsV2())
+	f.requests = append(f.requests, req)
+
+	text := f.texts[min(len(f.requests), len(f.texts))-1]
+	chunk := func(text, finishReason string) map[string]any {
+		candidate := map[string]any{
+			"content": map[string]any{"role": "model", "parts": []any{map[string]any{"text": text}}},
+		}
+		if finishReason != "" {
+			candidate["finishReason"] = finishReason
+		}
+		return map[string]any{"candidates": []any{candidate}}
+	}
+	if !stream {
+		w.Header().Set("Content-Type", "application/json")
+		json.MarshalWrite(w, chunk(text, "STOP"), json.DefaultOptionsV2())
+		return
+	}
+
+	// The text is streamed in two chunks, as server-sent events.
+	w.Header().Set("Content-Type", "text/event-stream")
+	half := len(text) / 2
+	for _, c := range []map[string]any{chunk(text[:half], ""), chunk(text[half:], "STOP")} {
+		data, _ := json.Marshal(c, json.DefaultOptionsV2())
+		fmt.Fprintf(w, "data: %s\n\n", data)
+	}
+}
+
+func TestGemini_GenerateContentJSONRepair(t *testing.T) {
+	tests := map[string]struct {
+		opt          model.Option
+		texts        []string
+		want         string
+		wantRepaired bool
+		wantCalls    int
+	}{
+		"valid": {
+			opt:       model.WithJSONRepair(),
+			texts:     []string{`{"ok": true}`},
+			want:      `{"ok": true}`,
+			wantCalls: 1,
+		},
+		"repaired": {
+			opt:          model.WithJSONRepair(),
+			texts:        []string{"```json\n{\"ok\": true,}\n```"},
+			want:         `{"ok": true}`,
+			wantRepaired: true,
+			wantCalls:    1,
+		},
+		"not repaired": {
+			opt:       model.WithJSONRepair(),
+			texts:     []string{`{"ok": tr`},
+			want:      `{"ok": tr`,
+			wantCalls: 1,
+		},
+		"re-prompted": {
+			opt:          model.WithJSONRepairReprompt(),
+			texts:        []string{`{"ok": tr`, `{"ok": true}`},
+			want:         `{"ok": true}`,
+			wantRepaired: true,
+			wantCalls:    2,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeGenerateAPI{texts: tt.texts}
+			srv := httptest.NewServer(api)
+			t.Cleanup(srv.Close)
+			t.Setenv("GOOGLE_GEMINI_BASE_URL", srv.URL)
+
+			gemini, err := model.NewGemini(t.Context(), "test-key", "gemini-2.0-flash", tt.opt)
+			if err != nil {
+				t.Fatalf("NewGemini: %v", err)
+			}
+
+			request := &types.LLMRequest{
+				Contents: []*genai.Content{genai.NewContentFromText("Give me JSON.", genai.RoleUser)},
+				Config:   &genai.GenerateContentConfig{ResponseMIMEType: "application/json"},
+			}
+			got, err := gemini.GenerateContent(t.Context(), request)
+			if err != nil {
+				t.Fatalf("GenerateContent() error = %v", err)
+			}
+
+			if text := got.Content.Parts[0].Text; text != tt.want {
+				t.Errorf("GenerateContent() text = %q, want %q", text, tt.want)
+			}
+			if got.JSONRepaired != tt.wantRepaired {
+				t.Errorf("GenerateContent() JSONRepaired = %t, want %t", got.JSONRepaired, tt.wantRepaired)
+			}
+			if len(api.requests) != tt.wantCalls {
+				t.Errorf("generateContent called %d times, want %d", len(api.requests), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestGemini_StreamGenerateContentJSONRepair(t *testing.T) {
+	tests := map[string]struct {
+		opts         []model.Option
+		texts        []string
+		want         string
+		wantRepaired bool
+		wantCalls    int
+	}{
+		"repaired": {
+			opts:         []model.Option{model.WithJSONRepair()},
+			texts:        []string{"```json\n{\"ok\": true,}\n```"},
+			want:         `{"ok": true}`,
+			wantRepaired: true,
+			wantCalls:    1,
+		},
+		"re-prompted": {
+			opts:         []model.Option{model.WithJSONRepairReprompt()},
+			texts:        []string{`{"ok": tr`, `{"ok": true}`},
+			want:         `{"ok": true}`,
+			wantRepaired: true,
+			wantCalls:    2,
+		},
+		"no repair": {
+			texts:     []string{"```json\n{\"ok\": true,}\n```"},
+			want:      "```json\n{\"ok\": true,}\n```",
+			wantCalls: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			api := &fakeGenerateAPI{texts: tt.texts}
+			srv := httptest.NewServer(api)
+			t.Cleanup(srv.Close)
+			t.Setenv("GOOGLE_GEMINI_BASE_URL", srv.URL)
+
+			gemini, err := model.NewGemini(t.Context(), "test-key", "gemini-2.0-flash", tt.opts...)
+			if err != nil {
+				t.Fatalf("NewGemini: %v", err)
+			}
+
+			request := &types.LLMRequest{
+				Contents: []*genai.Content{genai.NewContentFromText("Give me JSON.", genai.RoleUser)},
+				Config:   &genai.GenerateContentConfig{ResponseMIMEType: "application/json"},
+			}
+			var final *types.LLMResponse
+			for resp, err := range gemini.StreamGenerateContent(t.Context(), request) {
+				if err != nil {
+					t.Fatalf("StreamGenerateContent() error = %v", err)
+				}
+				if !resp.Partial {
+					final = resp
+				}
+			}
+			if final == nil {
+				t.Fatal("StreamGenerateContent() yielded no final response")
+			}
+
+			if text := final.Content.Parts[0].Text; text != tt.want {
+				t.Errorf("final response text = %q, want %q", text, tt.want)
+			}
+			if final.JSONRepaired != tt.wantRepaired {
+				t.Errorf("final response JSONRepaired = %t, want %t", final.JSONRepaired, tt.wantRepaired)
+			}
+			if len(api.requests) != tt.wantCalls {
+				t.Errorf("model called %d times, want %d", len(api.requests), tt.wantCalls)
+			}
+		})
+	}
+}
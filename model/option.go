@@ -5,8 +5,11 @@ package model
 
 import (
 	"log/slog"
+	"time"
 
 	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
 )
 
 // Config represents a base implementation of a Large Language Model.
@@ -20,11 +23,34 @@ type Config struct {
 
 	// logger is the logger used for logging.
 	logger *slog.Logger
+
+	// debugDumpDir is the directory where request and response debug dumps are written.
+	// Debug dumping is disabled when empty.
+	debugDumpDir string
+
+	// safetyPolicy is the provider-agnostic safety policy translated by each model.
+	safetyPolicy *types.SafetyPolicy
+
+	// safetyWarnings are the rules of the safety policy the provider cannot honor.
+	safetyWarnings []types.SafetyWarning
+
+	// filePollInterval is the interval at which an uploaded file is polled until it is active.
+	filePollInterval time.Duration
+
+	// jsonRepair repairs the responses of the requests asking for JSON.
+	jsonRepair bool
+
+	// jsonRepairReprompt re-prompts the model once when a JSON response cannot be repaired.
+	jsonRepairReprompt bool
+
+	// maxInlineBytes is the size above which an inline data part is not sent inline.
+	maxInlineBytes int64
 }
 
 func newConfig() Config {
 	return Config{
-		logger: slog.Default(),
+		logger:           slog.Default(),
+		filePollInterval: DefaultFilePollInterval,
 	}
 }
 
@@ -7,8 +7,11 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
 	"sync"
 
+	"github.com/go-a2a/adk-go/internal/cache"
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -22,6 +25,8 @@ func init() {
 		func(ctx context.Context, apiKey, modelName string) (types.Model, error) {
 			return NewClaude(ctx, modelName, ClaudeModeAnthropic)
 		},
+		WithRegistryLabel("claude"),
+		WithSampleNames("claude-3-5-sonnet-20241022"),
 	)
 
 	// Register Google/Gemini models
@@ -34,16 +39,101 @@ func init() {
 		func(ctx context.Context, apiKey, modelName string) (types.Model, error) {
 			return NewGemini(ctx, apiKey, modelName)
 		},
+		WithRegistryLabel("gemini"),
+		WithSampleNames(
+			"gemini-2.0-flash",
+			"projects/my-project/locations/us-central1/endpoints/1234567890",
+			"projects/my-project/locations/us-central1/publishers/google/models/gemini-2.0-flash",
+		),
 	)
 }
 
 // ModelCreatorFunc is a function type that creates a model instance.
 type ModelCreatorFunc func(ctx context.Context, apiKey, modelName string) (types.Model, error)
 
+// RegistryEntry describes a pattern registered in an [LLMRegistry].
+type RegistryEntry struct {
+	// Pattern is the regular expression matched against the model names.
+	Pattern string
+
+	// Label names the registration of the pattern, such as "gemini" for the built-in Gemini
+	// patterns. It defaults to the first pattern of the registration.
+	Label string
+
+	// SampleNames are the model names the registration is expected to handle, used to detect
+	// the patterns of other registrations matching them.
+	SampleNames []string
+
+	// Creator is the function creating the models of the pattern.
+	Creator ModelCreatorFunc
+}
+
+// AmbiguousMatchError is returned when a model name is matched by the patterns of several
+// registrations, so that the model implementation it resolves to depends on the registration order.
+type AmbiguousMatchError struct {
+	// Name is the ambiguous model name.
+	Name string
+
+	// Entries are the entries whose patterns match the name, in registration order.
+	Entries []RegistryEntry
+}
+
+// Error implements the error interface for AmbiguousMatchError.
+func (e *AmbiguousMatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "model %s is matched by several registrations:", e.Name)
+	for _, entry := range e.Entries {
+		fmt.Fprintf(&b, " %s (%s)", entry.Label, entry.Pattern)
+	}
+	return b.String()
+}
+
+// RegisterOption configures the registration of model patterns.
+type RegisterOption func(*registerConfig)
+
+// registerConfig holds the options of a registration.
+type registerConfig struct {
+	label       string
+	sampleNames []string
+}
+
+// WithRegistryLabel sets the label of the registration, reported by [LLMRegistry.List].
+func WithRegistryLabel(label string) RegisterOption {
+	return func(c *registerConfig) {
+		c.label = label
+	}
+}
+
+// WithSampleNames sets the model names the registration is expected to handle.
+//
+// The registration fails with an [*AmbiguousMatchError] if a pattern of another registration
+// matches one of the names, or if one of the registered patterns matches a sample name of
+// another registration.
+func WithSampleNames(names ...string) RegisterOption {
+	return func(c *registerConfig) {
+		c.sampleNames = append(c.sampleNames, names...)
+	}
+}
+
 // modelEntry represents a registry entry with a regex pattern and model creator function.
 type modelEntry struct {
 	pattern *regexp.Regexp
 	creator ModelCreatorFunc
+
+	// group identifies the registration of the entry, the entries of a registration sharing the creator.
+	group       int
+	label       string
+	sampleNames []string
+}
+
+// toRegistryEntry returns the public description of the entry.
+func (e modelEntry) toRegistryEntry() RegistryEntry {
+	return RegistryEntry{
+		Pattern:     e.pattern.String(),
+		Label:       e.label,
+		SampleNames: slices.Clone(e.sampleNames),
+		Creator:     e.creator,
+	}
 }
 
 // LLMRegistry provides a registry for LLM models.
@@ -51,8 +141,9 @@ type modelEntry struct {
 type LLMRegistry struct {
 	mu         sync.RWMutex
 	registry   []modelEntry
+	groups     int
 	cacheSize  int
-	modelCache map[string]ModelCreatorFunc // Simple LRU-like cache
+	modelCache *cache.LRU[string, ModelCreatorFunc]
 }
 
 var (
@@ -69,40 +160,163 @@ func GetRegistry() *LLMRegistry {
 }
 
 // NewLLMRegistry creates a new LLM registry with the specified cache size.
+//
+// The resolved model names are cached in an LRU cache of cacheSize entries. A cacheSize of zero
+// or less does not bound the cache.
 func NewLLMRegistry(cacheSize int) *LLMRegistry {
 	return &LLMRegistry{
 		registry:   make([]modelEntry, 0),
 		cacheSize:  cacheSize,
-		modelCache: make(map[string]ModelCreatorFunc),
+		modelCache: cache.NewLRU[string, ModelCreatorFunc](cacheSize, 0),
 	}
 }
 
 // RegisterLLM registers a model pattern with a creator function.
 // If the pattern already exists, it will be updated with the new creator.
-func (r *LLMRegistry) RegisterLLM(modelPattern string, creator ModelCreatorFunc) {
+//
+// It returns an error if the pattern is not a valid regular expression, or an
+// [*AmbiguousMatchError] if it overlaps with another registration for the sample names.
+func (r *LLMRegistry) RegisterLLM(modelPattern string, creator ModelCreatorFunc, opts ...RegisterOption) error {
+	return r.RegisterLLMType([]string{modelPattern}, creator, opts...)
+}
+
+// RegisterLLMType registers multiple patterns for a single model creator.
+//
+// The patterns are registered together: if one of them is not a valid regular expression, or
+// overlaps with another registration for the sample names set by [WithSampleNames], an error is
+// returned and none is registered.
+func (r *LLMRegistry) RegisterLLMType(patterns []string, creator ModelCreatorFunc, opts ...RegisterOption) error {
+	var cfg registerConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.label == "" && len(patterns) > 0 {
+		cfg.label = patterns[0]
+	}
+
+	regexes := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("compile model pattern %s: %w", pattern, err)
+		}
+		regexes[i] = regex
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	regex, err := regexp.Compile(modelPattern)
-	if err != nil {
-		// Log error and return
-		fmt.Printf("Failed to compile regex pattern %s: %v\n", modelPattern, err)
-		return
+	// The entries of the replaced patterns are not checked, as they are updated.
+	var others []modelEntry
+	for _, entry := range r.registry {
+		if !slices.Contains(patterns, entry.pattern.String()) {
+			others = append(others, entry)
+		}
 	}
+	for _, entry := range others {
+		for _, name := range cfg.sampleNames {
+			if entry.pattern.MatchString(name) {
+				return fmt.Errorf("register model patterns %s: %w", cfg.label, r.ambiguousMatch(name, entry, regexes, cfg))
+			}
+		}
+		for _, name := range entry.sampleNames {
+			for _, regex := range regexes {
+				if regex.MatchString(name) {
+					return fmt.Errorf("register model patterns %s: %w", cfg.label, r.ambiguousMatch(name, entry, regexes, cfg))
+				}
+			}
+		}
+	}
+
+	r.groups++
+	for _, regex := range regexes {
+		newEntry := modelEntry{
+			pattern:     regex,
+			creator:     creator,
+			group:       r.groups,
+			label:       cfg.label,
+			sampleNames: cfg.sampleNames,
+		}
+
+		// Look for existing entry to update
+		i := slices.IndexFunc(r.registry, func(entry modelEntry) bool {
+			return entry.pattern.String() == regex.String()
+		})
+		if i >= 0 {
+			r.registry[i] = newEntry
+			continue
+		}
+
+		// Add new entry
+		r.registry = append(r.registry, newEntry)
+	}
+
+	// The cached resolutions may be outdated by the new patterns.
+	r.modelCache = cache.NewLRU[string, ModelCreatorFunc](r.cacheSize, 0)
+
+	return nil
+}
+
+// ambiguousMatch returns the error reporting that the name is matched both by the existing entry
+// and by the registration of the patterns.
+func (r *LLMRegistry) ambiguousMatch(name string, existing modelEntry, regexes []*regexp.Regexp, cfg registerConfig) *AmbiguousMatchError {
+	err := &AmbiguousMatchError{
+		Name:    name,
+		Entries: []RegistryEntry{existing.toRegistryEntry()},
+	}
+	for _, regex := range regexes {
+		if regex.MatchString(name) {
+			err.Entries = append(err.Entries, RegistryEntry{Pattern: regex.String(), Label: cfg.label, SampleNames: cfg.sampleNames})
+			break
+		}
+	}
+	return err
+}
 
-	// Look for existing entry to update
+// List returns the registered patterns, in registration order.
+func (r *LLMRegistry) List() []RegistryEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := make([]RegistryEntry, len(r.registry))
 	for i, entry := range r.registry {
-		if entry.pattern.String() == modelPattern {
-			r.registry[i].creator = creator
-			return
+		entries[i] = entry.toRegistryEntry()
+	}
+	return entries
+}
+
+// ResolveFactory returns the entry that handles the model name.
+//
+// Unlike [LLMRegistry.ResolveLLM], which resolves the name with the first matching pattern, it
+// returns an [*AmbiguousMatchError] if the name is matched by the patterns of several
+// registrations, to explain which implementation a name resolves to.
+func (r *LLMRegistry) ResolveFactory(modelName string) (RegistryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []modelEntry
+	for _, entry := range r.registry {
+		if !entry.pattern.MatchString(modelName) {
+			continue
+		}
+		// The patterns of a registration share the creator, so that they are not ambiguous.
+		if !slices.ContainsFunc(matches, func(m modelEntry) bool { return m.group == entry.group }) {
+			matches = append(matches, entry)
 		}
 	}
 
-	// Add new entry
-	r.registry = append(r.registry, modelEntry{
-		pattern: regex,
-		creator: creator,
-	})
+	switch len(matches) {
+	case 0:
+		return RegistryEntry{}, fmt.Errorf("model %s not found", modelName)
+	case 1:
+		return matches[0].toRegistryEntry(), nil
+	default:
+		err := &AmbiguousMatchError{Name: modelName}
+		for _, m := range matches {
+			err.Entries = append(err.Entries, m.toRegistryEntry())
+		}
+		return RegistryEntry{}, err
+	}
 }
 
 // ResolveLLM finds the appropriate model creator for the given model name.
@@ -110,7 +324,7 @@ func (r *LLMRegistry) RegisterLLM(modelPattern string, creator ModelCreatorFunc)
 func (r *LLMRegistry) ResolveLLM(modelName string) (ModelCreatorFunc, error) {
 	// Check cache first (with read lock)
 	r.mu.RLock()
-	if creator, ok := r.modelCache[modelName]; ok {
+	if creator, ok := r.modelCache.Get(modelName); ok {
 		r.mu.RUnlock()
 		return creator, nil
 	}
@@ -133,11 +347,7 @@ func (r *LLMRegistry) ResolveLLM(modelName string) (ModelCreatorFunc, error) {
 
 	// Update cache (with write lock)
 	r.mu.Lock()
-	if len(r.modelCache) >= r.cacheSize {
-		// Simple eviction strategy - clear cache when full
-		r.modelCache = make(map[string]ModelCreatorFunc)
-	}
-	r.modelCache[modelName] = matchedCreator
+	r.modelCache.Put(modelName, matchedCreator)
 	r.mu.Unlock()
 
 	return matchedCreator, nil
@@ -156,16 +366,24 @@ func (r *LLMRegistry) NewLLM(ctx context.Context, modelName string) (types.Model
 }
 
 // RegisterLLM is a convenience function to register a model pattern.
-func RegisterLLM(modelPattern string, creator ModelCreatorFunc) {
-	GetRegistry().RegisterLLM(modelPattern, creator)
+func RegisterLLM(modelPattern string, creator ModelCreatorFunc, opts ...RegisterOption) error {
+	return GetRegistry().RegisterLLM(modelPattern, creator, opts...)
 }
 
 // RegisterLLMType registers multiple patterns for a single model creator.
-func RegisterLLMType(patterns []string, creator ModelCreatorFunc) {
-	registry := GetRegistry()
-	for _, pattern := range patterns {
-		registry.RegisterLLM(pattern, creator)
-	}
+func RegisterLLMType(patterns []string, creator ModelCreatorFunc, opts ...RegisterOption) error {
+	return GetRegistry().RegisterLLMType(patterns, creator, opts...)
+}
+
+// ListRegistered returns the patterns registered in the default registry, in registration order.
+func ListRegistered() []RegistryEntry {
+	return GetRegistry().List()
+}
+
+// ResolveFactory returns the entry of the default registry that handles the model name, or an
+// [*AmbiguousMatchError] if several registrations match it.
+func ResolveFactory(modelName string) (RegistryEntry, error) {
+	return GetRegistry().ResolveFactory(modelName)
 }
 
 // NewLLM is a convenience function to create a new LLM instance.
@@ -0,0 +1,113 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func newTestModel(context.Context, string, string) (types.Model, error) {
+	return nil, nil
+}
+
+func TestLLMRegistryResolveFactory(t *testing.T) {
+	t.Parallel()
+
+	r := model.NewLLMRegistry(8)
+	if err := r.RegisterLLMType([]string{`gemini-.*`, `projects/.*/models/gemini-.*`}, newTestModel,
+		model.WithRegistryLabel("gemini"), model.WithSampleNames("gemini-2.0-flash")); err != nil {
+		t.Fatalf("RegisterLLMType(gemini) error = %v", err)
+	}
+	if err := r.RegisterLLM(`.*-flash-custom`, newTestModel, model.WithRegistryLabel("custom")); err != nil {
+		t.Fatalf("RegisterLLM(custom) error = %v", err)
+	}
+
+	if got := r.List(); len(got) != 3 || got[0].Label != "gemini" || got[2].Label != "custom" {
+		t.Errorf("List() = %+v, want the gemini patterns then the custom pattern", got)
+	}
+
+	// Both gemini patterns match, but they belong to the same registration.
+	entry, err := r.ResolveFactory("projects/p/models/gemini-2.0-flash")
+	if err != nil || entry.Label != "gemini" {
+		t.Errorf("ResolveFactory(gemini) = (%q, %v), want gemini", entry.Label, err)
+	}
+
+	_, err = r.ResolveFactory("gemini-2.0-flash-custom")
+	var ambiguous *model.AmbiguousMatchError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Entries) != 2 {
+		t.Fatalf("ResolveFactory(ambiguous) error = %v, want AmbiguousMatchError with 2 entries", err)
+	}
+
+	if _, err := r.ResolveFactory("unknown"); err == nil || errors.As(err, &ambiguous) {
+		t.Errorf("ResolveFactory(unknown) error = %v, want not found", err)
+	}
+}
+
+func TestLLMRegistryRegisterOverlap(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		pattern string
+		samples []string
+		wantErr bool
+	}{
+		"existing pattern matches the samples": {
+			pattern: `gemini-custom-.*`,
+			samples: []string{"gemini-custom-1"},
+			wantErr: true,
+		},
+		"pattern matches the existing samples": {
+			pattern: `.*flash.*`,
+			wantErr: true,
+		},
+		"distinct patterns": {
+			pattern: `my-model-.*`,
+			samples: []string{"my-model-1"},
+		},
+		"invalid pattern": {
+			pattern: `(`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			r := model.NewLLMRegistry(8)
+			if err := r.RegisterLLM(`gemini-.*`, newTestModel, model.WithSampleNames("gemini-2.0-flash")); err != nil {
+				t.Fatalf("RegisterLLM(gemini) error = %v", err)
+			}
+
+			err := r.RegisterLLM(tt.pattern, newTestModel, model.WithSampleNames(tt.samples...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterLLM(%s) error = %v, wantErr %t", tt.pattern, err, tt.wantErr)
+			}
+			want := 2
+			if tt.wantErr {
+				want = 1
+			}
+			if got := len(r.List()); got != want {
+				t.Errorf("len(List()) = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestListRegisteredBuiltIn(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"claude-3-5-sonnet-20241022", "gemini-2.0-flash"} {
+		if _, err := model.ResolveFactory(name); err != nil {
+			t.Errorf("ResolveFactory(%s) error = %v", name, err)
+		}
+	}
+	if len(model.ListRegistered()) < 4 {
+		t.Errorf("ListRegistered() = %d entries, want the built-in patterns", len(model.ListRegistered()))
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// maxWordDiffCells bounds the size of the table of the word-level diff of [DiffResponses], past
+// which the differing middle of the texts is reported as a single deletion and insertion.
+const maxWordDiffCells = 4 << 20
+
+// TextOp is the operation of a [TextEdit].
+type TextOp int
+
+const (
+	// TextEqual is a run of words found in both texts.
+	TextEqual TextOp = iota
+
+	// TextDelete is a run of words only found in the first text.
+	TextDelete
+
+	// TextInsert is a run of words only found in the second text.
+	TextInsert
+)
+
+// String implements [fmt.Stringer].
+func (op TextOp) String() string {
+	switch op {
+	case TextEqual:
+		return "="
+	case TextDelete:
+		return "-"
+	case TextInsert:
+		return "+"
+	default:
+		return fmt.Sprintf("TextOp(%d)", int(op))
+	}
+}
+
+// TextEdit is a run of words of the word-level diff of the texts of two responses.
+type TextEdit struct {
+	Op TextOp
+
+	// Text is the words of the run, separated by single spaces.
+	Text string
+}
+
+// FunctionCallChange is the kind of a [FunctionCallDiff].
+type FunctionCallChange int
+
+const (
+	// FunctionCallAdded is a function call only made by the second response.
+	FunctionCallAdded FunctionCallChange = iota + 1
+
+	// FunctionCallRemoved is a function call only made by the first response.
+	FunctionCallRemoved
+
+	// FunctionCallChanged is a function call made by both responses with different arguments.
+	FunctionCallChanged
+)
+
+// String implements [fmt.Stringer].
+func (c FunctionCallChange) String() string {
+	switch c {
+	case FunctionCallAdded:
+		return "added"
+	case FunctionCallRemoved:
+		return "removed"
+	case FunctionCallChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("FunctionCallChange(%d)", int(c))
+	}
+}
+
+// FunctionCallDiff is a difference between the function calls of two responses.
+type FunctionCallDiff struct {
+	// Name is the name of the called function.
+	Name string
+
+	Change FunctionCallChange
+
+	// ArgsA and ArgsB are the arguments of the call in the first and the second response, nil for
+	// the response not making it.
+	ArgsA, ArgsB map[string]any
+
+	// ChangedArgs are the sorted names of the arguments added, removed or changed by a
+	// [FunctionCallChanged] call.
+	ChangedArgs []string
+}
+
+// UsageDiff is the difference of the token usage of two responses, the second minus the first.
+type UsageDiff struct {
+	PromptTokens     int32
+	CandidatesTokens int32
+	ThoughtsTokens   int32
+	TotalTokens      int32
+}
+
+// ResponseDiff is the structured difference between two responses, see [DiffResponses].
+type ResponseDiff struct {
+	// TextA and TextB are the final texts of the responses, their text parts other than thoughts.
+	TextA, TextB string
+
+	// TextEdits is the word-level diff of the texts, nil if they are equal.
+	TextEdits []TextEdit
+
+	// FunctionCalls are the function calls added, removed or changed by the second response.
+	FunctionCalls []FunctionCallDiff
+
+	// FinishReasonA and FinishReasonB are the finish reasons of the responses.
+	FinishReasonA, FinishReasonB genai.FinishReason
+
+	// Usage is the difference of the token usage, the missing usage counting as zero tokens.
+	Usage UsageDiff
+}
+
+// Changed reports whether the responses behave differently: their texts, function calls or
+// finish reasons differ. The token usage is not part of the behavior.
+func (d *ResponseDiff) Changed() bool {
+	return d.TextA != d.TextB || len(d.FunctionCalls) > 0 || d.FinishReasonA != d.FinishReasonB
+}
+
+// String returns a human-readable summary of the differences, empty if the responses behave the same.
+func (d *ResponseDiff) String() string {
+	var b strings.Builder
+	if d.TextA != d.TextB {
+		b.WriteString("text:")
+		for _, edit := range d.TextEdits {
+			switch edit.Op {
+			case TextEqual:
+				fmt.Fprintf(&b, " %s", edit.Text)
+			default:
+				fmt.Fprintf(&b, " [%s%s]", edit.Op, edit.Text)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	for _, call := range d.FunctionCalls {
+		fmt.Fprintf(&b, "function call %s %s", call.Name, call.Change)
+		if len(call.ChangedArgs) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(call.ChangedArgs, ", "))
+		}
+		b.WriteByte('\n')
+	}
+	if d.FinishReasonA != d.FinishReasonB {
+		fmt.Fprintf(&b, "finish reason: %s -> %s\n", d.FinishReasonA, d.FinishReasonB)
+	}
+	return b.String()
+}
+
+// DiffResponses returns the structured difference between the responses a and b, such as the
+// responses of two models or two prompt versions to the same request, for A/B evaluations and
+// regression tests of the behavior of an agent.
+//
+// It compares the final texts word by word, the function calls matched by name in the order they
+// are made, the finish reasons and the token usage. A nil response is compared as an empty one.
+func DiffResponses(a, b *types.LLMResponse) *ResponseDiff {
+	if a == nil {
+		a = &types.LLMResponse{}
+	}
+	if b == nil {
+		b = &types.LLMResponse{}
+	}
+
+	d := &ResponseDiff{
+		TextA:         responseText(a),
+		TextB:         responseText(b),
+		FunctionCalls: diffFunctionCalls(responseCalls(a), responseCalls(b)),
+		FinishReasonA: a.FinishReason,
+		FinishReasonB: b.FinishReason,
+		Usage:         diffUsage(a.UsageMetadata, b.UsageMetadata),
+	}
+	if d.TextA != d.TextB {
+		d.TextEdits = diffWords(strings.Fields(d.TextA), strings.Fields(d.TextB))
+	}
+	return d
+}
+
+// responseCalls returns the function calls of the response.
+func responseCalls(resp *types.LLMResponse) []*genai.FunctionCall {
+	if resp.Content == nil {
+		return nil
+	}
+	var calls []*genai.FunctionCall
+	for _, part := range resp.Content.Parts {
+		if part != nil && part.FunctionCall != nil {
+			calls = append(calls, part.FunctionCall)
+		}
+	}
+	return calls
+}
+
+// diffFunctionCalls returns the differences of the function calls, the n-th call of a function in
+// a being matched with its n-th call in b.
+func diffFunctionCalls(a, b []*genai.FunctionCall) []FunctionCallDiff {
+	pending := make(map[string][]*genai.FunctionCall)
+	for _, call := range b {
+		pending[call.Name] = append(pending[call.Name], call)
+	}
+
+	var diffs []FunctionCallDiff
+	for _, call := range a {
+		matches := pending[call.Name]
+		if len(matches) == 0 {
+			diffs = append(diffs, FunctionCallDiff{Name: call.Name, Change: FunctionCallRemoved, ArgsA: call.Args})
+			continue
+		}
+		other := matches[0]
+		pending[call.Name] = matches[1:]
+		if changed := changedArgs(call.Args, other.Args); len(changed) > 0 {
+			diffs = append(diffs, FunctionCallDiff{Name: call.Name, Change: FunctionCallChanged, ArgsA: call.Args, ArgsB: other.Args, ChangedArgs: changed})
+		}
+	}
+	// The calls of b left unmatched, in their order.
+	for _, call := range b {
+		if matches := pending[call.Name]; len(matches) > 0 && matches[0] == call {
+			pending[call.Name] = matches[1:]
+			diffs = append(diffs, FunctionCallDiff{Name: call.Name, Change: FunctionCallAdded, ArgsB: call.Args})
+		}
+	}
+	return diffs
+}
+
+// changedArgs returns the sorted names of the arguments added, removed or changed from a to b.
+func changedArgs(a, b map[string]any) []string {
+	var changed []string
+	for _, name := range slices.Sorted(maps.Keys(a)) {
+		if other, ok := b[name]; !ok || !reflect.DeepEqual(a[name], other) {
+			changed = append(changed, name)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(b)) {
+		if _, ok := a[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// diffUsage returns the difference of the token usage, nil counting as zero tokens.
+func diffUsage(a, b *genai.GenerateContentResponseUsageMetadata) UsageDiff {
+	if a == nil {
+		a = &genai.GenerateContentResponseUsageMetadata{}
+	}
+	if b == nil {
+		b = &genai.GenerateContentResponseUsageMetadata{}
+	}
+	return UsageDiff{
+		PromptTokens:     b.PromptTokenCount - a.PromptTokenCount,
+		CandidatesTokens: b.CandidatesTokenCount - a.CandidatesTokenCount,
+		ThoughtsTokens:   b.ThoughtsTokenCount - a.ThoughtsTokenCount,
+		TotalTokens:      b.TotalTokenCount - a.TotalTokenCount,
+	}
+}
+
+// diffWords returns the word-level diff of a and b from their longest common subsequence.
+//
+// The common prefix and suffix are trimmed first. A middle too large for the table of the longest
+// common subsequence is reported as a single deletion and insertion.
+func diffWords(a, b []string) []TextEdit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var edits []TextEdit
+	add := func(op TextOp, word string) {
+		if n := len(edits); n > 0 && edits[n-1].Op == op {
+			edits[n-1].Text += " " + word
+			return
+		}
+		edits = append(edits, TextEdit{Op: op, Text: word})
+	}
+	for _, word := range a[:prefix] {
+		add(TextEqual, word)
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(ma)*len(mb) > maxWordDiffCells {
+		for _, word := range ma {
+			add(TextDelete, word)
+		}
+		for _, word := range mb {
+			add(TextInsert, word)
+		}
+	} else {
+		// lcs[i][j] is the length of the longest common subsequence of ma[i:] and mb[j:].
+		lcs := make([][]int32, len(ma)+1)
+		for i := range lcs {
+			lcs[i] = make([]int32, len(mb)+1)
+		}
+		for i := len(ma) - 1; i >= 0; i-- {
+			for j := len(mb) - 1; j >= 0; j-- {
+				if ma[i] == mb[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else {
+					lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < len(ma) && j < len(mb) {
+			switch {
+			case ma[i] == mb[j]:
+				add(TextEqual, ma[i])
+				i++
+				j++
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				add(TextDelete, ma[i])
+				i++
+			default:
+				add(TextInsert, mb[j])
+				j++
+			}
+		}
+		for ; i < len(ma); i++ {
+			add(TextDelete, ma[i])
+		}
+		for ; j < len(mb); j++ {
+			add(TextInsert, mb[j])
+		}
+	}
+
+	for _, word := range a[len(a)-suffix:] {
+		add(TextEqual, word)
+	}
+	return edits
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestDiffResponses(t *testing.T) {
+	t.Parallel()
+
+	response := func(finish genai.FinishReason, total int32, parts ...*genai.Part) *types.LLMResponse {
+		return &types.LLMResponse{
+			Content:       genai.NewContentFromParts(parts, genai.RoleModel),
+			FinishReason:  finish,
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{TotalTokenCount: total},
+		}
+	}
+	call := func(name string, args map[string]any) *genai.Part {
+		return genai.NewPartFromFunctionCall(name, args)
+	}
+
+	tests := map[string]struct {
+		a, b        *types.LLMResponse
+		want        *model.ResponseDiff
+		wantChanged bool
+	}{
+		"nil responses": {
+			want: &model.ResponseDiff{},
+		},
+		"same behavior, different usage": {
+			a: response(genai.FinishReasonStop, 10, genai.NewPartFromText("It is sunny.")),
+			b: response(genai.FinishReasonStop, 12, genai.NewPartFromText("It is sunny."), &genai.Part{Text: "Thinking.", Thought: true}),
+			want: &model.ResponseDiff{
+				TextA: "It is sunny.", TextB: "It is sunny.",
+				FinishReasonA: genai.FinishReasonStop, FinishReasonB: genai.FinishReasonStop,
+				Usage: model.UsageDiff{TotalTokens: 2},
+			},
+		},
+		"text": {
+			a: response(genai.FinishReasonStop, 0, genai.NewPartFromText("The weather in Tokyo is sunny today.")),
+			b: response(genai.FinishReasonMaxTokens, 0, genai.NewPartFromText("The weather in Osaka is sunny")),
+			want: &model.ResponseDiff{
+				TextA: "The weather in Tokyo is sunny today.", TextB: "The weather in Osaka is sunny",
+				TextEdits: []model.TextEdit{
+					{Op: model.TextEqual, Text: "The weather in"},
+					{Op: model.TextDelete, Text: "Tokyo"},
+					{Op: model.TextInsert, Text: "Osaka"},
+					{Op: model.TextEqual, Text: "is sunny"},
+					{Op: model.TextDelete, Text: "today."},
+				},
+				FinishReasonA: genai.FinishReasonStop, FinishReasonB: genai.FinishReasonMaxTokens,
+			},
+			wantChanged: true,
+		},
+		"function calls": {
+			a: response("", 0,
+				call("search", map[string]any{"query": "go", "limit": 5}),
+				call("fetch", map[string]any{"url": "a"}),
+				call("search", map[string]any{"query": "rust"}),
+			),
+			b: response("", 0,
+				call("search", map[string]any{"query": "go", "limit": 10, "lang": "en"}),
+				call("search", map[string]any{"query": "rust"}),
+				call("notify", nil),
+			),
+			want: &model.ResponseDiff{
+				FunctionCalls: []model.FunctionCallDiff{
+					{
+						Name: "search", Change: model.FunctionCallChanged,
+						ArgsA:       map[string]any{"query": "go", "limit": 5},
+						ArgsB:       map[string]any{"query": "go", "limit": 10, "lang": "en"},
+						ChangedArgs: []string{"lang", "limit"},
+					},
+					{Name: "fetch", Change: model.FunctionCallRemoved, ArgsA: map[string]any{"url": "a"}},
+					{Name: "notify", Change: model.FunctionCallAdded},
+				},
+			},
+			wantChanged: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := model.DiffResponses(tt.a, tt.b)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffResponses() mismatch (-want +got):\n%s", diff)
+			}
+			if got.Changed() != tt.wantChanged {
+				t.Errorf("Changed() = %t, want %t", got.Changed(), tt.wantChanged)
+			}
+			if (got.String() != "") != tt.wantChanged {
+				t.Errorf("String() = %q, want a summary only of the changed responses", got.String())
+			}
+		})
+	}
+}
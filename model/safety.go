@@ -0,0 +1,170 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+type safetyPolicyOption struct{ *types.SafetyPolicy }
+
+func (o safetyPolicyOption) apply(base Config) Config {
+	base.safetyPolicy = o.SafetyPolicy
+	return base
+}
+
+// WithSafetyPolicy sets the provider-agnostic safety policy of the model.
+//
+// Each model translates the policy to the native mechanism of its provider. The rules the
+// provider cannot honor are logged as warnings and reported by [Config.SafetyWarnings].
+func WithSafetyPolicy(policy *types.SafetyPolicy) Option {
+	return safetyPolicyOption{policy}
+}
+
+// SafetyWarnings returns the rules of the safety policy that the provider of the model cannot honor as declared.
+func (c Config) SafetyWarnings() []types.SafetyWarning {
+	return slices.Clone(c.safetyWarnings)
+}
+
+// setSafetyWarnings records and logs the warnings of the safety policy translation.
+func (c *Config) setSafetyWarnings(ctx context.Context, warnings []types.SafetyWarning) {
+	c.safetyWarnings = warnings
+	for _, w := range warnings {
+		c.logger.WarnContext(ctx, "safety rule not honored",
+			slog.String("provider", w.Provider),
+			slog.String("category", string(w.Rule.Category)),
+			slog.String("threshold", w.Rule.Threshold.String()),
+			slog.String("reason", w.Reason),
+		)
+	}
+}
+
+// geminiHarmCategories maps the safety categories to the Gemini harm categories.
+var geminiHarmCategories = map[types.SafetyCategory]genai.HarmCategory{
+	types.SafetyCategoryHarassment:       genai.HarmCategoryHarassment,
+	types.SafetyCategoryHateSpeech:       genai.HarmCategoryHateSpeech,
+	types.SafetyCategorySexuallyExplicit: genai.HarmCategorySexuallyExplicit,
+	types.SafetyCategoryDangerousContent: genai.HarmCategoryDangerousContent,
+	types.SafetyCategoryCivicIntegrity:   genai.HarmCategoryCivicIntegrity,
+}
+
+// geminiHarmBlockThresholds maps the safety thresholds to the Gemini harm block thresholds.
+var geminiHarmBlockThresholds = map[types.SafetyThreshold]genai.HarmBlockThreshold{
+	types.SafetyThresholdOff:                 genai.HarmBlockThresholdOff,
+	types.SafetyThresholdBlockNone:           genai.HarmBlockThresholdBlockNone,
+	types.SafetyThresholdBlockOnlyHigh:       genai.HarmBlockThresholdBlockOnlyHigh,
+	types.SafetyThresholdBlockMediumAndAbove: genai.HarmBlockThresholdBlockMediumAndAbove,
+	types.SafetyThresholdBlockLowAndAbove:    genai.HarmBlockThresholdBlockLowAndAbove,
+}
+
+// geminiSafetySettings translates the safety policy to Gemini safety settings.
+func geminiSafetySettings(policy *types.SafetyPolicy) ([]*genai.SafetySetting, []types.SafetyWarning) {
+	var (
+		settings []*genai.SafetySetting
+		warnings []types.SafetyWarning
+	)
+	for _, rule := range policy.EffectiveRules() {
+		category, ok := geminiHarmCategories[rule.Category]
+		if !ok {
+			warnings = append(warnings, types.SafetyWarning{
+				Provider: "gemini",
+				Rule:     rule,
+				Reason:   "no matching Gemini harm category, the rule is ignored",
+			})
+			continue
+		}
+		threshold, ok := geminiHarmBlockThresholds[rule.Threshold]
+		if !ok {
+			warnings = append(warnings, types.SafetyWarning{
+				Provider: "gemini",
+				Rule:     rule,
+				Reason:   "no matching Gemini harm block threshold, the rule is ignored",
+			})
+			continue
+		}
+		settings = append(settings, &genai.SafetySetting{
+			Category:  category,
+			Threshold: threshold,
+		})
+	}
+
+	return settings, warnings
+}
+
+// mergeSafetySettings returns the config with the model safety settings added for the categories
+// the request does not set itself.
+//
+// The given config is not modified.
+func mergeSafetySettings(config *genai.GenerateContentConfig, settings []*genai.SafetySetting) *genai.GenerateContentConfig {
+	if len(settings) == 0 {
+		return config
+	}
+
+	merged := new(genai.GenerateContentConfig)
+	if config != nil {
+		*merged = *config
+	}
+	merged.SafetySettings = slices.Clone(merged.SafetySettings)
+	for _, setting := range settings {
+		if !slices.ContainsFunc(merged.SafetySettings, func(s *genai.SafetySetting) bool { return s.Category == setting.Category }) {
+			merged.SafetySettings = append(merged.SafetySettings, setting)
+		}
+	}
+
+	return merged
+}
+
+// claudeSafetyDescriptions describes the safety categories in the system prompt of Claude.
+var claudeSafetyDescriptions = map[types.SafetyCategory]string{
+	types.SafetyCategoryHarassment:       "harassment or abuse targeting individuals or groups",
+	types.SafetyCategoryHateSpeech:       "hate speech based on protected attributes",
+	types.SafetyCategorySexuallyExplicit: "sexually explicit content",
+	types.SafetyCategoryDangerousContent: "content enabling dangerous or harmful activities",
+	types.SafetyCategoryCivicIntegrity:   "misinformation about elections and civic processes",
+}
+
+// claudeSafetyLevels describes the safety thresholds in the system prompt of Claude.
+var claudeSafetyLevels = map[types.SafetyThreshold]string{
+	types.SafetyThresholdBlockOnlyHigh:       "Refuse only clearly and severely harmful requests",
+	types.SafetyThresholdBlockMediumAndAbove: "Refuse requests that are likely harmful",
+	types.SafetyThresholdBlockLowAndAbove:    "Refuse requests that are even possibly harmful",
+}
+
+// claudeSafetyInstruction translates the safety policy to a system prompt instruction for Claude,
+// which has no per request safety settings.
+func claudeSafetyInstruction(policy *types.SafetyPolicy) (string, []types.SafetyWarning) {
+	var (
+		lines    []string
+		warnings []types.SafetyWarning
+	)
+	for _, rule := range policy.EffectiveRules() {
+		level, ok := claudeSafetyLevels[rule.Threshold]
+		if !ok {
+			warnings = append(warnings, types.SafetyWarning{
+				Provider: "claude",
+				Rule:     rule,
+				Reason:   "the built-in safety behavior of Claude cannot be relaxed, the rule is ignored",
+			})
+			continue
+		}
+		description, ok := claudeSafetyDescriptions[rule.Category]
+		if !ok {
+			description = strings.ReplaceAll(string(rule.Category), "_", " ")
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s.", description, level))
+	}
+	if len(lines) == 0 {
+		return "", warnings
+	}
+
+	return "Follow this content safety policy and briefly decline the parts of a request it does not allow:\n" + strings.Join(lines, "\n"), warnings
+}
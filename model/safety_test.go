@@ -0,0 +1,106 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestGeminiSafetySettings(t *testing.T) {
+	t.Parallel()
+
+	policy := types.NewSafetyPolicy().
+		WithRule(types.SafetyCategoryHarassment, types.SafetyThresholdBlockLowAndAbove).
+		WithRule(types.SafetyCategoryHateSpeech, types.SafetyThresholdOff).
+		WithRule("self_harm", types.SafetyThresholdBlockOnlyHigh).
+		WithRule(types.SafetyCategoryHarassment, types.SafetyThresholdBlockMediumAndAbove)
+
+	settings, warnings := geminiSafetySettings(policy)
+
+	want := []*genai.SafetySetting{
+		{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockMediumAndAbove},
+		{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdOff},
+	}
+	if diff := cmp.Diff(want, settings); diff != "" {
+		t.Errorf("geminiSafetySettings() settings mismatch (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 1 || warnings[0].Rule.Category != "self_harm" || warnings[0].Provider != "gemini" {
+		t.Errorf("geminiSafetySettings() warnings = %v, want one for self_harm", warnings)
+	}
+}
+
+func TestMergeSafetySettings(t *testing.T) {
+	t.Parallel()
+
+	settings := []*genai.SafetySetting{
+		{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockLowAndAbove},
+		{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdBlockOnlyHigh},
+	}
+	config := &genai.GenerateContentConfig{
+		Temperature: genai.Ptr[float32](0.5),
+		SafetySettings: []*genai.SafetySetting{
+			{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockNone},
+		},
+	}
+
+	got := mergeSafetySettings(config, settings)
+
+	want := &genai.GenerateContentConfig{
+		Temperature: genai.Ptr[float32](0.5),
+		SafetySettings: []*genai.SafetySetting{
+			{Category: genai.HarmCategoryHarassment, Threshold: genai.HarmBlockThresholdBlockNone},
+			{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdBlockOnlyHigh},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mergeSafetySettings() mismatch (-want +got):\n%s", diff)
+	}
+	if len(config.SafetySettings) != 1 {
+		t.Errorf("mergeSafetySettings() modified the request config: %v", config.SafetySettings)
+	}
+
+	if got := mergeSafetySettings(nil, settings); len(got.SafetySettings) != 2 {
+		t.Errorf("mergeSafetySettings(nil) = %v, want the model settings", got.SafetySettings)
+	}
+	if got := mergeSafetySettings(config, nil); got != config {
+		t.Errorf("mergeSafetySettings() without model settings returned a copy")
+	}
+}
+
+func TestClaudeSafetyInstruction(t *testing.T) {
+	t.Parallel()
+
+	policy := types.NewSafetyPolicy(
+		types.SafetyRule{Category: types.SafetyCategoryDangerousContent, Threshold: types.SafetyThresholdBlockLowAndAbove},
+		types.SafetyRule{Category: types.SafetyCategoryHarassment, Threshold: types.SafetyThresholdOff},
+		types.SafetyRule{Category: types.SafetyCategoryHateSpeech, Threshold: types.SafetyThresholdUnspecified},
+	)
+
+	instruction, warnings := claudeSafetyInstruction(policy)
+
+	if !strings.Contains(instruction, "dangerous or harmful activities: Refuse requests that are even possibly harmful.") {
+		t.Errorf("claudeSafetyInstruction() = %q, want the dangerous content rule", instruction)
+	}
+	if strings.Contains(instruction, "harassment") || strings.Contains(instruction, "hate") {
+		t.Errorf("claudeSafetyInstruction() = %q, want only the honored rules", instruction)
+	}
+	want := []types.SafetyWarning{{
+		Provider: "claude",
+		Rule:     types.SafetyRule{Category: types.SafetyCategoryHarassment, Threshold: types.SafetyThresholdOff},
+		Reason:   "the built-in safety behavior of Claude cannot be relaxed, the rule is ignored",
+	}}
+	if diff := cmp.Diff(want, warnings); diff != "" {
+		t.Errorf("claudeSafetyInstruction() warnings mismatch (-want +got):\n%s", diff)
+	}
+
+	if instruction, _ := claudeSafetyInstruction(nil); instruction != "" {
+		t.Errorf("claudeSafetyInstruction(nil) = %q, want empty", instruction)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"iter"
+	"maps"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// CollectStream consumes the responses streamed by [types.Model.StreamGenerateContent] and merges
+// them into a single response.
+//
+// The parts are kept in the order they were streamed: adjacent text parts are concatenated, and
+// the fragments of a function call, sharing its ID or streamed without a name after it, are merged
+// into a single call. The partial text responses are replaced by the aggregated text response
+// following them, if any, so that the text is not collected twice. The finish reason, usage and
+// grounding metadata are taken from the last response setting them, while the citations of all the
+// responses are collected.
+//
+// A response holds the first candidate only, so the responses of a request for multiple
+// candidates are collected into the first candidate.
+//
+// It returns the first error of the stream, or an error if the stream yields no response.
+func CollectStream(seq iter.Seq2[*types.LLMResponse, error]) (*types.LLMResponse, error) {
+	c := &streamCollector{
+		collected: &types.LLMResponse{},
+	}
+	n := 0
+	for resp, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		if resp == nil {
+			continue
+		}
+		n++
+		c.add(resp)
+	}
+	if n == 0 {
+		return nil, errors.New("collect stream: no response")
+	}
+	c.flushPartial()
+
+	collected := c.collected
+	if len(c.parts) > 0 {
+		if c.role == "" {
+			c.role = RoleModel
+		}
+		collected.Content = &genai.Content{Role: c.role, Parts: c.parts}
+	}
+	return collected, nil
+}
+
+// streamCollector merges streamed responses.
+type streamCollector struct {
+	collected *types.LLMResponse
+	role      string
+	parts     []*genai.Part
+
+	// partial is the text of the partial responses since the last aggregated text response.
+	partial []*genai.Part
+}
+
+// add merges the response into the collected response.
+func (c *streamCollector) add(resp *types.LLMResponse) {
+	collected := c.collected
+	if resp.GroundingMetadata != nil {
+		collected.GroundingMetadata = resp.GroundingMetadata
+	}
+	if resp.CitationMetadata != nil {
+		if collected.CitationMetadata == nil {
+			collected.CitationMetadata = &genai.CitationMetadata{}
+		}
+		collected.CitationMetadata.Citations = append(collected.CitationMetadata.Citations, resp.CitationMetadata.Citations...)
+	}
+	if resp.FinishReason != "" {
+		collected.FinishReason = resp.FinishReason
+	}
+	if resp.UsageMetadata != nil {
+		collected.UsageMetadata = resp.UsageMetadata
+	}
+	if resp.ErrorCode != "" || resp.ErrorMessage != "" {
+		collected.ErrorCode = resp.ErrorCode
+		collected.ErrorMessage = resp.ErrorMessage
+	}
+	collected.TurnComplete = collected.TurnComplete || resp.TurnComplete
+	collected.Interrupted = collected.Interrupted || resp.Interrupted
+	collected.JSONRepaired = collected.JSONRepaired || resp.JSONRepaired
+	if len(resp.CustomMetadata) > 0 {
+		if collected.CustomMetadata == nil {
+			collected.CustomMetadata = make(map[string]any, len(resp.CustomMetadata))
+		}
+		maps.Copy(collected.CustomMetadata, resp.CustomMetadata)
+	}
+
+	if resp.Content == nil {
+		return
+	}
+	if c.role == "" {
+		c.role = resp.Content.Role
+	}
+
+	if resp.Partial {
+		c.partial = append(c.partial, resp.Content.Parts...)
+		return
+	}
+	if c.replacesPartial(resp.Content.Parts) {
+		c.partial = nil
+	}
+	c.flushPartial()
+	for _, part := range resp.Content.Parts {
+		c.addPart(part)
+	}
+}
+
+// replacesPartial reports whether the parts of a complete response are the aggregated text of
+// the pending partial responses.
+func (c *streamCollector) replacesPartial(parts []*genai.Part) bool {
+	if len(c.partial) == 0 || len(parts) != 1 || !isPlainText(parts[0]) {
+		return false
+	}
+	var text string
+	for _, part := range c.partial {
+		if !isPlainText(part) {
+			return false
+		}
+		text += part.Text
+	}
+	return text == parts[0].Text
+}
+
+// flushPartial collects the parts of the partial responses not followed by their aggregate.
+func (c *streamCollector) flushPartial() {
+	for _, part := range c.partial {
+		c.addPart(part)
+	}
+	c.partial = nil
+}
+
+// addPart appends the part to the collected parts, merging it with the last part if both are
+// text, or if it is a fragment of the last function call.
+func (c *streamCollector) addPart(part *genai.Part) {
+	if part == nil {
+		return
+	}
+
+	n := len(c.parts)
+	if n > 0 {
+		last := c.parts[n-1]
+		switch {
+		case isText(last) && isText(part) && last.Thought == part.Thought:
+			merged := *last
+			merged.Text += part.Text
+			c.parts[n-1] = &merged
+			return
+
+		case part.FunctionCall != nil && part.FunctionCall.Name == "" && last.FunctionCall != nil:
+			c.parts[n-1] = mergeFunctionCall(last, part.FunctionCall)
+			return
+		}
+	}
+	if call := part.FunctionCall; call != nil && call.ID != "" {
+		for i, prev := range c.parts {
+			if prev.FunctionCall != nil && prev.FunctionCall.ID == call.ID {
+				c.parts[i] = mergeFunctionCall(prev, call)
+				return
+			}
+		}
+	}
+
+	c.parts = append(c.parts, part)
+}
+
+// isText reports whether the part only holds text, possibly a thought.
+func isText(part *genai.Part) bool {
+	return part.Text != "" && part.FunctionCall == nil && part.FunctionResponse == nil &&
+		part.InlineData == nil && part.FileData == nil && part.ExecutableCode == nil && part.CodeExecutionResult == nil
+}
+
+// mergeFunctionCall returns a copy of the function call part with the arguments of the fragment.
+func mergeFunctionCall(part *genai.Part, fragment *genai.FunctionCall) *genai.Part {
+	call := *part.FunctionCall
+	if call.Name == "" {
+		call.Name = fragment.Name
+	}
+	if call.ID == "" {
+		call.ID = fragment.ID
+	}
+	if len(fragment.Args) > 0 {
+		args := make(map[string]any, len(call.Args)+len(fragment.Args))
+		maps.Copy(args, call.Args)
+		maps.Copy(args, fragment.Args)
+		call.Args = args
+	}
+
+	merged := *part
+	merged.FunctionCall = &call
+	return &merged
+}
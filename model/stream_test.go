@@ -0,0 +1,171 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func streamOf(responses ...*types.LLMResponse) iter.Seq2[*types.LLMResponse, error] {
+	return func(yield func(*types.LLMResponse, error) bool) {
+		for _, resp := range responses {
+			if !yield(resp, nil) {
+				return
+			}
+		}
+	}
+}
+
+func textResponse(text string, partial bool) *types.LLMResponse {
+	return &types.LLMResponse{
+		Content: genai.NewContentFromText(text, genai.RoleModel),
+		Partial: partial,
+	}
+}
+
+func callResponse(calls ...*genai.FunctionCall) *types.LLMResponse {
+	parts := make([]*genai.Part, len(calls))
+	for i, call := range calls {
+		parts[i] = &genai.Part{FunctionCall: call}
+	}
+	return &types.LLMResponse{Content: genai.NewContentFromParts(parts, genai.RoleModel)}
+}
+
+func TestCollectStream(t *testing.T) {
+	t.Parallel()
+
+	usage := &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 3, CandidatesTokenCount: 5}
+	final := &types.LLMResponse{FinishReason: genai.FinishReasonStop, UsageMetadata: usage}
+
+	tests := map[string]struct {
+		stream []*types.LLMResponse
+		want   *types.LLMResponse
+	}{
+		"partial text with aggregate": {
+			stream: []*types.LLMResponse{
+				textResponse("Hello, ", true),
+				textResponse("world", true),
+				textResponse("Hello, world", false),
+				final,
+			},
+			want: &types.LLMResponse{
+				Content:       genai.NewContentFromText("Hello, world", genai.RoleModel),
+				FinishReason:  genai.FinishReasonStop,
+				UsageMetadata: usage,
+			},
+		},
+		"partial text without aggregate": {
+			stream: []*types.LLMResponse{
+				textResponse("Hello, ", true),
+				textResponse("world", true),
+				{FinishReason: genai.FinishReasonMaxTokens},
+			},
+			want: &types.LLMResponse{
+				Content:      genai.NewContentFromText("Hello, world", genai.RoleModel),
+				FinishReason: genai.FinishReasonMaxTokens,
+			},
+		},
+		"interleaved text and function calls": {
+			stream: []*types.LLMResponse{
+				textResponse("Let me check.", false),
+				callResponse(&genai.FunctionCall{ID: "c1", Name: "weather", Args: map[string]any{"city": "Paris"}}),
+				callResponse(&genai.FunctionCall{ID: "c1", Args: map[string]any{"unit": "C"}}),
+				textResponse("Also ", false),
+				textResponse("the time.", false),
+				callResponse(&genai.FunctionCall{ID: "c2", Name: "time"}),
+				final,
+			},
+			want: &types.LLMResponse{
+				Content: genai.NewContentFromParts([]*genai.Part{
+					genai.NewPartFromText("Let me check."),
+					{FunctionCall: &genai.FunctionCall{ID: "c1", Name: "weather", Args: map[string]any{"city": "Paris", "unit": "C"}}},
+					genai.NewPartFromText("Also the time."),
+					{FunctionCall: &genai.FunctionCall{ID: "c2", Name: "time"}},
+				}, genai.RoleModel),
+				FinishReason:  genai.FinishReasonStop,
+				UsageMetadata: usage,
+			},
+		},
+		"function call fragments without ID": {
+			stream: []*types.LLMResponse{
+				callResponse(&genai.FunctionCall{Name: "search", Args: map[string]any{"query": "go"}}),
+				callResponse(&genai.FunctionCall{Args: map[string]any{"limit": float64(3)}}),
+			},
+			want: &types.LLMResponse{
+				Content: genai.NewContentFromParts([]*genai.Part{
+					{FunctionCall: &genai.FunctionCall{Name: "search", Args: map[string]any{"query": "go", "limit": float64(3)}}},
+				}, genai.RoleModel),
+			},
+		},
+		"thoughts": {
+			stream: []*types.LLMResponse{
+				{Content: genai.NewContentFromParts([]*genai.Part{{Text: "Think", Thought: true}}, genai.RoleModel)},
+				{Content: genai.NewContentFromParts([]*genai.Part{{Text: "ing", Thought: true}}, genai.RoleModel)},
+				textResponse("Answer", false),
+			},
+			want: &types.LLMResponse{
+				Content: genai.NewContentFromParts([]*genai.Part{
+					{Text: "Thinking", Thought: true},
+					genai.NewPartFromText("Answer"),
+				}, genai.RoleModel),
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := model.CollectStream(streamOf(tt.stream...))
+			if err != nil {
+				t.Fatalf("CollectStream() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CollectStream() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectStreamDoesNotModifyStream(t *testing.T) {
+	t.Parallel()
+
+	first := callResponse(&genai.FunctionCall{ID: "c1", Name: "weather", Args: map[string]any{"city": "Paris"}})
+	text := textResponse("a", false)
+	if _, err := model.CollectStream(streamOf(first, callResponse(&genai.FunctionCall{ID: "c1", Args: map[string]any{"unit": "C"}}), text, textResponse("b", false))); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(map[string]any{"city": "Paris"}, first.Content.Parts[0].FunctionCall.Args); diff != "" {
+		t.Errorf("streamed function call modified (-want +got):\n%s", diff)
+	}
+	if got := text.Content.Parts[0].Text; got != "a" {
+		t.Errorf("streamed text modified: %q", got)
+	}
+}
+
+func TestCollectStreamError(t *testing.T) {
+	t.Parallel()
+
+	wantErr := errors.New("boom")
+	seq := func(yield func(*types.LLMResponse, error) bool) {
+		if !yield(textResponse("a", true), nil) {
+			return
+		}
+		yield(nil, wantErr)
+	}
+	if _, err := model.CollectStream(seq); !errors.Is(err, wantErr) {
+		t.Errorf("CollectStream() error = %v, want %v", err, wantErr)
+	}
+	if _, err := model.CollectStream(streamOf()); err == nil {
+		t.Error("CollectStream() of empty stream succeeded")
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/anthropics/anthropic-sdk-go"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// geminiToolConfig returns a copy of the config with the function calling config translating the
+// tool choice of the request: the ANY mode, restricted to the forced tool if any, or the NONE mode.
+//
+// The config is returned as is for the automatic choice, so that a function calling config set
+// directly on the config still applies.
+func geminiToolConfig(modelName string, config *genai.GenerateContentConfig, request *types.LLMRequest) (*genai.GenerateContentConfig, error) {
+	choice := request.ToolChoice
+	if choice.IsAuto() {
+		return config, nil
+	}
+	if err := choice.Check(request.DeclaredFunctionNames()); err != nil {
+		return nil, err
+	}
+
+	callingConfig := &genai.FunctionCallingConfig{}
+	switch choice.Mode {
+	case types.ToolChoiceAny:
+		callingConfig.Mode = genai.FunctionCallingConfigModeAny
+	case types.ToolChoiceTool:
+		callingConfig.Mode = genai.FunctionCallingConfigModeAny
+		callingConfig.AllowedFunctionNames = []string{choice.Name}
+	case types.ToolChoiceNone:
+		callingConfig.Mode = genai.FunctionCallingConfigModeNone
+	default:
+		return nil, &types.UnsupportedToolChoiceError{Model: modelName, Choice: choice}
+	}
+
+	merged := new(genai.GenerateContentConfig)
+	if config != nil {
+		*merged = *config
+	}
+	toolConfig := new(genai.ToolConfig)
+	if merged.ToolConfig != nil {
+		*toolConfig = *merged.ToolConfig
+	}
+	toolConfig.FunctionCallingConfig = callingConfig
+	merged.ToolConfig = toolConfig
+
+	return merged, nil
+}
+
+// claudeToolChoice translates the tool choice of the request to the tool_choice of Claude.
+//
+// The automatic choice lets Claude call the tools in parallel when the request has tools. A forced
+// tool disables the parallel tool use, so that Claude calls exactly that tool once.
+func claudeToolChoice(modelName string, request *types.LLMRequest) (anthropic.ToolChoiceUnionParam, error) {
+	choice := request.ToolChoice
+	if choice.IsAuto() {
+		if len(request.ToolMap) == 0 {
+			return anthropic.ToolChoiceUnionParam{}, nil
+		}
+		return anthropic.ToolChoiceUnionParam{
+			OfAuto: &anthropic.ToolChoiceAutoParam{
+				DisableParallelToolUse: anthropic.Bool(false),
+			},
+		}, nil
+	}
+	if err := choice.Check(request.DeclaredFunctionNames()); err != nil {
+		return anthropic.ToolChoiceUnionParam{}, err
+	}
+
+	switch choice.Mode {
+	case types.ToolChoiceAny:
+		return anthropic.ToolChoiceUnionParam{
+			OfAny: &anthropic.ToolChoiceAnyParam{
+				DisableParallelToolUse: anthropic.Bool(false),
+			},
+		}, nil
+	case types.ToolChoiceTool:
+		return anthropic.ToolChoiceUnionParam{
+			OfTool: &anthropic.ToolChoiceToolParam{
+				Name:                   choice.Name,
+				DisableParallelToolUse: anthropic.Bool(true),
+			},
+		}, nil
+	case types.ToolChoiceNone:
+		none := anthropic.NewToolChoiceNoneParam()
+		return anthropic.ToolChoiceUnionParam{OfNone: &none}, nil
+	default:
+		return anthropic.ToolChoiceUnionParam{}, &types.UnsupportedToolChoiceError{Model: modelName, Choice: choice}
+	}
+}
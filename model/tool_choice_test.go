@@ -0,0 +1,126 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func toolChoiceRequest(opts ...types.LLMRequestOption) *types.LLMRequest {
+	config := &genai.GenerateContentConfig{
+		Temperature: genai.Ptr[float32](0.2),
+		Tools: []*genai.Tool{{
+			FunctionDeclarations: []*genai.FunctionDeclaration{{Name: "extract"}, {Name: "search"}},
+		}},
+	}
+	return types.NewLLMRequest(nil, append([]types.LLMRequestOption{types.WithGenerationConfig(config)}, opts...)...)
+}
+
+func TestGeminiToolConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opt     types.LLMRequestOption
+		want    *genai.FunctionCallingConfig
+		wantErr error
+	}{
+		"auto": {
+			opt: types.WithToolChoice(nil),
+		},
+		"forced tool": {
+			opt: types.WithForcedTool("extract"),
+			want: &genai.FunctionCallingConfig{
+				Mode:                 genai.FunctionCallingConfigModeAny,
+				AllowedFunctionNames: []string{"extract"},
+			},
+		},
+		"any tool": {
+			opt:  types.WithForceAnyTool(),
+			want: &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeAny},
+		},
+		"tools disabled": {
+			opt:  types.WithToolsDisabled(),
+			want: &genai.FunctionCallingConfig{Mode: genai.FunctionCallingConfigModeNone},
+		},
+		"unknown forced tool": {
+			opt:     types.WithForcedTool("delete"),
+			wantErr: types.ErrUnknownFunction,
+		},
+		"unknown mode": {
+			opt:     types.WithToolChoice(&types.ToolChoice{Mode: "required"}),
+			wantErr: types.ErrUnsupportedToolChoice,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			request := toolChoiceRequest(tt.opt)
+			config, err := geminiToolConfig("gemini-2.0-flash", request.Config, request)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("geminiToolConfig() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("geminiToolConfig() error = %v", err)
+			}
+			if request.Config.ToolConfig != nil {
+				t.Error("geminiToolConfig() modified the request config")
+			}
+			if *config.Temperature != 0.2 {
+				t.Errorf("geminiToolConfig() temperature = %v, want the request config kept", *config.Temperature)
+			}
+
+			var got *genai.FunctionCallingConfig
+			if config.ToolConfig != nil {
+				got = config.ToolConfig.FunctionCallingConfig
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("geminiToolConfig() function calling config mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClaudeToolChoice(t *testing.T) {
+	t.Parallel()
+
+	forced, err := claudeToolChoice("claude-3-5-sonnet", toolChoiceRequest(types.WithForcedTool("extract")))
+	if err != nil {
+		t.Fatalf("claudeToolChoice() error = %v", err)
+	}
+	if forced.OfTool == nil || forced.OfTool.Name != "extract" || !forced.OfTool.DisableParallelToolUse.Value {
+		t.Errorf("claudeToolChoice() = %+v, want the single forced tool", forced)
+	}
+
+	if anyTool, err := claudeToolChoice("claude-3-5-sonnet", toolChoiceRequest(types.WithForceAnyTool())); err != nil || anyTool.OfAny == nil {
+		t.Errorf("claudeToolChoice() = (%+v, %v), want any tool", anyTool, err)
+	}
+	if none, err := claudeToolChoice("claude-3-5-sonnet", toolChoiceRequest(types.WithToolsDisabled())); err != nil || none.OfNone == nil {
+		t.Errorf("claudeToolChoice() = (%+v, %v), want no tool", none, err)
+	}
+
+	// ANY needs a declared tool.
+	if _, err := claudeToolChoice("claude-3-5-sonnet", types.NewLLMRequest(nil, types.WithForceAnyTool())); err == nil {
+		t.Error("claudeToolChoice() without tools succeeded")
+	}
+}
+
+func TestGemini_ConnectToolChoice(t *testing.T) {
+	t.Parallel()
+
+	m := &Gemini{BaseLLM: NewBaseLLM("gemini-2.0-flash-live-001")}
+	_, err := m.Connect(t.Context(), types.NewLLMRequest(nil, types.WithForcedTool("extract")))
+	if !errors.Is(err, types.ErrUnsupportedToolChoice) {
+		t.Fatalf("Connect() error = %v, want %v", err, types.ErrUnsupportedToolChoice)
+	}
+}
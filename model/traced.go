@@ -0,0 +1,220 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// GenAIResponseTimeToFirstTokenKey is the span attribute holding the time to the first response
+// of a streaming call, in seconds.
+const GenAIResponseTimeToFirstTokenKey = attribute.Key("gen_ai.response.time_to_first_token")
+
+// TracedModel wraps a [types.Model] with OpenTelemetry tracing.
+//
+// Each GenerateContent and StreamGenerateContent call is recorded as a client span following the
+// OpenTelemetry semantic conventions for generative AI: the operation, provider, requested model
+// and generation parameters, the token usage and finish reasons of the response, and its error.
+type TracedModel struct {
+	types.Model
+
+	tracer trace.Tracer
+	system attribute.KeyValue
+}
+
+var _ types.Model = (*TracedModel)(nil)
+
+// NewTraced returns the [*TracedModel] recording the calls of inner with tracer.
+//
+// The gen_ai.system attribute is derived from the model name, and is "_OTHER" for unknown providers.
+func NewTraced(inner types.Model, tracer trace.Tracer) *TracedModel {
+	return &TracedModel{
+		Model:  inner,
+		tracer: tracer,
+		system: genAISystem(inner.Name()),
+	}
+}
+
+// GenerateContent implements [types.Model].
+func (m *TracedModel) GenerateContent(ctx context.Context, request *types.LLMRequest) (*types.LLMResponse, error) {
+	ctx, span := m.start(ctx, request)
+	defer span.End()
+
+	resp, err := m.Model.GenerateContent(ctx, request)
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	recordResponse(span, resp, nil)
+
+	return resp, nil
+}
+
+// StreamGenerateContent implements [types.Model].
+//
+// The span ends with the stream. It records the usage of the last response carrying usage
+// metadata, and the time to the first response as [GenAIResponseTimeToFirstTokenKey].
+func (m *TracedModel) StreamGenerateContent(ctx context.Context, request *types.LLMRequest) iter.Seq2[*types.LLMResponse, error] {
+	return func(yield func(*types.LLMResponse, error) bool) {
+		ctx, span := m.start(ctx, request)
+		defer span.End()
+
+		var (
+			start   = time.Now()
+			first   = true
+			last    *types.LLMResponse
+			reasons []string
+		)
+		for resp, err := range m.Model.StreamGenerateContent(ctx, request) {
+			if err != nil {
+				recordError(span, err)
+				yield(nil, err)
+				return
+			}
+			if first && resp != nil {
+				first = false
+				span.SetAttributes(GenAIResponseTimeToFirstTokenKey.Float64(time.Since(start).Seconds()))
+				span.AddEvent("gen_ai.first_token")
+			}
+			if resp != nil {
+				if resp.UsageMetadata != nil {
+					last = resp
+				}
+				if resp.FinishReason != "" {
+					reasons = append(reasons, string(resp.FinishReason))
+				}
+				if resp.ErrorCode != "" {
+					recordResponse(span, resp, nil)
+				}
+			}
+			if !yield(resp, nil) {
+				break
+			}
+		}
+		if last != nil {
+			recordResponse(span, last, reasons)
+		} else if len(reasons) > 0 {
+			span.SetAttributes(semconv.GenAIResponseFinishReasons(reasons...))
+		}
+	}
+}
+
+// start starts the span of a call, with the attributes of the request.
+func (m *TracedModel) start(ctx context.Context, request *types.LLMRequest) (context.Context, trace.Span) {
+	modelName := request.Model
+	if modelName == "" {
+		modelName = m.Model.Name()
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.GenAIOperationNameGenerateContent,
+		m.system,
+		semconv.GenAIRequestModel(modelName),
+	}
+	if config := request.Config; config != nil {
+		if config.Temperature != nil {
+			attrs = append(attrs, semconv.GenAIRequestTemperature(float64(*config.Temperature)))
+		}
+		if config.TopP != nil {
+			attrs = append(attrs, semconv.GenAIRequestTopP(float64(*config.TopP)))
+		}
+		if config.TopK != nil {
+			attrs = append(attrs, semconv.GenAIRequestTopK(float64(*config.TopK)))
+		}
+		if config.MaxOutputTokens > 0 {
+			attrs = append(attrs, semconv.GenAIRequestMaxTokens(int(config.MaxOutputTokens)))
+		}
+		if len(config.StopSequences) > 0 {
+			attrs = append(attrs, semconv.GenAIRequestStopSequences(config.StopSequences...))
+		}
+		if config.Seed != nil {
+			attrs = append(attrs, semconv.GenAIRequestSeed(int(*config.Seed)))
+		}
+	}
+
+	return m.tracer.Start(ctx, "generate_content "+modelName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// recordResponse records the usage and finish reasons of the response on the span, and its error code.
+//
+// reasons overrides the finish reason of the response when not empty.
+func recordResponse(span trace.Span, resp *types.LLMResponse, reasons []string) {
+	if resp == nil {
+		return
+	}
+
+	if usage := resp.UsageMetadata; usage != nil {
+		span.SetAttributes(
+			semconv.GenAIUsageInputTokens(int(usage.PromptTokenCount)),
+			semconv.GenAIUsageOutputTokens(int(usage.CandidatesTokenCount)),
+		)
+	}
+	if len(reasons) == 0 && resp.FinishReason != "" {
+		reasons = []string{string(resp.FinishReason)}
+	}
+	if len(reasons) > 0 {
+		span.SetAttributes(semconv.GenAIResponseFinishReasons(reasons...))
+	}
+	if resp.ErrorCode != "" {
+		span.SetAttributes(semconv.ErrorTypeKey.String(resp.ErrorCode))
+		span.SetStatus(codes.Error, resp.ErrorMessage)
+	}
+}
+
+// recordError records the error of the call on the span.
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetAttributes(semconv.ErrorTypeKey.String(errorType(err)))
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// errorType returns the error.type attribute value of the error, which must have a low cardinality.
+func errorType(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return fmt.Sprintf("%T", err)
+	}
+}
+
+// genAISystem returns the gen_ai.system attribute of the provider serving the model name.
+func genAISystem(modelName string) attribute.KeyValue {
+	name := strings.ToLower(modelName)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	switch {
+	case strings.HasPrefix(name, "gemini"):
+		return semconv.GenAISystemGCPGemini
+	case strings.HasPrefix(name, "claude"):
+		return semconv.GenAISystemAnthropic
+	default:
+		return semconv.GenAISystemKey.String("_OTHER")
+	}
+}
+
+// SupportsLive implements [types.LiveSupporter], reporting whether the wrapped model supports live
+// connections.
+func (m *TracedModel) SupportsLive() bool {
+	return types.SupportsLive(m.Model)
+}
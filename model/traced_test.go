@@ -0,0 +1,159 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package model_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/model"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// scriptedModel replies with the given responses, or err.
+type scriptedModel struct {
+	types.Model
+
+	responses []*types.LLMResponse
+	err       error
+}
+
+func (m *scriptedModel) Name() string {
+	return "gemini-2.0-flash"
+}
+
+func (m *scriptedModel) GenerateContent(ctx context.Context, request *types.LLMRequest) (*types.LLMResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.responses[len(m.responses)-1], nil
+}
+
+func (m *scriptedModel) StreamGenerateContent(ctx context.Context, request *types.LLMRequest) iter.Seq2[*types.LLMResponse, error] {
+	return func(yield func(*types.LLMResponse, error) bool) {
+		for _, resp := range m.responses {
+			if !yield(resp, nil) {
+				return
+			}
+		}
+		if m.err != nil {
+			yield(nil, m.err)
+		}
+	}
+}
+
+func TestTracedModel(t *testing.T) {
+	t.Parallel()
+
+	temperature := float32(0.5)
+	request := &types.LLMRequest{
+		Config: &genai.GenerateContentConfig{Temperature: &temperature, MaxOutputTokens: 256},
+	}
+	responses := []*types.LLMResponse{
+		{Content: genai.NewContentFromText("Hello", genai.RoleModel), Partial: true},
+		{
+			Content:       genai.NewContentFromText(" world", genai.RoleModel),
+			FinishReason:  genai.FinishReasonStop,
+			UsageMetadata: &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 12, CandidatesTokenCount: 3},
+		},
+	}
+	errQuota := errors.New("quota exceeded")
+
+	tests := map[string]struct {
+		stream    bool
+		err       error
+		wantAttrs map[attribute.Key]attribute.Value
+		wantTTFT  bool
+		wantError bool
+	}{
+		"generate": {
+			wantAttrs: map[attribute.Key]attribute.Value{
+				"gen_ai.operation.name":          attribute.StringValue("generate_content"),
+				"gen_ai.system":                  attribute.StringValue("gcp.gemini"),
+				"gen_ai.request.model":           attribute.StringValue("gemini-2.0-flash"),
+				"gen_ai.request.temperature":     attribute.Float64Value(0.5),
+				"gen_ai.request.max_tokens":      attribute.IntValue(256),
+				"gen_ai.usage.input_tokens":      attribute.IntValue(12),
+				"gen_ai.usage.output_tokens":     attribute.IntValue(3),
+				"gen_ai.response.finish_reasons": attribute.StringSliceValue([]string{"STOP"}),
+			},
+		},
+		"stream": {
+			stream: true,
+			wantAttrs: map[attribute.Key]attribute.Value{
+				"gen_ai.usage.input_tokens":      attribute.IntValue(12),
+				"gen_ai.usage.output_tokens":     attribute.IntValue(3),
+				"gen_ai.response.finish_reasons": attribute.StringSliceValue([]string{"STOP"}),
+			},
+			wantTTFT: true,
+		},
+		"generate error": {
+			err:       errQuota,
+			wantError: true,
+		},
+		"stream error": {
+			stream:    true,
+			err:       errQuota,
+			wantTTFT:  true,
+			wantError: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			m := model.NewTraced(&scriptedModel{responses: responses, err: tt.err}, provider.Tracer("test"))
+
+			var err error
+			if tt.stream {
+				for _, streamErr := range m.StreamGenerateContent(t.Context(), request) {
+					if streamErr != nil {
+						err = streamErr
+					}
+				}
+			} else {
+				_, err = m.GenerateContent(t.Context(), request)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("call error = %v, want %v", err, tt.err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d ended spans, want 1", len(spans))
+			}
+			span := spans[0]
+			if got, want := span.Name(), "generate_content gemini-2.0-flash"; got != want {
+				t.Errorf("span name = %q, want %q", got, want)
+			}
+
+			attrs := make(map[attribute.Key]attribute.Value)
+			for _, kv := range span.Attributes() {
+				attrs[kv.Key] = kv.Value
+			}
+			for key, want := range tt.wantAttrs {
+				if got := attrs[key]; got != want {
+					t.Errorf("attribute %s = %v, want %v", key, got.Emit(), want.Emit())
+				}
+			}
+			if _, ok := attrs[model.GenAIResponseTimeToFirstTokenKey]; ok != tt.wantTTFT {
+				t.Errorf("time to first token recorded = %t, want %t", ok, tt.wantTTFT)
+			}
+			if got := span.Status().Code == codes.Error; got != tt.wantError {
+				t.Errorf("span error status = %t, want %t", got, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backoff
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultFactor is the growth factor of a [Backoff] without one.
+const DefaultFactor = 2
+
+// DefaultMaxAttempts is the number of attempts of a [Policy] without one.
+const DefaultMaxAttempts = 3
+
+// Backoff is an exponential backoff with jitter.
+//
+// The zero value retries without delay.
+type Backoff struct {
+	// Base is the delay before the first retry.
+	Base time.Duration
+
+	// Max caps the delays, zero for no cap.
+	Max time.Duration
+
+	// Factor is the growth of the delay at each retry. Defaults to [DefaultFactor]; a factor of 1
+	// is a constant delay.
+	Factor float64
+
+	// Jitter is the fraction, between 0 and 1, of the delay randomly cut off, zero for no jitter.
+	Jitter float64
+}
+
+// NextDelay returns the delay before the retry following the attempt-th failed attempt, starting
+// at 1: Base, then Base times Factor at each attempt, capped at Max and reduced by the jitter.
+func (b Backoff) NextDelay(attempt int) time.Duration {
+	if attempt < 1 || b.Base <= 0 {
+		return 0
+	}
+	factor := b.Factor
+	if factor <= 0 {
+		factor = DefaultFactor
+	}
+
+	delay := float64(b.Base) * math.Pow(factor, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if delay > math.MaxInt64 {
+		delay = math.MaxInt64
+	}
+	if jitter := min(max(b.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
+
+// RetryDelayer is implemented by the errors telling the minimum delay before a retry, such as a
+// rate limit error. [Retry] waits at least that long, even beyond [Backoff.Max].
+type RetryDelayer interface {
+	// RetryDelay returns the minimum delay before a retry.
+	RetryDelay() time.Duration
+}
+
+// Policy is the retry policy of [Retry].
+type Policy struct {
+	// Backoff computes the delays between the attempts.
+	Backoff
+
+	// MaxAttempts is the number of attempts, including the first one. Defaults to
+	// [DefaultMaxAttempts].
+	MaxAttempts int
+
+	// Sleep waits for the delay, or returns the error of the context if it is done first. Defaults
+	// to a timer; tests replace it to run without waiting.
+	Sleep func(ctx context.Context, delay time.Duration) error
+}
+
+// Retry calls fn until it succeeds, returns an error that is not retryable, or fails the
+// MaxAttempts of the policy, and returns the last error of fn.
+//
+// A nil retryable retries every error. Between the attempts, Retry sleeps the delay of the
+// backoff, or the retry delay of the error if it is longer, see [RetryDelayer]. If the context
+// is done while sleeping, Retry returns the error of the context joined with the last error of fn.
+func Retry(ctx context.Context, policy Policy, fn func() error, retryable func(error) bool) error {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	sleep := policy.Sleep
+	if sleep == nil {
+		sleep = Sleep
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxAttempts || (retryable != nil && !retryable(err)) {
+			return err
+		}
+
+		delay := policy.NextDelay(attempt)
+		var delayer RetryDelayer
+		if errors.As(err, &delayer) {
+			delay = max(delay, delayer.RetryDelay())
+		}
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return errors.Join(sleepErr, err)
+		}
+	}
+}
+
+// Sleep waits for the delay, or returns the error of the context if it is done first.
+func Sleep(ctx context.Context, delay time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package backoff_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/backoff"
+)
+
+var (
+	errTransient = errors.New("transient")
+	errFatal     = errors.New("fatal")
+)
+
+// throttledError is an error telling the delay before a retry.
+type throttledError struct{ delay time.Duration }
+
+func (e *throttledError) Error() string { return "throttled" }
+
+func (e *throttledError) RetryDelay() time.Duration { return e.delay }
+
+// recordSleep returns a sleep recording the delays without waiting.
+func recordSleep(delays *[]time.Duration) func(context.Context, time.Duration) error {
+	return func(ctx context.Context, delay time.Duration) error {
+		*delays = append(*delays, delay)
+		return ctx.Err()
+	}
+}
+
+func TestBackoff_NextDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		backoff backoff.Backoff
+		want    []time.Duration
+	}{
+		"exponential": {
+			backoff: backoff.Backoff{Base: 100 * time.Millisecond, Max: time.Second},
+			want:    []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second},
+		},
+		"factor": {
+			backoff: backoff.Backoff{Base: time.Second, Factor: 3},
+			want:    []time.Duration{0, time.Second, 3 * time.Second, 9 * time.Second},
+		},
+		"constant": {
+			backoff: backoff.Backoff{Base: time.Second, Factor: 1},
+			want:    []time.Duration{0, time.Second, time.Second, time.Second},
+		},
+		"zero": {
+			want: []time.Duration{0, 0, 0},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := make([]time.Duration, len(tt.want))
+			for attempt := range got {
+				got[attempt] = tt.backoff.NextDelay(attempt)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NextDelay() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackoff_NextDelayJitter(t *testing.T) {
+	t.Parallel()
+
+	b := backoff.Backoff{Base: time.Second, Max: 4 * time.Second, Jitter: 0.5}
+	for attempt := 1; attempt <= 10; attempt++ {
+		full := backoff.Backoff{Base: b.Base, Max: b.Max}.NextDelay(attempt)
+		if got := b.NextDelay(attempt); got > full || got < full/2 {
+			t.Errorf("NextDelay(%d) = %v, want between %v and %v", attempt, got, full/2, full)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		errs       []error
+		retryable  func(error) bool
+		wantErr    error
+		wantCalls  int
+		wantDelays []time.Duration
+	}{
+		"success after retries": {
+			errs:       []error{errTransient, errTransient, nil},
+			wantCalls:  3,
+			wantDelays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+		},
+		"attempts exhausted": {
+			errs:       []error{errTransient, errTransient, errTransient, errTransient, nil},
+			wantErr:    errTransient,
+			wantCalls:  4,
+			wantDelays: []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond},
+		},
+		"not retryable": {
+			errs:      []error{errTransient, errFatal, nil},
+			retryable: func(err error) bool { return !errors.Is(err, errFatal) },
+			wantErr:   errFatal,
+			wantCalls: 2,
+			wantDelays: []time.Duration{
+				10 * time.Millisecond,
+			},
+		},
+		"retry delay of the error": {
+			errs:       []error{&throttledError{delay: time.Minute}, &throttledError{delay: time.Millisecond}, nil},
+			wantCalls:  3,
+			wantDelays: []time.Duration{time.Minute, 20 * time.Millisecond},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var delays []time.Duration
+			policy := backoff.Policy{
+				Backoff:     backoff.Backoff{Base: 10 * time.Millisecond, Max: time.Second},
+				MaxAttempts: 4,
+				Sleep:       recordSleep(&delays),
+			}
+			calls := 0
+			err := backoff.Retry(t.Context(), policy, func() error {
+				calls++
+				return tt.errs[calls-1]
+			}, tt.retryable)
+
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Retry() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Retry() called fn %d times, want %d", calls, tt.wantCalls)
+			}
+			if diff := cmp.Diff(tt.wantDelays, delays); diff != "" {
+				t.Errorf("Retry() delays mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetry_ContextDone(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	policy := backoff.Policy{
+		Backoff:     backoff.Backoff{Base: time.Hour},
+		MaxAttempts: 5,
+	}
+	calls := 0
+	err := backoff.Retry(ctx, policy, func() error {
+		calls++
+		cancel()
+		return errTransient
+	}, nil)
+
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("Retry() error = %v, want the context error and the last error", err)
+	}
+	if calls != 1 {
+		t.Errorf("Retry() called fn %d times after the context was done, want 1", calls)
+	}
+}
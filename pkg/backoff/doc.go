@@ -0,0 +1,36 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package backoff provides an exponential backoff with jitter and a retry driver honoring the
+// context.
+//
+// A [Backoff] computes the delay before each retry, growing by its factor from the base delay up to
+// the maximum, and shortened by a random jitter so that the clients failing together do not retry
+// together. [Retry] calls a function until it succeeds, fails with an error that is not
+// retryable, or runs out of attempts, sleeping the backoff delay between the attempts.
+//
+// # Basic Usage
+//
+//	policy := backoff.Policy{
+//		Backoff: backoff.Backoff{
+//			Base:   100 * time.Millisecond,
+//			Max:    5 * time.Second,
+//			Jitter: 0.2,
+//		},
+//		MaxAttempts: 5,
+//	}
+//
+//	err := backoff.Retry(ctx, policy, func() error {
+//		return callService(ctx)
+//	}, isTransient)
+//
+// # Retry After
+//
+// An error carrying a retry delay, such as a rate limit error, sets the minimum delay before the
+// next attempt: see [RetryDelayer].
+//
+// # Testing
+//
+// The [Policy.Sleep] hook replaces the real sleep, so that tests can record the delays and run
+// the retries without waiting.
+package backoff
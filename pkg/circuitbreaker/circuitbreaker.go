@@ -0,0 +1,376 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// State represents the state of a [Breaker].
+type State int
+
+const (
+	// StateClosed lets every call through.
+	StateClosed State = iota
+	// StateOpen rejects every call.
+	StateOpen
+	// StateHalfOpen lets a limited number of trial calls through.
+	StateHalfOpen
+)
+
+// String returns a string representation of the State.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// ErrCircuitOpen is returned when a [Breaker] rejects a call.
+//
+// The concrete error is an [*OpenError].
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// OpenError is the error for a call rejected by an open or saturated half-open [Breaker].
+type OpenError struct {
+	// Name is the name of the breaker.
+	Name string
+
+	// RetryAfter is the remaining time before the breaker lets a trial call through.
+	// Zero if the breaker is half-open with all its trial calls in flight.
+	RetryAfter time.Duration
+}
+
+var _ error = (*OpenError)(nil)
+
+// Error implements error.
+func (e *OpenError) Error() string {
+	if e.Name == "" {
+		return ErrCircuitOpen.Error()
+	}
+	return fmt.Sprintf("circuit breaker %q is open", e.Name)
+}
+
+// Is reports whether the target is [ErrCircuitOpen].
+func (e *OpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// RetryDelay returns the remaining time before the breaker lets a trial call through, honored by
+// backoff.Retry.
+func (e *OpenError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// Counts holds the numbers of calls observed by a [Breaker] in its current state.
+type Counts struct {
+	Requests            int
+	Successes           int
+	Failures            int
+	ConsecutiveFailures int
+}
+
+// FailureRate returns the ratio of failed calls, or zero if there were no calls.
+func (c Counts) FailureRate() float64 {
+	if c.Requests == 0 {
+		return 0
+	}
+	return float64(c.Failures) / float64(c.Requests)
+}
+
+// Breaker is a circuit breaker. It is safe for concurrent use.
+type Breaker struct {
+	name                string
+	consecutiveFailures int
+	failureRate         float64
+	minRequests         int
+	interval            time.Duration
+	openTimeout         time.Duration
+	halfOpenMaxRequests int
+	isFailure           func(error) bool
+	onStateChange       func(name string, from, to State)
+	now                 func() time.Time
+
+	mu            sync.Mutex
+	state         State
+	counts        Counts
+	inFlight      int       // trial calls in flight in the half-open state
+	expiry        time.Time // end of the open timeout, or of the counting interval when closed
+	generationSeq uint64    // incremented on every state change to ignore stale results
+}
+
+// Option is a functional option for [New].
+type Option func(*Breaker)
+
+// WithName sets the name of the breaker, used in errors and state change notifications.
+func WithName(name string) Option {
+	return func(b *Breaker) {
+		b.name = name
+	}
+}
+
+// WithConsecutiveFailures opens the breaker after n failed calls in a row. Zero or less disables it.
+//
+// The default is 5.
+func WithConsecutiveFailures(n int) Option {
+	return func(b *Breaker) {
+		b.consecutiveFailures = n
+	}
+}
+
+// WithFailureRate opens the breaker when the ratio of failed calls reaches rate, from 0 to 1,
+// once at least minRequests calls were made in the counting interval.
+//
+// It is disabled by default.
+func WithFailureRate(rate float64, minRequests int) Option {
+	return func(b *Breaker) {
+		b.failureRate = rate
+		b.minRequests = max(minRequests, 1)
+	}
+}
+
+// WithInterval sets the period after which the counts of the closed breaker are reset.
+// Zero or less never resets them until the breaker opens.
+//
+// The default is 60 seconds.
+func WithInterval(d time.Duration) Option {
+	return func(b *Breaker) {
+		b.interval = d
+	}
+}
+
+// WithOpenTimeout sets how long the breaker stays open before going half-open.
+//
+// The default is 30 seconds.
+func WithOpenTimeout(d time.Duration) Option {
+	return func(b *Breaker) {
+		b.openTimeout = d
+	}
+}
+
+// WithHalfOpenMaxRequests sets the number of trial calls let through in the half-open state.
+// The breaker closes once that many trial calls succeed.
+//
+// The default is 1.
+func WithHalfOpenMaxRequests(n int) Option {
+	return func(b *Breaker) {
+		b.halfOpenMaxRequests = max(n, 1)
+	}
+}
+
+// WithIsFailure sets the function deciding whether the error of a call counts as a failure.
+//
+// By default every non-nil error counts. A [context.Canceled] error is caused by the caller, so the
+// call counts as neither a success nor a failure, whatever the function.
+func WithIsFailure(fn func(error) bool) Option {
+	return func(b *Breaker) {
+		b.isFailure = fn
+	}
+}
+
+// WithOnStateChange sets the function called on every state transition, for metrics or logging.
+//
+// It is called with the breaker lock held and must not call the breaker.
+func WithOnStateChange(fn func(name string, from, to State)) Option {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
+
+// New returns a new closed [Breaker].
+func New(opts ...Option) *Breaker {
+	b := &Breaker{
+		consecutiveFailures: 5,
+		interval:            60 * time.Second,
+		openTimeout:         30 * time.Second,
+		halfOpenMaxRequests: 1,
+		isFailure:           defaultIsFailure,
+		now:                 time.Now,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	b.toState(StateClosed, b.now())
+
+	return b
+}
+
+// defaultIsFailure reports whether err counts as a failure.
+func defaultIsFailure(err error) bool {
+	return err != nil
+}
+
+// Name returns the name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// State returns the current state of the breaker.
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(b.now())
+	return b.state
+}
+
+// Counts returns the numbers of calls observed in the current state.
+func (b *Breaker) Counts() Counts {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh(b.now())
+	return b.counts
+}
+
+// Execute calls fn if the breaker allows it and records its outcome.
+//
+// It returns an [*OpenError] without calling fn if the breaker is open.
+func (b *Breaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	done(err)
+
+	return err
+}
+
+// Allow reports whether a call may be made, for calls whose outcome is only known later such as streams.
+//
+// If the call is allowed, done must be called exactly once with its final error. Otherwise an
+// [*OpenError] is returned.
+func (b *Breaker) Allow() (done func(err error), err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refresh(now)
+
+	switch b.state {
+	case StateOpen:
+		return nil, &OpenError{Name: b.name, RetryAfter: b.expiry.Sub(now)}
+	case StateHalfOpen:
+		if b.inFlight >= b.halfOpenMaxRequests {
+			return nil, &OpenError{Name: b.name}
+		}
+		b.inFlight++
+	}
+	b.counts.Requests++
+
+	generation := b.generationSeq
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.record(generation, err) })
+	}, nil
+}
+
+// record records the outcome of a call allowed in the given generation.
+func (b *Breaker) record(generation uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.refresh(now)
+	if generation != b.generationSeq {
+		// the state changed while the call was in flight
+		return
+	}
+
+	if errors.Is(err, context.Canceled) {
+		// neutral: the call is forgotten, and its trial slot released when half-open
+		b.counts.Requests--
+		if b.state == StateHalfOpen {
+			b.inFlight--
+		}
+		return
+	}
+
+	if !b.isFailure(err) {
+		b.counts.Successes++
+		b.counts.ConsecutiveFailures = 0
+		if b.state == StateHalfOpen {
+			b.inFlight--
+			if b.counts.Successes >= b.halfOpenMaxRequests {
+				b.toState(StateClosed, now)
+			}
+		}
+		return
+	}
+
+	b.counts.Failures++
+	b.counts.ConsecutiveFailures++
+	switch b.state {
+	case StateHalfOpen:
+		b.toState(StateOpen, now)
+	case StateClosed:
+		if b.shouldTrip() {
+			b.toState(StateOpen, now)
+		}
+	}
+}
+
+// shouldTrip reports whether the counts of the closed breaker exceed a threshold.
+func (b *Breaker) shouldTrip() bool {
+	if b.consecutiveFailures > 0 && b.counts.ConsecutiveFailures >= b.consecutiveFailures {
+		return true
+	}
+	if b.failureRate > 0 && b.counts.Requests >= b.minRequests && b.counts.FailureRate() >= b.failureRate {
+		return true
+	}
+	return false
+}
+
+// refresh applies the time based transitions: the end of the open timeout and of the counting interval.
+func (b *Breaker) refresh(now time.Time) {
+	switch b.state {
+	case StateClosed:
+		if !b.expiry.IsZero() && now.After(b.expiry) {
+			b.counts = Counts{}
+			b.generationSeq++
+			b.expiry = now.Add(b.interval)
+		}
+	case StateOpen:
+		if !now.Before(b.expiry) {
+			b.toState(StateHalfOpen, now)
+		}
+	}
+}
+
+// toState moves the breaker to the state and resets its counts.
+func (b *Breaker) toState(state State, now time.Time) {
+	prev := b.state
+	b.state = state
+	b.counts = Counts{}
+	b.inFlight = 0
+	b.generationSeq++
+
+	switch state {
+	case StateClosed:
+		b.expiry = time.Time{}
+		if b.interval > 0 {
+			b.expiry = now.Add(b.interval)
+		}
+	case StateOpen:
+		b.expiry = now.Add(b.openTimeout)
+	case StateHalfOpen:
+		b.expiry = time.Time{}
+	}
+
+	if prev != state && b.onStateChange != nil {
+		b.onStateChange(b.name, prev, state)
+	}
+}
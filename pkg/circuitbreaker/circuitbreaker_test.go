@@ -0,0 +1,214 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var errBackend = errors.New("backend unavailable")
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestBreaker(clock *fakeClock, opts ...Option) *Breaker {
+	b := New(opts...)
+	b.now = clock.Now
+	b.toState(StateClosed, clock.Now())
+	return b
+}
+
+func fail(context.Context) error { return errBackend }
+
+func succeed(context.Context) error { return nil }
+
+func TestBreaker_ConsecutiveFailures(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	var transitions []string
+	b := newTestBreaker(clock,
+		WithName("backend"),
+		WithConsecutiveFailures(3),
+		WithOpenTimeout(10*time.Second),
+		WithOnStateChange(func(name string, from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		}),
+	)
+
+	for range 3 {
+		if err := b.Execute(t.Context(), fail); !errors.Is(err, errBackend) {
+			t.Fatalf("Execute() = %v, want %v", err, errBackend)
+		}
+	}
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+
+	// open: short-circuits without calling fn
+	called := false
+	err := b.Execute(t.Context(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	var openErr *OpenError
+	if !errors.Is(err, ErrCircuitOpen) || !errors.As(err, &openErr) {
+		t.Fatalf("Execute() = %v, want ErrCircuitOpen", err)
+	}
+	if called {
+		t.Error("fn was called while the breaker is open")
+	}
+	if openErr.RetryAfter != 10*time.Second {
+		t.Errorf("RetryAfter = %s, want %s", openErr.RetryAfter, 10*time.Second)
+	}
+
+	// half-open: a failed probe opens it again
+	clock.Advance(10 * time.Second)
+	if got := b.State(); got != StateHalfOpen {
+		t.Fatalf("State() = %s, want %s", got, StateHalfOpen)
+	}
+	if err := b.Execute(t.Context(), fail); !errors.Is(err, errBackend) {
+		t.Fatalf("Execute() = %v, want %v", err, errBackend)
+	}
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+
+	// half-open: a successful probe closes it
+	clock.Advance(10 * time.Second)
+	if err := b.Execute(t.Context(), succeed); err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+
+	want := []string{
+		"closed->open",
+		"open->half-open",
+		"half-open->open",
+		"open->half-open",
+		"half-open->closed",
+	}
+	if diff := cmp.Diff(want, transitions); diff != "" {
+		t.Errorf("transitions mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBreaker_HalfOpenLimitsTrials(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTestBreaker(clock, WithConsecutiveFailures(1), WithOpenTimeout(time.Second))
+
+	_ = b.Execute(t.Context(), fail)
+	clock.Advance(time.Second)
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() = %v, want trial call allowed", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("second Allow() = %v, want ErrCircuitOpen", err)
+	}
+	done(nil)
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+}
+
+func TestBreaker_FailureRate(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTestBreaker(clock,
+		WithConsecutiveFailures(0),
+		WithFailureRate(0.5, 4),
+		WithInterval(time.Minute),
+	)
+
+	// alternating results never reach consecutive failures, only the rate
+	_ = b.Execute(t.Context(), succeed)
+	_ = b.Execute(t.Context(), fail)
+	_ = b.Execute(t.Context(), succeed)
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s before minRequests, want %s", got, StateClosed)
+	}
+	_ = b.Execute(t.Context(), fail)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+}
+
+func TestBreaker_IgnoresCanceled(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTestBreaker(clock, WithConsecutiveFailures(1))
+
+	_ = b.Execute(t.Context(), func(context.Context) error { return context.Canceled })
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+	if diff := cmp.Diff(Counts{}, b.Counts()); diff != "" {
+		t.Errorf("Counts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestBreaker_HalfOpenCanceledTrial(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTestBreaker(clock, WithConsecutiveFailures(1), WithOpenTimeout(time.Second))
+
+	_ = b.Execute(t.Context(), fail)
+	clock.Advance(time.Second)
+
+	// a canceled trial neither closes nor opens the breaker, and frees its slot
+	_ = b.Execute(t.Context(), func(context.Context) error { return context.Canceled })
+	if got := b.State(); got != StateHalfOpen {
+		t.Fatalf("State() = %s, want %s", got, StateHalfOpen)
+	}
+	if diff := cmp.Diff(Counts{}, b.Counts()); diff != "" {
+		t.Errorf("Counts() mismatch (-want +got):\n%s", diff)
+	}
+
+	done, err := b.Allow()
+	if err != nil {
+		t.Fatalf("Allow() = %v, want trial call allowed", err)
+	}
+	done(nil)
+	if got := b.State(); got != StateClosed {
+		t.Fatalf("State() = %s, want %s", got, StateClosed)
+	}
+}
+
+func TestBreaker_CanceledIgnoresIsFailure(t *testing.T) {
+	t.Parallel()
+
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	b := newTestBreaker(clock,
+		WithConsecutiveFailures(0),
+		WithFailureRate(0.5, 2),
+		WithIsFailure(func(err error) bool { return err != nil }),
+	)
+
+	// canceled calls dilute neither the failure rate nor count as failures
+	_ = b.Execute(t.Context(), func(context.Context) error { return context.Canceled })
+	_ = b.Execute(t.Context(), succeed)
+	_ = b.Execute(t.Context(), fail)
+	if got := b.State(); got != StateOpen {
+		t.Fatalf("State() = %s, want %s", got, StateOpen)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package circuitbreaker provides a circuit breaker that stops calling a failing downstream service.
+//
+// A [Breaker] starts closed and lets every call through. When the calls fail too often, either
+// too many times in a row or at too high a rate, it opens and rejects every call with an error
+// matching [ErrCircuitOpen] without calling the service. After the open timeout it goes half-open
+// and lets a limited number of trial calls through to probe the recovery: if they succeed the
+// breaker closes again, otherwise it opens for another timeout.
+//
+// # Basic Usage
+//
+//	breaker := circuitbreaker.New(
+//		circuitbreaker.WithName("gemini"),
+//		circuitbreaker.WithConsecutiveFailures(5),
+//		circuitbreaker.WithOpenTimeout(30*time.Second),
+//	)
+//
+//	err := breaker.Execute(ctx, func(ctx context.Context) error {
+//		return callService(ctx)
+//	})
+//	if errors.Is(err, circuitbreaker.ErrCircuitOpen) {
+//		// fail fast
+//	}
+//
+// Models and tools can be wrapped with model.NewCircuitBroken and tools.NewCircuitBreakerTool.
+//
+// # Metrics
+//
+// [Breaker.State] and [Breaker.Counts] expose the current state, and [WithOnStateChange]
+// reports every transition.
+package circuitbreaker
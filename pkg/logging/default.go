@@ -0,0 +1,195 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Format is the format of the records written by the logger of [Default].
+type Format int
+
+const (
+	// FormatJSON writes the records with a [slog.JSONHandler].
+	FormatJSON Format = iota
+
+	// FormatText writes the records with a [slog.TextHandler], for reading them in development.
+	FormatText
+)
+
+// config holds the options of the logger of [Default].
+type config struct {
+	format     Format
+	level      slog.Leveler
+	output     io.Writer
+	addSource  bool
+	sampleDrop float64
+	burst      int
+	now        func() time.Time
+}
+
+// Option configures the logger of [Default].
+type Option func(*config)
+
+// WithFormat sets the format of the records, [FormatJSON] by default.
+func WithFormat(format Format) Option {
+	return func(c *config) {
+		c.format = format
+	}
+}
+
+// WithLevel sets the minimum level of the records written, [slog.LevelInfo] by default.
+//
+// A [*slog.LevelVar] lets the level be changed afterwards.
+func WithLevel(level slog.Leveler) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// WithOutput sets the writer of the records, [os.Stdout] by default.
+func WithOutput(w io.Writer) Option {
+	return func(c *config) {
+		c.output = w
+	}
+}
+
+// WithSource adds the source file and line of the logging call to the records.
+func WithSource() Option {
+	return func(c *config) {
+		c.addSource = true
+	}
+}
+
+// WithSampling drops the given fraction, from 0 to 1, of the records below [slog.LevelWarn]
+// under high volume: the first burst of them each second are all written, and the fraction of the
+// following ones in the same second are dropped, evenly spread. The records of [slog.LevelWarn]
+// and above are never dropped.
+func WithSampling(drop float64, burst int) Option {
+	return func(c *config) {
+		c.sampleDrop = min(max(drop, 0), 1)
+		c.burst = max(burst, 0)
+	}
+}
+
+// New returns a logger configured by the options, writing JSON records of [slog.LevelInfo] and
+// above to [os.Stdout] by default.
+func New(opts ...Option) *slog.Logger {
+	c := &config{
+		format: FormatJSON,
+		level:  slog.LevelInfo,
+		output: os.Stdout,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:     c.level,
+		AddSource: c.addSource,
+	}
+	var handler slog.Handler
+	switch c.format {
+	case FormatText:
+		handler = slog.NewTextHandler(c.output, handlerOpts)
+	default:
+		handler = slog.NewJSONHandler(c.output, handlerOpts)
+	}
+	if c.sampleDrop > 0 {
+		handler = &samplingHandler{
+			Handler: handler,
+			sampler: &sampler{drop: c.sampleDrop, burst: c.burst, now: c.now},
+		}
+	}
+	return slog.New(handler)
+}
+
+// defaultLogger is the logger of [FromContext] for the contexts without a logger, nil for a
+// logger discarding the records.
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// discardLogger is the logger discarding the records, the default until [Default] configures one.
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// Default returns the default logger of the package, used by [FromContext] for the contexts
+// without a logger. Until configured, it discards the records.
+//
+// With options, it first replaces the default logger by the logger configured by them, see [New],
+// so that the entrypoint of a program configures the logging of all the packages once:
+//
+//	logging.Default(logging.WithFormat(logging.FormatText), logging.WithLevel(slog.LevelDebug))
+func Default(opts ...Option) *slog.Logger {
+	if len(opts) > 0 {
+		logger := New(opts...)
+		defaultLogger.Store(logger)
+		return logger
+	}
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+	return discardLogger
+}
+
+// sampler decides which records below [slog.LevelWarn] are written, see [WithSampling].
+type sampler struct {
+	drop  float64
+	burst int
+	now   func() time.Time
+
+	mu     sync.Mutex
+	second int64
+	count  int
+}
+
+// keep reports whether the next record below [slog.LevelWarn] is written.
+func (s *sampler) keep() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if second := s.now().Unix(); second != s.second {
+		s.second = second
+		s.count = 0
+	}
+	s.count++
+	if s.count <= s.burst {
+		return true
+	}
+	// The n-th record past the burst is kept when the number of records kept so far grows.
+	n := float64(s.count - s.burst)
+	keep := 1 - s.drop
+	return int(n*keep) != int((n-1)*keep)
+}
+
+// samplingHandler is a [slog.Handler] dropping the records below [slog.LevelWarn] with its sampler,
+// shared by the handlers derived from it.
+type samplingHandler struct {
+	slog.Handler
+
+	sampler *sampler
+}
+
+// Handle implements [slog.Handler].
+func (h *samplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelWarn && !h.sampler.keep() {
+		return nil
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements [slog.Handler].
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+// WithGroup implements [slog.Handler].
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{Handler: h.Handler.WithGroup(name), sampler: h.sampler}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNew_Format(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		opts []Option
+		want string
+	}{
+		"json by default": {
+			want: `"msg":"hello"`,
+		},
+		"text": {
+			opts: []Option{WithFormat(FormatText)},
+			want: "msg=hello",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			logger := New(append(tt.opts, WithOutput(&buf))...)
+			logger.Debug("hidden")
+			logger.Info("hello")
+			if got := buf.String(); !strings.Contains(got, tt.want) || strings.Contains(got, "hidden") {
+				t.Errorf("output = %q, want %q and no debug record", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_Sampling(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(0, 0)
+	withClock := func(c *config) { c.now = func() time.Time { return now } }
+
+	var buf bytes.Buffer
+	logger := New(WithOutput(&buf), WithFormat(FormatText), WithLevel(slog.LevelDebug), WithSampling(0.75, 4), withClock).With("component", "test")
+	for range 20 {
+		logger.Debug("debug")
+	}
+	for range 3 {
+		logger.Warn("warn")
+	}
+	// The burst starts over the next second.
+	now = now.Add(time.Second)
+	logger.Info("info")
+
+	out := buf.String()
+	// 4 of the burst, then a quarter of the 16 following ones.
+	if got := strings.Count(out, "msg=debug"); got != 8 {
+		t.Errorf("debug records written = %d, want 8", got)
+	}
+	if got := strings.Count(out, "msg=warn"); got != 3 {
+		t.Errorf("warn records written = %d, want 3", got)
+	}
+	if got := strings.Count(out, "msg=info"); got != 1 {
+		t.Errorf("info records written after a second = %d, want 1", got)
+	}
+}
+
+// Tests changing the default logger do not run in parallel.
+
+func TestDefault(t *testing.T) {
+	t.Cleanup(func() { defaultLogger.Store(nil) })
+
+	if got := FromContext(t.Context()); got != discardLogger {
+		t.Errorf("FromContext() before configuring = %v, want the discarding logger", got)
+	}
+
+	var buf bytes.Buffer
+	logger := Default(WithOutput(&buf))
+	if Default() != logger {
+		t.Error("Default() = another logger, want the configured one")
+	}
+	FromContext(context.Background()).Info("configured")
+	if !strings.Contains(buf.String(), `"msg":"configured"`) {
+		t.Errorf("output = %q, want the record of FromContext", buf.String())
+	}
+
+	own := slog.New(slog.DiscardHandler)
+	if got := FromContext(NewContext(t.Context(), own)); got != own {
+		t.Error("FromContext() = the default logger, want the logger of the context")
+	}
+}
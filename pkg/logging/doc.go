@@ -37,9 +37,17 @@
 //
 // # Default Behavior
 //
-// When no logger is found in the context, FromContext returns a default JSON logger
-// that writes to stdout with INFO level logging. This ensures logging always works
-// even when no explicit logger is configured.
+// When no logger is found in the context, FromContext returns the default logger of the package,
+// which discards the records until an entrypoint configures it with Default:
+//
+//	// Human-readable debug logs in development.
+//	logging.Default(logging.WithFormat(logging.FormatText), logging.WithLevel(slog.LevelDebug))
+//
+//	// JSON logs in production, dropping 90% of the records below WARN past 100 a second.
+//	logging.Default(logging.WithOutput(os.Stderr), logging.WithSampling(0.9, 100))
+//
+// New builds the same loggers without changing the default. The options default to JSON records
+// of INFO level and above written to stdout.
 //
 // # Structured Logging
 //
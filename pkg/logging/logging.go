@@ -18,11 +18,12 @@ func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
 
 // FromContext returns a [slog.Logger] from ctx.
 //
-// If no [*slog.Logger] is found, this returns a logger with [slog.DiscardHandler].
+// If no [*slog.Logger] is found, this returns the default logger configured with [Default], which
+// discards the records until configured.
 func FromContext(ctx context.Context) *slog.Logger {
 	if v := ctx.Value(contextKey{}); v != nil {
 		return v.(*slog.Logger)
 	}
 
-	return slog.New(slog.DiscardHandler)
+	return Default()
 }
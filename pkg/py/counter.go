@@ -0,0 +1,161 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import (
+	"iter"
+	"slices"
+)
+
+// Pair is a value with its count in a [Counter].
+type Pair[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Counter counts the occurrences of values, like Python's collections.Counter.
+//
+// The values are kept in the order they were first counted, which breaks the ties of MostCommon
+// as in Python. The zero value is an empty counter ready to use.
+type Counter[T comparable] struct {
+	counts map[T]int
+	order  []T
+}
+
+// NewCounter returns a Counter counting the items.
+func NewCounter[T comparable](items ...T) *Counter[T] {
+	c := &Counter[T]{}
+	for _, item := range items {
+		c.Add(item, 1)
+	}
+	return c
+}
+
+// Add adds n to the count of v, which may become zero or negative.
+func (c *Counter[T]) Add(v T, n int) *Counter[T] {
+	if c.counts == nil {
+		c.counts = make(map[T]int)
+	}
+	if _, ok := c.counts[v]; !ok {
+		c.order = append(c.order, v)
+	}
+	c.counts[v] += n
+	return c
+}
+
+// Get returns the count of v, zero if it was never counted.
+func (c *Counter[T]) Get(v T) int {
+	return c.counts[v]
+}
+
+// Has reports whether v was counted, even if its count is zero.
+func (c *Counter[T]) Has(v T) bool {
+	_, ok := c.counts[v]
+	return ok
+}
+
+// Delete removes v from the counter.
+func (c *Counter[T]) Delete(v T) *Counter[T] {
+	if _, ok := c.counts[v]; !ok {
+		return c
+	}
+	delete(c.counts, v)
+	c.order = slices.DeleteFunc(c.order, func(e T) bool { return e == v })
+	return c
+}
+
+// Len returns the number of distinct values counted.
+func (c *Counter[T]) Len() int {
+	return len(c.counts)
+}
+
+// Total returns the sum of the counts.
+func (c *Counter[T]) Total() int {
+	total := 0
+	for _, n := range c.counts {
+		total += n
+	}
+	return total
+}
+
+// All returns an iterator over the values and their counts, in the order they were first counted.
+func (c *Counter[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for _, v := range c.order {
+			if !yield(v, c.counts[v]) {
+				return
+			}
+		}
+	}
+}
+
+// MostCommon returns the k values with the highest counts, from the most common to the least.
+// The values with equal counts are ordered by first occurrence. A k of zero or less returns all
+// the values.
+func (c *Counter[T]) MostCommon(k int) []Pair[T] {
+	pairs := make([]Pair[T], 0, len(c.order))
+	for v, n := range c.All() {
+		pairs = append(pairs, Pair[T]{Value: v, Count: n})
+	}
+	slices.SortStableFunc(pairs, func(a, b Pair[T]) int {
+		return b.Count - a.Count
+	})
+	if k > 0 && k < len(pairs) {
+		pairs = pairs[:k]
+	}
+	return pairs
+}
+
+// Clone returns a copy of the counter.
+func (c *Counter[T]) Clone() *Counter[T] {
+	clone := &Counter[T]{}
+	for v, n := range c.All() {
+		clone.Add(v, n)
+	}
+	return clone
+}
+
+// Merge returns a new counter adding the counts of c and other, like c + other in Python.
+// Only the positive counts are kept.
+func (c *Counter[T]) Merge(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int { return a + b })
+}
+
+// Subtract returns a new counter subtracting the counts of other from those of c, like
+// c - other in Python. Only the positive counts are kept.
+func (c *Counter[T]) Subtract(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int { return a - b })
+}
+
+// Union returns a new counter with the maximum of the counts of c and other, like c | other in
+// Python. Only the positive counts are kept.
+func (c *Counter[T]) Union(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int { return max(a, b) })
+}
+
+// Intersection returns a new counter with the minimum of the counts of c and other, like
+// c & other in Python. Only the positive counts are kept.
+func (c *Counter[T]) Intersection(other *Counter[T]) *Counter[T] {
+	return c.combine(other, func(a, b int) int { return min(a, b) })
+}
+
+// combine returns a new counter with the positive results of op applied to the counts of the
+// values of c and other, c's values first.
+func (c *Counter[T]) combine(other *Counter[T], op func(a, b int) int) *Counter[T] {
+	result := &Counter[T]{}
+	for v := range c.All() {
+		if n := op(c.Get(v), other.Get(v)); n > 0 {
+			result.Add(v, n)
+		}
+	}
+	for v := range other.All() {
+		if c.Has(v) {
+			continue
+		}
+		if n := op(0, other.Get(v)); n > 0 {
+			result.Add(v, n)
+		}
+	}
+	return result
+}
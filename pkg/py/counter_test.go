@@ -0,0 +1,120 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestCounter(t *testing.T) {
+	t.Parallel()
+
+	c := py.NewCounter("b", "a", "c", "a", "b", "a")
+	c.Add("d", 2).Add("c", -1)
+
+	if got := c.Get("a"); got != 3 {
+		t.Errorf("Get(a) = %d, want 3", got)
+	}
+	if got := c.Get("missing"); got != 0 {
+		t.Errorf("Get(missing) = %d, want 0", got)
+	}
+	if got := c.Total(); got != 7 {
+		t.Errorf("Total() = %d, want 7", got)
+	}
+
+	want := []py.Pair[string]{{"a", 3}, {"b", 2}, {"d", 2}, {"c", 0}}
+	if diff := cmp.Diff(want, c.MostCommon(0)); diff != "" {
+		t.Errorf("MostCommon(0) mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want[:2], c.MostCommon(2)); diff != "" {
+		t.Errorf("MostCommon(2) mismatch (-want +got):\n%s", diff)
+	}
+
+	c.Delete("c")
+	if c.Has("c") || c.Len() != 3 {
+		t.Errorf("after Delete(c): Has(c) = %t, Len() = %d, want false, 3", c.Has("c"), c.Len())
+	}
+
+	var zero py.Counter[int]
+	zero.Add(1, 1)
+	if zero.Get(1) != 1 {
+		t.Errorf("zero Counter Get(1) = %d, want 1", zero.Get(1))
+	}
+}
+
+func TestCounterArithmetic(t *testing.T) {
+	t.Parallel()
+
+	a := py.NewCounter("x", "x", "x", "y")
+	b := py.NewCounter("x", "y", "y", "z")
+
+	tests := map[string]struct {
+		got  *py.Counter[string]
+		want []py.Pair[string]
+	}{
+		"merge": {
+			got:  a.Merge(b),
+			want: []py.Pair[string]{{"x", 4}, {"y", 3}, {"z", 1}},
+		},
+		"subtract": {
+			got:  a.Subtract(b),
+			want: []py.Pair[string]{{"x", 2}},
+		},
+		"union": {
+			got:  a.Union(b),
+			want: []py.Pair[string]{{"x", 3}, {"y", 2}, {"z", 1}},
+		},
+		"intersection": {
+			got:  a.Intersection(b),
+			want: []py.Pair[string]{{"x", 1}, {"y", 1}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, tt.got.MostCommon(0)); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if a.Get("x") != 3 || b.Get("z") != 1 {
+		t.Error("arithmetic modified its operands")
+	}
+}
+
+func TestDefaultDict(t *testing.T) {
+	t.Parallel()
+
+	groups := py.NewDefaultDict[string](func() *[]int { return new([]int) })
+	for i, key := range []string{"odd", "even", "odd", "even", "odd"} {
+		*groups.Get(key) = append(*groups.Get(key), i)
+	}
+
+	if diff := cmp.Diff([]int{0, 2, 4}, *groups.Get("odd")); diff != "" {
+		t.Errorf("Get(odd) mismatch (-want +got):\n%s", diff)
+	}
+	if _, ok := groups.Lookup("none"); ok {
+		t.Error("Lookup(none) found a value")
+	}
+	if got := groups.Len(); got != 2 {
+		t.Errorf("Len() = %d, want 2", got)
+	}
+	groups.Get("none")
+	if got := groups.Len(); got != 3 {
+		t.Errorf("Len() after Get(none) = %d, want 3", got)
+	}
+
+	counts := py.NewDefaultDict[string](func() int { return 0 })
+	counts.Set("a", counts.Get("a")+1)
+	counts.Set("a", counts.Get("a")+1)
+	if got := counts.Get("a"); got != 2 {
+		t.Errorf("Get(a) = %d, want 2", got)
+	}
+}
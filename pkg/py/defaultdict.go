@@ -0,0 +1,66 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import "iter"
+
+// DefaultDict is a map creating the missing entries with a factory on access, like Python's
+// collections.defaultdict.
+type DefaultDict[K comparable, V any] struct {
+	m       map[K]V
+	factory func() V
+}
+
+// NewDefaultDict returns an empty DefaultDict creating the missing values with factory.
+func NewDefaultDict[K comparable, V any](factory func() V) *DefaultDict[K, V] {
+	return &DefaultDict[K, V]{
+		m:       make(map[K]V),
+		factory: factory,
+	}
+}
+
+// Get returns the value of the key, setting it to a new value made by the factory if missing.
+//
+// As in Go maps, modifying a returned value that is not a reference, such as a slice appended
+// to, requires setting it back with Set.
+func (d *DefaultDict[K, V]) Get(key K) V {
+	v, ok := d.m[key]
+	if !ok {
+		v = d.factory()
+		d.m[key] = v
+	}
+	return v
+}
+
+// Lookup returns the value of the key and whether it is set, without creating it.
+func (d *DefaultDict[K, V]) Lookup(key K) (V, bool) {
+	v, ok := d.m[key]
+	return v, ok
+}
+
+// Set sets the value of the key.
+func (d *DefaultDict[K, V]) Set(key K, value V) {
+	d.m[key] = value
+}
+
+// Delete removes the key.
+func (d *DefaultDict[K, V]) Delete(key K) {
+	delete(d.m, key)
+}
+
+// Len returns the number of entries.
+func (d *DefaultDict[K, V]) Len() int {
+	return len(d.m)
+}
+
+// All returns an iterator over the entries, in no particular order.
+func (d *DefaultDict[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range d.m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
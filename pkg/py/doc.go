@@ -11,6 +11,10 @@
 //
 // The package currently implements:
 //   - Set[T]: Python-style sets with comprehensive set operations
+//   - FrozenSet[T]: immutable sets (frozenset)
+//   - OrderedSet[T]: sets remembering the insertion order, as the keys of a dict
+//   - Counter[T]: Python-style counters (collections.Counter)
+//   - DefaultDict[K, V]: maps creating missing entries (collections.defaultdict)
 //   - Additional Python patterns via subpackages (pyasyncio)
 //
 // # Set Implementation
@@ -67,6 +71,17 @@
 //	difference := set1.Difference(set2)      // {1, 2}
 //	symmetric := set1.SymmetricDifference(set2) // {1, 2, 5, 6}
 //
+// ## Deriving Sets
+//
+// Map, Filter and Reduce derive a set, or a value, from the items of a set:
+//
+//	lower := py.Map(names, strings.ToLower)                // collisions collapse into one item
+//	even := py.Filter(numbers, func(n int) bool { return n%2 == 0 })
+//	sum := py.Reduce(numbers, 0, func(acc, n int) int { return acc + n })
+//
+// They visit the items in random order, so Reduce is only deterministic with a function that does
+// not depend on the order.
+//
 // ## Set Relationships
 //
 // Test relationships between sets:
@@ -95,6 +110,26 @@
 //	// Unsorted slice (random order)
 //	unsorted := numbers.UnsortedList() // []int{3, 1, 4, 5, 9, 2, 6} (order varies)
 //
+// ## Text Form
+//
+// Sets of strings round-trip through comma-separated or line-delimited text, such as the
+// allowlists of the config files and the CLI flags:
+//
+//	tools := py.ParseSet("search, fetch,search", ",") // Set[string]{"fetch", "search"}
+//	text := py.Join(tools, ",")                        // "fetch,search"
+//
+//	// Repeated or comma-separated -allow flags
+//	allowed := py.NewSet[string]()
+//	flag.Var(py.SetFlag(allowed, ","), "allow", "allowed tools")
+//
+// ## JSON Form
+//
+// A Set encodes to a JSON array of its items, in random order, and decodes from an array,
+// dropping the duplicates. MarshalSortedSet encodes the items of an ordered type sorted, so that
+// equal sets encode identically:
+//
+//	data, _ := py.MarshalSortedSet(py.NewSet("b", "a")) // ["a","b"]
+//
 // ## Pop Operations
 //
 // Remove and return arbitrary elements:
@@ -105,6 +140,32 @@
 //		fmt.Printf("Remaining size: %d\n", numbers.Len())
 //	}
 //
+// ## Frozen Sets
+//
+// FrozenSet is the immutable variant of Set, as Python's frozenset. It has no Insert, Delete,
+// Clear or PopAny, and its set math operations return a new FrozenSet:
+//
+//	allowed := py.NewFrozenSet("search", "fetch")
+//	frozen := py.NewSet("list").Freeze() // immutable copy of a Set
+//	all := allowed.Union(frozen)         // FrozenSet[string]{"fetch", "list", "search"}
+//	editable := all.Thaw()               // mutable copy of a FrozenSet
+//
+// Unlike Python's frozenset, a FrozenSet is not comparable, so it cannot be a map key itself.
+// Its Key method returns a canonical string, equal for equal sets, to key a map by the set:
+//
+//	byTools := map[string]string{allowed.Key(): "reader"}
+//
+// ## Ordered Sets
+//
+// OrderedSet remembers the order its items were first inserted, so that the output built from
+// it is reproducible without sorting:
+//
+//	seen := py.NewOrderedSet("b", "a")
+//	seen.Insert("b", "c")
+//	for id := range seen.All() {
+//		fmt.Println(id) // b, a, c
+//	}
+//
 // # Memory Efficiency
 //
 // The set implementation is optimized for memory efficiency:
@@ -123,7 +184,8 @@
 //
 // # Thread Safety
 //
-// Sets are NOT thread-safe by default. For concurrent access, use external synchronization:
+// Sets are NOT thread-safe by default. A FrozenSet can be shared across goroutines as is, since
+// it is never modified. For concurrent access to a Set, use external synchronization:
 //
 //	var mu sync.RWMutex
 //	var sharedSet = py.NewSet[string]()
@@ -182,6 +244,9 @@
 //		handleEvent(event)
 //	}
 //
+// agent.DedupeEvents wraps an event stream the same way, remembering the IDs in an LRU cache so
+// that an endless stream stays bounded.
+//
 // ## Tool Validation
 //
 //	// Required parameters for tool execution
@@ -189,9 +254,14 @@
 //	providedParams := py.KeySet(toolArgs)
 //
 //	// Check if all required parameters are provided
-//	if !providedParams.IsSuperset(requiredParams) {
-//		missing := requiredParams.Difference(providedParams)
-//		return fmt.Errorf("missing required parameters: %v", missing.UnsortedList())
+//	if missing := py.MissingFrom(requiredParams, providedParams); len(missing) > 0 {
+//		return fmt.Errorf("missing required parameters: %v", missing) // sorted, stable
+//	}
+//
+//	// Reject unknown parameters
+//	allowedParams := requiredParams.Union(py.NewSet("top_p", "max_tokens"))
+//	if extra := py.Extra(allowedParams, providedParams); len(extra) > 0 {
+//		return fmt.Errorf("unknown parameters: %v", extra)
 //	}
 //
 // ## Agent Coordination
@@ -296,6 +366,23 @@
 //		}
 //	}
 //
+// # Counter and DefaultDict
+//
+// Counter counts values and returns the most common ones, breaking ties by first occurrence
+// as Python does:
+//
+//	words := py.NewCounter("a", "b", "a")
+//	words.Add("c", 2)
+//	top := words.MostCommon(1) // [{a 2}]
+//
+// Merge, Subtract, Union and Intersection mirror the +, -, | and & operators of Python's
+// Counter: they return a new counter keeping only the positive counts.
+//
+// DefaultDict creates the missing entries with its factory on Get:
+//
+//	groups := py.NewDefaultDict[string](func() *[]string { return new([]string) })
+//	*groups.Get("fruits") = append(*groups.Get("fruits"), "apple")
+//
 // # Attribution
 //
 // The Set implementation is adapted from Kubernetes' utility library
@@ -309,7 +396,7 @@
 // The package is designed for extensibility with additional Python patterns:
 //   - dict-like structures with Python semantics
 //   - list/tuple equivalents with Python behavior
-//   - Additional container types (deque, defaultdict, etc.)
+//   - Additional container types (deque, etc.)
 //   - Python-style iteration patterns
 //
 // The py package provides essential Python compatibility while maintaining Go's
@@ -0,0 +1,107 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FrozenSet is an immutable set, as Python's frozenset.
+//
+// It only has the read operations of [Set]: it cannot be modified once created by [NewFrozenSet]
+// or [Set.Freeze], so that it can be shared across goroutines without locking. The set math
+// operations return a new FrozenSet.
+//
+// The zero value is the empty set. A FrozenSet is not comparable with ==, so it cannot be a map
+// key as Python's frozenset can be a dict key: use [FrozenSet.Equal] to compare the sets and
+// [FrozenSet.Key] to key a map by them.
+type FrozenSet[T comparable] struct {
+	s Set[T]
+}
+
+// NewFrozenSet creates a FrozenSet from a list of values.
+// NOTE: type param must be explicitly instantiated if given items are empty.
+func NewFrozenSet[T comparable](items ...T) FrozenSet[T] {
+	return FrozenSet[T]{s: NewSet(items...)}
+}
+
+// Freeze returns the immutable copy of the set.
+func (s Set[T]) Freeze() FrozenSet[T] {
+	return FrozenSet[T]{s: s.Clone()}
+}
+
+// Thaw returns the mutable copy of the set.
+func (s FrozenSet[T]) Thaw() Set[T] {
+	return s.s.Clone()
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s FrozenSet[T]) Has(item T) bool {
+	return s.s.Has(item)
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (s FrozenSet[T]) HasAll(items ...T) bool {
+	return s.s.HasAll(items...)
+}
+
+// HasAny returns true if any items are contained in the set.
+func (s FrozenSet[T]) HasAny(items ...T) bool {
+	return s.s.HasAny(items...)
+}
+
+// Len returns the size of the set.
+func (s FrozenSet[T]) Len() int {
+	return s.s.Len()
+}
+
+// Union returns a new set which includes items in either s1 or s2.
+func (s1 FrozenSet[T]) Union(s2 FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: s1.s.Union(s2.s)}
+}
+
+// Intersection returns a new set which includes the item in BOTH s1 and s2.
+func (s1 FrozenSet[T]) Intersection(s2 FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: s1.s.Intersection(s2.s)}
+}
+
+// Difference returns a set of objects that are not in s2.
+func (s1 FrozenSet[T]) Difference(s2 FrozenSet[T]) FrozenSet[T] {
+	return FrozenSet[T]{s: s1.s.Difference(s2.s)}
+}
+
+// IsSuperset returns true if and only if s1 is a superset of s2.
+func (s1 FrozenSet[T]) IsSuperset(s2 FrozenSet[T]) bool {
+	return s1.s.IsSuperset(s2.s)
+}
+
+// Equal returns true if and only if s1 is equal (as a set) to s2.
+func (s1 FrozenSet[T]) Equal(s2 FrozenSet[T]) bool {
+	return s1.s.Equal(s2.s)
+}
+
+// UnsortedList returns the slice with contents in random order.
+func (s FrozenSet[T]) UnsortedList() []T {
+	return s.s.UnsortedList()
+}
+
+// Key returns the canonical string of the set: the Go-syntax representations of its items, sorted
+// and quoted. Equal sets have the same Key, so it can stand for the set as a map key:
+//
+//	counts := map[string]int{}
+//	counts[py.NewFrozenSet("b", "a").Key()]++ // same entry as py.NewFrozenSet("a", "b")
+//
+// Distinct items must have distinct Go-syntax representations (%#v), as the values of the basic
+// types and of the structs made of them have; pointers are represented by their address.
+func (s FrozenSet[T]) Key() string {
+	items := make([]string, 0, s.Len())
+	for item := range s.s {
+		items = append(items, strconv.Quote(fmt.Sprintf("%#v", item)))
+	}
+	slices.Sort(items)
+	return "{" + strings.Join(items, ",") + "}"
+}
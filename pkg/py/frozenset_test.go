@@ -0,0 +1,88 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestFrozenSet(t *testing.T) {
+	t.Parallel()
+
+	s := py.NewSet("a", "b")
+	frozen := s.Freeze()
+	s.Insert("c")
+	if frozen.Has("c") || frozen.Len() != 2 {
+		t.Errorf("frozen set changed with the set it was frozen from: %v", frozen.UnsortedList())
+	}
+	if !frozen.HasAll("a", "b") || !frozen.HasAny("x", "a") || frozen.HasAny("x") {
+		t.Errorf("frozen set membership mismatch: %v", frozen.UnsortedList())
+	}
+
+	other := py.NewFrozenSet("b", "d")
+	tests := map[string]struct {
+		got  py.FrozenSet[string]
+		want []string
+	}{
+		"union":        {got: frozen.Union(other), want: []string{"a", "b", "d"}},
+		"intersection": {got: frozen.Intersection(other), want: []string{"b"}},
+		"difference":   {got: frozen.Difference(other), want: []string{"a"}},
+		"empty":        {got: py.FrozenSet[string]{}.Union(py.NewFrozenSet[string]()), want: []string{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, py.List(tt.got.Thaw())); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	thawed := frozen.Thaw()
+	thawed.Insert("e")
+	if frozen.Has("e") {
+		t.Error("frozen set changed with its thawed copy")
+	}
+	if !frozen.Equal(py.NewFrozenSet("b", "a")) || !frozen.IsSuperset(py.NewFrozenSet("a")) {
+		t.Errorf("frozen set relationships mismatch: %v", frozen.UnsortedList())
+	}
+}
+
+func TestFrozenSet_Key(t *testing.T) {
+	t.Parallel()
+
+	groups := map[string]int{}
+	for _, s := range []py.FrozenSet[string]{
+		py.NewFrozenSet("a", "b"),
+		py.NewFrozenSet("b", "a"),
+		py.NewSet("a", "b", "a").Freeze(),
+		py.NewFrozenSet("a,b"),
+		py.NewFrozenSet("a", "b", "c"),
+		{},
+		py.NewFrozenSet[string](),
+	} {
+		groups[s.Key()]++
+	}
+	want := map[string]int{
+		`{"\"a\"","\"b\""}`:         3,
+		`{"\"a,b\""}`:               1,
+		`{"\"a\"","\"b\"","\"c\""}`: 1,
+		`{}`:                        2,
+	}
+	if diff := cmp.Diff(want, groups); diff != "" {
+		t.Errorf("Key groups mismatch (-want +got):\n%s", diff)
+	}
+
+	if got, want := py.NewFrozenSet(1, 10).Key(), py.NewFrozenSet(10, 1).Key(); got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+	if py.NewFrozenSet(1).Key() == py.NewFrozenSet(11).Key() {
+		t.Error("Key() of distinct sets collide")
+	}
+}
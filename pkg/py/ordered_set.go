@@ -0,0 +1,118 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import (
+	"iter"
+	"slices"
+)
+
+// OrderedSet is a set remembering the insertion order of its items, as the keys of a Python dict.
+//
+// It has the API of [Set], but [OrderedSet.UnsortedList] and [OrderedSet.All] return the items in
+// the order they were first inserted, so that the output built from the set is reproducible
+// without sorting. Inserting an item already in the set keeps its position.
+//
+// The zero value is an empty set ready to use. An OrderedSet is not safe for concurrent use.
+type OrderedSet[T comparable] struct {
+	index map[T]int
+	items []T
+}
+
+// NewOrderedSet creates an OrderedSet from a list of values, in their order.
+// NOTE: type param must be explicitly instantiated if given items are empty.
+func NewOrderedSet[T comparable](items ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{
+		index: make(map[T]int, len(items)),
+		items: make([]T, 0, len(items)),
+	}
+	return s.Insert(items...)
+}
+
+// Insert adds the items not in the set yet to its end.
+func (s *OrderedSet[T]) Insert(items ...T) *OrderedSet[T] {
+	if s.index == nil {
+		s.index = make(map[T]int, len(items))
+	}
+	for _, item := range items {
+		if _, ok := s.index[item]; ok {
+			continue
+		}
+		s.index[item] = len(s.items)
+		s.items = append(s.items, item)
+	}
+	return s
+}
+
+// Delete removes all items from the set, keeping the order of the other items.
+//
+// It takes time proportional to the size of the set for each item removed.
+func (s *OrderedSet[T]) Delete(items ...T) *OrderedSet[T] {
+	for _, item := range items {
+		i, ok := s.index[item]
+		if !ok {
+			continue
+		}
+		delete(s.index, item)
+		s.items = slices.Delete(s.items, i, i+1)
+		for j := i; j < len(s.items); j++ {
+			s.index[s.items[j]] = j
+		}
+	}
+	return s
+}
+
+// Clear empties the set.
+func (s *OrderedSet[T]) Clear() *OrderedSet[T] {
+	clear(s.index)
+	clear(s.items)
+	s.items = s.items[:0]
+	return s
+}
+
+// Has returns true if and only if item is contained in the set.
+func (s *OrderedSet[T]) Has(item T) bool {
+	_, contained := s.index[item]
+	return contained
+}
+
+// HasAll returns true if and only if all items are contained in the set.
+func (s *OrderedSet[T]) HasAll(items ...T) bool {
+	for _, item := range items {
+		if !s.Has(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if any items are contained in the set.
+func (s *OrderedSet[T]) HasAny(items ...T) bool {
+	return slices.ContainsFunc(items, s.Has)
+}
+
+// Len returns the size of the set.
+func (s *OrderedSet[T]) Len() int {
+	return len(s.items)
+}
+
+// UnsortedList returns the items of the set in insertion order.
+//
+// The name matches [Set.UnsortedList]: the items are not sorted, but unlike a [Set] their order is
+// deterministic.
+func (s *OrderedSet[T]) UnsortedList() []T {
+	return slices.Clone(s.items)
+}
+
+// All returns an iterator over the items of the set in insertion order.
+//
+// The set must not be modified during the iteration.
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return slices.Values(s.items)
+}
+
+// Set returns the items of the set as a [Set], dropping their order.
+func (s *OrderedSet[T]) Set() Set[T] {
+	return NewSetFromSlice(s.items)
+}
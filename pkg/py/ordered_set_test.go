@@ -0,0 +1,51 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestOrderedSet(t *testing.T) {
+	t.Parallel()
+
+	s := py.NewOrderedSet("c", "a", "c")
+	s.Insert("b", "a", "d")
+	if diff := cmp.Diff([]string{"c", "a", "b", "d"}, s.UnsortedList()); diff != "" {
+		t.Errorf("UnsortedList() mismatch (-want +got):\n%s", diff)
+	}
+
+	s.Delete("a", "x")
+	if diff := cmp.Diff([]string{"c", "b", "d"}, slices.Collect(s.All())); diff != "" {
+		t.Errorf("All() after Delete() mismatch (-want +got):\n%s", diff)
+	}
+	if s.Has("a") || !s.HasAll("b", "d") || !s.HasAny("x", "c") || s.Len() != 3 {
+		t.Errorf("membership mismatch: %v", s.UnsortedList())
+	}
+
+	// The positions of the items after the deleted one are kept up to date.
+	s.Delete("b").Insert("a")
+	if diff := cmp.Diff([]string{"c", "d", "a"}, s.UnsortedList()); diff != "" {
+		t.Errorf("UnsortedList() mismatch (-want +got):\n%s", diff)
+	}
+	s.Delete("d")
+	if diff := cmp.Diff(py.NewSet("c", "a"), s.Set()); diff != "" {
+		t.Errorf("Set() mismatch (-want +got):\n%s", diff)
+	}
+
+	if s.Clear().Len() != 0 || s.Has("c") {
+		t.Errorf("Clear() left %v", s.UnsortedList())
+	}
+
+	var zero py.OrderedSet[int]
+	zero.Insert(2, 1)
+	if diff := cmp.Diff([]int{2, 1}, zero.UnsortedList()); diff != "" {
+		t.Errorf("zero value UnsortedList() mismatch (-want +got):\n%s", diff)
+	}
+}
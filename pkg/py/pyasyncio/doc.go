@@ -12,8 +12,10 @@
 // The package implements core asyncio concepts:
 //   - Task[T]: Asynchronous task execution with lifecycle management
 //   - Queue[T]: Producer-consumer queues with blocking operations
+//   - RetryQueue[T]: Queue retrying failed items with backoff and routing them to a dead-letter queue
 //   - TaskGroup: Coordinated task execution (via task_group.go)
 //   - WaitFor: Timeout and cancellation utilities (via wait_for.go)
+//   - Race: First successful (or first finished) of several tasks, cancelling the others
 //
 // # Task Implementation
 //
@@ -103,6 +105,8 @@
 //		GetNowait() (T, error)                      // Non-blocking get
 //		TaskDone() error                            // Mark task complete
 //		Join(ctx context.Context) error             // Wait for all tasks
+//		JoinWithTimeout(ctx context.Context, timeout time.Duration) error // Wait with a deadline
+//		Pending() int                               // Number of unfinished tasks
 //	}
 //
 // # Basic Queue Usage
@@ -184,6 +188,26 @@
 //	fmt.Printf("Queue empty: %t\n", queue.Empty())
 //	fmt.Printf("Queue full: %t\n", queue.Full())
 //
+// ## Acknowledged Delivery
+//
+// An item returned by Get is gone from the queue, so a consumer crashing before TaskDone loses it.
+// GetWithAck keeps the item owned by the queue until it is acknowledged, and re-queues it for
+// another consumer if it is not acknowledged before the visibility timeout:
+//
+//	queue.SetVisibilityTimeout(time.Minute)
+//	item, token, err := queue.GetWithAck(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	if err := process(item); err != nil {
+//		queue.Nack(token) // retry now
+//		return err
+//	}
+//	queue.Ack(token) // instead of TaskDone
+//
+// Items are then processed at least once: an Ack returning an *ErrAckTokenExpired means the
+// item was re-queued and may be processed again.
+//
 // # Advanced Task Patterns
 //
 // ## Parallel Task Execution
@@ -239,6 +263,16 @@
 //		}
 //	}
 //
+// ## Racing Tasks
+//
+// Send the same request to several providers and keep the first successful answer; the
+// losing tasks are cancelled through their contexts:
+//
+//	answer, err := pyasyncio.Race(ctx,
+//		pyasyncio.CreateTask(ctx, askPrimary),
+//		pyasyncio.CreateTask(ctx, askSecondary),
+//	)
+//
 // ## Graceful Shutdown Pattern
 //
 // Coordinate clean shutdown of multiple tasks:
@@ -300,6 +334,16 @@
 //		return queue, nil
 //	}
 //
+// A worker that exits without calling TaskDone() makes Join() block forever. Use JoinWithTimeout()
+// to detect stuck consumers:
+//
+//	if err := queue.JoinWithTimeout(ctx, time.Minute); err != nil {
+//		var joinErr *pyasyncio.JoinTimeoutError
+//		if errors.As(err, &joinErr) {
+//			log.Printf("%d tasks still unfinished", joinErr.Pending)
+//		}
+//	}
+//
 // ## Pipeline Pattern
 //
 // Chain multiple processing stages:
@@ -5,8 +5,10 @@ package pyasyncio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // ErrQueueEmpty is raised when a non-blocking get operation is performed on an empty queue.
@@ -33,6 +35,27 @@ func (e *ErrQueueFull) Error() string {
 	return "queue is full"
 }
 
+// JoinTimeoutError is returned by [Queue.JoinWithTimeout] when the tasks are not all done before the deadline.
+//
+// It unwraps to a [*TimeoutError].
+type JoinTimeoutError struct {
+	// Timeout is the duration that was exceeded.
+	Timeout time.Duration
+
+	// Pending is the number of unfinished tasks when the deadline hit.
+	Pending int
+}
+
+// Error implements the error interface for JoinTimeoutError.
+func (e *JoinTimeoutError) Error() string {
+	return fmt.Sprintf("queue join timed out after %s with %d unfinished tasks", e.Timeout, e.Pending)
+}
+
+// Unwrap returns the [*TimeoutError] of the join.
+func (e *JoinTimeoutError) Unwrap() error {
+	return NewTimeoutError(e.Timeout)
+}
+
 // Queue defines the interface for asyncio-style queue operations.
 //
 // This interface matches Python's [asyncio.Queue] API.
@@ -65,6 +88,12 @@ type Queue[T any] interface {
 
 	// Join waits until all tasks are done.
 	Join(ctx context.Context) error
+
+	// JoinWithTimeout waits until all tasks are done or the timeout elapses.
+	JoinWithTimeout(ctx context.Context, timeout time.Duration) error
+
+	// Pending returns the number of tasks not yet marked as done.
+	Pending() int
 }
 
 // queue represents a Python [asyncio.queue] in Go.
@@ -99,6 +128,15 @@ type queue[T any] struct {
 
 	// closed indicates if the queue has been closed
 	closed bool
+
+	// visibilityTimeout is the time after which an item gotten with GetWithAck is re-queued
+	visibilityTimeout time.Duration
+
+	// inFlight stores the items gotten with GetWithAck and not yet acknowledged, by ack token
+	inFlight map[uint64]*inFlightItem[T]
+
+	// nextAckID is the ID of the last ack token
+	nextAckID uint64
 }
 
 var _ Queue[struct{}] = (*queue[struct{}])(nil)
@@ -113,8 +151,10 @@ var _ Queue[struct{}] = (*queue[struct{}])(nil)
 // [asyncio.Queue]: https://docs.python.org/3/library/asyncio-queue.html#asyncio.Queue
 func NewQueue[T any](maxsize int) *queue[T] {
 	q := &queue[T]{
-		maxsize: maxsize,
-		items:   make([]T, 0),
+		maxsize:           maxsize,
+		items:             make([]T, 0),
+		visibilityTimeout: DefaultVisibilityTimeout,
+		inFlight:          make(map[uint64]*inFlightItem[T]),
 	}
 
 	q.notEmpty = sync.NewCond(&q.mu)
@@ -426,6 +466,41 @@ func (q *queue[T]) Join(ctx context.Context) error {
 	}
 }
 
+// JoinWithTimeout is like Join() but gives up after the timeout.
+//
+// If tasks are still unfinished when the timeout elapses, for example because a consumer
+// exited without calling TaskDone(), it returns a [*JoinTimeoutError] reporting how many.
+// If ctx is done first, ctx.Err() is returned. A timeout of zero or less waits like Join().
+func (q *queue[T]) JoinWithTimeout(ctx context.Context, timeout time.Duration) error {
+	if timeout <= 0 {
+		return q.Join(ctx)
+	}
+
+	joinCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := q.Join(joinCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+		return &JoinTimeoutError{
+			Timeout: timeout,
+			Pending: q.Pending(),
+		}
+	}
+
+	return err
+}
+
+// Pending returns the number of tasks not yet marked as done.
+//
+// The count goes up on each Put() and down on each TaskDone(), so it includes the items
+// still in the queue and the items being processed by consumers.
+func (q *queue[T]) Pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.unfinished
+}
+
 // Close closes the queue, preventing further operations.
 // Any blocked Put/Get operations will be unblocked and return an error.
 func (q *queue[T]) Close() {
@@ -433,6 +508,9 @@ func (q *queue[T]) Close() {
 	defer q.mu.Unlock()
 
 	q.closed = true
+	for _, inFlight := range q.inFlight {
+		inFlight.timer.Stop()
+	}
 	q.notEmpty.Broadcast()
 	q.notFull.Broadcast()
 	q.allTasksDone.Broadcast()
@@ -0,0 +1,154 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultVisibilityTimeout is the time an item gotten with [AckQueue.GetWithAck] stays
+// invisible to the other consumers when no visibility timeout is set.
+const DefaultVisibilityTimeout = 30 * time.Second
+
+// ErrAckTokenExpired is returned when acknowledging an item whose ack token is unknown, already
+// acknowledged, or whose visibility timeout elapsed, so that the item was re-queued.
+type ErrAckTokenExpired struct{}
+
+// Error implements the error interface for ErrAckTokenExpired.
+func (e *ErrAckTokenExpired) Error() string {
+	return "ack token expired"
+}
+
+// AckToken identifies an item delivered by [AckQueue.GetWithAck] until it is acknowledged.
+type AckToken struct {
+	id uint64
+}
+
+// AckQueue is a [Queue] delivering items with at-least-once semantics.
+//
+// An item gotten with GetWithAck stays owned by the queue until it is acknowledged. If the
+// consumer does not call Ack before the visibility timeout, for example because it crashed,
+// the item is re-queued for another consumer.
+type AckQueue[T any] interface {
+	Queue[T]
+
+	// GetWithAck removes and returns an item from the queue with its ack token, blocking if empty.
+	GetWithAck(ctx context.Context) (T, AckToken, error)
+
+	// Ack marks the item of the token as done, like TaskDone.
+	Ack(token AckToken) error
+
+	// Nack re-queues the item of the token immediately.
+	Nack(token AckToken) error
+
+	// SetVisibilityTimeout sets the time after which an item not acknowledged is re-queued.
+	SetVisibilityTimeout(timeout time.Duration)
+}
+
+var _ AckQueue[struct{}] = (*queue[struct{}])(nil)
+
+// inFlightItem is an item delivered by GetWithAck and not yet acknowledged.
+type inFlightItem[T any] struct {
+	item  T
+	timer *time.Timer
+}
+
+// SetVisibilityTimeout sets the time after which an item gotten with GetWithAck and not
+// acknowledged is re-queued. It applies to the items gotten afterwards.
+//
+// A timeout of zero or less resets it to [DefaultVisibilityTimeout].
+func (q *queue[T]) SetVisibilityTimeout(timeout time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if timeout <= 0 {
+		timeout = DefaultVisibilityTimeout
+	}
+	q.visibilityTimeout = timeout
+}
+
+// GetWithAck removes and returns an item from the queue like Get, with the token to acknowledge it.
+//
+// The item must be acknowledged with Ack once processed, or Nack to retry it. If neither is called
+// before the visibility timeout, the item is put back at the end of the queue and the token expires.
+// The item stays counted as unfinished by Join until it is acknowledged, so TaskDone must not be
+// called for it.
+func (q *queue[T]) GetWithAck(ctx context.Context) (T, AckToken, error) {
+	item, err := q.Get(ctx)
+	if err != nil {
+		return item, AckToken{}, err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.nextAckID++
+	token := AckToken{id: q.nextAckID}
+	q.inFlight[token.id] = &inFlightItem[T]{
+		item: item,
+		timer: time.AfterFunc(q.visibilityTimeout, func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+
+			q.requeue(token)
+		}),
+	}
+
+	return item, token, nil
+}
+
+// Ack marks the item of the token as done, like TaskDone.
+//
+// It returns an [*ErrAckTokenExpired] if the item was already acknowledged or re-queued, in which
+// case it may be processed again by another consumer.
+func (q *queue[T]) Ack(token AckToken) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	inFlight, ok := q.inFlight[token.id]
+	if !ok {
+		return &ErrAckTokenExpired{}
+	}
+	inFlight.timer.Stop()
+	delete(q.inFlight, token.id)
+
+	q.unfinished--
+	if q.unfinished == 0 {
+		q.allTasksDone.Broadcast() // Wake up all waiting Join() calls
+	}
+
+	return nil
+}
+
+// Nack puts the item of the token back at the end of the queue immediately, for another attempt.
+//
+// It returns an [*ErrAckTokenExpired] if the item was already acknowledged or re-queued.
+func (q *queue[T]) Nack(token AckToken) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if !q.requeue(token) {
+		return &ErrAckTokenExpired{}
+	}
+	return nil
+}
+
+// requeue puts the in-flight item of the token back into the queue, and reports whether the
+// token was in flight. Must be called with mutex held.
+//
+// The item is still counted as unfinished, and is re-queued even if the queue is full, as its
+// slot was taken when it was first put.
+func (q *queue[T]) requeue(token AckToken) bool {
+	inFlight, ok := q.inFlight[token.id]
+	if !ok {
+		return false
+	}
+	inFlight.timer.Stop()
+	delete(q.inFlight, token.id)
+
+	q.items = append(q.items, inFlight.item)
+	q.notEmpty.Signal() // Wake up any waiting getters
+	return true
+}
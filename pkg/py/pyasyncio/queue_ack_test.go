@@ -0,0 +1,95 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/pkg/py/pyasyncio"
+)
+
+func TestQueueAck(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	q := pyasyncio.NewQueue[string](0)
+	for _, item := range []string{"a", "b"} {
+		if err := q.Put(ctx, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	item, token, err := q.GetWithAck(ctx)
+	if err != nil || item != "a" {
+		t.Fatalf("GetWithAck() = (%q, %v), want (%q, nil)", item, err, "a")
+	}
+	if err := q.Ack(token); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	var expired *pyasyncio.ErrAckTokenExpired
+	if err := q.Ack(token); !errors.As(err, &expired) {
+		t.Errorf("second Ack() error = %v, want %T", err, expired)
+	}
+
+	// A nacked item is re-queued immediately.
+	item, token, err = q.GetWithAck(ctx)
+	if err != nil || item != "b" {
+		t.Fatalf("GetWithAck() = (%q, %v), want (%q, nil)", item, err, "b")
+	}
+	if err := q.Nack(token); err != nil {
+		t.Fatalf("Nack() error = %v", err)
+	}
+	if got := q.Pending(); got != 1 {
+		t.Errorf("Pending() after Nack = %d, want 1", got)
+	}
+	item, token, err = q.GetWithAck(ctx)
+	if err != nil || item != "b" {
+		t.Fatalf("GetWithAck() after Nack = (%q, %v), want (%q, nil)", item, err, "b")
+	}
+	if err := q.Ack(token); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	if err := q.JoinWithTimeout(ctx, time.Second); err != nil {
+		t.Errorf("JoinWithTimeout() error = %v", err)
+	}
+}
+
+func TestQueueAckVisibilityTimeout(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	q := pyasyncio.NewQueue[int](1)
+	q.SetVisibilityTimeout(50 * time.Millisecond)
+	if err := q.Put(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The consumer crashes without acknowledging the item.
+	_, lost, err := q.GetWithAck(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Empty() {
+		t.Fatal("item visible to other consumers before the visibility timeout")
+	}
+
+	// The item is redelivered to another consumer after the visibility timeout.
+	item, token, err := q.GetWithAck(ctx)
+	if err != nil || item != 1 {
+		t.Fatalf("GetWithAck() = (%d, %v), want (1, nil)", item, err)
+	}
+	var expired *pyasyncio.ErrAckTokenExpired
+	if err := q.Ack(lost); !errors.As(err, &expired) {
+		t.Errorf("Ack() of expired token error = %v, want %T", err, expired)
+	}
+	if err := q.Ack(token); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if got := q.Pending(); got != 0 {
+		t.Errorf("Pending() = %d, want 0", got)
+	}
+}
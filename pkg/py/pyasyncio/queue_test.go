@@ -556,6 +556,69 @@ func TestQueueJoinCancellation(t *testing.T) {
 	}
 }
 
+func TestQueueJoinWithTimeout(t *testing.T) {
+	t.Parallel()
+
+	q := pyasyncio.NewQueue[int](0)
+
+	ctx := t.Context()
+	for i := range 3 {
+		if err := q.Put(ctx, i); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if got := q.Pending(); got != 3 {
+		t.Errorf("Pending() = %d, want 3", got)
+	}
+
+	// A consumer processes a single item and exits without finishing the others
+	if _, err := q.Get(ctx); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := q.TaskDone(); err != nil {
+		t.Fatalf("TaskDone failed: %v", err)
+	}
+	if _, err := q.Get(ctx); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	err := q.JoinWithTimeout(ctx, 50*time.Millisecond)
+	var joinErr *pyasyncio.JoinTimeoutError
+	if !errors.As(err, &joinErr) {
+		t.Fatalf("Expected JoinTimeoutError, got %v", err)
+	}
+	if joinErr.Pending != 2 {
+		t.Errorf("JoinTimeoutError.Pending = %d, want 2", joinErr.Pending)
+	}
+	var timeoutErr *pyasyncio.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Errorf("Expected JoinTimeoutError to unwrap to TimeoutError, got %v", err)
+	}
+
+	// Finishing the remaining tasks lets the join complete
+	for range 2 {
+		if err := q.TaskDone(); err != nil {
+			t.Fatalf("TaskDone failed: %v", err)
+		}
+	}
+	if err := q.JoinWithTimeout(ctx, time.Second); err != nil {
+		t.Errorf("JoinWithTimeout failed: %v", err)
+	}
+	if got := q.Pending(); got != 0 {
+		t.Errorf("Pending() = %d, want 0", got)
+	}
+
+	// Cancellation of the parent context is not reported as a timeout
+	if err := q.Put(ctx, 4); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := q.JoinWithTimeout(cctx, time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
 func TestQueueInterface(t *testing.T) {
 	t.Parallel()
 
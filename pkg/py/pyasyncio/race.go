@@ -0,0 +1,93 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Race waits for the first of the tasks to complete successfully, returns its result and
+// cancels the other tasks.
+//
+// This is similar to Python's [asyncio.wait] with return_when=FIRST_COMPLETED, retrying the
+// wait until a task succeeds, which is the building block of hedged requests and fallbacks.
+//
+// If every task fails, the errors of the tasks are returned joined, in the order the tasks
+// were given. If ctx is done first, every task is cancelled and the error of ctx is returned.
+//
+// The losing tasks are cancelled through their contexts; Race does not wait for them to return.
+//
+// Example:
+//
+//	primary := pyasyncio.CreateTask(ctx, queryPrimary)
+//	secondary := pyasyncio.CreateTask(ctx, querySecondary)
+//	result, err := pyasyncio.Race(ctx, primary, secondary)
+//
+// [asyncio.wait]: https://docs.python.org/3/library/asyncio-task.html#asyncio.wait
+func Race[T any](ctx context.Context, tasks ...*Task[T]) (T, error) {
+	return race(ctx, tasks, true)
+}
+
+// RaceAny waits for the first of the tasks to finish, whether it succeeds or fails, returns its
+// result and error and cancels the other tasks.
+//
+// If ctx is done first, every task is cancelled and the error of ctx is returned.
+func RaceAny[T any](ctx context.Context, tasks ...*Task[T]) (T, error) {
+	return race(ctx, tasks, false)
+}
+
+// race waits for the first task to finish, or to succeed if succeeded is true, and cancels the others.
+func race[T any](ctx context.Context, tasks []*Task[T], succeeded bool) (T, error) {
+	var zero T
+	if len(tasks) == 0 {
+		return zero, errors.New("race: at least one task must be provided")
+	}
+	for i, task := range tasks {
+		if task == nil {
+			return zero, fmt.Errorf("race: task %d is nil", i)
+		}
+	}
+
+	// The watchers stop with the race, so that none is left waiting for a losing task.
+	watchCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	finished := make(chan int, len(tasks))
+	for i, task := range tasks {
+		go func() {
+			if task.WaitDone(watchCtx) == nil {
+				finished <- i
+			}
+		}()
+	}
+
+	cancelOthers := func(winner int) {
+		for i, task := range tasks {
+			if i != winner {
+				task.Cancel()
+			}
+		}
+	}
+
+	errs := make([]error, len(tasks))
+	for range tasks {
+		select {
+		case i := <-finished:
+			result, err := tasks[i].Result()
+			if err == nil || !succeeded {
+				cancelOthers(i)
+				return result, err
+			}
+			errs[i] = fmt.Errorf("task %d: %w", i, err)
+
+		case <-ctx.Done():
+			cancelOthers(-1)
+			return zero, ctx.Err()
+		}
+	}
+
+	return zero, errors.Join(errs...)
+}
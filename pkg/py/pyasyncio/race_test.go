@@ -0,0 +1,122 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/pkg/py/pyasyncio"
+)
+
+// sleepTask returns a task function finishing with the result after d, or with the error of its
+// context, reported to cancelled, if it is cancelled first.
+func sleepTask(d time.Duration, result string, err error, cancelled chan<- string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		select {
+		case <-time.After(d):
+			return result, err
+		case <-ctx.Done():
+			if cancelled != nil {
+				cancelled <- result
+			}
+			return "", ctx.Err()
+		}
+	}
+}
+
+func TestRace(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	errFast := errors.New("fast failure")
+	cancelled := make(chan string, 1)
+
+	tasks := []*pyasyncio.Task[string]{
+		pyasyncio.CreateTask(ctx, sleepTask(time.Millisecond, "fast", errFast, nil)),
+		pyasyncio.CreateTask(ctx, sleepTask(20*time.Millisecond, "winner", nil, nil)),
+		pyasyncio.CreateTask(ctx, sleepTask(time.Minute, "slow", nil, cancelled)),
+	}
+
+	got, err := pyasyncio.Race(ctx, tasks...)
+	if err != nil {
+		t.Fatalf("Race() error = %v", err)
+	}
+	if got != "winner" {
+		t.Errorf("Race() = %q, want %q", got, "winner")
+	}
+
+	select {
+	case name := <-cancelled:
+		if name != "slow" {
+			t.Errorf("cancelled task = %q, want %q", name, "slow")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("losing task was not cancelled")
+	}
+}
+
+func TestRaceAllFail(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	_, err := pyasyncio.Race(ctx,
+		pyasyncio.CreateTask(ctx, sleepTask(10*time.Millisecond, "a", errA, nil)),
+		pyasyncio.CreateTask(ctx, sleepTask(time.Millisecond, "b", errB, nil)),
+	)
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Race() error = %v, want both %v and %v", err, errA, errB)
+	}
+}
+
+func TestRaceAny(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	errFast := errors.New("fast failure")
+	cancelled := make(chan string, 1)
+
+	_, err := pyasyncio.RaceAny(ctx,
+		pyasyncio.CreateTask(ctx, sleepTask(time.Millisecond, "fast", errFast, nil)),
+		pyasyncio.CreateTask(ctx, sleepTask(time.Minute, "slow", nil, cancelled)),
+	)
+	if !errors.Is(err, errFast) {
+		t.Errorf("RaceAny() error = %v, want %v", err, errFast)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("losing task was not cancelled")
+	}
+}
+
+func TestRaceContextDone(t *testing.T) {
+	t.Parallel()
+
+	taskCtx := t.Context()
+	cancelled := make(chan string, 2)
+	tasks := []*pyasyncio.Task[string]{
+		pyasyncio.CreateTask(taskCtx, sleepTask(time.Minute, "a", nil, cancelled)),
+		pyasyncio.CreateTask(taskCtx, sleepTask(time.Minute, "b", nil, cancelled)),
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := pyasyncio.Race(ctx, tasks...); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Race() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	for range tasks {
+		select {
+		case <-cancelled:
+		case <-time.After(time.Second):
+			t.Fatal("task was not cancelled")
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-a2a/adk-go/pkg/backoff"
+)
+
+// DefaultMaxAttempts is the number of processing attempts of an item when [RetryPolicy.MaxAttempts] is not set.
+const DefaultMaxAttempts = 3
+
+// RetryPolicy configures how a [RetryQueue] retries the items whose processing failed.
+type RetryPolicy struct {
+	// MaxAttempts is the number of processing attempts of an item, including the first one,
+	// before it is dead-lettered. Defaults to [DefaultMaxAttempts].
+	MaxAttempts int
+
+	// Backoff returns the delay before the item is re-enqueued after its attempt-th failed attempt.
+	// Defaults to [ExponentialBackoff] with a base of 100ms and a maximum of 10s.
+	Backoff func(attempt int) time.Duration
+}
+
+// ExponentialBackoff returns a backoff doubling from base on every attempt, capped at maximum.
+func ExponentialBackoff(base, maximum time.Duration) func(attempt int) time.Duration {
+	return backoff.Backoff{Base: base, Max: maximum}.NextDelay
+}
+
+// RetryItem is an item delivered by [RetryQueue.Get].
+//
+// It must be passed back to exactly one of [RetryQueue.TaskDone] or [RetryQueue.TaskFailed].
+type RetryItem[T any] struct {
+	// Value is the enqueued value.
+	Value T
+
+	// Attempt is the number of this processing attempt, starting at 1.
+	Attempt int
+
+	// Err is the error of the last failed attempt, or nil on the first attempt.
+	Err error
+
+	// finished reports whether the attempt was marked done or failed.
+	finished bool
+}
+
+// RetryStats holds the counters of a [RetryQueue].
+type RetryStats struct {
+	// Succeeded is the number of items marked done.
+	Succeeded int
+
+	// Retries is the number of failed attempts that were re-enqueued.
+	Retries int
+
+	// DeadLettered is the number of items that failed every attempt.
+	DeadLettered int
+}
+
+// RetryQueue is a queue whose failed items are retried with backoff, then routed to a dead-letter queue.
+//
+// Consumers get a [*RetryItem] and report the outcome of its processing with TaskDone or TaskFailed.
+// A failed item is re-enqueued after the backoff of the [RetryPolicy] until it reaches the
+// maximum number of attempts. It is then put into the dead-letter queue if one is set, or kept
+// for [RetryQueue.Failed] otherwise.
+type RetryQueue[T any] struct {
+	items      *queue[*RetryItem[T]]
+	policy     RetryPolicy
+	deadLetter Queue[T]
+
+	mu     sync.Mutex
+	stats  RetryStats
+	failed []*RetryItem[T]
+}
+
+// NewRetryQueue creates a new RetryQueue with the specified maximum size and retry policy.
+//
+// If maxsize is less than or equal to zero, the queue size is infinite. Items that fail every
+// attempt are put into deadLetter, which may be nil to keep them for [RetryQueue.Failed].
+func NewRetryQueue[T any](maxsize int, policy RetryPolicy, deadLetter Queue[T]) *RetryQueue[T] {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+	if policy.Backoff == nil {
+		policy.Backoff = ExponentialBackoff(100*time.Millisecond, 10*time.Second)
+	}
+
+	return &RetryQueue[T]{
+		items:      NewQueue[*RetryItem[T]](maxsize),
+		policy:     policy,
+		deadLetter: deadLetter,
+	}
+}
+
+// Put adds an item to the queue for its first attempt, blocking if full.
+func (q *RetryQueue[T]) Put(ctx context.Context, item T) error {
+	return q.items.Put(ctx, &RetryItem[T]{Value: item})
+}
+
+// PutNowait adds an item to the queue for its first attempt without blocking.
+func (q *RetryQueue[T]) PutNowait(item T) error {
+	return q.items.PutNowait(&RetryItem[T]{Value: item})
+}
+
+// Get removes and returns the next item to process, blocking if empty.
+func (q *RetryQueue[T]) Get(ctx context.Context) (*RetryItem[T], error) {
+	item, err := q.items.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q.mu.Lock()
+	item.Attempt++
+	item.finished = false
+	q.mu.Unlock()
+
+	return item, nil
+}
+
+// TaskDone marks the processing of the item as successful.
+func (q *RetryQueue[T]) TaskDone(item *RetryItem[T]) error {
+	if err := q.finish(item); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	q.stats.Succeeded++
+	q.mu.Unlock()
+
+	return q.items.TaskDone()
+}
+
+// TaskFailed marks the processing of the item as failed with the cause.
+//
+// The item is re-enqueued after the backoff if it has attempts left, and dead-lettered otherwise.
+// An error is returned if the dead-letter queue rejects the item, which is then kept for
+// [RetryQueue.Failed] so that it is not lost.
+func (q *RetryQueue[T]) TaskFailed(item *RetryItem[T], cause error) error {
+	if err := q.finish(item); err != nil {
+		return err
+	}
+	item.Err = cause
+
+	if item.Attempt < q.policy.MaxAttempts {
+		q.mu.Lock()
+		q.stats.Retries++
+		q.mu.Unlock()
+
+		// The failed attempt stays unfinished until the retry is enqueued, so that Join keeps waiting.
+		time.AfterFunc(q.policy.Backoff(item.Attempt), func() {
+			if err := q.items.Put(context.Background(), item); err != nil {
+				q.deadLetterItem(item)
+			}
+			q.items.TaskDone()
+		})
+		return nil
+	}
+
+	err := q.deadLetterItem(item)
+	if doneErr := q.items.TaskDone(); doneErr != nil {
+		return doneErr
+	}
+	return err
+}
+
+// finish marks the attempt of the item as finished, failing if it already was.
+func (q *RetryQueue[T]) finish(item *RetryItem[T]) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if item == nil || item.Attempt == 0 {
+		return errors.New("item was not delivered by Get")
+	}
+	if item.finished {
+		return fmt.Errorf("attempt %d of the item already marked done or failed", item.Attempt)
+	}
+	item.finished = true
+
+	return nil
+}
+
+// deadLetterItem routes the item that failed every attempt to the dead-letter queue or the failed items.
+func (q *RetryQueue[T]) deadLetterItem(item *RetryItem[T]) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.stats.DeadLettered++
+	if q.deadLetter != nil {
+		err := q.deadLetter.PutNowait(item.Value)
+		if err == nil {
+			return nil
+		}
+		q.failed = append(q.failed, item)
+		return fmt.Errorf("put item into dead-letter queue: %w", err)
+	}
+
+	q.failed = append(q.failed, item)
+	return nil
+}
+
+// Failed returns an iterator over the items that failed every attempt and were not put into a
+// dead-letter queue, in the order they failed.
+func (q *RetryQueue[T]) Failed() iter.Seq[*RetryItem[T]] {
+	q.mu.Lock()
+	failed := slices.Clone(q.failed)
+	q.mu.Unlock()
+
+	return slices.Values(failed)
+}
+
+// Stats returns the counters of the queue.
+func (q *RetryQueue[T]) Stats() RetryStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.stats
+}
+
+// Size returns the number of items waiting in the queue, excluding the items waiting for their backoff.
+func (q *RetryQueue[T]) Size() int {
+	return q.items.Size()
+}
+
+// Pending returns the number of items not yet done or dead-lettered, including the items being
+// processed and the items waiting for their backoff.
+func (q *RetryQueue[T]) Pending() int {
+	return q.items.Pending()
+}
+
+// Join waits until every item is done or dead-lettered.
+func (q *RetryQueue[T]) Join(ctx context.Context) error {
+	return q.items.Join(ctx)
+}
+
+// JoinWithTimeout is like Join() but gives up after the timeout, see [Queue.JoinWithTimeout].
+func (q *RetryQueue[T]) JoinWithTimeout(ctx context.Context, timeout time.Duration) error {
+	return q.items.JoinWithTimeout(ctx, timeout)
+}
+
+// Close closes the queue. Items waiting for their backoff are dead-lettered instead of retried.
+func (q *RetryQueue[T]) Close() {
+	q.items.Close()
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pyasyncio_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py/pyasyncio"
+)
+
+func TestRetryQueue(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	deadLetter := pyasyncio.NewQueue[string](0)
+	q := pyasyncio.NewRetryQueue(0, pyasyncio.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     func(int) time.Duration { return time.Millisecond },
+	}, deadLetter)
+
+	for _, v := range []string{"ok", "flaky", "poison"} {
+		if err := q.Put(ctx, v); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	errProcess := errors.New("process failed")
+	consumerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		for {
+			item, err := q.Get(consumerCtx)
+			if err != nil {
+				return
+			}
+			switch {
+			case item.Value == "poison", item.Value == "flaky" && item.Attempt < 2:
+				if item.Attempt > 1 && !errors.Is(item.Err, errProcess) {
+					t.Errorf("item %s attempt %d: Err = %v, want the previous failure", item.Value, item.Attempt, item.Err)
+				}
+				if err := q.TaskFailed(item, errProcess); err != nil {
+					t.Errorf("TaskFailed failed: %v", err)
+				}
+			default:
+				if err := q.TaskDone(item); err != nil {
+					t.Errorf("TaskDone failed: %v", err)
+				}
+			}
+		}
+	}()
+
+	if err := q.JoinWithTimeout(ctx, 5*time.Second); err != nil {
+		t.Fatalf("JoinWithTimeout failed: %v", err)
+	}
+
+	want := pyasyncio.RetryStats{Succeeded: 2, Retries: 3, DeadLettered: 1}
+	if diff := cmp.Diff(want, q.Stats()); diff != "" {
+		t.Errorf("Stats() mismatch (-want +got):\n%s", diff)
+	}
+	if got, err := deadLetter.GetNowait(); err != nil || got != "poison" {
+		t.Errorf("dead-letter queue = (%q, %v), want poison", got, err)
+	}
+	if got := slices.Collect(q.Failed()); len(got) != 0 {
+		t.Errorf("Failed() = %v, want none with a dead-letter queue", got)
+	}
+}
+
+func TestRetryQueueFailed(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	q := pyasyncio.NewRetryQueue[int](0, pyasyncio.RetryPolicy{MaxAttempts: 1}, nil)
+	if err := q.PutNowait(42); err != nil {
+		t.Fatalf("PutNowait failed: %v", err)
+	}
+
+	item, err := q.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if err := q.TaskFailed(item, errors.New("boom")); err != nil {
+		t.Fatalf("TaskFailed failed: %v", err)
+	}
+	if err := q.TaskDone(item); err == nil {
+		t.Error("Expected an error when finishing an attempt twice")
+	}
+	if err := q.TaskDone(&pyasyncio.RetryItem[int]{Value: 1}); err == nil {
+		t.Error("Expected an error when finishing an item not delivered by Get")
+	}
+
+	failed := slices.Collect(q.Failed())
+	if len(failed) != 1 || failed[0].Value != 42 || failed[0].Attempt != 1 || failed[0].Err == nil {
+		t.Errorf("Failed() = %v, want item 42 after one attempt", failed)
+	}
+	if got := q.Pending(); got != 0 {
+		t.Errorf("Pending() = %d, want 0", got)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff := pyasyncio.ExponentialBackoff(100*time.Millisecond, time.Second)
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
@@ -248,6 +248,8 @@ func (t *Task[T]) State() TaskState {
 // If the task completed successfully, returns the result.
 //
 // This method can be called multiple times and will return the same result.
+// It is the convenience path for a single waiter; use [Task.WaitDone] to
+// coordinate several waiters on the completion alone.
 //
 // The context can be used to timeout the wait operation, but does not
 // cancel the task itself.
@@ -267,6 +269,22 @@ func (t *Task[T]) Wait(ctx context.Context) (T, error) {
 	}
 }
 
+// WaitDone blocks until the task is done (completed or cancelled) without returning its result.
+//
+// It returns nil once the task is done, whatever its outcome, or the error of ctx if the
+// wait is abandoned first. Like [Task.Wait], ctx does not cancel the task itself.
+//
+// Any number of goroutines may call WaitDone concurrently and then each read the
+// stored outcome with [Task.Result] or [Task.Exception].
+func (t *Task[T]) WaitDone(ctx context.Context) error {
+	select {
+	case <-t.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Result returns the result of the task without blocking.
 //
 // This is equivalent to Python's [asyncio.Task.result].
@@ -275,6 +293,9 @@ func (t *Task[T]) Wait(ctx context.Context) (T, error) {
 // If the task was cancelled, returns a TaskCancelledError.
 // If the task completed with an error, returns that error.
 //
+// Once the task is done, the outcome is stored and every call, from any goroutine,
+// returns the same value.
+//
 // [asyncio.Task.result]: https://docs.python.org/3/library/asyncio-task.html#asyncio.Task.result
 func (t *Task[T]) Result() (T, error) {
 	var zero T
@@ -296,6 +317,8 @@ func (t *Task[T]) Result() (T, error) {
 // If the task completed successfully, returns nil.
 // If the task was cancelled or failed, returns the error.
 //
+// Like [Task.Result], it is safe to call repeatedly once the task is done.
+//
 // [asyncio.Task.exception]: https://docs.python.org/3/library/asyncio-task.html#asyncio.Task.exception
 func (t *Task[T]) Exception() error {
 	if !t.Done() {
@@ -641,3 +641,51 @@ func TestTaskErrorEquality(t *testing.T) {
 		t.Errorf("Error mismatch: wait=%v, exception=%v", err1, err2)
 	}
 }
+
+func TestTaskWaitDone(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	task := pyasyncio.CreateTask(t.Context(), func(ctx context.Context) (string, error) {
+		<-release
+		return "shared result", nil
+	})
+
+	// abandoned wait does not affect the task
+	waitCtx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+	if err := task.WaitDone(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitDone() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	const waiters = 8
+	results := make([]string, waiters)
+	var wg sync.WaitGroup
+	for i := range waiters {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := task.WaitDone(t.Context()); err != nil {
+				t.Errorf("WaitDone() = %v", err)
+				return
+			}
+			result, err := task.Result()
+			if err != nil {
+				t.Errorf("Result() = %v", err)
+				return
+			}
+			results[i] = result
+		}()
+	}
+	close(release)
+	wg.Wait()
+
+	for i, result := range results {
+		if result != "shared result" {
+			t.Errorf("waiter %d got %q, want %q", i, result, "shared result")
+		}
+	}
+	if err := task.Exception(); err != nil {
+		t.Errorf("Exception() = %v, want nil", err)
+	}
+}
@@ -36,6 +36,17 @@ func NewSet[T comparable](items ...T) Set[T] {
 	return ss
 }
 
+// NewSetFromSlice creates a Set from the slice s.
+//
+// Unlike [NewSet], the slice is used directly and is not expanded as variadic arguments.
+func NewSetFromSlice[T comparable](s []T) Set[T] {
+	ss := make(Set[T], len(s))
+	for _, item := range s {
+		ss[item] = Empty{}
+	}
+	return ss
+}
+
 // KeySet creates a Set from a keys of a map[comparable](? extends interface{}).
 // If the value passed in is not actually a map, this will panic.
 func KeySet[T comparable, V any](theMap map[T]V) Set[T] {
@@ -96,10 +107,21 @@ func (s Set[T]) HasAny(items ...T) bool {
 }
 
 // Clone returns a new set which is a copy of the current set.
+//
+// The new set is allocated with the capacity of the current set.
 func (s Set[T]) Clone() Set[T] {
-	result := make(Set[T], len(s))
+	return s.CloneWithCapacity(0)
+}
+
+// CloneWithCapacity returns a new set which is a copy of the current set,
+// allocated with room for extra more items.
+//
+// Use it instead of [Set.Clone] when the number of upcoming inserts is known to avoid rehashing.
+// A negative extra is treated as zero.
+func (s Set[T]) CloneWithCapacity(extra int) Set[T] {
+	result := make(Set[T], len(s)+max(extra, 0))
 	for key := range s {
-		result.Insert(key)
+		result[key] = Empty{}
 	}
 	return result
 }
@@ -137,7 +159,11 @@ func (s1 Set[T]) SymmetricDifference(s2 Set[T]) Set[T] {
 // s1.Union(s2) = {a1, a2, a3, a4}
 // s2.Union(s1) = {a1, a2, a3, a4}
 func (s1 Set[T]) Union(s2 Set[T]) Set[T] {
-	result := s1.Clone()
+	// Allocate for the upper bound of the result to avoid rehashing while inserting.
+	result := make(Set[T], len(s1)+len(s2))
+	for key := range s1 {
+		result.Insert(key)
+	}
 	for key := range s2 {
 		result.Insert(key)
 	}
@@ -149,16 +175,15 @@ func (s1 Set[T]) Union(s2 Set[T]) Set[T] {
 // s1 = {a1, a2}
 // s2 = {a2, a3}
 // s1.Intersection(s2) = {a2}
+//
+// The smaller of the two sets is walked and the larger one is probed, so the cost
+// is proportional to the smaller set.
 func (s1 Set[T]) Intersection(s2 Set[T]) Set[T] {
-	var walk, other Set[T]
-	result := NewSet[T]()
-	if s1.Len() < s2.Len() {
-		walk = s1
-		other = s2
-	} else {
-		walk = s2
-		other = s1
+	walk, other := s1, s2
+	if s2.Len() < s1.Len() {
+		walk, other = s2, s1
 	}
+	result := NewSet[T]()
 	for key := range walk {
 		if other.Has(key) {
 			result.Insert(key)
@@ -168,7 +193,13 @@ func (s1 Set[T]) Intersection(s2 Set[T]) Set[T] {
 }
 
 // IsSuperset returns true if and only if s1 is a superset of s2.
+//
+// s1 can only be a superset when s2 is the smaller operand, so only s2 is walked
+// and s1 is probed.
 func (s1 Set[T]) IsSuperset(s2 Set[T]) bool {
+	if len(s2) > len(s1) {
+		return false
+	}
 	for item := range s2 {
 		if !s1.Has(item) {
 			return false
@@ -203,7 +234,32 @@ func List[T cmp.Ordered](s Set[T]) []T {
 	return res
 }
 
+// MissingFrom returns the sorted elements of required that are absent from provided.
+//
+// It returns an empty, non-nil slice when nothing is missing, which makes it suitable for
+// stable error messages such as missing required tool parameters.
+func MissingFrom[T cmp.Ordered](required, provided Set[T]) []T {
+	res := make([]T, 0)
+	for key := range required {
+		if !provided.Has(key) {
+			res = append(res, key)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// Extra returns the sorted elements of provided that are absent from allowed.
+//
+// It is the counterpart of [MissingFrom] and returns an empty, non-nil slice when there are none.
+func Extra[T cmp.Ordered](allowed, provided Set[T]) []T {
+	return MissingFrom(provided, allowed)
+}
+
 // UnsortedList returns the slice with contents in random order.
+//
+// The order follows Go's randomized map iteration and is nondeterministic, it may differ
+// between two calls on the same set. Use [List] or [Set.OrderedList] when a stable order is needed.
 func (s Set[T]) UnsortedList() []T {
 	res := make([]T, 0, len(s))
 	for key := range s {
@@ -212,6 +268,25 @@ func (s Set[T]) UnsortedList() []T {
 	return res
 }
 
+// OrderedList returns the contents as a slice sorted by less.
+//
+// Unlike [List], T does not need to be ordered. less reports whether a sorts before b
+// and must be a strict weak ordering.
+func (s Set[T]) OrderedList(less func(a, b T) bool) []T {
+	res := s.UnsortedList()
+	slices.SortFunc(res, func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return res
+}
+
 // PopAny returns a single element from the set.
 func (s Set[T]) PopAny() (T, bool) {
 	for key := range s {
@@ -0,0 +1,42 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+// Map returns the set of the results of fn on the items of s.
+//
+// The items mapped to the same result collapse into one, so the result may be smaller than s.
+// fn is called in random order, as [Set.UnsortedList].
+func Map[T, U comparable](s Set[T], fn func(T) U) Set[U] {
+	result := make(Set[U], len(s))
+	for item := range s {
+		result[fn(item)] = Empty{}
+	}
+	return result
+}
+
+// Filter returns the set of the items of s for which pred returns true.
+//
+// pred is called in random order, as [Set.UnsortedList].
+func Filter[T comparable](s Set[T], pred func(T) bool) Set[T] {
+	result := make(Set[T], len(s))
+	for item := range s {
+		if pred(item) {
+			result[item] = Empty{}
+		}
+	}
+	return result
+}
+
+// Reduce folds the items of s into an accumulator, starting from init, as Python's
+// functools.reduce.
+//
+// fn is called in random order, as [Set.UnsortedList], so the result is only deterministic if fn
+// does not depend on the order, such as a sum or a maximum.
+func Reduce[T comparable, A any](s Set[T], init A, fn func(A, T) A) A {
+	acc := init
+	for item := range s {
+		acc = fn(acc, item)
+	}
+	return acc
+}
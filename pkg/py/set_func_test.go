@@ -0,0 +1,38 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestSetFunctions(t *testing.T) {
+	t.Parallel()
+
+	lower := py.Map(py.NewSet("Search", "SEARCH", "Fetch"), strings.ToLower)
+	if diff := cmp.Diff(py.NewSet("search", "fetch"), lower); diff != "" {
+		t.Errorf("Map() mismatch (-want +got):\n%s", diff)
+	}
+
+	numbers := py.NewSet(1, 2, 3, 4, 5, 6)
+	even := py.Filter(numbers, func(n int) bool { return n%2 == 0 })
+	if diff := cmp.Diff(py.NewSet(2, 4, 6), even); diff != "" {
+		t.Errorf("Filter() mismatch (-want +got):\n%s", diff)
+	}
+
+	if sum := py.Reduce(numbers, 0, func(acc, n int) int { return acc + n }); sum != 21 {
+		t.Errorf("Reduce() = %d, want 21", sum)
+	}
+	if got := py.Reduce(py.NewSet[int](), "init", func(acc string, n int) string { return "" }); got != "init" {
+		t.Errorf("Reduce() of the empty set = %q, want init", got)
+	}
+	if got := py.Map(py.Set[int](nil), func(n int) int { return n }); got == nil || got.Len() != 0 {
+		t.Errorf("Map() of a nil set = %v, want an empty set", got)
+	}
+}
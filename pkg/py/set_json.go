@@ -0,0 +1,39 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import (
+	"cmp"
+
+	"github.com/go-json-experiment/json"
+)
+
+// MarshalJSON implements json.Marshaler to encode the set as a JSON array of its items.
+//
+// The items are in random order, as [Set.UnsortedList]; use [MarshalSortedSet] for a stable
+// output. A nil set is encoded as an empty array.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.UnsortedList())
+}
+
+// UnmarshalJSON implements json.Unmarshaler to decode the set from a JSON array, dropping the
+// duplicate items. A JSON null decodes to a nil set.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		*s = nil
+		return nil
+	}
+	*s = NewSetFromSlice(items)
+	return nil
+}
+
+// MarshalSortedSet encodes the set as a JSON array of its items in sorted order, as [List], so that
+// the output of equal sets is identical.
+func MarshalSortedSet[T cmp.Ordered](s Set[T]) ([]byte, error) {
+	return json.Marshal(List(s))
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestSet_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		data    string
+		want    py.Set[string]
+		wantOut string
+	}{
+		"items": {
+			data:    `["b","a","b"]`,
+			want:    py.NewSet("a", "b"),
+			wantOut: `["a","b"]`,
+		},
+		"empty": {
+			data:    `[]`,
+			want:    py.NewSet[string](),
+			wantOut: `[]`,
+		},
+		"null": {
+			data:    `null`,
+			wantOut: `[]`,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got py.Set[string]
+			if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+			}
+
+			out, err := py.MarshalSortedSet(got)
+			if err != nil {
+				t.Fatalf("MarshalSortedSet() error = %v", err)
+			}
+			if string(out) != tt.wantOut {
+				t.Errorf("MarshalSortedSet() = %s, want %s", out, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestSet_MarshalJSON_Field(t *testing.T) {
+	t.Parallel()
+
+	type event struct {
+		IDs py.Set[int] `json:"ids"`
+	}
+	out, err := json.Marshal(event{IDs: py.NewSet(7)})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(out) != `{"ids":[7]}` {
+		t.Errorf("Marshal() = %s, want {\"ids\":[7]}", out)
+	}
+
+	var got event
+	if err := json.Unmarshal([]byte(`{"ids":[7,7,9]}`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if diff := cmp.Diff(py.NewSet(7, 9), got.IDs); diff != "" {
+		t.Errorf("Unmarshal() mismatch (-want +got):\n%s", diff)
+	}
+}
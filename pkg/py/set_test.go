@@ -156,6 +156,35 @@ func TestNewSet(t *testing.T) {
 	}
 }
 
+func TestNewSetFromSlice(t *testing.T) {
+	t.Parallel()
+
+	s := py.NewSetFromSlice([]string{"a", "b", "c", "a"})
+	if !s.Equal(py.NewSet("a", "b", "c")) {
+		t.Errorf("Unexpected contents: %#v", py.List(s))
+	}
+
+	if s := py.NewSetFromSlice[string](nil); s == nil || s.Len() != 0 {
+		t.Errorf("Expected empty non-nil set: %#v", s)
+	}
+}
+
+func TestSetCloneWithCapacity(t *testing.T) {
+	t.Parallel()
+
+	s := py.NewSet("a", "b")
+	for _, extra := range []int{-1, 0, 10} {
+		c := s.CloneWithCapacity(extra)
+		if !c.Equal(s) {
+			t.Errorf("CloneWithCapacity(%d) = %v, want %v", extra, py.List(c), py.List(s))
+		}
+		c.Insert("c")
+		if s.Has("c") {
+			t.Errorf("CloneWithCapacity(%d) shares storage with the original set", extra)
+		}
+	}
+}
+
 func TestKeySet(t *testing.T) {
 	t.Parallel()
 
@@ -191,6 +220,27 @@ func TestSortedList(t *testing.T) {
 	}
 }
 
+func TestOrderedList(t *testing.T) {
+	t.Parallel()
+
+	type point struct{ x, y int }
+	s := py.NewSet(point{2, 1}, point{1, 2}, point{1, 1})
+	got := s.OrderedList(func(a, b point) bool {
+		if a.x != b.x {
+			return a.x < b.x
+		}
+		return a.y < b.y
+	})
+	want := []point{{1, 1}, {1, 2}, {2, 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("OrderedList gave unexpected result: %#v", got)
+	}
+
+	if got := py.NewSet[point]().OrderedList(func(a, b point) bool { return false }); len(got) != 0 {
+		t.Errorf("OrderedList of empty set gave unexpected result: %#v", got)
+	}
+}
+
 func TestSetDifference(t *testing.T) {
 	t.Parallel()
 
@@ -212,6 +262,27 @@ func TestSetDifference(t *testing.T) {
 	}
 }
 
+func TestMissingFromAndExtra(t *testing.T) {
+	t.Parallel()
+
+	required := py.NewSet("query", "model", "limit")
+	provided := py.NewSet("model", "verbose", "debug")
+
+	if got, want := py.MissingFrom(required, provided), []string{"limit", "query"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingFrom gave unexpected result: %#v", got)
+	}
+	if got, want := py.Extra(required, provided), []string{"debug", "verbose"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Extra gave unexpected result: %#v", got)
+	}
+
+	if got := py.MissingFrom(required, required); got == nil || len(got) != 0 {
+		t.Errorf("MissingFrom with nothing missing gave %#v, want empty non-nil slice", got)
+	}
+	if got := py.Extra(required, nil); got == nil || len(got) != 0 {
+		t.Errorf("Extra with nil provided gave %#v, want empty non-nil slice", got)
+	}
+}
+
 func TestSetSymmetricDifference(t *testing.T) {
 	t.Parallel()
 
@@ -385,3 +456,76 @@ func TestIntersection(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSuperset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		s1       py.Set[string]
+		s2       py.Set[string]
+		expected bool
+	}{
+		{py.NewSet("1", "2", "3"), py.NewSet("1", "2"), true},
+		{py.NewSet("1", "2"), py.NewSet("1", "2", "3"), false},
+		{py.NewSet("1", "2", "3"), py.NewSet("1", "4"), false},
+		{py.NewSet("1", "2"), py.NewSet[string](), true},
+		{py.NewSet[string](), py.NewSet("1"), false},
+		{py.NewSet[string](), py.NewSet[string](), true},
+	}
+
+	for _, test := range tests {
+		if got := test.s1.IsSuperset(test.s2); got != test.expected {
+			t.Errorf("%v.IsSuperset(%v) = %t, want %t", py.List(test.s1), py.List(test.s2), got, test.expected)
+		}
+	}
+}
+
+func lopsidedSets(large, small int) (py.Set[int], py.Set[int]) {
+	l := make(py.Set[int], large)
+	for i := range large {
+		l.Insert(i)
+	}
+	s := make(py.Set[int], small)
+	for i := range small {
+		s.Insert(i * 2)
+	}
+	return l, s
+}
+
+func BenchmarkIntersection(b *testing.B) {
+	large, small := lopsidedSets(1_000_000, 3)
+
+	b.Run("LargeReceiver", func(b *testing.B) {
+		for b.Loop() {
+			_ = large.Intersection(small)
+		}
+	})
+	b.Run("SmallReceiver", func(b *testing.B) {
+		for b.Loop() {
+			_ = small.Intersection(large)
+		}
+	})
+}
+
+func BenchmarkIsSuperset(b *testing.B) {
+	large, small := lopsidedSets(1_000_000, 3)
+
+	b.Run("LargeReceiver", func(b *testing.B) {
+		for b.Loop() {
+			_ = large.IsSuperset(small)
+		}
+	})
+	b.Run("SmallReceiver", func(b *testing.B) {
+		for b.Loop() {
+			_ = small.IsSuperset(large)
+		}
+	})
+}
+
+func BenchmarkUnion(b *testing.B) {
+	large, small := lopsidedSets(100_000, 100_000)
+
+	for b.Loop() {
+		_ = large.Union(small)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py
+
+import (
+	"flag"
+	"strings"
+)
+
+// ParseSet returns the set of the non-empty items of s separated by sep, with their surrounding
+// white space trimmed, as Python's set(s.split(sep)).
+//
+// An empty sep splits s around runs of white space, so that line-delimited and space-delimited
+// lists parse alike.
+func ParseSet(s, sep string) Set[string] {
+	var items []string
+	if sep == "" {
+		items = strings.Fields(s)
+	} else {
+		items = strings.Split(s, sep)
+	}
+
+	set := make(Set[string], len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			set.Insert(item)
+		}
+	}
+	return set
+}
+
+// Join returns the sorted items of the set separated by sep, the inverse of [ParseSet].
+func Join[T ~string](s Set[T], sep string) string {
+	items := List(s)
+	parts := make([]string, len(items))
+	for i, item := range items {
+		parts[i] = string(item)
+	}
+	return strings.Join(parts, sep)
+}
+
+// setFlag is the [flag.Value] of [SetFlag].
+type setFlag struct {
+	set Set[string]
+	sep string
+}
+
+var _ flag.Getter = (*setFlag)(nil)
+
+// SetFlag returns a [flag.Value] inserting the items of each occurrence of the flag into the set,
+// parsed by [ParseSet] with sep. The flag can be repeated, and each occurrence can list several
+// items:
+//
+//	allowed := py.NewSet[string]()
+//	flag.Var(py.SetFlag(allowed, ","), "allow", "allowed tools, comma-separated or repeated")
+//
+// The set must be non-nil.
+func SetFlag(set Set[string], sep string) flag.Value {
+	return &setFlag{set: set, sep: sep}
+}
+
+// String implements [flag.Value].
+func (f *setFlag) String() string {
+	if f == nil || f.set == nil {
+		return ""
+	}
+	sep := f.sep
+	if sep == "" {
+		sep = " "
+	}
+	return Join(f.set, sep)
+}
+
+// Set implements [flag.Value].
+func (f *setFlag) Set(value string) error {
+	f.set.Insert(ParseSet(value, f.sep).UnsortedList()...)
+	return nil
+}
+
+// Get implements [flag.Getter].
+func (f *setFlag) Get() any {
+	return f.set
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package py_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/pkg/py"
+)
+
+func TestParseSet(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		s    string
+		sep  string
+		want []string
+	}{
+		"comma-separated": {
+			s:    "search, fetch,search,,list ",
+			sep:  ",",
+			want: []string{"fetch", "list", "search"},
+		},
+		"line-delimited": {
+			s:    "search\r\nfetch\n\nsearch\n",
+			sep:  "\n",
+			want: []string{"fetch", "search"},
+		},
+		"white space": {
+			s:    " search\tfetch \n list ",
+			want: []string{"fetch", "list", "search"},
+		},
+		"empty": {
+			s:    "",
+			sep:  ",",
+			want: []string{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := py.ParseSet(tt.s, tt.sep)
+			if diff := cmp.Diff(tt.want, py.List(got)); diff != "" {
+				t.Errorf("ParseSet(%q, %q) mismatch (-want +got):\n%s", tt.s, tt.sep, diff)
+			}
+		})
+	}
+}
+
+func TestJoin(t *testing.T) {
+	t.Parallel()
+
+	s := py.NewSet("search", "fetch", "list")
+	if got, want := py.Join(s, ","), "fetch,list,search"; got != want {
+		t.Errorf("Join() = %q, want %q", got, want)
+	}
+	if got := py.ParseSet(py.Join(s, "\n"), "\n"); !got.Equal(s) {
+		t.Errorf("ParseSet(Join()) = %v, want %v", got, s)
+	}
+	if got := py.Join(py.NewSet[string](), ","); got != "" {
+		t.Errorf("Join() of an empty set = %q, want empty", got)
+	}
+}
+
+func TestSetFlag(t *testing.T) {
+	t.Parallel()
+
+	allowed := py.NewSet[string]()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(py.SetFlag(allowed, ","), "allow", "allowed tools")
+
+	if err := fs.Parse([]string{"-allow", "search,fetch", "-allow", "list", "-allow=search"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"fetch", "list", "search"}, py.List(allowed)); diff != "" {
+		t.Errorf("flag set mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := fs.Lookup("allow").Value.String(), "fetch,list,search"; got != want {
+		t.Errorf("flag String() = %q, want %q", got, want)
+	}
+}
@@ -5,6 +5,7 @@ package planner
 
 import (
 	"context"
+	"strings"
 
 	"google.golang.org/genai"
 
@@ -16,26 +17,72 @@ type BuiltInPlanner struct {
 	// Config for model built-in thinking features. An error will be returned if this
 	// field is set for models that don't support thinking.
 	thinkingConfig *genai.ThinkingConfig
+
+	// Whether the thinking of the model is captured into reasoning events.
+	captureThoughts bool
+
+	// Whether the reasoning events are persisted to the session.
+	persistThoughts bool
 }
 
 var _ types.Planner = (*BuiltInPlanner)(nil)
 
+// BuiltInPlannerOption configures a [BuiltInPlanner].
+type BuiltInPlannerOption func(*BuiltInPlanner)
+
+// WithCaptureThoughts sets whether the thinking of the model is captured into a reasoning event,
+// with [types.Event.Reasoning] set, emitted before the event of the response and kept out of it.
+//
+// The model is asked to include its thoughts in the response, and the persist argument sets
+// whether the reasoning events are persisted to the session. The reasoning events not persisted
+// are marked as partial, which the session services do not persist.
+//
+// The reasoning events are never sent back to the model.
+func WithCaptureThoughts(capture, persist bool) BuiltInPlannerOption {
+	return func(p *BuiltInPlanner) {
+		p.captureThoughts = capture
+		p.persistThoughts = persist
+	}
+}
+
 // NewBuiltInPlanner returns a new BuiltInPlanner with the provided thinking configuration.
-func NewBuiltInPlanner(thinkingConfig *genai.ThinkingConfig) *BuiltInPlanner {
-	return &BuiltInPlanner{
+func NewBuiltInPlanner(thinkingConfig *genai.ThinkingConfig, opts ...BuiltInPlannerOption) *BuiltInPlanner {
+	p := &BuiltInPlanner{
 		thinkingConfig: thinkingConfig,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // ApplyThinkingConfig applies the thinking config to the LLM request.
+//
+// With [WithCaptureThoughts], the config asks the model to include its thoughts in the response.
 func (p *BuiltInPlanner) ApplyThinkingConfig(request *types.LLMRequest) {
-	if p.thinkingConfig != nil {
-		if request.Config == nil {
-			request.Config = new(genai.GenerateContentConfig)
-		}
+	if p.thinkingConfig == nil && !p.captureThoughts {
+		return
+	}
 
-		request.Config.ThinkingConfig = p.thinkingConfig
+	if request.Config == nil {
+		request.Config = new(genai.GenerateContentConfig)
 	}
+	thinkingConfig := p.thinkingConfig
+	if p.captureThoughts {
+		cfg := genai.ThinkingConfig{}
+		if thinkingConfig != nil {
+			cfg = *thinkingConfig
+		}
+		cfg.IncludeThoughts = true
+		thinkingConfig = &cfg
+	}
+	request.Config.ThinkingConfig = thinkingConfig
+}
+
+// CaptureThoughts reports whether the thinking of the model is captured into reasoning events,
+// and whether they are persisted to the session.
+func (p *BuiltInPlanner) CaptureThoughts() (capture, persist bool) {
+	return p.captureThoughts, p.persistThoughts
 }
 
 // BuildPlanningInstruction implements [types.Planner].
@@ -44,6 +91,21 @@ func (p *BuiltInPlanner) BuildPlanningInstruction(context.Context, *types.ReadOn
 }
 
 // ProcessPlanningResponse implements [types.Planner].
-func (p *BuiltInPlanner) ProcessPlanningResponse(context.Context, *types.CallbackContext, []*genai.Part) []*genai.Part {
+//
+// It leaves the response as is, and sets the plan of the thinking of the model, its numbered or
+// bulleted steps, on the context, see [ParsePlan].
+func (p *BuiltInPlanner) ProcessPlanningResponse(_ context.Context, cctx *types.CallbackContext, responseParts []*genai.Part) []*genai.Part {
+	var thoughts []string
+	for _, part := range responseParts {
+		if part != nil && part.Thought && part.Text != "" {
+			thoughts = append(thoughts, part.Text)
+		}
+	}
+	if len(thoughts) == 0 || cctx == nil {
+		return nil
+	}
+	if plan := ParsePlan(strings.Join(thoughts, "\n"), agentToolNames(cctx)...); plan != nil {
+		cctx.SetPlan(plan)
+	}
 	return nil
 }
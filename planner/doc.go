@@ -32,6 +32,21 @@
 // This approach leverages the model's internal reasoning capabilities for planning
 // without requiring explicit planning prompts or structured formats.
 //
+// With WithCaptureThoughts, the thinking of the model is surfaced as a separate reasoning event,
+// kept out of the answer, to show the reasoning or debug the planning:
+//
+//	planner := planner.NewBuiltInPlanner(thinkingConfig,
+//		planner.WithCaptureThoughts(true, false), // capture the thoughts without persisting them
+//	)
+//
+//	for event, err := range agent.Run(ctx, ictx) {
+//		if event.Reasoning {
+//			showReasoning(event.Content)
+//			continue
+//		}
+//		...
+//	}
+//
 // # ReAct Planning Framework
 //
 // PlanReActPlanner implements a structured Reasoning and Acting (ReAct) framework:
@@ -51,6 +66,12 @@
 //  4. Iteration: Repeat action/reasoning until goal is achieved
 //  5. Final Answer: Provide the complete solution
 //
+// A model that never gives its final answer would iterate forever. WithMaxPlanningSteps bounds the
+// iteration: once the steps are exhausted, the model is told to answer now with its tools disabled,
+// and the planning ends with an event of the ErrorCodeMaxPlanningSteps error code if it still acts:
+//
+//	planner := planner.NewPlanReActPlanner(planner.WithMaxPlanningSteps(10))
+//
 // # Planning Format Tags
 //
 // PlanReActPlanner uses structured tags to organize the planning process:
@@ -88,6 +109,24 @@
 //  4. Agent executes tools based on the plan
 //  5. Results are fed back for iterative planning
 //
+// # Inspecting Plans
+//
+// The plan detected in a response, the steps under the planning or replanning tag of the
+// PlanReActPlanner or the numbered steps of the thinking of the BuiltInPlanner, is parsed by
+// ParsePlan into a [types.Plan], carried by the event emitted after the response. Each step names
+// the tool it uses, with the arguments of its call if written, so that the plan can be rendered or
+// validated before it runs:
+//
+//	for event, err := range agent.Run(ctx, ictx) {
+//		if event.Plan != nil {
+//			if unknown := event.Plan.UnknownTools(availableTools...); len(unknown) > 0 {
+//				log.Printf("plan refers to unknown tools: %v", unknown)
+//			}
+//			showPlan(event.Plan.Steps)
+//		}
+//		...
+//	}
+//
 // # Custom Planner Implementation
 //
 // Implement the Planner interface for custom planning strategies:
@@ -0,0 +1,191 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planner
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+var (
+	// planStepRe matches a step of a plan, an item of a numbered or a bulleted list.
+	planStepRe = regexp.MustCompile(`^\s*(?:\d+[.)]|[-*•])\s+(.+)$`)
+
+	// planCallRe matches a tool call written in a step, such as vertex_search.search(query="x").
+	planCallRe = regexp.MustCompile(`([A-Za-z_][\w.]*)\(([^()]*)\)`)
+
+	// planTags are the tags of the Plan-ReAct planner ending a planning section.
+	planTags = []string{PlanningTag, ReplanningTag, ReasoningTag, ActionTag, FinalAnswerTag}
+)
+
+// ParsePlan parses the steps of the plan in text, the items of its numbered or bulleted list, and
+// returns nil if it has none. The lines following an item continue its description.
+//
+// The tool of a step is the first tool call written in it, such as get_weather(city="Paris"),
+// whose arguments are parsed too. Given the names of the available tools, only their calls are
+// considered, and a step without call uses the first available tool it names.
+func ParsePlan(text string, tools ...string) *types.Plan {
+	var steps []types.PlanStep
+	for line := range strings.Lines(text) {
+		line = strings.TrimSpace(line)
+		if m := planStepRe.FindStringSubmatch(line); m != nil {
+			steps = append(steps, types.PlanStep{Description: strings.TrimSpace(m[1])})
+			continue
+		}
+		if line != "" && len(steps) > 0 {
+			last := &steps[len(steps)-1]
+			last.Description += " " + line
+		}
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+
+	for i := range steps {
+		steps[i].ToolName, steps[i].Args = parseStepTool(steps[i].Description, tools)
+	}
+	return &types.Plan{
+		Steps: steps,
+		Text:  strings.TrimSpace(text),
+	}
+}
+
+// parseReActPlan returns the plan under the planning or the replanning tag of the text, or nil.
+func parseReActPlan(text string, tools []string) *types.Plan {
+	replanned := false
+	idx := strings.Index(text, PlanningTag)
+	start := idx + len(PlanningTag)
+	if idx < 0 {
+		if idx = strings.Index(text, ReplanningTag); idx < 0 {
+			return nil
+		}
+		replanned = true
+		start = idx + len(ReplanningTag)
+	}
+
+	section := text[start:]
+	for _, tag := range planTags {
+		if end := strings.Index(section, tag); end >= 0 {
+			section = section[:end]
+		}
+	}
+	plan := ParsePlan(section, tools...)
+	if plan != nil {
+		plan.Replanned = replanned
+	}
+	return plan
+}
+
+// agentToolNames returns the names of the tools of the agent of the context, or nil.
+func agentToolNames(cctx *types.CallbackContext) []string {
+	if cctx == nil || cctx.InvocationContext == nil || cctx.InvocationContext.Agent == nil {
+		return nil
+	}
+	llmAgent, ok := cctx.InvocationContext.Agent.AsLLMAgent()
+	if !ok {
+		return nil
+	}
+	var names []string
+	for _, tool := range llmAgent.CanonicalTool(cctx.ReadOnlyContext) {
+		names = append(names, tool.Name())
+	}
+	return names
+}
+
+// parseStepTool returns the tool used by the step description and the arguments of its call.
+func parseStepTool(description string, tools []string) (string, map[string]any) {
+	for _, m := range planCallRe.FindAllStringSubmatch(description, -1) {
+		name := m[1]
+		if i := strings.LastIndexByte(name, '.'); i >= 0 && !slices.Contains(tools, name) {
+			name = name[i+1:]
+		}
+		if len(tools) > 0 && !slices.Contains(tools, name) {
+			continue
+		}
+		return name, parseCallArgs(m[2])
+	}
+
+	for _, word := range strings.FieldsFunc(description, func(r rune) bool {
+		return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if slices.Contains(tools, word) {
+			return word, nil
+		}
+	}
+	return "", nil
+}
+
+// parseCallArgs parses the keyword arguments of a call, such as query="x", limit=3, returning nil
+// if it has none. The values are decoded as JSON, or as the Python constants, and are kept as
+// text otherwise.
+func parseCallArgs(text string) map[string]any {
+	var args map[string]any
+	for _, arg := range splitArgs(text) {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			continue
+		}
+		if args == nil {
+			args = make(map[string]any)
+		}
+		args[strings.TrimSpace(key)] = parseArgValue(strings.TrimSpace(value))
+	}
+	return args
+}
+
+// splitArgs splits the arguments of a call at the commas out of the quotes.
+func splitArgs(text string) []string {
+	var (
+		args  []string
+		quote rune
+		start int
+	)
+	for i, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == ',':
+			args = append(args, text[start:i])
+			start = i + 1
+		}
+	}
+	if rest := strings.TrimSpace(text[start:]); rest != "" {
+		args = append(args, rest)
+	}
+	return args
+}
+
+// parseArgValue decodes the value of a call argument.
+func parseArgValue(value string) any {
+	switch value {
+	case "True":
+		return true
+	case "False":
+		return false
+	case "None":
+		return nil
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return value[1 : len(value)-1]
+	}
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return n
+	}
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err == nil {
+		return v
+	}
+	return value
+}
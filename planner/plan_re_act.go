@@ -63,17 +63,62 @@ VERY IMPORTANT instruction that you MUST follow in addition to the above instruc
 
 You should ask for clarification if you need more information to answer the question.
 You should prefer using the information available in the context instead of repeated tool use.
+`
+
+	// ForceFinalAnswerPreamble is the directive appended to the request once the planning steps
+	// are exhausted, see [WithMaxPlanningSteps].
+	ForceFinalAnswerPreamble = `
+VERY IMPORTANT: You have used all the planning steps you are allowed. You MUST answer now: do not plan, reason or use any tool any further, and return your best final answer under ` + FinalAnswerTag + ` based on the information gathered so far.
 `
 )
 
+// ErrorCodeMaxPlanningSteps is the error code of the event ending a planning that did not reach
+// a final answer within its steps, see [WithMaxPlanningSteps].
+const ErrorCodeMaxPlanningSteps = "MAX_PLANNING_STEPS"
+
 // PlanReActPlanner represents a plan-Re-Act planner that constrains the LLM response to generate a plan before any action/observation.
 //
 // NOTE(adk-go): this planner does not require the model to support built-in thinking
 // features or setting the thinking config.
-type PlanReActPlanner struct{}
+type PlanReActPlanner struct {
+	// The maximum number of planning steps of an invocation, or zero for no limit.
+	maxPlanningSteps int
+}
 
 var _ types.Planner = (*PlanReActPlanner)(nil)
 
+// PlanReActPlannerOption configures a [PlanReActPlanner].
+type PlanReActPlannerOption func(*PlanReActPlanner)
+
+// WithMaxPlanningSteps bounds the planning steps of an invocation to n, a step being a response
+// of the model acting with tools rather than giving its final answer.
+//
+// Once n steps are taken, the next request tells the model it must answer now, with
+// [ForceFinalAnswerPreamble], and disables its tools. If the model still does not give a final
+// answer, the planning ends with an event of the [ErrorCodeMaxPlanningSteps] error code, and the
+// function calls of the response are dropped so that no further step is taken. Zero or less means
+// no limit, which is the default.
+func WithMaxPlanningSteps(n int) PlanReActPlannerOption {
+	return func(p *PlanReActPlanner) {
+		p.maxPlanningSteps = max(n, 0)
+	}
+}
+
+// NewPlanReActPlanner returns a new PlanReActPlanner.
+func NewPlanReActPlanner(opts ...PlanReActPlannerOption) *PlanReActPlanner {
+	p := &PlanReActPlanner{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// MaxPlanningSteps returns the maximum number of planning steps of an invocation, or zero for no
+// limit.
+func (p *PlanReActPlanner) MaxPlanningSteps() int {
+	return p.maxPlanningSteps
+}
+
 // BuildPlanningInstruction implements [types.Planner].
 func (p *PlanReActPlanner) BuildPlanningInstruction(ctx context.Context, rctx *types.ReadOnlyContext, request *types.LLMRequest) string {
 	return p.buildNLPlannerInstruction()
@@ -99,6 +144,17 @@ func (p *PlanReActPlanner) ProcessPlanningResponse(ctx context.Context, cctx *ty
 		return []*genai.Part{}
 	}
 
+	// Detect the plan, or the revised plan, of the response.
+	for _, part := range responseParts {
+		if cctx == nil || part == nil || part.Text == "" {
+			continue
+		}
+		if plan := parseReActPlan(part.Text, agentToolNames(cctx)); plan != nil {
+			cctx.SetPlan(plan)
+			break
+		}
+	}
+
 	preservedParts := []*genai.Part{}
 	firstFCPartIndex := -1
 	for i := range responseParts {
@@ -0,0 +1,81 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package planner_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/planner"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestParsePlan(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		text  string
+		tools []string
+		want  *types.Plan
+	}{
+		"no steps": {
+			text: "I should look the weather up.",
+		},
+		"numbered steps with calls": {
+			text: "1. Search the weather with default_api.get_weather(city=\"Paris, FR\", days=3, metric=True).\n" +
+				"2) Summarize it\n   for the user.",
+			want: &types.Plan{
+				Steps: []types.PlanStep{
+					{
+						Description: "Search the weather with default_api.get_weather(city=\"Paris, FR\", days=3, metric=True).",
+						ToolName:    "get_weather",
+						Args:        map[string]any{"city": "Paris, FR", "days": int64(3), "metric": true},
+					},
+					{Description: "Summarize it for the user."},
+				},
+				Text: "1. Search the weather with default_api.get_weather(city=\"Paris, FR\", days=3, metric=True).\n" +
+					"2) Summarize it\n   for the user.",
+			},
+		},
+		"tools named by the steps": {
+			text:  "- Use search_docs to find the guide.\n- Call print(x) then fetch_page.",
+			tools: []string{"search_docs", "fetch_page"},
+			want: &types.Plan{
+				Steps: []types.PlanStep{
+					{Description: "Use search_docs to find the guide.", ToolName: "search_docs"},
+					{Description: "Call print(x) then fetch_page.", ToolName: "fetch_page"},
+				},
+				Text: "- Use search_docs to find the guide.\n- Call print(x) then fetch_page.",
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := planner.ParsePlan(tt.text, tt.tools...)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParsePlan() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPlan_UnknownTools(t *testing.T) {
+	t.Parallel()
+
+	plan := &types.Plan{Steps: []types.PlanStep{
+		{ToolName: "search"},
+		{Description: "think"},
+		{ToolName: "delete_all"},
+		{ToolName: "search"},
+	}}
+	if diff := cmp.Diff([]string{"search", "delete_all"}, plan.ToolNames()); diff != "" {
+		t.Errorf("ToolNames() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"delete_all"}, plan.UnknownTools("search", "fetch")); diff != "" {
+		t.Errorf("UnknownTools() mismatch (-want +got):\n%s", diff)
+	}
+}
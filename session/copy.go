@@ -0,0 +1,148 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// deepCopy returns a copy of v sharing no pointer, slice nor map with it.
+//
+// The unexported fields of the structs are copied shallowly.
+func deepCopy[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	copyValue(dst, src)
+	return dst.Interface().(T)
+}
+
+// copyValue deep copies src into dst, which are of the same type.
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Elem().Type()))
+		copyValue(dst.Elem(), src.Elem())
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem())
+		dst.Set(elem)
+
+	case reflect.Struct:
+		dst.Set(src)
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Array:
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			copyValue(key, iter.Key())
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, iter.Value())
+			dst.SetMapIndex(key, value)
+		}
+
+	default:
+		dst.Set(src)
+	}
+}
+
+// truncateEvents returns the events of a copy with the given config, and whether the copy is
+// truncated before the last event.
+func truncateEvents(sessionID string, events []*types.Event, config *types.CopySessionConfig) ([]*types.Event, bool, error) {
+	if !config.Truncated() {
+		return events, false, nil
+	}
+
+	n := len(events)
+	if config.UntilEventID != "" {
+		n = -1
+		for i, event := range events {
+			if event.ID == config.UntilEventID {
+				n = i + 1
+				break
+			}
+		}
+		if n < 0 {
+			return nil, false, fmt.Errorf("event %s not found in session %s", config.UntilEventID, sessionID)
+		}
+	}
+	if !config.UntilTimestamp.IsZero() {
+		for i, event := range events[:n] {
+			if event.Timestamp.After(config.UntilTimestamp) {
+				n = i
+				break
+			}
+		}
+	}
+
+	return events[:n], n < len(events), nil
+}
+
+// replayState returns the session-scoped state after applying the state deltas of the events to
+// the initial state, merging the values of the keys with a merger.
+func replayState(initial map[string]any, events []*types.Event, mergers map[string]StateMerger) (map[string]any, error) {
+	state := deepCopy(initial)
+	if state == nil {
+		state = make(map[string]any)
+	}
+
+	for _, event := range events {
+		if event.Actions == nil {
+			continue
+		}
+		var sessionDelta map[string]any
+		for key, value := range event.Actions.StateDelta {
+			if isSessionKey(key) && !strings.HasPrefix(key, types.TempPrefix) {
+				if sessionDelta == nil {
+					sessionDelta = make(map[string]any)
+				}
+				sessionDelta[key] = value
+			}
+		}
+		delta, err := mergeStateDelta(sessionDelta, mergers, func(key string) any {
+			return state[key]
+		})
+		if err != nil {
+			return nil, fmt.Errorf("replay event %s: %w", event.ID, err)
+		}
+		for key, value := range delta {
+			state[key] = deepCopy(value)
+		}
+	}
+
+	return state, nil
+}
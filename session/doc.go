@@ -95,6 +95,12 @@
 //	event.Actions.StateDelta["temp:context"] = "discussing_weather"
 //	event.Actions.StateDelta["temp:step"] = 3
 //
+// The scoped setters of EventActions add the prefixes for you:
+//
+//	event.Actions.
+//		SetUserState("theme", "dark_mode").
+//		SetTempState("step", 3)
+//
 // # Integration with Agents
 //
 // Sessions integrate seamlessly with the agent system:
@@ -148,6 +154,14 @@
 //  4. State updates: Track conversation progress and preferences
 //  5. DeleteSession: Clean up completed conversations
 //
+// Retention jobs delete the sessions in bulk with DeleteSessions, selecting them by last update
+// time, session state or ID prefix. DryRun previews the number of sessions to delete:
+//
+//	n, err := service.DeleteSessions(ctx, appName, "", types.SessionFilter{
+//		UpdatedBefore: time.Now().AddDate(0, 0, -30),
+//		DryRun:        true,
+//	})
+//
 // # Persistence and Scaling
 //
 // The InMemoryService is suitable for development and small deployments.
@@ -180,6 +194,60 @@
 //	// State changes are applied when event is appended
 //	service.AppendEvent(ctx, session, event)
 //
+// # Concurrent State Updates
+//
+// By default the last appended delta wins, so that concurrent writers of the same key, such as
+// the branches of a ParallelAgent, may lose updates. An event computed from a known state
+// version is rejected with types.ErrStateConflict if one of its session-scoped keys was
+// written since that version:
+//
+//	base := ses.(types.StateVersioner).StateVersion()
+//	// ... read the state and compute the delta
+//	event.Actions.WithStateBaseVersion(base)
+//	if _, err := service.AppendEvent(ctx, ses, event); errors.Is(err, types.ErrStateConflict) {
+//		// reload the session and retry
+//	}
+//
+// Keys whose values can be combined in any order can instead be merged by the InMemoryService:
+//
+//	service := session.NewInMemoryService(
+//		session.WithStateMerger("visits", session.MergeSum),
+//		session.WithStateMerger("user:topics", session.MergeUnion),
+//	)
+//
+// # Branching Conversations
+//
+// CopySession forks a session into a new one, to explore an alternative continuation without
+// changing the original conversation:
+//
+//	fork, err := service.CopySession(ctx, appName, userID, sessionID, "")
+//
+// The fork can also start from an earlier point, keeping the events up to an event or a time
+// and the session state as it was then:
+//
+//	fork, err := service.CopySession(ctx, appName, userID, sessionID, "", types.WithCopyUntilEvent(eventID))
+//
+// The events and the session-scoped state are deep copied. The app and user scoped state is
+// shared by all the sessions of the user and is not copied.
+//
+// # Change Feed
+//
+// WatchEvents yields the events appended to a session, and WatchSessions the sessions created
+// and deleted, for the live views over the sessions without polling:
+//
+//	for event, err := range service.WatchEvents(ctx, appName, userID, sessionID) {
+//		var lag *types.WatchLagError
+//		if errors.As(err, &lag) {
+//			log.Printf("missed %d events", lag.Dropped)
+//			continue
+//		}
+//		render(event)
+//	}
+//
+// The changes are buffered for each watcher, so that AppendEvent never waits for a slow watcher;
+// the oldest changes are dropped once the buffer, sized with WithWatchBufferSize, is full. The
+// GCSService only signals the changes made through the same service.
+//
 // # Thread Safety
 //
 // The InMemoryService implementation is safe for concurrent use across multiple
@@ -202,7 +270,7 @@
 // # Best Practices
 //
 //  1. Use meaningful session IDs for debugging and tracing
-//  2. Clean up old sessions periodically to manage memory/storage
+//  2. Clean up old sessions periodically with DeleteSessions to manage memory/storage
 //  3. Use appropriate state prefixes (app:, user:, temp:)
 //  4. Include relevant context in events for replay/debugging
 //  5. Handle session not found errors gracefully
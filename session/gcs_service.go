@@ -0,0 +1,904 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"log/slog"
+	"maps"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/auth/credentials"
+	"cloud.google.com/go/storage"
+	"github.com/go-json-experiment/json"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+const (
+	// gcsSessionObject is the name of the object holding the session metadata and state.
+	gcsSessionObject = "session.json"
+
+	// gcsEventsDir is the directory holding one object per event.
+	gcsEventsDir = "events/"
+
+	// gcsStateObject is the name of the object holding the app or user scoped state.
+	gcsStateObject = "state.json"
+
+	// gcsTimestampKey is the object metadata key holding the event timestamp.
+	gcsTimestampKey = "timestamp"
+
+	// gcsDefaultMaxAppendRetries is the default number of retries of [GCSService.AppendEvent] on concurrent updates.
+	gcsDefaultMaxAppendRetries = 10
+
+	// gcsDownloadConcurrency is the number of concurrent event downloads.
+	gcsDownloadConcurrency = 8
+)
+
+// GCSService is a [types.SessionService] implementation using Google Cloud Storage (GCS).
+//
+// The objects are laid out in the bucket as follows:
+//
+//	{appName}/.app/state.json                         app scoped state
+//	{appName}/{userID}/.user/state.json               user scoped state
+//	{appName}/{userID}/{sessionID}/session.json       session metadata and state
+//	{appName}/{userID}/{sessionID}/events/{seq}.json  one object per event
+//
+// Event objects are named by a zero padded sequence number so that listing returns
+// them in the appended order, and carry the event timestamp as object metadata so
+// that [types.GetSessionConfig] filters only download the selected events.
+//
+// [GCSService.AppendEvent] is atomic: the event object is created with a does-not-exist
+// precondition and the session object is updated with a generation-match precondition,
+// retrying from the latest session on concurrent updates.
+type GCSService struct {
+	client        *storage.Client
+	bucket        *storage.BucketHandle
+	clientOptions []option.ClientOption
+	maxRetries    int
+	logger        *slog.Logger
+
+	// watches fans out the appended events and the session changes to the watchers.
+	watches watchHub
+}
+
+var _ types.SessionService = (*GCSService)(nil)
+
+// GCSServiceOption configures a [GCSService].
+type GCSServiceOption func(*GCSService)
+
+// WithStorageClient sets the storage client used by the [GCSService].
+//
+// The client is owned by the [GCSService] and closed by [GCSService.Close].
+func WithStorageClient(client *storage.Client) GCSServiceOption {
+	return func(s *GCSService) {
+		s.client = client
+	}
+}
+
+// WithClientOptions sets the options used to create the storage client of the [GCSService].
+//
+// They are ignored if the client is given by [WithStorageClient].
+func WithClientOptions(opts ...option.ClientOption) GCSServiceOption {
+	return func(s *GCSService) {
+		s.clientOptions = append(s.clientOptions, opts...)
+	}
+}
+
+// WithMaxAppendRetries sets the number of times [GCSService.AppendEvent] retries on concurrent updates.
+func WithMaxAppendRetries(n int) GCSServiceOption {
+	return func(s *GCSService) {
+		s.maxRetries = n
+	}
+}
+
+// WithLogger sets the logger of the [GCSService].
+func WithLogger(logger *slog.Logger) GCSServiceOption {
+	return func(s *GCSService) {
+		s.logger = logger
+	}
+}
+
+// NewGCSService creates a new [GCSService] storing the sessions in the given bucket.
+func NewGCSService(ctx context.Context, bucketName string, opts ...GCSServiceOption) (*GCSService, error) {
+	s := &GCSService{
+		maxRetries: gcsDefaultMaxAppendRetries,
+		logger:     slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.client == nil {
+		clientOpts := s.clientOptions
+		if len(clientOpts) == 0 {
+			creds, err := credentials.DetectDefault(&credentials.DetectOptions{
+				Scopes: []string{
+					storage.ScopeReadWrite,
+				},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("get credentials for storage: %w", err)
+			}
+			clientOpts = append(clientOpts, option.WithAuthCredentials(creds))
+		}
+
+		client, err := storage.NewGRPCClient(ctx, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("create storage client: %w", err)
+		}
+		s.client = client
+	}
+	s.bucket = s.client.Bucket(bucketName)
+
+	return s, nil
+}
+
+// gcsSessionRecord is the stored representation of a session.
+type gcsSessionRecord struct {
+	ID             string         `json:"id"`
+	AppName        string         `json:"app_name"`
+	UserID         string         `json:"user_id"`
+	State          map[string]any `json:"state"`
+	LastUpdateTime time.Time      `json:"last_update_time"`
+
+	// NextEventSeq is the sequence number of the next appended event.
+	NextEventSeq int64 `json:"next_event_seq"`
+
+	// StateVersion is the version of the session state, see [types.StateVersioner].
+	StateVersion int64 `json:"state_version,omitzero"`
+
+	// KeyVersions is the state version at which each session-scoped key was last written.
+	KeyVersions map[string]int64 `json:"key_versions,omitzero"`
+
+	// InitialState is the session-scoped state the session was created with, from which the
+	// state of a truncated copy is replayed.
+	InitialState map[string]any `json:"initial_state,omitzero"`
+}
+
+func (s *GCSService) appStateName(appName string) string {
+	return path.Join(appName, ".app", gcsStateObject)
+}
+
+func (s *GCSService) userStateName(appName, userID string) string {
+	return path.Join(appName, userID, ".user", gcsStateObject)
+}
+
+func (s *GCSService) sessionPrefix(appName, userID, sessionID string) string {
+	return path.Join(appName, userID, sessionID) + "/"
+}
+
+func (s *GCSService) sessionName(appName, userID, sessionID string) string {
+	return s.sessionPrefix(appName, userID, sessionID) + gcsSessionObject
+}
+
+func (s *GCSService) eventsPrefix(appName, userID, sessionID string) string {
+	return s.sessionPrefix(appName, userID, sessionID) + gcsEventsDir
+}
+
+func (s *GCSService) eventName(appName, userID, sessionID string, seq int64) string {
+	return fmt.Sprintf("%s%020d.json", s.eventsPrefix(appName, userID, sessionID), seq)
+}
+
+// CreateSession implements [types.SessionService].
+func (s *GCSService) CreateSession(ctx context.Context, appName, userID, sessionID string, state map[string]any) (types.Session, error) {
+	s.logger.InfoContext(ctx, "Creating session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("session_id", sessionID),
+	)
+
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+
+	appDelta, userDelta, sessionState := splitStateDelta(state)
+	if err := s.updateState(ctx, s.appStateName(appName), appDelta); err != nil {
+		return nil, err
+	}
+	if err := s.updateState(ctx, s.userStateName(appName, userID), userDelta); err != nil {
+		return nil, err
+	}
+
+	rec := &gcsSessionRecord{
+		ID:             sessionID,
+		AppName:        appName,
+		UserID:         userID,
+		State:          sessionState,
+		LastUpdateTime: time.Now(),
+		StateVersion:   1,
+		KeyVersions:    make(map[string]int64, len(sessionState)),
+		InitialState:   sessionState,
+	}
+	for key := range sessionState {
+		rec.KeyVersions[key] = rec.StateVersion
+	}
+	obj := s.bucket.Object(s.sessionName(appName, userID, sessionID)).If(storage.Conditions{DoesNotExist: true})
+	if err := s.writeJSON(ctx, obj, rec, nil); err != nil {
+		if isPreconditionFailed(err) {
+			return nil, fmt.Errorf("session %s already exists for user %s in app %s", sessionID, userID, appName)
+		}
+		return nil, fmt.Errorf("create session %s: %w", sessionID, err)
+	}
+	s.watches.publishSession(types.SessionCreated, appName, userID, sessionID)
+
+	ses := NewSession(appName, userID, sessionID, maps.Clone(rec.State), rec.LastUpdateTime)
+	ses.stateVersion = rec.StateVersion
+
+	return s.mergeState(ctx, ses)
+}
+
+// GetSession implements [types.SessionService].
+//
+// The [types.GetSessionConfig] filters are applied on the listed event objects, so only
+// the selected events are downloaded.
+func (s *GCSService) GetSession(ctx context.Context, appName, userID, sessionID string, config *types.GetSessionConfig) (types.Session, error) {
+	s.logger.InfoContext(ctx, "Getting session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("session_id", sessionID),
+	)
+
+	rec, _, err := s.readSession(ctx, appName, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		numRecentEvents int
+		after           time.Time
+	)
+	if config != nil {
+		numRecentEvents = config.NumRecentEvents
+		after = config.AfterTimestamp
+	}
+
+	events, err := s.loadEvents(ctx, appName, userID, sessionID, numRecentEvents, after)
+	if err != nil {
+		return nil, err
+	}
+
+	ses := NewSession(appName, userID, sessionID, rec.State, rec.LastUpdateTime)
+	ses.stateVersion = rec.StateVersion
+	ses.AddEvent(events...)
+
+	return s.mergeState(ctx, ses)
+}
+
+// ListSessions implements [types.SessionService].
+//
+// The returned sessions have no events nor state.
+func (s *GCSService) ListSessions(ctx context.Context, appName, userID string) ([]types.Session, error) {
+	s.logger.InfoContext(ctx, "Listing sessions",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+	)
+
+	it := s.bucket.Objects(ctx, &storage.Query{
+		Prefix:    path.Join(appName, userID) + "/",
+		MatchGlob: path.Join(appName, userID) + "/*/" + gcsSessionObject,
+	})
+
+	sessions := []types.Session{}
+	for {
+		attrs, err := it.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("list sessions: %w", err)
+		}
+		sessionID := path.Base(path.Dir(attrs.Name))
+		rec, _, err := s.readSession(ctx, appName, userID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, NewSession(appName, userID, sessionID, make(map[string]any), rec.LastUpdateTime))
+	}
+
+	return sessions, nil
+}
+
+// DeleteSession implements [types.SessionService].
+func (s *GCSService) DeleteSession(ctx context.Context, appName, userID, sessionID string) error {
+	s.logger.InfoContext(ctx, "Deleting session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("session_id", sessionID),
+	)
+
+	it := s.bucket.Objects(ctx, &storage.Query{
+		Prefix: s.sessionPrefix(appName, userID, sessionID),
+	})
+	deleted := false
+	for {
+		attrs, err := it.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return fmt.Errorf("list session objects: %w", err)
+		}
+
+		if err := s.bucket.Object(attrs.Name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("delete %s: %w", attrs.Name, err)
+		}
+		deleted = true
+	}
+	if deleted {
+		s.watches.publishSession(types.SessionDeleted, appName, userID, sessionID)
+	}
+
+	return nil
+}
+
+// DeleteSessions implements [types.SessionService].
+//
+// The ID prefix of a filter on the sessions of a user is pushed down to the listing of the
+// objects, and the session objects are only read for the other criteria.
+func (s *GCSService) DeleteSessions(ctx context.Context, appName, userID string, filter types.SessionFilter) (int, error) {
+	if filter.IsEmpty() {
+		return 0, types.ErrEmptySessionFilter
+	}
+
+	s.logger.InfoContext(ctx, "Deleting sessions",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.Bool("dry_run", filter.DryRun),
+	)
+
+	query := &storage.Query{
+		Prefix:    appName + "/",
+		MatchGlob: appName + "/*/*/" + gcsSessionObject,
+	}
+	if userID != "" {
+		query = &storage.Query{
+			Prefix:    path.Join(appName, userID) + "/" + filter.IDPrefix,
+			MatchGlob: path.Join(appName, userID) + "/*/" + gcsSessionObject,
+		}
+	}
+	readRecord := !filter.UpdatedBefore.IsZero() || len(filter.State) > 0
+
+	var selected []*session
+	it := s.bucket.Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return 0, fmt.Errorf("list sessions: %w", err)
+		}
+		sessionDir := path.Dir(attrs.Name)
+		user, sessionID := path.Base(path.Dir(sessionDir)), path.Base(sessionDir)
+
+		ses := NewSession(appName, user, sessionID, make(map[string]any), attrs.Updated)
+		if readRecord {
+			rec, _, err := s.readSession(ctx, appName, user, sessionID)
+			if err != nil {
+				return 0, err
+			}
+			ses = NewSession(appName, user, sessionID, rec.State, rec.LastUpdateTime)
+		}
+		if filter.Match(ses) {
+			selected = append(selected, ses)
+		}
+	}
+	if filter.DryRun {
+		return len(selected), nil
+	}
+
+	for i, ses := range selected {
+		if err := s.DeleteSession(ctx, appName, ses.UserID(), ses.ID()); err != nil {
+			return i, err
+		}
+	}
+
+	return len(selected), nil
+}
+
+// CopySession implements [types.SessionService].
+//
+// A truncated copy replays the state deltas of the copied events over the initial state of the
+// source session. The sessions stored before the initial state was recorded are replayed from an
+// empty state.
+func (s *GCSService) CopySession(ctx context.Context, appName, userID, srcSessionID, dstSessionID string, opts ...types.CopySessionOption) (types.Session, error) {
+	s.logger.InfoContext(ctx, "Copying session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("src_session_id", srcSessionID),
+		slog.String("dst_session_id", dstSessionID),
+	)
+
+	if dstSessionID == "" {
+		dstSessionID = uuid.New().String()
+	}
+
+	src, _, err := s.readSession(ctx, appName, userID, srcSessionID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.loadEvents(ctx, appName, userID, srcSessionID, 0, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	events, truncated, err := truncateEvents(srcSessionID, events, types.NewCopySessionConfig(opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &gcsSessionRecord{
+		ID:             dstSessionID,
+		AppName:        appName,
+		UserID:         userID,
+		State:          src.State,
+		LastUpdateTime: src.LastUpdateTime,
+		NextEventSeq:   int64(len(events)),
+		StateVersion:   1,
+		InitialState:   src.InitialState,
+	}
+	if truncated {
+		rec.State, err = replayState(src.InitialState, events, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(events) > 0 {
+			rec.LastUpdateTime = events[len(events)-1].Timestamp
+		}
+	}
+	rec.KeyVersions = make(map[string]int64, len(rec.State))
+	for key := range rec.State {
+		rec.KeyVersions[key] = rec.StateVersion
+	}
+
+	// Claim the session ID before writing the events, so that an existing session is left untouched.
+	obj := s.bucket.Object(s.sessionName(appName, userID, dstSessionID)).If(storage.Conditions{DoesNotExist: true})
+	if err := s.writeJSON(ctx, obj, rec, nil); err != nil {
+		if isPreconditionFailed(err) {
+			return nil, fmt.Errorf("session %s already exists for user %s in app %s", dstSessionID, userID, appName)
+		}
+		return nil, fmt.Errorf("create session %s: %w", dstSessionID, err)
+	}
+
+	eg, egctx := errgroup.WithContext(ctx)
+	eg.SetLimit(gcsDownloadConcurrency)
+	for seq, event := range events {
+		eg.Go(func() error {
+			metadata := map[string]string{
+				gcsTimestampKey: event.Timestamp.UTC().Format(time.RFC3339Nano),
+			}
+			eventObj := s.bucket.Object(s.eventName(appName, userID, dstSessionID, int64(seq)))
+			if err := s.writeJSON(egctx, eventObj, event, metadata); err != nil {
+				return fmt.Errorf("write event: %w", err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		if derr := s.DeleteSession(ctx, appName, userID, dstSessionID); derr != nil {
+			s.logger.WarnContext(ctx, "delete partial session copy", slog.String("session_id", dstSessionID), slog.Any("err", derr))
+		}
+		return nil, fmt.Errorf("copy session %s: %w", srcSessionID, err)
+	}
+
+	s.watches.publishSession(types.SessionCreated, appName, userID, dstSessionID)
+
+	ses := NewSession(appName, userID, dstSessionID, maps.Clone(rec.State), rec.LastUpdateTime)
+	ses.stateVersion = rec.StateVersion
+	ses.AddEvent(events...)
+
+	return s.mergeState(ctx, ses)
+}
+
+// AppendEvent implements [types.SessionService].
+//
+// Partial events are not persisted.
+//
+// If the event sets [types.EventActions.StateBaseVersion], the event is rejected with a
+// [*types.StateConflictError] when a session-scoped key of its delta was written after that
+// version. The app and user scoped keys are shared by the sessions and not checked.
+func (s *GCSService) AppendEvent(ctx context.Context, ses types.Session, event *types.Event) (*types.Event, error) {
+	if event.LLMResponse != nil && event.Partial {
+		return event, nil
+	}
+
+	appName := ses.AppName()
+	userID := ses.UserID()
+	sessionID := ses.ID()
+
+	s.logger.InfoContext(ctx, "Appending event to session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("session_id", sessionID),
+	)
+
+	if event.ID == "" {
+		event.ID = types.NewEventID()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	var (
+		stateDelta  map[string]any
+		baseVersion int64
+	)
+	if event.Actions != nil {
+		stateDelta = event.Actions.StateDelta
+		baseVersion = event.Actions.StateBaseVersion
+	}
+	appDelta, userDelta, sessionDelta := splitStateDelta(stateDelta)
+
+	prevVersion, version, err := s.appendEvent(ctx, appName, userID, sessionID, event, sessionDelta, baseVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateState(ctx, s.appStateName(appName), appDelta); err != nil {
+		return nil, err
+	}
+	if err := s.updateState(ctx, s.userStateName(appName, userID), userDelta); err != nil {
+		return nil, err
+	}
+	s.watches.publishEvent(appName, userID, sessionID, event)
+
+	// Update the provided session
+	ses.AddEvent(event)
+	ses.SetLastUpdateTime(event.Timestamp)
+	if provided, ok := ses.(*session); ok && provided.stateVersion == prevVersion {
+		// The provided session stays at its version if it missed updates of other copies of the session.
+		provided.stateVersion = version
+	}
+	for key, value := range stateDelta {
+		if !strings.HasPrefix(key, types.TempPrefix) {
+			ses.State()[key] = value
+		}
+	}
+
+	return event, nil
+}
+
+// appendEvent stores the event and updates the session object, retrying on concurrent updates.
+//
+// It returns the state versions of the session before and after the update.
+func (s *GCSService) appendEvent(ctx context.Context, appName, userID, sessionID string, event *types.Event, sessionDelta map[string]any, baseVersion int64) (prevVersion, version int64, err error) {
+	metadata := map[string]string{
+		gcsTimestampKey: event.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		rec, generation, err := s.readSession(ctx, appName, userID, sessionID)
+		if err != nil {
+			return 0, 0, err
+		}
+		if err := checkStateConflict(sessionID, sessionDelta, baseVersion, rec.StateVersion, rec.KeyVersions, nil); err != nil {
+			return 0, 0, err
+		}
+
+		// Claim the sequence number. A concurrent append of the same sequence number fails here.
+		eventObj := s.bucket.Object(s.eventName(appName, userID, sessionID, rec.NextEventSeq))
+		if err := s.writeJSON(ctx, eventObj.If(storage.Conditions{DoesNotExist: true}), event, metadata); err != nil {
+			if isPreconditionFailed(err) {
+				continue
+			}
+			return 0, 0, fmt.Errorf("write event: %w", err)
+		}
+
+		rec.NextEventSeq++
+		rec.LastUpdateTime = event.Timestamp
+		prevVersion = rec.StateVersion
+		if len(sessionDelta) > 0 {
+			rec.StateVersion++
+			if rec.KeyVersions == nil {
+				rec.KeyVersions = make(map[string]int64, len(sessionDelta))
+			}
+			for key := range sessionDelta {
+				rec.KeyVersions[key] = rec.StateVersion
+			}
+		}
+		maps.Copy(rec.State, sessionDelta)
+
+		sessionObj := s.bucket.Object(s.sessionName(appName, userID, sessionID)).If(storage.Conditions{GenerationMatch: generation})
+		if err := s.writeJSON(ctx, sessionObj, rec, nil); err != nil {
+			// Release the claimed sequence number so the session stays consistent.
+			if derr := eventObj.Delete(ctx); derr != nil && !errors.Is(derr, storage.ErrObjectNotExist) {
+				s.logger.WarnContext(ctx, "delete orphaned event", slog.String("name", eventObj.ObjectName()), slog.Any("err", derr))
+			}
+			if isPreconditionFailed(err) {
+				continue
+			}
+			return 0, 0, fmt.Errorf("update session: %w", err)
+		}
+
+		return prevVersion, rec.StateVersion, nil
+	}
+
+	return 0, 0, fmt.Errorf("append event to session %s: gave up after %d concurrent updates", sessionID, s.maxRetries+1)
+}
+
+// ListEvents implements [types.SessionService].
+func (s *GCSService) ListEvents(ctx context.Context, appName, userID, sessionID string, maxEvents int, since *time.Time) ([]types.Event, error) {
+	var after time.Time
+	if since != nil {
+		after = *since
+	}
+
+	events, err := s.loadEvents(ctx, appName, userID, sessionID, maxEvents, after)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Event, len(events))
+	for i, event := range events {
+		result[i] = *event
+	}
+
+	return result, nil
+}
+
+// WatchEvents implements [types.SessionService].
+//
+// Only the events appended through this service are yielded, not those appended by other
+// processes sharing the bucket.
+func (s *GCSService) WatchEvents(ctx context.Context, appName, userID, sessionID string) iter.Seq2[*types.Event, error] {
+	return s.watches.watchEvents(ctx, appName, userID, sessionID)
+}
+
+// WatchSessions implements [types.SessionService].
+//
+// Only the sessions created and deleted through this service are signaled, not those of other
+// processes sharing the bucket.
+func (s *GCSService) WatchSessions(ctx context.Context, appName, userID string) iter.Seq2[*types.SessionChange, error] {
+	return s.watches.watchSessions(ctx, appName, userID)
+}
+
+// Ping implements [types.SessionService].
+//
+// It lists at most one object of the bucket, which checks that the bucket exists and that the
+// credentials may read it.
+func (s *GCSService) Ping(ctx context.Context) error {
+	it := s.bucket.Objects(ctx, &storage.Query{})
+	it.PageInfo().MaxSize = 1
+	if _, err := it.Next(); err != nil && !errors.Is(err, iterator.Done) {
+		return &types.UnhealthyError{Service: "session.GCSService", Err: err}
+	}
+	return nil
+}
+
+// Close releases the storage client.
+func (s *GCSService) Close() error {
+	return s.client.Close()
+}
+
+// readSession reads the session object and returns it with its generation.
+func (s *GCSService) readSession(ctx context.Context, appName, userID, sessionID string) (*gcsSessionRecord, int64, error) {
+	rec := new(gcsSessionRecord)
+	generation, err := s.readJSON(ctx, s.bucket.Object(s.sessionName(appName, userID, sessionID)), rec)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, 0, fmt.Errorf("session %s not found for user %s in app %s", sessionID, userID, appName)
+		}
+		return nil, 0, fmt.Errorf("read session %s: %w", sessionID, err)
+	}
+	if rec.State == nil {
+		rec.State = make(map[string]any)
+	}
+
+	return rec, generation, nil
+}
+
+// loadEvents lists the event objects of the session, selects the events appended after
+// the given time and the last numRecentEvents of them, and downloads only the selected events.
+func (s *GCSService) loadEvents(ctx context.Context, appName, userID, sessionID string, numRecentEvents int, after time.Time) ([]*types.Event, error) {
+	query := &storage.Query{
+		Prefix: s.eventsPrefix(appName, userID, sessionID),
+	}
+	if after.IsZero() {
+		if err := query.SetAttrSelection([]string{"Name"}); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := query.SetAttrSelection([]string{"Name", "Metadata"}); err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+	it := s.bucket.Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err != nil {
+			if errors.Is(err, iterator.Done) {
+				break
+			}
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+
+		if !after.IsZero() {
+			ts, err := time.Parse(time.RFC3339Nano, attrs.Metadata[gcsTimestampKey])
+			if err == nil && !ts.After(after) {
+				continue
+			}
+		}
+		names = append(names, attrs.Name)
+	}
+
+	if numRecentEvents > 0 && len(names) > numRecentEvents {
+		names = names[len(names)-numRecentEvents:]
+	}
+
+	events := make([]*types.Event, len(names))
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.SetLimit(gcsDownloadConcurrency)
+	for i, name := range names {
+		eg.Go(func() error {
+			event := new(types.Event)
+			if _, err := s.readJSON(ctx, s.bucket.Object(name), event); err != nil {
+				return fmt.Errorf("read event %s: %w", name, err)
+			}
+			events[i] = event
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// updateState applies the delta to the state object, retrying on concurrent updates.
+func (s *GCSService) updateState(ctx context.Context, name string, delta map[string]any) error {
+	if len(delta) == 0 {
+		return nil
+	}
+
+	for range s.maxRetries + 1 {
+		state, generation, err := s.readState(ctx, name)
+		if err != nil {
+			return err
+		}
+		maps.Copy(state, delta)
+
+		cond := storage.Conditions{GenerationMatch: generation}
+		if generation == 0 {
+			cond = storage.Conditions{DoesNotExist: true}
+		}
+		if err := s.writeJSON(ctx, s.bucket.Object(name).If(cond), state, nil); err != nil {
+			if isPreconditionFailed(err) {
+				continue
+			}
+			return fmt.Errorf("write state %s: %w", name, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("update state %s: gave up after %d concurrent updates", name, s.maxRetries+1)
+}
+
+// readState reads the state object and returns it with its generation.
+//
+// A missing object is reported as an empty state with the zero generation.
+func (s *GCSService) readState(ctx context.Context, name string) (map[string]any, int64, error) {
+	state := make(map[string]any)
+	generation, err := s.readJSON(ctx, s.bucket.Object(name), &state)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return make(map[string]any), 0, nil
+		}
+		return nil, 0, fmt.Errorf("read state %s: %w", name, err)
+	}
+	if state == nil {
+		state = make(map[string]any)
+	}
+
+	return state, generation, nil
+}
+
+// mergeState merges app and user state into the session state.
+func (s *GCSService) mergeState(ctx context.Context, ses *session) (types.Session, error) {
+	appState, _, err := s.readState(ctx, s.appStateName(ses.AppName()))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range appState {
+		ses.State()[types.AppPrefix+key] = value
+	}
+
+	userState, _, err := s.readState(ctx, s.userStateName(ses.AppName(), ses.UserID()))
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range userState {
+		ses.State()[types.UserPrefix+key] = value
+	}
+
+	return ses, nil
+}
+
+// readJSON decodes the object into v and returns the generation of the object read.
+func (s *GCSService) readJSON(ctx context.Context, obj *storage.ObjectHandle, v any) (int64, error) {
+	r, err := obj.NewReader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(data, v, json.DefaultOptionsV2()); err != nil {
+		return 0, err
+	}
+
+	return r.Attrs.Generation, nil
+}
+
+// writeJSON encodes v into the object with the given custom metadata.
+func (s *GCSService) writeJSON(ctx context.Context, obj *storage.ObjectHandle, v any, metadata map[string]string) error {
+	data, err := json.Marshal(v, json.DefaultOptionsV2())
+	if err != nil {
+		return err
+	}
+
+	w := obj.NewWriter(ctx)
+	w.ContentType = "application/json"
+	w.Metadata = metadata
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// splitStateDelta splits the state delta into the app, user and session scoped deltas.
+//
+// The app and user prefixes are trimmed and temporary keys are dropped.
+func splitStateDelta(delta map[string]any) (appDelta, userDelta, sessionDelta map[string]any) {
+	appDelta = make(map[string]any)
+	userDelta = make(map[string]any)
+	sessionDelta = make(map[string]any)
+
+	for key, value := range delta {
+		switch {
+		case strings.HasPrefix(key, types.AppPrefix):
+			appDelta[strings.TrimPrefix(key, types.AppPrefix)] = value
+		case strings.HasPrefix(key, types.UserPrefix):
+			userDelta[strings.TrimPrefix(key, types.UserPrefix)] = value
+		case strings.HasPrefix(key, types.TempPrefix):
+			// not persisted
+		default:
+			sessionDelta[key] = value
+		}
+	}
+
+	return appDelta, userDelta, sessionDelta
+}
+
+// isPreconditionFailed reports whether err is a failed GCS precondition for both the JSON and gRPC APIs.
+func isPreconditionFailed(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusPreconditionFailed
+	}
+	code := status.Code(err)
+	return code == codes.FailedPrecondition || code == codes.Aborted
+}
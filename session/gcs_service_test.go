@@ -0,0 +1,547 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/go-json-experiment/json"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// fakeObject is an object of a [fakeGCS] bucket.
+type fakeObject struct {
+	data        []byte
+	contentType string
+	metadata    map[string]string
+	generation  int64
+	updated     time.Time
+}
+
+// fakeGCS is an in-memory bucket serving the subset of the GCS JSON API used by the storage
+// client: the multipart uploads, the media downloads, the object listings and the deletions, with
+// the generation preconditions.
+type fakeGCS struct {
+	bucket string
+
+	mu         sync.Mutex
+	objects    map[string]*fakeObject
+	generation int64
+}
+
+const (
+	fakeObjectsPath = "/storage/v1/b/%s/o"
+	fakeUploadPath  = "/upload/storage/v1/b/%s/o"
+)
+
+func (f *fakeGCS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	objects := fmt.Sprintf(fakeObjectsPath, f.bucket)
+	escaped := r.URL.EscapedPath()
+	switch {
+	case escaped == fmt.Sprintf(fakeUploadPath, f.bucket) && r.Method == http.MethodPost:
+		f.upload(w, r)
+	case escaped == objects && r.Method == http.MethodGet:
+		f.list(w, r)
+	case strings.HasPrefix(escaped, objects+"/"):
+		name, err := url.PathUnescape(strings.TrimPrefix(escaped, objects+"/"))
+		if err != nil {
+			writeFakeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			f.get(w, r, name)
+		case http.MethodDelete:
+			f.delete(w, r, name)
+		default:
+			writeFakeError(w, http.StatusNotImplemented, r.Method+" "+escaped)
+		}
+	default:
+		writeFakeError(w, http.StatusNotImplemented, r.Method+" "+escaped)
+	}
+}
+
+// checkGeneration reports whether the ifGenerationMatch precondition of the request holds for the
+// object, zero matching a missing object.
+func checkGeneration(r *http.Request, obj *fakeObject) bool {
+	want := r.URL.Query().Get("ifGenerationMatch")
+	if want == "" {
+		return true
+	}
+	var gen int64
+	if obj != nil {
+		gen = obj.generation
+	}
+	return want == strconv.FormatInt(gen, 10)
+}
+
+func (f *fakeGCS) upload(w http.ResponseWriter, r *http.Request) {
+	if typ := r.URL.Query().Get("uploadType"); typ != "multipart" {
+		writeFakeError(w, http.StatusNotImplemented, "upload type "+typ)
+		return
+	}
+	_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	mr := multipart.NewReader(r.Body, params["boundary"])
+	var attrs struct {
+		Name        string            `json:"name"`
+		ContentType string            `json:"contentType"`
+		Metadata    map[string]string `json:"metadata"`
+	}
+	part, err := mr.NextPart()
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := json.UnmarshalRead(part, &attrs, json.RejectUnknownMembers(false)); err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	part, err = mr.NextPart()
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	data, err := io.ReadAll(part)
+	if err != nil {
+		writeFakeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !checkGeneration(r, f.objects[attrs.Name]) {
+		writeFakeError(w, http.StatusPreconditionFailed, "precondition failed")
+		return
+	}
+	f.generation++
+	obj := &fakeObject{
+		data:        data,
+		contentType: attrs.ContentType,
+		metadata:    attrs.Metadata,
+		generation:  f.generation,
+		updated:     time.Now(),
+	}
+	f.objects[attrs.Name] = obj
+	writeFakeJSON(w, f.resource(attrs.Name, obj))
+}
+
+func (f *fakeGCS) get(w http.ResponseWriter, r *http.Request, name string) {
+	f.mu.Lock()
+	obj, ok := f.objects[name]
+	f.mu.Unlock()
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "no such object: "+name)
+		return
+	}
+	if r.URL.Query().Get("alt") != "media" {
+		writeFakeJSON(w, f.resource(name, obj))
+		return
+	}
+
+	w.Header().Set("Content-Type", obj.contentType)
+	w.Header().Set("X-Goog-Generation", strconv.FormatInt(obj.generation, 10))
+	for key, value := range obj.metadata {
+		w.Header().Set("X-Goog-Meta-"+key, value)
+	}
+	w.Write(obj.data)
+}
+
+func (f *fakeGCS) delete(w http.ResponseWriter, r *http.Request, name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	obj, ok := f.objects[name]
+	if !ok {
+		writeFakeError(w, http.StatusNotFound, "no such object: "+name)
+		return
+	}
+	if !checkGeneration(r, obj) {
+		writeFakeError(w, http.StatusPreconditionFailed, "precondition failed")
+		return
+	}
+	delete(f.objects, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeGCS) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	glob := r.URL.Query().Get("matchGlob")
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for name := range f.objects {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if glob != "" {
+			if ok, _ := path.Match(glob, name); !ok {
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	items := make([]map[string]any, len(names))
+	for i, name := range names {
+		items[i] = f.resource(name, f.objects[name])
+	}
+	writeFakeJSON(w, map[string]any{"kind": "storage#objects", "items": items})
+}
+
+// resource returns the JSON API resource of the object.
+func (f *fakeGCS) resource(name string, obj *fakeObject) map[string]any {
+	return map[string]any{
+		"kind":        "storage#object",
+		"bucket":      f.bucket,
+		"name":        name,
+		"generation":  strconv.FormatInt(obj.generation, 10),
+		"size":        strconv.Itoa(len(obj.data)),
+		"contentType": obj.contentType,
+		"metadata":    obj.metadata,
+		"updated":     obj.updated.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// names returns the sorted names of the objects of the bucket.
+func (f *fakeGCS) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.objects))
+	for name := range f.objects {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func writeFakeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.MarshalWrite(w, v)
+}
+
+func writeFakeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.MarshalWrite(w, map[string]any{"error": map[string]any{"code": code, "message": message}})
+}
+
+// newFakeGCSService returns a [session.GCSService] storing its sessions in a fake bucket.
+func newFakeGCSService(t *testing.T, opts ...session.GCSServiceOption) (*session.GCSService, *fakeGCS) {
+	t.Helper()
+
+	fake := &fakeGCS{bucket: "sessions", objects: make(map[string]*fakeObject)}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	ctx := t.Context()
+	client, err := storage.NewClient(ctx,
+		option.WithEndpoint(srv.URL+"/storage/v1/"),
+		option.WithoutAuthentication(),
+		storage.WithJSONReads(),
+	)
+	if err != nil {
+		t.Fatalf("storage.NewClient() error = %v", err)
+	}
+	opts = append([]session.GCSServiceOption{
+		session.WithStorageClient(client),
+		session.WithLogger(slog.New(slog.DiscardHandler)),
+	}, opts...)
+	svc, err := session.NewGCSService(ctx, fake.bucket, opts...)
+	if err != nil {
+		t.Fatalf("NewGCSService() error = %v", err)
+	}
+	t.Cleanup(func() { svc.Close() })
+
+	return svc, fake
+}
+
+func eventTexts(events []*types.Event) []string {
+	texts := make([]string, len(events))
+	for i, event := range events {
+		texts[i] = event.Content.Parts[0].Text
+	}
+	return texts
+}
+
+func TestGCSService(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc, fake := newFakeGCSService(t)
+
+	ses, err := svc.CreateSession(ctx, "app", "user", "s1", map[string]any{
+		"topic":                  "go",
+		types.AppPrefix + "env":  "prod",
+		types.UserPrefix + "tz":  "UTC",
+		types.TempPrefix + "tmp": "dropped",
+	})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	wantState := map[string]any{"topic": "go", types.AppPrefix + "env": "prod", types.UserPrefix + "tz": "UTC"}
+	if diff := cmp.Diff(wantState, ses.State()); diff != "" {
+		t.Errorf("CreateSession() state mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := svc.CreateSession(ctx, "app", "user", "s1", nil); err == nil {
+		t.Error("CreateSession() of an existing session succeeded")
+	}
+
+	for _, text := range []string{"one", "two", "three"} {
+		event := textEvent(text).WithActions(types.NewEventActions().WithStateDelta(map[string]any{"last": text}))
+		if _, err := svc.AppendEvent(ctx, ses, event); err != nil {
+			t.Fatalf("AppendEvent(%s) error = %v", text, err)
+		}
+	}
+	partial := textEvent("partial")
+	partial.Partial = true
+	if _, err := svc.AppendEvent(ctx, ses, partial); err != nil {
+		t.Fatalf("AppendEvent() of a partial event error = %v", err)
+	}
+
+	got, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"one", "two", "three"}, eventTexts(got.Events())); diff != "" {
+		t.Errorf("GetSession() events mismatch (-want +got):\n%s", diff)
+	}
+	wantState["last"] = "three"
+	if diff := cmp.Diff(wantState, got.State()); diff != "" {
+		t.Errorf("GetSession() state mismatch (-want +got):\n%s", diff)
+	}
+
+	recent, err := svc.GetSession(ctx, "app", "user", "s1", &types.GetSessionConfig{NumRecentEvents: 2})
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"two", "three"}, eventTexts(recent.Events())); diff != "" {
+		t.Errorf("GetSession() recent events mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := svc.CreateSession(ctx, "app", "user", "s2", nil); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	sessions, err := svc.ListSessions(ctx, "app", "user")
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	ids := make([]string, len(sessions))
+	for i, ses := range sessions {
+		ids[i] = ses.ID()
+	}
+	if diff := cmp.Diff([]string{"s1", "s2"}, ids); diff != "" {
+		t.Errorf("ListSessions() mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := svc.DeleteSession(ctx, "app", "user", "s1"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if _, err := svc.GetSession(ctx, "app", "user", "s1", nil); err == nil {
+		t.Error("GetSession() of a deleted session succeeded")
+	}
+	for _, name := range fake.names() {
+		if strings.HasPrefix(name, "app/user/s1/") {
+			t.Errorf("object %s of the deleted session left in the bucket", name)
+		}
+	}
+	// The app and user scoped state outlives the sessions.
+	s2, err := svc.GetSession(ctx, "app", "user", "s2", nil)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	if s2.State()[types.AppPrefix+"env"] != "prod" || s2.State()[types.UserPrefix+"tz"] != "UTC" {
+		t.Errorf("GetSession() state = %v, want the app and user scoped state", s2.State())
+	}
+
+	if err := svc.Ping(ctx); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+}
+
+func TestGCSServiceAppendEventStateConflict(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc, _ := newFakeGCSService(t)
+
+	ses, err := svc.CreateSession(ctx, "app", "user", "s1", map[string]any{"count": 1})
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	base := stateVersion(t, ses)
+	if _, err := svc.AppendEvent(ctx, ses, stateEvent(base, map[string]any{"count": 2})); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+
+	stale, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	_, err = svc.AppendEvent(ctx, stale, stateEvent(base, map[string]any{"count": 3}))
+	if !errors.Is(err, types.ErrStateConflict) {
+		t.Fatalf("AppendEvent() with a stale base version error = %v, want %v", err, types.ErrStateConflict)
+	}
+	var conflict *types.StateConflictError
+	if !errors.As(err, &conflict) || !slices.Contains(conflict.Keys, "count") {
+		t.Errorf("AppendEvent() error = %v, want a conflict on count", err)
+	}
+
+	// A delta of another key does not conflict.
+	if _, err := svc.AppendEvent(ctx, stale, stateEvent(base, map[string]any{"other": true})); err != nil {
+		t.Errorf("AppendEvent() of another key error = %v", err)
+	}
+}
+
+func TestGCSServiceConcurrentAppendEvent(t *testing.T) {
+	t.Parallel()
+
+	const n = 8
+	ctx := t.Context()
+	// Each writer may lose the race to all the others, several times.
+	svc, _ := newFakeGCSService(t, session.WithMaxAppendRetries(4*n))
+
+	if _, err := svc.CreateSession(ctx, "app", "user", "s1", nil); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each writer appends through its own copy of the session, as separate processes do.
+			ses, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			_, errs[i] = svc.AppendEvent(ctx, ses, textEvent(strconv.Itoa(i)))
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("AppendEvent(%d) error = %v", i, err)
+		}
+	}
+
+	got, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	texts := eventTexts(got.Events())
+	slices.Sort(texts)
+	want := make([]string, n)
+	for i := range n {
+		want[i] = strconv.Itoa(i)
+	}
+	if diff := cmp.Diff(want, texts); diff != "" {
+		t.Errorf("GetSession() events mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGCSServiceDeleteSessions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		userID    string
+		filter    types.SessionFilter
+		want      int
+		wantNames []string
+	}{
+		"numeric state": {
+			userID:    "user",
+			filter:    types.SessionFilter{State: map[string]any{"tier": 1}},
+			want:      1,
+			wantNames: []string{"app/other/s3/session.json", "app/user/s2/session.json"},
+		},
+		"numeric state of all users": {
+			filter:    types.SessionFilter{State: map[string]any{"tier": 1}},
+			want:      2,
+			wantNames: []string{"app/user/s2/session.json"},
+		},
+		"nested state": {
+			userID:    "user",
+			filter:    types.SessionFilter{State: map[string]any{"limits": map[string]any{"rpm": 60}}},
+			want:      1,
+			wantNames: []string{"app/other/s3/session.json", "app/user/s2/session.json"},
+		},
+		"ID prefix": {
+			userID:    "user",
+			filter:    types.SessionFilter{IDPrefix: "s2"},
+			want:      1,
+			wantNames: []string{"app/other/s3/session.json", "app/user/s1/session.json"},
+		},
+		"dry run": {
+			userID:    "user",
+			filter:    types.SessionFilter{State: map[string]any{"tier": 1}, DryRun: true},
+			want:      1,
+			wantNames: []string{"app/other/s3/session.json", "app/user/s1/session.json", "app/user/s2/session.json"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := t.Context()
+			gcs, fake := newFakeGCSService(t)
+			services := map[string]types.SessionService{
+				"gcs":       gcs,
+				"in-memory": session.NewInMemoryService(),
+			}
+			for svcName, svc := range services {
+				for _, s := range []struct {
+					userID, sessionID string
+					state             map[string]any
+				}{
+					{"user", "s1", map[string]any{"tier": 1, "limits": map[string]any{"rpm": 60}}},
+					{"user", "s2", map[string]any{"tier": 2}},
+					{"other", "s3", map[string]any{"tier": 1}},
+				} {
+					if _, err := svc.CreateSession(ctx, "app", s.userID, s.sessionID, s.state); err != nil {
+						t.Fatalf("%s: CreateSession() error = %v", svcName, err)
+					}
+				}
+
+				// Both backends select the same sessions, the GCS one decoding the numbers as float64.
+				got, err := svc.DeleteSessions(ctx, "app", tt.userID, tt.filter)
+				if err != nil {
+					t.Fatalf("%s: DeleteSessions() error = %v", svcName, err)
+				}
+				if got != tt.want {
+					t.Errorf("%s: DeleteSessions() = %d, want %d", svcName, got, tt.want)
+				}
+			}
+			if diff := cmp.Diff(tt.wantNames, fake.names()); diff != "" {
+				t.Errorf("objects left mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
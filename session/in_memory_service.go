@@ -6,14 +6,13 @@ package session
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log/slog"
 	"maps"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/google/uuid"
-
 	"github.com/go-a2a/adk-go/types"
 )
 
@@ -30,20 +29,72 @@ type InMemoryService struct {
 	// appState is a map from app name to a map from key to value.
 	appState map[string]map[string]any
 
+	// mergers is a map from state key to the merger of its values.
+	mergers map[string]StateMerger
+
+	clock types.Clock
+	ids   types.IDGenerator
+
+	// watches fans out the appended events and the session changes to the watchers.
+	watches watchHub
+
 	logger *slog.Logger
 	mu     sync.RWMutex
 }
 
 var _ types.SessionService = (*InMemoryService)(nil)
 
+// InMemoryServiceOption configures an [InMemoryService].
+type InMemoryServiceOption func(*InMemoryService)
+
+// WithStateMerger sets the merger of the values of a state key, such as [MergeSum] or [MergeUnion].
+//
+// The value of the key in a state delta is then merged with the current value instead of
+// replacing it, and the key is never reported as a state conflict. The key includes its scope
+// prefix, if any.
+func WithStateMerger(key string, merger StateMerger) InMemoryServiceOption {
+	return func(s *InMemoryService) {
+		s.mergers[key] = merger
+	}
+}
+
+// WithClock sets the [types.Clock] telling the creation time of the sessions.
+func WithClock(clock types.Clock) InMemoryServiceOption {
+	return func(s *InMemoryService) {
+		s.clock = clock
+	}
+}
+
+// WithIDGenerator sets the [types.IDGenerator] generating the IDs of the sessions created without an ID.
+func WithIDGenerator(ids types.IDGenerator) InMemoryServiceOption {
+	return func(s *InMemoryService) {
+		s.ids = ids
+	}
+}
+
+// WithWatchBufferSize sets the number of changes buffered for each watcher of
+// [InMemoryService.WatchEvents] and [InMemoryService.WatchSessions] before the oldest ones are
+// dropped. Zero or less means [DefaultWatchBufferSize].
+func WithWatchBufferSize(n int) InMemoryServiceOption {
+	return func(s *InMemoryService) {
+		s.watches.bufferSize = n
+	}
+}
+
 // NewInMemoryService creates a new [InMemoryService].
-func NewInMemoryService() *InMemoryService {
+func NewInMemoryService(opts ...InMemoryServiceOption) *InMemoryService {
 	s := &InMemoryService{
 		sessions:  make(map[string]map[string]map[string]types.Session),
 		userState: make(map[string]map[string]map[string]any),
 		appState:  make(map[string]map[string]any),
+		mergers:   make(map[string]StateMerger),
+		clock:     types.SystemClock{},
+		ids:       types.RandomIDGenerator{},
 		logger:    slog.Default(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
 
 	return s
 }
@@ -60,14 +111,20 @@ func (s *InMemoryService) CreateSession(ctx context.Context, appName, userID, se
 	)
 
 	if sessionID == "" {
-		sessionID = uuid.New().String()
+		sessionID = s.ids.NewSessionID()
 	}
 
 	if state == nil {
 		state = make(map[string]any)
 	}
 
-	ses := NewSession(appName, userID, sessionID, state, time.Now())
+	ses := NewSession(appName, userID, sessionID, state, s.clock.Now())
+	ses.stateVersion = 1
+	ses.keyVersions = make(map[string]int64, len(state))
+	for key := range state {
+		ses.keyVersions[key] = ses.stateVersion
+	}
+	ses.initialState = deepCopy(state)
 
 	if _, ok := s.sessions[appName]; !ok {
 		s.sessions[appName] = make(map[string]map[string]types.Session)
@@ -77,6 +134,7 @@ func (s *InMemoryService) CreateSession(ctx context.Context, appName, userID, se
 	}
 
 	s.sessions[appName][userID][sessionID] = ses
+	s.watches.publishSession(types.SessionCreated, appName, userID, sessionID)
 
 	// Deep copy the session to avoid modifying the stored one
 	copiedSession := s.copySession(ses)
@@ -105,13 +163,13 @@ func (s *InMemoryService) GetSession(ctx context.Context, appName, userID, sessi
 		return nil, fmt.Errorf("session %s not found for user %s in app %s", sessionID, userID, appName)
 	}
 
-	session := s.sessions[appName][userID][sessionID]
-	copiedSession := s.copySession(session).(*InMemoryService)
+	storedSession := s.sessions[appName][userID][sessionID]
+	copiedSession := s.copySession(storedSession)
 
 	if config != nil {
 		// Filter events based on config
 		if config.NumRecentEvents > 0 {
-			copiedSession.AddEvent(copiedSession.session.GetRecentEvents(config.NumRecentEvents)...)
+			copiedSession.events = copiedSession.GetRecentEvents(config.NumRecentEvents)
 		}
 		// if !config.AfterTimestamp.IsZero() {
 		// 	copiedSession.AddEvent(copiedSession.GetEventsAfter(config.AfterTimestamp))
@@ -171,11 +229,119 @@ func (s *InMemoryService) DeleteSession(ctx context.Context, appName, userID, se
 	}
 
 	delete(s.sessions[appName][userID], sessionID)
+	s.watches.publishSession(types.SessionDeleted, appName, userID, sessionID)
 	return nil
 }
 
+// DeleteSessions deletes the sessions selected by the filter, scanning the sessions of the user,
+// or of all the users of the application if userID is empty.
+func (s *InMemoryService) DeleteSessions(ctx context.Context, appName, userID string, filter types.SessionFilter) (int, error) {
+	if filter.IsEmpty() {
+		return 0, types.ErrEmptySessionFilter
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "Deleting sessions",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.Bool("dry_run", filter.DryRun),
+	)
+
+	n := 0
+	for user, sessions := range s.sessions[appName] {
+		if userID != "" && user != userID {
+			continue
+		}
+		for sessionID, ses := range sessions {
+			if !filter.Match(ses) {
+				continue
+			}
+			n++
+			if filter.DryRun {
+				continue
+			}
+			delete(sessions, sessionID)
+			s.watches.publishSession(types.SessionDeleted, appName, user, sessionID)
+		}
+	}
+
+	return n, nil
+}
+
+// CopySession copies a session to a new session.
+//
+// A truncated copy replays the state deltas of the copied events over the initial state of the
+// source session, with the mergers of the service.
+func (s *InMemoryService) CopySession(ctx context.Context, appName, userID, srcSessionID, dstSessionID string, opts ...types.CopySessionOption) (types.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.logger.InfoContext(ctx, "Copying session",
+		slog.String("app_name", appName),
+		slog.String("user_id", userID),
+		slog.String("src_session_id", srcSessionID),
+		slog.String("dst_session_id", dstSessionID),
+	)
+
+	src, ok := s.sessions[appName][userID][srcSessionID].(*session)
+	if !ok {
+		return nil, fmt.Errorf("session %s not found for user %s in app %s", srcSessionID, userID, appName)
+	}
+	if dstSessionID == "" {
+		dstSessionID = s.ids.NewSessionID()
+	}
+	if _, ok := s.sessions[appName][userID][dstSessionID]; ok {
+		return nil, fmt.Errorf("session %s already exists for user %s in app %s", dstSessionID, userID, appName)
+	}
+
+	events, truncated, err := truncateEvents(srcSessionID, src.events, types.NewCopySessionConfig(opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	state := deepCopy(src.state)
+	if truncated {
+		state, err = replayState(src.initialState, events, s.mergers)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lastUpdateTime := src.lastUpdateTime
+	if truncated && len(events) > 0 {
+		lastUpdateTime = events[len(events)-1].Timestamp
+	}
+
+	dst := NewSession(appName, userID, dstSessionID, state, lastUpdateTime)
+	dst.events = deepCopy(events)
+	dst.stateVersion = 1
+	dst.keyVersions = make(map[string]int64, len(state))
+	for key := range state {
+		dst.keyVersions[key] = dst.stateVersion
+	}
+	dst.initialState = deepCopy(src.initialState)
+
+	s.sessions[appName][userID][dstSessionID] = dst
+	s.watches.publishSession(types.SessionCreated, appName, userID, dstSessionID)
+
+	return s.mergeState(appName, userID, s.copySession(dst)), nil
+}
+
 // AppendEvent appends an event to a session.
+//
+// Partial events are not persisted.
+//
+// The state delta of the event is applied to the stored state, and to the provided session
+// except for the temporary keys. If the event sets [types.EventActions.StateBaseVersion], the
+// event is rejected with a [*types.StateConflictError] when a session-scoped key of its delta was
+// written after that version, and nothing is applied.
 func (s *InMemoryService) AppendEvent(ctx context.Context, ses types.Session, event *types.Event) (*types.Event, error) {
+	if event.LLMResponse != nil && event.Partial {
+		return event, nil
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -189,55 +355,124 @@ func (s *InMemoryService) AppendEvent(ctx context.Context, ses types.Session, ev
 		slog.String("session_id", sessionID),
 	)
 
+	// Update only the provided session if the stored session does not exist
+	storedSession, ok := s.sessions[appName][userID][sessionID].(*session)
+	if !ok {
+		ses.AddEvent(event)
+		ses.SetLastUpdateTime(event.Timestamp)
+		return event, nil
+	}
+
+	var (
+		stateDelta  map[string]any
+		baseVersion int64
+	)
+	if event.Actions != nil {
+		stateDelta = event.Actions.StateDelta
+		baseVersion = event.Actions.StateBaseVersion
+	}
+	if err := checkStateConflict(sessionID, stateDelta, baseVersion, storedSession.stateVersion, storedSession.keyVersions, s.mergers); err != nil {
+		return nil, err
+	}
+	delta, err := mergeStateDelta(stateDelta, s.mergers, func(key string) any {
+		return s.storedValue(appName, userID, storedSession, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the provided session
 	ses.AddEvent(event)
 	ses.SetLastUpdateTime(event.Timestamp)
 
-	// Update the stored session if it exists
-	if _, ok := s.sessions[appName]; !ok {
+	// Update the stored session
+	storedSession.AddEvent(event)
+	storedSession.SetLastUpdateTime(event.Timestamp)
+	s.watches.publishEvent(appName, userID, sessionID, event)
+
+	if len(delta) == 0 {
 		return event, nil
 	}
-	if _, ok := s.sessions[appName][userID]; !ok {
-		return event, nil
+
+	// The provided session stays at its version if it missed updates of other copies of the session.
+	prevVersion := storedSession.stateVersion
+	storedSession.stateVersion++
+	if provided, ok := ses.(*session); ok && provided != storedSession && provided.stateVersion == prevVersion {
+		provided.stateVersion = storedSession.stateVersion
 	}
-	if storedSession, ok := s.sessions[appName][userID][sessionID]; ok {
-		storedSession.AddEvent(event)
-		storedSession.SetLastUpdateTime(event.Timestamp)
-
-		// Update state if there's state delta in the event
-		if event.Actions != nil && event.Actions.StateDelta != nil {
-			for key, value := range event.Actions.StateDelta {
-				if strings.HasPrefix(key, types.AppPrefix) {
-					if _, ok := s.appState[appName]; !ok {
-						s.appState[appName] = make(map[string]any)
-					}
-					s.appState[appName][strings.TrimPrefix(key, types.AppPrefix)] = value
-				} else if strings.HasPrefix(key, types.UserPrefix) {
-					if _, ok := s.userState[appName]; !ok {
-						s.userState[appName] = make(map[string]map[string]any)
-					}
-					if _, ok := s.userState[appName][userID]; !ok {
-						s.userState[appName][userID] = make(map[string]any)
-					}
-					s.userState[appName][userID][strings.TrimPrefix(key, types.UserPrefix)] = value
-				}
+
+	for key, value := range delta {
+		switch {
+		case strings.HasPrefix(key, types.AppPrefix):
+			if _, ok := s.appState[appName]; !ok {
+				s.appState[appName] = make(map[string]any)
+			}
+			s.appState[appName][strings.TrimPrefix(key, types.AppPrefix)] = value
+		case strings.HasPrefix(key, types.UserPrefix):
+			if _, ok := s.userState[appName]; !ok {
+				s.userState[appName] = make(map[string]map[string]any)
+			}
+			if _, ok := s.userState[appName][userID]; !ok {
+				s.userState[appName][userID] = make(map[string]any)
 			}
+			s.userState[appName][userID][strings.TrimPrefix(key, types.UserPrefix)] = value
+		case strings.HasPrefix(key, types.TempPrefix):
+			// not persisted
+			continue
+		default:
+			storedSession.state[key] = value
+			storedSession.keyVersions[key] = storedSession.stateVersion
 		}
+		ses.State()[key] = value
 	}
 
 	return event, nil
 }
 
+// storedValue returns the current value of the state key in the stored state, or nil if not set.
+func (s *InMemoryService) storedValue(appName, userID string, storedSession *session, key string) any {
+	switch {
+	case strings.HasPrefix(key, types.AppPrefix):
+		return s.appState[appName][strings.TrimPrefix(key, types.AppPrefix)]
+	case strings.HasPrefix(key, types.UserPrefix):
+		return s.userState[appName][userID][strings.TrimPrefix(key, types.UserPrefix)]
+	default:
+		return storedSession.state[key]
+	}
+}
+
 // ListEvents lists events for a session.
 func (s *InMemoryService) ListEvents(ctx context.Context, appName, userID, sessionID string, maxEvents int, since *time.Time) ([]types.Event, error) {
 	// This method is not implemented in the Python version
 	return nil, fmt.Errorf("ListEvents is not implemented")
 }
 
+// WatchEvents implements [types.SessionService].
+//
+// The events of the stored sessions are yielded as appended, and must not be modified.
+func (s *InMemoryService) WatchEvents(ctx context.Context, appName, userID, sessionID string) iter.Seq2[*types.Event, error] {
+	return s.watches.watchEvents(ctx, appName, userID, sessionID)
+}
+
+// WatchSessions implements [types.SessionService].
+func (s *InMemoryService) WatchSessions(ctx context.Context, appName, userID string) iter.Seq2[*types.SessionChange, error] {
+	return s.watches.watchSessions(ctx, appName, userID)
+}
+
+// Ping implements [types.SessionService].
+//
+// It always succeeds, the sessions having no backend.
+func (s *InMemoryService) Ping(context.Context) error {
+	return nil
+}
+
 // copySession creates a deep copy of a session.
-func (s *InMemoryService) copySession(ses types.Session) types.Session {
+func (s *InMemoryService) copySession(ses types.Session) *session {
 	// Create a new session with the same metadata
 	copiedSession := NewSession(ses.AppName(), ses.UserID(), ses.ID(), make(map[string]any), ses.LastUpdateTime())
+	if versioner, ok := ses.(types.StateVersioner); ok {
+		copiedSession.stateVersion = versioner.StateVersion()
+	}
 
 	// Copy events
 	for _, event := range ses.Events() {
@@ -0,0 +1,409 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func stateEvent(baseVersion int64, delta map[string]any) *types.Event {
+	return types.NewEvent().
+		WithAuthor("agent").
+		WithActions(types.NewEventActions().WithStateDelta(delta).WithStateBaseVersion(baseVersion))
+}
+
+func stateVersion(t *testing.T, ses types.Session) int64 {
+	t.Helper()
+
+	versioner, ok := ses.(types.StateVersioner)
+	if !ok {
+		t.Fatalf("session %T does not implement types.StateVersioner", ses)
+	}
+	return versioner.StateVersion()
+}
+
+func TestInMemoryServiceAppendEventStateConflict(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := session.NewInMemoryService()
+	if _, err := svc.CreateSession(ctx, "app", "user", "s1", map[string]any{"x": 0}); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := stateVersion(t, b)
+
+	if _, err := svc.AppendEvent(ctx, a, stateEvent(stateVersion(t, a), map[string]any{"x": 1})); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	if got := stateVersion(t, a); got != base+1 {
+		t.Errorf("StateVersion() after append = %d, want %d", got, base+1)
+	}
+
+	_, err = svc.AppendEvent(ctx, b, stateEvent(base, map[string]any{"x": 2, "y": 2}))
+	if !errors.Is(err, types.ErrStateConflict) {
+		t.Fatalf("AppendEvent() error = %v, want %v", err, types.ErrStateConflict)
+	}
+	var conflict *types.StateConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("AppendEvent() error = %T, want *types.StateConflictError", err)
+	}
+	want := &types.StateConflictError{SessionID: "s1", Keys: []string{"x"}, BaseVersion: base, Version: base + 1}
+	if diff := cmp.Diff(want, conflict); diff != "" {
+		t.Errorf("AppendEvent() error mismatch (-want +got):\n%s", diff)
+	}
+	if len(b.Events()) != 0 {
+		t.Errorf("rejected event was added to the session: %d events", len(b.Events()))
+	}
+
+	// Keys not written since the base version do not conflict.
+	if _, err := svc.AppendEvent(ctx, b, stateEvent(base, map[string]any{"y": 2})); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	// The copy missed the update of x, so it keeps its version.
+	if got := stateVersion(t, b); got != base {
+		t.Errorf("StateVersion() of stale copy = %d, want %d", got, base)
+	}
+
+	// A zero base version applies the delta unconditionally.
+	if _, err := svc.AppendEvent(ctx, b, stateEvent(0, map[string]any{"x": 3})); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+
+	got, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]any{"x": 3, "y": 2}, got.State()); diff != "" {
+		t.Errorf("State() mismatch (-want +got):\n%s", diff)
+	}
+	if got, want := stateVersion(t, got), base+3; got != want {
+		t.Errorf("StateVersion() = %d, want %d", got, want)
+	}
+}
+
+func TestInMemoryServiceAppendEventStateMerger(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := session.NewInMemoryService(
+		session.WithStateMerger("count", session.MergeSum),
+		session.WithStateMerger("user:tags", session.MergeUnion),
+	)
+	ses, err := svc.CreateSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := stateVersion(t, ses)
+
+	// Concurrent branches computing their deltas from the same base version.
+	deltas := []map[string]any{
+		{"count": 1, "user:tags": []string{"a", "b"}},
+		{"count": 2, "user:tags": []string{"b", "c"}},
+	}
+	for _, delta := range deltas {
+		if _, err := svc.AppendEvent(ctx, ses, stateEvent(base, delta)); err != nil {
+			t.Fatalf("AppendEvent() error = %v", err)
+		}
+	}
+
+	got, err := svc.GetSession(ctx, "app", "user", "s1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"count": 3, "user:tags": []any{"a", "b", "c"}}
+	if diff := cmp.Diff(want, got.State()); diff != "" {
+		t.Errorf("State() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want, ses.State()); diff != "" {
+		t.Errorf("provided session State() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeSum(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		current any
+		delta   any
+		want    any
+		wantErr bool
+	}{
+		"unset":      {current: nil, delta: 2, want: 2},
+		"ints":       {current: 1, delta: 2, want: 3},
+		"int64s":     {current: int64(1), delta: int64(2), want: int64(3)},
+		"json":       {current: float64(1.5), delta: 2, want: float64(3.5)},
+		"not number": {current: "a", delta: 1, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := session.MergeSum(tt.current, tt.delta)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MergeSum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("MergeSum() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInMemoryServiceCreateSessionClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc := session.NewInMemoryService(
+		session.WithClock(types.NewFakeClock(start, time.Second)),
+		session.WithIDGenerator(types.NewSequentialIDGenerator()),
+	)
+
+	for _, want := range []struct {
+		id string
+		ts time.Time
+	}{
+		{id: "session-1", ts: start},
+		{id: "session-2", ts: start.Add(time.Second)},
+	} {
+		ses, err := svc.CreateSession(t.Context(), "app", "user", "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ses.ID() != want.id || !ses.LastUpdateTime().Equal(want.ts) {
+			t.Errorf("CreateSession() = (%s, %s), want (%s, %s)", ses.ID(), ses.LastUpdateTime(), want.id, want.ts)
+		}
+	}
+}
+
+func TestInMemoryServiceCopySession(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := t.Context()
+	svc := session.NewInMemoryService(session.WithStateMerger("count", session.MergeSum))
+	src, err := svc.CreateSession(ctx, "app", "user", "src", map[string]any{"topic": "weather"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deltas := []map[string]any{
+		{"count": 1, "items": []any{"a"}},
+		{"count": 2, "topic": "news", "temp:step": 2},
+		{"count": 3, "items": []any{"a", "b"}, "user:theme": "dark"},
+	}
+	var ids []string
+	for i, delta := range deltas {
+		event := stateEvent(0, delta)
+		event.Timestamp = start.Add(time.Duration(i) * time.Minute)
+		if _, err := svc.AppendEvent(ctx, src, event); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, event.ID)
+	}
+
+	tests := map[string]struct {
+		opts       []types.CopySessionOption
+		wantEvents []string
+		wantState  map[string]any
+		wantErr    bool
+	}{
+		"full": {
+			wantEvents: ids,
+			wantState:  map[string]any{"topic": "news", "count": 6, "items": []any{"a", "b"}, "user:theme": "dark"},
+		},
+		"until event": {
+			opts:       []types.CopySessionOption{types.WithCopyUntilEvent(ids[1])},
+			wantEvents: ids[:2],
+			wantState:  map[string]any{"topic": "news", "count": 3, "items": []any{"a"}, "user:theme": "dark"},
+		},
+		"until timestamp": {
+			opts:       []types.CopySessionOption{types.WithCopyUntil(start.Add(30 * time.Second))},
+			wantEvents: ids[:1],
+			wantState:  map[string]any{"topic": "weather", "count": 1, "items": []any{"a"}, "user:theme": "dark"},
+		},
+		"unknown event": {
+			opts:    []types.CopySessionOption{types.WithCopyUntilEvent("missing")},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			dst, err := svc.CopySession(ctx, "app", "user", "src", "", tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CopySession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var gotEvents []string
+			for _, event := range dst.Events() {
+				gotEvents = append(gotEvents, event.ID)
+			}
+			if diff := cmp.Diff(tt.wantEvents, gotEvents); diff != "" {
+				t.Errorf("Events() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantState, dst.State()); diff != "" {
+				t.Errorf("State() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInMemoryServiceCopySessionNoAliasing(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := session.NewInMemoryService()
+	src, err := svc.CreateSession(ctx, "app", "user", "src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.AppendEvent(ctx, src, stateEvent(0, map[string]any{"items": []any{"a"}})); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.CopySession(ctx, "app", "user", "src", "dst"); err != nil {
+		t.Fatalf("CopySession() error = %v", err)
+	}
+	if _, err := svc.CopySession(ctx, "app", "user", "src", "dst"); err == nil {
+		t.Error("CopySession() to an existing session succeeded")
+	}
+
+	dst, err := svc.GetSession(ctx, "app", "user", "dst", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst.State()["items"].([]any)[0] = "changed"
+	dst.Events()[0].Actions.StateDelta["items"] = "changed"
+	if _, err := svc.AppendEvent(ctx, dst, stateEvent(0, map[string]any{"extra": true})); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := svc.GetSession(ctx, "app", "user", "src", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]any{"items": []any{"a"}}, got.State()); diff != "" {
+		t.Errorf("source State() mismatch (-want +got):\n%s", diff)
+	}
+	if len(got.Events()) != 1 {
+		t.Fatalf("source has %d events, want 1", len(got.Events()))
+	}
+	if diff := cmp.Diff([]any{"a"}, got.Events()[0].Actions.StateDelta["items"]); diff != "" {
+		t.Errorf("source event delta mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInMemoryServiceDeleteSessions(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newService := func(t *testing.T) *session.InMemoryService {
+		t.Helper()
+
+		svc := session.NewInMemoryService(session.WithClock(types.NewFakeClock(start, time.Hour)))
+		for _, s := range []struct {
+			userID, sessionID string
+			state             map[string]any
+		}{
+			{"user", "old-1", map[string]any{"status": "closed"}},
+			{"other", "old-2", map[string]any{"status": "closed"}},
+			{"user", "keep-1", map[string]any{"status": "open"}},
+			{"user", "new-1", map[string]any{"status": "closed"}},
+		} {
+			if _, err := svc.CreateSession(t.Context(), "app", s.userID, s.sessionID, s.state); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return svc
+	}
+	cutoff := start.Add(90 * time.Minute)
+
+	tests := map[string]struct {
+		userID   string
+		filter   types.SessionFilter
+		want     int
+		wantLeft []string
+	}{
+		"updated before": {
+			userID:   "user",
+			filter:   types.SessionFilter{UpdatedBefore: cutoff},
+			want:     1,
+			wantLeft: []string{"keep-1", "new-1"},
+		},
+		"all users": {
+			filter:   types.SessionFilter{UpdatedBefore: cutoff},
+			want:     2,
+			wantLeft: []string{"keep-1", "new-1"},
+		},
+		"state": {
+			userID:   "user",
+			filter:   types.SessionFilter{State: map[string]any{"status": "closed"}},
+			want:     2,
+			wantLeft: []string{"keep-1"},
+		},
+		"ID prefix and state": {
+			userID:   "user",
+			filter:   types.SessionFilter{IDPrefix: "new-", State: map[string]any{"status": "closed"}},
+			want:     1,
+			wantLeft: []string{"keep-1", "old-1"},
+		},
+		"dry run": {
+			userID:   "user",
+			filter:   types.SessionFilter{State: map[string]any{"status": "closed"}, DryRun: true},
+			want:     2,
+			wantLeft: []string{"keep-1", "new-1", "old-1"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := newService(t)
+			got, err := svc.DeleteSessions(t.Context(), "app", tt.userID, tt.filter)
+			if err != nil {
+				t.Fatalf("DeleteSessions() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("DeleteSessions() = %d, want %d", got, tt.want)
+			}
+
+			sessions, err := svc.ListSessions(t.Context(), "app", "user")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var left []string
+			for _, ses := range sessions {
+				left = append(left, ses.ID())
+			}
+			if diff := cmp.Diff(tt.wantLeft, left, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("sessions left mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := newService(t).DeleteSessions(t.Context(), "app", "user", types.SessionFilter{DryRun: true}); !errors.Is(err, types.ErrEmptySessionFilter) {
+		t.Errorf("DeleteSessions() with an empty filter error = %v, want %v", err, types.ErrEmptySessionFilter)
+	}
+}
@@ -17,9 +17,23 @@ type session struct {
 	events         []*types.Event
 	state          map[string]any
 	lastUpdateTime time.Time
+
+	// stateVersion is the version of the session state this session reflects.
+	stateVersion int64
+
+	// keyVersions is the state version at which each session-scoped key was last written.
+	// It is only tracked by the stored sessions of the [InMemoryService].
+	keyVersions map[string]int64
+
+	// initialState is the state the session was created with, from which the state of a truncated
+	// copy is replayed. It is only tracked by the stored sessions of the [InMemoryService].
+	initialState map[string]any
 }
 
-var _ types.Session = (*session)(nil)
+var (
+	_ types.Session        = (*session)(nil)
+	_ types.StateVersioner = (*session)(nil)
+)
 
 // NewSession creates a new session with the given parameters.
 func NewSession(appName, userID, id string, state map[string]any, lastUpdateTime time.Time) *session {
@@ -67,6 +81,11 @@ func (s *session) LastUpdateTime() time.Time {
 	return s.lastUpdateTime
 }
 
+// StateVersion implements [types.StateVersioner].
+func (s *session) StateVersion() int64 {
+	return s.stateVersion
+}
+
 // SetLastUpdateTime sets the last update time of this session.
 func (s *session) SetLastUpdateTime(t time.Time) {
 	s.lastUpdateTime = t
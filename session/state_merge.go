@@ -0,0 +1,152 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// StateMerger combines the current value of a state key with the value of a state delta.
+//
+// The current value is nil if the key is not set. A key with a merger is never reported as a
+// state conflict, so the merger must give the same result whatever the order of the deltas.
+type StateMerger func(current, delta any) (any, error)
+
+// MergeSum is a [StateMerger] for counters: the delta is added to the current value.
+//
+// Two ints or two int64s sum to the same type, other numbers sum to a float64.
+func MergeSum(current, delta any) (any, error) {
+	if current == nil {
+		return delta, nil
+	}
+
+	switch c := current.(type) {
+	case int:
+		if d, ok := delta.(int); ok {
+			return c + d, nil
+		}
+	case int64:
+		if d, ok := delta.(int64); ok {
+			return c + d, nil
+		}
+	}
+
+	cf, cok := toFloat(current)
+	df, dok := toFloat(delta)
+	if !cok || !dok {
+		return nil, fmt.Errorf("sum %T and %T: not numbers", current, delta)
+	}
+	return cf + df, nil
+}
+
+// MergeUnion is a [StateMerger] for sets: the elements of the delta missing from the current
+// value are appended to it.
+//
+// Both values may be slices of any type or single elements; the result is a []any keeping the
+// order in which the elements were first added.
+func MergeUnion(current, delta any) (any, error) {
+	union := make([]any, 0)
+	add := func(v any) {
+		if !slices.ContainsFunc(union, func(e any) bool { return reflect.DeepEqual(e, v) }) {
+			union = append(union, v)
+		}
+	}
+
+	for _, v := range []any{current, delta} {
+		if v == nil {
+			continue
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			add(v)
+			continue
+		}
+		for i := range rv.Len() {
+			add(rv.Index(i).Interface())
+		}
+	}
+
+	return union, nil
+}
+
+// checkStateConflict returns a [*types.StateConflictError] if a session-scoped key of the delta
+// without a merger was written after the base version.
+//
+// A zero base version never conflicts.
+func checkStateConflict(sessionID string, delta map[string]any, baseVersion, version int64, keyVersions map[string]int64, mergers map[string]StateMerger) error {
+	if baseVersion == 0 {
+		return nil
+	}
+
+	var keys []string
+	for key := range delta {
+		if !isSessionKey(key) {
+			continue
+		}
+		if _, ok := mergers[key]; ok {
+			continue
+		}
+		if keyVersions[key] > baseVersion {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+
+	return &types.StateConflictError{
+		SessionID:   sessionID,
+		Keys:        keys,
+		BaseVersion: baseVersion,
+		Version:     version,
+	}
+}
+
+// mergeStateDelta returns the delta with the values of the keys with a merger replaced by their
+// merge with the current value, as returned by current.
+func mergeStateDelta(delta map[string]any, mergers map[string]StateMerger, current func(key string) any) (map[string]any, error) {
+	merged := make(map[string]any, len(delta))
+	for key, value := range delta {
+		merge, ok := mergers[key]
+		if !ok || strings.HasPrefix(key, types.TempPrefix) {
+			merged[key] = value
+			continue
+		}
+		v, err := merge(current(key), value)
+		if err != nil {
+			return nil, fmt.Errorf("merge state key %s: %w", key, err)
+		}
+		merged[key] = v
+	}
+
+	return merged, nil
+}
+
+// isSessionKey reports whether the state key is scoped to the session and persisted.
+func isSessionKey(key string) bool {
+	return !strings.HasPrefix(key, types.AppPrefix) &&
+		!strings.HasPrefix(key, types.UserPrefix) &&
+		!strings.HasPrefix(key, types.TempPrefix)
+}
+
+// toFloat converts a numeric value, either decoded from JSON or given by Go code, to a float64.
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session
+
+import (
+	"context"
+	"iter"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultWatchBufferSize is the number of changes buffered for each watcher when no buffer size is
+// set, before the oldest ones are dropped.
+const DefaultWatchBufferSize = 256
+
+// watchKey identifies the watched session, or the watched sessions of a user or of all the users
+// of an application if the session ID or the user ID is empty.
+type watchKey struct {
+	appName   string
+	userID    string
+	sessionID string
+}
+
+// watchHub fans out the changes of a session service to its watchers. The zero value is ready to use.
+type watchHub struct {
+	mu sync.Mutex
+
+	// bufferSize is the number of changes buffered for each watcher. Zero or less means
+	// [DefaultWatchBufferSize].
+	bufferSize int
+
+	events   map[watchKey]map[*subscription[*types.Event]]struct{}
+	sessions map[watchKey]map[*subscription[*types.SessionChange]]struct{}
+}
+
+// watchEvents yields the events published for the session.
+func (h *watchHub) watchEvents(ctx context.Context, appName, userID, sessionID string) iter.Seq2[*types.Event, error] {
+	return watch(ctx, h, &h.events, watchKey{appName: appName, userID: userID, sessionID: sessionID})
+}
+
+// watchSessions yields the changes published for the sessions of the user, or of all the users
+// of the application if userID is empty.
+func (h *watchHub) watchSessions(ctx context.Context, appName, userID string) iter.Seq2[*types.SessionChange, error] {
+	return watch(ctx, h, &h.sessions, watchKey{appName: appName, userID: userID})
+}
+
+// publishEvent sends the event appended to the session to its watchers.
+func (h *watchHub) publishEvent(appName, userID, sessionID string, event *types.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.events[watchKey{appName: appName, userID: userID, sessionID: sessionID}] {
+		sub.push(event)
+	}
+}
+
+// publishSession sends the change of a session to the watchers of its user and of its application.
+func (h *watchHub) publishSession(kind types.SessionChangeKind, appName, userID, sessionID string) {
+	change := &types.SessionChange{Kind: kind, AppName: appName, UserID: userID, SessionID: sessionID}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.sessions[watchKey{appName: appName, userID: userID}] {
+		sub.push(change)
+	}
+	if userID == "" {
+		return
+	}
+	for sub := range h.sessions[watchKey{appName: appName}] {
+		sub.push(change)
+	}
+}
+
+// watch subscribes for the key in subs, so that the changes published from now on are buffered,
+// and returns the sequence yielding them.
+//
+// The subscription is released when the iteration stops or ctx is done, so that the sequence
+// can only be iterated once.
+func watch[T any](ctx context.Context, h *watchHub, subs *map[watchKey]map[*subscription[T]]struct{}, key watchKey) iter.Seq2[T, error] {
+	size := h.bufferSize
+	if size <= 0 {
+		size = DefaultWatchBufferSize
+	}
+	sub := &subscription[T]{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+
+	h.mu.Lock()
+	if *subs == nil {
+		*subs = make(map[watchKey]map[*subscription[T]]struct{})
+	}
+	if (*subs)[key] == nil {
+		(*subs)[key] = make(map[*subscription[T]]struct{})
+	}
+	(*subs)[key][sub] = struct{}{}
+	h.mu.Unlock()
+
+	release := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete((*subs)[key], sub)
+		if len((*subs)[key]) == 0 {
+			delete(*subs, key)
+		}
+		sub.closed.Store(true)
+	}
+	stop := context.AfterFunc(ctx, release)
+
+	return func(yield func(T, error) bool) {
+		if sub.closed.Load() {
+			return
+		}
+		defer func() {
+			stop()
+			release()
+		}()
+
+		sub.run(ctx, yield)
+	}
+}
+
+// subscription is the buffer of the changes not yet consumed by a watcher.
+type subscription[T any] struct {
+	mu      sync.Mutex
+	items   []T
+	dropped int
+	size    int
+
+	// ready is signaled when items are pushed.
+	ready chan struct{}
+
+	// closed is set once the subscription is released.
+	closed atomic.Bool
+}
+
+// push buffers the item, dropping the oldest one if the buffer is full. It never blocks on the watcher.
+func (s *subscription[T]) push(item T) {
+	s.mu.Lock()
+	if len(s.items) >= s.size {
+		var zero T
+		s.items[0] = zero
+		s.items = s.items[1:]
+		s.dropped++
+	}
+	s.items = append(s.items, item)
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// run yields the buffered items as they are pushed, preceded by a [*types.WatchLagError] after
+// dropped items, until ctx is done or yield returns false.
+func (s *subscription[T]) run(ctx context.Context, yield func(T, error) bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ready:
+		}
+
+		s.mu.Lock()
+		items, dropped := s.items, s.dropped
+		s.items, s.dropped = nil, 0
+		s.mu.Unlock()
+
+		if dropped > 0 {
+			var zero T
+			if !yield(zero, &types.WatchLagError{Dropped: dropped}) {
+				return
+			}
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package session_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func textEvent(text string) *types.Event {
+	return types.NewEvent().
+		WithAuthor("agent").
+		WithContent(genai.NewContentFromText(text, genai.RoleModel))
+}
+
+// pull returns the next n values of the sequence, with a nil value for each error.
+func pull[T any](t *testing.T, next func() (T, error, bool), n int) ([]T, []error) {
+	t.Helper()
+
+	var (
+		values []T
+		errs   []error
+	)
+	for range n {
+		v, err, ok := next()
+		if !ok {
+			t.Fatalf("sequence ended after %d values", len(values))
+		}
+		values = append(values, v)
+		errs = append(errs, err)
+	}
+	return values, errs
+}
+
+func TestInMemoryServiceWatchEvents(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := session.NewInMemoryService(session.WithWatchBufferSize(2))
+	ses, err := svc.CreateSession(ctx, "app", "user", "session", nil)
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	other, err := svc.CreateSession(ctx, "app", "user", "other", nil)
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	next, stop := iter.Pull2(svc.WatchEvents(ctx, "app", "user", "session"))
+	defer stop()
+
+	// The appends do not wait for the watcher, which only keeps the last two events.
+	for _, text := range []string{"one", "two", "three", "four"} {
+		if _, err := svc.AppendEvent(ctx, ses, textEvent(text)); err != nil {
+			t.Fatalf("AppendEvent(%s) error = %v", text, err)
+		}
+	}
+	if _, err := svc.AppendEvent(ctx, other, textEvent("elsewhere")); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+
+	events, errs := pull(t, next, 3)
+	var lag *types.WatchLagError
+	if !errors.As(errs[0], &lag) || lag.Dropped != 2 {
+		t.Fatalf("first watched error = %v, want 2 events dropped", errs[0])
+	}
+	var texts []string
+	for i, event := range events[1:] {
+		if errs[i+1] != nil {
+			t.Fatalf("watched error = %v", errs[i+1])
+		}
+		texts = append(texts, event.Content.Parts[0].Text)
+	}
+	if diff := cmp.Diff([]string{"three", "four"}, texts); diff != "" {
+		t.Errorf("watched events mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := svc.AppendEvent(ctx, ses, textEvent("five")); err != nil {
+		t.Fatalf("AppendEvent() error = %v", err)
+	}
+	events, errs = pull(t, next, 1)
+	if errs[0] != nil || events[0].Content.Parts[0].Text != "five" {
+		t.Errorf("watched event = (%v, %v), want five", events[0], errs[0])
+	}
+}
+
+func TestInMemoryServiceWatchSessions(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	svc := session.NewInMemoryService()
+
+	nextUser, stopUser := iter.Pull2(svc.WatchSessions(ctx, "app", "user"))
+	defer stopUser()
+	nextApp, stopApp := iter.Pull2(svc.WatchSessions(ctx, "app", ""))
+	defer stopApp()
+
+	if _, err := svc.CreateSession(ctx, "app", "user", "s1", nil); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if _, err := svc.CreateSession(ctx, "app", "someone", "s2", nil); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if _, err := svc.CopySession(ctx, "app", "user", "s1", "s3"); err != nil {
+		t.Fatalf("CopySession() error = %v", err)
+	}
+	if err := svc.DeleteSession(ctx, "app", "user", "s1"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if err := svc.DeleteSession(ctx, "app", "user", "missing"); err != nil {
+		t.Fatalf("DeleteSession() error = %v", err)
+	}
+	if _, err := svc.CreateSession(ctx, "other", "user", "s4", nil); err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	created := func(userID, sessionID string) *types.SessionChange {
+		return &types.SessionChange{Kind: types.SessionCreated, AppName: "app", UserID: userID, SessionID: sessionID}
+	}
+	deleted := &types.SessionChange{Kind: types.SessionDeleted, AppName: "app", UserID: "user", SessionID: "s1"}
+
+	changes, _ := pull(t, nextUser, 3)
+	if diff := cmp.Diff([]*types.SessionChange{created("user", "s1"), created("user", "s3"), deleted}, changes); diff != "" {
+		t.Errorf("user changes mismatch (-want +got):\n%s", diff)
+	}
+	changes, _ = pull(t, nextApp, 4)
+	if diff := cmp.Diff([]*types.SessionChange{created("user", "s1"), created("someone", "s2"), created("user", "s3"), deleted}, changes); diff != "" {
+		t.Errorf("app changes mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInMemoryServiceWatchCanceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	svc := session.NewInMemoryService()
+	watch := svc.WatchSessions(ctx, "app", "user")
+	cancel()
+
+	for change, err := range watch {
+		t.Errorf("watch after cancel yielded (%v, %v)", change, err)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tool
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// RedactedValue replaces the values of the redacted arguments in the audit records.
+const RedactedValue = "[REDACTED]"
+
+// auditConfig holds the settings shared by the auditors.
+type auditConfig struct {
+	// redacted maps a tool name, or "" for all tools, to the redacted argument paths.
+	redacted map[string][]string
+}
+
+// AuditOption configures the [SlogAuditor] and [InMemoryAuditor].
+type AuditOption func(*auditConfig)
+
+// WithRedactedArgs redacts the given arguments of the calls of the named tool, or of all tools if
+// toolName is empty.
+//
+// A field is an argument name, or a dotted path to a field of a nested object such as
+// "credentials.password".
+func WithRedactedArgs(toolName string, fields ...string) AuditOption {
+	return func(c *auditConfig) {
+		if c.redacted == nil {
+			c.redacted = make(map[string][]string)
+		}
+		c.redacted[toolName] = append(c.redacted[toolName], fields...)
+	}
+}
+
+func newAuditConfig(opts []AuditOption) auditConfig {
+	var c auditConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// redact returns the record with its redacted arguments replaced by [RedactedValue].
+// The arguments of the record are copied before being modified.
+func (c auditConfig) redact(record types.ToolCallRecord) types.ToolCallRecord {
+	fields := append(slices.Clone(c.redacted[""]), c.redacted[record.ToolName]...)
+	if len(fields) == 0 || len(record.Args) == 0 {
+		return record
+	}
+
+	args := cloneArgs(record.Args)
+	for _, field := range fields {
+		redactPath(args, strings.Split(field, "."))
+	}
+	record.Args = args
+	return record
+}
+
+// cloneArgs returns a copy of the arguments and of their nested objects.
+func cloneArgs(args map[string]any) map[string]any {
+	clone := maps.Clone(args)
+	for key, value := range clone {
+		if nested, ok := value.(map[string]any); ok {
+			clone[key] = cloneArgs(nested)
+		}
+	}
+	return clone
+}
+
+// redactPath replaces the value at path in args, if set.
+func redactPath(args map[string]any, path []string) {
+	value, ok := args[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		args[path[0]] = RedactedValue
+		return
+	}
+	if nested, ok := value.(map[string]any); ok {
+		redactPath(nested, path[1:])
+	}
+}
+
+// SlogAuditor is a [types.ToolAuditor] logging each tool call as a structured record.
+type SlogAuditor struct {
+	logger *slog.Logger
+	config auditConfig
+}
+
+var _ types.ToolAuditor = (*SlogAuditor)(nil)
+
+// NewSlogAuditor returns a new [SlogAuditor] logging to logger, or to [slog.Default] if nil.
+func NewSlogAuditor(logger *slog.Logger, opts ...AuditOption) *SlogAuditor {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogAuditor{
+		logger: logger,
+		config: newAuditConfig(opts),
+	}
+}
+
+// RecordToolCall implements [types.ToolAuditor].
+//
+// Successful calls are logged at the info level and failed calls at the warn level.
+func (a *SlogAuditor) RecordToolCall(record types.ToolCallRecord) {
+	record = a.config.redact(record)
+
+	level := slog.LevelInfo
+	if record.Status == types.ToolCallStatusError {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.Time("timestamp", record.Timestamp),
+		slog.String("tool_name", record.ToolName),
+		slog.String("function_call_id", record.FunctionCallID),
+		slog.Any("args", record.Args),
+		slog.String("status", string(record.Status)),
+		slog.Duration("duration", record.Duration),
+		slog.String("app_name", record.AppName),
+		slog.String("user_id", record.UserID),
+		slog.String("session_id", record.SessionID),
+		slog.String("invocation_id", record.InvocationID),
+		slog.String("agent_name", record.AgentName),
+	}
+	if record.Error != "" {
+		attrs = append(attrs, slog.String("error", record.Error))
+	}
+	a.logger.LogAttrs(context.Background(), level, "tool call", attrs...)
+}
+
+// InMemoryAuditor is a [types.ToolAuditor] keeping the tool call records in memory, for tests.
+type InMemoryAuditor struct {
+	config auditConfig
+
+	mu      sync.Mutex
+	records []types.ToolCallRecord
+}
+
+var _ types.ToolAuditor = (*InMemoryAuditor)(nil)
+
+// NewInMemoryAuditor returns a new [InMemoryAuditor].
+func NewInMemoryAuditor(opts ...AuditOption) *InMemoryAuditor {
+	return &InMemoryAuditor{
+		config: newAuditConfig(opts),
+	}
+}
+
+// RecordToolCall implements [types.ToolAuditor].
+func (a *InMemoryAuditor) RecordToolCall(record types.ToolCallRecord) {
+	record = a.config.redact(record)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.records = append(a.records, record)
+}
+
+// Records returns the recorded tool calls, in the order they were recorded.
+func (a *InMemoryAuditor) Records() []types.ToolCallRecord {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return slices.Clone(a.records)
+}
+
+// Reset removes the recorded tool calls.
+func (a *InMemoryAuditor) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.records = nil
+}
@@ -221,6 +221,21 @@
 //   - Secure credential management
 //   - Audit logging for sensitive operations
 //
+// # Audit Logging
+//
+// A types.ToolAuditor set on the flow records every tool call with its arguments, outcome,
+// duration and session, whatever the tool. The SlogAuditor logs the records, with the sensitive
+// arguments redacted:
+//
+//	auditor := tool.NewSlogAuditor(logger,
+//		tool.WithRedactedArgs("", "api_key"),                // all tools
+//		tool.WithRedactedArgs("send_email", "body.content"), // a nested field of one tool
+//	)
+//	flow := llmflow.NewAutoFlow()
+//	flow.WithToolAuditor(auditor)
+//
+// The InMemoryAuditor keeps the records for tests.
+//
 // # Performance Optimization
 //
 // Optimize tool performance through:
@@ -176,6 +176,14 @@ func buildParametersSchema(funcType reflect.Type, config *functionConfig) (*gena
 		}
 	}
 
+	// A single map[string]any parameter receives the arguments object itself, as a [Function] does,
+	// so its properties are not known.
+	if numParams-startIdx == 1 && funcType.In(startIdx) == reflect.TypeFor[map[string]any]() {
+		return &genai.Schema{
+			Type: genai.TypeObject,
+		}, nil
+	}
+
 	// Process each parameter
 	for i := startIdx; i < numParams; i++ {
 		paramType := funcType.In(i)
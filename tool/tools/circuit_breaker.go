@@ -0,0 +1,51 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+
+	"github.com/go-a2a/adk-go/pkg/circuitbreaker"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// CircuitBreakerTool wraps a [types.Tool] with a [*circuitbreaker.Breaker].
+//
+// While the breaker is open, Run fails fast with an error matching [circuitbreaker.ErrCircuitOpen]
+// without running the underlying tool.
+type CircuitBreakerTool struct {
+	types.Tool
+
+	breaker *circuitbreaker.Breaker
+}
+
+var _ types.Tool = (*CircuitBreakerTool)(nil)
+
+// NewCircuitBreakerTool returns the [*CircuitBreakerTool] guarding tool with breaker.
+func NewCircuitBreakerTool(tool types.Tool, breaker *circuitbreaker.Breaker) *CircuitBreakerTool {
+	return &CircuitBreakerTool{
+		Tool:    tool,
+		breaker: breaker,
+	}
+}
+
+// Breaker returns the circuit breaker of the tool.
+func (t *CircuitBreakerTool) Breaker() *circuitbreaker.Breaker {
+	return t.breaker
+}
+
+// Run implements [types.Tool].
+func (t *CircuitBreakerTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	var result any
+	err := t.breaker.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = t.Tool.Run(ctx, args, toolCtx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
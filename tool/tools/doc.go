@@ -17,6 +17,7 @@
 //
 // ## Agent Tools
 //   - Agent: Wraps other agents as tools for hierarchical agent composition
+//   - IsolatedAgentTool: Delegates to an agent run in a fresh, ephemeral session
 //   - ExitLoopTool: Provides loop termination control for LoopAgent
 //   - GetUserChoiceTool: Interactive user input for decision-making
 //
@@ -38,6 +39,10 @@
 // ## Utility Tools
 //   - LongRunningTool: Base class for asynchronous operations
 //   - ExampleTool: Demonstration tool for learning and testing
+//   - ListToolsTool: Lists the other tools exposed to the agent in the current turn
+//
+// ## MCP Server
+//   - MCPServer: Serves the tools of a toolset to Model Context Protocol clients over stdio or SSE
 //
 // # Basic Usage
 //
@@ -98,6 +103,12 @@
 //		agent.WithInstruction("Coordinate research and analysis tasks"),
 //	)
 //
+// IsolatedAgentTool runs the agent in a new session seeded with the request of the model only,
+// so that it neither sees nor alters the conversation of the caller, and returns its final answer.
+// The memory service of the caller is shared unless disabled:
+//
+//	researchTool := tools.NewIsolatedAgentTool(researchAgent, tools.WithSharedMemory(false))
+//
 // # Code Execution Tool
 //
 // Provide secure code execution capabilities:
@@ -149,23 +160,26 @@
 //
 // # Long-Running Operations
 //
-// Handle asynchronous operations with long-running tools:
+// Handle asynchronous operations with long-running tools, starting the background work with
+// StartJob so that it is cancelled with the invocation instead of leaking:
 //
-//	type DataProcessingTool struct {
-//		*tools.LongRunningTool
-//	}
-//
-//	func (t *DataProcessingTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
-//		// Start background processing
-//		jobID := t.startProcessingJob(args)
+//	processData := tools.NewLongRunningFunctionTool(func(ctx context.Context, args map[string]any) (any, error) {
+//		jobID, err := tools.StartJob(ctx, func(ctx context.Context) {
+//			process(ctx, args) // returns when ctx is done
+//		})
+//		if err != nil {
+//			return nil, err
+//		}
 //
 //		// Return immediately with job tracking info
 //		return map[string]any{
 //			"job_id": jobID,
 //			"status": "processing",
-//			"check_url": fmt.Sprintf("/jobs/%s/status", jobID),
 //		}, nil
-//	}
+//	})
+//
+// A job is cancelled with processData.Cancel(jobID), or with the other jobs of the invocation
+// when the context of the flow is done while it runs, or by types.CloseServices.
 //
 // # Interactive Tools
 //
@@ -200,6 +214,16 @@
 //		}, nil
 //	}
 //
+// LoadArtifactsTool can guard the context against large or slow artifact stores:
+//
+//	loadArtifacts := tools.NewLoadArtifactsTool(
+//		tools.WithMaxArtifactBytes(64<<10, tools.TruncateOversizedArtifact),
+//		tools.WithArtifactLoadTimeout(5*time.Second),
+//	)
+//
+// The model can also ask for the name, size and MIME type of the artifacts only, with the
+// metadata_only argument, before deciding which to load.
+//
 // # Error Handling Best Practices
 //
 // Tools should provide clear error messages:
@@ -245,6 +269,33 @@
 //		return t.callAuthenticatedAPI(args)
 //	}
 //
+// # Merging Toolsets
+//
+// MergeToolsets combines several toolsets into one, each with a precedence resolving the tool names
+// they share, and a filter selecting the tools kept:
+//
+//	toolset := tools.MergeToolsets(
+//		tools.WithToolsetSource(searchToolset, 0, nil),
+//		tools.WithToolsetSource(webToolset, 1, tools.ExcludeTools("debug")), // wins over searchToolset
+//		tools.WithToolSource(0, tools.NewListToolsTool()),
+//	)
+//
+// WithConflictError reports the shared tool names as duplicates instead, and Tools returns the
+// merged tools for inspection.
+//
+// # Coalescing Concurrent Calls
+//
+// NewSingleflightTool wraps an expensive idempotent tool so that its concurrent calls of identical
+// arguments, such as the parallel function calls of a model or the calls of concurrent users, run
+// it once and share its result or error:
+//
+//	search := tools.NewSingleflightTool(searchTool, nil) // keyed by tools.ArgsKey
+//
+// A key function may scope the key, such as by user, or return an empty key to run a call on its
+// own. Tools with side effects must not be wrapped. A caller cancelled while waiting does not
+// cancel the shared execution, which WithTimeout may bound instead. The execution runs with a tool
+// context of its own, and each caller gets its state delta with the result.
+//
 // # Performance Considerations
 //
 //  1. Cache expensive computations and API calls
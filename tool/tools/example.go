@@ -77,7 +77,7 @@ func (t *ExampleTool[T]) ProcessLLMRequest(ctx context.Context, toolCtx *types.T
 	if err != nil {
 		panic(err)
 	}
-	request.AppendInstructions(instructions)
+	request.AppendSystemContent(types.SystemContentExamples, instructions)
 
 	return nil
 }
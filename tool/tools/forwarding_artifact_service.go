@@ -6,6 +6,7 @@ package tools
 import (
 	"context"
 	"errors"
+	"io"
 
 	"google.golang.org/genai"
 
@@ -38,6 +39,16 @@ func (a *ForwardingArtifactService) LoadArtifact(ctx context.Context, appName, u
 	return a.toolCtx.LoadArtifact(ctx, filename, version)
 }
 
+// SaveArtifactStream implements [types.ArtifactService].
+func (a *ForwardingArtifactService) SaveArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, r io.Reader, mimeType string) (int, error) {
+	return a.toolCtx.SaveArtifactStream(ctx, filename, r, mimeType)
+}
+
+// LoadArtifactStream implements [types.ArtifactService].
+func (a *ForwardingArtifactService) LoadArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, version int) (io.ReadCloser, string, error) {
+	return a.toolCtx.LoadArtifactStream(ctx, filename, version)
+}
+
 // ListArtifactKey implements [types.ArtifactService].
 func (a *ForwardingArtifactService) ListArtifactKey(ctx context.Context, appName, userID, sessionID string) ([]string, error) {
 	return a.toolCtx.ListArtifacts(ctx)
@@ -61,6 +72,17 @@ func (a *ForwardingArtifactService) ListVersions(ctx context.Context, appName, u
 	return a.ictx.ArtifactService.ListVersions(ctx, a.ictx.AppName(), a.ictx.UserID(), a.ictx.Session.ID(), filename)
 }
 
+// Ping implements [types.ArtifactService].
+//
+// It pings the artifact service of the parent invocation.
+func (a *ForwardingArtifactService) Ping(ctx context.Context) error {
+	if a.ictx.ArtifactService == nil {
+		return errors.New("artifact service is not initialized")
+	}
+
+	return a.ictx.ArtifactService.Ping(ctx)
+}
+
 // Close implements [types.ArtifactService].
 func (a *ForwardingArtifactService) Close() error {
 	// nothing to do
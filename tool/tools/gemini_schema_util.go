@@ -9,6 +9,7 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/go-json-experiment/json"
 	"github.com/modelcontextprotocol/go-sdk/jsonschema"
 	"google.golang.org/genai"
 
@@ -510,6 +511,89 @@ func convertToGenaiSchema(schema *jsonschema.Schema) (*genai.Schema, error) {
 	return result, nil
 }
 
+// FromGeminiSchema converts a [*genai.Schema] to a JSON Schema, the inverse of [ToGeminiSchema].
+//
+// A nullable schema allows the "null" type besides its own type.
+func FromGeminiSchema(schema *genai.Schema) (*jsonschema.Schema, error) {
+	if schema == nil {
+		return nil, nil
+	}
+
+	result := &jsonschema.Schema{
+		Title:       schema.Title,
+		Description: schema.Description,
+		Format:      schema.Format,
+		Pattern:     schema.Pattern,
+		Required:    schema.Required,
+		Minimum:     schema.Minimum,
+		Maximum:     schema.Maximum,
+	}
+
+	if schema.Type != genai.TypeUnspecified {
+		typ := strings.ToLower(string(schema.Type))
+		if schema.Nullable != nil && *schema.Nullable && typ != "null" {
+			result.Types = []string{typ, "null"}
+		} else {
+			result.Type = typ
+		}
+	}
+
+	for _, e := range schema.Enum {
+		result.Enum = append(result.Enum, e)
+	}
+
+	toInt := func(v *int64) *int {
+		if v == nil {
+			return nil
+		}
+		return types.ToPtr(int(*v))
+	}
+	result.MinLength = toInt(schema.MinLength)
+	result.MaxLength = toInt(schema.MaxLength)
+	result.MinItems = toInt(schema.MinItems)
+	result.MaxItems = toInt(schema.MaxItems)
+	result.MinProperties = toInt(schema.MinProperties)
+	result.MaxProperties = toInt(schema.MaxProperties)
+
+	if schema.Default != nil {
+		data, err := json.Marshal(schema.Default, json.DefaultOptionsV2())
+		if err != nil {
+			return nil, fmt.Errorf("marshal default: %w", err)
+		}
+		result.Default = data
+	}
+	if schema.Example != nil {
+		result.Examples = []any{schema.Example}
+	}
+
+	var err error
+	result.Items, err = FromGeminiSchema(schema.Items)
+	if err != nil {
+		return nil, fmt.Errorf("convert items schema: %w", err)
+	}
+
+	if len(schema.Properties) > 0 {
+		result.Properties = make(map[string]*jsonschema.Schema, len(schema.Properties))
+		for propName, propVal := range schema.Properties {
+			converted, err := FromGeminiSchema(propVal)
+			if err != nil {
+				return nil, fmt.Errorf("convert property %s schema: %w", propName, err)
+			}
+			result.Properties[propName] = converted
+		}
+	}
+
+	for i, sub := range schema.AnyOf {
+		converted, err := FromGeminiSchema(sub)
+		if err != nil {
+			return nil, fmt.Errorf("convert anyOf[%d] schema: %w", i, err)
+		}
+		result.AnyOf = append(result.AnyOf, converted)
+	}
+
+	return result, nil
+}
+
 // ValidateGeminiSchema validates that a schema is compatible with Gemini's requirements.
 // This function checks for common issues and returns descriptive error messages.
 func ValidateGeminiSchema(schema *genai.Schema) error {
@@ -0,0 +1,200 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// IsolatedAgentTool is a [tool.Tool] that delegates the request of the model to an agent run in a
+// fresh, ephemeral session.
+//
+// The agent does not see the conversation of the calling agent: its session is seeded with the
+// request only, and is discarded once the agent completes, so that the events and state of the
+// delegated run do not leak into the calling session either. Only the final answer of the agent
+// is returned to the model.
+type IsolatedAgentTool struct {
+	*tool.Tool
+
+	agent            types.Agent
+	shareMemory      bool
+	shareArtifacts   bool
+	shareCredentials bool
+}
+
+var _ types.Tool = (*IsolatedAgentTool)(nil)
+
+// IsolatedAgentToolOption configures an [IsolatedAgentTool].
+type IsolatedAgentToolOption func(*IsolatedAgentTool)
+
+// WithSharedMemory sets whether the agent uses the memory service of the calling invocation.
+//
+// The memory is shared by default.
+func WithSharedMemory(share bool) IsolatedAgentToolOption {
+	return func(t *IsolatedAgentTool) {
+		t.shareMemory = share
+	}
+}
+
+// WithSharedArtifacts sets whether the agent uses the artifact service of the calling invocation.
+//
+// The artifacts are keyed by session, so that the agent still does not see the artifacts of the
+// calling session. The artifacts are not shared by default.
+func WithSharedArtifacts(share bool) IsolatedAgentToolOption {
+	return func(t *IsolatedAgentTool) {
+		t.shareArtifacts = share
+	}
+}
+
+// WithSharedCredentials sets whether the agent uses the credential service of the calling
+// invocation.
+//
+// The credentials are not shared by default.
+func WithSharedCredentials(share bool) IsolatedAgentToolOption {
+	return func(t *IsolatedAgentTool) {
+		t.shareCredentials = share
+	}
+}
+
+// NewIsolatedAgentTool returns a new [IsolatedAgentTool] running agent, named and described after it.
+func NewIsolatedAgentTool(agent types.Agent, opts ...IsolatedAgentToolOption) *IsolatedAgentTool {
+	t := &IsolatedAgentTool{
+		Tool:        tool.NewTool(agent.Name(), agent.Description(), false),
+		agent:       agent,
+		shareMemory: true,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Name implements [types.Tool].
+func (t *IsolatedAgentTool) Name() string {
+	return t.Tool.Name()
+}
+
+// Description implements [types.Tool].
+func (t *IsolatedAgentTool) Description() string {
+	return t.Tool.Description()
+}
+
+// IsLongRunning implements [types.Tool].
+func (t *IsolatedAgentTool) IsLongRunning() bool {
+	return t.Tool.IsLongRunning()
+}
+
+// GetDeclaration implements [types.Tool].
+func (t *IsolatedAgentTool) GetDeclaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+		Parameters: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"request": {
+					Type:        genai.TypeString,
+					Description: "The request for the agent, with all the context it needs, as it does not see the conversation.",
+				},
+			},
+			Required: []string{"request"},
+		},
+	}
+}
+
+// Run implements [types.Tool].
+//
+// It runs the agent to completion in a new session seeded with the request, and returns the text
+// of its final answer as the "result". The session is deleted afterwards, whether the run
+// succeeded or not.
+func (t *IsolatedAgentTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	request, _ := args["request"].(string)
+	if request == "" {
+		return nil, errors.New("isolated agent: missing request")
+	}
+
+	appName, userID := t.agent.Name(), "user"
+	var parent *types.InvocationContext
+	if toolCtx != nil {
+		parent = toolCtx.InvocationContext()
+	}
+	if parent != nil && parent.Session != nil {
+		appName, userID = parent.AppName(), parent.UserID()
+	}
+
+	svc := session.NewInMemoryService()
+	ses, err := svc.CreateSession(ctx, appName, userID, uuid.NewString(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("isolated agent: create session: %w", err)
+	}
+	defer svc.DeleteSession(context.WithoutCancel(ctx), appName, userID, ses.ID())
+
+	content := genai.NewContentFromText(request, genai.RoleUser)
+	ictx := types.NewInvocationContext(t.agent, ses, svc, types.WithUserContent(content))
+	if parent != nil {
+		if t.shareMemory {
+			ictx.MemoryService = parent.MemoryService
+		}
+		if t.shareArtifacts {
+			ictx.ArtifactService = parent.ArtifactService
+		}
+		if t.shareCredentials {
+			ictx.CredentialService = parent.CredentialService
+		}
+		ictx.RunConfig = parent.RunConfig
+	}
+	defer ictx.CancelJobs()
+
+	userEvent := types.NewEvent().
+		WithInvocationID(ictx.InvocationID).
+		WithAuthor("user").
+		WithContent(content)
+	if _, err := svc.AppendEvent(ctx, ses, userEvent); err != nil {
+		return nil, fmt.Errorf("isolated agent: append request: %w", err)
+	}
+
+	answer, err := runIsolated(ctx, t.agent, ictx)
+	if err != nil {
+		return nil, fmt.Errorf("isolated agent %s: %w", t.agent.Name(), err)
+	}
+
+	return map[string]any{"result": answer}, nil
+}
+
+// ProcessLLMRequest implements [types.Tool].
+func (t *IsolatedAgentTool) ProcessLLMRequest(ctx context.Context, toolCtx *types.ToolContext, request *types.LLMRequest) error {
+	return t.Tool.ProcessLLMRequest(ctx, toolCtx, request)
+}
+
+// runIsolated runs the agent until it completes, appending its events to its session, and returns
+// the text of its last final response, without the thoughts.
+func runIsolated(ctx context.Context, agent types.Agent, ictx *types.InvocationContext) (string, error) {
+	var answer string
+	for event, err := range agent.Run(ctx, ictx) {
+		if err != nil {
+			return "", err
+		}
+		if event == nil {
+			continue
+		}
+		if _, err := ictx.SessionService.AppendEvent(ctx, ictx.Session, event); err != nil {
+			return "", fmt.Errorf("append event of %s: %w", event.Author, err)
+		}
+
+		if text, ok := event.FinalText(); ok {
+			answer = text
+		}
+	}
+
+	return answer, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/memory"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// echoAgent answers with the texts of the events of its session, and records its invocation context.
+type echoAgent struct {
+	types.Agent
+
+	ictx *types.InvocationContext
+}
+
+func (a *echoAgent) Name() string {
+	return "echo"
+}
+
+func (a *echoAgent) Description() string {
+	return "Echoes the session."
+}
+
+func (a *echoAgent) Run(ctx context.Context, ictx *types.InvocationContext) iter.Seq2[*types.Event, error] {
+	return func(yield func(*types.Event, error) bool) {
+		a.ictx = ictx
+
+		var parts []*genai.Part
+		for _, event := range ictx.Session.Events() {
+			for _, part := range event.Content.Parts {
+				parts = append(parts, genai.NewPartFromText(event.Author+": "+part.Text+"\n"))
+			}
+		}
+		parts = append(parts, &genai.Part{Text: "done", Thought: true})
+		yield(types.NewEvent().
+			WithAuthor(a.Name()).
+			WithContent(genai.NewContentFromParts(parts, genai.RoleModel)).
+			WithActions(types.NewEventActions()), nil)
+	}
+}
+
+func TestIsolatedAgentTool(t *testing.T) {
+	t.Parallel()
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ses.AddEvent(types.NewEvent().WithAuthor("user").WithContent(genai.NewContentFromText("private", genai.RoleUser)))
+	memorySvc := memory.NewInMemoryService()
+	parent := types.NewInvocationContext(nil, ses, nil, types.WithMemoryService(memorySvc))
+	toolCtx := types.NewToolContext(parent)
+
+	tests := map[string]struct {
+		opts       []tools.IsolatedAgentToolOption
+		wantMemory types.MemoryService
+	}{
+		"shares memory by default": {
+			wantMemory: memorySvc,
+		},
+		"isolated memory": {
+			opts: []tools.IsolatedAgentToolOption{tools.WithSharedMemory(false)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			agent := &echoAgent{}
+			tool := tools.NewIsolatedAgentTool(agent, tt.opts...)
+			if tool.Name() != "echo" || tool.Description() != "Echoes the session." {
+				t.Errorf("NewIsolatedAgentTool() = (%q, %q), want the name and description of the agent", tool.Name(), tool.Description())
+			}
+
+			got, err := tool.Run(t.Context(), map[string]any{"request": "hello"}, toolCtx)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if diff := cmp.Diff(map[string]any{"result": "user: hello\n"}, got); diff != "" {
+				t.Errorf("Run() mismatch (-want +got):\n%s", diff)
+			}
+
+			if agent.ictx.Session.ID() == ses.ID() {
+				t.Error("Run() ran the agent in the calling session")
+			}
+			if agent.ictx.MemoryService != tt.wantMemory {
+				t.Errorf("Run() memory service = %v, want %v", agent.ictx.MemoryService, tt.wantMemory)
+			}
+			if agent.ictx.SessionService == nil {
+				t.Fatal("Run() ran the agent without a session service")
+			}
+			if _, err := agent.ictx.SessionService.GetSession(t.Context(), "app", "user", agent.ictx.Session.ID(), nil); err == nil {
+				t.Error("Run() kept the ephemeral session")
+			}
+			if n := len(ses.Events()); n != 1 {
+				t.Errorf("calling session has %d events, want 1", n)
+			}
+		})
+	}
+
+	if _, err := tools.NewIsolatedAgentTool(&echoAgent{}).Run(t.Context(), map[string]any{}, toolCtx); err == nil {
+		t.Error("Run() without request succeeded")
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/tool"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// ListToolsTool represents a tool that lists the other tools available to the agent in the current invocation.
+//
+// It resolves the tools of the agent with the current context, so that the tools of a toolset
+// hidden this turn are not reported, and never reports itself.
+type ListToolsTool struct {
+	*tool.Tool
+}
+
+var _ types.Tool = (*ListToolsTool)(nil)
+
+// NewListToolsTool returns the new [ListToolsTool].
+func NewListToolsTool() *ListToolsTool {
+	return &ListToolsTool{
+		Tool: tool.NewTool("list_tools", "Lists the names, descriptions and parameters of the other tools you can call.", false),
+	}
+}
+
+// Name implements [types.Tool].
+func (t *ListToolsTool) Name() string {
+	return t.Tool.Name()
+}
+
+// Description implements [types.Tool].
+func (t *ListToolsTool) Description() string {
+	return t.Tool.Description()
+}
+
+// IsLongRunning implements [types.Tool].
+func (t *ListToolsTool) IsLongRunning() bool {
+	return t.Tool.IsLongRunning()
+}
+
+// GetDeclaration implements [types.Tool].
+func (t *ListToolsTool) GetDeclaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+		Parameters: &genai.Schema{
+			Type: genai.TypeObject,
+		},
+	}
+}
+
+// Run implements [types.Tool].
+//
+// It returns the tools under the "tools" key, each with its "name", "description", "parameters"
+// schema when it declares parameters, and "is_long_running".
+func (t *ListToolsTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	ictx := toolCtx.InvocationContext()
+	if ictx == nil || ictx.Agent == nil {
+		return nil, errors.New("list tools: no agent in the invocation context")
+	}
+	llmAgent, ok := ictx.Agent.AsLLMAgent()
+	if !ok {
+		return nil, errors.New("list tools: agent " + ictx.Agent.Name() + " has no tools")
+	}
+
+	tools := []map[string]any{}
+	for _, tool := range llmAgent.CanonicalTool(types.NewReadOnlyContext(ictx)) {
+		if tool == nil || tool.Name() == t.Name() {
+			continue
+		}
+
+		info := map[string]any{
+			"name":            tool.Name(),
+			"description":     tool.Description(),
+			"is_long_running": tool.IsLongRunning(),
+		}
+		if decl := tool.GetDeclaration(); decl != nil {
+			if decl.Description != "" {
+				info["description"] = decl.Description
+			}
+			switch {
+			case decl.Parameters != nil:
+				info["parameters"] = decl.Parameters
+			case decl.ParametersJsonSchema != nil:
+				info["parameters"] = decl.ParametersJsonSchema
+			}
+		}
+		tools = append(tools, info)
+	}
+
+	return map[string]any{
+		"tools": tools,
+	}, nil
+}
+
+// ProcessLLMRequest implements [types.Tool].
+func (t *ListToolsTool) ProcessLLMRequest(ctx context.Context, toolCtx *types.ToolContext, request *types.LLMRequest) error {
+	return t.Tool.ProcessLLMRequest(ctx, toolCtx, request)
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/agent"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// adminToolset exposes its tools only to the admin users.
+type adminToolset struct {
+	tools []types.Tool
+}
+
+func (s *adminToolset) GetTools(rctx *types.ReadOnlyContext) []types.Tool {
+	if admin, _ := rctx.GetBool("user:admin"); admin {
+		return s.tools
+	}
+	return nil
+}
+
+func (s *adminToolset) Close() {}
+
+func TestListToolsTool(t *testing.T) {
+	t.Parallel()
+
+	listTools := tools.NewListToolsTool()
+	a, err := agent.NewLLMAgent(t.Context(), "assistant",
+		agent.WithTools(listTools, tools.NewLoadArtifactsTool()),
+		agent.WithToolset(&adminToolset{tools: []types.Tool{tools.NewGoogleSearchTool()}}),
+	)
+	if err != nil {
+		t.Fatalf("NewLLMAgent: %v", err)
+	}
+
+	tests := map[string]struct {
+		state map[string]any
+		want  []string
+	}{
+		"gated toolset hidden": {
+			want: []string{"load_artifacts"},
+		},
+		"gated toolset exposed": {
+			state: map[string]any{"user:admin": true},
+			want:  []string{"load_artifacts", "google_search"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ses := session.NewSession("app", "user", "session", tt.state, time.Now())
+			ictx := types.NewInvocationContext(a, ses, session.NewInMemoryService())
+
+			result, err := listTools.Run(t.Context(), nil, types.NewToolContext(ictx))
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			var names []string
+			for _, tool := range result.(map[string]any)["tools"].([]map[string]any) {
+				names = append(names, tool["name"].(string))
+				if tool["description"] == "" {
+					t.Errorf("tool %s has no description", tool["name"])
+				}
+			}
+			if diff := cmp.Diff(tt.want, names); diff != "" {
+				t.Errorf("listed tools mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
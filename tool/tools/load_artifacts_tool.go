@@ -5,7 +5,11 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/go-json-experiment/json"
 	"google.golang.org/genai"
@@ -16,18 +20,77 @@ import (
 	"github.com/go-a2a/adk-go/types"
 )
 
+// ArtifactTruncationMarker ends the text of an artifact truncated by [WithMaxArtifactBytes].
+const ArtifactTruncationMarker = "\n...(truncated)"
+
+// OversizedArtifactAction is what [LoadArtifactsTool] does with an artifact larger than its
+// maximum size.
+type OversizedArtifactAction int
+
+const (
+	// TruncateOversizedArtifact truncates the text artifacts to the maximum size, followed by
+	// [ArtifactTruncationMarker]. The binary artifacts, which cannot be truncated, are refused.
+	TruncateOversizedArtifact OversizedArtifactAction = iota
+
+	// RefuseOversizedArtifact replaces the oversized artifacts with a note giving their size.
+	RefuseOversizedArtifact
+)
+
 // LoadArtifactsTool represents a tool that loads the artifacts and adds them to the session.
 type LoadArtifactsTool struct {
 	*tool.Tool
+
+	maxArtifactBytes int
+	oversizedAction  OversizedArtifactAction
+	loadTimeout      time.Duration
+	metadataOnly     bool
 }
 
 var _ types.Tool = (*LoadArtifactsTool)(nil)
 
+// LoadArtifactsToolOption configures a [LoadArtifactsTool].
+type LoadArtifactsToolOption func(*LoadArtifactsTool)
+
+// WithMaxArtifactBytes caps the size of the content of each loaded artifact to n bytes, handling
+// the larger artifacts with action.
+//
+// Zero means no limit.
+func WithMaxArtifactBytes(n int, action OversizedArtifactAction) LoadArtifactsToolOption {
+	return func(t *LoadArtifactsTool) {
+		t.maxArtifactBytes = n
+		t.oversizedAction = action
+	}
+}
+
+// WithArtifactLoadTimeout bounds the time to load each artifact. An artifact not loaded in time is
+// reported to the model instead of stalling the run.
+//
+// Zero means no timeout.
+func WithArtifactLoadTimeout(timeout time.Duration) LoadArtifactsToolOption {
+	return func(t *LoadArtifactsTool) {
+		t.loadTimeout = timeout
+	}
+}
+
+// WithArtifactMetadataOnly makes the tool always give the name, size and MIME type of the
+// artifacts instead of their content.
+//
+// Without it, the model can still ask for the metadata only with the metadata_only argument.
+func WithArtifactMetadataOnly() LoadArtifactsToolOption {
+	return func(t *LoadArtifactsTool) {
+		t.metadataOnly = true
+	}
+}
+
 // NewLoadArtifactsTool returns the new [LoadArtifactsTool].
-func NewLoadArtifactsTool() *LoadArtifactsTool {
-	return &LoadArtifactsTool{
+func NewLoadArtifactsTool(opts ...LoadArtifactsToolOption) *LoadArtifactsTool {
+	t := &LoadArtifactsTool{
 		Tool: tool.NewTool("load_artifacts", "Loads the artifacts and adds them to the session.", false),
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
 }
 
 // Name implements [types.Tool].
@@ -59,6 +122,10 @@ func (t *LoadArtifactsTool) GetDeclaration() *genai.FunctionDeclaration {
 						Type: genai.TypeString,
 					},
 				},
+				"metadata_only": {
+					Type:        genai.TypeBoolean,
+					Description: "If true, only loads the name, size and MIME type of the artifacts, not their content.",
+				},
 			},
 		},
 	}
@@ -74,6 +141,9 @@ func (t *LoadArtifactsTool) Run(ctx context.Context, args map[string]any, toolCt
 	result := map[string]any{
 		"artifact_names": artifactNames,
 	}
+	if metadataOnly, _ := args["metadata_only"].(bool); metadataOnly || t.metadataOnly {
+		result["metadata_only"] = true
+	}
 
 	return result, nil
 }
@@ -123,16 +193,12 @@ func (t *LoadArtifactsTool) appendArtifactsToLLMRequest(ctx context.Context, too
 	if len(request.Contents) > 0 && len(request.Contents[len(request.Contents)-1].Parts) > 0 {
 		funcResponse := request.Contents[len(request.Contents)-1].Parts[0].FunctionResponse
 		if funcResponse != nil && funcResponse.Name == "load_artifacts" {
-			artifactNames := funcResponse.Response["artifact_names"]
-			for _, artifactName := range artifactNames.([]string) {
-				artifact, err := toolCtx.LoadArtifact(ctx, artifactName, 0)
+			metadataOnly, _ := funcResponse.Response["metadata_only"].(bool)
+			for _, artifactName := range toStrings(funcResponse.Response["artifact_names"]) {
+				parts, err := t.loadArtifact(ctx, toolCtx, artifactName, metadataOnly || t.metadataOnly)
 				if err != nil {
 					return err
 				}
-				parts := []*genai.Part{
-					genai.NewPartFromText(fmt.Sprintf("Artifact %s is:", artifactNames)),
-					artifact,
-				}
 				request.Contents = append(request.Contents, genai.NewContentFromParts(parts, model.ToGenAIRole(model.RoleUser)))
 			}
 		}
@@ -140,3 +206,97 @@ func (t *LoadArtifactsTool) appendArtifactsToLLMRequest(ctx context.Context, too
 
 	return nil
 }
+
+// loadArtifact returns the parts giving the artifact to the model, applying the load timeout and
+// the size limit of the tool.
+//
+// A missing or timed out artifact is reported to the model rather than as an error.
+func (t *LoadArtifactsTool) loadArtifact(ctx context.Context, toolCtx *types.ToolContext, name string, metadataOnly bool) ([]*genai.Part, error) {
+	loadCtx := ctx
+	if t.loadTimeout > 0 {
+		var cancel context.CancelFunc
+		loadCtx, cancel = context.WithTimeout(ctx, t.loadTimeout)
+		defer cancel()
+	}
+	artifact, err := toolCtx.LoadArtifact(loadCtx, name, 0)
+	switch {
+	case err != nil && t.loadTimeout > 0 && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil:
+		return []*genai.Part{genai.NewPartFromText(fmt.Sprintf("Artifact %s could not be loaded: timed out after %s.", name, t.loadTimeout))}, nil
+	case err != nil:
+		return nil, err
+	case artifact == nil:
+		return []*genai.Part{genai.NewPartFromText(fmt.Sprintf("Error: artifact %s was not found.", name))}, nil
+	}
+
+	size, mimeType := artifactSize(artifact)
+	if metadataOnly {
+		return []*genai.Part{genai.NewPartFromText(fmt.Sprintf("Artifact %s: %d bytes, MIME type %s.", name, size, mimeType))}, nil
+	}
+
+	if t.maxArtifactBytes > 0 && size > t.maxArtifactBytes {
+		truncated, ok := truncateArtifact(artifact, t.maxArtifactBytes)
+		if t.oversizedAction == RefuseOversizedArtifact || !ok {
+			return []*genai.Part{genai.NewPartFromText(fmt.Sprintf(
+				"Artifact %s was not loaded: its %d bytes exceed the limit of %d bytes.", name, size, t.maxArtifactBytes))}, nil
+		}
+		artifact = truncated
+	}
+
+	return []*genai.Part{
+		genai.NewPartFromText(fmt.Sprintf("Artifact %s is:", name)),
+		artifact,
+	}, nil
+}
+
+// artifactSize returns the size in bytes and the MIME type of the content of the artifact.
+func artifactSize(artifact *genai.Part) (int, string) {
+	switch {
+	case artifact.InlineData != nil:
+		return len(artifact.InlineData.Data), artifact.InlineData.MIMEType
+	case artifact.FileData != nil:
+		return 0, artifact.FileData.MIMEType
+	default:
+		return len(artifact.Text), "text/plain"
+	}
+}
+
+// truncateArtifact returns a copy of the text artifact truncated to n bytes with
+// [ArtifactTruncationMarker], or false if the artifact is not text.
+func truncateArtifact(artifact *genai.Part, n int) (*genai.Part, bool) {
+	truncate := func(s string) string {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return s[:n] + ArtifactTruncationMarker
+	}
+
+	switch {
+	case artifact.InlineData != nil:
+		if !strings.HasPrefix(artifact.InlineData.MIMEType, "text/") {
+			return nil, false
+		}
+		return genai.NewPartFromText(truncate(string(artifact.InlineData.Data))), true
+	case artifact.FileData != nil:
+		return nil, false
+	default:
+		return genai.NewPartFromText(truncate(artifact.Text)), true
+	}
+}
+
+// toStrings returns the strings of v, a []string or a []any decoded from JSON.
+func toStrings(v any) []string {
+	switch v := v.(type) {
+	case []string:
+		return v
+	case []any:
+		ss := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				ss = append(ss, s)
+			}
+		}
+		return ss
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/artifact"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// slowArtifactService delays the loading of the artifact named slow.txt until the context is done.
+type slowArtifactService struct {
+	*artifact.InMemoryService
+}
+
+func (s *slowArtifactService) LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error) {
+	if filename == "slow.txt" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return s.InMemoryService.LoadArtifact(ctx, appName, userID, sessionID, filename, version)
+}
+
+func TestLoadArtifactsTool(t *testing.T) {
+	t.Parallel()
+
+	svc := &slowArtifactService{InMemoryService: artifact.NewInMemoryService()}
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	for name, part := range map[string]*genai.Part{
+		"notes.txt": genai.NewPartFromText(strings.Repeat("a", 20)),
+		"image.png": genai.NewPartFromBytes(make([]byte, 20), "image/png"),
+		"slow.txt":  genai.NewPartFromText("slow"),
+	} {
+		if _, err := svc.SaveArtifact(t.Context(), "app", "user", "session", name, part); err != nil {
+			t.Fatalf("SaveArtifact(%s): %v", name, err)
+		}
+	}
+	ictx := types.NewInvocationContext(nil, ses, nil, types.WithArtifactService(svc))
+
+	tests := map[string]struct {
+		opts         []tools.LoadArtifactsToolOption
+		names        []any
+		metadataOnly bool
+		want         []string
+	}{
+		"content": {
+			names: []any{"notes.txt"},
+			want:  []string{"Artifact notes.txt is:", strings.Repeat("a", 20)},
+		},
+		"missing": {
+			names: []any{"missing.txt"},
+			want:  []string{"Error: artifact missing.txt was not found."},
+		},
+		"truncated": {
+			opts:  []tools.LoadArtifactsToolOption{tools.WithMaxArtifactBytes(5, tools.TruncateOversizedArtifact)},
+			names: []any{"notes.txt", "image.png"},
+			want: []string{
+				"Artifact notes.txt is:", "aaaaa" + tools.ArtifactTruncationMarker,
+				"Artifact image.png was not loaded: its 20 bytes exceed the limit of 5 bytes.",
+			},
+		},
+		"refused": {
+			opts:  []tools.LoadArtifactsToolOption{tools.WithMaxArtifactBytes(5, tools.RefuseOversizedArtifact)},
+			names: []any{"notes.txt"},
+			want:  []string{"Artifact notes.txt was not loaded: its 20 bytes exceed the limit of 5 bytes."},
+		},
+		"metadata only": {
+			names:        []any{"image.png"},
+			metadataOnly: true,
+			want:         []string{"Artifact image.png: 20 bytes, MIME type image/png."},
+		},
+		"timeout": {
+			opts:  []tools.LoadArtifactsToolOption{tools.WithArtifactLoadTimeout(10 * time.Millisecond)},
+			names: []any{"slow.txt"},
+			want:  []string{"Artifact slow.txt could not be loaded: timed out after 10ms."},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tool := tools.NewLoadArtifactsTool(tt.opts...)
+			toolCtx := types.NewToolContext(ictx)
+			result, err := tool.Run(t.Context(), map[string]any{"artifact_names": tt.names, "metadata_only": tt.metadataOnly}, toolCtx)
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+			funcResponse := genai.NewPartFromFunctionResponse(tool.Name(), result.(map[string]any))
+			request := &types.LLMRequest{
+				Contents: []*genai.Content{genai.NewContentFromParts([]*genai.Part{funcResponse}, genai.RoleUser)},
+			}
+			if err := tool.ProcessLLMRequest(t.Context(), toolCtx, request); err != nil {
+				t.Fatalf("ProcessLLMRequest: %v", err)
+			}
+
+			var got []string
+			for _, content := range request.Contents[1:] {
+				for _, part := range content.Parts {
+					got = append(got, part.Text)
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("loaded artifacts mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
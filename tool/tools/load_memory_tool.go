@@ -5,6 +5,7 @@ package tools
 
 import (
 	"context"
+	"errors"
 
 	"github.com/go-a2a/adk-go/types"
 )
@@ -12,11 +13,23 @@ import (
 // LoadMemoryResponse represents a response from the LoadMemory tool.
 type LoadMemoryResponse struct {
 	memories []*types.MemoryEntry
+
+	// Message explains why no memory was loaded, if any.
+	Message string `json:"message,omitempty"`
 }
 
+// noMemoryServiceMessage is the message of the memory tools used without a memory service.
+const noMemoryServiceMessage = "no memory service configured; proceeding without memory"
+
 // LoadMemory loads the memory for the current user.
+//
+// Without a memory service, it returns no memory with a message saying so rather than an error,
+// so that the agent keeps working without memory.
 func LoadMemory(ctx context.Context, query string, toolCtx *types.ToolContext) (*LoadMemoryResponse, error) {
 	searchMemoryResponse, err := toolCtx.SearchMemory(ctx, query)
+	if errors.Is(err, types.ErrNoMemoryService) {
+		return &LoadMemoryResponse{Message: noMemoryServiceMessage}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,18 @@
 
 package tools
 
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
 // LongRunningFunctionTool represents a function tool that returns the result asynchronously.
 //
 // This tool is used for long-running operations that may take a significant
@@ -10,18 +22,130 @@ package tools
 // function returns, the response will be returned asynchronously to the
 // framework which is identified by the function_call_id.
 //
+// The function starts its background work with [StartJob], so that the work is cancelled by
+// [LongRunningFunctionTool.Cancel] or with the invocation, see [types.InvocationContext.CancelJobs].
+//
 // Example:
 //
 //	tool = LongRunningFunctionTool(a_long_running_function)
 type LongRunningFunctionTool struct {
 	*FunctionTool
+
+	mu   sync.Mutex
+	jobs map[string]*runningJob
 }
 
+var (
+	_ types.Tool        = (*LongRunningFunctionTool)(nil)
+	_ types.JobCanceler = (*LongRunningFunctionTool)(nil)
+)
+
 // NewLongRunningFunctionTool returns the new [LongRunningFunctionTool] with the given function.
 func NewLongRunningFunctionTool(fn Function) *LongRunningFunctionTool {
 	t := &LongRunningFunctionTool{
 		FunctionTool: NewFunctionTool(fn),
+		jobs:         make(map[string]*runningJob),
 	}
 	t.FunctionTool.Tool.SetLongRunning(true)
 	return t
 }
+
+// Job is the background work of a long-running tool. It must return when ctx is done.
+//
+// The result of the work is reported by the job itself, typically to the session or to an
+// external system queried by the agent.
+type Job func(ctx context.Context)
+
+// runningJob is a started [Job].
+type runningJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// jobStarterKey is the context key of the [jobStarter] of a call of a [LongRunningFunctionTool].
+type jobStarterKey struct{}
+
+// jobStarter starts the jobs of a call of a [LongRunningFunctionTool].
+type jobStarter struct {
+	tool    *LongRunningFunctionTool
+	toolCtx *types.ToolContext
+}
+
+// Run implements [types.Tool].
+func (t *LongRunningFunctionTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	ctx = context.WithValue(ctx, jobStarterKey{}, &jobStarter{tool: t, toolCtx: toolCtx})
+	return t.FunctionTool.Run(ctx, args, toolCtx)
+}
+
+// StartJob starts job in a new goroutine as the background work of the [LongRunningFunctionTool]
+// whose function is called with ctx, and returns the ID of the job.
+//
+// The job outlives the call, but not the invocation: it is registered to the invocation context
+// of the call and is cancelled with it. It can also be cancelled with [LongRunningFunctionTool.Cancel].
+func StartJob(ctx context.Context, job Job) (string, error) {
+	starter, ok := ctx.Value(jobStarterKey{}).(*jobStarter)
+	if !ok {
+		return "", errors.New("start job: not called from a long-running function tool")
+	}
+	return starter.tool.startJob(ctx, starter.toolCtx, job), nil
+}
+
+// startJob starts the job with a context detached from the cancellation of ctx.
+func (t *LongRunningFunctionTool) startJob(ctx context.Context, toolCtx *types.ToolContext, job Job) string {
+	jobID := uuid.NewString()
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	rj := &runningJob{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	t.mu.Lock()
+	t.jobs[jobID] = rj
+	t.mu.Unlock()
+
+	var ictx *types.InvocationContext
+	if toolCtx != nil {
+		ictx = toolCtx.InvocationContext()
+	}
+	if ictx != nil {
+		ictx.RegisterJob(jobID, t)
+	}
+
+	go func() {
+		defer close(rj.done)
+		defer cancel()
+
+		job(jobCtx)
+
+		t.mu.Lock()
+		delete(t.jobs, jobID)
+		t.mu.Unlock()
+		if ictx != nil {
+			ictx.UnregisterJob(jobID)
+		}
+	}()
+
+	return jobID
+}
+
+// Cancel implements [types.JobCanceler].
+func (t *LongRunningFunctionTool) Cancel(jobID string) error {
+	t.mu.Lock()
+	rj, ok := t.jobs[jobID]
+	t.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	rj.cancel()
+	<-rj.done
+	return nil
+}
+
+// Jobs returns the IDs of the running jobs of the tool.
+func (t *LongRunningFunctionTool) Jobs() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(t.jobs))
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// newJobTool returns a long-running tool starting a job running until cancelled, and signaling
+// started when it runs.
+func newJobTool(t *testing.T, started chan<- struct{}) *tools.LongRunningFunctionTool {
+	t.Helper()
+
+	return tools.NewLongRunningFunctionTool(func(ctx context.Context, args map[string]any) (any, error) {
+		jobID, err := tools.StartJob(ctx, func(ctx context.Context) {
+			started <- struct{}{}
+			<-ctx.Done()
+		})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"job_id": jobID, "status": "started"}, nil
+	})
+}
+
+// waitGoroutines waits for the number of goroutines to fall back to want.
+func waitGoroutines(t *testing.T, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > want {
+		if time.Now().After(deadline) {
+			t.Fatalf("goroutines = %d after cancellation, want %d", runtime.NumGoroutine(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// Tests counting the goroutines do not run in parallel.
+
+func TestLongRunningFunctionTool_Cancel(t *testing.T) {
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(nil, ses, session.NewInMemoryService())
+	started := make(chan struct{})
+	tool := newJobTool(t, started)
+	baseline := runtime.NumGoroutine()
+
+	result, err := tool.Run(t.Context(), nil, types.NewToolContext(ictx))
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	<-started
+	jobID := result.(map[string]any)["job_id"].(string)
+	if got := tool.Jobs(); len(got) != 1 || got[0] != jobID {
+		t.Fatalf("Jobs() = %v, want [%s]", got, jobID)
+	}
+
+	if err := tool.Cancel(jobID); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if got := tool.Jobs(); len(got) != 0 {
+		t.Errorf("Jobs() after Cancel = %v, want none", got)
+	}
+	waitGoroutines(t, baseline)
+
+	// Cancelling a finished job is a no-op.
+	if err := tool.Cancel(jobID); err != nil {
+		t.Errorf("Cancel() of a finished job error = %v", err)
+	}
+}
+
+func TestLongRunningFunctionTool_CancelWithInvocation(t *testing.T) {
+	const numJobs = 10
+
+	ses := session.NewSession("app", "user", "session", nil, time.Now())
+	ictx := types.NewInvocationContext(nil, ses, session.NewInMemoryService())
+	started := make(chan struct{}, numJobs)
+	tool := newJobTool(t, started)
+	baseline := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	ictx.CancelJobsOnDone(ctx)
+	for range numJobs {
+		// The jobs outlive the context of the call.
+		callCtx, cancelCall := context.WithCancel(ctx)
+		if _, err := tool.Run(callCtx, nil, types.NewToolContext(ictx)); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		cancelCall()
+	}
+	for range numJobs {
+		<-started
+	}
+	if got := len(tool.Jobs()); got != numJobs {
+		t.Fatalf("running jobs = %d, want %d", got, numJobs)
+	}
+
+	cancel()
+	waitGoroutines(t, baseline)
+	if got := tool.Jobs(); len(got) != 0 {
+		t.Errorf("Jobs() after the invocation is cancelled = %v, want none", got)
+	}
+}
+
+func TestStartJob_OutsideLongRunningTool(t *testing.T) {
+	t.Parallel()
+
+	if _, err := tools.StartJob(t.Context(), func(context.Context) {}); err == nil {
+		t.Error("StartJob() error = nil, want an error")
+	}
+}
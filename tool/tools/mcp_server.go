@@ -0,0 +1,286 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/modelcontextprotocol/go-sdk/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// DefaultMCPShutdownTimeout is the time given to the in-flight requests to complete on shutdown of an [MCPServer].
+const DefaultMCPShutdownTimeout = 10 * time.Second
+
+// MCPServer serves the tools of a [types.Toolset] to Model Context Protocol clients, over stdio or SSE.
+//
+// Each tool is advertised with its function declaration translated to an MCP tool, and each MCP
+// tool call runs [types.Tool.Run] with the call arguments. An error of the tool is returned to
+// the client as a tool result flagged as an error, so that the model calling the tool sees it.
+//
+// The tools are resolved once, when the server is created, with the invocation context of the
+// server. The toolset is closed by [MCPServer.Close].
+type MCPServer struct {
+	server  *mcp.Server
+	toolset types.Toolset
+
+	version           string
+	appName           string
+	shutdownTimeout   time.Duration
+	invocationContext func(ctx context.Context) *types.InvocationContext
+
+	closeOnce sync.Once
+}
+
+// MCPServerOption configures an [MCPServer].
+type MCPServerOption func(*MCPServer)
+
+// WithMCPServerVersion sets the version of the server advertised to the clients.
+func WithMCPServerVersion(version string) MCPServerOption {
+	return func(s *MCPServer) {
+		s.version = version
+	}
+}
+
+// WithMCPAppName sets the application name of the sessions of the default invocation contexts.
+//
+// It defaults to the name of the server.
+func WithMCPAppName(appName string) MCPServerOption {
+	return func(s *MCPServer) {
+		s.appName = appName
+	}
+}
+
+// WithMCPShutdownTimeout sets the time given to the in-flight requests to complete on shutdown.
+//
+// The default is [DefaultMCPShutdownTimeout].
+func WithMCPShutdownTimeout(timeout time.Duration) MCPServerOption {
+	return func(s *MCPServer) {
+		s.shutdownTimeout = timeout
+	}
+}
+
+// WithMCPInvocationContext sets the function returning the invocation context of each tool call,
+// and of the resolution of the tools of the toolset.
+//
+// By default, each call runs with a new invocation context whose session is a new, empty
+// in-memory session, so that state changes of a call are not seen by the following calls.
+func WithMCPInvocationContext(fn func(ctx context.Context) *types.InvocationContext) MCPServerOption {
+	return func(s *MCPServer) {
+		s.invocationContext = fn
+	}
+}
+
+// NewMCPServer returns a new [MCPServer] with the given name serving the tools of toolset.
+//
+// It returns an error if a tool has no declaration or a declaration that cannot be translated
+// to an MCP tool.
+func NewMCPServer(ctx context.Context, name string, toolset types.Toolset, opts ...MCPServerOption) (*MCPServer, error) {
+	s := &MCPServer{
+		toolset:         toolset,
+		version:         "v0.0.0",
+		appName:         name,
+		shutdownTimeout: DefaultMCPShutdownTimeout,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.invocationContext == nil {
+		s.invocationContext = s.newInvocationContext
+	}
+
+	s.server = mcp.NewServer(&mcp.Implementation{Name: name, Version: s.version}, nil)
+	for _, t := range toolset.GetTools(types.NewReadOnlyContext(s.invocationContext(ctx))) {
+		mcpTool, err := toMCPTool(t)
+		if err != nil {
+			return nil, err
+		}
+		s.server.AddTool(mcpTool, s.toolHandler(t))
+	}
+
+	return s, nil
+}
+
+// Server returns the underlying MCP server, to serve it over other transports.
+func (s *MCPServer) Server() *mcp.Server {
+	return s.server
+}
+
+// ServeStdio serves a single client over the standard input and output until the client
+// disconnects or ctx is done.
+//
+// It returns nil when ctx is done.
+func (s *MCPServer) ServeStdio(ctx context.Context) error {
+	err := s.server.Run(ctx, mcp.NewStdioTransport())
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		return nil
+	}
+	return err
+}
+
+// SSEHandler returns the [http.Handler] serving the clients over server-sent events.
+func (s *MCPServer) SSEHandler() http.Handler {
+	return mcp.NewSSEHandler(func(*http.Request) *mcp.Server {
+		return s.server
+	})
+}
+
+// ServeSSE serves the clients over server-sent events on the listener until ctx is done,
+// then shuts down gracefully, closing the client sessions and waiting for the in-flight
+// requests up to the shutdown timeout.
+//
+// It returns nil after a graceful shutdown.
+func (s *MCPServer) ServeSSE(ctx context.Context, l net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.SSEHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.Serve(l)
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	for ss := range s.server.Sessions() {
+		ss.Close()
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shut down MCP server: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the client sessions and the toolset.
+func (s *MCPServer) Close() error {
+	s.closeOnce.Do(func() {
+		for ss := range s.server.Sessions() {
+			ss.Close()
+		}
+		s.toolset.Close()
+	})
+	return nil
+}
+
+// newInvocationContext returns the default invocation context, with a new in-memory session.
+func (s *MCPServer) newInvocationContext(ctx context.Context) *types.InvocationContext {
+	ses := session.NewSession(s.appName, "mcp", types.NewInvocationContextID(), map[string]any{}, time.Now())
+	ictx := types.NewInvocationContext(nil, ses, session.NewInMemoryService())
+	ictx.InvocationID = types.NewInvocationContextID()
+	return ictx
+}
+
+// toolHandler returns the MCP handler running the tool.
+func (s *MCPServer) toolHandler(t types.Tool) mcp.ToolHandler {
+	return func(ctx context.Context, _ *mcp.ServerSession, params *mcp.CallToolParamsFor[map[string]any]) (*mcp.CallToolResultFor[any], error) {
+		args := params.Arguments
+		if args == nil {
+			args = map[string]any{}
+		}
+		toolCtx := types.NewToolContext(s.invocationContext(ctx)).WithEventActions(types.NewEventActions())
+
+		result, err := t.Run(ctx, args, toolCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		return toMCPResult(result)
+	}
+}
+
+// toMCPTool translates the declaration of the tool to an MCP tool.
+func toMCPTool(t types.Tool) (*mcp.Tool, error) {
+	decl := t.GetDeclaration()
+	if decl == nil {
+		return nil, fmt.Errorf("tool %s has no declaration", t.Name())
+	}
+
+	mcpTool := &mcp.Tool{
+		Name:        t.Name(),
+		Description: decl.Description,
+	}
+	if mcpTool.Description == "" {
+		mcpTool.Description = t.Description()
+	}
+
+	var err error
+	switch {
+	case decl.Parameters != nil:
+		mcpTool.InputSchema, err = FromGeminiSchema(decl.Parameters)
+	case decl.ParametersJsonSchema != nil:
+		mcpTool.InputSchema, err = toJSONSchema(decl.ParametersJsonSchema)
+	default:
+		mcpTool.InputSchema = &jsonschema.Schema{Type: "object"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("convert parameters of tool %s: %w", t.Name(), err)
+	}
+
+	return mcpTool, nil
+}
+
+// toJSONSchema converts a JSON Schema given as any Go value to a [*jsonschema.Schema].
+func toJSONSchema(v any) (*jsonschema.Schema, error) {
+	if schema, ok := v.(*jsonschema.Schema); ok {
+		return schema, nil
+	}
+
+	data, err := json.Marshal(v, json.DefaultOptionsV2())
+	if err != nil {
+		return nil, err
+	}
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(data, &schema, json.DefaultOptionsV2()); err != nil {
+		return nil, err
+	}
+
+	return &schema, nil
+}
+
+// toMCPResult translates the result of a tool to an MCP tool result.
+//
+// A map result is returned as structured content, along with its JSON text for the clients
+// reading only the unstructured content.
+func toMCPResult(result any) (*mcp.CallToolResultFor[any], error) {
+	if text, ok := result.(string); ok {
+		return &mcp.CallToolResultFor[any]{
+			Content: []mcp.Content{&mcp.TextContent{Text: text}},
+		}, nil
+	}
+
+	data, err := json.Marshal(result, json.DefaultOptionsV2())
+	if err != nil {
+		return nil, fmt.Errorf("marshal tool result: %w", err)
+	}
+	mcpResult := &mcp.CallToolResultFor[any]{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}
+	if m, ok := result.(map[string]any); ok {
+		mcpResult.StructuredContent = m
+	}
+
+	return mcpResult, nil
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/tool"
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// divideTool divides two numbers.
+type divideTool struct {
+	*tool.Tool
+}
+
+func (t *divideTool) GetDeclaration() *genai.FunctionDeclaration {
+	return &genai.FunctionDeclaration{
+		Name:        t.Name(),
+		Description: t.Description(),
+		Parameters: &genai.Schema{
+			Type: genai.TypeObject,
+			Properties: map[string]*genai.Schema{
+				"a": {Type: genai.TypeNumber},
+				"b": {Type: genai.TypeNumber},
+			},
+			Required: []string{"a", "b"},
+		},
+	}
+}
+
+func (t *divideTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	a, _ := args["a"].(float64)
+	b, _ := args["b"].(float64)
+	if b == 0 {
+		return nil, errors.New("division by zero")
+	}
+	return map[string]any{"result": a / b}, nil
+}
+
+// staticToolset serves a fixed list of tools.
+type staticToolset struct {
+	tools  []types.Tool
+	closed bool
+}
+
+func (s *staticToolset) GetTools(*types.ReadOnlyContext) []types.Tool { return s.tools }
+
+func (s *staticToolset) Close() { s.closed = true }
+
+func TestMCPServer(t *testing.T) {
+	t.Parallel()
+
+	toolset := &staticToolset{
+		tools: []types.Tool{&divideTool{Tool: tool.NewTool("divide", "Divides a by b.", false)}},
+	}
+	server, err := tools.NewMCPServer(t.Context(), "calculator", toolset)
+	if err != nil {
+		t.Fatalf("NewMCPServer: %v", err)
+	}
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Server().Connect(t.Context(), serverTransport); err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "v1.0.0"}, nil)
+	cs, err := client.Connect(t.Context(), clientTransport)
+	if err != nil {
+		t.Fatalf("connect client: %v", err)
+	}
+	defer cs.Close()
+
+	list, err := cs.ListTools(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	if len(list.Tools) != 1 || list.Tools[0].Name != "divide" || list.Tools[0].Description != "Divides a by b." {
+		t.Fatalf("ListTools() = %+v, want the divide tool", list.Tools)
+	}
+	if got := list.Tools[0].InputSchema.Properties["a"].Type; got != "number" {
+		t.Errorf("input schema type of a = %q, want number", got)
+	}
+
+	result, err := cs.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "divide",
+		Arguments: map[string]any{"a": 6, "b": 3},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("CallTool() returned an error result: %+v", result.Content)
+	}
+	if got := result.Content[0].(*mcp.TextContent).Text; got != `{"result":2}` {
+		t.Errorf("CallTool() text = %s, want {\"result\":2}", got)
+	}
+
+	result, err = cs.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "divide",
+		Arguments: map[string]any{"a": 1, "b": 0},
+	})
+	if err != nil {
+		t.Fatalf("CallTool: %v", err)
+	}
+	if !result.IsError || result.Content[0].(*mcp.TextContent).Text != "division by zero" {
+		t.Errorf("CallTool() = %+v, want the division by zero error result", result)
+	}
+
+	if err := server.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if !toolset.closed {
+		t.Error("Close did not close the toolset")
+	}
+}
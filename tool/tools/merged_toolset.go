@@ -0,0 +1,179 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/go-a2a/adk-go/internal/xreflect"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// ToolFilter reports whether a tool of a toolset merged by [MergeToolsets] is kept.
+type ToolFilter func(tool types.Tool) bool
+
+// IncludeTools returns the filter keeping only the tools of the given names.
+func IncludeTools(names ...string) ToolFilter {
+	return func(tool types.Tool) bool {
+		return slices.Contains(names, tool.Name())
+	}
+}
+
+// ExcludeTools returns the filter dropping the tools of the given names.
+func ExcludeTools(names ...string) ToolFilter {
+	return func(tool types.Tool) bool {
+		return !slices.Contains(names, tool.Name())
+	}
+}
+
+// toolsetSource is a toolset merged by [MergeToolsets].
+type toolsetSource struct {
+	toolset    types.Toolset
+	precedence int
+	filter     ToolFilter
+}
+
+// MergedToolset is a toolset flattening the tools of several toolsets, see [MergeToolsets].
+type MergedToolset struct {
+	sources       []toolsetSource
+	errOnConflict bool
+	logger        *slog.Logger
+}
+
+var _ types.Toolset = (*MergedToolset)(nil)
+
+// ToolsetOption configures a [MergedToolset].
+type ToolsetOption func(*MergedToolset)
+
+// WithToolsetSource adds the toolset to merge, with the precedence resolving the conflicts of its
+// tool names with the other toolsets. The filter, unless nil, selects the tools of the toolset
+// kept, such as [IncludeTools] or [ExcludeTools].
+func WithToolsetSource(toolset types.Toolset, precedence int, filter ToolFilter) ToolsetOption {
+	return func(ts *MergedToolset) {
+		ts.sources = append(ts.sources, toolsetSource{toolset: toolset, precedence: precedence, filter: filter})
+	}
+}
+
+// WithToolSource adds the tools to merge, as a toolset of the given precedence.
+func WithToolSource(precedence int, tools ...types.Tool) ToolsetOption {
+	return WithToolsetSource(staticToolset(tools), precedence, nil)
+}
+
+// WithConflictError makes a tool name provided by several toolsets an error rather than resolved by
+// precedence, reported by [MergedToolset.Tools] as a [*types.DuplicateToolNameError].
+func WithConflictError() ToolsetOption {
+	return func(ts *MergedToolset) {
+		ts.errOnConflict = true
+	}
+}
+
+// WithToolsetLogger sets the logger of the toolset, reporting the conflicts of [WithConflictError]
+// found by [MergedToolset.GetTools]. It defaults to [slog.Default].
+func WithToolsetLogger(logger *slog.Logger) ToolsetOption {
+	return func(ts *MergedToolset) {
+		ts.logger = logger
+	}
+}
+
+// MergeToolsets returns the toolset merging the tools of the toolsets added by the options.
+//
+// The tools of a toolset are kept in their order, after the tools of the toolsets added before it.
+// A tool name provided by several toolsets is resolved deterministically: the tool of the toolset
+// of the highest precedence wins, or of the toolset added first at equal precedence. With
+// [WithConflictError], the conflicting tools are all kept instead: [MergedToolset.Tools] returns
+// the conflict as an error, [MergedToolset.GetTools] logs it, and the Validate method of the agent
+// reports the duplicate tool names of the toolset.
+func MergeToolsets(opts ...ToolsetOption) *MergedToolset {
+	ts := &MergedToolset{
+		logger: slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(ts)
+	}
+	return ts
+}
+
+// GetTools implements [types.Toolset].
+//
+// As [types.Toolset] cannot return an error, the conflict of [WithConflictError] is logged, and
+// all the tools are returned.
+func (ts *MergedToolset) GetTools(rctx *types.ReadOnlyContext) []types.Tool {
+	tools, err := ts.Tools(rctx)
+	if err != nil {
+		ts.logger.Error("merged toolsets provide conflicting tools", slog.Any("error", err))
+	}
+	return tools
+}
+
+// Tools returns the merged tools resolved with the context, for inspection.
+//
+// With [WithConflictError], it also returns a [*types.DuplicateToolNameError] naming the tools
+// provided by several toolsets, along with all the tools.
+func (ts *MergedToolset) Tools(rctx *types.ReadOnlyContext) ([]types.Tool, error) {
+	type candidate struct {
+		tool       types.Tool
+		precedence int
+	}
+	var candidates []candidate
+	for _, src := range ts.sources {
+		if src.toolset == nil {
+			continue
+		}
+		for _, tool := range src.toolset.GetTools(rctx) {
+			if tool == nil || src.filter != nil && !src.filter(tool) {
+				continue
+			}
+			candidates = append(candidates, candidate{tool: tool, precedence: src.precedence})
+		}
+	}
+
+	tools := make([]types.Tool, 0, len(candidates))
+	if ts.errOnConflict {
+		for _, c := range candidates {
+			tools = append(tools, c.tool)
+		}
+		return tools, types.CheckToolNames(tools)
+	}
+
+	// The winner of each name is the first candidate of the highest precedence.
+	winners := make(map[string]int, len(candidates))
+	for i, c := range candidates {
+		name := c.tool.Name()
+		if j, ok := winners[name]; !ok || c.precedence > candidates[j].precedence {
+			winners[name] = i
+		}
+	}
+	for i, c := range candidates {
+		if winners[c.tool.Name()] == i {
+			tools = append(tools, c.tool)
+		}
+	}
+	return tools, nil
+}
+
+// Close implements [types.Toolset].
+//
+// It closes the merged toolsets, once each.
+func (ts *MergedToolset) Close() {
+	var closed []types.Toolset
+	for _, src := range ts.sources {
+		if src.toolset == nil || slices.ContainsFunc(closed, func(other types.Toolset) bool { return xreflect.Same(other, src.toolset) }) {
+			continue
+		}
+		closed = append(closed, src.toolset)
+		src.toolset.Close()
+	}
+}
+
+// staticToolset is a toolset of fixed tools.
+type staticToolset []types.Tool
+
+// GetTools implements [types.Toolset].
+func (ts staticToolset) GetTools(*types.ReadOnlyContext) []types.Tool {
+	return ts
+}
+
+// Close implements [types.Toolset].
+func (staticToolset) Close() {}
@@ -0,0 +1,129 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// closeCountingToolset is a toolset of fixed tools counting its releases.
+type closeCountingToolset struct {
+	tools  []types.Tool
+	closed int
+}
+
+func (ts *closeCountingToolset) GetTools(*types.ReadOnlyContext) []types.Tool { return ts.tools }
+
+func (ts *closeCountingToolset) Close() { ts.closed++ }
+
+func TestMergeToolsets(t *testing.T) {
+	t.Parallel()
+
+	// The description of the tools tells the toolset providing them.
+	newTool := func(name, source string) types.Tool {
+		return tools.NewAgent(name, source)
+	}
+	search := &closeCountingToolset{tools: []types.Tool{newTool("search", "search"), newTool("fetch", "search")}}
+	web := &closeCountingToolset{tools: []types.Tool{newTool("fetch", "web"), newTool("browse", "web"), newTool("debug", "web")}}
+
+	tests := map[string]struct {
+		opts    []tools.ToolsetOption
+		want    []string
+		wantDup []string
+	}{
+		"higher precedence wins": {
+			opts: []tools.ToolsetOption{
+				tools.WithToolsetSource(search, 0, nil),
+				tools.WithToolsetSource(web, 1, nil),
+			},
+			want: []string{"search/search", "fetch/web", "browse/web", "debug/web"},
+		},
+		"first added wins at equal precedence": {
+			opts: []tools.ToolsetOption{
+				tools.WithToolsetSource(search, 1, nil),
+				tools.WithToolsetSource(web, 1, nil),
+			},
+			want: []string{"search/search", "fetch/search", "browse/web", "debug/web"},
+		},
+		"filters": {
+			opts: []tools.ToolsetOption{
+				tools.WithToolsetSource(search, 0, tools.IncludeTools("search")),
+				tools.WithToolsetSource(web, 0, tools.ExcludeTools("debug")),
+				tools.WithToolSource(0, newTool("exit", "static")),
+			},
+			want: []string{"search/search", "fetch/web", "browse/web", "exit/static"},
+		},
+		"conflict error": {
+			opts: []tools.ToolsetOption{
+				tools.WithToolsetSource(search, 0, nil),
+				tools.WithToolsetSource(web, 1, nil),
+				tools.WithConflictError(),
+			},
+			want:    []string{"search/search", "fetch/search", "fetch/web", "browse/web", "debug/web"},
+			wantDup: []string{"fetch"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var logs bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&logs, nil))
+			merged := tools.MergeToolsets(append(slices.Clone(tt.opts), tools.WithToolsetLogger(logger))...)
+			got, err := merged.Tools(nil)
+			if tt.wantDup == nil && err != nil {
+				t.Fatalf("Tools() error = %v", err)
+			}
+			if tt.wantDup != nil {
+				var dupErr *types.DuplicateToolNameError
+				if !errors.As(err, &dupErr) {
+					t.Fatalf("Tools() error = %v, want a *types.DuplicateToolNameError", err)
+				}
+				if diff := cmp.Diff(tt.wantDup, dupErr.Names); diff != "" {
+					t.Errorf("duplicate names mismatch (-want +got):\n%s", diff)
+				}
+			}
+
+			names := make([]string, len(got))
+			for i, tool := range got {
+				names[i] = tool.Name() + "/" + tool.Description()
+			}
+			if diff := cmp.Diff(tt.want, names); diff != "" {
+				t.Errorf("Tools() mismatch (-want +got):\n%s", diff)
+			}
+			if get := merged.GetTools(nil); !slices.Equal(get, got) {
+				t.Errorf("GetTools() = %v, want the tools of Tools() %v", get, got)
+			}
+			if logged := strings.Contains(logs.String(), "conflicting tools"); logged != (tt.wantDup != nil) {
+				t.Errorf("GetTools() logged the conflict = %t, want %t:\n%s", logged, tt.wantDup != nil, logs.String())
+			}
+		})
+	}
+}
+
+func TestMergedToolset_Close(t *testing.T) {
+	t.Parallel()
+
+	shared := &closeCountingToolset{}
+	other := &closeCountingToolset{}
+	tools.MergeToolsets(
+		tools.WithToolsetSource(shared, 0, tools.IncludeTools("a")),
+		tools.WithToolsetSource(other, 0, nil),
+		tools.WithToolsetSource(shared, 1, tools.IncludeTools("b")),
+	).Close()
+
+	if shared.closed != 1 || other.closed != 1 {
+		t.Errorf("closed (shared, other) = (%d, %d) times, want once each", shared.closed, other.closed)
+	}
+}
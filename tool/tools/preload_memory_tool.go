@@ -4,8 +4,12 @@
 package tools
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/go-a2a/adk-go/tool"
@@ -17,18 +21,74 @@ import (
 // NOTE(adk-python): Currently this tool only uses text part from the memory.
 type PreloadMemoryTool struct {
 	*tool.Tool
+
+	Logger *slog.Logger
+
+	minRelevanceScore float64
+	maxMemoryTokens   int
+	maxMemories       int
+	tokenizer         types.Tokenizer
 }
 
 var _ types.Tool = (*PreloadMemoryTool)(nil)
 
+// PreloadMemoryToolOption configures a [PreloadMemoryTool].
+type PreloadMemoryToolOption func(*PreloadMemoryTool)
+
+// WithMinRelevanceScore drops the memories scored below score.
+//
+// Memories from memory services which do not score their results have a zero score.
+func WithMinRelevanceScore(score float64) PreloadMemoryToolOption {
+	return func(t *PreloadMemoryTool) {
+		t.minRelevanceScore = score
+	}
+}
+
+// WithMaxMemoryTokens caps the estimated number of tokens of the preloaded memories.
+//
+// The lowest scored memories are trimmed first. Zero means no limit.
+func WithMaxMemoryTokens(n int) PreloadMemoryToolOption {
+	return func(t *PreloadMemoryTool) {
+		t.maxMemoryTokens = n
+	}
+}
+
+// WithMemoryTokenizer sets the tokenizer counting the tokens of the memories for
+// [WithMaxMemoryTokens]. Without it, the tokens are estimated at four characters per token.
+func WithMemoryTokenizer(tokenizer types.Tokenizer) PreloadMemoryToolOption {
+	return func(t *PreloadMemoryTool) {
+		t.tokenizer = tokenizer
+	}
+}
+
+// WithMaxMemories caps the number of preloaded memories to the k highest scored ones.
+//
+// Zero means no limit.
+func WithMaxMemories(k int) PreloadMemoryToolOption {
+	return func(t *PreloadMemoryTool) {
+		t.maxMemories = k
+	}
+}
+
 // NewPreloadMemoryTool returns the new [PreloadMemoryTool].
-func NewPreloadMemoryTool() *PreloadMemoryTool {
-	return &PreloadMemoryTool{
-		Tool: tool.NewTool("preload_memory", "preload_memory", false),
+func NewPreloadMemoryTool(opts ...PreloadMemoryToolOption) *PreloadMemoryTool {
+	t := &PreloadMemoryTool{
+		Tool:   tool.NewTool("preload_memory", "preload_memory", false),
+		Logger: slog.Default(),
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
 }
 
 // ProcessLLMRequest implements [types.Tool].
+//
+// The injected memories are logged along with the number of memories dropped by
+// each limit, so that what was added to the request can be audited.
+//
+// Without a memory service, no memory is injected and the request proceeds unchanged.
 func (t *PreloadMemoryTool) ProcessLLMRequest(ctx context.Context, toolCtx *types.ToolContext, request *types.LLMRequest) error {
 	userContent := toolCtx.UserContent()
 	if userContent == nil || len(userContent.Parts) == 0 || userContent.Parts[0].Text == "" {
@@ -37,12 +97,25 @@ func (t *PreloadMemoryTool) ProcessLLMRequest(ctx context.Context, toolCtx *type
 
 	userQuery := userContent.Parts[0].Text
 	response, err := toolCtx.SearchMemory(ctx, userQuery)
+	if errors.Is(err, types.ErrNoMemoryService) {
+		t.Logger.DebugContext(ctx, noMemoryServiceMessage)
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
+	memories, dropped := t.selectMemories(response.Memories)
+	t.Logger.InfoContext(ctx, "preloaded memories",
+		slog.Int("injected", len(memories)),
+		slog.Int("dropped_low_score", dropped.lowScore),
+		slog.Int("dropped_max_memories", dropped.maxMemories),
+		slog.Int("dropped_max_tokens", dropped.maxTokens),
+		slog.Any("scores", memoryScores(memories)),
+	)
+
 	var memoryTextLines []string
-	for _, memory := range response.Memories {
+	for _, memory := range memories {
 		if !memory.Timestamp.IsZero() {
 			timeStr := fmt.Sprintf("Time: %s", memory.Timestamp)
 			memoryTextLines = append(memoryTextLines, timeStr)
@@ -70,14 +143,84 @@ They may be useful for answering the user's current query.
 		`
 </PAST_CONVERSATIONS>
 `
-	request.AppendInstructions(si)
+	request.AppendSystemContent(types.SystemContentMemory, si)
 
 	return nil
 }
 
+// droppedMemories counts the memories dropped by each limit of [PreloadMemoryTool].
+type droppedMemories struct {
+	lowScore    int
+	maxMemories int
+	maxTokens   int
+}
+
+// selectMemories applies the relevance, count and token limits to memories.
+//
+// The selected memories keep the order of memories.
+func (t *PreloadMemoryTool) selectMemories(memories []*types.MemoryEntry) ([]*types.MemoryEntry, droppedMemories) {
+	var dropped droppedMemories
+
+	// indexes of the kept memories, the highest scored first
+	kept := make([]int, 0, len(memories))
+	for i, memory := range memories {
+		if memory.Score < t.minRelevanceScore {
+			dropped.lowScore++
+			continue
+		}
+		kept = append(kept, i)
+	}
+	slices.SortStableFunc(kept, func(a, b int) int {
+		return cmp.Compare(memories[b].Score, memories[a].Score)
+	})
+
+	if t.maxMemories > 0 && len(kept) > t.maxMemories {
+		dropped.maxMemories = len(kept) - t.maxMemories
+		kept = kept[:t.maxMemories]
+	}
+
+	if t.maxMemoryTokens > 0 {
+		tokens := 0
+		for _, i := range kept {
+			tokens += t.countTokens(extractText(memories[i], " "))
+		}
+		for tokens > t.maxMemoryTokens && len(kept) > 0 {
+			tokens -= t.countTokens(extractText(memories[kept[len(kept)-1]], " "))
+			kept = kept[:len(kept)-1]
+			dropped.maxTokens++
+		}
+	}
+
+	slices.Sort(kept)
+	selected := make([]*types.MemoryEntry, len(kept))
+	for i, idx := range kept {
+		selected[i] = memories[idx]
+	}
+
+	return selected, dropped
+}
+
+// memoryScores returns the scores of memories.
+func memoryScores(memories []*types.MemoryEntry) []float64 {
+	scores := make([]float64, len(memories))
+	for i, memory := range memories {
+		scores[i] = memory.Score
+	}
+	return scores
+}
+
+// countTokens counts the tokens of text with the tokenizer of the tool, or estimates them at about
+// four characters per token.
+func (t *PreloadMemoryTool) countTokens(text string) int {
+	if t.tokenizer == nil {
+		return (&types.ApproximateTokenizer{}).CountTokens(text)
+	}
+	return t.tokenizer.CountTokens(text)
+}
+
 // extractText extracts the text from the memory entry.
 func extractText(memory *types.MemoryEntry, splitter string) string {
-	if len(memory.Content.Parts) == 0 {
+	if memory.Content == nil || len(memory.Content.Parts) == 0 {
 		return ""
 	}
 
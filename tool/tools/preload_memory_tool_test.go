@@ -0,0 +1,109 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestPreloadMemoryTool_selectMemories(t *testing.T) {
+	t.Parallel()
+
+	memory := func(text string, score float64) *types.MemoryEntry {
+		return &types.MemoryEntry{
+			Content: genai.NewContentFromText(text, genai.RoleUser),
+			Score:   score,
+		}
+	}
+	memories := []*types.MemoryEntry{
+		memory("a", 0.2),
+		memory(strings.Repeat("b", 40), 0.9), // 10 tokens
+		memory(strings.Repeat("c", 20), 0.5), // 5 tokens
+		memory(strings.Repeat("d", 8), 0.7),  // 2 tokens
+	}
+
+	tests := map[string]struct {
+		opts        []PreloadMemoryToolOption
+		wantScores  []float64
+		wantDropped droppedMemories
+	}{
+		"no limits": {
+			wantScores: []float64{0.2, 0.9, 0.5, 0.7},
+		},
+		"min relevance score": {
+			opts:        []PreloadMemoryToolOption{WithMinRelevanceScore(0.5)},
+			wantScores:  []float64{0.9, 0.5, 0.7},
+			wantDropped: droppedMemories{lowScore: 1},
+		},
+		"max memories keeps the highest scored in order": {
+			opts:        []PreloadMemoryToolOption{WithMaxMemories(2)},
+			wantScores:  []float64{0.9, 0.7},
+			wantDropped: droppedMemories{maxMemories: 2},
+		},
+		"max tokens trims the lowest scored first": {
+			opts:        []PreloadMemoryToolOption{WithMaxMemoryTokens(12)},
+			wantScores:  []float64{0.9, 0.7},
+			wantDropped: droppedMemories{maxTokens: 2},
+		},
+		"all limits": {
+			opts: []PreloadMemoryToolOption{
+				WithMinRelevanceScore(0.3),
+				WithMaxMemories(2),
+				WithMaxMemoryTokens(5),
+			},
+			wantScores:  []float64{},
+			wantDropped: droppedMemories{lowScore: 1, maxMemories: 1, maxTokens: 2},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, dropped := NewPreloadMemoryTool(tt.opts...).selectMemories(memories)
+			if diff := cmp.Diff(tt.wantScores, memoryScores(got)); diff != "" {
+				t.Errorf("scores mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantDropped, dropped, cmp.AllowUnexported(droppedMemories{})); diff != "" {
+				t.Errorf("dropped mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMemoryTools_NoMemoryService(t *testing.T) {
+	t.Parallel()
+
+	userContent := genai.NewContentFromText("what did I say yesterday?", genai.RoleUser)
+	ictx := types.NewInvocationContext(nil, nil, nil, types.WithUserContent(userContent))
+
+	t.Run("load memory", func(t *testing.T) {
+		t.Parallel()
+
+		resp, err := LoadMemory(t.Context(), "yesterday", types.NewToolContext(ictx))
+		if err != nil {
+			t.Fatalf("LoadMemory() error = %v", err)
+		}
+		if len(resp.memories) != 0 || resp.Message != noMemoryServiceMessage {
+			t.Errorf("LoadMemory() = (%d memories, %q), want (0, %q)", len(resp.memories), resp.Message, noMemoryServiceMessage)
+		}
+	})
+
+	t.Run("preload memory", func(t *testing.T) {
+		t.Parallel()
+
+		request := &types.LLMRequest{}
+		if err := NewPreloadMemoryTool().ProcessLLMRequest(t.Context(), types.NewToolContext(ictx), request); err != nil {
+			t.Fatalf("ProcessLLMRequest() error = %v", err)
+		}
+		if request.Config != nil && request.Config.SystemInstruction != nil {
+			t.Errorf("ProcessLLMRequest() set system instruction %v, want none", request.Config.SystemInstruction)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// SingleflightKeyFunc returns the key of a call of a [SingleflightTool], the concurrent calls of
+// the same key sharing a single execution. An empty key runs the call on its own.
+type SingleflightKeyFunc func(args map[string]any, toolCtx *types.ToolContext) string
+
+// ArgsKey is the [SingleflightKeyFunc] keying a call by the hash of its arguments, see
+// [types.HashArgs], so that the concurrent calls of identical arguments are coalesced, whichever
+// invocation or user makes them.
+//
+// The arguments that cannot be hashed have an empty key, and are never coalesced.
+func ArgsKey(args map[string]any, _ *types.ToolContext) string {
+	key, err := types.HashArgs(args)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// SingleflightTool wraps an expensive idempotent [types.Tool], coalescing its concurrent calls of
+// the same key into a single execution whose result, or error, all the callers share, like
+// [singleflight.Group].
+//
+// The result is shared as is, and must not be modified by the callers. The execution runs with the
+// values of the context of the first caller, but not its cancellation: a caller whose context is
+// done returns its context error without waiting further, while the execution goes on for the
+// others, bounded by [SingleflightTool.WithTimeout] if set. The calls that are not concurrent each
+// run the tool.
+//
+// The execution runs with a [types.ToolContext] of its own, on the invocation of the first caller,
+// so that it never writes into the context of a caller that has returned. The state delta of the
+// execution is copied to the actions of each caller that gets the result; its other actions, such
+// as the artifact delta, are dropped.
+//
+// Only wrap the tools whose calls of the same key are interchangeable: a tool with side effects
+// must not be coalesced.
+type SingleflightTool struct {
+	types.Tool
+
+	keyFn   SingleflightKeyFunc
+	timeout time.Duration
+	group   singleflight.Group
+}
+
+var _ types.Tool = (*SingleflightTool)(nil)
+
+// NewSingleflightTool returns the [*SingleflightTool] coalescing the concurrent calls of tool of the
+// same key returned by keyFn, or by [ArgsKey] if keyFn is nil.
+func NewSingleflightTool(tool types.Tool, keyFn SingleflightKeyFunc) *SingleflightTool {
+	if keyFn == nil {
+		keyFn = ArgsKey
+	}
+	return &SingleflightTool{
+		Tool:  tool,
+		keyFn: keyFn,
+	}
+}
+
+// WithTimeout bounds each shared execution of the tool to d. Zero or less means no bound, which is
+// the default.
+func (t *SingleflightTool) WithTimeout(d time.Duration) *SingleflightTool {
+	t.timeout = d
+	return t
+}
+
+// Run implements [types.Tool].
+func (t *SingleflightTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	key := t.keyFn(args, toolCtx)
+	if key == "" {
+		return t.Tool.Run(ctx, args, toolCtx)
+	}
+
+	ch := t.group.DoChan(key, func() (any, error) {
+		// The execution is shared, so the cancellation of the first caller must not end it.
+		execCtx := context.WithoutCancel(ctx)
+		if t.timeout > 0 {
+			var cancel context.CancelFunc
+			execCtx, cancel = context.WithTimeout(execCtx, t.timeout)
+			defer cancel()
+		}
+		sharedCtx := sharedToolContext(toolCtx)
+		val, err := t.Tool.Run(execCtx, args, sharedCtx)
+		return &sharedResult{val: val, stateDelta: sharedCtx.Actions().StateDelta}, err
+	})
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		shared := res.Val.(*sharedResult)
+		if toolCtx != nil && toolCtx.Actions() != nil && len(shared.stateDelta) > 0 {
+			if toolCtx.Actions().StateDelta == nil {
+				toolCtx.Actions().StateDelta = make(map[string]any, len(shared.stateDelta))
+			}
+			maps.Copy(toolCtx.Actions().StateDelta, shared.stateDelta)
+		}
+		return shared.val, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// sharedResult is the result of a shared execution, with the state delta it made.
+type sharedResult struct {
+	val        any
+	stateDelta map[string]any
+}
+
+// sharedToolContext returns the tool context of a shared execution, on the invocation and function
+// call of the tool context of the first caller, with actions of its own.
+func sharedToolContext(toolCtx *types.ToolContext) *types.ToolContext {
+	if toolCtx == nil {
+		return types.NewToolContext(nil).WithEventActions(types.NewEventActions())
+	}
+	return types.NewToolContext(toolCtx.InvocationContext()).
+		WithFunctionCallID(toolCtx.FunctionCallID()).
+		WithEventActions(types.NewEventActions())
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-a2a/adk-go/tool/tools"
+	"github.com/go-a2a/adk-go/types"
+)
+
+// blockingTool is a tool counting its runs, each blocked until release is closed, and recording
+// the last query in the state.
+type blockingTool struct {
+	types.Tool
+
+	runs    atomic.Int64
+	toolCtx atomic.Pointer[types.ToolContext]
+	release chan struct{}
+	err     error
+}
+
+func (t *blockingTool) Run(ctx context.Context, args map[string]any, toolCtx *types.ToolContext) (any, error) {
+	t.runs.Add(1)
+	t.toolCtx.Store(toolCtx)
+	select {
+	case <-t.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+	if toolCtx != nil && toolCtx.Actions() != nil {
+		toolCtx.Actions().StateDelta["last_query"] = args["query"]
+	}
+	return map[string]any{"query": args["query"]}, nil
+}
+
+func TestSingleflightTool(t *testing.T) {
+	t.Parallel()
+
+	errDownstream := errors.New("downstream unavailable")
+	noKey := func(map[string]any, *types.ToolContext) string { return "" }
+
+	tests := map[string]struct {
+		keyFn    tools.SingleflightKeyFunc
+		args     []map[string]any
+		err      error
+		wantRuns int64
+	}{
+		"identical calls": {
+			args:     []map[string]any{{"query": "go"}, {"query": "go"}, {"query": "go"}},
+			wantRuns: 1,
+		},
+		"error shared by the callers": {
+			args:     []map[string]any{{"query": "go"}, {"query": "go"}},
+			err:      errDownstream,
+			wantRuns: 1,
+		},
+		"different arguments": {
+			args:     []map[string]any{{"query": "go"}, {"query": "rust"}, {"query": "go"}},
+			wantRuns: 2,
+		},
+		"calls without a key": {
+			keyFn:    noKey,
+			args:     []map[string]any{{"query": "go"}, {"query": "go"}},
+			wantRuns: 2,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			inner := &blockingTool{release: make(chan struct{}), err: tt.err}
+			var keyed sync.WaitGroup
+			keyed.Add(len(tt.args))
+			keyFn := tt.keyFn
+			if keyFn == nil {
+				keyFn = tools.ArgsKey
+			}
+			tool := tools.NewSingleflightTool(inner, func(args map[string]any, toolCtx *types.ToolContext) string {
+				defer keyed.Done()
+				return keyFn(args, toolCtx)
+			})
+
+			results := make([]any, len(tt.args))
+			errs := make([]error, len(tt.args))
+			var done sync.WaitGroup
+			for i, args := range tt.args {
+				done.Add(1)
+				go func() {
+					defer done.Done()
+					results[i], errs[i] = tool.Run(t.Context(), args, nil)
+				}()
+			}
+			keyed.Wait()
+			// Let the callers join the execution in flight before it completes.
+			time.Sleep(20 * time.Millisecond)
+			close(inner.release)
+			done.Wait()
+
+			if got := inner.runs.Load(); got != tt.wantRuns {
+				t.Errorf("tool ran %d times, want %d", got, tt.wantRuns)
+			}
+			for i, err := range errs {
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Run(%v) error = %v, want %v", tt.args[i], err, tt.err)
+					continue
+				}
+				if err == nil && results[i].(map[string]any)["query"] != tt.args[i]["query"] {
+					t.Errorf("Run(%v) = %v, want the result of its arguments", tt.args[i], results[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSingleflightTool_WaiterCancelled(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTool{release: make(chan struct{})}
+	defer close(inner.release)
+	tool := tools.NewSingleflightTool(inner, nil)
+	args := map[string]any{"query": "go"}
+
+	go tool.Run(t.Context(), args, nil)
+	for inner.runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	if _, err := tool.Run(ctx, args, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() of a cancelled waiter error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSingleflightTool_FirstCallerCancelled(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTool{release: make(chan struct{})}
+	tool := tools.NewSingleflightTool(inner, nil)
+	args := map[string]any{"query": "go"}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	first := make(chan error, 1)
+	go func() {
+		_, err := tool.Run(ctx, args, nil)
+		first <- err
+	}()
+	for inner.runs.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	type result struct {
+		val any
+		err error
+	}
+	second := make(chan result, 1)
+	go func() {
+		val, err := tool.Run(t.Context(), args, nil)
+		second <- result{val: val, err: err}
+	}()
+	// Let the second caller join the execution in flight.
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run() of the cancelled first caller error = %v, want %v", err, context.Canceled)
+	}
+	close(inner.release)
+	res := <-second
+	if res.err != nil {
+		t.Fatalf("Run() of the waiting caller error = %v, want the shared result", res.err)
+	}
+	if res.val.(map[string]any)["query"] != "go" {
+		t.Errorf("Run() of the waiting caller = %v, want the shared result", res.val)
+	}
+	if got := inner.runs.Load(); got != 1 {
+		t.Errorf("tool ran %d times, want 1", got)
+	}
+}
+
+func TestSingleflightTool_WithTimeout(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTool{release: make(chan struct{})}
+	defer close(inner.release)
+	tool := tools.NewSingleflightTool(inner, nil).WithTimeout(10 * time.Millisecond)
+
+	if _, err := tool.Run(t.Context(), map[string]any{"query": "go"}, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Run() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestSingleflightTool_StateDelta(t *testing.T) {
+	t.Parallel()
+
+	inner := &blockingTool{release: make(chan struct{})}
+	tool := tools.NewSingleflightTool(inner, nil)
+	args := map[string]any{"query": "go"}
+
+	toolCtxs := []*types.ToolContext{
+		types.NewToolContext(nil).WithEventActions(types.NewEventActions()),
+		types.NewToolContext(nil).WithEventActions(types.NewEventActions()),
+	}
+	var wg sync.WaitGroup
+	for i, toolCtx := range toolCtxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := tool.Run(t.Context(), args, toolCtx); err != nil {
+				t.Errorf("Run(%d) error = %v", i, err)
+			}
+		}()
+		if i == 0 {
+			for inner.runs.Load() == 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}
+	// Let the second caller join the execution in flight.
+	time.Sleep(20 * time.Millisecond)
+	close(inner.release)
+	wg.Wait()
+
+	if got := inner.runs.Load(); got != 1 {
+		t.Errorf("tool ran %d times, want 1", got)
+	}
+	for i, toolCtx := range toolCtxs {
+		if toolCtx == inner.toolCtx.Load() {
+			t.Errorf("shared execution ran with the tool context of caller %d", i)
+		}
+		if got := toolCtx.Actions().StateDelta["last_query"]; got != "go" {
+			t.Errorf("caller %d state delta = %v, want the delta of the shared execution", i, toolCtx.Actions().StateDelta)
+		}
+	}
+}
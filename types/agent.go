@@ -116,10 +116,11 @@ type LLMAgent interface {
 	// This method is only for use by Agent Development Kit.
 	CanonicalModel(ctx context.Context) (Model, error)
 
-	// CanonicalInstructions returns the resolved self.instruction field to construct instruction for this agent.
+	// CanonicalInstructions returns the resolved self.instruction field to construct instruction for this agent,
+	// and whether to bypass the state injection, which is the case of an [InstructionProvider].
 	//
 	// This method is only for use by Agent Development Kit.
-	CanonicalInstructions(rctx *ReadOnlyContext) string
+	CanonicalInstructions(rctx *ReadOnlyContext) (string, bool)
 
 	// CanonicalGlobalInstruction returns the resolved self.instruction field to construct global instruction.
 	//
@@ -152,6 +153,12 @@ type LLMAgent interface {
 	// OutputKey returns the key in session state to store the output of the agent.
 	OutputKey() string
 
+	// ToolChoice returns the function calling mode of the model requests, nil to let the model decide.
+	ToolChoice() *ToolChoice
+
+	// GoogleSearchGrounding returns the grounding of the answers in Google Search, nil for no grounding.
+	GoogleSearchGrounding() *GoogleSearchGrounding
+
 	// Planner returns the instructs the agent to make a plan and execute it step by step.
 	Planner() Planner
 
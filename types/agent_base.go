@@ -8,8 +8,6 @@ import (
 	"fmt"
 	"iter"
 	"log/slog"
-
-	"github.com/go-a2a/adk-go/internal/xiter"
 )
 
 // BaseAgent represents the base agent.
@@ -79,11 +77,21 @@ func (a *BaseAgent) AfterAgentCallbacks() []AgentCallback {
 
 // Run implements [Agent].
 func (a *BaseAgent) Run(ctx context.Context, parentContext *InvocationContext) iter.Seq2[*Event, error] {
+	return a.RunAgent(ctx, a, parentContext)
+}
+
+// RunAgent runs the agent built on the base agent, such as an agent embedding it: it makes the
+// agent the agent of the invocation, and runs its [Agent.Execute] between the agent callbacks of
+// the base agent.
+//
+// The agents built on a base agent implement [Agent.Run] with it, so that their own Execute is
+// run rather than the one of the base agent.
+func (a *BaseAgent) RunAgent(ctx context.Context, agent Agent, parentContext *InvocationContext) iter.Seq2[*Event, error] {
 	return func(yield func(*Event, error) bool) {
-		parentContext = a.createInvocationContext(parentContext)
+		parentContext = a.createInvocationContext(agent, parentContext)
 		beforeEvent, err := a.handleBeforeAgentCallbacks(ctx, parentContext)
 		if err != nil {
-			xiter.Error[Event](err)
+			yield(nil, err)
 			return
 		}
 		if beforeEvent != nil {
@@ -95,9 +103,9 @@ func (a *BaseAgent) Run(ctx context.Context, parentContext *InvocationContext) i
 			}
 		}
 
-		for event, err := range a.Execute(ctx, parentContext) {
+		for event, err := range agent.Execute(ctx, parentContext) {
 			if err != nil {
-				xiter.Error[Event](err)
+				yield(nil, err)
 				return
 			}
 			if !yield(event, nil) {
@@ -111,10 +119,10 @@ func (a *BaseAgent) Run(ctx context.Context, parentContext *InvocationContext) i
 
 		afterEvent, err := a.handleAfterAgentCallback(ctx, parentContext)
 		if err != nil {
-			xiter.Error[Event](err)
+			yield(nil, err)
 			return
 		}
-		if beforeEvent != nil {
+		if afterEvent != nil {
 			if !yield(afterEvent, nil) {
 				return
 			}
@@ -124,13 +132,19 @@ func (a *BaseAgent) Run(ctx context.Context, parentContext *InvocationContext) i
 
 // RunLive implements [Agent].
 func (a *BaseAgent) RunLive(ctx context.Context, parentContext *InvocationContext) iter.Seq2[*Event, error] {
+	return a.RunLiveAgent(ctx, a, parentContext)
+}
+
+// RunLiveAgent runs the agent built on the base agent with its [Agent.ExecuteLive], as
+// [BaseAgent.RunAgent].
+func (a *BaseAgent) RunLiveAgent(ctx context.Context, agent Agent, parentContext *InvocationContext) iter.Seq2[*Event, error] {
 	return func(yield func(*Event, error) bool) {
-		parentContext = a.createInvocationContext(parentContext)
+		parentContext = a.createInvocationContext(agent, parentContext)
 		// TODO(adk-python): support before/after_agent_callback
 
-		for event, err := range a.ExecuteLive(ctx, parentContext) {
+		for event, err := range agent.ExecuteLive(ctx, parentContext) {
 			if err != nil {
-				xiter.Error[Event](err)
+				yield(nil, err)
 				return
 			}
 			if !yield(event, nil) {
@@ -143,16 +157,14 @@ func (a *BaseAgent) RunLive(ctx context.Context, parentContext *InvocationContex
 // Execute implements [Agent].
 func (a *BaseAgent) Execute(ctx context.Context, ictx *InvocationContext) iter.Seq2[*Event, error] {
 	return func(yield func(*Event, error) bool) {
-		xiter.Error[Event](NotImplementedError("Execute for Base is not implemented"))
-		return
+		yield(nil, NotImplementedError("Execute for Base is not implemented"))
 	}
 }
 
 // ExecuteLive implements [Agent].
 func (a *BaseAgent) ExecuteLive(ctx context.Context, ictx *InvocationContext) iter.Seq2[*Event, error] {
 	return func(yield func(*Event, error) bool) {
-		xiter.Error[Event](NotImplementedError("ExecuteLive for Base is not implemented"))
-		return
+		yield(nil, NotImplementedError("ExecuteLive for Base is not implemented"))
 	}
 }
 
@@ -193,9 +205,9 @@ func (a *BaseAgent) FindSubAgent(name string) Agent {
 	return nil
 }
 
-// createInvocationContext creates a new invocation context for this agent.
-func (a *BaseAgent) createInvocationContext(parentContext *InvocationContext) *InvocationContext {
-	parentContext.Agent = a
+// createInvocationContext creates a new invocation context for the agent built on this base agent.
+func (a *BaseAgent) createInvocationContext(agent Agent, parentContext *InvocationContext) *InvocationContext {
+	parentContext.Agent = agent
 	if parentContext.Branch != "" {
 		parentContext.Branch += "." + a.Name()
 	}
@@ -218,7 +230,7 @@ func (a *BaseAgent) handleBeforeAgentCallbacks(ctx context.Context, ictx *Invoca
 			return nil, err
 		}
 		if beforeAgentCallbackContent != nil {
-			event = NewEvent().
+			event = ictx.NewEvent().
 				WithInvocationID(ictx.InvocationID).
 				WithAuthor(a.Config.Name).
 				WithBranch(ictx.Branch).
@@ -230,7 +242,7 @@ func (a *BaseAgent) handleBeforeAgentCallbacks(ctx context.Context, ictx *Invoca
 	}
 
 	if callbackCtx.State().HasDelta() {
-		event = NewEvent().
+		event = ictx.NewEvent().
 			WithInvocationID(ictx.InvocationID).
 			WithAuthor(a.Config.Name).
 			WithBranch(ictx.Branch).
@@ -256,7 +268,7 @@ func (a *BaseAgent) handleAfterAgentCallback(ctx context.Context, ictx *Invocati
 			return nil, err
 		}
 		if afterAgentCallbackContent != nil {
-			event = NewEvent().
+			event = ictx.NewEvent().
 				WithAuthor(a.Config.Name).
 				WithBranch(ictx.Branch).
 				WithContent(afterAgentCallbackContent).
@@ -265,7 +277,7 @@ func (a *BaseAgent) handleAfterAgentCallback(ctx context.Context, ictx *Invocati
 		}
 
 		if callbackCtx.State().HasDelta() {
-			event = NewEvent().
+			event = ictx.NewEvent().
 				WithInvocationID(ictx.InvocationID).
 				WithAuthor(a.Config.Name).
 				WithBranch(ictx.Branch).
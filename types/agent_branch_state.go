@@ -0,0 +1,96 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"maps"
+	"strings"
+	"sync"
+)
+
+// BranchPrefix is the prefix of the state keys private to a branch of a ParallelAgent.
+//
+// Unlike the other [TempPrefix] keys, which are shared by all the agents of the invocation, a
+// branch-scoped key is only visible to the branch that wrote it, and is discarded when the branch
+// ends. It is never persisted to the session.
+const BranchPrefix = TempPrefix + "branch:"
+
+// branchState holds the branch-scoped state of an invocation context.
+type branchState struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+func (b *branchState) get(key string) (any, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	value, ok := b.values[key]
+	return value, ok
+}
+
+func (b *branchState) set(key string, value any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.values == nil {
+		b.values = make(map[string]any)
+	}
+	b.values[key] = value
+}
+
+// ForkBranch returns a copy of the invocation context for a branch running concurrently with
+// its siblings, such as a sub-agent of a ParallelAgent.
+//
+// The copy shares the services, the session and the cost and job tracking of the invocation, and
+// starts with a copy of its branch-scoped state, so that the writes of a branch to the
+// [BranchPrefix] keys are invisible to its siblings and to the parent.
+func (ictx *InvocationContext) ForkBranch() *InvocationContext {
+	var values map[string]any
+	if parent := ictx.branchState; parent != nil {
+		parent.mu.RLock()
+		values = maps.Clone(parent.values)
+		parent.mu.RUnlock()
+	}
+
+	branch := *ictx
+	branch.branchState = &branchState{values: values}
+	return &branch
+}
+
+// BranchState returns the value of the branch-scoped key, stored as [BranchPrefix]+key.
+func (ictx *InvocationContext) BranchState(key string) (any, bool) {
+	if ictx.branchState == nil {
+		return nil, false
+	}
+	return ictx.branchState.get(key)
+}
+
+// SetBranchState sets the value of the branch-scoped key, stored as [BranchPrefix]+key.
+//
+// The value is not set on an invocation context not created with [NewInvocationContext] or
+// [InvocationContext.ForkBranch].
+func (ictx *InvocationContext) SetBranchState(key string, value any) {
+	if ictx.branchState == nil {
+		return
+	}
+	ictx.branchState.set(key, value)
+}
+
+// ApplyBranchDelta moves the [BranchPrefix] keys of the event state delta to the branch-scoped
+// state of the invocation, so that they are not persisted to the session.
+//
+// The keys are left in the delta of an invocation context without branch-scoped state, not
+// created with [NewInvocationContext] or [InvocationContext.ForkBranch].
+func (ictx *InvocationContext) ApplyBranchDelta(event *Event) {
+	if ictx.branchState == nil || event == nil || event.Actions == nil {
+		return
+	}
+	for key, value := range event.Actions.StateDelta {
+		if name, ok := strings.CutPrefix(key, BranchPrefix); ok {
+			ictx.SetBranchState(name, value)
+			delete(event.Actions.StateDelta, key)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package types
 import (
 	"context"
 	"errors"
+	"io"
 
 	"google.golang.org/genai"
 )
@@ -17,6 +18,8 @@ type CallbackContext struct {
 	eventActions *EventActions
 
 	state *State
+
+	plan *Plan
 }
 
 func (cc *CallbackContext) WithEventActions(eventActions *EventActions) *CallbackContext {
@@ -33,6 +36,7 @@ func NewCallbackContext(iccx *InvocationContext) *CallbackContext {
 	}
 
 	cc.state = NewState(iccx.Session.State(), cc.eventActions.StateDelta)
+	cc.state.branch = iccx.branchState
 
 	return cc
 }
@@ -50,6 +54,25 @@ func (cc *CallbackContext) State() *State {
 	return cc.state
 }
 
+// Plan returns the plan set by [CallbackContext.SetPlan], or nil.
+func (cc *CallbackContext) Plan() *Plan {
+	return cc.plan
+}
+
+// SetPlan sets the plan the planner detected in the response of the model, carried by the event
+// emitted after the response is processed.
+func (cc *CallbackContext) SetPlan(plan *Plan) {
+	cc.plan = plan
+}
+
+// SetBranchState sets the value of the key private to the current branch, stored as [BranchPrefix]+key.
+//
+// The value is visible to the following steps of the branch, but not to its sibling branches,
+// and is not persisted to the session.
+func (cc *CallbackContext) SetBranchState(key string, value any) {
+	cc.InvocationContext.SetBranchState(key, value)
+}
+
 // LoadArtifact loads an artifact attached to the current session.
 func (cc *CallbackContext) LoadArtifact(ctx context.Context, filename string, version int) (*genai.Part, error) {
 	artifactSvc := cc.InvocationContext.ArtifactService
@@ -88,3 +111,45 @@ func (cc *CallbackContext) SaveArtifact(ctx context.Context, filename string, ar
 	cc.eventActions.ArtifactDelta[filename] = version
 	return version, nil
 }
+
+// LoadArtifactStream loads a reader of an artifact attached to the current session along with its MIME type.
+//
+// The caller must close the returned reader.
+func (cc *CallbackContext) LoadArtifactStream(ctx context.Context, filename string, version int) (io.ReadCloser, string, error) {
+	artifactSvc := cc.InvocationContext.ArtifactService
+	if artifactSvc == nil {
+		return nil, "", errors.New("artifact service is not initialized")
+	}
+
+	return artifactSvc.LoadArtifactStream(ctx,
+		cc.InvocationContext.AppName(),
+		cc.InvocationContext.UserID(),
+		cc.InvocationContext.Session.ID(),
+		filename,
+		version,
+	)
+}
+
+// SaveArtifactStream saves an artifact read from r and records it as delta for the current session.
+func (cc *CallbackContext) SaveArtifactStream(ctx context.Context, filename string, r io.Reader, mimeType string) (int, error) {
+	artifactSvc := cc.InvocationContext.ArtifactService
+	if artifactSvc == nil {
+		return 0, errors.New("artifact service is not initialized")
+	}
+
+	version, err := artifactSvc.SaveArtifactStream(
+		ctx,
+		cc.InvocationContext.AppName(),
+		cc.InvocationContext.UserID(),
+		cc.InvocationContext.Session.ID(),
+		filename,
+		r,
+		mimeType,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	cc.eventActions.ArtifactDelta[filename] = version
+	return version, nil
+}
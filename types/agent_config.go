@@ -4,6 +4,7 @@
 package types
 
 import (
+	"io"
 	"log/slog"
 )
 
@@ -40,6 +41,9 @@ type Config struct {
 	afterAgentCallbacks []AgentCallback
 
 	logger *slog.Logger
+
+	// The resources of the agent, released by CloseAgent.
+	resources []io.Closer
 }
 
 // Option configures a [Config].
@@ -0,0 +1,129 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/go-a2a/adk-go/internal/xreflect"
+)
+
+// closerRegistry holds the resources registered with [InvocationContext.AddCloser].
+type closerRegistry struct {
+	mu      sync.Mutex
+	closers []io.Closer
+}
+
+// AddCloser registers a resource of the invocation, such as a model or a code executor, to be
+// closed by [CloseServices] before the services. The resources are closed in the reverse order of
+// their registration.
+//
+// The resource is not registered to an invocation context not created with [NewInvocationContext],
+// and must be closed by the caller.
+func (ictx *InvocationContext) AddCloser(c io.Closer) {
+	r := ictx.closers
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.closers = append(r.closers, c)
+}
+
+// CloseServices releases the resources of the invocation once it ended, in this order:
+//
+//  1. the background jobs of the long-running tools are cancelled,
+//  2. the live request queue is closed,
+//  3. the resources registered with [InvocationContext.AddCloser] are closed, the last registered first,
+//  4. the credential, memory, artifact and session services are closed, if they implement [io.Closer].
+//
+// The services are closed last as the other resources may still use them while closing. A
+// service set for several roles is closed once.
+//
+// A failure does not stop the others from being closed: the errors are joined.
+func CloseServices(ictx *InvocationContext) error {
+	var errs []error
+	if err := ictx.CancelJobs(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if ictx.LiveRequestQueue != nil {
+		ictx.LiveRequestQueue.Close()
+	}
+
+	var closers []io.Closer
+	if r := ictx.closers; r != nil {
+		r.mu.Lock()
+		closers = r.closers
+		r.closers = nil
+		r.mu.Unlock()
+	}
+	for _, c := range slices.Backward(closers) {
+		if err := c.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %T: %w", c, err))
+		}
+	}
+
+	services := []struct {
+		name    string
+		service any
+	}{
+		{"credential service", ictx.CredentialService},
+		{"memory service", ictx.MemoryService},
+		{"artifact service", ictx.ArtifactService},
+		{"session service", ictx.SessionService},
+	}
+	var closed []any
+	for _, svc := range services {
+		c, ok := svc.service.(io.Closer)
+		if !ok || slices.ContainsFunc(closed, func(other any) bool { return xreflect.Same(other, c) }) {
+			continue
+		}
+		closed = append(closed, c)
+		if err := c.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", svc.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// PingServices probes the memory, artifact and session services of the invocation that are set,
+// such as for the readiness probe of a server, and returns the joined errors of the services that
+// are unhealthy. A service set for several roles is probed once.
+func PingServices(ctx context.Context, ictx *InvocationContext) error {
+	type pinger interface {
+		Ping(ctx context.Context) error
+	}
+	services := []struct {
+		name    string
+		service pinger
+	}{
+		{"memory service", ictx.MemoryService},
+		{"artifact service", ictx.ArtifactService},
+		{"session service", ictx.SessionService},
+	}
+
+	var (
+		errs   []error
+		pinged []any
+	)
+	for _, svc := range services {
+		if svc.service == nil || slices.ContainsFunc(pinged, func(other any) bool { return xreflect.Same(other, svc.service) }) {
+			continue
+		}
+		pinged = append(pinged, svc.service)
+		if err := svc.service.Ping(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("ping %s: %w", svc.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
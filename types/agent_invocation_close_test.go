@@ -0,0 +1,146 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// closeLog records the order in which resources are closed.
+type closeLog struct {
+	closed []string
+}
+
+type recordingCloser struct {
+	name string
+	log  *closeLog
+	err  error
+}
+
+func (c *recordingCloser) Close() error {
+	c.log.closed = append(c.log.closed, c.name)
+	return c.err
+}
+
+type closingMemoryService struct {
+	types.MemoryService
+	recordingCloser
+}
+
+func (s *closingMemoryService) Close() error { return s.recordingCloser.Close() }
+
+type closingArtifactService struct {
+	types.ArtifactService
+	recordingCloser
+}
+
+func (s *closingArtifactService) Close() error { return s.recordingCloser.Close() }
+
+type closingSessionService struct {
+	types.SessionService
+	recordingCloser
+}
+
+func (s *closingSessionService) Close() error { return s.recordingCloser.Close() }
+
+func TestCloseServices(t *testing.T) {
+	t.Parallel()
+
+	log := &closeLog{}
+	errMemory := errors.New("memory backend unreachable")
+	errModel := errors.New("model connection reset")
+	ictx := types.NewInvocationContext(nil, nil,
+		&closingSessionService{recordingCloser: recordingCloser{name: "session", log: log}},
+		types.WithMemoryService(&closingMemoryService{recordingCloser: recordingCloser{name: "memory", log: log, err: errMemory}}),
+		types.WithArtifactService(&closingArtifactService{recordingCloser: recordingCloser{name: "artifact", log: log}}),
+	)
+	ictx.AddCloser(&recordingCloser{name: "model", log: log, err: errModel})
+	ictx.AddCloser(&recordingCloser{name: "code executor", log: log})
+
+	err := types.CloseServices(ictx)
+	if !errors.Is(err, errMemory) || !errors.Is(err, errModel) {
+		t.Errorf("CloseServices() error = %v, want both %v and %v", err, errMemory, errModel)
+	}
+	want := []string{"code executor", "model", "memory", "artifact", "session"}
+	if diff := cmp.Diff(want, log.closed); diff != "" {
+		t.Errorf("close order mismatch (-want +got):\n%s", diff)
+	}
+
+	// The registered resources are closed once.
+	log.closed = nil
+	types.CloseServices(ictx)
+	if diff := cmp.Diff([]string{"memory", "artifact", "session"}, log.closed); diff != "" {
+		t.Errorf("second close mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// closingService serves as both the memory and the artifact service.
+type closingService struct {
+	types.MemoryService
+	types.ArtifactService
+	recordingCloser
+}
+
+func (s *closingService) Close() error { return s.recordingCloser.Close() }
+
+func (s *closingService) Ping(context.Context) error { return nil }
+
+func TestCloseServices_SharedService(t *testing.T) {
+	t.Parallel()
+
+	log := &closeLog{}
+	svc := &closingService{recordingCloser: recordingCloser{name: "shared", log: log}}
+	ictx := types.NewInvocationContext(nil, nil, nil, types.WithMemoryService(svc), types.WithArtifactService(svc))
+
+	if err := types.CloseServices(ictx); err != nil {
+		t.Fatalf("CloseServices() error = %v", err)
+	}
+	if diff := cmp.Diff([]string{"shared"}, log.closed); diff != "" {
+		t.Errorf("closed mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// pingingService is a service whose probe fails with err, counting its probes.
+type pingingService struct {
+	types.MemoryService
+	types.ArtifactService
+	types.SessionService
+
+	err   error
+	pings int
+}
+
+func (s *pingingService) Ping(context.Context) error {
+	s.pings++
+	return s.err
+}
+
+func (s *pingingService) Close() error { return nil }
+
+func TestPingServices(t *testing.T) {
+	t.Parallel()
+
+	errBucket := errors.New("bucket not found")
+	shared := &pingingService{}
+	unhealthy := &pingingService{err: &types.UnhealthyError{Service: "session.GCSService", Err: errBucket}}
+	ictx := types.NewInvocationContext(nil, nil, unhealthy, types.WithMemoryService(shared), types.WithArtifactService(shared))
+
+	err := types.PingServices(t.Context(), ictx)
+	if !errors.Is(err, types.ErrUnhealthy) || !errors.Is(err, errBucket) {
+		t.Errorf("PingServices() error = %v, want %v wrapping %v", err, types.ErrUnhealthy, errBucket)
+	}
+	if shared.pings != 1 || unhealthy.pings != 1 {
+		t.Errorf("pings = (shared %d, session %d), want (1, 1)", shared.pings, unhealthy.pings)
+	}
+
+	if err := types.PingServices(t.Context(), types.NewInvocationContext(nil, nil, nil)); err != nil {
+		t.Errorf("PingServices() without services error = %v", err)
+	}
+}
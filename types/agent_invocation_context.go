@@ -5,6 +5,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/genai"
@@ -90,6 +91,10 @@ type InvocationContext struct {
 	MemoryService     MemoryService
 	CredentialService CredentialService
 
+	// CodeExecutor is the code executor of the LLM agents without a code executor of their own.
+	// Nil means no code execution for them.
+	CodeExecutor CodeExecutor
+
 	// InvocationID is the id of this invocation context. Readonly.
 	InvocationID string
 
@@ -128,9 +133,34 @@ type InvocationContext struct {
 	// Configurations for live agents under this invocation.
 	RunConfig *RunConfig
 
+	// Clock tells the time of the events of this invocation. Nil means [SystemClock].
+	Clock Clock
+
+	// IDGenerator generates the IDs of the events of this invocation. Nil means [RandomIDGenerator].
+	IDGenerator IDGenerator
+
 	// A container to keep track of different kinds of costs incurred as a part
 	// of this invocation.
 	invocationCostManager *InvocationCostManager
+
+	// The background jobs started by the long-running tools of this invocation, nil if the
+	// invocation context was not created with [NewInvocationContext].
+	jobs *jobRegistry
+
+	// The state private to the branch of this invocation context, see [BranchPrefix], nil if the
+	// invocation context was not created with [NewInvocationContext] or [InvocationContext.ForkBranch].
+	branchState *branchState
+
+	// The resources closed with the services by [CloseServices], nil if the invocation context was
+	// not created with [NewInvocationContext].
+	closers *closerRegistry
+
+	// The number of agent transfers made in this invocation.
+	transferDepth int
+
+	// The tool calls intercepted by the dry runs of this invocation, nil if the invocation context
+	// was not created with [NewInvocationContext].
+	intended *intendedToolCalls
 }
 
 // InvocationContextOption is a function that modifies the [InvocationContext].
@@ -178,11 +208,29 @@ func WithTranscriptionCache(entries ...*TranscriptionEntry) InvocationContextOpt
 	}
 }
 
+// WithClock sets the [Clock] telling the time of the events of the invocation.
+func WithClock(clock Clock) InvocationContextOption {
+	return func(ictx *InvocationContext) {
+		ictx.Clock = clock
+	}
+}
+
+// WithIDGenerator sets the [IDGenerator] generating the IDs of the events of the invocation.
+func WithIDGenerator(ids IDGenerator) InvocationContextOption {
+	return func(ictx *InvocationContext) {
+		ictx.IDGenerator = ids
+	}
+}
+
 // NewInvocationContext creates a new [InvocationContext].
 func NewInvocationContext(agent Agent, session Session, sessionSvc SessionService, opts ...InvocationContextOption) *InvocationContext {
 	ictx := &InvocationContext{
 		Agent:                 agent,
 		invocationCostManager: &InvocationCostManager{},
+		jobs:                  &jobRegistry{},
+		branchState:           &branchState{},
+		closers:               &closerRegistry{},
+		intended:              &intendedToolCalls{},
 		Session:               session,
 		SessionService:        sessionSvc,
 	}
@@ -198,6 +246,43 @@ func (ictx *InvocationContext) IncrementLLMCallCount() error {
 	return ictx.invocationCostManager.IncrementAndEnforceLLMCallsLimit(ictx.RunConfig)
 }
 
+// TransferDepth returns the number of agent transfers made so far in the invocation.
+func (ictx *InvocationContext) TransferDepth() int {
+	return ictx.transferDepth
+}
+
+// IncrementTransferDepth counts an agent transfer made in the invocation and returns the new
+// number of transfers.
+func (ictx *InvocationContext) IncrementTransferDepth() int {
+	ictx.transferDepth++
+	return ictx.transferDepth
+}
+
+// Now returns the current time of the [Clock] of the invocation.
+func (ictx *InvocationContext) Now() time.Time {
+	if ictx.Clock == nil {
+		return time.Now()
+	}
+	return ictx.Clock.Now()
+}
+
+// NewEventID returns a new event ID from the [IDGenerator] of the invocation.
+func (ictx *InvocationContext) NewEventID() string {
+	if ictx.IDGenerator == nil {
+		return NewEventID()
+	}
+	return ictx.IDGenerator.NewEventID()
+}
+
+// NewEvent creates a new event with an ID and a timestamp from the [IDGenerator] and the [Clock]
+// of the invocation.
+func (ictx *InvocationContext) NewEvent() *Event {
+	return &Event{
+		ID:        ictx.NewEventID(),
+		Timestamp: ictx.Now(),
+	}
+}
+
 func (ictx *InvocationContext) AppName() string {
 	return ictx.Session.AppName()
 }
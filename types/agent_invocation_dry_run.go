@@ -0,0 +1,43 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"slices"
+	"sync"
+)
+
+// intendedToolCalls holds the tool calls intercepted by the dry runs of an invocation.
+type intendedToolCalls struct {
+	mu      sync.Mutex
+	records []ToolCallRecord
+}
+
+// AddIntendedToolCall records a tool call intercepted by a dry run of the invocation, instead of
+// running the tool. It is safe for concurrent use by the parallel function calls of a model turn.
+//
+// The call is not recorded to an invocation context not created with [NewInvocationContext].
+func (ictx *InvocationContext) AddIntendedToolCall(record ToolCallRecord) {
+	calls := ictx.intended
+	if calls == nil {
+		return
+	}
+	calls.mu.Lock()
+	defer calls.mu.Unlock()
+
+	calls.records = append(calls.records, record)
+}
+
+// IntendedToolCalls returns the tool calls intercepted by the dry runs of the invocation so far,
+// in the order they were made.
+func (ictx *InvocationContext) IntendedToolCalls() []ToolCallRecord {
+	calls := ictx.intended
+	if calls == nil {
+		return nil
+	}
+	calls.mu.Lock()
+	defer calls.mu.Unlock()
+
+	return slices.Clone(calls.records)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// jobRegistry holds the long-running jobs started by the tools of an invocation.
+type jobRegistry struct {
+	mu   sync.Mutex
+	jobs map[string]JobCanceler
+}
+
+// RegisterJob registers the background job of a long-running tool to the invocation, so that it
+// is cancelled by canceler with [InvocationContext.CancelJobs].
+//
+// The job is not registered to an invocation context not created with [NewInvocationContext].
+func (ictx *InvocationContext) RegisterJob(jobID string, canceler JobCanceler) {
+	r := ictx.jobs
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.jobs == nil {
+		r.jobs = make(map[string]JobCanceler)
+	}
+	r.jobs[jobID] = canceler
+}
+
+// UnregisterJob removes a finished job from the invocation.
+func (ictx *InvocationContext) UnregisterJob(jobID string) {
+	r := ictx.jobs
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.jobs, jobID)
+}
+
+// CancelJobs cancels the registered jobs of the invocation and waits for them to return.
+func (ictx *InvocationContext) CancelJobs() error {
+	r := ictx.jobs
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	jobs := r.jobs
+	r.jobs = nil
+	r.mu.Unlock()
+
+	var errs []error
+	for jobID, canceler := range jobs {
+		if err := canceler.Cancel(jobID); err != nil {
+			errs = append(errs, fmt.Errorf("cancel job %s: %w", jobID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CancelJobsOnDone arranges for the registered jobs of the invocation to be cancelled when ctx is
+// done, and returns a function stopping the arrangement as [context.AfterFunc].
+//
+// The arrangement holds the invocation context until ctx is done: call stop once the jobs no longer
+// need to be cancelled with ctx, such as when the run ends.
+func (ictx *InvocationContext) CancelJobsOnDone(ctx context.Context) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		ictx.CancelJobs()
+	})
+}
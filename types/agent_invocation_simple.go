@@ -0,0 +1,137 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genai"
+)
+
+// simpleInvocation is the configuration of [NewSimpleInvocationContext].
+type simpleInvocation struct {
+	sessionID         string
+	memoryService     MemoryService
+	artifactService   ArtifactService
+	credentialService CredentialService
+	codeExecutor      CodeExecutor
+	runConfig         *RunConfig
+}
+
+// SimpleInvocationOption configures the [InvocationContext] built by [NewSimpleInvocationContext].
+type SimpleInvocationOption func(*simpleInvocation)
+
+// WithSessionID runs the invocation in the session of the given ID, fetched if it exists and
+// created otherwise. Without it, a new session is created with a generated ID.
+func WithSessionID(sessionID string) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.sessionID = sessionID
+	}
+}
+
+// WithMemory sets the [MemoryService] of the invocation.
+func WithMemory(svc MemoryService) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.memoryService = svc
+	}
+}
+
+// WithArtifacts sets the [ArtifactService] of the invocation.
+func WithArtifacts(svc ArtifactService) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.artifactService = svc
+	}
+}
+
+// WithCredentials sets the [CredentialService] of the invocation.
+func WithCredentials(svc CredentialService) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.credentialService = svc
+	}
+}
+
+// WithCodeExecutor sets the [CodeExecutor] of the invocation, used by the LLM agents without a
+// code executor of their own.
+func WithCodeExecutor(executor CodeExecutor) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.codeExecutor = executor
+	}
+}
+
+// WithSimpleRunConfig sets the [RunConfig] of the invocation.
+func WithSimpleRunConfig(config *RunConfig) SimpleInvocationOption {
+	return func(c *simpleInvocation) {
+		c.runConfig = config
+	}
+}
+
+// NewSimpleInvocationContext builds the [InvocationContext] of a one-shot call of an agent, such as
+// agent.RunToCompletion, from the session service and the user message.
+//
+// It creates a session of the user, or fetches the one given by [WithSessionID], and appends the
+// user message to it as the event starting the invocation, unless the message is nil. The
+// invocation gets a new invocation ID, and the services set by the options; its agent is set by
+// the agent run with it.
+func NewSimpleInvocationContext(ctx context.Context, sessionSvc SessionService, appName, userID string, userMessage *genai.Content, opts ...SimpleInvocationOption) (*InvocationContext, error) {
+	if sessionSvc == nil {
+		return nil, errors.New("new simple invocation context: no session service")
+	}
+	c := &simpleInvocation{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	ses, err := getOrCreateSession(ctx, sessionSvc, appName, userID, c.sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("new simple invocation context: %w", err)
+	}
+
+	ictx := NewInvocationContext(nil, ses, sessionSvc,
+		WithUserContent(userMessage),
+		WithMemoryService(c.memoryService),
+		WithArtifactService(c.artifactService),
+	)
+	ictx.InvocationID = NewInvocationContextID()
+	ictx.CredentialService = c.credentialService
+	ictx.CodeExecutor = c.codeExecutor
+	ictx.RunConfig = c.runConfig
+
+	if userMessage != nil {
+		event := ictx.NewEvent().
+			WithInvocationID(ictx.InvocationID).
+			WithAuthor("user").
+			WithContent(userMessage)
+		if _, err := sessionSvc.AppendEvent(ctx, ses, event); err != nil {
+			return nil, fmt.Errorf("new simple invocation context: append user message: %w", err)
+		}
+	}
+
+	return ictx, nil
+}
+
+// getOrCreateSession fetches the session if sessionID is set and the session exists, and creates
+// it otherwise.
+func getOrCreateSession(ctx context.Context, sessionSvc SessionService, appName, userID, sessionID string) (Session, error) {
+	if sessionID == "" {
+		ses, err := sessionSvc.CreateSession(ctx, appName, userID, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("create session: %w", err)
+		}
+		return ses, nil
+	}
+
+	// The session services do not tell a missing session from a failure, so that the session is
+	// created whenever it cannot be fetched.
+	ses, getErr := sessionSvc.GetSession(ctx, appName, userID, sessionID, nil)
+	if getErr == nil && ses != nil {
+		return ses, nil
+	}
+	ses, err := sessionSvc.CreateSession(ctx, appName, userID, sessionID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get or create session %s: %w", sessionID, errors.Join(getErr, err))
+	}
+	return ses, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/memory"
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestNewSimpleInvocationContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	sessionSvc := session.NewInMemoryService()
+	memorySvc := memory.NewInMemoryService()
+	message := genai.NewContentFromText("hello", genai.RoleUser)
+
+	ictx, err := types.NewSimpleInvocationContext(ctx, sessionSvc, "app", "user", message, types.WithMemory(memorySvc))
+	if err != nil {
+		t.Fatalf("NewSimpleInvocationContext() error = %v", err)
+	}
+	if ictx.InvocationID == "" || ictx.UserContent != message || ictx.MemoryService != memorySvc || ictx.SessionService != sessionSvc {
+		t.Errorf("NewSimpleInvocationContext() = %+v, want the invocation ID, user content and services set", ictx)
+	}
+	if ictx.AppName() != "app" || ictx.UserID() != "user" || ictx.Session.ID() == "" {
+		t.Errorf("NewSimpleInvocationContext() session = (%s, %s, %s), want a new session of app and user", ictx.AppName(), ictx.UserID(), ictx.Session.ID())
+	}
+
+	stored, err := sessionSvc.GetSession(ctx, "app", "user", ictx.Session.ID(), nil)
+	if err != nil {
+		t.Fatalf("GetSession() error = %v", err)
+	}
+	events := stored.Events()
+	if len(events) != 1 || events[0].Author != "user" || events[0].InvocationID != ictx.InvocationID || events[0].Content != message {
+		t.Fatalf("session events = %+v, want the user message of the invocation", events)
+	}
+
+	// The session of the given ID is fetched, and seeded with the new message.
+	again, err := types.NewSimpleInvocationContext(ctx, sessionSvc, "app", "user", genai.NewContentFromText("again", genai.RoleUser), types.WithSessionID(ictx.Session.ID()))
+	if err != nil {
+		t.Fatalf("NewSimpleInvocationContext() error = %v", err)
+	}
+	if n := len(again.Session.Events()); n != 2 {
+		t.Errorf("fetched session has %d events, want 2", n)
+	}
+	if again.MemoryService != nil {
+		t.Errorf("NewSimpleInvocationContext() memory service = %v, want nil", again.MemoryService)
+	}
+
+	// A missing session of the given ID is created.
+	created, err := types.NewSimpleInvocationContext(ctx, sessionSvc, "app", "user", nil, types.WithSessionID("fixed"))
+	if err != nil {
+		t.Fatalf("NewSimpleInvocationContext() error = %v", err)
+	}
+	if created.Session.ID() != "fixed" || len(created.Session.Events()) != 0 {
+		t.Errorf("NewSimpleInvocationContext() session = (%s, %d events), want (fixed, 0 events)", created.Session.ID(), len(created.Session.Events()))
+	}
+
+	if _, err := types.NewSimpleInvocationContext(ctx, nil, "app", "user", message); err == nil {
+		t.Error("NewSimpleInvocationContext() without session service succeeded")
+	}
+}
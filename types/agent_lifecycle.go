@@ -0,0 +1,169 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/go-a2a/adk-go/internal/xreflect"
+)
+
+// Initializer is implemented by the resources of an agent that need to be initialized before the
+// first run, such as the connection of a toolset.
+type Initializer interface {
+	// Init initializes the resource.
+	Init(ctx context.Context) error
+}
+
+// ResourceHolder is implemented by the agents holding resources, such as model clients, tools and
+// toolsets, initialized by [InitAgent] and released by [CloseAgent].
+type ResourceHolder interface {
+	// Resources returns the resources of the agent, excluding the ones of its sub-agents.
+	Resources() []io.Closer
+}
+
+// WithResources registers resources of the agent, released by [CloseAgent].
+func WithResources(closers ...io.Closer) Option {
+	return optionFunc(func(c *Config) {
+		c.resources = append(c.resources, closers...)
+	})
+}
+
+// AddResource registers a resource of the agent, released by [CloseAgent].
+func (c *Config) AddResource(closer io.Closer) {
+	c.resources = append(c.resources, closer)
+}
+
+// Resources implements [ResourceHolder].
+func (c *Config) Resources() []io.Closer {
+	return slices.Clone(c.resources)
+}
+
+// ToolsetResource returns the toolset as a resource of an agent: its Close never fails, and it is
+// initialized if the toolset implements [Initializer].
+func ToolsetResource(ts Toolset) io.Closer {
+	return toolsetResource{ts}
+}
+
+// toolsetResource is the resource of [ToolsetResource].
+type toolsetResource struct {
+	Toolset
+}
+
+var _ Initializer = toolsetResource{}
+
+// Init implements [Initializer].
+func (r toolsetResource) Init(ctx context.Context) error {
+	if init, ok := r.Toolset.(Initializer); ok {
+		return init.Init(ctx)
+	}
+	return nil
+}
+
+// Close implements [io.Closer].
+func (r toolsetResource) Close() error {
+	r.Toolset.Close()
+	return nil
+}
+
+// InitAgent initializes the resources of the agent tree implementing [Initializer], the agent
+// before its sub-agents, and the resources of each agent in their registration order. So that
+// the resources of an agent are ready before the sub-agents using them.
+//
+// A resource shared by several agents is initialized once. InitAgent stops at the first failure;
+// the tree should then be released with [CloseAgent], which is safe on a partly initialized tree.
+func InitAgent(ctx context.Context, agent Agent) error {
+	var initialized []io.Closer
+	return initAgent(ctx, agent, &initialized)
+}
+
+func initAgent(ctx context.Context, agent Agent, initialized *[]io.Closer) error {
+	if holder, ok := agent.(ResourceHolder); ok {
+		for _, resource := range holder.Resources() {
+			init, ok := resource.(Initializer)
+			isInitialized := slices.ContainsFunc(*initialized, func(other io.Closer) bool {
+				return sameResource(other, resource)
+			})
+			if !ok || isInitialized {
+				continue
+			}
+			*initialized = append(*initialized, resource)
+			if err := init.Init(ctx); err != nil {
+				return fmt.Errorf("init agent %s: %T: %w", agent.Name(), resource, err)
+			}
+		}
+	}
+	for _, subAgent := range agent.SubAgents() {
+		if err := initAgent(ctx, subAgent, initialized); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CloseAgent releases the resources of the agent tree in the reverse order of [InitAgent]: the
+// sub-agents before the agent, the last sub-agent first, and the resources of each agent in the
+// reverse order of their registration.
+//
+// A resource shared by several agents is closed once, after the last agent using it in this order,
+// so that it outlives all of them. A failure does not stop the others from being closed: the
+// errors are joined.
+func CloseAgent(agent Agent) error {
+	var owned []ownedResource
+	collectResources(agent, &owned)
+
+	var errs []error
+	for i, r := range owned {
+		closedLater := slices.ContainsFunc(owned[i+1:], func(later ownedResource) bool {
+			return sameResource(later.resource, r.resource)
+		})
+		if closedLater {
+			continue
+		}
+		if err := r.resource.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close agent %s: %T: %w", r.agent, r.resource, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ownedResource is a resource of the named agent.
+type ownedResource struct {
+	agent    string
+	resource io.Closer
+}
+
+// collectResources appends the resources of the agent tree in the order they are closed.
+func collectResources(agent Agent, owned *[]ownedResource) {
+	for _, subAgent := range slices.Backward(agent.SubAgents()) {
+		collectResources(subAgent, owned)
+	}
+
+	holder, ok := agent.(ResourceHolder)
+	if !ok {
+		return
+	}
+	for _, resource := range slices.Backward(holder.Resources()) {
+		if resource != nil {
+			*owned = append(*owned, ownedResource{agent: agent.Name(), resource: resource})
+		}
+	}
+}
+
+// resourceKey returns the value identifying the resource, the toolset for a [ToolsetResource].
+func resourceKey(resource io.Closer) any {
+	if r, ok := resource.(toolsetResource); ok {
+		return r.Toolset
+	}
+	return resource
+}
+
+// sameResource reports whether a and b are the same resource, comparing only the comparable ones.
+func sameResource(a, b io.Closer) bool {
+	return xreflect.Same(resourceKey(a), resourceKey(b))
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// lifecycleLog records the initialization and the release of the resources.
+type lifecycleLog struct {
+	calls []string
+}
+
+// resource is a resource recording its lifecycle in the log.
+type resource struct {
+	name     string
+	log      *lifecycleLog
+	closeErr error
+}
+
+func (r *resource) Init(context.Context) error {
+	r.log.calls = append(r.log.calls, "init "+r.name)
+	return nil
+}
+
+func (r *resource) Close() error {
+	r.log.calls = append(r.log.calls, "close "+r.name)
+	return r.closeErr
+}
+
+// recordingToolset is a toolset recording its release in the log.
+type recordingToolset struct {
+	log *lifecycleLog
+}
+
+func (ts *recordingToolset) GetTools(*types.ReadOnlyContext) []types.Tool { return nil }
+
+func (ts *recordingToolset) Close() {
+	ts.log.calls = append(ts.log.calls, "close toolset")
+}
+
+func TestAgentLifecycle(t *testing.T) {
+	t.Parallel()
+
+	log := &lifecycleLog{}
+	errBroken := errors.New("broken")
+	shared := &resource{name: "shared", log: log}
+
+	first := types.NewBaseAgent("first", types.WithResources(
+		&resource{name: "first", log: log},
+		shared,
+	))
+	second := types.NewBaseAgent("second", types.WithResources(
+		&resource{name: "second", log: log, closeErr: errBroken},
+		types.ToolsetResource(&recordingToolset{log: log}),
+		shared,
+	))
+	root := types.NewBaseAgent("root", types.WithSubAgents(first, second))
+	root.AddResource(&resource{name: "root-a", log: log})
+	root.AddResource(&resource{name: "root-b", log: log})
+
+	if err := types.InitAgent(t.Context(), root); err != nil {
+		t.Fatalf("InitAgent() error = %v", err)
+	}
+	err := types.CloseAgent(root)
+	if !errors.Is(err, errBroken) {
+		t.Errorf("CloseAgent() error = %v, want %v", err, errBroken)
+	}
+
+	want := []string{
+		"init root-a",
+		"init root-b",
+		"init first",
+		"init shared",
+		"init second",
+		"close toolset",
+		"close second",
+		"close shared",
+		"close first",
+		"close root-b",
+		"close root-a",
+	}
+	if diff := cmp.Diff(want, log.calls); diff != "" {
+		t.Errorf("lifecycle mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestInitAgent_StopsAtFailure(t *testing.T) {
+	t.Parallel()
+
+	errInit := errors.New("unreachable")
+	log := &lifecycleLog{}
+	agent := types.NewBaseAgent("agent", types.WithResources(
+		failingInit{errInit},
+		&resource{name: "after", log: log},
+	))
+
+	if err := types.InitAgent(t.Context(), agent); !errors.Is(err, errInit) {
+		t.Fatalf("InitAgent() error = %v, want %v", err, errInit)
+	}
+	if len(log.calls) != 0 {
+		t.Errorf("InitAgent() went on after a failure: %v", log.calls)
+	}
+}
+
+// failingInit is a resource failing to initialize.
+type failingInit struct{ err error }
+
+var _ io.Closer = failingInit{}
+
+func (r failingInit) Init(context.Context) error { return r.err }
+
+func (failingInit) Close() error { return nil }
@@ -4,6 +4,8 @@
 package types
 
 import (
+	"strings"
+
 	"google.golang.org/genai"
 )
 
@@ -38,3 +40,153 @@ func (rc *ReadOnlyContext) AgentName() string {
 func (rc *ReadOnlyContext) State() map[string]any {
 	return rc.InvocationContext.Session.State()
 }
+
+// AppState returns the value of the application scoped key, stored as [AppPrefix]+key.
+func (rc *ReadOnlyContext) AppState(key string) (any, bool) {
+	return rc.lookup(AppPrefix + key)
+}
+
+// UserState returns the value of the user scoped key, stored as [UserPrefix]+key.
+func (rc *ReadOnlyContext) UserState(key string) (any, bool) {
+	return rc.lookup(UserPrefix + key)
+}
+
+// TempState returns the value of the invocation scoped key, stored as [TempPrefix]+key.
+func (rc *ReadOnlyContext) TempState(key string) (any, bool) {
+	return rc.lookup(TempPrefix + key)
+}
+
+// BranchState returns the value of the key private to the current branch, stored as [BranchPrefix]+key.
+func (rc *ReadOnlyContext) BranchState(key string) (any, bool) {
+	return rc.InvocationContext.BranchState(key)
+}
+
+// SessionState returns the value of the session scoped key, stored without prefix.
+//
+// It reports false for a key carrying a scope prefix; use the accessor of that scope instead.
+func (rc *ReadOnlyContext) SessionState(key string) (any, bool) {
+	if _, _, scoped := splitStateScope(key); scoped {
+		return nil, false
+	}
+	return rc.lookup(key)
+}
+
+// EffectiveState returns the state with the scope prefixes removed, where a narrower scope shadows
+// a broader one: temp over session over user over app.
+//
+// The returned map is a copy.
+func (rc *ReadOnlyContext) EffectiveState() map[string]any {
+	state := rc.State()
+	effective := make(map[string]any, len(state))
+	for _, prefix := range []string{AppPrefix, UserPrefix, "", TempPrefix} {
+		for key, value := range state {
+			name, keyPrefix, _ := splitStateScope(key)
+			if keyPrefix == prefix {
+				effective[name] = value
+			}
+		}
+	}
+	return effective
+}
+
+// Lookup returns the value of the key.
+//
+// A key with a scope prefix, such as "user:name", is read from that scope. A key without prefix
+// resolves like [ReadOnlyContext.EffectiveState], from the narrowest scope that has it.
+func (rc *ReadOnlyContext) Lookup(key string) (any, bool) {
+	if _, _, scoped := splitStateScope(key); scoped {
+		return rc.lookup(key)
+	}
+	for _, prefix := range []string{TempPrefix, "", UserPrefix, AppPrefix} {
+		if value, ok := rc.lookup(prefix + key); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// GetString returns the string value of the key, resolved like [ReadOnlyContext.Lookup].
+//
+// It reports false if the key is missing or its value is not a string.
+func (rc *ReadOnlyContext) GetString(key string) (string, bool) {
+	value, ok := rc.Lookup(key)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetInt returns the integer value of the key, resolved like [ReadOnlyContext.Lookup].
+//
+// Any integer type and whole floating point numbers, as decoded from JSON, are accepted.
+// It reports false if the key is missing or its value is not an integer.
+func (rc *ReadOnlyContext) GetInt(key string) (int, bool) {
+	value, ok := rc.Lookup(key)
+	if !ok {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int8:
+		return int(v), true
+	case int16:
+		return int(v), true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint8:
+		return int(v), true
+	case uint16:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float32:
+		if v == float32(int(v)) {
+			return int(v), true
+		}
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), true
+		}
+	}
+	return 0, false
+}
+
+// GetBool returns the boolean value of the key, resolved like [ReadOnlyContext.Lookup].
+//
+// It reports false if the key is missing or its value is not a boolean.
+func (rc *ReadOnlyContext) GetBool(key string) (value, ok bool) {
+	v, ok := rc.Lookup(key)
+	if !ok {
+		return false, false
+	}
+	value, ok = v.(bool)
+	return value, ok
+}
+
+// lookup returns the value of the raw state key.
+func (rc *ReadOnlyContext) lookup(key string) (any, bool) {
+	if name, ok := strings.CutPrefix(key, BranchPrefix); ok {
+		return rc.BranchState(name)
+	}
+	value, ok := rc.State()[key]
+	return value, ok
+}
+
+// splitStateScope splits the scope prefix from the state key.
+func splitStateScope(key string) (name, prefix string, scoped bool) {
+	for _, prefix := range []string{AppPrefix, UserPrefix, TempPrefix} {
+		if name, ok := strings.CutPrefix(key, prefix); ok {
+			return name, prefix, true
+		}
+	}
+	return key, "", false
+}
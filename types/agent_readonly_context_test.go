@@ -0,0 +1,103 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/session"
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestReadOnlyContext_State(t *testing.T) {
+	t.Parallel()
+
+	state := map[string]any{
+		"app:theme":   "dark",
+		"app:limit":   10,
+		"user:theme":  "light",
+		"user:name":   "Alice",
+		"user:beta":   true,
+		"theme":       "solarized",
+		"count":       float64(3),
+		"ratio":       0.5,
+		"temp:theme":  "contrast",
+		"temp:cursor": "abc",
+	}
+	rctx := types.NewReadOnlyContext(&types.InvocationContext{
+		Session: session.NewSession("app", "alice", "session", state, time.Now()),
+	})
+
+	t.Run("scoped", func(t *testing.T) {
+		t.Parallel()
+
+		if got, ok := rctx.AppState("theme"); !ok || got != "dark" {
+			t.Errorf("AppState(theme) = %v, %t, want dark", got, ok)
+		}
+		if got, ok := rctx.UserState("theme"); !ok || got != "light" {
+			t.Errorf("UserState(theme) = %v, %t, want light", got, ok)
+		}
+		if got, ok := rctx.SessionState("theme"); !ok || got != "solarized" {
+			t.Errorf("SessionState(theme) = %v, %t, want solarized", got, ok)
+		}
+		if got, ok := rctx.TempState("theme"); !ok || got != "contrast" {
+			t.Errorf("TempState(theme) = %v, %t, want contrast", got, ok)
+		}
+		if _, ok := rctx.SessionState("user:theme"); ok {
+			t.Error("SessionState(user:theme) found a scoped key")
+		}
+		if _, ok := rctx.UserState("limit"); ok {
+			t.Error("UserState(limit) found an app key")
+		}
+	})
+
+	t.Run("effective", func(t *testing.T) {
+		t.Parallel()
+
+		want := map[string]any{
+			"theme":  "contrast",
+			"limit":  10,
+			"name":   "Alice",
+			"beta":   true,
+			"count":  float64(3),
+			"ratio":  0.5,
+			"cursor": "abc",
+		}
+		if diff := cmp.Diff(want, rctx.EffectiveState()); diff != "" {
+			t.Errorf("EffectiveState() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("typed getters", func(t *testing.T) {
+		t.Parallel()
+
+		if got, ok := rctx.GetString("theme"); !ok || got != "contrast" {
+			t.Errorf("GetString(theme) = %q, %t, want contrast", got, ok)
+		}
+		if got, ok := rctx.GetString("app:theme"); !ok || got != "dark" {
+			t.Errorf("GetString(app:theme) = %q, %t, want dark", got, ok)
+		}
+		if got, ok := rctx.GetInt("limit"); !ok || got != 10 {
+			t.Errorf("GetInt(limit) = %d, %t, want 10", got, ok)
+		}
+		if got, ok := rctx.GetInt("count"); !ok || got != 3 {
+			t.Errorf("GetInt(count) = %d, %t, want 3 from a JSON number", got, ok)
+		}
+		if _, ok := rctx.GetInt("ratio"); ok {
+			t.Error("GetInt(ratio) accepted a fractional number")
+		}
+		if got, ok := rctx.GetBool("beta"); !ok || !got {
+			t.Errorf("GetBool(beta) = %t, %t, want true", got, ok)
+		}
+		if _, ok := rctx.GetBool("name"); ok {
+			t.Error("GetBool(name) accepted a string")
+		}
+		if _, ok := rctx.GetString("missing"); ok {
+			t.Error("GetString(missing) found a value")
+		}
+	})
+}
@@ -59,4 +59,7 @@ type RunConfig struct {
 
 	// A limit on the total number of llm calls for a given run.
 	MaxLLMCalls int
+
+	// Overrides of the generation config of the agents for this run, nil to keep their config.
+	GenerationOverrides *GenerationOverrides
 }
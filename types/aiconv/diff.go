@@ -0,0 +1,291 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aiconv
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"cloud.google.com/go/aiplatform/apiv1beta1/aiplatformpb"
+	"github.com/go-json-experiment/json"
+	jsonv1 "github.com/go-json-experiment/json/v1"
+	"google.golang.org/genai"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// DiffKind is the kind of a [FieldDiff].
+type DiffKind int
+
+const (
+	// DiffMissingInAIPlatform is a field set on the genai side that is not set on the aiplatform side,
+	// typically dropped by the conversion.
+	DiffMissingInAIPlatform DiffKind = iota + 1
+	// DiffMissingInGenAI is a field set on the aiplatform side that is not set on the genai side.
+	DiffMissingInGenAI
+	// DiffValueMismatch is a field set on both sides to different values.
+	DiffValueMismatch
+)
+
+// String returns a string representation of the DiffKind.
+func (k DiffKind) String() string {
+	switch k {
+	case DiffMissingInAIPlatform:
+		return "missing in aiplatform"
+	case DiffMissingInGenAI:
+		return "missing in genai"
+	case DiffValueMismatch:
+		return "value mismatch"
+	default:
+		return fmt.Sprintf("DiffKind(%d)", int(k))
+	}
+}
+
+// FieldDiff is a field that differs between a genai value and its aiplatform counterpart.
+//
+// Both sides are compared in their JSON form, so the path uses the JSON field names shared by
+// the two type systems, and the values are the decoded JSON values.
+type FieldDiff struct {
+	// Path is the path of the field, such as "thinkingConfig.thinkingBudget" or "parts[0].text".
+	Path string
+
+	// Kind is the kind of the difference.
+	Kind DiffKind
+
+	// GenAI is the value on the genai side, or nil if the field is not set.
+	GenAI any
+
+	// AIPlatform is the value on the aiplatform side, or nil if the field is not set.
+	AIPlatform any
+}
+
+// String returns a string representation of the FieldDiff.
+func (d FieldDiff) String() string {
+	switch d.Kind {
+	case DiffMissingInAIPlatform:
+		return fmt.Sprintf("%s: %v in genai, not set in aiplatform", d.Path, d.GenAI)
+	case DiffMissingInGenAI:
+		return fmt.Sprintf("%s: %v in aiplatform, not set in genai", d.Path, d.AIPlatform)
+	default:
+		return fmt.Sprintf("%s: %v in genai, %v in aiplatform", d.Path, d.GenAI, d.AIPlatform)
+	}
+}
+
+// DiffGenerationConfig reports the fields that differ between a genai generation config and an
+// aiplatform generation config, sorted by path.
+//
+// Compared with the result of [ToAIPlatformGenerationConfig], it lists the settings lost or
+// altered by the conversion:
+//
+//	for _, diff := range aiconv.DiffGenerationConfig(cfg, aiconv.ToAIPlatformGenerationConfig(cfg)) {
+//		log.Println(diff)
+//	}
+//
+// Fields set to their zero value are treated as not set, as they are on the wire.
+func DiffGenerationConfig(genaiCfg *genai.GenerationConfig, platformCfg *aiplatformpb.GenerationConfig) []FieldDiff {
+	// Both types always encode to JSON, so that the error is never set.
+	diffs, _ := diffJSON(genaiCfg, []proto.Message{platformCfg}, false)
+	return diffs
+}
+
+// ExplainConversion converts the genai value to its aiplatform counterpart and reports the fields
+// that the conversion drops or alters, sorted by path.
+//
+// The value is one of *genai.Content, []*genai.Content, *genai.Tool, []*genai.Tool and
+// *genai.GenerationConfig; other types are reported as an error.
+func ExplainConversion(v any) ([]FieldDiff, error) {
+	switch v := v.(type) {
+	case *genai.Content:
+		return diffJSON(v, []proto.Message{ToAIPlatformContent(v)}, false)
+	case []*genai.Content:
+		converted := ToAIPlatformContents(v)
+		msgs := make([]proto.Message, len(converted))
+		for i, c := range converted {
+			msgs[i] = c
+		}
+		return diffJSON(v, msgs, true)
+	case *genai.Tool:
+		return diffJSON(v, []proto.Message{ToAIPlatformTool(v)}, false)
+	case []*genai.Tool:
+		converted := ToAIPlatformTools(v)
+		msgs := make([]proto.Message, len(converted))
+		for i, t := range converted {
+			msgs[i] = t
+		}
+		return diffJSON(v, msgs, true)
+	case *genai.GenerationConfig:
+		return diffJSON(v, []proto.Message{ToAIPlatformGenerationConfig(v)}, false)
+	default:
+		return nil, fmt.Errorf("explain conversion: unsupported type %T", v)
+	}
+}
+
+// diffJSON compares the JSON form of the genai value with the JSON form of the messages, taken as
+// an array if list is true and as the single message otherwise.
+func diffJSON(genaiValue any, msgs []proto.Message, list bool) ([]FieldDiff, error) {
+	// The legacy omitempty keeps the pointers to empty structs, which are meaningful as empty messages.
+	data, err := json.Marshal(genaiValue, json.DefaultOptionsV2(), jsonv1.OmitEmptyWithLegacySemantics(true))
+	if err != nil {
+		return nil, fmt.Errorf("encode genai value: %w", err)
+	}
+	var left any
+	if err := json.Unmarshal(data, &left, json.DefaultOptionsV2()); err != nil {
+		return nil, fmt.Errorf("decode genai value: %w", err)
+	}
+
+	values := make([]any, len(msgs))
+	for i, msg := range msgs {
+		if msg == nil || reflect.ValueOf(msg).IsNil() {
+			continue
+		}
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, fmt.Errorf("encode aiplatform value: %w", err)
+		}
+		if err := json.Unmarshal(data, &values[i], json.DefaultOptionsV2()); err != nil {
+			return nil, fmt.Errorf("decode aiplatform value: %w", err)
+		}
+	}
+	var right any = values
+	if !list {
+		right = values[0]
+	}
+
+	var diffs []FieldDiff
+	diffValues("", pruneZero(left), pruneZero(right), &diffs)
+	slices.SortStableFunc(diffs, func(a, b FieldDiff) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return diffs, nil
+}
+
+// diffValues appends the differences between the decoded JSON values at path to diffs.
+func diffValues(path string, left, right any, diffs *[]FieldDiff) {
+	switch {
+	case left == nil && right == nil:
+		return
+	case right == nil:
+		*diffs = append(*diffs, FieldDiff{Path: displayPath(path), Kind: DiffMissingInAIPlatform, GenAI: left})
+		return
+	case left == nil:
+		*diffs = append(*diffs, FieldDiff{Path: displayPath(path), Kind: DiffMissingInGenAI, AIPlatform: right})
+		return
+	}
+
+	switch l := left.(type) {
+	case map[string]any:
+		if r, ok := right.(map[string]any); ok {
+			keys := slices.Sorted(maps.Keys(l))
+			for key := range r {
+				if _, ok := l[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			for _, key := range keys {
+				diffValues(joinPath(path, key), l[key], r[key], diffs)
+			}
+			return
+		}
+	case []any:
+		if r, ok := right.([]any); ok {
+			for i := range max(len(l), len(r)) {
+				var le, re any
+				if i < len(l) {
+					le = l[i]
+				}
+				if i < len(r) {
+					re = r[i]
+				}
+				diffValues(path+"["+strconv.Itoa(i)+"]", le, re, diffs)
+			}
+			return
+		}
+	}
+
+	if !equalScalars(left, right) {
+		*diffs = append(*diffs, FieldDiff{Path: displayPath(path), Kind: DiffValueMismatch, GenAI: left, AIPlatform: right})
+	}
+}
+
+// equalScalars reports whether the decoded JSON values are equal, taking the numbers encoded as
+// strings, such as the 64-bit integers of protojson, as numbers.
+func equalScalars(left, right any) bool {
+	if reflect.DeepEqual(left, right) {
+		return true
+	}
+
+	toNumber := func(v any) (float64, bool) {
+		switch v := v.(type) {
+		case float64:
+			return v, true
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			return f, err == nil
+		default:
+			return 0, false
+		}
+	}
+	l, lok := toNumber(left)
+	r, rok := toNumber(right)
+	return lok && rok && l == r
+}
+
+// pruneZero returns the decoded JSON value without its zero scalars and empty arrays, which are
+// treated as not set. Empty objects are kept, as an empty message such as a tool without options
+// is meaningful.
+func pruneZero(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		pruned := make(map[string]any, len(v))
+		for key, e := range v {
+			if e := pruneZero(e); e != nil {
+				pruned[key] = e
+			}
+		}
+		return pruned
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		pruned := make([]any, len(v))
+		for i, e := range v {
+			pruned[i] = pruneZero(e)
+		}
+		return pruned
+	case string:
+		if v == "" {
+			return nil
+		}
+	case float64:
+		if v == 0 {
+			return nil
+		}
+	case bool:
+		if !v {
+			return nil
+		}
+	}
+	return v
+}
+
+// joinPath returns the path of the named field of the object at path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// displayPath returns the path shown in the diffs, naming the root value.
+func displayPath(path string) string {
+	if path == "" {
+		return "."
+	}
+	return path
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package aiconv_test
+
+import (
+	"testing"
+
+	"cloud.google.com/go/aiplatform/apiv1beta1/aiplatformpb"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+	"github.com/go-a2a/adk-go/types/aiconv"
+)
+
+func TestDiffGenerationConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		genaiCfg    *genai.GenerationConfig
+		platformCfg *aiplatformpb.GenerationConfig
+		want        []aiconv.FieldDiff
+	}{
+		"both nil": {},
+		"equal": {
+			genaiCfg: &genai.GenerationConfig{
+				Temperature:     types.ToPtr[float32](0.5),
+				MaxOutputTokens: 128,
+				StopSequences:   []string{"END"},
+			},
+			platformCfg: &aiplatformpb.GenerationConfig{
+				Temperature:     types.ToPtr[float32](0.5),
+				MaxOutputTokens: types.ToPtr[int32](128),
+				StopSequences:   []string{"END"},
+			},
+		},
+		"zero values are not set": {
+			genaiCfg:    &genai.GenerationConfig{StopSequences: []string{}},
+			platformCfg: &aiplatformpb.GenerationConfig{Temperature: types.ToPtr[float32](0)},
+		},
+		"missing in aiplatform": {
+			genaiCfg: &genai.GenerationConfig{
+				Seed:           types.ToPtr[int32](42),
+				ThinkingConfig: &genai.GenerationConfigThinkingConfig{IncludeThoughts: true},
+			},
+			platformCfg: &aiplatformpb.GenerationConfig{},
+			want: []aiconv.FieldDiff{
+				{Path: "seed", Kind: aiconv.DiffMissingInAIPlatform, GenAI: float64(42)},
+				{Path: "thinkingConfig", Kind: aiconv.DiffMissingInAIPlatform, GenAI: map[string]any{"includeThoughts": true}},
+			},
+		},
+		"missing in genai": {
+			genaiCfg:    &genai.GenerationConfig{},
+			platformCfg: &aiplatformpb.GenerationConfig{CandidateCount: types.ToPtr[int32](2)},
+			want: []aiconv.FieldDiff{
+				{Path: "candidateCount", Kind: aiconv.DiffMissingInGenAI, AIPlatform: float64(2)},
+			},
+		},
+		"value mismatch": {
+			genaiCfg:    &genai.GenerationConfig{TopK: types.ToPtr[float32](40), StopSequences: []string{"a", "b"}},
+			platformCfg: &aiplatformpb.GenerationConfig{TopK: types.ToPtr[float32](20), StopSequences: []string{"a"}},
+			want: []aiconv.FieldDiff{
+				{Path: "stopSequences[1]", Kind: aiconv.DiffMissingInAIPlatform, GenAI: "b"},
+				{Path: "topK", Kind: aiconv.DiffValueMismatch, GenAI: float64(40), AIPlatform: float64(20)},
+			},
+		},
+		"nil aiplatform config": {
+			genaiCfg: &genai.GenerationConfig{MaxOutputTokens: 10},
+			want: []aiconv.FieldDiff{
+				{Path: ".", Kind: aiconv.DiffMissingInAIPlatform, GenAI: map[string]any{"maxOutputTokens": float64(10)}},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := aiconv.DiffGenerationConfig(tt.genaiCfg, tt.platformCfg)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("DiffGenerationConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExplainConversion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		value   any
+		want    []aiconv.FieldDiff
+		wantErr bool
+	}{
+		"lossless content": {
+			value: genai.NewContentFromText("hello", genai.RoleUser),
+		},
+		"function call id": {
+			value: []*genai.Content{{
+				Role: genai.RoleModel,
+				Parts: []*genai.Part{
+					genai.NewPartFromText("calling"),
+					{FunctionCall: &genai.FunctionCall{ID: "call-1", Name: "lookup"}},
+				},
+			}},
+			want: []aiconv.FieldDiff{
+				{Path: "[0].parts[1].functionCall.id", Kind: aiconv.DiffMissingInAIPlatform, GenAI: "call-1"},
+			},
+		},
+		"empty tool message": {
+			value: &genai.Tool{GoogleSearch: &genai.GoogleSearch{}},
+			want: []aiconv.FieldDiff{
+				{Path: "googleSearch", Kind: aiconv.DiffMissingInAIPlatform, GenAI: map[string]any{}},
+			},
+		},
+		"generation config": {
+			value: &genai.GenerationConfig{Seed: types.ToPtr[int32](7)},
+			want: []aiconv.FieldDiff{
+				{Path: "seed", Kind: aiconv.DiffMissingInAIPlatform, GenAI: float64(7)},
+			},
+		},
+		"unsupported type": {
+			value:   "text",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := aiconv.ExplainConversion(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExplainConversion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ExplainConversion() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
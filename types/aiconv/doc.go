@@ -265,6 +265,17 @@
 //   - Nil-safe operations that return nil for nil inputs
 //   - Consistent bidirectional conversion behavior
 //
+// # Debugging Conversions
+//
+// The genai and aiplatform types do not support the same fields, so that a conversion may drop
+// settings silently. DiffGenerationConfig and ExplainConversion report them:
+//
+//	for _, diff := range aiconv.DiffGenerationConfig(cfg, aiconv.ToAIPlatformGenerationConfig(cfg)) {
+//		log.Println(diff) // seed: 42 in genai, not set in aiplatform
+//	}
+//
+//	diffs, err := aiconv.ExplainConversion(contents)
+//
 // # Bidirectional Consistency
 //
 // All conversion functions maintain round-trip consistency:
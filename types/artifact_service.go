@@ -5,6 +5,7 @@ package types
 
 import (
 	"context"
+	"io"
 
 	"google.golang.org/genai"
 )
@@ -23,6 +24,19 @@ type ArtifactService interface {
 	// The artifact is a file identified by the app name, user ID, session ID, and filename.
 	LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error)
 
+	// SaveArtifactStream saves an artifact read from r to the artifact service storage.
+	//
+	// It is the streaming variant of SaveArtifact for artifacts too large to be buffered
+	// in memory, and follows the same versioning.
+	SaveArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, r io.Reader, mimeType string) (int, error)
+
+	// LoadArtifactStream gets a reader of an artifact from the artifact service storage
+	// along with its MIME type.
+	//
+	// It is the streaming variant of LoadArtifact and follows the same versioning.
+	// The caller must close the returned reader.
+	LoadArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, version int) (io.ReadCloser, string, error)
+
 	// ListArtifactKey lists all the artifact filenames within a session.
 	ListArtifactKey(ctx context.Context, appName, userID, sessionID string) ([]string, error)
 
@@ -32,6 +46,10 @@ type ArtifactService interface {
 	// ListVersions lists all versions of an artifact.
 	ListVersions(ctx context.Context, appName, userID, sessionID, filename string) ([]int, error)
 
+	// Ping probes the backend of the artifact service with a lightweight call, and reports an
+	// [*UnhealthyError] when it cannot be reached. An in-memory service always succeeds.
+	Ping(ctx context.Context) error
+
 	// Close closes the artifact service connection.
 	Close() error
 }
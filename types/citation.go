@@ -0,0 +1,137 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// CitationSource is the origin of a [Citation].
+type CitationSource string
+
+const (
+	// CitationSourceGrounding is a source found by grounding, such as Google Search or a retrieval
+	// corpus, from the grounding supports of the response.
+	CitationSourceGrounding CitationSource = "grounding"
+
+	// CitationSourceRecitation is a source the model recited from, from the citation metadata of
+	// the response.
+	CitationSourceRecitation CitationSource = "recitation"
+)
+
+// Citation is a source supporting a span of the text of a response.
+type Citation struct {
+	// Source is the origin of the citation.
+	Source CitationSource
+
+	// URI is the URI of the source.
+	URI string
+
+	// Title is the title of the source.
+	Title string
+
+	// Text is the span of the response supported by the source.
+	Text string
+
+	// PartIndex is the index of the part of the response content holding the span.
+	PartIndex int
+
+	// StartIndex and EndIndex are the byte offsets of the span in the text of the part for
+	// grounding, or in the text of the whole response for recitation.
+	StartIndex int
+	EndIndex   int
+
+	// Confidence is the confidence of the source supporting the span, in [0, 1].
+	// Zero if not reported.
+	Confidence float64
+
+	// License is the license of a recited source, if known.
+	License string
+}
+
+// ExtractCitations returns the citations of the response, from its grounding supports first and
+// then from its citation metadata.
+//
+// A grounding support backed by several chunks gives a citation per chunk. The supports
+// referring to unknown chunks are skipped. It returns nil for a response without grounding nor
+// citations.
+func ExtractCitations(response *LLMResponse) []Citation {
+	if response == nil {
+		return nil
+	}
+
+	var citations []Citation
+	if gm := response.GroundingMetadata; gm != nil {
+		for _, support := range gm.GroundingSupports {
+			if support == nil {
+				continue
+			}
+			var segment genai.Segment
+			if support.Segment != nil {
+				segment = *support.Segment
+			}
+			for i, idx := range support.GroundingChunkIndices {
+				if idx < 0 || int(idx) >= len(gm.GroundingChunks) || gm.GroundingChunks[idx] == nil {
+					continue
+				}
+				citation := Citation{
+					Source:     CitationSourceGrounding,
+					Text:       segment.Text,
+					PartIndex:  int(segment.PartIndex),
+					StartIndex: int(segment.StartIndex),
+					EndIndex:   int(segment.EndIndex),
+				}
+				switch chunk := gm.GroundingChunks[idx]; {
+				case chunk.Web != nil:
+					citation.URI, citation.Title = chunk.Web.URI, chunk.Web.Title
+				case chunk.RetrievedContext != nil:
+					citation.URI, citation.Title = chunk.RetrievedContext.URI, chunk.RetrievedContext.Title
+				}
+				if i < len(support.ConfidenceScores) {
+					citation.Confidence = float64(support.ConfidenceScores[i])
+				}
+				citations = append(citations, citation)
+			}
+		}
+	}
+
+	if cm := response.CitationMetadata; cm != nil {
+		text := responseText(response.Content)
+		for _, c := range cm.Citations {
+			if c == nil {
+				continue
+			}
+			citation := Citation{
+				Source:     CitationSourceRecitation,
+				URI:        c.URI,
+				Title:      c.Title,
+				StartIndex: int(c.StartIndex),
+				EndIndex:   int(c.EndIndex),
+				License:    c.License,
+			}
+			if 0 <= citation.StartIndex && citation.StartIndex <= citation.EndIndex && citation.EndIndex <= len(text) {
+				citation.Text = text[citation.StartIndex:citation.EndIndex]
+			}
+			citations = append(citations, citation)
+		}
+	}
+
+	return citations
+}
+
+// responseText returns the text of the parts of the content, thoughts excluded.
+func responseText(content *genai.Content) string {
+	if content == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, part := range content.Parts {
+		if part != nil && !part.Thought {
+			sb.WriteString(part.Text)
+		}
+	}
+	return sb.String()
+}
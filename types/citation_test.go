@@ -0,0 +1,74 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestExtractCitations(t *testing.T) {
+	t.Parallel()
+
+	text := "Kyoto was the capital of Japan for over a thousand years."
+	tests := map[string]struct {
+		response *types.LLMResponse
+		want     []types.Citation
+	}{
+		"nil response": {},
+		"no grounding": {
+			response: &types.LLMResponse{Content: genai.NewContentFromText(text, genai.RoleModel)},
+		},
+		"grounding supports": {
+			response: &types.LLMResponse{
+				Content: genai.NewContentFromText(text, genai.RoleModel),
+				GroundingMetadata: &genai.GroundingMetadata{
+					GroundingChunks: []*genai.GroundingChunk{
+						{Web: &genai.GroundingChunkWeb{URI: "https://example.com/kyoto", Title: "Kyoto"}},
+						{RetrievedContext: &genai.GroundingChunkRetrievedContext{URI: "gs://corpus/japan.txt", Title: "Japan"}},
+					},
+					GroundingSupports: []*genai.GroundingSupport{
+						{
+							Segment:               &genai.Segment{StartIndex: 0, EndIndex: 30, Text: "Kyoto was the capital of Japan"},
+							GroundingChunkIndices: []int32{0, 1, 7},
+							ConfidenceScores:      []float32{0.5, 0.25},
+						},
+					},
+				},
+			},
+			want: []types.Citation{
+				{Source: types.CitationSourceGrounding, URI: "https://example.com/kyoto", Title: "Kyoto", Text: "Kyoto was the capital of Japan", EndIndex: 30, Confidence: 0.5},
+				{Source: types.CitationSourceGrounding, URI: "gs://corpus/japan.txt", Title: "Japan", Text: "Kyoto was the capital of Japan", EndIndex: 30, Confidence: 0.25},
+			},
+		},
+		"recitation": {
+			response: &types.LLMResponse{
+				Content: genai.NewContentFromText(text, genai.RoleModel),
+				CitationMetadata: &genai.CitationMetadata{
+					Citations: []*genai.Citation{
+						{URI: "https://example.com/history", StartIndex: 31, EndIndex: 56, License: "CC-BY"},
+						{URI: "https://example.com/out-of-range", StartIndex: 31, EndIndex: 500},
+					},
+				},
+			},
+			want: []types.Citation{
+				{Source: types.CitationSourceRecitation, URI: "https://example.com/history", Text: "for over a thousand years", StartIndex: 31, EndIndex: 56, License: "CC-BY"},
+				{Source: types.CitationSourceRecitation, URI: "https://example.com/out-of-range", StartIndex: 31, EndIndex: 500},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, types.ExtractCitations(tt.response)); diff != "" {
+				t.Errorf("ExtractCitations() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Clock tells the time of the events and sessions.
+//
+// It is injected with [WithClock] so that tests can use a [FakeClock].
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// IDGenerator generates the IDs of the events, invocations and sessions.
+//
+// It is injected with [WithIDGenerator] so that tests can use sequential or seeded IDs.
+type IDGenerator interface {
+	// NewEventID returns the ID of a new event.
+	NewEventID() string
+
+	// NewInvocationID returns the ID of a new invocation.
+	NewInvocationID() string
+
+	// NewSessionID returns the ID of a new session.
+	NewSessionID() string
+}
+
+// SystemClock is the [Clock] telling the real time.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now implements [Clock].
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// RandomIDGenerator is the [IDGenerator] generating random IDs.
+type RandomIDGenerator struct{}
+
+var _ IDGenerator = RandomIDGenerator{}
+
+// NewEventID implements [IDGenerator].
+func (RandomIDGenerator) NewEventID() string {
+	return NewEventID()
+}
+
+// NewInvocationID implements [IDGenerator].
+func (RandomIDGenerator) NewInvocationID() string {
+	return NewInvocationContextID()
+}
+
+// NewSessionID implements [IDGenerator].
+func (RandomIDGenerator) NewSessionID() string {
+	return uuid.NewString()
+}
+
+// FakeClock is a [Clock] for tests, starting at a fixed time and advancing by a fixed step on
+// each call to Now.
+//
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+var _ Clock = (*FakeClock)(nil)
+
+// NewFakeClock returns a new [FakeClock] whose first call to Now returns start, and each next
+// call the previous time plus step. A zero step stops the clock.
+func NewFakeClock(start time.Time, step time.Duration) *FakeClock {
+	return &FakeClock{
+		now:  start,
+		step: step,
+	}
+}
+
+// Now implements [Clock].
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+// Advance moves the clock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+// SequentialIDGenerator is an [IDGenerator] for tests generating IDs such as "event-1",
+// "invocation-1" and "session-1", with a counter for each kind.
+//
+// It is safe for concurrent use.
+type SequentialIDGenerator struct {
+	mu          sync.Mutex
+	events      int
+	invocations int
+	sessions    int
+}
+
+var _ IDGenerator = (*SequentialIDGenerator)(nil)
+
+// NewSequentialIDGenerator returns a new [SequentialIDGenerator].
+func NewSequentialIDGenerator() *SequentialIDGenerator {
+	return new(SequentialIDGenerator)
+}
+
+// NewEventID implements [IDGenerator].
+func (g *SequentialIDGenerator) NewEventID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.events++
+	return fmt.Sprintf("event-%d", g.events)
+}
+
+// NewInvocationID implements [IDGenerator].
+func (g *SequentialIDGenerator) NewInvocationID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.invocations++
+	return fmt.Sprintf("invocation-%d", g.invocations)
+}
+
+// NewSessionID implements [IDGenerator].
+func (g *SequentialIDGenerator) NewSessionID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.sessions++
+	return fmt.Sprintf("session-%d", g.sessions)
+}
+
+// SeededIDGenerator is an [IDGenerator] for tests generating IDs of the same form as the
+// [RandomIDGenerator] from a seeded pseudo-random source, so that a run can be replayed.
+//
+// It is safe for concurrent use.
+type SeededIDGenerator struct {
+	mu  sync.Mutex
+	rng *rand.Rand
+}
+
+var _ IDGenerator = (*SeededIDGenerator)(nil)
+
+// NewSeededIDGenerator returns a new [SeededIDGenerator] generating the same IDs for the same seed.
+func NewSeededIDGenerator(seed uint64) *SeededIDGenerator {
+	return &SeededIDGenerator{
+		rng: rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// NewEventID implements [IDGenerator].
+func (g *SeededIDGenerator) NewEventID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	b := make([]byte, 8)
+	for i := range b {
+		b[i] = letterBytes[g.rng.IntN(len(letterBytes))]
+	}
+	return string(b)
+}
+
+// NewInvocationID implements [IDGenerator].
+func (g *SeededIDGenerator) NewInvocationID() string {
+	return `e-` + g.newUUID()
+}
+
+// NewSessionID implements [IDGenerator].
+func (g *SeededIDGenerator) NewSessionID() string {
+	return g.newUUID()
+}
+
+// newUUID returns a version 4 UUID made of pseudo-random bytes.
+func (g *SeededIDGenerator) newUUID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var id uuid.UUID
+	for i := range id {
+		id[i] = byte(g.rng.Uint32())
+	}
+	id[6] = (id[6] & 0x0f) | 0x40 // version 4
+	id[8] = (id[8] & 0x3f) | 0x80 // variant 10
+	return id.String()
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestFakeClock(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := types.NewFakeClock(start, time.Second)
+
+	got := []time.Time{clock.Now(), clock.Now()}
+	clock.Advance(time.Minute)
+	got = append(got, clock.Now())
+
+	want := []time.Time{start, start.Add(time.Second), start.Add(2*time.Second + time.Minute)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Now() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSequentialIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	ids := types.NewSequentialIDGenerator()
+	got := []string{ids.NewEventID(), ids.NewEventID(), ids.NewInvocationID(), ids.NewSessionID()}
+	want := []string{"event-1", "event-2", "invocation-1", "session-1"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("IDs mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSeededIDGenerator(t *testing.T) {
+	t.Parallel()
+
+	generate := func(seed uint64) []string {
+		ids := types.NewSeededIDGenerator(seed)
+		return []string{ids.NewEventID(), ids.NewInvocationID(), ids.NewSessionID()}
+	}
+
+	first, second := generate(42), generate(42)
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("IDs of the same seed mismatch (-first +second):\n%s", diff)
+	}
+	if other := generate(7); cmp.Equal(first, other) {
+		t.Errorf("IDs of different seeds are equal: %v", other)
+	}
+	if len(first[0]) != 8 {
+		t.Errorf("NewEventID() = %q, want 8 characters", first[0])
+	}
+}
+
+func TestInvocationContextNewEvent(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ictx := types.NewInvocationContext(nil, nil, nil,
+		types.WithClock(types.NewFakeClock(start, time.Second)),
+		types.WithIDGenerator(types.NewSequentialIDGenerator()),
+	)
+
+	for i, want := range []struct {
+		id string
+		ts time.Time
+	}{
+		{id: "event-1", ts: start},
+		{id: "event-2", ts: start.Add(time.Second)},
+	} {
+		event := ictx.NewEvent()
+		if event.ID != want.id || !event.Timestamp.Equal(want.ts) {
+			t.Errorf("event %d = (%s, %s), want (%s, %s)", i, event.ID, event.Timestamp, want.id, want.ts)
+		}
+	}
+}
@@ -61,6 +61,15 @@ type CodeExecutor interface {
 	Close() error
 }
 
+// LanguageSupporter is implemented by the [CodeExecutor]s telling the languages of the code blocks
+// they run, such as "python" or "bash".
+//
+// The code execution of the LLM flow only runs the code blocks of these languages, or of python for
+// an executor not implementing it.
+type LanguageSupporter interface {
+	SupportedLanguages() []string
+}
+
 // ExecutionConfig holds configuration options for code executors.
 type ExecutionConfig struct {
 	// OptimizeDataFiles enables optimization for large data files (e.g., CSV processing).
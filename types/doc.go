@@ -136,7 +136,18 @@
 //	// Session-level state (specific to conversation)
 //	StateDelta["temp:context"] = "current_topic"
 //
-// State changes are applied through EventActions with automatic propagation.
+//	// Branch-level state (private to a ParallelAgent branch, discarded when it ends)
+//	StateDelta["temp:branch:draft"] = draft
+//
+// State changes are applied through EventActions with automatic propagation. The scoped setters
+// add the prefixes, and ScopedStateDelta groups a delta back by scope:
+//
+//	actions := NewEventActions().
+//		SetAppState("config", "production").
+//		SetUserState("preferences", userPrefs).
+//		SetSessionState("topic", "weather").
+//		SetTempState("context", "current_topic")
+//	userDelta := actions.ScopedStateDelta().User // {"preferences": userPrefs}
 //
 // # Context System
 //
@@ -167,6 +178,15 @@
 //		Branch() string
 //	}
 //
+// NewSimpleInvocationContext builds the context of a one-shot call from a session service: it
+// creates or fetches the session, seeds it with the user message, and sets the optional services:
+//
+//	ictx, err := types.NewSimpleInvocationContext(ctx, sessionService, "app", "user",
+//		genai.NewContentFromText("Summarize the report", genai.RoleUser),
+//		types.WithMemory(memoryService),
+//	)
+//	answer, _, err := agent.RunToCompletion(ctx, myAgent, ictx)
+//
 // # Flow System
 //
 // Flows provide pipeline architecture for processing:
@@ -228,6 +248,8 @@
 //	type ArtifactService interface {
 //		SaveArtifact(ctx context.Context, appName, userID, sessionID, filename string, artifact *genai.Part) (int, error)
 //		LoadArtifact(ctx context.Context, appName, userID, sessionID, filename string, version int) (*genai.Part, error)
+//		SaveArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, r io.Reader, mimeType string) (int, error)
+//		LoadArtifactStream(ctx context.Context, appName, userID, sessionID, filename string, version int) (io.ReadCloser, string, error)
 //		ListArtifactKey(ctx context.Context, appName, userID, sessionID string) ([]string, error)
 //		DeleteArtifact(ctx context.Context, appName, userID, sessionID, filename string) error
 //		ListVersions(ctx context.Context, appName, userID, sessionID, filename string) ([]int, error)
@@ -243,6 +265,64 @@
 //		ProcessPlanningResponse(ctx context.Context, cctx *CallbackContext, responseParts []*genai.Part) []*genai.Part
 //	}
 //
+// # Deterministic Tests
+//
+// The IDs and timestamps of the events come from the Clock and IDGenerator of the invocation
+// context, the real time and random IDs by default. Tests inject fixed ones to get stable traces:
+//
+//	ictx := types.NewInvocationContext(agent, session, sessionService,
+//		types.WithClock(types.NewFakeClock(start, time.Second)),
+//		types.WithIDGenerator(types.NewSequentialIDGenerator()),
+//	)
+//
+// # Releasing Resources
+//
+// CloseServices releases the resources of an invocation once it ended: it cancels the jobs of the
+// long-running tools, closes the resources registered with AddCloser, then the services, and
+// joins the errors rather than stopping at the first:
+//
+//	ictx.AddCloser(codeExecutor)
+//	defer func() {
+//		if err := types.CloseServices(ictx); err != nil {
+//			log.Printf("close services: %v", err)
+//		}
+//	}()
+//
+// The resources of the agents, such as the model clients, the tools and the toolsets, outlive the
+// invocations. An agent exposes them by implementing ResourceHolder, and further resources are
+// registered with WithResources or AddResource. InitAgent initializes the resources implementing
+// Initializer, each agent before its sub-agents, and CloseAgent releases them in the reverse
+// order, the sub-agents before their parent. A resource shared by several agents is initialized
+// and closed once.
+//
+// # Health Checks
+//
+// The memory, artifact and session services implement Ping, a lightweight probe of their backend
+// reporting an UnhealthyError, matched by ErrUnhealthy, when it cannot be reached. The in-memory
+// services always succeed. PingServices probes the services of an invocation, such as for the
+// readiness probe of a server:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//		if err := types.PingServices(r.Context(), ictx); err != nil {
+//			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//		}
+//	})
+//
+// # Argument Hashing
+//
+// HashArgs returns a stable hash of the arguments of a function call, hashing the maps in the
+// order of their keys, for the tools that key their calls by arguments:
+//
+//	key, err := types.HashArgs(call.Args)
+//
+// # Token Estimation
+//
+// A Tokenizer counts the tokens of a text locally, for the token budgets where the exact count
+// of [TokenCounter] is unavailable or too slow. ApproximateTokenizer estimates them from the
+// number of characters per token of the model family, and a real tokenizer can be plugged in:
+//
+//	tokens := types.CountRequestTokens(types.NewApproximateTokenizer(req.Model), req)
+//
 // # Best Practices
 //
 // When implementing these interfaces:
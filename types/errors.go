@@ -3,6 +3,13 @@
 
 package types
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // NotImplementedError is the error type for unimplemented behaiviour.
 type NotImplementedError string
 
@@ -10,3 +17,387 @@ type NotImplementedError string
 func (e NotImplementedError) Error() string {
 	return string(e)
 }
+
+// ErrUnknownFunction is reported when the model calls a function that is not registered as a tool.
+//
+// The concrete error is an [*UnknownFunctionError]; use [errors.Is] to match it and
+// [errors.As] to get the requested name and the available tools.
+var ErrUnknownFunction = errors.New("unknown function")
+
+// ErrorCodeUnknownFunction is the [LLMResponse.ErrorCode] set on the function response event
+// of a call to an unknown function.
+const ErrorCodeUnknownFunction = "UNKNOWN_FUNCTION"
+
+// UnknownFunctionError is the error for a function call to a tool that does not exist.
+type UnknownFunctionError struct {
+	// Name is the function name requested by the model.
+	Name string
+
+	// Available is the sorted list of the tool names available to the model.
+	Available []string
+}
+
+var _ error = (*UnknownFunctionError)(nil)
+
+// Error implements error.
+func (e *UnknownFunctionError) Error() string {
+	return "no such tool: " + e.Name
+}
+
+// Is reports whether the target is [ErrUnknownFunction].
+func (e *UnknownFunctionError) Is(target error) bool {
+	return target == ErrUnknownFunction
+}
+
+// ErrDuplicateToolName is reported when several tools of an agent have the same name, so that the
+// function declarations collide and the calls of the model are ambiguous.
+//
+// The concrete error is a [*DuplicateToolNameError]; use [errors.Is] to match it and
+// [errors.As] to get the conflicting names.
+var ErrDuplicateToolName = errors.New("duplicate tool name")
+
+// DuplicateToolNameError is the error for the tools sharing a name.
+type DuplicateToolNameError struct {
+	// Names is the sorted list of the names shared by several tools.
+	Names []string
+}
+
+var _ error = (*DuplicateToolNameError)(nil)
+
+// Error implements error.
+func (e *DuplicateToolNameError) Error() string {
+	return "duplicate tool names: " + strings.Join(e.Names, ", ")
+}
+
+// Is reports whether the target is [ErrDuplicateToolName].
+func (e *DuplicateToolNameError) Is(target error) bool {
+	return target == ErrDuplicateToolName
+}
+
+// ErrInvalidArguments is reported when the model calls a function with arguments that do not match
+// the parameters declared by the tool.
+//
+// The concrete error is an [*InvalidArgumentsError]; use [errors.Is] to match it and
+// [errors.As] to get the violations.
+var ErrInvalidArguments = errors.New("invalid function arguments")
+
+// ErrorCodeInvalidArguments is the [LLMResponse.ErrorCode] set on the function response event
+// of a call with invalid arguments.
+const ErrorCodeInvalidArguments = "INVALID_ARGUMENTS"
+
+// ArgumentViolation describes a single argument that does not match the declared parameters.
+type ArgumentViolation struct {
+	// Path is the path of the argument, such as "query" or "filters[0].field".
+	Path string
+
+	// Reason is why the argument is invalid.
+	Reason string
+}
+
+// InvalidArgumentsError is the error for a function call whose arguments do not match the
+// parameters declared by the tool.
+type InvalidArgumentsError struct {
+	// Name is the function name requested by the model.
+	Name string
+
+	// Violations lists the invalid arguments, sorted by path.
+	Violations []ArgumentViolation
+}
+
+var _ error = (*InvalidArgumentsError)(nil)
+
+// Error implements error.
+func (e *InvalidArgumentsError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("invalid arguments for ")
+	sb.WriteString(e.Name)
+	for i, v := range e.Violations {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(v.Path)
+		sb.WriteString(": ")
+		sb.WriteString(v.Reason)
+	}
+	return sb.String()
+}
+
+// Is reports whether the target is [ErrInvalidArguments].
+func (e *InvalidArgumentsError) Is(target error) bool {
+	return target == ErrInvalidArguments
+}
+
+// ErrorCodeMaxTransferDepth is the error code of the event ending an agent transfer refused as the
+// invocation reached its maximum number of transfers.
+const ErrorCodeMaxTransferDepth = "MAX_TRANSFER_DEPTH"
+
+// ErrNoMemoryService is reported when searching the memory of an invocation without a
+// [MemoryService].
+var ErrNoMemoryService = errors.New("no memory service configured")
+
+// ErrEmptySessionFilter is reported by [SessionService.DeleteSessions] for a [SessionFilter]
+// without criterion.
+var ErrEmptySessionFilter = errors.New("empty session filter")
+
+// ErrRateLimited is reported when a tenant has exhausted its model usage budget.
+//
+// The concrete error is a [*RateLimitError]; use [errors.Is] to match it and
+// [errors.As] to get the tenant and when to retry.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is the error for a model call rejected because the tenant exhausted its budget.
+type RateLimitError struct {
+	// AppName is the application of the tenant.
+	AppName string
+
+	// UserID is the user of the tenant.
+	UserID string
+
+	// Limit is the exhausted limit, such as "qps" or "tokens_per_minute".
+	Limit string
+
+	// RetryAfter is the time after which the call would be allowed.
+	RetryAfter time.Duration
+}
+
+var _ error = (*RateLimitError)(nil)
+
+// Error implements error.
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited: tenant %s/%s exceeded %s limit, retry after %s", e.AppName, e.UserID, e.Limit, e.RetryAfter)
+}
+
+// Is reports whether the target is [ErrRateLimited].
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// RetryDelay returns the delay before the call would be allowed, honored by backoff.Retry.
+func (e *RateLimitError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
+
+// ErrStateConflict is reported when an event updates state keys that were modified after the
+// state version the event was computed from.
+//
+// The concrete error is a [*StateConflictError]; use [errors.Is] to match it and
+// [errors.As] to get the conflicting keys.
+var ErrStateConflict = errors.New("state conflict")
+
+// StateConflictError is the error for an event rejected by [SessionService.AppendEvent] because
+// its [EventActions.StateBaseVersion] is stale for some keys of its state delta.
+type StateConflictError struct {
+	// SessionID is the ID of the session.
+	SessionID string
+
+	// Keys is the sorted list of the keys modified after the base version.
+	Keys []string
+
+	// BaseVersion is the state version the event was computed from.
+	BaseVersion int64
+
+	// Version is the current state version of the session.
+	Version int64
+}
+
+var _ error = (*StateConflictError)(nil)
+
+// Error implements error.
+func (e *StateConflictError) Error() string {
+	return fmt.Sprintf("state conflict in session %s: %s modified after version %d (now %d)",
+		e.SessionID, strings.Join(e.Keys, ", "), e.BaseVersion, e.Version)
+}
+
+// Is reports whether the target is [ErrStateConflict].
+func (e *StateConflictError) Is(target error) bool {
+	return target == ErrStateConflict
+}
+
+// ErrUnsupportedToolChoice is reported by a [Model] that cannot honor the [ToolChoice] of a request.
+//
+// The concrete error is an [*UnsupportedToolChoiceError]; use [errors.Is] to match it and
+// [errors.As] to get the model and the choice.
+var ErrUnsupportedToolChoice = errors.New("unsupported tool choice")
+
+// UnsupportedToolChoiceError is the error for a [ToolChoice] the model has no mechanism for.
+type UnsupportedToolChoiceError struct {
+	// Model is the name of the model.
+	Model string
+
+	// Choice is the unsupported choice.
+	Choice *ToolChoice
+}
+
+var _ error = (*UnsupportedToolChoiceError)(nil)
+
+// Error implements error.
+func (e *UnsupportedToolChoiceError) Error() string {
+	return fmt.Sprintf("model %s does not support tool choice %s", e.Model, e.Choice)
+}
+
+// Is reports whether the target is [ErrUnsupportedToolChoice].
+func (e *UnsupportedToolChoiceError) Is(target error) bool {
+	return target == ErrUnsupportedToolChoice
+}
+
+// ErrUnsupportedGrounding is reported by a [Model] that cannot ground its answers as requested by
+// the [GoogleSearchGrounding] of a request.
+//
+// The concrete error is an [*UnsupportedGroundingError]; use [errors.Is] to match it.
+var ErrUnsupportedGrounding = errors.New("unsupported grounding")
+
+// UnsupportedGroundingError is the error for a [GoogleSearchGrounding] the model has no mechanism for.
+type UnsupportedGroundingError struct {
+	// Model is the name of the model.
+	Model string
+}
+
+var _ error = (*UnsupportedGroundingError)(nil)
+
+// Error implements error.
+func (e *UnsupportedGroundingError) Error() string {
+	return fmt.Sprintf("model %s does not support grounding with Google Search", e.Model)
+}
+
+// Is reports whether the target is [ErrUnsupportedGrounding].
+func (e *UnsupportedGroundingError) Is(target error) bool {
+	return target == ErrUnsupportedGrounding
+}
+
+// ErrInlineDataTooLarge is reported by a [Model] for a request whose inline data part exceeds the
+// size it accepts inline and cannot be uploaded instead.
+//
+// The concrete error is an [*InlineDataTooLargeError]; use [errors.Is] to match it.
+var ErrInlineDataTooLarge = errors.New("inline data too large")
+
+// InlineDataTooLargeError is the error for an inline data part over the size limit of the model.
+type InlineDataTooLargeError struct {
+	// Model is the name of the model.
+	Model string
+
+	// MIMEType is the MIME type of the inline data.
+	MIMEType string
+
+	// Size is the size of the inline data, in bytes.
+	Size int
+
+	// Limit is the maximum size of the inline data, in bytes.
+	Limit int64
+}
+
+var _ error = (*InlineDataTooLargeError)(nil)
+
+// Error implements error.
+func (e *InlineDataTooLargeError) Error() string {
+	return fmt.Sprintf("model %s: inline data of type %s is %d bytes, over the limit of %d bytes: upload it as a file instead", e.Model, e.MIMEType, e.Size, e.Limit)
+}
+
+// Is reports whether the target is [ErrInlineDataTooLarge].
+func (e *InlineDataTooLargeError) Is(target error) bool {
+	return target == ErrInlineDataTooLarge
+}
+
+// ErrUnhealthy is reported by the Ping method of a service whose backend cannot be reached, such
+// as a missing bucket or broken credentials.
+//
+// The concrete error is an [*UnhealthyError]; use [errors.Is] to match it and [errors.Unwrap] to
+// get the failure of the probe.
+var ErrUnhealthy = errors.New("service unhealthy")
+
+// UnhealthyError is the error for a failed probe of the backend of a service.
+type UnhealthyError struct {
+	// Service is the name of the service, such as "session.GCSService".
+	Service string
+
+	// Err is the failure of the probe.
+	Err error
+}
+
+var _ error = (*UnhealthyError)(nil)
+
+// Error implements error.
+func (e *UnhealthyError) Error() string {
+	return fmt.Sprintf("%s unhealthy: %v", e.Service, e.Err)
+}
+
+// Is reports whether the target is [ErrUnhealthy].
+func (e *UnhealthyError) Is(target error) bool {
+	return target == ErrUnhealthy
+}
+
+// Unwrap returns the failure of the probe.
+func (e *UnhealthyError) Unwrap() error {
+	return e.Err
+}
+
+// FlowStage is the stage of a flow step at which a [FlowError] occurred.
+type FlowStage string
+
+const (
+	// FlowStagePreprocess is the run of the request processors before the model call.
+	FlowStagePreprocess FlowStage = "preprocess"
+
+	// FlowStageModel is the model call, with its before and after model callbacks.
+	FlowStageModel FlowStage = "model"
+
+	// FlowStagePostprocess is the run of the response processors after the model call.
+	FlowStagePostprocess FlowStage = "postprocess"
+
+	// FlowStageTool is the call of a tool requested by the model, with its tool callbacks.
+	FlowStageTool FlowStage = "tool"
+
+	// FlowStageTransfer is the transfer to another agent requested by the model.
+	FlowStageTransfer FlowStage = "transfer"
+)
+
+// ErrFlow is reported when a stage of a flow fails.
+//
+// The concrete error is a [*FlowError]; use [errors.Is] to match it, [errors.As] to get the
+// failed stage and the events produced before the failure, and [errors.Unwrap] to get the
+// failure of the stage.
+var ErrFlow = errors.New("flow failed")
+
+// FlowError is the error for a failed stage of a flow.
+type FlowError struct {
+	// Stage is the stage that failed.
+	Stage FlowStage
+
+	// Name names what failed in the stage: the type of the processor, the name of the model or
+	// of the tool, or the agent transferred to.
+	Name string
+
+	// InvocationID is the ID of the invocation running the flow.
+	InvocationID string
+
+	// Branch is the branch of the invocation running the flow.
+	Branch string
+
+	// Events are the events the flow yielded in the failed step before the failure. The events of
+	// the previous steps are in the session.
+	Events []*Event
+
+	// Err is the failure of the stage.
+	Err error
+}
+
+var _ error = (*FlowError)(nil)
+
+// Error implements error.
+func (e *FlowError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("flow %s: %v", e.Stage, e.Err)
+	}
+	return fmt.Sprintf("flow %s %s: %v", e.Stage, e.Name, e.Err)
+}
+
+// Is reports whether the target is [ErrFlow].
+func (e *FlowError) Is(target error) bool {
+	return target == ErrFlow
+}
+
+// Unwrap returns the failure of the stage.
+func (e *FlowError) Unwrap() error {
+	return e.Err
+}
@@ -5,6 +5,7 @@ package types
 
 import (
 	rand "math/rand/v2"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -45,6 +46,24 @@ type Event struct {
 	// conversation history.
 	Branch string
 
+	// Reasoning indicates that the event holds the thinking of the model, captured by the planner
+	// and kept out of the answer.
+	//
+	// A reasoning event is never the final response, and is left out of the history sent to the model.
+	Reasoning bool
+
+	// Citations are the sources supporting the text of the event, extracted from the grounding and
+	// citation metadata of the model response when enabled on the flow.
+	Citations []Citation
+
+	// Plan is the plan of the model detected by the planner in its response, set on the event the
+	// planner emits after processing the response.
+	Plan *Plan
+
+	// IntendedToolCalls are the tool calls a dry run intercepted instead of running them, set on
+	// the event ending the dry run of an agent.
+	IntendedToolCalls []ToolCallRecord
+
 	// Do not assign the ID. It will be assigned by the session.
 
 	// ID is the unique identifier of the event.
@@ -114,13 +133,35 @@ func NewEvent() *Event {
 
 // IsFinalResponse returns whether the event is the final response of the agent.
 func (e *Event) IsFinalResponse() bool {
-	if e.Actions.SkipSummarization || len(e.LongRunningToolIDs) > 0 {
+	if e.Reasoning {
+		return false
+	}
+	if (e.Actions != nil && e.Actions.SkipSummarization) || len(e.LongRunningToolIDs) > 0 {
 		return true
 	}
 
 	return len(e.GetFunctionCalls()) == 0 && len(e.GetFunctionResponses()) == 0 && !e.Partial && !e.HasTrailingCodeExecutionResult()
 }
 
+// FinalText returns the text of the event if it is a final response of an agent holding text,
+// such as the answer of an agent run to completion. The thoughts of the model are left out.
+func (e *Event) FinalText() (string, bool) {
+	if e.Author == "user" || e.LLMResponse == nil || e.Content == nil || !e.IsFinalResponse() {
+		return "", false
+	}
+
+	var text strings.Builder
+	for _, part := range e.Content.Parts {
+		if part != nil && !part.Thought {
+			text.WriteString(part.Text)
+		}
+	}
+	if text.Len() == 0 {
+		return "", false
+	}
+	return text.String(), true
+}
+
 // GetFunctionCalls returns the function calls in the event.
 func (e *Event) GetFunctionCalls() []*genai.FunctionCall {
 	var funcCalls []*genai.FunctionCall
@@ -13,6 +13,14 @@ type EventActions struct {
 	// StateDelta indicates that the event is updating the state with the given delta.
 	StateDelta map[string]any
 
+	// StateBaseVersion is the session state version the state delta was computed from, as reported
+	// by [StateVersioner.StateVersion].
+	//
+	// When set, [SessionService.AppendEvent] rejects the event with a [*StateConflictError] if a
+	// session-scoped key of the delta was modified after that version. Zero applies the delta
+	// unconditionally, the last writer winning.
+	StateBaseVersion int64
+
 	// ArtifactDelta indicates that the event is updating an artifact. key is the filename, value is the version.
 	ArtifactDelta map[string]int
 
@@ -22,6 +30,10 @@ type EventActions struct {
 	// Escalate is the agent is escalating to a higher level agent.
 	Escalate bool
 
+	// ReplacesHistory is the event summarizes the preceding events of its branch, which are
+	// left out of the contents of later model requests.
+	ReplacesHistory bool
+
 	// RequestedAuthConfigs authentication configurations requested by tool responses.
 	//
 	// This field will only be set by a tool response event indicating tool request
@@ -50,6 +62,12 @@ func (ea *EventActions) WithStateDelta(stateDelta map[string]any) *EventActions
 	return ea
 }
 
+// WithStateBaseVersion configures the stateBaseVersion to the [EventActions].
+func (ea *EventActions) WithStateBaseVersion(version int64) *EventActions {
+	ea.StateBaseVersion = version
+	return ea
+}
+
 // WithArtifactDelta configures the artifactDelta to the [EventActions].
 func (ea *EventActions) WithArtifactDelta(artifactDelta map[string]int) *EventActions {
 	ea.ArtifactDelta = artifactDelta
@@ -68,6 +86,12 @@ func (ea *EventActions) WithEscalate(escalate bool) *EventActions {
 	return ea
 }
 
+// WithReplacesHistory configures the replacesHistory to the [EventActions].
+func (ea *EventActions) WithReplacesHistory(replacesHistory bool) *EventActions {
+	ea.ReplacesHistory = replacesHistory
+	return ea
+}
+
 // WithRequestedAuthConfigs configures the requestedAuthConfigs to the [EventActions].
 func (ea *EventActions) WithRequestedAuthConfigs(requestedAuthConfigs map[string]*AuthConfig) *EventActions {
 	ea.RequestedAuthConfigs = requestedAuthConfigs
@@ -0,0 +1,85 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+// StateScopes is a state delta grouped by scope, the keys stripped of their scope prefix.
+type StateScopes struct {
+	// App holds the application scoped keys, stored as [AppPrefix]+key.
+	App map[string]any
+
+	// User holds the user scoped keys, stored as [UserPrefix]+key.
+	User map[string]any
+
+	// Session holds the session scoped keys, stored without prefix.
+	Session map[string]any
+
+	// Temp holds the invocation scoped keys, stored as [TempPrefix]+key. The keys private to a
+	// branch keep the "branch:" part of [BranchPrefix].
+	Temp map[string]any
+}
+
+// SetAppState sets the application scoped key in the state delta, stored as [AppPrefix]+key.
+func (ea *EventActions) SetAppState(key string, val any) *EventActions {
+	return ea.setState(AppPrefix+key, val)
+}
+
+// SetUserState sets the user scoped key in the state delta, stored as [UserPrefix]+key.
+func (ea *EventActions) SetUserState(key string, val any) *EventActions {
+	return ea.setState(UserPrefix+key, val)
+}
+
+// SetSessionState sets the session scoped key in the state delta, stored without prefix.
+//
+// The key must not carry a scope prefix, which would store it in that scope instead; use the
+// setter of that scope.
+func (ea *EventActions) SetSessionState(key string, val any) *EventActions {
+	return ea.setState(key, val)
+}
+
+// SetTempState sets the invocation scoped key in the state delta, stored as [TempPrefix]+key.
+//
+// The temp keys are visible to the agents of the invocation, but not persisted by the session
+// services.
+func (ea *EventActions) SetTempState(key string, val any) *EventActions {
+	return ea.setState(TempPrefix+key, val)
+}
+
+// setState sets the raw key in the state delta.
+func (ea *EventActions) setState(key string, val any) *EventActions {
+	if ea.StateDelta == nil {
+		ea.StateDelta = make(map[string]any)
+	}
+	ea.StateDelta[key] = val
+	return ea
+}
+
+// ScopedStateDelta returns the state delta grouped by scope.
+//
+// The maps of the scopes are nil if the delta has no key of that scope.
+func (ea *EventActions) ScopedStateDelta() StateScopes {
+	var scopes StateScopes
+	if ea == nil {
+		return scopes
+	}
+
+	for key, val := range ea.StateDelta {
+		name, prefix, _ := splitStateScope(key)
+		var scope *map[string]any
+		switch prefix {
+		case AppPrefix:
+			scope = &scopes.App
+		case UserPrefix:
+			scope = &scopes.User
+		case TempPrefix:
+			scope = &scopes.Temp
+		default:
+			scope = &scopes.Session
+		}
+		if *scope == nil {
+			*scope = make(map[string]any)
+		}
+		(*scope)[name] = val
+	}
+	return scopes
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestEventActions_ScopedState(t *testing.T) {
+	t.Parallel()
+
+	actions := (&types.EventActions{}).
+		SetAppState("config", "production").
+		SetUserState("theme", "dark").
+		SetSessionState("topic", "weather").
+		SetTempState("step", 3)
+	actions.StateDelta[types.BranchPrefix+"draft"] = "text"
+
+	wantDelta := map[string]any{
+		"app:config":        "production",
+		"user:theme":        "dark",
+		"topic":             "weather",
+		"temp:step":         3,
+		"temp:branch:draft": "text",
+	}
+	if diff := cmp.Diff(wantDelta, actions.StateDelta); diff != "" {
+		t.Errorf("StateDelta mismatch (-want +got):\n%s", diff)
+	}
+
+	want := types.StateScopes{
+		App:     map[string]any{"config": "production"},
+		User:    map[string]any{"theme": "dark"},
+		Session: map[string]any{"topic": "weather"},
+		Temp:    map[string]any{"step": 3, "branch:draft": "text"},
+	}
+	if diff := cmp.Diff(want, actions.ScopedStateDelta()); diff != "" {
+		t.Errorf("ScopedStateDelta() mismatch (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(types.StateScopes{User: map[string]any{"name": "ada"}}, types.NewEventActions().SetUserState("name", "ada").ScopedStateDelta()); diff != "" {
+		t.Errorf("ScopedStateDelta() of a user key mismatch (-want +got):\n%s", diff)
+	}
+	var nilActions *types.EventActions
+	if diff := cmp.Diff(types.StateScopes{}, nilActions.ScopedStateDelta()); diff != "" {
+		t.Errorf("ScopedStateDelta() of nil actions mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -5,6 +5,8 @@ package types
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"google.golang.org/genai"
@@ -17,9 +19,18 @@ type MemoryService interface {
 	// AddSessionToMemory adds the contents of a session to memory.
 	AddSessionToMemory(ctx context.Context, session Session) error
 
+	// AddSessionsToMemory adds the contents of many sessions to memory in bulk.
+	//
+	// A failure to add a session does not stop the others; it is reported in the result.
+	AddSessionsToMemory(ctx context.Context, sessions []Session, opts ...AddSessionsOption) (*AddSessionsResult, error)
+
 	// SearchMemory searches for sessions that match the query.
 	SearchMemory(ctx context.Context, appName, userID, query string) (*SearchMemoryResponse, error)
 
+	// Ping probes the backend of the memory service with a lightweight call, and reports an
+	// [*UnhealthyError] when it cannot be reached. An in-memory service always succeeds.
+	Ping(ctx context.Context) error
+
 	// Close closes the underlying memory client and releases resources.
 	Close() error
 }
@@ -36,6 +47,11 @@ type MemoryEntry struct {
 	//
 	// This string will be forwarded to LLM. Preferred format is ISO 8601 format.
 	Timestamp time.Time
+
+	// The relevance score of the memory to the search query, from 0 to 1.
+	//
+	// Higher is more relevant. Zero if the memory service does not score the results.
+	Score float64
 }
 
 // SearchMemoryResponse represents the response from a memory search.
@@ -43,3 +59,74 @@ type SearchMemoryResponse struct {
 	// Results are the memory items matching the search.
 	Memories []*MemoryEntry `json:"memories"`
 }
+
+// AddSessionsConfig holds the options of [MemoryService.AddSessionsToMemory].
+type AddSessionsConfig struct {
+	// Deduplicate skips the events whose stored form is byte-identical to one already stored in memory.
+	Deduplicate bool
+}
+
+// DefaultAddSessionsConfig returns the default [AddSessionsConfig], with deduplication enabled.
+func DefaultAddSessionsConfig() *AddSessionsConfig {
+	return &AddSessionsConfig{
+		Deduplicate: true,
+	}
+}
+
+// AddSessionsOption is a functional option for [MemoryService.AddSessionsToMemory].
+type AddSessionsOption func(*AddSessionsConfig)
+
+// WithDeduplication sets whether to skip the events already stored in memory.
+func WithDeduplication(enabled bool) AddSessionsOption {
+	return func(c *AddSessionsConfig) {
+		c.Deduplicate = enabled
+	}
+}
+
+// AddSessionResult is the outcome of adding a single session to memory.
+type AddSessionResult struct {
+	AppName   string
+	UserID    string
+	SessionID string
+
+	// Added is the number of new memory entries stored.
+	Added int
+
+	// Skipped is the number of entries skipped as duplicates.
+	Skipped int
+
+	// Err is the error adding the session, if any.
+	Err error
+}
+
+// AddSessionsResult is the outcome of [MemoryService.AddSessionsToMemory].
+type AddSessionsResult struct {
+	// Sessions holds a result for each session, in the order they were given.
+	Sessions []*AddSessionResult
+
+	// Added is the total number of new memory entries stored.
+	Added int
+
+	// Skipped is the total number of entries skipped as duplicates.
+	Skipped int
+}
+
+// Failed returns the results of the sessions that could not be added.
+func (r *AddSessionsResult) Failed() []*AddSessionResult {
+	var failed []*AddSessionResult
+	for _, res := range r.Sessions {
+		if res.Err != nil {
+			failed = append(failed, res)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of the sessions that could not be added joined together, or nil.
+func (r *AddSessionsResult) Err() error {
+	var errs []error
+	for _, res := range r.Failed() {
+		errs = append(errs, fmt.Errorf("session %s: %w", res.SessionID, res.Err))
+	}
+	return errors.Join(errs...)
+}
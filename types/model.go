@@ -30,6 +30,31 @@ type Model interface {
 	StreamGenerateContent(ctx context.Context, request *LLMRequest) iter.Seq2[*LLMResponse, error]
 }
 
+// LiveSupporter is implemented by the models reporting whether they support the live connections
+// of [Model.Connect].
+type LiveSupporter interface {
+	// SupportsLive reports whether the model supports live connections.
+	SupportsLive() bool
+}
+
+// SupportsLive reports whether the model supports live connections, as reported by its
+// [LiveSupporter] implementation. A model not implementing [LiveSupporter] is assumed to support them.
+func SupportsLive(m Model) bool {
+	if m == nil {
+		return false
+	}
+	if ls, ok := m.(LiveSupporter); ok {
+		return ls.SupportsLive()
+	}
+	return true
+}
+
+// TokenCounter is implemented by the models that can count the tokens of a request before sending it.
+type TokenCounter interface {
+	// CountTokens returns the number of input tokens of the request contents.
+	CountTokens(ctx context.Context, request *LLMRequest) (int, error)
+}
+
 // ModelConnection defines the interface for a live model connection.
 type ModelConnection interface {
 	// SendHistory sends the conversation history to the model.
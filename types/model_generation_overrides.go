@@ -0,0 +1,93 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/genai"
+)
+
+// GenerationOverrides overrides the generation config of the agents for one invocation, such as a
+// lower temperature for a precise answer, without rebuilding the agents.
+//
+// It is set by [RunConfig.GenerationOverrides] and merged over the generation config of each
+// agent of the invocation before its model calls, leaving the agent unchanged. The nil fields keep
+// the value of the agent.
+type GenerationOverrides struct {
+	// Temperature overrides the temperature, between 0 and 2.
+	Temperature *float32
+
+	// TopP overrides the nucleus sampling probability, between 0 and 1.
+	TopP *float32
+
+	// TopK overrides the number of the most probable tokens sampled, at least 1.
+	TopK *float32
+
+	// MaxOutputTokens overrides the maximum number of output tokens, at least 1.
+	MaxOutputTokens *int32
+
+	// StopSequences overrides the stop sequences; an empty non-nil slice removes those of the agent.
+	StopSequences []string
+}
+
+// Validate reports the overrides out of their range, joined.
+func (o *GenerationOverrides) Validate() error {
+	if o == nil {
+		return nil
+	}
+
+	var errs []error
+	if o.Temperature != nil && (*o.Temperature < 0 || *o.Temperature > 2) {
+		errs = append(errs, fmt.Errorf("temperature %v out of [0, 2]", *o.Temperature))
+	}
+	if o.TopP != nil && (*o.TopP < 0 || *o.TopP > 1) {
+		errs = append(errs, fmt.Errorf("top_p %v out of [0, 1]", *o.TopP))
+	}
+	if o.TopK != nil && *o.TopK < 1 {
+		errs = append(errs, fmt.Errorf("top_k %v below 1", *o.TopK))
+	}
+	if o.MaxOutputTokens != nil && *o.MaxOutputTokens < 1 {
+		errs = append(errs, fmt.Errorf("max_output_tokens %d below 1", *o.MaxOutputTokens))
+	}
+	if slices.Contains(o.StopSequences, "") {
+		errs = append(errs, errors.New("empty stop sequence"))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid generation overrides: %w", err)
+	}
+	return nil
+}
+
+// Apply returns a copy of the config with the overrides merged over it. The config is not
+// modified, and is returned as is for nil overrides.
+func (o *GenerationOverrides) Apply(config *genai.GenerateContentConfig) *genai.GenerateContentConfig {
+	if o == nil {
+		return config
+	}
+
+	merged := new(genai.GenerateContentConfig)
+	if config != nil {
+		*merged = *config
+	}
+	if o.Temperature != nil {
+		merged.Temperature = ToPtr(*o.Temperature)
+	}
+	if o.TopP != nil {
+		merged.TopP = ToPtr(*o.TopP)
+	}
+	if o.TopK != nil {
+		merged.TopK = ToPtr(*o.TopK)
+	}
+	if o.MaxOutputTokens != nil {
+		merged.MaxOutputTokens = *o.MaxOutputTokens
+	}
+	if o.StopSequences != nil {
+		merged.StopSequences = slices.Clone(o.StopSequences)
+	}
+
+	return merged
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestGenerationOverrides_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		overrides *types.GenerationOverrides
+		wantErr   bool
+	}{
+		"nil": {},
+		"valid": {
+			overrides: &types.GenerationOverrides{
+				Temperature:     types.ToPtr[float32](0),
+				TopP:            types.ToPtr[float32](1),
+				TopK:            types.ToPtr[float32](40),
+				MaxOutputTokens: types.ToPtr[int32](256),
+				StopSequences:   []string{"\n\n"},
+			},
+		},
+		"temperature": {
+			overrides: &types.GenerationOverrides{Temperature: types.ToPtr[float32](2.5)},
+			wantErr:   true,
+		},
+		"top_p": {
+			overrides: &types.GenerationOverrides{TopP: types.ToPtr[float32](-0.1)},
+			wantErr:   true,
+		},
+		"top_k": {
+			overrides: &types.GenerationOverrides{TopK: types.ToPtr[float32](0)},
+			wantErr:   true,
+		},
+		"max_output_tokens": {
+			overrides: &types.GenerationOverrides{MaxOutputTokens: types.ToPtr[int32](0)},
+			wantErr:   true,
+		},
+		"empty stop sequence": {
+			overrides: &types.GenerationOverrides{StopSequences: []string{"END", ""}},
+			wantErr:   true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.overrides.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerationOverrides_Apply(t *testing.T) {
+	t.Parallel()
+
+	config := &genai.GenerateContentConfig{
+		Temperature:     types.ToPtr[float32](0.9),
+		TopK:            types.ToPtr[float32](40),
+		MaxOutputTokens: 1024,
+		StopSequences:   []string{"END"},
+	}
+	overrides := &types.GenerationOverrides{
+		Temperature:     types.ToPtr[float32](0.2),
+		MaxOutputTokens: types.ToPtr[int32](64),
+		StopSequences:   []string{},
+	}
+
+	got := overrides.Apply(config)
+	want := &genai.GenerateContentConfig{
+		Temperature:     types.ToPtr[float32](0.2),
+		TopK:            types.ToPtr[float32](40),
+		MaxOutputTokens: 64,
+		StopSequences:   []string{},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Apply() mismatch (-want +got):\n%s", diff)
+	}
+	if *config.Temperature != 0.9 || config.MaxOutputTokens != 1024 || len(config.StopSequences) != 1 {
+		t.Errorf("Apply() modified the config: %+v", config)
+	}
+
+	var none *types.GenerationOverrides
+	if none.Apply(config) != config {
+		t.Error("Apply() of nil overrides did not return the config")
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"time"
+
+	"google.golang.org/genai"
+)
+
+// GoogleSearchGrounding grounds the answers of a Gemini model in Google Search results, searched by
+// the model server-side without any tool call.
+//
+// Unlike the google_search tool, the grounding needs no tool of the agent: the model answers
+// directly, with grounding metadata linking the parts of its answer to the web sources, which the
+// flow extracts into [Event.Citations]. A model lacking the grounding reports an
+// [*UnsupportedGroundingError].
+type GoogleSearchGrounding struct {
+	// DynamicThreshold is the threshold of the dynamic retrieval of the Gemini 1.x models, which
+	// only search when the prediction of the model is above it. Nil always searches.
+	DynamicThreshold *float32 `json:"dynamic_threshold,omitempty"`
+
+	// TimeRange restricts the search results of the Gemini 2.0+ models to the time range. Nil
+	// means no restriction.
+	TimeRange *genai.Interval `json:"time_range,omitempty"`
+}
+
+// GroundingOption configures a [GoogleSearchGrounding].
+type GroundingOption func(*GoogleSearchGrounding)
+
+// WithDynamicRetrievalThreshold sets the [GoogleSearchGrounding.DynamicThreshold].
+func WithDynamicRetrievalThreshold(threshold float32) GroundingOption {
+	return func(g *GoogleSearchGrounding) {
+		g.DynamicThreshold = &threshold
+	}
+}
+
+// WithSearchTimeRange restricts the search results to the time range, see
+// [GoogleSearchGrounding.TimeRange].
+func WithSearchTimeRange(start, end time.Time) GroundingOption {
+	return func(g *GoogleSearchGrounding) {
+		g.TimeRange = &genai.Interval{StartTime: start, EndTime: end}
+	}
+}
+
+// NewGoogleSearchGrounding returns the [GoogleSearchGrounding] configured by the options.
+//
+// It returns nil if not enabled, which disables the grounding.
+func NewGoogleSearchGrounding(enabled bool, opts ...GroundingOption) *GoogleSearchGrounding {
+	if !enabled {
+		return nil
+	}
+	g := &GoogleSearchGrounding{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// WithGoogleSearchGrounding sets whether the answers of the model are grounded in Google Search, see
+// [GoogleSearchGrounding].
+func WithGoogleSearchGrounding(enabled bool, opts ...GroundingOption) LLMRequestOption {
+	return func(r *LLMRequest) {
+		r.GoogleSearchGrounding = NewGoogleSearchGrounding(enabled, opts...)
+	}
+}
@@ -29,6 +29,44 @@ type LLMRequest struct {
 
 	// The tools map.
 	ToolMap map[string]Tool `json:"tool_map,omitempty"`
+
+	// ToolChoice controls the function calling of the model. Nil lets the model decide.
+	ToolChoice *ToolChoice `json:"tool_choice,omitempty"`
+
+	// GoogleSearchGrounding grounds the answers of the model in Google Search. Nil means no
+	// grounding.
+	GoogleSearchGrounding *GoogleSearchGrounding `json:"google_search_grounding,omitempty"`
+
+	// systemContent records the block of the system instruction parts appended with AppendSystemContent.
+	systemContent map[*genai.Part]SystemContentBlock
+}
+
+// SystemContentBlock is a kind of system-level content contributed to the system instruction of an [LLMRequest].
+type SystemContentBlock string
+
+const (
+	// SystemContentIdentity is the name and description of the agent.
+	SystemContentIdentity SystemContentBlock = "identity"
+
+	// SystemContentInstructions is the global instruction of the root agent and the instruction of the agent.
+	SystemContentInstructions SystemContentBlock = "instructions"
+
+	// SystemContentExamples is the few-shot examples.
+	SystemContentExamples SystemContentBlock = "examples"
+
+	// SystemContentMemory is the memories of the previous conversations.
+	SystemContentMemory SystemContentBlock = "memory"
+)
+
+// DefaultSystemContentOrder returns the order in which the default request processors and tools
+// contribute the system content blocks.
+func DefaultSystemContentOrder() []SystemContentBlock {
+	return []SystemContentBlock{
+		SystemContentInstructions,
+		SystemContentIdentity,
+		SystemContentExamples,
+		SystemContentMemory,
+	}
 }
 
 type LLMRequestOption func(*LLMRequest)
@@ -99,6 +137,55 @@ func (r *LLMRequest) AppendInstructions(instructions ...string) {
 	})
 }
 
+// AppendSystemContent appends instructions to the system instruction as a part of the given block,
+// so that the block can be reordered or omitted by [LLMRequest.OrderSystemContent].
+func (r *LLMRequest) AppendSystemContent(block SystemContentBlock, instructions ...string) {
+	r.AppendInstructions(instructions...)
+
+	if r.systemContent == nil {
+		r.systemContent = make(map[*genai.Part]SystemContentBlock)
+	}
+	parts := r.Config.SystemInstruction.Parts
+	r.systemContent[parts[len(parts)-1]] = block
+}
+
+// OrderSystemContent reorders the parts of the system instruction appended with
+// [LLMRequest.AppendSystemContent] by block, in the given order.
+//
+// The reordered blocks take the place of the first of them, and the parts of a block keep their
+// relative order. The blocks not in order are removed. Other parts of the system instruction are
+// left in place.
+func (r *LLMRequest) OrderSystemContent(order ...SystemContentBlock) {
+	if r.Config == nil || r.Config.SystemInstruction == nil || len(r.systemContent) == 0 {
+		return
+	}
+
+	byBlock := make(map[SystemContentBlock][]*genai.Part)
+	for _, part := range r.Config.SystemInstruction.Parts {
+		if block, ok := r.systemContent[part]; ok {
+			byBlock[block] = append(byBlock[block], part)
+		}
+	}
+
+	parts := make([]*genai.Part, 0, len(r.Config.SystemInstruction.Parts))
+	placed := false
+	for _, part := range r.Config.SystemInstruction.Parts {
+		if _, ok := r.systemContent[part]; !ok {
+			parts = append(parts, part)
+			continue
+		}
+		if placed {
+			continue
+		}
+		placed = true
+		for _, block := range order {
+			parts = append(parts, byBlock[block]...)
+			delete(byBlock, block)
+		}
+	}
+	r.Config.SystemInstruction.Parts = parts
+}
+
 // AppendTools adds tools to the request.
 func (r *LLMRequest) AppendTools(tools ...Tool) *LLMRequest {
 	if r.Config == nil {
@@ -16,6 +16,10 @@ type LLMResponse struct {
 	// GroundingMetadata is the grounding metadata of the response.
 	GroundingMetadata *genai.GroundingMetadata
 
+	// CitationMetadata is the citation metadata of the response, listing the sources recited by
+	// the model.
+	CitationMetadata *genai.CitationMetadata
+
 	// Partial indicates whether the text content is part of an unfinished text stream.
 	// Only used for streaming mode and when the content is plain text.
 	Partial bool
@@ -39,6 +43,10 @@ type LLMResponse struct {
 	// The entire map must be JSON serializable.
 	CustomMetadata map[string]any
 
+	// JSONRepaired indicates that the JSON text of the response was repaired, or regenerated by
+	// re-prompting the model, because it was not valid JSON.
+	JSONRepaired bool
+
 	FinishReason genai.FinishReason
 
 	UsageMetadata *genai.GenerateContentResponseUsageMetadata
@@ -54,12 +62,16 @@ func CreateLLMResponse(resp *genai.GenerateContentResponse) *LLMResponse {
 		return response
 	}
 
+	response.UsageMetadata = resp.UsageMetadata
+
 	switch {
 	case len(resp.Candidates) > 0:
 		candidate := resp.Candidates[0]
+		response.FinishReason = candidate.FinishReason
 		if candidate.Content != nil && len(candidate.Content.Parts) > 0 {
 			response.Content = candidate.Content
 			response.GroundingMetadata = candidate.GroundingMetadata
+			response.CitationMetadata = candidate.CitationMetadata
 		} else {
 			response.ErrorCode = string(candidate.FinishReason)
 			response.ErrorMessage = candidate.FinishMessage
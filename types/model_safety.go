@@ -0,0 +1,136 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+)
+
+// SafetyCategory is a provider-agnostic category of harmful content.
+type SafetyCategory string
+
+const (
+	// SafetyCategoryHarassment is content targeting an individual or a group with abuse.
+	SafetyCategoryHarassment SafetyCategory = "harassment"
+	// SafetyCategoryHateSpeech is content promoting hatred based on protected attributes.
+	SafetyCategoryHateSpeech SafetyCategory = "hate_speech"
+	// SafetyCategorySexuallyExplicit is content containing sexual acts or other lewd content.
+	SafetyCategorySexuallyExplicit SafetyCategory = "sexually_explicit"
+	// SafetyCategoryDangerousContent is content promoting or enabling access to harmful goods, services and activities.
+	SafetyCategoryDangerousContent SafetyCategory = "dangerous_content"
+	// SafetyCategoryCivicIntegrity is content that may be used to harm civic integrity, such as election misinformation.
+	SafetyCategoryCivicIntegrity SafetyCategory = "civic_integrity"
+)
+
+// SafetyThreshold is the level from which content of a [SafetyCategory] is blocked.
+type SafetyThreshold int
+
+const (
+	// SafetyThresholdUnspecified leaves the provider default.
+	SafetyThresholdUnspecified SafetyThreshold = iota
+	// SafetyThresholdOff turns the filter off.
+	SafetyThresholdOff
+	// SafetyThresholdBlockNone never blocks, but still reports the safety ratings where supported.
+	SafetyThresholdBlockNone
+	// SafetyThresholdBlockOnlyHigh blocks content with a high probability of harm.
+	SafetyThresholdBlockOnlyHigh
+	// SafetyThresholdBlockMediumAndAbove blocks content with a medium or high probability of harm.
+	SafetyThresholdBlockMediumAndAbove
+	// SafetyThresholdBlockLowAndAbove blocks content with a low, medium or high probability of harm.
+	SafetyThresholdBlockLowAndAbove
+)
+
+// String returns a string representation of the SafetyThreshold.
+func (t SafetyThreshold) String() string {
+	switch t {
+	case SafetyThresholdUnspecified:
+		return "unspecified"
+	case SafetyThresholdOff:
+		return "off"
+	case SafetyThresholdBlockNone:
+		return "block_none"
+	case SafetyThresholdBlockOnlyHigh:
+		return "block_only_high"
+	case SafetyThresholdBlockMediumAndAbove:
+		return "block_medium_and_above"
+	case SafetyThresholdBlockLowAndAbove:
+		return "block_low_and_above"
+	default:
+		return fmt.Sprintf("SafetyThreshold(%d)", int(t))
+	}
+}
+
+// SafetyRule sets the threshold of a single category.
+type SafetyRule struct {
+	Category  SafetyCategory
+	Threshold SafetyThreshold
+}
+
+// SafetyPolicy declares the content safety intent of an agent once, independently of the model provider.
+//
+// Each model translates it to the native mechanism of its provider and reports the rules it
+// cannot honor as [SafetyWarning].
+type SafetyPolicy struct {
+	// Rules is the list of rules, in declaration order. A later rule for the same category wins.
+	Rules []SafetyRule
+}
+
+// NewSafetyPolicy returns a new [SafetyPolicy] with the given rules.
+func NewSafetyPolicy(rules ...SafetyRule) *SafetyPolicy {
+	return &SafetyPolicy{
+		Rules: rules,
+	}
+}
+
+// WithRule adds a rule setting the threshold of the category.
+func (p *SafetyPolicy) WithRule(category SafetyCategory, threshold SafetyThreshold) *SafetyPolicy {
+	p.Rules = append(p.Rules, SafetyRule{Category: category, Threshold: threshold})
+	return p
+}
+
+// EffectiveRules returns one rule per category, in the order the categories first appear,
+// with the threshold of the last rule for that category.
+//
+// Rules with [SafetyThresholdUnspecified] are left out.
+func (p *SafetyPolicy) EffectiveRules() []SafetyRule {
+	if p == nil {
+		return nil
+	}
+
+	index := make(map[SafetyCategory]int, len(p.Rules))
+	rules := make([]SafetyRule, 0, len(p.Rules))
+	for _, rule := range p.Rules {
+		if i, ok := index[rule.Category]; ok {
+			rules[i].Threshold = rule.Threshold
+			continue
+		}
+		index[rule.Category] = len(rules)
+		rules = append(rules, rule)
+	}
+
+	effective := rules[:0]
+	for _, rule := range rules {
+		if rule.Threshold != SafetyThresholdUnspecified {
+			effective = append(effective, rule)
+		}
+	}
+	return effective
+}
+
+// SafetyWarning reports a [SafetyRule] that a model provider cannot honor as declared.
+type SafetyWarning struct {
+	// Provider is the name of the model provider, such as "gemini" or "claude".
+	Provider string
+
+	// Rule is the rule that is not honored.
+	Rule SafetyRule
+
+	// Reason is why the rule is not honored, and how it is handled instead.
+	Reason string
+}
+
+// String returns a string representation of the SafetyWarning.
+func (w SafetyWarning) String() string {
+	return fmt.Sprintf("%s: safety rule %s=%s: %s", w.Provider, w.Rule.Category, w.Rule.Threshold, w.Reason)
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ToolChoiceMode is a provider-neutral mode of function calling, translated by each [Model] to its
+// native mechanism.
+type ToolChoiceMode string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call a tool. This is the default.
+	ToolChoiceAuto ToolChoiceMode = ""
+
+	// ToolChoiceAny forces the model to call one of the tools of the request.
+	ToolChoiceAny ToolChoiceMode = "any"
+
+	// ToolChoiceTool forces the model to call the tool named by [ToolChoice.Name].
+	ToolChoiceTool ToolChoiceMode = "tool"
+
+	// ToolChoiceNone forbids the model to call any tool, while the tools stay declared.
+	ToolChoiceNone ToolChoiceMode = "none"
+)
+
+// ToolChoice controls the function calling of a model request.
+//
+// Gemini translates it to the mode and the allowed function names of its function calling config,
+// Claude to its tool_choice. A model lacking the mode reports an [*UnsupportedToolChoiceError]
+// rather than ignoring it.
+type ToolChoice struct {
+	// Mode is the mode of function calling.
+	Mode ToolChoiceMode `json:"mode,omitempty"`
+
+	// Name is the name of the tool forced by [ToolChoiceTool].
+	Name string `json:"name,omitempty"`
+}
+
+// IsAuto reports whether the choice leaves the function calling to the model, as a nil choice does.
+func (c *ToolChoice) IsAuto() bool {
+	return c == nil || c.Mode == ToolChoiceAuto
+}
+
+// String returns the mode of the choice, with the forced tool name if any.
+func (c *ToolChoice) String() string {
+	switch {
+	case c.IsAuto():
+		return "auto"
+	case c.Mode == ToolChoiceTool:
+		return "tool " + c.Name
+	default:
+		return string(c.Mode)
+	}
+}
+
+// Check reports whether the choice is applicable to the declared function names: a forced tool
+// must be declared, and [ToolChoiceAny] needs at least one declared function.
+//
+// The modes are checked by the models translating them, which report the modes they lack.
+func (c *ToolChoice) Check(declared []string) error {
+	switch {
+	case c.IsAuto():
+		return nil
+	case c.Mode == ToolChoiceAny:
+		if len(declared) == 0 {
+			return fmt.Errorf("tool choice %s: no tools declared", c)
+		}
+		return nil
+	case c.Mode == ToolChoiceTool:
+		if c.Name == "" {
+			return fmt.Errorf("tool choice %s: no tool name", c)
+		}
+		if !slices.Contains(declared, c.Name) {
+			return fmt.Errorf("tool choice %s: %w", c, &UnknownFunctionError{Name: c.Name})
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// DeclaredFunctionNames returns the names of the function declarations of the request config, in
+// declaration order.
+func (r *LLMRequest) DeclaredFunctionNames() []string {
+	if r.Config == nil {
+		return nil
+	}
+	var names []string
+	for _, tool := range r.Config.Tools {
+		for _, decl := range tool.FunctionDeclarations {
+			names = append(names, decl.Name)
+		}
+	}
+	return names
+}
+
+// WithToolChoice sets the [ToolChoice] of the request.
+func WithToolChoice(choice *ToolChoice) LLMRequestOption {
+	return func(r *LLMRequest) {
+		r.ToolChoice = choice
+	}
+}
+
+// WithForcedTool forces the model to call the named tool.
+func WithForcedTool(name string) LLMRequestOption {
+	return WithToolChoice(&ToolChoice{Mode: ToolChoiceTool, Name: name})
+}
+
+// WithForceAnyTool forces the model to call one of the tools of the request.
+func WithForceAnyTool() LLMRequestOption {
+	return WithToolChoice(&ToolChoice{Mode: ToolChoiceAny})
+}
+
+// WithToolsDisabled forbids the model to call any tool.
+func WithToolsDisabled() LLMRequestOption {
+	return WithToolChoice(&ToolChoice{Mode: ToolChoiceNone})
+}
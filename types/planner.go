@@ -5,6 +5,7 @@ package types
 
 import (
 	"context"
+	"slices"
 
 	"google.golang.org/genai"
 )
@@ -20,3 +21,55 @@ type Planner interface {
 	// ProcessPlanningResponse Processes the LLM response for planning.
 	ProcessPlanningResponse(ctx context.Context, cctx *CallbackContext, responseParts []*genai.Part) []*genai.Part
 }
+
+// Plan is the plan of the model detected by a planner in its response, such as the plan under the
+// planning tag of the Plan-ReAct planner or the numbered steps of the thinking of the model.
+type Plan struct {
+	// Steps are the steps of the plan, in order.
+	Steps []PlanStep
+
+	// Replanned indicates that the plan revises a previous plan of the invocation.
+	Replanned bool
+
+	// Text is the text the plan was parsed from.
+	Text string
+}
+
+// PlanStep is a step of a [Plan].
+type PlanStep struct {
+	// Description is the text of the step.
+	Description string
+
+	// ToolName is the name of the tool the step uses, or empty if it refers to none.
+	ToolName string
+
+	// Args are the arguments of the tool call written in the step, if any.
+	Args map[string]any
+}
+
+// ToolNames returns the names of the tools used by the steps of the plan, in the order of their
+// first use.
+func (p *Plan) ToolNames() []string {
+	if p == nil {
+		return nil
+	}
+	var names []string
+	for _, step := range p.Steps {
+		if step.ToolName != "" && !slices.Contains(names, step.ToolName) {
+			names = append(names, step.ToolName)
+		}
+	}
+	return names
+}
+
+// UnknownTools returns the names of the tools used by the steps of the plan that are not among
+// the available tools, so that a plan can be validated before it is executed.
+func (p *Plan) UnknownTools(available ...string) []string {
+	var unknown []string
+	for _, name := range p.ToolNames() {
+		if !slices.Contains(available, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
@@ -40,6 +40,15 @@ type Session interface {
 	SetLastUpdateTime(time.Time)
 }
 
+// StateVersioner is implemented by the sessions whose state is versioned by their [SessionService].
+//
+// The version grows with each appended event updating the session-scoped state. It is used as
+// [EventActions.StateBaseVersion] to detect concurrent updates of the same keys.
+type StateVersioner interface {
+	// StateVersion returns the version of the session state this session reflects.
+	StateVersion() int64
+}
+
 // EncodeContent encodes a Content object to a JSON dictionary.
 func EncodeContent(content *genai.Content) (map[string]any, error) {
 	if content == nil {
@@ -5,7 +5,13 @@ package types
 
 import (
 	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
 	"time"
+
+	"github.com/go-json-experiment/json"
 )
 
 // GetSessionConfig is the configuration of getting a session.
@@ -27,6 +33,160 @@ type ListEventsResponse struct {
 	NextPageToken string
 }
 
+// CopySessionConfig is the configuration of copying a session.
+type CopySessionConfig struct {
+	// UntilEventID copies the events up to and including the event with this ID.
+	UntilEventID string
+
+	// UntilTimestamp copies the events appended at or before this time.
+	UntilTimestamp time.Time
+}
+
+// CopySessionOption configures a [SessionService.CopySession] call.
+type CopySessionOption func(*CopySessionConfig)
+
+// WithCopyUntilEvent copies the events of the session up to and including the event with the
+// given ID, and the state as it was after that event.
+func WithCopyUntilEvent(eventID string) CopySessionOption {
+	return func(c *CopySessionConfig) {
+		c.UntilEventID = eventID
+	}
+}
+
+// WithCopyUntil copies the events of the session appended at or before t, and the state as it was
+// after them.
+func WithCopyUntil(t time.Time) CopySessionOption {
+	return func(c *CopySessionConfig) {
+		c.UntilTimestamp = t
+	}
+}
+
+// NewCopySessionConfig returns the [CopySessionConfig] of the options.
+func NewCopySessionConfig(opts ...CopySessionOption) *CopySessionConfig {
+	c := &CopySessionConfig{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Truncated reports whether the copy stops before the last event of the session.
+func (c *CopySessionConfig) Truncated() bool {
+	return c.UntilEventID != "" || !c.UntilTimestamp.IsZero()
+}
+
+// SessionFilter selects the sessions deleted by [SessionService.DeleteSessions].
+//
+// A session is selected if it matches all the criteria set. A filter without criterion is
+// rejected with [ErrEmptySessionFilter], so that all the sessions are not deleted by mistake.
+type SessionFilter struct {
+	// UpdatedBefore selects the sessions last updated before this time.
+	UpdatedBefore time.Time
+
+	// State selects the sessions whose session-scoped state holds all these values. The app and
+	// user scoped keys, shared by all the sessions, are never matched.
+	State map[string]any
+
+	// IDPrefix selects the sessions whose ID starts with this prefix.
+	IDPrefix string
+
+	// DryRun only counts the selected sessions, without deleting them.
+	DryRun bool
+}
+
+// IsEmpty reports whether the filter has no criterion.
+func (f SessionFilter) IsEmpty() bool {
+	return f.UpdatedBefore.IsZero() && len(f.State) == 0 && f.IDPrefix == ""
+}
+
+// Match reports whether the session matches the criteria of the filter.
+//
+// The state values are compared as JSON values, so that a number matches whatever its Go type,
+// as the state of the sessions stored as JSON holds float64 numbers.
+func (f SessionFilter) Match(ses Session) bool {
+	if f.IDPrefix != "" && !strings.HasPrefix(ses.ID(), f.IDPrefix) {
+		return false
+	}
+	if !f.UpdatedBefore.IsZero() && !ses.LastUpdateTime().Before(f.UpdatedBefore) {
+		return false
+	}
+	state := ses.State()
+	for key, want := range f.State {
+		got, ok := state[key]
+		if !ok || !stateValueEqual(got, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// stateValueEqual reports whether the state values are equal once encoded to JSON and decoded back.
+func stateValueEqual(a, b any) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	return reflect.DeepEqual(jsonValue(a), jsonValue(b))
+}
+
+// jsonValue returns the value decoded from its JSON encoding, with the numbers as float64, or the
+// value itself if it cannot be encoded.
+func jsonValue(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var decoded any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return v
+	}
+	return decoded
+}
+
+// SessionChangeKind is the kind of a [SessionChange].
+type SessionChangeKind int
+
+const (
+	// SessionCreated is the creation of a session, including by [SessionService.CopySession].
+	SessionCreated SessionChangeKind = iota + 1
+
+	// SessionDeleted is the deletion of a session.
+	SessionDeleted
+)
+
+// String returns a string representation of the SessionChangeKind.
+func (k SessionChangeKind) String() string {
+	switch k {
+	case SessionCreated:
+		return "created"
+	case SessionDeleted:
+		return "deleted"
+	default:
+		return fmt.Sprintf("SessionChangeKind(%d)", int(k))
+	}
+}
+
+// SessionChange is a creation or deletion of a session signaled by [SessionService.WatchSessions].
+type SessionChange struct {
+	Kind      SessionChangeKind
+	AppName   string
+	UserID    string
+	SessionID string
+}
+
+// WatchLagError is yielded by the watches of a [SessionService] to a subscriber that fell behind,
+// when the oldest changes not yet consumed were dropped to make room for the new ones.
+//
+// The watch goes on after it, with the changes following the dropped ones.
+type WatchLagError struct {
+	// Dropped is the number of changes dropped since the previous one yielded.
+	Dropped int
+}
+
+// Error implements the error interface for WatchLagError.
+func (e *WatchLagError) Error() string {
+	return fmt.Sprintf("watch lagged: %d changes dropped", e.Dropped)
+}
+
 // SessionService is an interface for managing sessions and their events.
 type SessionService interface {
 	// CreateSession creates a new session with the given parameters.
@@ -43,6 +203,18 @@ type SessionService interface {
 	// DeleteSession removes a specific session.
 	DeleteSession(ctx context.Context, appName, userID, sessionID string) error
 
+	// CopySession copies the events and the session-scoped state of a session to a new session,
+	// to fork the conversation. If dstSessionID is empty, an ID is generated.
+	//
+	// The copy shares no mutable value with the source session. The app and user scoped state is
+	// shared by the sessions and not copied.
+	CopySession(ctx context.Context, appName, userID, srcSessionID, dstSessionID string, opts ...CopySessionOption) (Session, error)
+
+	// DeleteSessions removes the sessions of a user, or of all the users of the application if
+	// userID is empty, selected by the filter, and returns their number. With
+	// [SessionFilter.DryRun], it returns the number of the selected sessions without deleting them.
+	DeleteSessions(ctx context.Context, appName, userID string, filter SessionFilter) (int, error)
+
 	// // CloseSession marks a session as closed.
 	// CloseSession(ctx context.Context, appName, userID, sessionID string) error
 
@@ -51,4 +223,22 @@ type SessionService interface {
 
 	// ListEvents retrieves events within a session.
 	ListEvents(ctx context.Context, appName, userID, sessionID string, maxEvents int, since *time.Time) ([]Event, error)
+
+	// WatchEvents yields the events appended to a session from the call of WatchEvents, until ctx
+	// is done or the iteration stops. The sequence can only be iterated once, and releases the
+	// watch when the iteration stops or ctx is done.
+	//
+	// Appending an event never waits for the watchers: a watcher falling behind has its oldest
+	// pending events dropped, and is yielded a [*WatchLagError] before the events following them.
+	WatchEvents(ctx context.Context, appName, userID, sessionID string) iter.Seq2[*Event, error]
+
+	// WatchSessions yields the creations and deletions of the sessions of a user, or of all the
+	// users of the application if userID is empty, from the call of WatchSessions, like WatchEvents.
+	//
+	// A watcher falling behind has its oldest pending changes dropped, as with WatchEvents.
+	WatchSessions(ctx context.Context, appName, userID string) iter.Seq2[*SessionChange, error]
+
+	// Ping probes the backend of the session service with a lightweight call, and reports an
+	// [*UnhealthyError] when it cannot be reached. An in-memory service always succeeds.
+	Ping(ctx context.Context) error
 }
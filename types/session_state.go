@@ -5,6 +5,7 @@ package types
 
 import (
 	"maps"
+	"strings"
 	"sync"
 )
 
@@ -31,6 +32,9 @@ type State struct {
 
 	// delta is the pending change to the current value that hasn't been committed
 	delta map[string]any
+
+	// branch holds the [BranchPrefix] keys, which are neither stored in value nor in delta
+	branch *branchState
 }
 
 // NewState creates a new State with the given value and delta maps.
@@ -51,6 +55,10 @@ func NewState(value, delta map[string]any) *State {
 // Get returns the value for the given key, prioritizing delta values
 // over the base values.
 func (s *State) Get(key string) (any, bool) {
+	if name, ok := s.branchKey(key); ok {
+		return s.branch.get(name)
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -67,6 +75,13 @@ func (s *State) Get(key string) (any, bool) {
 // GetWithDefault returns the value for the given key, or the default value if
 // the key doesn't exist.
 func (s *State) GetWithDefault(key string, defaultVal any) any {
+	if name, ok := s.branchKey(key); ok {
+		if val, ok := s.branch.get(name); ok {
+			return val
+		}
+		return defaultVal
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -86,6 +101,11 @@ func (s *State) GetWithDefault(key string, defaultVal any) any {
 // Set sets the value for the given key, updating both value and delta.
 // TODO: Consider updating only delta, with value updated at commit time.
 func (s *State) Set(key string, val any) {
+	if name, ok := s.branchKey(key); ok {
+		s.branch.set(name, val)
+		return
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -95,6 +115,11 @@ func (s *State) Set(key string, val any) {
 
 // Has checks if the state contains the given key.
 func (s *State) Has(key string) bool {
+	if name, ok := s.branchKey(key); ok {
+		_, ok := s.branch.get(name)
+		return ok
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -118,6 +143,10 @@ func (s *State) Update(update map[string]any) {
 	defer s.mu.Unlock()
 
 	for k, v := range update {
+		if name, ok := s.branchKey(k); ok {
+			s.branch.set(name, v)
+			continue
+		}
 		s.value[k] = v
 		s.delta[k] = v
 	}
@@ -170,6 +199,14 @@ func (s *State) ApplyDelta() {
 	s.delta = make(map[string]any)
 }
 
+// branchKey returns the name of the [BranchPrefix] key, if the state holds the branch-scoped keys.
+func (s *State) branchKey(key string) (string, bool) {
+	if s.branch == nil {
+		return "", false
+	}
+	return strings.CutPrefix(key, BranchPrefix)
+}
+
 // GetApp retrieves a value with the app prefix.
 func (s *State) GetApp(key string) (any, bool) {
 	return s.Get(AppPrefix + key)
@@ -0,0 +1,161 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+)
+
+// Tokenizer counts the tokens of a text locally, without a call to the model.
+//
+// It is the offline counterpart of [TokenCounter], used where the exact count of the provider is
+// unavailable or too slow, such as for the token budgets. A real tokenizer of the model family,
+// such as a tiktoken or SentencePiece implementation, can be plugged in for an exact count; see
+// [ApproximateTokenizer] for the default.
+type Tokenizer interface {
+	// CountTokens returns the number of tokens of the text.
+	CountTokens(text string) int
+}
+
+// TokenCodec is implemented by the tokenizers that can also convert between text and tokens.
+type TokenCodec interface {
+	Tokenizer
+
+	// Encode returns the tokens of the text.
+	Encode(text string) []int
+
+	// Decode returns the text of the tokens.
+	Decode(tokens []int) string
+}
+
+// DefaultCharsPerToken is the number of characters per token of an [ApproximateTokenizer] for the
+// models of an unknown family.
+const DefaultCharsPerToken = 4
+
+// ApproximateTokenizer estimates the tokens of a text from its number of characters.
+type ApproximateTokenizer struct {
+	// CharsPerToken is the average number of characters per token. Zero or less means
+	// [DefaultCharsPerToken].
+	CharsPerToken float64
+}
+
+var _ Tokenizer = (*ApproximateTokenizer)(nil)
+
+// charsPerToken is the average number of characters per token of the model families, by model
+// name prefix.
+var charsPerToken = []struct {
+	prefix string
+	chars  float64
+}{
+	{"gemini", 4},
+	{"gemma", 4},
+	{"gpt", 4},
+	{"o1", 4},
+	{"o3", 4},
+	{"o4", 4},
+	{"claude", 3.5},
+}
+
+// NewApproximateTokenizer returns an [ApproximateTokenizer] with the average number of characters
+// per token of the family of the model, or [DefaultCharsPerToken] for an unknown family.
+//
+// The model is a model name such as "gemini-2.0-flash", optionally prefixed with its resource path.
+func NewApproximateTokenizer(model string) *ApproximateTokenizer {
+	model = strings.ToLower(model[strings.LastIndexByte(model, '/')+1:])
+	for _, family := range charsPerToken {
+		if strings.HasPrefix(model, family.prefix) {
+			return &ApproximateTokenizer{CharsPerToken: family.chars}
+		}
+	}
+	return &ApproximateTokenizer{CharsPerToken: DefaultCharsPerToken}
+}
+
+// CountTokens implements [Tokenizer].
+//
+// It divides the number of characters of the text by the characters per token, rounding up.
+func (t *ApproximateTokenizer) CountTokens(text string) int {
+	chars := t.CharsPerToken
+	if chars <= 0 {
+		chars = DefaultCharsPerToken
+	}
+	return int(math.Ceil(float64(utf8.RuneCountInString(text)) / chars))
+}
+
+// inlineDataBytesPerToken is the number of bytes per token of the inline data, which cannot be
+// tokenized as text.
+const inlineDataBytesPerToken = 4
+
+// CountRequestTokens estimates the input tokens of the request with the tokenizer, or with the
+// [ApproximateTokenizer] of the model of the request if tokenizer is nil.
+//
+// The text of the contents and of the system instruction is counted along with the names and the
+// arguments of the function calls and responses. The inline data is estimated from its size.
+func CountRequestTokens(tokenizer Tokenizer, req *LLMRequest) int {
+	if req == nil {
+		return 0
+	}
+	if tokenizer == nil {
+		tokenizer = NewApproximateTokenizer(req.Model)
+	}
+
+	var (
+		text       strings.Builder
+		inlineData int
+	)
+	addContent := func(content *genai.Content) {
+		if content == nil {
+			return
+		}
+		for _, part := range content.Parts {
+			if part == nil {
+				continue
+			}
+			text.WriteString(part.Text)
+			if part.InlineData != nil {
+				inlineData += len(part.InlineData.Data)
+			}
+			if part.FunctionCall != nil {
+				text.WriteString(part.FunctionCall.Name)
+				writeValueText(&text, part.FunctionCall.Args)
+			}
+			if part.FunctionResponse != nil {
+				text.WriteString(part.FunctionResponse.Name)
+				writeValueText(&text, part.FunctionResponse.Response)
+			}
+		}
+	}
+	for _, content := range req.Contents {
+		addContent(content)
+	}
+	if req.Config != nil {
+		addContent(req.Config.SystemInstruction)
+	}
+
+	return tokenizer.CountTokens(text.String()) + (inlineData+inlineDataBytesPerToken-1)/inlineDataBytesPerToken
+}
+
+// writeValueText writes the keys and the values of a function argument or result to the builder.
+func writeValueText(b *strings.Builder, v any) {
+	switch v := v.(type) {
+	case nil:
+	case string:
+		b.WriteString(v)
+	case map[string]any:
+		for k, e := range v {
+			b.WriteString(k)
+			writeValueText(b, e)
+		}
+	case []any:
+		for _, e := range v {
+			writeValueText(b, e)
+		}
+	default:
+		fmt.Fprint(b, v)
+	}
+}
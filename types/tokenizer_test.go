@@ -0,0 +1,118 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+// wordTokenizer counts a token per word.
+type wordTokenizer struct{}
+
+func (wordTokenizer) CountTokens(text string) int {
+	return len(strings.Fields(text))
+}
+
+func TestApproximateTokenizer(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		model string
+		text  string
+		want  int
+	}{
+		"gemini": {
+			model: "gemini-2.0-flash",
+			text:  "123456789",
+			want:  3,
+		},
+		"resource path": {
+			model: "projects/p/locations/l/publishers/google/models/gemini-2.0-flash",
+			text:  "12345678",
+			want:  2,
+		},
+		"claude": {
+			model: "claude-3-5-sonnet",
+			text:  "1234567",
+			want:  2,
+		},
+		"unknown family": {
+			model: "custom",
+			text:  "12345",
+			want:  2,
+		},
+		"counts characters": {
+			model: "gemini-2.0-flash",
+			text:  "日本語の文章",
+			want:  2,
+		},
+		"empty": {
+			model: "gemini-2.0-flash",
+			want:  0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := types.NewApproximateTokenizer(tt.model).CountTokens(tt.text); got != tt.want {
+				t.Errorf("CountTokens(%q) = %d, want %d", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	if got := (&types.ApproximateTokenizer{}).CountTokens("12345"); got != 2 {
+		t.Errorf("zero ApproximateTokenizer CountTokens() = %d, want 2", got)
+	}
+}
+
+func TestCountRequestTokens(t *testing.T) {
+	t.Parallel()
+
+	req := &types.LLMRequest{
+		Model: "gemini-2.0-flash",
+		Contents: []*genai.Content{
+			genai.NewContentFromText("hello big world", genai.RoleUser),
+			genai.NewContentFromFunctionCall("lookup", map[string]any{"query": "weather today", "days": 3}, genai.RoleModel),
+			genai.NewContentFromBytes(make([]byte, 10), "image/png", genai.RoleUser),
+		},
+		Config: &genai.GenerateContentConfig{
+			SystemInstruction: genai.NewContentFromText("be brief", genai.RoleUser),
+		},
+	}
+
+	tests := map[string]struct {
+		tokenizer types.Tokenizer
+		want      int
+	}{
+		"approximate": {
+			// "hello big world" + "lookup" + "query" + "weather today" + "days" + "3" + "be brief"
+			// are 52 characters, and the 10 bytes of inline data 3 tokens.
+			want: 13 + 3,
+		},
+		"custom tokenizer": {
+			tokenizer: wordTokenizer{},
+			// The texts are joined without separator, so that the words at their boundaries merge.
+			want: 5 + 3,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := types.CountRequestTokens(tt.tokenizer, req); got != tt.want {
+				t.Errorf("CountRequestTokens() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	if got := types.CountRequestTokens(nil, nil); got != 0 {
+		t.Errorf("CountRequestTokens(nil) = %d, want 0", got)
+	}
+}
@@ -5,6 +5,7 @@ package types
 
 import (
 	"context"
+	"slices"
 
 	"google.golang.org/genai"
 )
@@ -30,3 +31,35 @@ type Tool interface {
 	// ProcessLLMRequest processes the outgoing LLM request for this tool.
 	ProcessLLMRequest(ctx context.Context, toolCtx *ToolContext, request *LLMRequest) error
 }
+
+// CheckToolNames reports the names shared by several of the tools as a [*DuplicateToolNameError],
+// or returns nil if the names are unique.
+func CheckToolNames(tools []Tool) error {
+	seen := make(map[string]int, len(tools))
+	var dups []string
+	for _, tool := range tools {
+		if tool == nil {
+			continue
+		}
+		name := tool.Name()
+		seen[name]++
+		if seen[name] == 2 {
+			dups = append(dups, name)
+		}
+	}
+	if len(dups) == 0 {
+		return nil
+	}
+	slices.Sort(dups)
+	return &DuplicateToolNameError{Names: dups}
+}
+
+// JobCanceler is implemented by the long-running tools whose background jobs can be cancelled.
+//
+// The jobs registered to an [InvocationContext] with [InvocationContext.RegisterJob] are cancelled
+// with [InvocationContext.CancelJobs].
+type JobCanceler interface {
+	// Cancel cancels the job and waits for it to return.
+	// Cancelling an unknown or finished job is a no-op.
+	Cancel(jobID string) error
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+
+	"github.com/go-json-experiment/json"
+)
+
+// HashArgs returns a stable hash of the arguments of a function call, hashing the maps in the
+// order of their keys at any depth.
+//
+// Numbers are hashed by value, so that an int and a float64 holding the same integer, as decoded
+// from the JSON arguments of the model, hash identically. A nil map has the hash of an empty map.
+func HashArgs(args map[string]any) (string, error) {
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	h := sha256.New()
+	if err := writeHashValue(h, args); err != nil {
+		return "", fmt.Errorf("hash args: %w", err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashValue writes the deterministic JSON encoding of the value to the hash.
+func writeHashValue(h hash.Hash, v any) error {
+	b, err := json.Marshal(v, json.DefaultOptionsV2(), json.Deterministic(true))
+	if err != nil {
+		return err
+	}
+	writeHashField(h, b)
+	return nil
+}
+
+// writeHashField writes the length prefixed bytes to the hash, so that adjacent fields cannot collide.
+func writeHashField(h hash.Hash, b []byte) {
+	writeHashLen(h, len(b))
+	h.Write(b)
+}
+
+// writeHashLen writes the length to the hash.
+func writeHashLen(h hash.Hash, n int) {
+	var buf [binary.MaxVarintLen64]byte
+	h.Write(buf[:binary.PutUvarint(buf[:], uint64(n))])
+}
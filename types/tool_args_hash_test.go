@@ -0,0 +1,39 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/go-a2a/adk-go/types"
+)
+
+func TestHashArgs(t *testing.T) {
+	t.Parallel()
+
+	hash := func(args map[string]any) string {
+		t.Helper()
+
+		got, err := types.HashArgs(args)
+		if err != nil {
+			t.Fatalf("HashArgs(%v) error = %v", args, err)
+		}
+		return got
+	}
+
+	a := map[string]any{"city": "Kyoto", "days": 3, "tags": []any{"a", "b"}, "opts": map[string]any{"x": 1, "y": true}}
+	b := map[string]any{"opts": map[string]any{"y": true, "x": 1.0}, "tags": []any{"a", "b"}, "days": 3.0, "city": "Kyoto"}
+	if hash(a) != hash(b) {
+		t.Error("HashArgs() differs for equivalent args")
+	}
+	if hash(a) == hash(map[string]any{"city": "Kyoto", "days": 4, "tags": []any{"a", "b"}, "opts": map[string]any{"x": 1, "y": true}}) {
+		t.Error("HashArgs() equal for different args")
+	}
+	if hash(a) == hash(map[string]any{"city": "Kyoto", "days": 3, "tags": []any{"b", "a"}, "opts": map[string]any{"x": 1, "y": true}}) {
+		t.Error("HashArgs() equal for reordered list")
+	}
+	if hash(nil) != hash(map[string]any{}) {
+		t.Error("HashArgs(nil) differs from the empty map")
+	}
+}
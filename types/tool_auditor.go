@@ -0,0 +1,70 @@
+// Copyright 2025 The Go A2A Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"time"
+)
+
+// ToolCallStatus is the outcome of a tool call recorded by a [ToolAuditor].
+type ToolCallStatus string
+
+const (
+	// ToolCallStatusOK is a tool call that returned a result.
+	ToolCallStatusOK ToolCallStatus = "ok"
+
+	// ToolCallStatusError is a tool call that returned an error.
+	ToolCallStatusError ToolCallStatus = "error"
+
+	// ToolCallStatusDryRun is a tool call intercepted by a dry run, the tool not being run.
+	ToolCallStatusDryRun ToolCallStatus = "dry_run"
+)
+
+// ToolCallRecord is the audit record of a single call of [Tool.Run].
+type ToolCallRecord struct {
+	// Timestamp is the time the tool call started.
+	Timestamp time.Time
+
+	// ToolName is the name of the called tool.
+	ToolName string
+
+	// FunctionCallID is the ID of the function call of the model.
+	FunctionCallID string
+
+	// Args is the arguments of the call, with the redacted fields replaced.
+	Args map[string]any
+
+	// Status is the outcome of the call.
+	Status ToolCallStatus
+
+	// Error is the error message of a failed call.
+	Error string
+
+	// Duration is the time the tool took to run.
+	Duration time.Duration
+
+	// AppName is the name of the application of the session.
+	AppName string
+
+	// UserID is the ID of the user of the session.
+	UserID string
+
+	// SessionID is the ID of the session.
+	SessionID string
+
+	// InvocationID is the ID of the invocation.
+	InvocationID string
+
+	// AgentName is the name of the agent calling the tool.
+	AgentName string
+}
+
+// ToolAuditor records every tool call of the function-calling path, regardless of the tool.
+//
+// RecordToolCall is called concurrently for the parallel function calls of a model turn, so that
+// implementations must be safe for concurrent use.
+type ToolAuditor interface {
+	// RecordToolCall records the call of a tool.
+	RecordToolCall(record ToolCallRecord)
+}
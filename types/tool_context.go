@@ -86,10 +86,12 @@ func (tc *ToolContext) ListArtifacts(ctx context.Context) ([]string, error) {
 }
 
 // SearchMemory searches the memory of the current user.
+//
+// It returns [ErrNoMemoryService] if the invocation has no memory service.
 func (tc *ToolContext) SearchMemory(ctx context.Context, query string) (*SearchMemoryResponse, error) {
 	memorySvc := tc.invocationContext.MemoryService
 	if memorySvc == nil {
-		return nil, errors.New("memory service is not available")
+		return nil, ErrNoMemoryService
 	}
 
 	return memorySvc.SearchMemory(ctx, tc.InvocationContext().AppName(), tc.InvocationContext().UserID(), query)